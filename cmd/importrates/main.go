@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/infra/config"
+	"github.com/stra1g/saver-api/internal/infra/database"
+	"github.com/stra1g/saver-api/internal/infra/database/repositories"
+	"github.com/stra1g/saver-api/internal/infra/exchangerates"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// importrates loads ECB reference rates into the exchange_rates table, either
+// from a downloaded eurofxref CSV/XML file or from the configured provider.
+//
+//	go run ./cmd/importrates -file eurofxref-hist.zip.csv
+//	go run ./cmd/importrates -sync
+func main() {
+	file := flag.String("file", "", "path to an ECB eurofxref .csv or .xml file")
+	sync := flag.Bool("sync", false, "fetch today's rates from EXCHANGE_RATE_PROVIDER")
+	flag.Parse()
+
+	_ = godotenv.Load()
+
+	log := logger.Initialize(os.Stdout, os.Getenv("DEBUG") == "true")
+
+	var rateService services.ExchangeRateService
+	app := fx.New(
+		config.Module,
+		database.Module,
+		repositories.Module,
+		exchangerates.Module,
+		fx.Provide(
+			func() logger.Logger { return log },
+			services.NewExchangeRateService,
+		),
+		fx.Populate(&rateService),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		log.Fatal("Failed to initialize importer", map[string]interface{}{"error": err.Error()})
+	}
+
+	var (
+		count int
+		err   error
+	)
+	switch {
+	case *sync:
+		count, err = rateService.SyncRates(time.Now())
+	case *file != "":
+		var rates []*entities.ExchangeRate
+		if rates, err = readRates(*file); err == nil {
+			count, err = rateService.ImportRates(rates)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal("Failed to import exchange rates", map[string]interface{}{"error": err.Error()})
+	}
+
+	log.Info("Exchange rates imported", map[string]interface{}{"count": count})
+}
+
+func readRates(path string) ([]*entities.ExchangeRate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return exchangerates.ParseECBCSV(f)
+	case ".xml":
+		return exchangerates.ParseECBXML(f)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q, expected .csv or .xml", filepath.Ext(path))
+	}
+}
@@ -15,8 +15,11 @@ import (
 	"github.com/stra1g/saver-api/internal/infra/config"
 	"github.com/stra1g/saver-api/internal/infra/database"
 	"github.com/stra1g/saver-api/internal/infra/database/repositories"
+	"github.com/stra1g/saver-api/internal/infra/exchangerates"
+	"github.com/stra1g/saver-api/internal/infra/filestorage"
 	"github.com/stra1g/saver-api/internal/infra/http/handlers"
 	"github.com/stra1g/saver-api/internal/infra/http/routes"
+	"github.com/stra1g/saver-api/internal/infra/jobs"
 	"github.com/stra1g/saver-api/pkg/logger"
 	"go.uber.org/fx"
 )
@@ -78,10 +81,13 @@ func main() {
 		apperror.Module,
 		database.Module,
 		repositories.Module,
+		exchangerates.Module,
+		filestorage.Module,
 		services.Module,
 		middlewares.Module,
 		handlers.Module,
 		routes.Module,
+		jobs.Module,
 		fx.Provide(
 			ProvideLogger,
 			Server,
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/infra/config"
+	"github.com/stra1g/saver-api/internal/infra/database"
+	"github.com/stra1g/saver-api/internal/infra/database/repositories"
+	"github.com/stra1g/saver-api/internal/infra/exchangerates"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// verifybalances recomputes the running wallet totals and the balance
+// snapshots from the raw transactions and reports every figure that drifted.
+// It exits with status 1 when any did.
+//
+//	go run ./cmd/verifybalances
+func main() {
+	_ = godotenv.Load()
+
+	log := logger.Initialize(os.Stdout, os.Getenv("DEBUG") == "true")
+
+	var balanceService services.BalanceService
+	app := fx.New(
+		config.Module,
+		database.Module,
+		repositories.Module,
+		exchangerates.Module,
+		fx.Provide(
+			func() logger.Logger { return log },
+			services.NewExchangeRateService,
+			services.NewBalanceService,
+		),
+		fx.Populate(&balanceService),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		log.Fatal("Failed to initialize verifier", map[string]interface{}{"error": err.Error()})
+	}
+
+	drifts, err := balanceService.VerifyBalances()
+	if err != nil {
+		log.Fatal("Failed to verify balances", map[string]interface{}{"error": err.Error()})
+	}
+
+	for _, drift := range drifts.Totals {
+		log.Warn("Running total drifted", map[string]interface{}{
+			"wallet_id":          drift.WalletID,
+			"status":             drift.Stored.Status,
+			"currency":           drift.Stored.Inflow.Currency().Code,
+			"stored_inflow":      drift.Stored.Inflow.Decimal(),
+			"stored_outflow":     drift.Stored.Outflow.Decimal(),
+			"recomputed_inflow":  drift.Recomputed.Inflow.Decimal(),
+			"recomputed_outflow": drift.Recomputed.Outflow.Decimal(),
+		})
+	}
+	for _, drift := range drifts.Snapshots {
+		log.Warn("Balance snapshot drifted", map[string]interface{}{
+			"wallet_id":  drift.Stored.WalletID,
+			"as_of":      drift.Stored.AsOf.Format("2006-01-02"),
+			"currency":   drift.Stored.Balance.Currency().Code,
+			"stored":     drift.Stored.Balance.Decimal(),
+			"recomputed": drift.Recomputed.Balance.Decimal(),
+		})
+	}
+
+	if drifts.Count() > 0 {
+		log.Warn("Balances drifted from their transactions", map[string]interface{}{"count": drifts.Count()})
+		os.Exit(1)
+	}
+
+	log.Info("Balances match their transactions", map[string]interface{}{})
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+type ActivityService interface {
+	// ListActivities returns a page of the wallet feed as actorID sees it,
+	// the cursor of the next page, nil on the last one, and how many items
+	// of the whole feed they have not read.
+	ListActivities(walletID, actorID string, filter repositories.ActivityFilter, page query.Page) ([]*entities.Activity, *query.Cursor, int, error)
+	// MarkActivitiesRead marks the items with the given IDs read by actorID,
+	// or the whole feed when ids is empty.
+	MarkActivitiesRead(walletID, actorID string, ids []string) error
+}
+
+type activityService struct {
+	activityRepo repositories.ActivityRepository
+	walletRepo   repositories.WalletRepository
+	logger       logger.Logger
+}
+
+func (s *activityService) ListActivities(
+	walletID string,
+	actorID string,
+	filter repositories.ActivityFilter,
+	page query.Page,
+) ([]*entities.Activity, *query.Cursor, int, error) {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	activities, next, err := s.activityRepo.FindActivitiesByWalletID(walletID, member.UserID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list activities", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, nil, 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	unread, err := s.activityRepo.CountUnreadActivities(walletID, member.UserID)
+	if err != nil {
+		s.logger.Error(err, "Failed to count unread activities", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, nil, 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return activities, next, unread, nil
+}
+
+func (s *activityService) MarkActivitiesRead(walletID, actorID string, ids []string) error {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.activityRepo.MarkActivitiesRead(walletID, member.UserID, entities.UniqueIDs(ids), time.Now()); err != nil {
+		s.logger.Error(err, "Failed to mark activities read", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// record adds events that already happened to their wallet feeds. Like
+// notify, it logs a failure rather than returning it; nil activities, such
+// as edits that changed nothing shown, are skipped.
+func record(activityRepo repositories.ActivityRepository, log logger.Logger, activities ...*entities.Activity) {
+	recorded := make([]*entities.Activity, 0, len(activities))
+	for _, activity := range activities {
+		if activity != nil {
+			recorded = append(recorded, activity)
+		}
+	}
+	if len(recorded) == 0 {
+		return
+	}
+
+	if err := activityRepo.CreateActivities(recorded); err != nil {
+		log.Error(err, "Failed to record activities", map[string]interface{}{
+			"count": len(recorded),
+		})
+	}
+}
+
+// newActivity builds an activity for record, logging rather than returning
+// an invalid one.
+func newActivity(log logger.Logger, build func() (*entities.Activity, error)) *entities.Activity {
+	activity, err := build()
+	if err != nil {
+		log.Error(err, "Invalid activity", map[string]interface{}{})
+		return nil
+	}
+	return activity
+}
+
+func NewActivityService(
+	activityRepo repositories.ActivityRepository,
+	walletRepo repositories.WalletRepository,
+	logger logger.Logger,
+) ActivityService {
+	return &activityService{
+		activityRepo: activityRepo,
+		walletRepo:   walletRepo,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockActivityRepository) CreateActivities(activities []*entities.Activity) error {
+	args := m.Called(activities)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) FindActivitiesByWalletID(
+	walletID string,
+	userID string,
+	filter repositories.ActivityFilter,
+	page query.Page,
+) ([]*entities.Activity, *query.Cursor, error) {
+	args := m.Called(walletID, userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.Activity), next, args.Error(2)
+}
+
+func (m *MockActivityRepository) CountUnreadActivities(walletID, userID string) (int, error) {
+	args := m.Called(walletID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockActivityRepository) MarkActivitiesRead(walletID, userID string, ids []string, readAt time.Time) error {
+	args := m.Called(walletID, userID, ids, readAt)
+	return args.Error(0)
+}
+
+// newActivityRepository returns an activity repository that records
+// anything.
+func newActivityRepository() *MockActivityRepository {
+	repo := new(MockActivityRepository)
+	repo.On("CreateActivities", mock.Anything).Return(nil).Maybe()
+	return repo
+}
+
+func TestActivityService_ListActivities(t *testing.T) {
+	page, err := repositories.ActivitySorts.NewPage(repositories.DefaultActivitySort, nil, 20)
+	require.NoError(t, err)
+
+	t.Run("lists the feed with the unread count", func(t *testing.T) {
+		activity, err := entities.NewActivity("wallet-id", "owner-id", entities.ActivityMemberJoined, "member-id", nil)
+		require.NoError(t, err)
+		repo := new(MockActivityRepository)
+		repo.On("FindActivitiesByWalletID", "wallet-id", "member-id", repositories.ActivityFilter{}, page).
+			Return([]*entities.Activity{activity}, nil, nil)
+		repo.On("CountUnreadActivities", "wallet-id", "member-id").Return(1, nil)
+
+		service := services.NewActivityService(repo, newTestWalletRepository(), mocks.NewMockLogger())
+		activities, next, unread, err := service.ListActivities("wallet-id", "member-id", repositories.ActivityFilter{}, page)
+
+		require.NoError(t, err)
+		assert.Len(t, activities, 1)
+		assert.Nil(t, next)
+		assert.Equal(t, 1, unread)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		repo := new(MockActivityRepository)
+
+		service := services.NewActivityService(repo, newTestWalletRepository(), mocks.NewMockLogger())
+		_, _, _, err := service.ListActivities("wallet-id", "stranger-id", repositories.ActivityFilter{}, page)
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+		repo.AssertNotCalled(t, "FindActivitiesByWalletID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestActivityService_MarkActivitiesRead(t *testing.T) {
+	repo := new(MockActivityRepository)
+	repo.On("MarkActivitiesRead", "wallet-id", "member-id", []string{"first-id"}, mock.AnythingOfType("time.Time")).Return(nil)
+
+	service := services.NewActivityService(repo, newTestWalletRepository(), mocks.NewMockLogger())
+	err := service.MarkActivitiesRead("wallet-id", "member-id", []string{"first-id", "first-id"})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestTransactionService_RecordsActivity(t *testing.T) {
+	t.Run("edit records the changed fields", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+		repo.On("UpdateTransaction", mock.Anything).Return(newTestTransaction(t), nil)
+		activityRepo := new(MockActivityRepository)
+		activityRepo.On("CreateActivities", mock.MatchedBy(func(activities []*entities.Activity) bool {
+			return len(activities) == 1 && activities[0].Type == entities.ActivityTransactionEdited &&
+				activities[0].ActorID == "user-id" && activities[0].SubjectID == "transaction-id" &&
+				activities[0].Changes["amount"] == entities.ActivityChange{Before: "BRL 42.90", After: "BRL 19.99"}
+		})).Return(nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), activityRepo, new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.UpdateTransaction("wallet-id", "user-id", "transaction-id", newTransactionInput(t, "19.99"))
+
+		require.NoError(t, err)
+		activityRepo.AssertExpectations(t)
+	})
+
+	t.Run("a feed failure does not fail the change", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+		repo.On("DeleteTransaction", mock.Anything).Return(nil)
+		activityRepo := new(MockActivityRepository)
+		activityRepo.On("CreateActivities", mock.MatchedBy(func(activities []*entities.Activity) bool {
+			return activities[0].Type == entities.ActivityTransactionDeleted
+		})).Return(errors.New("database error"))
+		logger := mocks.NewMockLogger()
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), activityRepo, new(MockRecurringTransactionRepository), newRateService(), logger)
+		err := service.DeleteTransaction("wallet-id", "user-id", "transaction-id")
+
+		assert.NoError(t, err)
+		activityRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type ApprovalService interface {
+	// ListApprovals returns the open requests of the wallet, oldest first.
+	ListApprovals(walletID, actorID string) ([]*entities.Approval, error)
+	// ApproveExpense lets an owner other than the requester, or the parent
+	// of a dependent requester, approve a held expense, which then goes
+	// through.
+	ApproveExpense(walletID, actorID, approvalID, comment string) (*entities.Approval, error)
+	// RejectExpense lets the same reviewers reject a held expense with a
+	// comment; the expense is deleted.
+	RejectExpense(walletID, actorID, approvalID, comment string) (*entities.Approval, error)
+	// ExpireOverdue expires the requests overdue by now, deleting their
+	// expenses, and returns how many expired.
+	ExpireOverdue(now time.Time) (int, error)
+}
+
+type approvalService struct {
+	approvalRepo    repositories.ApprovalRepository
+	transactionRepo repositories.TransactionRepository
+	walletRepo      repositories.WalletRepository
+	logger          logger.Logger
+}
+
+var (
+	ErrApprovalNotFound        = apperror.New(apperror.ErrorTypeNotFound, "Approval request not found")
+	ErrApprovalReviewForbidden = apperror.New(apperror.ErrorTypeForbidden, "Only wallet owners, or the parent of a dependent, can review expenses")
+	ErrApprovalClosed          = apperror.New(apperror.ErrorTypeUnprocessable, "Approval request was already closed")
+)
+
+func (s *approvalService) ListApprovals(walletID, actorID string) ([]*entities.Approval, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.approvalRepo.FindPendingApprovalsByWalletID(walletID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list approvals", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return approvals, nil
+}
+
+func (s *approvalService) ApproveExpense(walletID, actorID, approvalID, comment string) (*entities.Approval, error) {
+	return s.review(walletID, actorID, approvalID, func(approval *entities.Approval, now time.Time) error {
+		return approval.Approve(actorID, comment, now)
+	})
+}
+
+func (s *approvalService) RejectExpense(walletID, actorID, approvalID, comment string) (*entities.Approval, error) {
+	return s.review(walletID, actorID, approvalID, func(approval *entities.Approval, now time.Time) error {
+		return approval.Reject(actorID, comment, now)
+	})
+}
+
+// review applies the decision of a reviewer to a request of their wallet
+// and releases the expense. A request that expired before the decision is
+// closed as expired and reported as such.
+func (s *approvalService) review(
+	walletID string,
+	actorID string,
+	approvalID string,
+	decide func(*entities.Approval, time.Time) error,
+) (*entities.Approval, error) {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := s.approvalRepo.FindApprovalByID(approvalID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find approval", map[string]interface{}{
+			"approval_id": approvalID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if approval == nil || approval.WalletID != walletID {
+		return nil, ErrApprovalNotFound
+	}
+	if !approval.CanReview(member) {
+		return nil, ErrApprovalReviewForbidden
+	}
+
+	if err := decide(approval, time.Now()); err != nil {
+		if !errors.Is(err, entities.ErrApprovalExpired) {
+			return nil, reviewError(approval, err)
+		}
+		if releaseErr := s.release(approval); releaseErr != nil {
+			return nil, releaseErr
+		}
+		return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+
+	if err := s.release(approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+func (s *approvalService) ExpireOverdue(now time.Time) (int, error) {
+	overdue, err := s.approvalRepo.FindOverdueApprovals(now)
+	if err != nil {
+		s.logger.Error(err, "Failed to find overdue approvals", map[string]interface{}{})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	expired := 0
+	for _, approval := range overdue {
+		if err := approval.Expire(now); err != nil {
+			s.logger.Error(err, "Failed to expire approval", map[string]interface{}{
+				"approval_id": approval.ID,
+			})
+			continue
+		}
+		if err := s.release(approval); err != nil {
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// release saves the closed request together with its expense, which goes
+// through or is deleted depending on the outcome. An expense its author
+// deleted in the meantime only has the request closed.
+func (s *approvalService) release(approval *entities.Approval) error {
+	transaction, err := s.transactionRepo.FindTransactionByID(approval.TransactionID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find transaction", map[string]interface{}{
+			"transaction_id": approval.TransactionID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if transaction != nil {
+		if err := transaction.Release(approval.Status); err != nil {
+			s.logger.Error(err, "Failed to release transaction", map[string]interface{}{
+				"transaction_id": transaction.ID,
+			})
+			return apperror.Wrap(apperror.ErrorTypeInternal, err)
+		}
+	}
+
+	saved, err := s.approvalRepo.SaveReview(approval, transaction)
+	if err != nil {
+		s.logger.Error(err, "Failed to save approval review", map[string]interface{}{
+			"approval_id": approval.ID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if !saved {
+		return ErrApprovalClosed
+	}
+
+	return nil
+}
+
+func reviewError(approval *entities.Approval, err error) error {
+	switch {
+	case errors.Is(err, entities.ErrSelfApproval), errors.Is(err, entities.ErrNotReviewer):
+		return apperror.Wrap(apperror.ErrorTypeForbidden, err)
+	case errors.Is(err, entities.ErrInvalidApprovalTransition):
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err).
+			AddContext("status", approval.Status)
+	case errors.Is(err, entities.ErrRejectionCommentRequired):
+		return apperror.Wrap(apperror.ErrorTypeValidation, err).
+			AddContext("field", "comment")
+	default:
+		return apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+}
+
+func NewApprovalService(
+	approvalRepo repositories.ApprovalRepository,
+	transactionRepo repositories.TransactionRepository,
+	walletRepo repositories.WalletRepository,
+	logger logger.Logger,
+) ApprovalService {
+	return &approvalService{
+		approvalRepo:    approvalRepo,
+		transactionRepo: transactionRepo,
+		walletRepo:      walletRepo,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,280 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockApprovalRepository struct {
+	mock.Mock
+}
+
+func (m *MockApprovalRepository) CreateHeldTransaction(
+	transaction *entities.Transaction,
+	approval *entities.Approval,
+) (*entities.Transaction, error) {
+	args := m.Called(transaction, approval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+func (m *MockApprovalRepository) HoldTransactions(transactions []*entities.Transaction, approvals []*entities.Approval) error {
+	args := m.Called(transactions, approvals)
+	return args.Error(0)
+}
+
+func (m *MockApprovalRepository) FindApprovalByID(id string) (*entities.Approval, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Approval), args.Error(1)
+}
+
+func (m *MockApprovalRepository) FindPendingApprovalsByWalletID(walletID string) ([]*entities.Approval, error) {
+	args := m.Called(walletID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Approval), args.Error(1)
+}
+
+func (m *MockApprovalRepository) FindOverdueApprovals(now time.Time) ([]*entities.Approval, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Approval), args.Error(1)
+}
+
+func (m *MockApprovalRepository) SaveReview(approval *entities.Approval, transaction *entities.Transaction) (bool, error) {
+	args := m.Called(approval, transaction)
+	return args.Bool(0), args.Error(1)
+}
+
+// newHeldExpense builds an expense of "member-id" in "wallet-id" held for
+// approval, and its request, which expires after ttl.
+func newHeldExpense(t *testing.T, ttl time.Duration) (*entities.Transaction, *entities.Approval) {
+	t.Helper()
+	transaction := newTestTransaction(t)
+	transaction.CreatedBy = "member-id"
+	require.NoError(t, transaction.HoldForApproval())
+	approval, err := entities.NewApproval(transaction, ttl, time.Now())
+	require.NoError(t, err)
+	approval.ID = "approval-id"
+	return transaction, approval
+}
+
+func TestApprovalService_ListApprovals(t *testing.T) {
+	_, approval := newHeldExpense(t, time.Hour)
+	approvalRepo := new(MockApprovalRepository)
+	approvalRepo.On("FindPendingApprovalsByWalletID", "wallet-id").Return([]*entities.Approval{approval}, nil)
+
+	service := services.NewApprovalService(approvalRepo, new(MockTransactionRepository), newTestWalletRepository(), mocks.NewMockLogger())
+
+	approvals, err := service.ListApprovals("wallet-id", "member-id")
+	assert.NoError(t, err)
+	assert.Len(t, approvals, 1)
+
+	_, err = service.ListApprovals("wallet-id", "stranger-id")
+	assert.ErrorIs(t, err, services.ErrWalletForbidden)
+}
+
+func TestApprovalService_Review(t *testing.T) {
+	type review func(services.ApprovalService, string, string) (*entities.Approval, error)
+	approve := func(service services.ApprovalService, actorID, comment string) (*entities.Approval, error) {
+		return service.ApproveExpense("wallet-id", actorID, "approval-id", comment)
+	}
+	reject := func(service services.ApprovalService, actorID, comment string) (*entities.Approval, error) {
+		return service.RejectExpense("wallet-id", actorID, "approval-id", comment)
+	}
+
+	tests := []struct {
+		name            string
+		review          review
+		actorID         string
+		comment         string
+		prepare         func(*entities.Approval)
+		saved           bool
+		wantErr         error
+		wantErrType     apperror.ErrorType
+		wantStatus      entities.ApprovalStatus
+		wantTransaction func(*testing.T, *entities.Transaction)
+	}{
+		{
+			name:       "owner approves and the expense clears",
+			review:     approve,
+			actorID:    "owner-id",
+			saved:      true,
+			wantStatus: entities.ApprovalStatusApproved,
+			wantTransaction: func(t *testing.T, transaction *entities.Transaction) {
+				assert.False(t, transaction.AwaitingApproval)
+				assert.Equal(t, entities.TransactionStatusCleared, transaction.Status)
+			},
+		},
+		{
+			name:       "owner rejects and the expense is deleted",
+			review:     reject,
+			actorID:    "owner-id",
+			comment:    "too expensive",
+			saved:      true,
+			wantStatus: entities.ApprovalStatusRejected,
+			wantTransaction: func(t *testing.T, transaction *entities.Transaction) {
+				assert.True(t, transaction.IsDeleted)
+			},
+		},
+		{
+			name:        "rejection needs a comment",
+			review:      reject,
+			actorID:     "owner-id",
+			wantErrType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:    "members cannot review",
+			review:  approve,
+			actorID: "member-id",
+			wantErr: services.ErrApprovalReviewForbidden,
+		},
+		{
+			name:    "parent reviews the expense of their dependent",
+			review:  approve,
+			actorID: "member-id",
+			prepare: func(a *entities.Approval) {
+				a.RequestedBy = "teen-id"
+				a.ReviewerID = "member-id"
+			},
+			saved:      true,
+			wantStatus: entities.ApprovalStatusApproved,
+			wantTransaction: func(t *testing.T, transaction *entities.Transaction) {
+				assert.False(t, transaction.AwaitingApproval)
+			},
+		},
+		{
+			name:    "owners cannot review requests assigned to a parent",
+			review:  approve,
+			actorID: "owner-id",
+			prepare: func(a *entities.Approval) {
+				a.RequestedBy = "teen-id"
+				a.ReviewerID = "member-id"
+			},
+			wantErr: services.ErrApprovalReviewForbidden,
+		},
+		{
+			name:    "outsiders cannot review",
+			review:  approve,
+			actorID: "stranger-id",
+			wantErr: services.ErrWalletForbidden,
+		},
+		{
+			name:        "owners cannot approve their own expenses",
+			review:      approve,
+			actorID:     "owner-id",
+			prepare:     func(a *entities.Approval) { a.RequestedBy = "owner-id" },
+			wantErrType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:    "request of another wallet",
+			review:  approve,
+			actorID: "owner-id",
+			prepare: func(a *entities.Approval) { a.WalletID = "other-wallet-id" },
+			wantErr: services.ErrApprovalNotFound,
+		},
+		{
+			name:        "overdue request expires and the expense is deleted",
+			review:      approve,
+			actorID:     "owner-id",
+			prepare:     func(a *entities.Approval) { a.ExpiresAt = time.Now().Add(-time.Minute) },
+			saved:       true,
+			wantErrType: apperror.ErrorTypeUnprocessable,
+			wantStatus:  entities.ApprovalStatusExpired,
+			wantTransaction: func(t *testing.T, transaction *entities.Transaction) {
+				assert.True(t, transaction.IsDeleted)
+			},
+		},
+		{
+			name:        "request closed in the meantime",
+			review:      approve,
+			actorID:     "owner-id",
+			saved:       false,
+			wantErr:     services.ErrApprovalClosed,
+			wantStatus:  entities.ApprovalStatusApproved,
+			wantErrType: apperror.ErrorTypeUnprocessable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction, approval := newHeldExpense(t, time.Hour)
+			if tt.prepare != nil {
+				tt.prepare(approval)
+			}
+
+			approvalRepo := new(MockApprovalRepository)
+			approvalRepo.On("FindApprovalByID", "approval-id").Return(approval, nil).Maybe()
+			approvalRepo.On("SaveReview", approval, transaction).Return(tt.saved, nil).Maybe()
+			transactionRepo := new(MockTransactionRepository)
+			transactionRepo.On("FindTransactionByID", transaction.ID).Return(transaction, nil).Maybe()
+
+			service := services.NewApprovalService(approvalRepo, transactionRepo, newTestWalletRepository(), mocks.NewMockLogger())
+			reviewed, err := tt.review(service, tt.actorID, tt.comment)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrType != "":
+				assert.True(t, apperror.IsErrorType(err, tt.wantErrType), "got %v", err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.actorID, reviewed.ReviewedBy)
+			}
+			if tt.wantStatus != "" {
+				assert.Equal(t, tt.wantStatus, approval.Status)
+				approvalRepo.AssertCalled(t, "SaveReview", approval, transaction)
+			} else {
+				approvalRepo.AssertNotCalled(t, "SaveReview", mock.Anything, mock.Anything)
+			}
+			if tt.wantTransaction != nil {
+				tt.wantTransaction(t, transaction)
+			}
+		})
+	}
+}
+
+func TestApprovalService_ExpireOverdue(t *testing.T) {
+	now := time.Now().Add(2 * time.Hour)
+	expiring, first := newHeldExpense(t, time.Hour)
+	failing, second := newHeldExpense(t, time.Hour)
+	failing.ID = "failing-id"
+	second.ID = "failing-approval-id"
+	second.TransactionID = failing.ID
+
+	approvalRepo := new(MockApprovalRepository)
+	approvalRepo.On("FindOverdueApprovals", now).Return([]*entities.Approval{first, second}, nil)
+	approvalRepo.On("SaveReview", first, expiring).Return(true, nil)
+	approvalRepo.On("SaveReview", second, failing).Return(false, errors.New("database error"))
+	transactionRepo := new(MockTransactionRepository)
+	transactionRepo.On("FindTransactionByID", expiring.ID).Return(expiring, nil)
+	transactionRepo.On("FindTransactionByID", failing.ID).Return(failing, nil)
+	logger := mocks.NewMockLogger()
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	service := services.NewApprovalService(approvalRepo, transactionRepo, new(MockWalletRepository), logger)
+	expired, err := service.ExpireOverdue(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, entities.ApprovalStatusExpired, first.Status)
+	assert.True(t, expiring.IsDeleted)
+	approvalRepo.AssertExpectations(t)
+}
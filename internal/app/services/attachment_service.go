@@ -0,0 +1,321 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/storage"
+)
+
+const (
+	// thumbnailSize bounds the width and height of image thumbnails.
+	thumbnailSize = 256
+	// downloadURLLifetime is how long a download link stays valid.
+	downloadURLLifetime = 15 * time.Minute
+)
+
+// AttachmentInput holds an uploaded file. Its type is sniffed from Content,
+// whatever the name or the client says.
+type AttachmentInput struct {
+	FileName string
+	Content  io.Reader
+}
+
+// AttachmentDownload holds signed links to an attachment and its thumbnail,
+// valid until ExpiresAt.
+type AttachmentDownload struct {
+	URL          string
+	ThumbnailURL string
+	ExpiresAt    time.Time
+}
+
+type AttachmentService interface {
+	UploadAttachment(walletID, actorID, transactionID string, input AttachmentInput) (*entities.Attachment, error)
+	ListAttachments(walletID, actorID, transactionID string) ([]*entities.Attachment, error)
+	GetAttachment(walletID, actorID, transactionID, attachmentID string) (*entities.Attachment, error)
+	DownloadAttachment(walletID, actorID, transactionID, attachmentID string) (*AttachmentDownload, error)
+	DeleteAttachment(walletID, actorID, transactionID, attachmentID string) error
+	// PurgeDeletedTransactions removes transactions deleted before
+	// deletedBefore for good, along with their attachments, and returns how
+	// many transactions were removed.
+	PurgeDeletedTransactions(deletedBefore time.Time) (int64, error)
+}
+
+type attachmentService struct {
+	attachmentRepo  repositories.AttachmentRepository
+	transactionRepo repositories.TransactionRepository
+	walletRepo      repositories.WalletRepository
+	storage         storage.Storage
+	logger          logger.Logger
+}
+
+var ErrAttachmentNotFound = apperror.New(apperror.ErrorTypeNotFound, "Attachment not found")
+
+// UploadAttachment stores the file and, for images, a thumbnail. A missing
+// thumbnail does not fail the upload, since the file itself is what counts.
+func (s *attachmentService) UploadAttachment(walletID, actorID, transactionID string, input AttachmentInput) (*entities.Attachment, error) {
+	if _, err := s.findTransaction(walletID, actorID, transactionID); err != nil {
+		return nil, err
+	}
+
+	content, err := storage.ReadLimited(input.Content, entities.MaxAttachmentSize)
+	if errors.Is(err, storage.ErrTooLarge) {
+		return nil, apperror.New(apperror.ErrorTypeValidation, "File must be at most 10 MB").
+			AddContext("field", "file")
+	}
+	if err != nil {
+		return nil, apperror.New(apperror.ErrorTypeValidation, "File could not be read").
+			AddContext("field", "file")
+	}
+
+	attachment, err := entities.NewAttachment(
+		walletID,
+		transactionID,
+		input.FileName,
+		storage.DetectContentType(content),
+		int64(len(content)),
+		storage.Checksum(content),
+		actorID,
+	)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.checkNotAttached(attachment); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Put(attachment.StorageKey, bytes.NewReader(content), attachment.Size, attachment.ContentType); err != nil {
+		s.logger.Error(err, "Failed to store attachment", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeExternalAPI, err)
+	}
+
+	if attachment.IsImage() {
+		s.storeThumbnail(attachment, content)
+	}
+
+	createdAttachment, err := s.attachmentRepo.CreateAttachment(attachment)
+	if err != nil {
+		s.logger.Error(err, "Failed to create attachment", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		s.removeFiles(attachment)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdAttachment, nil
+}
+
+func (s *attachmentService) ListAttachments(walletID, actorID, transactionID string) ([]*entities.Attachment, error) {
+	if _, err := s.findTransaction(walletID, actorID, transactionID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.FindAttachmentsByTransactionID(transactionID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list attachments", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return attachments, nil
+}
+
+// GetAttachment returns the attachment only when it belongs to the
+// transaction and the transaction to the wallet.
+func (s *attachmentService) GetAttachment(walletID, actorID, transactionID, attachmentID string) (*entities.Attachment, error) {
+	if _, err := s.findTransaction(walletID, actorID, transactionID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.FindAttachmentByID(attachmentID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find attachment", map[string]interface{}{
+			"attachment_id": attachmentID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if attachment == nil || attachment.TransactionID != transactionID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+func (s *attachmentService) DownloadAttachment(walletID, actorID, transactionID, attachmentID string) (*AttachmentDownload, error) {
+	attachment, err := s.GetAttachment(walletID, actorID, transactionID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	download := &AttachmentDownload{ExpiresAt: time.Now().Add(downloadURLLifetime)}
+
+	download.URL, err = s.storage.SignedURL(attachment.StorageKey, downloadURLLifetime)
+	if err != nil {
+		s.logger.Error(err, "Failed to sign attachment URL", map[string]interface{}{
+			"attachment_id": attachmentID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeExternalAPI, err)
+	}
+
+	if attachment.HasThumbnail() {
+		download.ThumbnailURL, err = s.storage.SignedURL(attachment.ThumbnailKey, downloadURLLifetime)
+		if err != nil {
+			s.logger.Error(err, "Failed to sign thumbnail URL", map[string]interface{}{
+				"attachment_id": attachmentID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeExternalAPI, err)
+		}
+	}
+
+	return download, nil
+}
+
+func (s *attachmentService) DeleteAttachment(walletID, actorID, transactionID, attachmentID string) error {
+	attachment, err := s.GetAttachment(walletID, actorID, transactionID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteAttachment(attachment)
+}
+
+// PurgeDeletedTransactions removes attachments first and only then the
+// transactions without any left, so a file that could not be removed is
+// retried on the next purge instead of being left behind.
+func (s *attachmentService) PurgeDeletedTransactions(deletedBefore time.Time) (int64, error) {
+	attachments, err := s.attachmentRepo.FindAttachmentsOfDeletedTransactions(deletedBefore)
+	if err != nil {
+		s.logger.Error(err, "Failed to find attachments to purge", map[string]interface{}{})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	for _, attachment := range attachments {
+		// deleteAttachment logs; the transaction is kept and retried later
+		_ = s.deleteAttachment(attachment)
+	}
+
+	purged, err := s.transactionRepo.PurgeDeletedTransactions(deletedBefore)
+	if err != nil {
+		s.logger.Error(err, "Failed to purge deleted transactions", map[string]interface{}{})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return purged, nil
+}
+
+func (s *attachmentService) deleteAttachment(attachment *entities.Attachment) error {
+	for _, key := range attachment.StorageKeys() {
+		if err := s.storage.Delete(key); err != nil {
+			s.logger.Error(err, "Failed to remove attachment file", map[string]interface{}{
+				"attachment_id": attachment.ID,
+			})
+			return apperror.Wrap(apperror.ErrorTypeExternalAPI, err)
+		}
+	}
+
+	if err := s.attachmentRepo.DeleteAttachment(attachment); err != nil {
+		s.logger.Error(err, "Failed to delete attachment", map[string]interface{}{
+			"attachment_id": attachment.ID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// checkNotAttached rejects a file already attached to the transaction, as
+// told by its checksum.
+func (s *attachmentService) checkNotAttached(attachment *entities.Attachment) error {
+	attachments, err := s.attachmentRepo.FindAttachmentsByTransactionID(attachment.TransactionID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list attachments", map[string]interface{}{
+			"transaction_id": attachment.TransactionID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	for _, existing := range attachments {
+		if existing.Checksum == attachment.Checksum {
+			return apperror.New(apperror.ErrorTypeUnprocessable, "File is already attached to the transaction").
+				AddContext("attachment_id", existing.ID)
+		}
+	}
+	return nil
+}
+
+func (s *attachmentService) storeThumbnail(attachment *entities.Attachment, content []byte) {
+	thumbnail, err := storage.Thumbnail(content, thumbnailSize)
+	if err != nil {
+		// Formats without a decoder, such as WebP, simply have no thumbnail
+		return
+	}
+
+	err = s.storage.Put(attachment.ThumbnailStorageKey(), bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg")
+	if err != nil {
+		s.logger.Error(err, "Failed to store attachment thumbnail", map[string]interface{}{
+			"attachment_id": attachment.ID,
+		})
+		return
+	}
+
+	attachment.SetThumbnail()
+}
+
+// removeFiles cleans up after an upload that could not be recorded.
+func (s *attachmentService) removeFiles(attachment *entities.Attachment) {
+	for _, key := range attachment.StorageKeys() {
+		if err := s.storage.Delete(key); err != nil {
+			s.logger.Error(err, "Failed to remove attachment file", map[string]interface{}{
+				"attachment_id": attachment.ID,
+			})
+		}
+	}
+}
+
+// findTransaction returns a transaction of a wallet the actor is a member of.
+func (s *attachmentService) findTransaction(walletID, actorID, transactionID string) (*entities.Transaction, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionRepo.FindTransactionByID(transactionID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find transaction", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if transaction == nil || transaction.WalletID != walletID {
+		return nil, ErrTransactionNotFound
+	}
+
+	return transaction, nil
+}
+
+func NewAttachmentService(
+	attachmentRepo repositories.AttachmentRepository,
+	transactionRepo repositories.TransactionRepository,
+	walletRepo repositories.WalletRepository,
+	storage storage.Storage,
+	logger logger.Logger,
+) AttachmentService {
+	return &attachmentService{
+		attachmentRepo:  attachmentRepo,
+		transactionRepo: transactionRepo,
+		walletRepo:      walletRepo,
+		storage:         storage,
+		logger:          logger,
+	}
+}
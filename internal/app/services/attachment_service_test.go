@@ -0,0 +1,310 @@
+package services_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/storage"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) CreateAttachment(attachment *entities.Attachment) (*entities.Attachment, error) {
+	args := m.Called(attachment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindAttachmentByID(id string) (*entities.Attachment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindAttachmentsByTransactionID(transactionID string) ([]*entities.Attachment, error) {
+	args := m.Called(transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindAttachmentsOfDeletedTransactions(deletedBefore time.Time) ([]*entities.Attachment, error) {
+	args := m.Called(deletedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) DeleteAttachment(attachment *entities.Attachment) error {
+	args := m.Called(attachment)
+	return args.Error(0)
+}
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Put(key string, r io.Reader, size int64, contentType string) error {
+	args := m.Called(key, size, contentType)
+	return args.Error(0)
+}
+
+func (m *MockStorage) Get(key string) (io.ReadCloser, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockStorage) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockStorage) SignedURL(key string, expiresIn time.Duration) (string, error) {
+	args := m.Called(key, expiresIn)
+	return args.String(0), args.Error(1)
+}
+
+const testPDF = "%PDF-1.7\n1 0 obj << >> endobj\n%%EOF"
+
+func newTestAttachment(t *testing.T) *entities.Attachment {
+	t.Helper()
+	attachment, err := entities.NewAttachment("wallet-id", "transaction-id", "receipt.pdf", "application/pdf",
+		int64(len(testPDF)), storage.Checksum([]byte(testPDF)), "user-id")
+	require.NoError(t, err)
+	attachment.ID = "attachment-id"
+	return attachment
+}
+
+func newTestPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 600, 300))))
+	return buf.Bytes()
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		mockSetup func(*MockAttachmentRepository, *MockStorage, *mocks.MockLogger)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:    "pdf",
+			content: testPDF,
+			mockSetup: func(ar *MockAttachmentRepository, s *MockStorage, l *mocks.MockLogger) {
+				ar.On("FindAttachmentsByTransactionID", "transaction-id").Return([]*entities.Attachment{}, nil)
+				s.On("Put", mock.AnythingOfType("string"), int64(len(testPDF)), "application/pdf").Return(nil)
+				ar.On("CreateAttachment", mock.MatchedBy(func(attachment *entities.Attachment) bool {
+					return attachment.Checksum == storage.Checksum([]byte(testPDF)) && !attachment.HasThumbnail()
+				})).Return(newTestAttachment(t), nil)
+			},
+		},
+		{
+			name:    "image with thumbnail",
+			content: string(newTestPNG(t)),
+			mockSetup: func(ar *MockAttachmentRepository, s *MockStorage, l *mocks.MockLogger) {
+				ar.On("FindAttachmentsByTransactionID", "transaction-id").Return([]*entities.Attachment{}, nil)
+				s.On("Put", mock.AnythingOfType("string"), mock.Anything, "image/png").Return(nil)
+				s.On("Put", mock.MatchedBy(func(key string) bool {
+					return strings.HasSuffix(key, ".thumbnail.jpg")
+				}), mock.Anything, "image/jpeg").Return(nil)
+				ar.On("CreateAttachment", mock.MatchedBy(func(attachment *entities.Attachment) bool {
+					return attachment.HasThumbnail()
+				})).Return(newTestAttachment(t), nil)
+			},
+		},
+		{
+			name:    "unsupported type",
+			content: "<html><body>not a receipt</body></html>",
+			mockSetup: func(ar *MockAttachmentRepository, s *MockStorage, l *mocks.MockLogger) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:    "too large",
+			content: "%PDF-1.7" + strings.Repeat(" ", entities.MaxAttachmentSize),
+			mockSetup: func(ar *MockAttachmentRepository, s *MockStorage, l *mocks.MockLogger) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:    "already attached",
+			content: testPDF,
+			mockSetup: func(ar *MockAttachmentRepository, s *MockStorage, l *mocks.MockLogger) {
+				ar.On("FindAttachmentsByTransactionID", "transaction-id").Return([]*entities.Attachment{newTestAttachment(t)}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:    "storage error",
+			content: testPDF,
+			mockSetup: func(ar *MockAttachmentRepository, s *MockStorage, l *mocks.MockLogger) {
+				ar.On("FindAttachmentsByTransactionID", "transaction-id").Return([]*entities.Attachment{}, nil)
+				s.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("storage unavailable"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeExternalAPI,
+		},
+		{
+			name:    "repository error removes the file",
+			content: testPDF,
+			mockSetup: func(ar *MockAttachmentRepository, s *MockStorage, l *mocks.MockLogger) {
+				ar.On("FindAttachmentsByTransactionID", "transaction-id").Return([]*entities.Attachment{}, nil)
+				s.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				ar.On("CreateAttachment", mock.Anything).Return(nil, errors.New("database error"))
+				s.On("Delete", mock.AnythingOfType("string")).Return(nil).Once()
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentRepo := new(MockAttachmentRepository)
+			transactionRepo := new(MockTransactionRepository)
+			fileStorage := new(MockStorage)
+			logger := mocks.NewMockLogger()
+			transactionRepo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+			tt.mockSetup(attachmentRepo, fileStorage, logger)
+
+			service := services.NewAttachmentService(attachmentRepo, transactionRepo, newWalletRepository(), fileStorage, logger)
+			attachment, err := service.UploadAttachment("wallet-id", "user-id", "transaction-id", services.AttachmentInput{
+				FileName: "receipt",
+				Content:  strings.NewReader(tt.content),
+			})
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, attachment)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, attachment)
+			}
+			attachmentRepo.AssertExpectations(t)
+			fileStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttachmentService_GetAttachment_OtherTransaction(t *testing.T) {
+	attachmentRepo := new(MockAttachmentRepository)
+	transactionRepo := new(MockTransactionRepository)
+	other := newTestTransaction(t)
+	other.ID = "other-transaction"
+	transactionRepo.On("FindTransactionByID", "other-transaction").Return(other, nil)
+	attachmentRepo.On("FindAttachmentByID", "attachment-id").Return(newTestAttachment(t), nil)
+
+	service := services.NewAttachmentService(attachmentRepo, transactionRepo, newWalletRepository(), new(MockStorage), mocks.NewMockLogger())
+	attachment, err := service.GetAttachment("wallet-id", "user-id", "other-transaction", "attachment-id")
+
+	assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	assert.Nil(t, attachment)
+}
+
+func TestAttachmentService_ListAttachments_NonMember(t *testing.T) {
+	attachmentRepo := new(MockAttachmentRepository)
+	transactionRepo := new(MockTransactionRepository)
+
+	service := services.NewAttachmentService(attachmentRepo, transactionRepo, newWalletRepository(), new(MockStorage), mocks.NewMockLogger())
+	attachments, err := service.ListAttachments("wallet-id", "stranger-id", "transaction-id")
+
+	assert.ErrorIs(t, err, services.ErrWalletForbidden)
+	assert.Nil(t, attachments)
+	transactionRepo.AssertNotCalled(t, "FindTransactionByID", mock.Anything)
+}
+
+func TestAttachmentService_DownloadAttachment(t *testing.T) {
+	attachmentRepo := new(MockAttachmentRepository)
+	transactionRepo := new(MockTransactionRepository)
+	fileStorage := new(MockStorage)
+	attachment := newTestAttachment(t)
+	transactionRepo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+	attachmentRepo.On("FindAttachmentByID", "attachment-id").Return(attachment, nil)
+	fileStorage.On("SignedURL", attachment.StorageKey, mock.AnythingOfType("time.Duration")).
+		Return("https://files.example.com/receipt?signature=abc", nil)
+
+	service := services.NewAttachmentService(attachmentRepo, transactionRepo, newWalletRepository(), fileStorage, mocks.NewMockLogger())
+	download, err := service.DownloadAttachment("wallet-id", "user-id", "transaction-id", "attachment-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://files.example.com/receipt?signature=abc", download.URL)
+	assert.Empty(t, download.ThumbnailURL)
+	assert.True(t, download.ExpiresAt.After(time.Now()))
+}
+
+func TestAttachmentService_PurgeDeletedTransactions(t *testing.T) {
+	cutoff := time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC)
+
+	t.Run("removes files before transactions", func(t *testing.T) {
+		attachmentRepo := new(MockAttachmentRepository)
+		transactionRepo := new(MockTransactionRepository)
+		fileStorage := new(MockStorage)
+		attachment := newTestAttachment(t)
+		attachment.SetThumbnail()
+		attachmentRepo.On("FindAttachmentsOfDeletedTransactions", cutoff).Return([]*entities.Attachment{attachment}, nil)
+		fileStorage.On("Delete", attachment.StorageKey).Return(nil)
+		fileStorage.On("Delete", attachment.ThumbnailKey).Return(nil)
+		attachmentRepo.On("DeleteAttachment", attachment).Return(nil)
+		transactionRepo.On("PurgeDeletedTransactions", cutoff).Return(int64(3), nil)
+
+		service := services.NewAttachmentService(attachmentRepo, transactionRepo, newWalletRepository(), fileStorage, mocks.NewMockLogger())
+		purged, err := service.PurgeDeletedTransactions(cutoff)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), purged)
+		attachmentRepo.AssertExpectations(t)
+		fileStorage.AssertExpectations(t)
+	})
+
+	t.Run("keeps attachments whose files cannot be removed", func(t *testing.T) {
+		attachmentRepo := new(MockAttachmentRepository)
+		transactionRepo := new(MockTransactionRepository)
+		fileStorage := new(MockStorage)
+		logger := mocks.NewMockLogger()
+		attachment := newTestAttachment(t)
+		attachmentRepo.On("FindAttachmentsOfDeletedTransactions", cutoff).Return([]*entities.Attachment{attachment}, nil)
+		fileStorage.On("Delete", attachment.StorageKey).Return(errors.New("storage unavailable"))
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		transactionRepo.On("PurgeDeletedTransactions", cutoff).Return(int64(0), nil)
+
+		service := services.NewAttachmentService(attachmentRepo, transactionRepo, newWalletRepository(), fileStorage, logger)
+		purged, err := service.PurgeDeletedTransactions(cutoff)
+
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+		attachmentRepo.AssertNotCalled(t, "DeleteAttachment", mock.Anything)
+	})
+}
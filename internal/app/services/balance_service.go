@@ -0,0 +1,241 @@
+package services
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type BalanceService interface {
+	// GetBalances shows the net balance of every wallet member on shared
+	// expenses and who owes whom.
+	GetBalances(walletID, actorID string) (*entities.SharedBalances, error)
+	// GetWalletBalances shows the current, available and projected balance
+	// of the wallet in each currency it holds or, when currency is set, a
+	// single balance with every currency converted into it at the latest
+	// rates. Without a currency, the base currency of the actor is used
+	// when they have one.
+	GetWalletBalances(walletID, actorID, currency string) ([]*entities.WalletBalance, error)
+	// GetBalancesAsOf shows the current balance of the wallet at the end of
+	// date in each currency or, with a currency or the actor's base
+	// currency, a single balance converted at the rates of that day.
+	GetBalancesAsOf(walletID, actorID string, date time.Time, currency string) ([]*entities.BalanceSnapshot, error)
+	// SnapshotBalances stores the balance of every wallet at the end of
+	// asOf and returns how many balances were stored.
+	SnapshotBalances(asOf time.Time) (int, error)
+	// VerifyBalances recomputes the running totals and snapshots from the
+	// raw transactions and reports the ones that drifted.
+	VerifyBalances() (*entities.BalanceDrifts, error)
+}
+
+type balanceService struct {
+	balanceRepo repositories.BalanceRepository
+	walletRepo  repositories.WalletRepository
+	userRepo    repositories.UserRepository
+	rateService ExchangeRateService
+	logger      logger.Logger
+}
+
+func (s *balanceService) GetBalances(walletID, actorID string) (*entities.SharedBalances, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	debts, err := s.balanceRepo.FindDebts(walletID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet debts", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	balances, err := entities.NewSharedBalances(debts)
+	if err != nil {
+		s.logger.Error(err, "Failed to compute wallet balances", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeInternal, err)
+	}
+
+	return balances, nil
+}
+
+func (s *balanceService) GetWalletBalances(walletID, actorID, currency string) ([]*entities.WalletBalance, error) {
+	if err := checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	currency, err := displayCurrency(s.userRepo, s.logger, actorID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.balanceRepo.FindStatusTotals(walletID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet totals", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	balances, err := entities.NewWalletBalances(totals)
+	if err != nil {
+		s.logger.Error(err, "Failed to compute wallet balances", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeInternal, err)
+	}
+
+	if currency == "" {
+		return balances, nil
+	}
+
+	converted, err := s.convertBalances(balances, currency, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return []*entities.WalletBalance{converted}, nil
+}
+
+func (s *balanceService) GetBalancesAsOf(walletID, actorID string, date time.Time, currency string) ([]*entities.BalanceSnapshot, error) {
+	if err := checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	currency, err := displayCurrency(s.userRepo, s.logger, actorID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.balanceRepo.FindBalancesAsOf(walletID, date)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet balances", map[string]interface{}{
+			"wallet_id": walletID,
+			"as_of":     date,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if currency == "" {
+		return balances, nil
+	}
+
+	amounts := make([]money.Money, 0, len(balances))
+	for _, balance := range balances {
+		amounts = append(amounts, balance.Balance)
+	}
+	total, conversions, err := s.rateService.ConvertTotal(amounts, currency, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*entities.BalanceSnapshot{{
+		WalletID:    walletID,
+		AsOf:        date,
+		Balance:     total,
+		Conversions: conversions,
+	}}, nil
+}
+
+func (s *balanceService) SnapshotBalances(asOf time.Time) (int, error) {
+	count, err := s.balanceRepo.CreateSnapshots(asOf)
+	if err != nil {
+		s.logger.Error(err, "Failed to snapshot wallet balances", map[string]interface{}{
+			"as_of": asOf,
+		})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return count, nil
+}
+
+func (s *balanceService) VerifyBalances() (*entities.BalanceDrifts, error) {
+	drifts, err := s.balanceRepo.FindDrifts()
+	if err != nil {
+		s.logger.Error(err, "Failed to recompute wallet balances", map[string]interface{}{})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return drifts, nil
+}
+
+// convertBalances adds up the balances into one in currencyCode. Each
+// currency is converted at a single rate, looked up for its current balance.
+func (s *balanceService) convertBalances(balances []*entities.WalletBalance, currencyCode string, date time.Time) (*entities.WalletBalance, error) {
+	zero, err := money.Zero(currencyCode)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	converted := &entities.WalletBalance{
+		Current:         zero,
+		Available:       zero,
+		Projected:       zero,
+		PendingApproval: zero,
+		Conversions:     make([]*entities.Conversion, 0, len(balances)),
+	}
+	for _, balance := range balances {
+		conversion, err := s.rateService.Convert(balance.Current, currencyCode, date)
+		if err != nil {
+			return nil, err
+		}
+
+		available, err := balance.Available.Convert(currencyCode, conversion.Rate, money.RoundHalfEven)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		projected, err := balance.Projected.Convert(currencyCode, conversion.Rate, money.RoundHalfEven)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		pendingApproval, err := balance.PendingApproval.Convert(currencyCode, conversion.Rate, money.RoundHalfEven)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+
+		if converted.Current, err = converted.Current.Add(conversion.Converted); err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		if converted.Available, err = converted.Available.Add(available); err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		if converted.Projected, err = converted.Projected.Add(projected); err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		if converted.PendingApproval, err = converted.PendingApproval.Add(pendingApproval); err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		converted.Conversions = append(converted.Conversions, conversion)
+	}
+
+	return converted, nil
+}
+
+func NewBalanceService(
+	balanceRepo repositories.BalanceRepository,
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+	rateService ExchangeRateService,
+	logger logger.Logger,
+) BalanceService {
+	return &balanceService{
+		balanceRepo: balanceRepo,
+		walletRepo:  walletRepo,
+		userRepo:    userRepo,
+		rateService: rateService,
+		logger:      logger,
+	}
+}
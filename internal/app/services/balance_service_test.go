@@ -0,0 +1,274 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockBalanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockBalanceRepository) FindDebts(walletID string) ([]*entities.Debt, error) {
+	args := m.Called(walletID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Debt), args.Error(1)
+}
+
+func (m *MockBalanceRepository) FindStatusTotals(walletID string) ([]*entities.StatusTotal, error) {
+	args := m.Called(walletID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.StatusTotal), args.Error(1)
+}
+
+func (m *MockBalanceRepository) FindBalancesAsOf(walletID string, date time.Time) ([]*entities.BalanceSnapshot, error) {
+	args := m.Called(walletID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.BalanceSnapshot), args.Error(1)
+}
+
+func (m *MockBalanceRepository) CreateSnapshots(asOf time.Time) (int, error) {
+	args := m.Called(asOf)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBalanceRepository) FindDrifts() (*entities.BalanceDrifts, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BalanceDrifts), args.Error(1)
+}
+
+func TestBalanceService_GetBalances(t *testing.T) {
+	t.Run("nets debts between members", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindDebts", "wallet-id").Return([]*entities.Debt{
+			{DebtorID: "bia", CreditorID: "ana", Amount: newMoney(t, "30.00", "BRL")},
+			{DebtorID: "ana", CreditorID: "bia", Amount: newMoney(t, "10.00", "BRL")},
+		}, nil)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+		balances, err := service.GetBalances("wallet-id", "user-id")
+
+		require.NoError(t, err)
+		require.Len(t, balances.Debts, 1)
+		assert.Equal(t, "bia", balances.Debts[0].DebtorID)
+		assert.Equal(t, int64(2000), balances.Debts[0].Amount.MinorUnits())
+		assert.Len(t, balances.Members, 2)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		logger := mocks.NewMockLogger()
+		repo.On("FindDebts", "wallet-id").Return(nil, errors.New("database error"))
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), logger)
+		balances, err := service.GetBalances("wallet-id", "user-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+		assert.Nil(t, balances)
+	})
+}
+
+func TestBalanceService_GetWalletBalances(t *testing.T) {
+	t.Run("splits the balance by status", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindStatusTotals", "wallet-id").Return([]*entities.StatusTotal{
+			{Status: entities.TransactionStatusCleared, Inflow: newMoney(t, "100.00", "BRL"), Outflow: newMoney(t, "40.00", "BRL")},
+			{Status: entities.TransactionStatusPending, Inflow: newMoney(t, "0", "BRL"), Outflow: newMoney(t, "25.00", "BRL")},
+		}, nil)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+		balances, err := service.GetWalletBalances("wallet-id", "user-id", "")
+
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		assert.Equal(t, int64(6000), balances[0].Current.MinorUnits())
+		assert.Equal(t, int64(3500), balances[0].Available.MinorUnits())
+		assert.Equal(t, int64(3500), balances[0].Projected.MinorUnits())
+	})
+
+	t.Run("converts every currency", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindStatusTotals", "wallet-id").Return([]*entities.StatusTotal{
+			{Status: entities.TransactionStatusCleared, Inflow: newMoney(t, "100.00", "BRL"), Outflow: newMoney(t, "40.00", "BRL")},
+			{Status: entities.TransactionStatusCleared, Inflow: newMoney(t, "10.00", "USD"), Outflow: newMoney(t, "0", "USD")},
+			{Status: entities.TransactionStatusPending, Inflow: newMoney(t, "0", "USD"), Outflow: newMoney(t, "5.00", "USD")},
+		}, nil)
+		rates := newRateService(newRate(t, "USD", "BRL", "5", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), rates, mocks.NewMockLogger())
+		balances, err := service.GetWalletBalances("wallet-id", "user-id", "BRL")
+
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		assert.Equal(t, "BRL", balances[0].Current.Currency().Code)
+		assert.Equal(t, int64(11000), balances[0].Current.MinorUnits())
+		assert.Equal(t, int64(8500), balances[0].Available.MinorUnits())
+		assert.Equal(t, int64(8500), balances[0].Projected.MinorUnits())
+		require.Len(t, balances[0].Conversions, 2)
+		assert.Equal(t, "5", balances[0].Conversions[1].Rate.RatString())
+	})
+
+	t.Run("defaults to the base currency of the actor", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindStatusTotals", "wallet-id").Return([]*entities.StatusTotal{
+			{Status: entities.TransactionStatusCleared, Inflow: newMoney(t, "10.00", "USD"), Outflow: newMoney(t, "0", "USD")},
+		}, nil)
+		rates := newRateService(newRate(t, "USD", "BRL", "5", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+		users := new(MockUserRepository)
+		users.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id", BaseCurrency: "BRL"}, nil)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), users, rates, mocks.NewMockLogger())
+		balances, err := service.GetWalletBalances("wallet-id", "user-id", "")
+
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		assert.Equal(t, "BRL", balances[0].Current.Currency().Code)
+		assert.Equal(t, int64(5000), balances[0].Current.MinorUnits())
+	})
+
+	t.Run("missing rate", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindStatusTotals", "wallet-id").Return([]*entities.StatusTotal{
+			{Status: entities.TransactionStatusCleared, Inflow: newMoney(t, "10.00", "USD"), Outflow: newMoney(t, "0", "USD")},
+		}, nil)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+		balances, err := service.GetWalletBalances("wallet-id", "user-id", "BRL")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		assert.Nil(t, balances)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+		_, err := service.GetWalletBalances("wallet-id", "user-id", "XYZ")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		repo.AssertNotCalled(t, "FindStatusTotals", mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		logger := mocks.NewMockLogger()
+		repo.On("FindStatusTotals", "wallet-id").Return(nil, errors.New("database error"))
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), logger)
+		balances, err := service.GetWalletBalances("wallet-id", "user-id", "")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+		assert.Nil(t, balances)
+	})
+}
+
+func TestBalanceService_NonMember(t *testing.T) {
+	repo := new(MockBalanceRepository)
+	service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+
+	_, err := service.GetBalances("wallet-id", "stranger-id")
+	assert.ErrorIs(t, err, services.ErrWalletForbidden)
+
+	_, err = service.GetWalletBalances("wallet-id", "stranger-id", "")
+	assert.ErrorIs(t, err, services.ErrWalletForbidden)
+
+	repo.AssertNotCalled(t, "FindDebts", mock.Anything)
+	repo.AssertNotCalled(t, "FindStatusTotals", mock.Anything)
+}
+
+func TestBalanceService_GetBalancesAsOf(t *testing.T) {
+	date := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("per currency", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindBalancesAsOf", "wallet-id", date).Return([]*entities.BalanceSnapshot{
+			{WalletID: "wallet-id", AsOf: date, Balance: newMoney(t, "60.00", "BRL")},
+			{WalletID: "wallet-id", AsOf: date, Balance: newMoney(t, "10.00", "USD")},
+		}, nil)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+		balances, err := service.GetBalancesAsOf("wallet-id", "user-id", date, "")
+
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+		assert.Equal(t, int64(6000), balances[0].Balance.MinorUnits())
+	})
+
+	t.Run("converted at the rates of the day", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindBalancesAsOf", "wallet-id", date).Return([]*entities.BalanceSnapshot{
+			{WalletID: "wallet-id", AsOf: date, Balance: newMoney(t, "60.00", "BRL")},
+			{WalletID: "wallet-id", AsOf: date, Balance: newMoney(t, "10.00", "USD")},
+		}, nil)
+		rates := newRateService(newRate(t, "USD", "BRL", "5", date))
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), rates, mocks.NewMockLogger())
+		balances, err := service.GetBalancesAsOf("wallet-id", "user-id", date, "BRL")
+
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		assert.Equal(t, int64(11000), balances[0].Balance.MinorUnits())
+		assert.Len(t, balances[0].Conversions, 2)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+		_, err := service.GetBalancesAsOf("wallet-id", "stranger-id", date, "")
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+		repo.AssertNotCalled(t, "FindBalancesAsOf", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBalanceService_VerifyBalances(t *testing.T) {
+	t.Run("reports drift", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		repo.On("FindDrifts").Return(&entities.BalanceDrifts{
+			Totals: []*entities.StatusTotalDrift{{
+				WalletID:   "wallet-id",
+				Stored:     &entities.StatusTotal{Status: entities.TransactionStatusCleared, Inflow: newMoney(t, "10.00", "BRL"), Outflow: newMoney(t, "0", "BRL")},
+				Recomputed: &entities.StatusTotal{Status: entities.TransactionStatusCleared, Inflow: newMoney(t, "12.00", "BRL"), Outflow: newMoney(t, "0", "BRL")},
+			}},
+		}, nil)
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), mocks.NewMockLogger())
+		drifts, err := service.VerifyBalances()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, drifts.Count())
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockBalanceRepository)
+		logger := mocks.NewMockLogger()
+		repo.On("FindDrifts").Return(nil, errors.New("database error"))
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewBalanceService(repo, newWalletRepository(), newUserRepository(), newRateService(), logger)
+		_, err := service.VerifyBalances()
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+}
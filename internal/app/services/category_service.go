@@ -0,0 +1,314 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// CategoryInput holds the editable fields of a category. An empty ParentID
+// makes the category top level.
+type CategoryInput struct {
+	Name     string
+	Icon     string
+	Color    string
+	ParentID string
+}
+
+type CategoryService interface {
+	CreateCategory(userID string, categoryType entities.TransactionType, input CategoryInput) (*entities.Category, error)
+	ListCategories(userID string, filter repositories.CategoryFilter, page query.Page) ([]*entities.Category, *query.Cursor, error)
+	UpdateCategory(userID, categoryID string, input CategoryInput) (*entities.Category, error)
+	MergeCategories(userID, sourceID, targetID string) error
+	DeleteCategory(userID, categoryID, reassignToID string) error
+}
+
+type categoryService struct {
+	categoryRepo repositories.CategoryRepository
+	userRepo     repositories.UserRepository
+	logger       logger.Logger
+}
+
+var (
+	ErrCategoryNotFound      = apperror.New(apperror.ErrorTypeNotFound, "Category not found")
+	ErrCategoryUserNotFound  = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	ErrCategoryAlreadyExists = apperror.New(apperror.ErrorTypeUnprocessable, "A category with this name already exists")
+)
+
+func (s *categoryService) CreateCategory(userID string, categoryType entities.TransactionType, input CategoryInput) (*entities.Category, error) {
+	categories, err := s.userCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := entities.NewCategory(userID, input.Name, categoryType, input.Icon, input.Color)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if input.ParentID != "" {
+		parent := findCategory(categories, input.ParentID)
+		if parent == nil {
+			return nil, ErrCategoryNotFound
+		}
+		if err := category.SetParent(parent); err != nil {
+			return nil, s.domainError(err)
+		}
+	}
+
+	if hasSibling(categories, category) {
+		return nil, ErrCategoryAlreadyExists
+	}
+
+	createdCategory, err := s.categoryRepo.CreateCategory(category)
+	if err != nil {
+		s.logger.Error(err, "Failed to create category", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdCategory, nil
+}
+
+func (s *categoryService) ListCategories(
+	userID string,
+	filter repositories.CategoryFilter,
+	page query.Page,
+) ([]*entities.Category, *query.Cursor, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, nil, err
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	categories, next, err := s.categoryRepo.FindCategoryPageByUserID(userID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list categories", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return categories, next, nil
+}
+
+func (s *categoryService) UpdateCategory(userID, categoryID string, input CategoryInput) (*entities.Category, error) {
+	categories, err := s.userCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	category := findCategory(categories, categoryID)
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	if err := category.Update(input.Name, input.Icon, input.Color); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if input.ParentID != category.ParentID {
+		var parent *entities.Category
+		if input.ParentID != "" {
+			if parent = findCategory(categories, input.ParentID); parent == nil {
+				return nil, ErrCategoryNotFound
+			}
+			if len(childCategories(categories, category.ID)) > 0 {
+				return nil, s.domainError(entities.ErrInvalidCategoryParent)
+			}
+		}
+		if err := category.SetParent(parent); err != nil {
+			return nil, s.domainError(err)
+		}
+	}
+
+	if hasSibling(categories, category) {
+		return nil, ErrCategoryAlreadyExists
+	}
+
+	updatedCategory, err := s.categoryRepo.UpdateCategory(category)
+	if err != nil {
+		s.logger.Error(err, "Failed to update category", map[string]interface{}{
+			"category_id": categoryID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return updatedCategory, nil
+}
+
+// MergeCategories moves every transaction and child of source into target
+// and removes source.
+func (s *categoryService) MergeCategories(userID, sourceID, targetID string) error {
+	categories, err := s.userCategories(userID)
+	if err != nil {
+		return err
+	}
+
+	source := findCategory(categories, sourceID)
+	target := findCategory(categories, targetID)
+	if source == nil || target == nil {
+		return ErrCategoryNotFound
+	}
+
+	if err := source.CanMergeInto(target); err != nil {
+		return s.domainError(err)
+	}
+
+	// Children of source become children of target, which must therefore be
+	// top level to keep the one-level nesting
+	children := childCategories(categories, source.ID)
+	if !target.IsTopLevel() && len(children) > 0 {
+		return s.domainError(entities.ErrInvalidCategoryParent)
+	}
+
+	for _, child := range children {
+		for _, sibling := range childCategories(categories, target.ID) {
+			if strings.EqualFold(child.Name, sibling.Name) {
+				return apperror.New(apperror.ErrorTypeUnprocessable, "Target already has a child category with this name; rename or merge it first").
+					AddContext("name", child.Name)
+			}
+		}
+	}
+
+	if err := s.categoryRepo.MergeCategories(source.ID, target.ID); err != nil {
+		s.logger.Error(err, "Failed to merge categories", map[string]interface{}{
+			"source_id": sourceID,
+			"target_id": targetID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// DeleteCategory removes an unused category. Categories with transactions or
+// children can only be deleted by reassigning them to another category.
+func (s *categoryService) DeleteCategory(userID, categoryID, reassignToID string) error {
+	if reassignToID != "" {
+		return s.MergeCategories(userID, categoryID, reassignToID)
+	}
+
+	categories, err := s.userCategories(userID)
+	if err != nil {
+		return err
+	}
+
+	category := findCategory(categories, categoryID)
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+
+	transactionCount, err := s.categoryRepo.CountTransactionsByCategoryID(categoryID)
+	if err != nil {
+		s.logger.Error(err, "Failed to count category transactions", map[string]interface{}{
+			"category_id": categoryID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	childCount := len(childCategories(categories, categoryID))
+	if transactionCount > 0 || childCount > 0 {
+		return apperror.New(apperror.ErrorTypeUnprocessable, "Category is in use; reassign it to another category to delete it").
+			AddContext("transactions", transactionCount).
+			AddContext("children", childCount)
+	}
+
+	if err := s.categoryRepo.DeleteCategory(categoryID); err != nil {
+		s.logger.Error(err, "Failed to delete category", map[string]interface{}{
+			"category_id": categoryID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+func (s *categoryService) checkUser(userID string) error {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return ErrCategoryUserNotFound
+	}
+	return nil
+}
+
+// userCategories loads every category of an existing user.
+func (s *categoryService) userCategories(userID string) ([]*entities.Category, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.FindCategoriesByUserID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list categories", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return categories, nil
+}
+
+func (s *categoryService) domainError(err error) error {
+	if errors.Is(err, entities.ErrInvalidCategoryParent) || errors.Is(err, entities.ErrCategoryTypeMismatch) {
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+	return apperror.Wrap(apperror.ErrorTypeValidation, err)
+}
+
+func findCategory(categories []*entities.Category, id string) *entities.Category {
+	for _, category := range categories {
+		if category.ID == id {
+			return category
+		}
+	}
+	return nil
+}
+
+func childCategories(categories []*entities.Category, parentID string) []*entities.Category {
+	var children []*entities.Category
+	for _, category := range categories {
+		if category.ParentID == parentID {
+			children = append(children, category)
+		}
+	}
+	return children
+}
+
+// hasSibling reports whether another category with the same type and parent
+// already uses the name, ignoring case.
+func hasSibling(categories []*entities.Category, category *entities.Category) bool {
+	for _, other := range categories {
+		if other.ID != category.ID &&
+			other.Type == category.Type &&
+			other.ParentID == category.ParentID &&
+			strings.EqualFold(other.Name, category.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewCategoryService(
+	categoryRepo repositories.CategoryRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) CategoryService {
+	return &categoryService{
+		categoryRepo: categoryRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,384 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) CreateCategory(category *entities.Category) (*entities.Category, error) {
+	args := m.Called(category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindCategoryByID(id string) (*entities.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindCategoriesByUserID(userID string) ([]*entities.Category, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindCategoryPageByUserID(
+	userID string,
+	filter repositories.CategoryFilter,
+	page query.Page,
+) ([]*entities.Category, *query.Cursor, error) {
+	args := m.Called(userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.Category), next, args.Error(2)
+}
+
+func (m *MockCategoryRepository) UpdateCategory(category *entities.Category) (*entities.Category, error) {
+	args := m.Called(category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) CountTransactionsByCategoryID(categoryID string) (int, error) {
+	args := m.Called(categoryID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) MergeCategories(sourceID, targetID string) error {
+	args := m.Called(sourceID, targetID)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) DeleteCategory(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// newTestCategories builds the categories of "user-id": Food with the child
+// Restaurants, a top-level Transport, an income category Salary, and the
+// Salary child Bonus.
+func newTestCategories() []*entities.Category {
+	food := &entities.Category{ID: "food-id", UserID: "user-id", Name: "Food", Type: entities.TransactionTypeExpense}
+	restaurants := &entities.Category{ID: "restaurants-id", UserID: "user-id", ParentID: "food-id", Name: "Restaurants", Type: entities.TransactionTypeExpense}
+	transport := &entities.Category{ID: "transport-id", UserID: "user-id", Name: "Transport", Type: entities.TransactionTypeExpense}
+	salary := &entities.Category{ID: "salary-id", UserID: "user-id", Name: "Salary", Type: entities.TransactionTypeIncome}
+	bonus := &entities.Category{ID: "bonus-id", UserID: "user-id", ParentID: "salary-id", Name: "Bonus", Type: entities.TransactionTypeIncome}
+	return []*entities.Category{food, restaurants, transport, salary, bonus}
+}
+
+func newCategoryService(categoryRepo *MockCategoryRepository) services.CategoryService {
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+	categoryRepo.On("FindCategoriesByUserID", "user-id").Return(newTestCategories(), nil)
+
+	logger := mocks.NewMockLogger()
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return services.NewCategoryService(categoryRepo, userRepo, logger)
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	tests := []struct {
+		name         string
+		categoryType entities.TransactionType
+		input        services.CategoryInput
+		wantErr      bool
+		errType      apperror.ErrorType
+	}{
+		{
+			name:         "top level category",
+			categoryType: entities.TransactionTypeExpense,
+			input:        services.CategoryInput{Name: "Pets", Icon: "paw", Color: "#795548"},
+		},
+		{
+			name:         "child category",
+			categoryType: entities.TransactionTypeExpense,
+			input:        services.CategoryInput{Name: "Delivery", ParentID: "food-id"},
+		},
+		{
+			name:         "same name under another parent",
+			categoryType: entities.TransactionTypeExpense,
+			input:        services.CategoryInput{Name: "Restaurants", ParentID: "transport-id"},
+		},
+		{
+			name:         "duplicate sibling name",
+			categoryType: entities.TransactionTypeExpense,
+			input:        services.CategoryInput{Name: "restaurants", ParentID: "food-id"},
+			wantErr:      true,
+			errType:      apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:         "too deep",
+			categoryType: entities.TransactionTypeExpense,
+			input:        services.CategoryInput{Name: "Sushi", ParentID: "restaurants-id"},
+			wantErr:      true,
+			errType:      apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:         "parent of another type",
+			categoryType: entities.TransactionTypeIncome,
+			input:        services.CategoryInput{Name: "Refunds", ParentID: "food-id"},
+			wantErr:      true,
+			errType:      apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:         "unknown parent",
+			categoryType: entities.TransactionTypeExpense,
+			input:        services.CategoryInput{Name: "Sushi", ParentID: "missing-id"},
+			wantErr:      true,
+			errType:      apperror.ErrorTypeNotFound,
+		},
+		{
+			name:         "invalid colour",
+			categoryType: entities.TransactionTypeExpense,
+			input:        services.CategoryInput{Name: "Pets", Color: "brown"},
+			wantErr:      true,
+			errType:      apperror.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockCategoryRepository)
+			if !tt.wantErr {
+				repo.On("CreateCategory", mock.MatchedBy(func(c *entities.Category) bool {
+					return c.UserID == "user-id" && c.ParentID == tt.input.ParentID
+				})).Return(&entities.Category{ID: "new-id"}, nil)
+			}
+
+			service := newCategoryService(repo)
+			category, err := service.CreateCategory("user-id", tt.categoryType, tt.input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, category)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "new-id", category.ID)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unknown user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "missing-id").Return(nil, nil)
+
+		service := services.NewCategoryService(new(MockCategoryRepository), userRepo, mocks.NewMockLogger())
+		_, err := service.CreateCategory("missing-id", entities.TransactionTypeExpense, services.CategoryInput{Name: "Pets"})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+}
+
+func TestCategoryService_ListCategories(t *testing.T) {
+	page, err := repositories.CategorySorts.NewPage(repositories.DefaultCategorySort, nil, 5)
+	require.NoError(t, err)
+
+	t.Run("lists a page of the user categories", func(t *testing.T) {
+		filter := repositories.CategoryFilter{Query: "food", Types: []entities.TransactionType{entities.TransactionTypeExpense}}
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("FindCategoryPageByUserID", "user-id", filter, page).Return(newTestCategories()[:1], nil, nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+
+		service := services.NewCategoryService(categoryRepo, userRepo, mocks.NewMockLogger())
+		categories, next, err := service.ListCategories("user-id", repositories.CategoryFilter{
+			Query: "  food ",
+			Types: []entities.TransactionType{entities.TransactionTypeExpense},
+		}, page)
+
+		assert.NoError(t, err)
+		assert.Len(t, categories, 1)
+		assert.Nil(t, next)
+		categoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "missing-id").Return(nil, nil)
+
+		service := services.NewCategoryService(categoryRepo, userRepo, mocks.NewMockLogger())
+		_, _, err := service.ListCategories("missing-id", repositories.CategoryFilter{}, page)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		categoryRepo.AssertNotCalled(t, "FindCategoryPageByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	tests := []struct {
+		name       string
+		categoryID string
+		input      services.CategoryInput
+		wantErr    bool
+		errType    apperror.ErrorType
+	}{
+		{name: "rename", categoryID: "transport-id", input: services.CategoryInput{Name: "Transportation"}},
+		{name: "move under parent", categoryID: "transport-id", input: services.CategoryInput{Name: "Transport", ParentID: "food-id"}},
+		{name: "move to top level", categoryID: "restaurants-id", input: services.CategoryInput{Name: "Restaurants"}},
+		{name: "parent with children cannot be nested", categoryID: "food-id", input: services.CategoryInput{Name: "Food", ParentID: "transport-id"}, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "own parent", categoryID: "transport-id", input: services.CategoryInput{Name: "Transport", ParentID: "transport-id"}, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "name taken", categoryID: "transport-id", input: services.CategoryInput{Name: "food"}, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "unknown category", categoryID: "missing-id", input: services.CategoryInput{Name: "Pets"}, wantErr: true, errType: apperror.ErrorTypeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockCategoryRepository)
+			if !tt.wantErr {
+				repo.On("UpdateCategory", mock.MatchedBy(func(c *entities.Category) bool {
+					return c.ID == tt.categoryID && c.ParentID == tt.input.ParentID && c.Name == tt.input.Name
+				})).Return(&entities.Category{ID: tt.categoryID}, nil)
+			}
+
+			service := newCategoryService(repo)
+			_, err := service.UpdateCategory("user-id", tt.categoryID, tt.input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryService_MergeCategories(t *testing.T) {
+	tests := []struct {
+		name     string
+		sourceID string
+		targetID string
+		wantErr  bool
+		errType  apperror.ErrorType
+	}{
+		{name: "merge child into top level", sourceID: "restaurants-id", targetID: "transport-id"},
+		{name: "merge parent into top level", sourceID: "food-id", targetID: "transport-id"},
+		{name: "merge leaf into child", sourceID: "transport-id", targetID: "restaurants-id"},
+		{name: "merge parent into its own child", sourceID: "food-id", targetID: "restaurants-id", wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "merge across types", sourceID: "transport-id", targetID: "salary-id", wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "merge into itself", sourceID: "transport-id", targetID: "transport-id", wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "unknown target", sourceID: "transport-id", targetID: "missing-id", wantErr: true, errType: apperror.ErrorTypeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockCategoryRepository)
+			if !tt.wantErr {
+				repo.On("MergeCategories", tt.sourceID, tt.targetID).Return(nil)
+			}
+
+			service := newCategoryService(repo)
+			err := service.MergeCategories("user-id", tt.sourceID, tt.targetID)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("children clash with the target's children", func(t *testing.T) {
+		categories := append(newTestCategories(), &entities.Category{
+			ID: "taxi-id", UserID: "user-id", ParentID: "transport-id", Name: "restaurants", Type: entities.TransactionTypeExpense,
+		})
+		repo := new(MockCategoryRepository)
+		repo.On("FindCategoriesByUserID", "user-id").Return(categories, nil)
+
+		service := newCategoryService(repo)
+		err := service.MergeCategories("user-id", "food-id", "transport-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		repo.AssertNotCalled(t, "MergeCategories", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("MergeCategories", "restaurants-id", "transport-id").Return(errors.New("database error"))
+
+		service := newCategoryService(repo)
+		err := service.MergeCategories("user-id", "restaurants-id", "transport-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	t.Run("unused category is deleted", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("CountTransactionsByCategoryID", "transport-id").Return(0, nil)
+		repo.On("DeleteCategory", "transport-id").Return(nil)
+
+		service := newCategoryService(repo)
+		err := service.DeleteCategory("user-id", "transport-id", "")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("category with transactions is blocked", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("CountTransactionsByCategoryID", "transport-id").Return(3, nil)
+
+		service := newCategoryService(repo)
+		err := service.DeleteCategory("user-id", "transport-id", "")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		repo.AssertNotCalled(t, "DeleteCategory", mock.Anything)
+	})
+
+	t.Run("category with children is blocked", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("CountTransactionsByCategoryID", "food-id").Return(0, nil)
+
+		service := newCategoryService(repo)
+		err := service.DeleteCategory("user-id", "food-id", "")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		repo.AssertNotCalled(t, "DeleteCategory", mock.Anything)
+	})
+
+	t.Run("category in use is reassigned", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("MergeCategories", "restaurants-id", "transport-id").Return(nil)
+
+		service := newCategoryService(repo)
+		err := service.DeleteCategory("user-id", "restaurants-id", "transport-id")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "CountTransactionsByCategoryID", mock.Anything)
+	})
+}
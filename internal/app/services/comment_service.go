@@ -0,0 +1,296 @@
+package services
+
+import (
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+// CommentInput is a new comment; ParentID, when set, is the comment of the
+// same transaction it replies to.
+type CommentInput struct {
+	ParentID string
+	Body     string
+}
+
+type CommentService interface {
+	// CreateComment posts a comment on the transaction and notifies the
+	// members it mentions.
+	CreateComment(walletID, actorID, transactionID string, input CommentInput) (*entities.Comment, error)
+	ListComments(walletID, actorID, transactionID string) ([]*entities.Comment, error)
+	// UpdateComment lets the author change the body, keeping the previous
+	// one in the edit history, and notifies the members newly mentioned.
+	UpdateComment(walletID, actorID, transactionID, commentID, body string) (*entities.Comment, error)
+	// DeleteComment lets the author or a wallet owner delete a comment.
+	DeleteComment(walletID, actorID, transactionID, commentID string) error
+	ListCommentEdits(walletID, actorID, transactionID, commentID string) ([]*entities.CommentEdit, error)
+}
+
+type commentService struct {
+	commentRepo      repositories.CommentRepository
+	transactionRepo  repositories.TransactionRepository
+	walletRepo       repositories.WalletRepository
+	notificationRepo repositories.NotificationRepository
+	logger           logger.Logger
+}
+
+var (
+	ErrCommentNotFound        = apperror.New(apperror.ErrorTypeNotFound, "Comment not found")
+	ErrCommentEditForbidden   = apperror.New(apperror.ErrorTypeForbidden, "Only the author can edit a comment")
+	ErrCommentDeleteForbidden = apperror.New(apperror.ErrorTypeForbidden, "Only the author or a wallet owner can delete a comment")
+)
+
+func (s *commentService) CreateComment(
+	walletID string,
+	actorID string,
+	transactionID string,
+	input CommentInput,
+) (*entities.Comment, error) {
+	if _, err := s.findTransaction(walletID, actorID, transactionID); err != nil {
+		return nil, err
+	}
+
+	comment, err := entities.NewComment(walletID, transactionID, input.ParentID, actorID, input.Body)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err).AddContext("field", "body")
+	}
+
+	if comment.ParentID != "" {
+		parent, err := s.commentRepo.FindCommentByID(comment.ParentID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find comment", map[string]interface{}{
+				"comment_id": comment.ParentID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if parent == nil || parent.TransactionID != transactionID {
+			return nil, apperror.New(apperror.ErrorTypeValidation, "Parent comment not found").
+				AddContext("field", "parent_id")
+		}
+	}
+
+	mentioned, err := s.checkMentions(comment, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.CreateComment(comment); err != nil {
+		s.logger.Error(err, "Failed to create comment", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	s.notifyMentioned(comment, mentioned)
+	return comment, nil
+}
+
+func (s *commentService) ListComments(walletID, actorID, transactionID string) ([]*entities.Comment, error) {
+	if _, err := s.findTransaction(walletID, actorID, transactionID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.FindCommentsByTransactionID(transactionID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list comments", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return comments, nil
+}
+
+func (s *commentService) UpdateComment(
+	walletID string,
+	actorID string,
+	transactionID string,
+	commentID string,
+	body string,
+) (*entities.Comment, error) {
+	comment, _, err := s.findComment(walletID, actorID, transactionID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.AuthorID != actorID {
+		return nil, ErrCommentEditForbidden
+	}
+
+	previousMentions := comment.Mentions()
+	edit, err := comment.Edit(body)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err).AddContext("field", "body")
+	}
+	if edit == nil {
+		return comment, nil
+	}
+
+	mentioned, err := s.checkMentions(comment, previousMentions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.UpdateComment(comment, edit); err != nil {
+		s.logger.Error(err, "Failed to update comment", map[string]interface{}{
+			"comment_id": commentID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	s.notifyMentioned(comment, mentioned)
+	return comment, nil
+}
+
+func (s *commentService) DeleteComment(walletID, actorID, transactionID, commentID string) error {
+	comment, member, err := s.findComment(walletID, actorID, transactionID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.AuthorID != actorID && !member.CanManageMembers() {
+		return ErrCommentDeleteForbidden
+	}
+
+	comment.Delete()
+	if err := s.commentRepo.DeleteComment(comment); err != nil {
+		s.logger.Error(err, "Failed to delete comment", map[string]interface{}{
+			"comment_id": commentID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+func (s *commentService) ListCommentEdits(walletID, actorID, transactionID, commentID string) ([]*entities.CommentEdit, error) {
+	if _, _, err := s.findComment(walletID, actorID, transactionID, commentID); err != nil {
+		return nil, err
+	}
+
+	edits, err := s.commentRepo.FindCommentEdits(commentID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list comment edits", map[string]interface{}{
+			"comment_id": commentID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return edits, nil
+}
+
+// checkMentions makes sure everyone the comment mentions is a member of its
+// wallet and returns those to notify: the mentioned members other than the
+// author who are not in already.
+func (s *commentService) checkMentions(comment *entities.Comment, already []string) ([]string, error) {
+	notified := make(map[string]bool, len(already)+1)
+	for _, userID := range already {
+		notified[userID] = true
+	}
+	notified[comment.AuthorID] = true
+
+	mentioned := make([]string, 0)
+	for _, userID := range comment.Mentions() {
+		member, err := s.walletRepo.FindWalletMember(comment.WalletID, userID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find wallet member", map[string]interface{}{
+				"wallet_id": comment.WalletID,
+				"user_id":   userID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if member == nil {
+			return nil, apperror.New(apperror.ErrorTypeValidation, "Only wallet members can be mentioned").
+				AddContext("field", "body").
+				AddContext("user_id", userID)
+		}
+
+		if !notified[userID] {
+			mentioned = append(mentioned, userID)
+		}
+	}
+	return mentioned, nil
+}
+
+func (s *commentService) notifyMentioned(comment *entities.Comment, userIDs []string) {
+	notifications := make([]*entities.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notification, err := entities.NewMentionNotification(userID, comment)
+		if err != nil {
+			s.logger.Error(err, "Invalid mention notification", map[string]interface{}{
+				"comment_id": comment.ID,
+			})
+			continue
+		}
+		notifications = append(notifications, notification)
+	}
+	notify(s.notificationRepo, s.logger, notifications)
+}
+
+// findComment returns a comment that is not deleted only when it belongs to
+// the transaction, along with the membership of the actor.
+func (s *commentService) findComment(
+	walletID string,
+	actorID string,
+	transactionID string,
+	commentID string,
+) (*entities.Comment, *entities.WalletMember, error) {
+	member, err := s.findTransaction(walletID, actorID, transactionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment, err := s.commentRepo.FindCommentByID(commentID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find comment", map[string]interface{}{
+			"comment_id": commentID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if comment == nil || comment.TransactionID != transactionID {
+		return nil, nil, ErrCommentNotFound
+	}
+
+	return comment, member, nil
+}
+
+// findTransaction checks the actor can access the wallet and the
+// transaction belongs to it, and returns the membership of the actor.
+func (s *commentService) findTransaction(walletID, actorID, transactionID string) (*entities.WalletMember, error) {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionRepo.FindTransactionByID(transactionID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find transaction", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if transaction == nil || transaction.WalletID != walletID {
+		return nil, ErrTransactionNotFound
+	}
+
+	return member, nil
+}
+
+func NewCommentService(
+	commentRepo repositories.CommentRepository,
+	transactionRepo repositories.TransactionRepository,
+	walletRepo repositories.WalletRepository,
+	notificationRepo repositories.NotificationRepository,
+	logger logger.Logger,
+) CommentService {
+	return &commentService{
+		commentRepo:      commentRepo,
+		transactionRepo:  transactionRepo,
+		walletRepo:       walletRepo,
+		notificationRepo: notificationRepo,
+		logger:           logger,
+	}
+}
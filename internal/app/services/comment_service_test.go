@@ -0,0 +1,197 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mentionedMemberID is a wallet member written the way comments mention
+// users.
+const mentionedMemberID = "6f1c2d3e-4b5a-4c6d-8e7f-9a0b1c2d3e4f"
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) CreateComment(comment *entities.Comment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) FindCommentByID(id string) (*entities.Comment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindCommentsByTransactionID(transactionID string) ([]*entities.Comment, error) {
+	args := m.Called(transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) UpdateComment(comment *entities.Comment, edit *entities.CommentEdit) error {
+	args := m.Called(comment, edit)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) DeleteComment(comment *entities.Comment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) FindCommentEdits(commentID string) ([]*entities.CommentEdit, error) {
+	args := m.Called(commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.CommentEdit), args.Error(1)
+}
+
+// newCommentTransactionRepository returns a transaction repository holding
+// the test transaction of "wallet-id".
+func newCommentTransactionRepository(t *testing.T) *MockTransactionRepository {
+	repo := new(MockTransactionRepository)
+	repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil).Maybe()
+	return repo
+}
+
+func newTestComment(t *testing.T, authorID, body string) *entities.Comment {
+	t.Helper()
+	comment, err := entities.NewComment("wallet-id", "transaction-id", "", authorID, body)
+	require.NoError(t, err)
+	comment.ID = "comment-id"
+	return comment
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	t.Run("notifies the mentioned members", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		commentRepo.On("CreateComment", mock.MatchedBy(func(comment *entities.Comment) bool {
+			return comment.AuthorID == "user-id" && comment.TransactionID == "transaction-id"
+		})).Return(nil)
+		notificationRepo := new(MockNotificationRepository)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].UserID == mentionedMemberID &&
+				notifications[0].Type == entities.NotificationMention
+		})).Return(nil)
+
+		service := services.NewCommentService(commentRepo, newCommentTransactionRepository(t), newWalletRepositoryFor("user-id", mentionedMemberID), notificationRepo, mocks.NewMockLogger())
+		comment, err := service.CreateComment("wallet-id", "user-id", "transaction-id", services.CommentInput{
+			Body: "@" + mentionedMemberID + " what was this charge?",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "wallet-id", comment.WalletID)
+		commentRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("mention of a non-member", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+
+		service := services.NewCommentService(commentRepo, newCommentTransactionRepository(t), newWalletRepository(), new(MockNotificationRepository), mocks.NewMockLogger())
+		_, err := service.CreateComment("wallet-id", "user-id", "transaction-id", services.CommentInput{
+			Body: "@" + mentionedMemberID + " what was this charge?",
+		})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		commentRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+	})
+
+	t.Run("reply to a comment of another transaction", func(t *testing.T) {
+		parent := newTestComment(t, "user-id", "Pizza")
+		parent.TransactionID = "other-transaction-id"
+		commentRepo := new(MockCommentRepository)
+		commentRepo.On("FindCommentByID", "comment-id").Return(parent, nil)
+
+		service := services.NewCommentService(commentRepo, newCommentTransactionRepository(t), newWalletRepository(), new(MockNotificationRepository), mocks.NewMockLogger())
+		_, err := service.CreateComment("wallet-id", "user-id", "transaction-id", services.CommentInput{
+			ParentID: "comment-id",
+			Body:     "Thanks!",
+		})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		commentRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		service := services.NewCommentService(new(MockCommentRepository), newCommentTransactionRepository(t), newWalletRepository(), new(MockNotificationRepository), mocks.NewMockLogger())
+		_, err := service.CreateComment("wallet-id", "stranger-id", "transaction-id", services.CommentInput{Body: "Hi"})
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+	})
+}
+
+func TestCommentService_UpdateComment(t *testing.T) {
+	t.Run("keeps the previous body and notifies new mentions only", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		commentRepo.On("FindCommentByID", "comment-id").Return(newTestComment(t, "user-id", "Pizza"), nil)
+		commentRepo.On("UpdateComment", mock.MatchedBy(func(comment *entities.Comment) bool {
+			return comment.IsEdited()
+		}), mock.MatchedBy(func(edit *entities.CommentEdit) bool {
+			return edit.Body == "Pizza"
+		})).Return(nil)
+		notificationRepo := new(MockNotificationRepository)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].UserID == mentionedMemberID
+		})).Return(nil)
+
+		service := services.NewCommentService(commentRepo, newCommentTransactionRepository(t), newWalletRepositoryFor("user-id", mentionedMemberID), notificationRepo, mocks.NewMockLogger())
+		comment, err := service.UpdateComment("wallet-id", "user-id", "transaction-id", "comment-id", "Pizza, right @"+mentionedMemberID+"?")
+
+		require.NoError(t, err)
+		assert.Contains(t, comment.Body, "right")
+		commentRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("someone else's comment", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		commentRepo.On("FindCommentByID", "comment-id").Return(newTestComment(t, "owner-id", "Pizza"), nil)
+
+		service := services.NewCommentService(commentRepo, newCommentTransactionRepository(t), newWalletRepository(), new(MockNotificationRepository), mocks.NewMockLogger())
+		_, err := service.UpdateComment("wallet-id", "user-id", "transaction-id", "comment-id", "Burgers")
+
+		assert.ErrorIs(t, err, services.ErrCommentEditForbidden)
+		commentRepo.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCommentService_DeleteComment(t *testing.T) {
+	t.Run("owner deletes a member's comment", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		commentRepo.On("FindCommentByID", "comment-id").Return(newTestComment(t, "member-id", "Pizza"), nil)
+		commentRepo.On("DeleteComment", mock.MatchedBy(func(comment *entities.Comment) bool {
+			return comment.IsDeleted && !comment.DeletedAt.IsZero()
+		})).Return(nil)
+
+		service := services.NewCommentService(commentRepo, newCommentTransactionRepository(t), newTestWalletRepository(), new(MockNotificationRepository), mocks.NewMockLogger())
+		err := service.DeleteComment("wallet-id", "owner-id", "transaction-id", "comment-id")
+
+		assert.NoError(t, err)
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("member deletes someone else's comment", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		commentRepo.On("FindCommentByID", "comment-id").Return(newTestComment(t, "owner-id", "Pizza"), nil)
+
+		service := services.NewCommentService(commentRepo, newCommentTransactionRepository(t), newTestWalletRepository(), new(MockNotificationRepository), mocks.NewMockLogger())
+		err := service.DeleteComment("wallet-id", "member-id", "transaction-id", "comment-id")
+
+		assert.ErrorIs(t, err, services.ErrCommentDeleteForbidden)
+		commentRepo.AssertNotCalled(t, "DeleteComment", mock.Anything)
+	})
+}
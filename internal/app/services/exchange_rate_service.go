@@ -0,0 +1,213 @@
+package services
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/providers"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// pivotCurrency is used to derive cross rates, as reference rates are
+// published against it (e.g. USD -> BRL through EUR -> USD and EUR -> BRL).
+const pivotCurrency = "EUR"
+
+type ExchangeRateService interface {
+	ImportRates(rates []*entities.ExchangeRate) (int, error)
+	SyncRates(date time.Time) (int, error)
+	Convert(amount money.Money, currencyCode string, date time.Time) (*entities.Conversion, error)
+	ConvertTotal(amounts []money.Money, currencyCode string, date time.Time) (money.Money, []*entities.Conversion, error)
+}
+
+type exchangeRateService struct {
+	rateRepo repositories.ExchangeRateRepository
+	provider providers.ExchangeRateProvider
+	logger   logger.Logger
+}
+
+func (s *exchangeRateService) ImportRates(rates []*entities.ExchangeRate) (int, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	if err := s.rateRepo.SaveRates(rates); err != nil {
+		s.logger.Error(err, "Failed to save exchange rates", map[string]interface{}{
+			"count": len(rates),
+		})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return len(rates), nil
+}
+
+func (s *exchangeRateService) SyncRates(date time.Time) (int, error) {
+	rates, err := s.provider.FetchRates(date)
+	if err != nil {
+		s.logger.Error(err, "Failed to fetch exchange rates", map[string]interface{}{
+			"provider": s.provider.Name(),
+			"date":     date,
+		})
+		return 0, apperror.Wrap(apperror.ErrorTypeExternalAPI, err)
+	}
+
+	return s.ImportRates(rates)
+}
+
+func (s *exchangeRateService) Convert(amount money.Money, currencyCode string, date time.Time) (*entities.Conversion, error) {
+	target, err := money.LookupCurrency(currencyCode)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if amount.Currency().Code == target.Code {
+		return &entities.Conversion{
+			Original:  amount,
+			Converted: amount,
+			Rate:      big.NewRat(1, 1),
+			RateDate:  date,
+		}, nil
+	}
+
+	rate, err := s.findRate(amount.Currency().Code, target.Code, date)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := amount.Convert(target.Code, rate.Rate, money.RoundHalfEven)
+	if err != nil {
+		s.logger.Error(err, "Failed to convert amount", map[string]interface{}{
+			"from": amount.Currency().Code,
+			"to":   target.Code,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+
+	return &entities.Conversion{
+		Original:  amount,
+		Converted: converted,
+		Rate:      rate.Rate,
+		RateDate:  rate.Date,
+		Source:    rate.Source,
+	}, nil
+}
+
+// ConvertTotal converts every amount into currencyCode and sums them. The
+// individual conversions are returned so callers can show which rate was
+// applied to each figure.
+func (s *exchangeRateService) ConvertTotal(amounts []money.Money, currencyCode string, date time.Time) (money.Money, []*entities.Conversion, error) {
+	total, err := money.Zero(currencyCode)
+	if err != nil {
+		return money.Money{}, nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	conversions := make([]*entities.Conversion, 0, len(amounts))
+	for _, amount := range amounts {
+		conversion, err := s.Convert(amount, currencyCode, date)
+		if err != nil {
+			return money.Money{}, nil, err
+		}
+
+		if total, err = total.Add(conversion.Converted); err != nil {
+			return money.Money{}, nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		conversions = append(conversions, conversion)
+	}
+
+	return total, conversions, nil
+}
+
+// checkCurrency validates an optional currency to present figures in.
+func checkCurrency(currencyCode string) error {
+	if currencyCode == "" {
+		return nil
+	}
+
+	if _, err := money.LookupCurrency(currencyCode); err != nil {
+		return apperror.New(apperror.ErrorTypeValidation, "Currency is not supported").
+			AddContext("field", "currency")
+	}
+	return nil
+}
+
+// findRate looks for a direct rate, then an inverse one, then a cross rate
+// through the pivot currency.
+func (s *exchangeRateService) findRate(from, to string, date time.Time) (*entities.ExchangeRate, error) {
+	rate, err := s.lookup(from, to, date)
+	if err != nil || rate != nil {
+		return rate, err
+	}
+
+	if from != pivotCurrency && to != pivotCurrency {
+		fromPivot, err := s.lookup(pivotCurrency, from, date)
+		if err != nil {
+			return nil, err
+		}
+		toPivot, err := s.lookup(pivotCurrency, to, date)
+		if err != nil {
+			return nil, err
+		}
+
+		if fromPivot != nil && toPivot != nil {
+			cross := new(big.Rat).Quo(toPivot.Rate, fromPivot.Rate)
+			rateDate := fromPivot.Date
+			if toPivot.Date.Before(rateDate) {
+				rateDate = toPivot.Date
+			}
+			return &entities.ExchangeRate{
+				BaseCurrency:  fromPivot.QuoteCurrency,
+				QuoteCurrency: toPivot.QuoteCurrency,
+				Rate:          cross,
+				Date:          rateDate,
+				Source:        fromPivot.Source,
+			}, nil
+		}
+	}
+
+	s.logger.Warn("Exchange rate not found", map[string]interface{}{
+		"from": from,
+		"to":   to,
+		"date": date,
+	})
+	return nil, apperror.New(apperror.ErrorTypeNotFound, "Exchange rate not found").
+		AddContext("from", from).
+		AddContext("to", to).
+		AddContext("date", date.Format("2006-01-02"))
+}
+
+func (s *exchangeRateService) lookup(from, to string, date time.Time) (*entities.ExchangeRate, error) {
+	rate, err := s.rateRepo.FindRate(from, to, date)
+	if err != nil {
+		s.logger.Error(err, "Failed to find exchange rate", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if rate != nil {
+		return rate, nil
+	}
+
+	inverse, err := s.rateRepo.FindRate(to, from, date)
+	if err != nil {
+		s.logger.Error(err, "Failed to find exchange rate", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if inverse != nil {
+		return inverse.Inverse(), nil
+	}
+
+	return nil, nil
+}
+
+func NewExchangeRateService(
+	rateRepo repositories.ExchangeRateRepository,
+	provider providers.ExchangeRateProvider,
+	logger logger.Logger,
+) ExchangeRateService {
+	return &exchangeRateService{
+		rateRepo: rateRepo,
+		provider: provider,
+		logger:   logger,
+	}
+}
@@ -0,0 +1,267 @@
+package services_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/money"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockExchangeRateRepository struct {
+	mock.Mock
+}
+
+func (m *MockExchangeRateRepository) SaveRates(rates []*entities.ExchangeRate) error {
+	args := m.Called(rates)
+	return args.Error(0)
+}
+
+func (m *MockExchangeRateRepository) FindRate(baseCurrency, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error) {
+	args := m.Called(baseCurrency, quoteCurrency, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ExchangeRate), args.Error(1)
+}
+
+type MockExchangeRateProvider struct {
+	mock.Mock
+}
+
+func (m *MockExchangeRateProvider) Name() string {
+	return "MOCK"
+}
+
+func (m *MockExchangeRateProvider) FetchRates(date time.Time) ([]*entities.ExchangeRate, error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.ExchangeRate), args.Error(1)
+}
+
+func newRate(t *testing.T, base, quote, value string, date time.Time) *entities.ExchangeRate {
+	t.Helper()
+	rate, err := money.ParseRate(value)
+	require.NoError(t, err)
+	exchangeRate, err := entities.NewExchangeRate(base, quote, rate, date, "ECB")
+	require.NoError(t, err)
+	return exchangeRate
+}
+
+func newMoney(t *testing.T, value, currency string) money.Money {
+	t.Helper()
+	m, err := money.Parse(value, currency)
+	require.NoError(t, err)
+	return m
+}
+
+// newRateService returns an exchange rate service that only knows rates.
+func newRateService(rates ...*entities.ExchangeRate) services.ExchangeRateService {
+	repo := new(MockExchangeRateRepository)
+	for _, rate := range rates {
+		repo.On("FindRate", rate.BaseCurrency.Code, rate.QuoteCurrency.Code, mock.Anything).Return(rate, nil).Maybe()
+	}
+	repo.On("FindRate", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	logger := mocks.NewMockLogger()
+	logger.On("Warn", mock.Anything, mock.Anything).Return().Maybe()
+	return services.NewExchangeRateService(repo, new(MockExchangeRateProvider), logger)
+}
+
+func TestExchangeRateService_Convert(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		amount    money.Money
+		to        string
+		mockSetup func(*MockExchangeRateRepository, *mocks.MockLogger)
+		want      string
+		wantRate  *big.Rat
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:   "same currency is not converted",
+			amount: newMoney(t, "10.00", "BRL"),
+			to:     "BRL",
+			mockSetup: func(r *MockExchangeRateRepository, l *mocks.MockLogger) {
+			},
+			want:     "10.00",
+			wantRate: big.NewRat(1, 1),
+		},
+		{
+			name:   "direct rate",
+			amount: newMoney(t, "10.00", "EUR"),
+			to:     "BRL",
+			mockSetup: func(r *MockExchangeRateRepository, l *mocks.MockLogger) {
+				r.On("FindRate", "EUR", "BRL", date).Return(newRate(t, "EUR", "BRL", "6.05", date), nil)
+			},
+			want:     "60.50",
+			wantRate: big.NewRat(605, 100),
+		},
+		{
+			name:   "inverse rate",
+			amount: newMoney(t, "60.50", "BRL"),
+			to:     "EUR",
+			mockSetup: func(r *MockExchangeRateRepository, l *mocks.MockLogger) {
+				r.On("FindRate", "BRL", "EUR", date).Return(nil, nil)
+				r.On("FindRate", "EUR", "BRL", date).Return(newRate(t, "EUR", "BRL", "6.05", date), nil)
+			},
+			want:     "10.00",
+			wantRate: big.NewRat(100, 605),
+		},
+		{
+			name:   "cross rate through EUR",
+			amount: newMoney(t, "10.00", "USD"),
+			to:     "BRL",
+			mockSetup: func(r *MockExchangeRateRepository, l *mocks.MockLogger) {
+				r.On("FindRate", "USD", "BRL", date).Return(nil, nil)
+				r.On("FindRate", "BRL", "USD", date).Return(nil, nil)
+				r.On("FindRate", "EUR", "USD", date).Return(newRate(t, "EUR", "USD", "1.08", date), nil)
+				r.On("FindRate", "EUR", "BRL", date).Return(newRate(t, "EUR", "BRL", "6.048", date), nil)
+			},
+			want:     "56.00",
+			wantRate: big.NewRat(56, 10),
+		},
+		{
+			name:   "rate not found",
+			amount: newMoney(t, "10.00", "USD"),
+			to:     "BRL",
+			mockSetup: func(r *MockExchangeRateRepository, l *mocks.MockLogger) {
+				r.On("FindRate", mock.Anything, mock.Anything, date).Return(nil, nil)
+				l.On("Warn", mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:   "repository error",
+			amount: newMoney(t, "10.00", "USD"),
+			to:     "BRL",
+			mockSetup: func(r *MockExchangeRateRepository, l *mocks.MockLogger) {
+				r.On("FindRate", "USD", "BRL", date).Return(nil, errors.New("database error"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+		{
+			name:   "unknown target currency",
+			amount: newMoney(t, "10.00", "USD"),
+			to:     "XXX",
+			mockSetup: func(r *MockExchangeRateRepository, l *mocks.MockLogger) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockExchangeRateRepository)
+			mockProvider := new(MockExchangeRateProvider)
+			mockLogger := mocks.NewMockLogger()
+
+			tt.mockSetup(mockRepo, mockLogger)
+
+			service := services.NewExchangeRateService(mockRepo, mockProvider, mockLogger)
+
+			conversion, err := service.Convert(tt.amount, tt.to, date)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, conversion)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, conversion.Converted.Currency().Code)
+				assert.Equal(t, tt.want, conversion.Converted.Decimal())
+				assert.Equal(t, 0, conversion.Rate.Cmp(tt.wantRate), "got rate %s", conversion.Rate)
+				assert.True(t, tt.amount.Equal(conversion.Original))
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockLogger.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExchangeRateService_ConvertTotal(t *testing.T) {
+	// Arrange
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	mockRepo := new(MockExchangeRateRepository)
+	mockRepo.On("FindRate", "EUR", "BRL", date).Return(newRate(t, "EUR", "BRL", "6.05", date), nil)
+	service := services.NewExchangeRateService(mockRepo, new(MockExchangeRateProvider), mocks.NewMockLogger())
+
+	// Act
+	total, conversions, err := service.ConvertTotal(
+		[]money.Money{newMoney(t, "100.00", "BRL"), newMoney(t, "10.00", "EUR")},
+		"BRL",
+		date,
+	)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "160.50", total.Decimal())
+	require.Len(t, conversions, 2)
+	assert.Equal(t, 0, conversions[1].Rate.Cmp(big.NewRat(605, 100)))
+	assert.Equal(t, "ECB", conversions[1].Source)
+}
+
+func TestExchangeRateService_SyncRates(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("saves fetched rates", func(t *testing.T) {
+		rates := []*entities.ExchangeRate{newRate(t, "EUR", "BRL", "6.05", date)}
+		mockRepo := new(MockExchangeRateRepository)
+		mockProvider := new(MockExchangeRateProvider)
+		mockProvider.On("FetchRates", date).Return(rates, nil)
+		mockRepo.On("SaveRates", rates).Return(nil)
+
+		service := services.NewExchangeRateService(mockRepo, mockProvider, mocks.NewMockLogger())
+		count, err := service.SyncRates(date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		mockRepo.AssertExpectations(t)
+		mockProvider.AssertExpectations(t)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		mockProvider := new(MockExchangeRateProvider)
+		mockProvider.On("FetchRates", date).Return(nil, errors.New("timeout"))
+		mockLogger := mocks.NewMockLogger()
+		mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewExchangeRateService(new(MockExchangeRateRepository), mockProvider, mockLogger)
+		_, err := service.SyncRates(date)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeExternalAPI))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		rates := []*entities.ExchangeRate{newRate(t, "EUR", "BRL", "6.05", date)}
+		mockRepo := new(MockExchangeRateRepository)
+		mockProvider := new(MockExchangeRateProvider)
+		mockProvider.On("FetchRates", date).Return(rates, nil)
+		mockRepo.On("SaveRates", rates).Return(errors.New("database error"))
+		mockLogger := mocks.NewMockLogger()
+		mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewExchangeRateService(mockRepo, mockProvider, mockLogger)
+		_, err := service.SyncRates(date)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+}
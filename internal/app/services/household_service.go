@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type HouseholdService interface {
+	CreateHousehold(name, ownerID string) (*entities.Household, error)
+	GetHousehold(householdID, actorID string) (*entities.Household, error)
+	AddMember(householdID, actorID, email string, role entities.HouseholdRole) (*entities.HouseholdMember, error)
+	ChangeMemberRole(householdID, actorID, userID string, role entities.HouseholdRole) error
+	RemoveMember(householdID, actorID, userID string) error
+}
+
+type householdService struct {
+	householdRepo repositories.HouseholdRepository
+	userRepo      repositories.UserRepository
+	logger        logger.Logger
+}
+
+var (
+	ErrHouseholdNotFound     = apperror.New(apperror.ErrorTypeNotFound, "Household not found")
+	ErrHouseholdForbidden    = apperror.New(apperror.ErrorTypeForbidden, "Only household owners and admins can manage members")
+	ErrHouseholdUserNotFound = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	ErrInvalidHouseholdRole  = apperror.New(apperror.ErrorTypeValidation, "Role must be OWNER, ADMIN or MEMBER")
+	ErrHouseholdDependent    = apperror.New(apperror.ErrorTypeForbidden, "Dependents cannot create households")
+)
+
+func (s *householdService) CreateHousehold(name, ownerID string) (*entities.Household, error) {
+	owner, err := s.findUser(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.IsDependent() {
+		return nil, ErrHouseholdDependent
+	}
+
+	household, err := entities.NewHousehold(name, owner.ID)
+	if err != nil {
+		s.logger.Error(err, "Invalid household data", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	createdHousehold, err := s.householdRepo.CreateHousehold(household)
+	if err != nil {
+		s.logger.Error(err, "Failed to create household", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdHousehold, nil
+}
+
+// GetHousehold returns the household if actorID is one of its members.
+// Non-members get a not found error so household IDs cannot be probed.
+func (s *householdService) GetHousehold(householdID, actorID string) (*entities.Household, error) {
+	household, err := s.householdRepo.FindHouseholdByID(householdID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find household", map[string]interface{}{
+			"household_id": householdID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if household == nil || household.Member(actorID) == nil {
+		return nil, ErrHouseholdNotFound
+	}
+
+	return household, nil
+}
+
+func (s *householdService) AddMember(householdID, actorID, email string, role entities.HouseholdRole) (*entities.HouseholdMember, error) {
+	if !role.Valid() {
+		return nil, ErrInvalidHouseholdRole
+	}
+
+	household, err := s.managedHousehold(householdID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.guardOwnerChange(household, actorID, "", role); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindUserByEmail(email)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return nil, ErrHouseholdUserNotFound
+	}
+	if err := checkDependentRole(user, role); err != nil {
+		return nil, s.domainError(err)
+	}
+
+	member, err := household.AddMember(user.ID, role)
+	if err != nil {
+		return nil, s.domainError(err)
+	}
+
+	if err := s.householdRepo.AddMember(member); err != nil {
+		s.logger.Error(err, "Failed to add household member", map[string]interface{}{
+			"household_id": householdID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return member, nil
+}
+
+func (s *householdService) ChangeMemberRole(householdID, actorID, userID string, role entities.HouseholdRole) error {
+	if !role.Valid() {
+		return ErrInvalidHouseholdRole
+	}
+
+	household, err := s.managedHousehold(householdID, actorID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.guardOwnerChange(household, actorID, userID, role); err != nil {
+		return err
+	}
+
+	if role != entities.HouseholdRoleMember && household.Member(userID) != nil {
+		user, err := s.findUser(userID)
+		if err != nil {
+			return err
+		}
+		if err := checkDependentRole(user, role); err != nil {
+			return s.domainError(err)
+		}
+	}
+
+	if err := household.ChangeRole(userID, role); err != nil {
+		return s.domainError(err)
+	}
+
+	if err := s.householdRepo.UpdateMemberRole(householdID, userID, role); err != nil {
+		s.logger.Error(err, "Failed to update household member role", map[string]interface{}{
+			"household_id": householdID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// RemoveMember lets managers remove others and any member leave on their own.
+func (s *householdService) RemoveMember(householdID, actorID, userID string) error {
+	var (
+		household *entities.Household
+		err       error
+	)
+	if actorID == userID {
+		household, err = s.GetHousehold(householdID, actorID)
+	} else {
+		household, err = s.managedHousehold(householdID, actorID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if actorID != userID {
+		if target := household.Member(userID); target != nil {
+			if err := s.guardOwnerChange(household, actorID, userID, target.Role); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := household.RemoveMember(userID); err != nil {
+		return s.domainError(err)
+	}
+
+	if err := s.householdRepo.RemoveMember(householdID, userID); err != nil {
+		s.logger.Error(err, "Failed to remove household member", map[string]interface{}{
+			"household_id": householdID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+func (s *householdService) managedHousehold(householdID, actorID string) (*entities.Household, error) {
+	household, err := s.GetHousehold(householdID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !household.Member(actorID).CanManageMembers() {
+		return nil, ErrHouseholdForbidden
+	}
+
+	return household, nil
+}
+
+// guardOwnerChange keeps admins from granting, revoking or removing the
+// owner role; only owners can do that.
+func (s *householdService) guardOwnerChange(household *entities.Household, actorID, userID string, role entities.HouseholdRole) error {
+	if household.Member(actorID).Role == entities.HouseholdRoleOwner {
+		return nil
+	}
+
+	target := household.Member(userID)
+	if role == entities.HouseholdRoleOwner || (target != nil && target.Role == entities.HouseholdRoleOwner) {
+		return apperror.New(apperror.ErrorTypeForbidden, "Only household owners can manage owners")
+	}
+
+	return nil
+}
+
+func (s *householdService) findUser(userID string) (*entities.User, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return nil, ErrHouseholdUserNotFound
+	}
+	return user, nil
+}
+
+// checkDependentRole keeps dependents to the member role: household owners
+// and admins own every wallet of the household.
+func checkDependentRole(user *entities.User, role entities.HouseholdRole) error {
+	if user.IsDependent() && role != entities.HouseholdRoleMember {
+		return entities.ErrDependentRole
+	}
+	return nil
+}
+
+func (s *householdService) domainError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrNotHouseholdMember):
+		return apperror.Wrap(apperror.ErrorTypeNotFound, err)
+	case errors.Is(err, entities.ErrAlreadyHouseholdMember), errors.Is(err, entities.ErrLastHouseholdOwner):
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	case errors.Is(err, entities.ErrDependentRole):
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err).
+			AddContext("field", "role")
+	default:
+		return apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+}
+
+func NewHouseholdService(
+	householdRepo repositories.HouseholdRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) HouseholdService {
+	return &householdService{
+		householdRepo: householdRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+	}
+}
@@ -0,0 +1,343 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHouseholdRepository struct {
+	mock.Mock
+}
+
+func (m *MockHouseholdRepository) CreateHousehold(household *entities.Household) (*entities.Household, error) {
+	args := m.Called(household)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) FindHouseholdByID(id string) (*entities.Household, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) AddMember(member *entities.HouseholdMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) UpdateMemberRole(householdID, userID string, role entities.HouseholdRole) error {
+	args := m.Called(householdID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) RemoveMember(householdID, userID string) error {
+	args := m.Called(householdID, userID)
+	return args.Error(0)
+}
+
+// newTestHousehold builds a household owned by "owner-id" with an admin
+// "admin-id" and a plain member "member-id".
+func newTestHousehold() *entities.Household {
+	household, _ := entities.NewHousehold("Silva family", "owner-id")
+	household.ID = "household-id"
+	for _, m := range household.Members {
+		m.HouseholdID = household.ID
+	}
+	_, _ = household.AddMember("admin-id", entities.HouseholdRoleAdmin)
+	_, _ = household.AddMember("member-id", entities.HouseholdRoleMember)
+	return household
+}
+
+// newHouseholdUserRepository serves the members of newTestHousehold and
+// "teen-id", a dependent of the owner.
+func newHouseholdUserRepository() *MockUserRepository {
+	repo := new(MockUserRepository)
+	for _, id := range []string{"owner-id", "admin-id", "member-id"} {
+		repo.On("FindUserByID", id).Return(&entities.User{ID: id, Role: entities.RoleUser}, nil).Maybe()
+	}
+	repo.On("FindUserByID", "teen-id").Return(&entities.User{ID: "teen-id", Role: entities.RoleDependent, ParentID: "owner-id"}, nil).Maybe()
+	return repo
+}
+
+func TestHouseholdService_CreateHousehold(t *testing.T) {
+	t.Run("successful creation", func(t *testing.T) {
+		repo := new(MockHouseholdRepository)
+		repo.On("CreateHousehold", mock.AnythingOfType("*entities.Household")).Return(newTestHousehold(), nil)
+
+		service := services.NewHouseholdService(repo, newHouseholdUserRepository(), mocks.NewMockLogger())
+		household, err := service.CreateHousehold("Silva family", "owner-id")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, household)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		logger := mocks.NewMockLogger()
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewHouseholdService(new(MockHouseholdRepository), newHouseholdUserRepository(), logger)
+		_, err := service.CreateHousehold("", "owner-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+	})
+
+	t.Run("dependent cannot create households", func(t *testing.T) {
+		service := services.NewHouseholdService(new(MockHouseholdRepository), newHouseholdUserRepository(), mocks.NewMockLogger())
+		_, err := service.CreateHousehold("Teen club", "teen-id")
+
+		assert.ErrorIs(t, err, services.ErrHouseholdDependent)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockHouseholdRepository)
+		repo.On("CreateHousehold", mock.Anything).Return(nil, errors.New("database error"))
+		logger := mocks.NewMockLogger()
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewHouseholdService(repo, newHouseholdUserRepository(), logger)
+		_, err := service.CreateHousehold("Silva family", "owner-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+}
+
+func TestHouseholdService_GetHousehold(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID string
+		found   bool
+		wantErr bool
+	}{
+		{name: "member can see household", actorID: "member-id", found: true},
+		{name: "non member gets not found", actorID: "stranger-id", found: true, wantErr: true},
+		{name: "missing household", actorID: "owner-id", found: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockHouseholdRepository)
+			if tt.found {
+				repo.On("FindHouseholdByID", "household-id").Return(newTestHousehold(), nil)
+			} else {
+				repo.On("FindHouseholdByID", "household-id").Return(nil, nil)
+			}
+
+			service := services.NewHouseholdService(repo, new(MockUserRepository), mocks.NewMockLogger())
+			household, err := service.GetHousehold("household-id", tt.actorID)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+				assert.Nil(t, household)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "household-id", household.ID)
+			}
+		})
+	}
+}
+
+func TestHouseholdService_AddMember(t *testing.T) {
+	tests := []struct {
+		name      string
+		actorID   string
+		email     string
+		role      entities.HouseholdRole
+		mockSetup func(*MockHouseholdRepository, *MockUserRepository)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:    "owner adds member",
+			actorID: "owner-id",
+			email:   "kid@example.com",
+			role:    entities.HouseholdRoleMember,
+			mockSetup: func(hr *MockHouseholdRepository, ur *MockUserRepository) {
+				ur.On("FindUserByEmail", "kid@example.com").Return(&entities.User{ID: "kid-id"}, nil)
+				hr.On("AddMember", mock.MatchedBy(func(m *entities.HouseholdMember) bool {
+					return m.UserID == "kid-id" && m.HouseholdID == "household-id"
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "plain member cannot add",
+			actorID: "member-id",
+			email:   "kid@example.com",
+			role:    entities.HouseholdRoleMember,
+			mockSetup: func(hr *MockHouseholdRepository, ur *MockUserRepository) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:    "admin cannot grant owner",
+			actorID: "admin-id",
+			email:   "kid@example.com",
+			role:    entities.HouseholdRoleOwner,
+			mockSetup: func(hr *MockHouseholdRepository, ur *MockUserRepository) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:    "dependent cannot join as admin",
+			actorID: "owner-id",
+			email:   "teen@example.com",
+			role:    entities.HouseholdRoleAdmin,
+			mockSetup: func(hr *MockHouseholdRepository, ur *MockUserRepository) {
+				ur.On("FindUserByEmail", "teen@example.com").Return(&entities.User{ID: "teen-id", Role: entities.RoleDependent, ParentID: "owner-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:    "unknown user",
+			actorID: "owner-id",
+			email:   "nobody@example.com",
+			role:    entities.HouseholdRoleMember,
+			mockSetup: func(hr *MockHouseholdRepository, ur *MockUserRepository) {
+				ur.On("FindUserByEmail", "nobody@example.com").Return(nil, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:    "already a member",
+			actorID: "owner-id",
+			email:   "member@example.com",
+			role:    entities.HouseholdRoleMember,
+			mockSetup: func(hr *MockHouseholdRepository, ur *MockUserRepository) {
+				ur.On("FindUserByEmail", "member@example.com").Return(&entities.User{ID: "member-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeUnprocessable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			householdRepo := new(MockHouseholdRepository)
+			userRepo := new(MockUserRepository)
+			householdRepo.On("FindHouseholdByID", "household-id").Return(newTestHousehold(), nil)
+			tt.mockSetup(householdRepo, userRepo)
+
+			service := services.NewHouseholdService(householdRepo, userRepo, mocks.NewMockLogger())
+			member, err := service.AddMember("household-id", tt.actorID, tt.email, tt.role)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, member)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.role, member.Role)
+			}
+
+			householdRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHouseholdService_ChangeMemberRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID string
+		userID  string
+		role    entities.HouseholdRole
+		wantErr bool
+		errType apperror.ErrorType
+	}{
+		{name: "owner promotes member", actorID: "owner-id", userID: "member-id", role: entities.HouseholdRoleAdmin},
+		{name: "admin demotes owner", actorID: "admin-id", userID: "owner-id", role: entities.HouseholdRoleMember, wantErr: true, errType: apperror.ErrorTypeForbidden},
+		{name: "last owner steps down", actorID: "owner-id", userID: "owner-id", role: entities.HouseholdRoleAdmin, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "unknown member", actorID: "owner-id", userID: "stranger-id", role: entities.HouseholdRoleAdmin, wantErr: true, errType: apperror.ErrorTypeNotFound},
+		{name: "dependent cannot be promoted", actorID: "owner-id", userID: "teen-id", role: entities.HouseholdRoleAdmin, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			household := newTestHousehold()
+			_, _ = household.AddMember("teen-id", entities.HouseholdRoleMember)
+			repo := new(MockHouseholdRepository)
+			repo.On("FindHouseholdByID", "household-id").Return(household, nil)
+			if !tt.wantErr {
+				repo.On("UpdateMemberRole", "household-id", tt.userID, tt.role).Return(nil)
+			}
+
+			service := services.NewHouseholdService(repo, newHouseholdUserRepository(), mocks.NewMockLogger())
+			err := service.ChangeMemberRole("household-id", tt.actorID, tt.userID, tt.role)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHouseholdService_InvalidRole(t *testing.T) {
+	repo := new(MockHouseholdRepository)
+	service := services.NewHouseholdService(repo, new(MockUserRepository), mocks.NewMockLogger())
+
+	_, err := service.AddMember("household-id", "owner-id", "kid@example.com", entities.HouseholdRole("GUEST"))
+	assert.ErrorIs(t, err, services.ErrInvalidHouseholdRole)
+
+	err = service.ChangeMemberRole("household-id", "owner-id", "member-id", entities.HouseholdRole(""))
+	assert.ErrorIs(t, err, services.ErrInvalidHouseholdRole)
+
+	repo.AssertNotCalled(t, "AddMember", mock.Anything)
+	repo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHouseholdService_RemoveMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID string
+		userID  string
+		wantErr bool
+		errType apperror.ErrorType
+	}{
+		{name: "admin removes member", actorID: "admin-id", userID: "member-id"},
+		{name: "member leaves", actorID: "member-id", userID: "member-id"},
+		{name: "member cannot remove others", actorID: "member-id", userID: "admin-id", wantErr: true, errType: apperror.ErrorTypeForbidden},
+		{name: "admin cannot remove owner", actorID: "admin-id", userID: "owner-id", wantErr: true, errType: apperror.ErrorTypeForbidden},
+		{name: "last owner cannot leave", actorID: "owner-id", userID: "owner-id", wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockHouseholdRepository)
+			repo.On("FindHouseholdByID", "household-id").Return(newTestHousehold(), nil)
+			if !tt.wantErr {
+				repo.On("RemoveMember", "household-id", tt.userID).Return(nil)
+			}
+
+			service := services.NewHouseholdService(repo, new(MockUserRepository), mocks.NewMockLogger())
+			err := service.RemoveMember("household-id", tt.actorID, tt.userID)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -4,4 +4,24 @@ import "go.uber.org/fx"
 
 var Module = fx.Provide(
 	NewUserService,
+	NewExchangeRateService,
+	NewHouseholdService,
+	NewTransactionService,
+	NewCategoryService,
+	NewTagService,
+	NewTransferService,
+	NewRecurringTransactionService,
+	NewReportService,
+	NewBalanceService,
+	NewSettlementService,
+	NewAttachmentService,
+	NewPayeeService,
+	NewRuleService,
+	NewWalletService,
+	NewApprovalService,
+	NewReconciliationService,
+	NewSpendingLimitService,
+	NewNotificationService,
+	NewActivityService,
+	NewCommentService,
 )
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+type NotificationService interface {
+	// ListNotifications returns a page of the notifications of userID, the
+	// cursor of the next page, nil on the last one, and how many of all
+	// their notifications are unread.
+	ListNotifications(userID string, filter repositories.NotificationFilter, page query.Page) ([]*entities.Notification, *query.Cursor, int, error)
+	// MarkNotificationsRead marks the notifications with the given IDs read,
+	// or all of them when ids is empty.
+	MarkNotificationsRead(userID string, ids []string) error
+}
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+	logger           logger.Logger
+}
+
+var ErrNotificationUserNotFound = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+
+func (s *notificationService) ListNotifications(
+	userID string,
+	filter repositories.NotificationFilter,
+	page query.Page,
+) ([]*entities.Notification, *query.Cursor, int, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, nil, 0, err
+	}
+
+	notifications, next, err := s.notificationRepo.FindNotificationsByUserID(userID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list notifications", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, nil, 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	unread, err := s.notificationRepo.CountUnreadNotifications(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to count unread notifications", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, nil, 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return notifications, next, unread, nil
+}
+
+func (s *notificationService) MarkNotificationsRead(userID string, ids []string) error {
+	if err := s.checkUser(userID); err != nil {
+		return err
+	}
+
+	if err := s.notificationRepo.MarkNotificationsRead(userID, entities.UniqueIDs(ids), time.Now()); err != nil {
+		s.logger.Error(err, "Failed to mark notifications read", map[string]interface{}{
+			"user_id": userID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+func (s *notificationService) checkUser(userID string) error {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return ErrNotificationUserNotFound
+	}
+	return nil
+}
+
+// notify saves notifications about something that already happened. A
+// failure is logged rather than returned, as it must not undo the change
+// the notifications are about.
+func notify(notificationRepo repositories.NotificationRepository, log logger.Logger, notifications []*entities.Notification) {
+	if len(notifications) == 0 {
+		return
+	}
+
+	if err := notificationRepo.CreateNotifications(notifications); err != nil {
+		log.Error(err, "Failed to create notifications", map[string]interface{}{
+			"count": len(notifications),
+		})
+	}
+}
+
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		logger:           logger,
+	}
+}
@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateNotifications(notifications []*entities.Notification) error {
+	args := m.Called(notifications)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) FindNotificationsByUserID(
+	userID string,
+	filter repositories.NotificationFilter,
+	page query.Page,
+) ([]*entities.Notification, *query.Cursor, error) {
+	args := m.Called(userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.Notification), next, args.Error(2)
+}
+
+func (m *MockNotificationRepository) CountUnreadNotifications(userID string) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkNotificationsRead(userID string, ids []string, readAt time.Time) error {
+	args := m.Called(userID, ids, readAt)
+	return args.Error(0)
+}
+
+func TestNotificationService_ListNotifications(t *testing.T) {
+	page, err := repositories.NotificationSorts.NewPage(repositories.DefaultNotificationSort, nil, 20)
+	require.NoError(t, err)
+
+	t.Run("lists with the unread count", func(t *testing.T) {
+		notification, err := entities.NewNotification("user-id", entities.NotificationSpendingLimitNear, "wallet-id", "Close to the limit", nil)
+		require.NoError(t, err)
+		repo := new(MockNotificationRepository)
+		repo.On("FindNotificationsByUserID", "user-id", repositories.NotificationFilter{}, page).
+			Return([]*entities.Notification{notification}, nil, nil)
+		repo.On("CountUnreadNotifications", "user-id").Return(3, nil)
+
+		service := services.NewNotificationService(repo, newUserRepository(), mocks.NewMockLogger())
+		notifications, next, unread, err := service.ListNotifications("user-id", repositories.NotificationFilter{}, page)
+
+		require.NoError(t, err)
+		assert.Len(t, notifications, 1)
+		assert.Nil(t, next)
+		assert.Equal(t, 3, unread)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "ghost-id").Return(nil, nil)
+
+		service := services.NewNotificationService(new(MockNotificationRepository), userRepo, mocks.NewMockLogger())
+		_, _, _, err := service.ListNotifications("ghost-id", repositories.NotificationFilter{}, page)
+
+		assert.ErrorIs(t, err, services.ErrNotificationUserNotFound)
+	})
+}
+
+func TestNotificationService_MarkNotificationsRead(t *testing.T) {
+	repo := new(MockNotificationRepository)
+	repo.On("MarkNotificationsRead", "user-id", []string{"first-id", "second-id"}, mock.AnythingOfType("time.Time")).Return(nil)
+
+	service := services.NewNotificationService(repo, newUserRepository(), mocks.NewMockLogger())
+	err := service.MarkNotificationsRead("user-id", []string{"first-id", "second-id", "first-id"})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
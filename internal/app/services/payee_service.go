@@ -0,0 +1,294 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// PayeeInput holds the editable fields of a payee. An empty
+// DefaultCategoryID leaves new transactions of the payee uncategorized.
+type PayeeInput struct {
+	Name              string
+	DefaultCategoryID string
+}
+
+type PayeeService interface {
+	CreatePayee(userID string, input PayeeInput) (*entities.Payee, error)
+	ListPayees(userID string, filter repositories.PayeeFilter, page query.Page) ([]*entities.Payee, *query.Cursor, error)
+	UpdatePayee(userID, payeeID string, input PayeeInput) (*entities.Payee, error)
+	MergePayees(userID, sourceID, targetID string) error
+	DeletePayee(userID, payeeID string) error
+	// SuggestPayee returns the payee a transaction description maps to.
+	SuggestPayee(userID, description string) (*entities.Payee, error)
+}
+
+type payeeService struct {
+	payeeRepo    repositories.PayeeRepository
+	categoryRepo repositories.CategoryRepository
+	userRepo     repositories.UserRepository
+	logger       logger.Logger
+}
+
+var (
+	ErrPayeeNotFound      = apperror.New(apperror.ErrorTypeNotFound, "Payee not found")
+	ErrPayeeUserNotFound  = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	ErrPayeeAlreadyExists = apperror.New(apperror.ErrorTypeUnprocessable, "A payee with this name already exists")
+)
+
+func (s *payeeService) CreatePayee(userID string, input PayeeInput) (*entities.Payee, error) {
+	payees, err := s.userPayees(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err := entities.NewPayee(userID, input.Name)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.setDefaultCategory(payee, input.DefaultCategoryID); err != nil {
+		return nil, err
+	}
+
+	if hasAliasConflict(payees, payee) {
+		return nil, ErrPayeeAlreadyExists
+	}
+
+	createdPayee, err := s.payeeRepo.CreatePayee(payee)
+	if err != nil {
+		s.logger.Error(err, "Failed to create payee", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdPayee, nil
+}
+
+func (s *payeeService) ListPayees(
+	userID string,
+	filter repositories.PayeeFilter,
+	page query.Page,
+) ([]*entities.Payee, *query.Cursor, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, nil, err
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.CategoryIDs = entities.UniqueIDs(filter.CategoryIDs)
+
+	payees, next, err := s.payeeRepo.FindPayeePageByUserID(userID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list payees", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return payees, next, nil
+}
+
+func (s *payeeService) UpdatePayee(userID, payeeID string, input PayeeInput) (*entities.Payee, error) {
+	payees, err := s.userPayees(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payee := findPayee(payees, payeeID)
+	if payee == nil {
+		return nil, ErrPayeeNotFound
+	}
+
+	if err := payee.Rename(input.Name); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.setDefaultCategory(payee, input.DefaultCategoryID); err != nil {
+		return nil, err
+	}
+
+	if hasAliasConflict(payees, payee) {
+		return nil, ErrPayeeAlreadyExists
+	}
+
+	updatedPayee, err := s.payeeRepo.UpdatePayee(payee)
+	if err != nil {
+		s.logger.Error(err, "Failed to update payee", map[string]interface{}{
+			"payee_id": payeeID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return updatedPayee, nil
+}
+
+// MergePayees moves every transaction and alias of source into target and
+// removes source, so duplicates left by unrecognized names can be joined.
+func (s *payeeService) MergePayees(userID, sourceID, targetID string) error {
+	payees, err := s.userPayees(userID)
+	if err != nil {
+		return err
+	}
+
+	source := findPayee(payees, sourceID)
+	target := findPayee(payees, targetID)
+	if source == nil || target == nil {
+		return ErrPayeeNotFound
+	}
+
+	if err := source.CanMergeInto(target); err != nil {
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+	target.Absorb(source)
+
+	if err := s.payeeRepo.MergePayees(source, target); err != nil {
+		s.logger.Error(err, "Failed to merge payees", map[string]interface{}{
+			"source_id": sourceID,
+			"target_id": targetID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// DeletePayee removes the payee; its transactions keep their description
+// and are left without a payee.
+func (s *payeeService) DeletePayee(userID, payeeID string) error {
+	payees, err := s.userPayees(userID)
+	if err != nil {
+		return err
+	}
+
+	if findPayee(payees, payeeID) == nil {
+		return ErrPayeeNotFound
+	}
+
+	if err := s.payeeRepo.DeletePayee(payeeID); err != nil {
+		s.logger.Error(err, "Failed to delete payee", map[string]interface{}{
+			"payee_id": payeeID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+func (s *payeeService) SuggestPayee(userID, description string) (*entities.Payee, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	alias := entities.NormalizePayeeName(description)
+	if alias == "" {
+		return nil, ErrPayeeNotFound
+	}
+
+	payee, err := s.payeeRepo.FindPayeeByAlias(userID, alias)
+	if err != nil {
+		s.logger.Error(err, "Failed to find payee", map[string]interface{}{
+			"alias": alias,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if payee == nil {
+		return nil, ErrPayeeNotFound
+	}
+
+	return payee, nil
+}
+
+// setDefaultCategory sets the default category of the payee, which must be
+// one of the payee owner's categories.
+func (s *payeeService) setDefaultCategory(payee *entities.Payee, categoryID string) error {
+	if categoryID != "" {
+		category, err := s.categoryRepo.FindCategoryByID(categoryID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find category", map[string]interface{}{
+				"category_id": categoryID,
+			})
+			return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if category == nil || category.UserID != payee.UserID {
+			return ErrCategoryNotFound
+		}
+	}
+
+	payee.SetDefaultCategory(categoryID)
+	return nil
+}
+
+func (s *payeeService) checkUser(userID string) error {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return ErrPayeeUserNotFound
+	}
+	return nil
+}
+
+// userPayees loads every payee of an existing user.
+func (s *payeeService) userPayees(userID string) ([]*entities.Payee, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	payees, err := s.payeeRepo.FindPayeesByUserID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list payees", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return payees, nil
+}
+
+func findPayee(payees []*entities.Payee, id string) *entities.Payee {
+	for _, payee := range payees {
+		if payee.ID == id {
+			return payee
+		}
+	}
+	return nil
+}
+
+// hasAliasConflict reports whether another payee already answers to one of
+// the payee's aliases.
+func hasAliasConflict(payees []*entities.Payee, payee *entities.Payee) bool {
+	for _, other := range payees {
+		if other.ID == payee.ID {
+			continue
+		}
+		for _, alias := range payee.Aliases {
+			if other.HasAlias(alias) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func NewPayeeService(
+	payeeRepo repositories.PayeeRepository,
+	categoryRepo repositories.CategoryRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) PayeeService {
+	return &payeeService{
+		payeeRepo:    payeeRepo,
+		categoryRepo: categoryRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+	}
+}
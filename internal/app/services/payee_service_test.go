@@ -0,0 +1,330 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPayeeRepository struct {
+	mock.Mock
+}
+
+func (m *MockPayeeRepository) CreatePayee(payee *entities.Payee) (*entities.Payee, error) {
+	args := m.Called(payee)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) FindPayeeByID(id string) (*entities.Payee, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) FindPayeesByUserID(userID string) ([]*entities.Payee, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) FindPayeePageByUserID(
+	userID string,
+	filter repositories.PayeeFilter,
+	page query.Page,
+) ([]*entities.Payee, *query.Cursor, error) {
+	args := m.Called(userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.Payee), next, args.Error(2)
+}
+
+func (m *MockPayeeRepository) FindPayeeByAlias(userID, alias string) (*entities.Payee, error) {
+	args := m.Called(userID, alias)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) UpdatePayee(payee *entities.Payee) (*entities.Payee, error) {
+	args := m.Called(payee)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) MergePayees(source, target *entities.Payee) error {
+	args := m.Called(source, target)
+	return args.Error(0)
+}
+
+func (m *MockPayeeRepository) DeletePayee(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// newPayeeRepository knows every description as the payee "payee-id" of
+// "user-id", for tests that do not care about payees.
+func newPayeeRepository() *MockPayeeRepository {
+	repo := new(MockPayeeRepository)
+	repo.On("FindPayeeByAlias", mock.Anything, mock.Anything).
+		Return(&entities.Payee{ID: "payee-id", UserID: "user-id"}, nil).Maybe()
+	return repo
+}
+
+// newTestPayees builds the payees of "user-id": Uber, filed under
+// transport-id, and iFood.
+func newTestPayees() []*entities.Payee {
+	return []*entities.Payee{
+		{ID: "uber-id", UserID: "user-id", Name: "Uber", DefaultCategoryID: "transport-id", Aliases: []string{"UBER"}},
+		{ID: "ifood-id", UserID: "user-id", Name: "iFood", Aliases: []string{"IFOOD", "IFOOD CLUB"}},
+	}
+}
+
+func newPayeeService(payeeRepo *MockPayeeRepository, categoryRepo *MockCategoryRepository) services.PayeeService {
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+	payeeRepo.On("FindPayeesByUserID", "user-id").Return(newTestPayees(), nil).Maybe()
+
+	logger := mocks.NewMockLogger()
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return services.NewPayeeService(payeeRepo, categoryRepo, userRepo, logger)
+}
+
+func TestPayeeService_CreatePayee(t *testing.T) {
+	transport := &entities.Category{ID: "transport-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+	foreign := &entities.Category{ID: "foreign-id", UserID: "other-user-id", Type: entities.TransactionTypeExpense}
+
+	tests := []struct {
+		name     string
+		input    services.PayeeInput
+		category *entities.Category
+		wantErr  bool
+		errType  apperror.ErrorType
+	}{
+		{name: "new payee", input: services.PayeeInput{Name: "Padaria São João"}},
+		{name: "with default category", input: services.PayeeInput{Name: "99 Taxi", DefaultCategoryID: "transport-id"}, category: transport},
+		{name: "another user's category", input: services.PayeeInput{Name: "99 Taxi", DefaultCategoryID: "foreign-id"}, category: foreign, wantErr: true, errType: apperror.ErrorTypeNotFound},
+		{name: "name of an existing alias", input: services.PayeeInput{Name: "iFood Club"}, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "name normalizing to an existing alias", input: services.PayeeInput{Name: "UBER BV"}, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "empty name", input: services.PayeeInput{Name: "1234"}, wantErr: true, errType: apperror.ErrorTypeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payeeRepo := new(MockPayeeRepository)
+			categoryRepo := new(MockCategoryRepository)
+			if tt.category != nil {
+				categoryRepo.On("FindCategoryByID", tt.category.ID).Return(tt.category, nil)
+			}
+			if !tt.wantErr {
+				payeeRepo.On("CreatePayee", mock.MatchedBy(func(payee *entities.Payee) bool {
+					return payee.UserID == "user-id" && payee.DefaultCategoryID == tt.input.DefaultCategoryID
+				})).Return(&entities.Payee{ID: "payee-id"}, nil)
+			}
+
+			service := newPayeeService(payeeRepo, categoryRepo)
+			payee, err := service.CreatePayee("user-id", tt.input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, payee)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, payee)
+			}
+			payeeRepo.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPayeeService_ListPayees(t *testing.T) {
+	page, err := repositories.PayeeSorts.NewPage(repositories.DefaultPayeeSort, nil, 2)
+	require.NoError(t, err)
+
+	t.Run("lists a page of the user payees", func(t *testing.T) {
+		next := page.Next("iFood", "ifood-id")
+		payeeRepo := new(MockPayeeRepository)
+		payeeRepo.On("FindPayeePageByUserID", "user-id", repositories.PayeeFilter{
+			Query:       "ub",
+			CategoryIDs: []string{"food-id", "transport-id"},
+		}, page).Return(newTestPayees(), next, nil)
+
+		service := newPayeeService(payeeRepo, new(MockCategoryRepository))
+		payees, cursor, err := service.ListPayees("user-id", repositories.PayeeFilter{
+			Query:       " ub ",
+			CategoryIDs: []string{"transport-id", "food-id", "transport-id"},
+		}, page)
+
+		assert.NoError(t, err)
+		assert.Len(t, payees, 2)
+		assert.Equal(t, next, cursor)
+		payeeRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		payeeRepo := new(MockPayeeRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "missing-id").Return(nil, nil)
+
+		service := services.NewPayeeService(payeeRepo, new(MockCategoryRepository), userRepo, mocks.NewMockLogger())
+		_, _, err := service.ListPayees("missing-id", repositories.PayeeFilter{}, page)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		payeeRepo.AssertNotCalled(t, "FindPayeePageByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPayeeService_UpdatePayee(t *testing.T) {
+	t.Run("rename keeps the old alias", func(t *testing.T) {
+		payeeRepo := new(MockPayeeRepository)
+		payeeRepo.On("UpdatePayee", mock.MatchedBy(func(payee *entities.Payee) bool {
+			return payee.Name == "Uber Eats" && payee.HasAlias("UBER") && payee.HasAlias("UBER EATS") &&
+				payee.DefaultCategoryID == ""
+		})).Return(&entities.Payee{ID: "uber-id"}, nil)
+
+		service := newPayeeService(payeeRepo, new(MockCategoryRepository))
+		_, err := service.UpdatePayee("user-id", "uber-id", services.PayeeInput{Name: "Uber Eats"})
+
+		assert.NoError(t, err)
+		payeeRepo.AssertExpectations(t)
+	})
+
+	t.Run("rename onto another payee", func(t *testing.T) {
+		payeeRepo := new(MockPayeeRepository)
+
+		service := newPayeeService(payeeRepo, new(MockCategoryRepository))
+		_, err := service.UpdatePayee("user-id", "uber-id", services.PayeeInput{Name: "IFOOD"})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		payeeRepo.AssertNotCalled(t, "UpdatePayee", mock.Anything)
+	})
+
+	t.Run("unknown payee", func(t *testing.T) {
+		service := newPayeeService(new(MockPayeeRepository), new(MockCategoryRepository))
+		_, err := service.UpdatePayee("user-id", "missing-id", services.PayeeInput{Name: "Uber"})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+}
+
+func TestPayeeService_MergePayees(t *testing.T) {
+	tests := []struct {
+		name     string
+		sourceID string
+		targetID string
+		wantErr  bool
+		errType  apperror.ErrorType
+	}{
+		{name: "merge into another payee", sourceID: "uber-id", targetID: "ifood-id"},
+		{name: "merge into itself", sourceID: "uber-id", targetID: "uber-id", wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "unknown target", sourceID: "uber-id", targetID: "missing-id", wantErr: true, errType: apperror.ErrorTypeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockPayeeRepository)
+			if !tt.wantErr {
+				repo.On("MergePayees",
+					mock.MatchedBy(func(source *entities.Payee) bool { return source.ID == tt.sourceID }),
+					mock.MatchedBy(func(target *entities.Payee) bool {
+						return target.ID == tt.targetID && target.HasAlias("UBER") && target.HasAlias("IFOOD") &&
+							target.DefaultCategoryID == "transport-id"
+					}),
+				).Return(nil)
+			}
+
+			service := newPayeeService(repo, new(MockCategoryRepository))
+			err := service.MergePayees("user-id", tt.sourceID, tt.targetID)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockPayeeRepository)
+		repo.On("MergePayees", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+		service := newPayeeService(repo, new(MockCategoryRepository))
+		err := service.MergePayees("user-id", "uber-id", "ifood-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+}
+
+func TestPayeeService_DeletePayee(t *testing.T) {
+	t.Run("own payee is deleted", func(t *testing.T) {
+		repo := new(MockPayeeRepository)
+		repo.On("DeletePayee", "uber-id").Return(nil)
+
+		service := newPayeeService(repo, new(MockCategoryRepository))
+		err := service.DeletePayee("user-id", "uber-id")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown payee", func(t *testing.T) {
+		repo := new(MockPayeeRepository)
+
+		service := newPayeeService(repo, new(MockCategoryRepository))
+		err := service.DeletePayee("user-id", "missing-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		repo.AssertNotCalled(t, "DeletePayee", mock.Anything)
+	})
+}
+
+func TestPayeeService_SuggestPayee(t *testing.T) {
+	uber := newTestPayees()[0]
+
+	t.Run("description of a known payee", func(t *testing.T) {
+		repo := new(MockPayeeRepository)
+		repo.On("FindPayeeByAlias", "user-id", "UBER").Return(uber, nil)
+
+		service := newPayeeService(repo, new(MockCategoryRepository))
+		payee, err := service.SuggestPayee("user-id", "UBER *TRIP 1234 SAO PAULO")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "uber-id", payee.ID)
+		assert.Equal(t, "transport-id", payee.DefaultCategoryID)
+	})
+
+	t.Run("unknown description", func(t *testing.T) {
+		repo := new(MockPayeeRepository)
+		repo.On("FindPayeeByAlias", "user-id", "NETFLIX").Return(nil, nil)
+
+		service := newPayeeService(repo, new(MockCategoryRepository))
+		_, err := service.SuggestPayee("user-id", "PAYPAL *NETFLIX 4029357733")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ReconciliationService interface {
+	// StartReconciliation opens a session checking the wallet against a
+	// statement ending on statementDate with statementBalance. A wallet has
+	// one open session per currency.
+	StartReconciliation(walletID, actorID string, statementDate time.Time, statementBalance money.Money) (*entities.Reconciliation, error)
+	GetReconciliation(walletID, actorID, reconciliationID string) (*entities.Reconciliation, error)
+	// ClearTransactions ticks off the transactions in tickIDs and takes
+	// those in untickIDs off again.
+	ClearTransactions(walletID, actorID, reconciliationID string, tickIDs, untickIDs []string) (*entities.Reconciliation, error)
+	// CompleteReconciliation reconciles the ticked transactions once they
+	// account for the whole statement balance.
+	CompleteReconciliation(walletID, actorID, reconciliationID string) (*entities.Reconciliation, error)
+	// CancelReconciliation drops an open session, leaving its transactions
+	// as they were.
+	CancelReconciliation(walletID, actorID, reconciliationID string) error
+}
+
+type reconciliationService struct {
+	reconciliationRepo repositories.ReconciliationRepository
+	transactionRepo    repositories.TransactionRepository
+	walletRepo         repositories.WalletRepository
+	logger             logger.Logger
+}
+
+var (
+	ErrReconciliationNotFound = apperror.New(apperror.ErrorTypeNotFound, "Reconciliation not found")
+	ErrReconciliationOpen     = apperror.New(apperror.ErrorTypeUnprocessable, "The wallet already has an open reconciliation in this currency")
+)
+
+func (s *reconciliationService) StartReconciliation(
+	walletID string,
+	actorID string,
+	statementDate time.Time,
+	statementBalance money.Money,
+) (*entities.Reconciliation, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	currency := statementBalance.Currency().Code
+	open, err := s.reconciliationRepo.FindOpenReconciliation(walletID, currency)
+	if err != nil {
+		s.logger.Error(err, "Failed to find open reconciliation", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if open != nil {
+		return nil, ErrReconciliationOpen
+	}
+
+	opening, err := s.reconciliationRepo.FindReconciledBalance(walletID, currency, statementDate)
+	if err != nil {
+		s.logger.Error(err, "Failed to find reconciled balance", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	reconciliation, err := entities.NewReconciliation(walletID, actorID, statementDate, statementBalance, opening)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.reconciliationRepo.CreateReconciliation(reconciliation); err != nil {
+		s.logger.Error(err, "Failed to create reconciliation", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return reconciliation, nil
+}
+
+func (s *reconciliationService) GetReconciliation(walletID, actorID, reconciliationID string) (*entities.Reconciliation, error) {
+	return s.memberReconciliation(walletID, actorID, reconciliationID)
+}
+
+func (s *reconciliationService) ClearTransactions(
+	walletID string,
+	actorID string,
+	reconciliationID string,
+	tickIDs []string,
+	untickIDs []string,
+) (*entities.Reconciliation, error) {
+	reconciliation, err := s.memberReconciliation(walletID, actorID, reconciliationID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticked, err := s.findTransactions(tickIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, transaction := range ticked {
+		if err := reconciliation.Tick(transaction); err != nil {
+			return nil, reconciliationError(err).AddContext("transaction_id", transaction.ID)
+		}
+	}
+
+	unticked, err := s.findTransactions(untickIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, transaction := range unticked {
+		if err := reconciliation.Untick(transaction); err != nil {
+			return nil, reconciliationError(err).AddContext("transaction_id", transaction.ID)
+		}
+	}
+
+	if err := s.reconciliationRepo.SaveClearedTransactions(reconciliation); err != nil {
+		s.logger.Error(err, "Failed to save cleared transactions", map[string]interface{}{
+			"reconciliation_id": reconciliationID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return reconciliation, nil
+}
+
+func (s *reconciliationService) CompleteReconciliation(walletID, actorID, reconciliationID string) (*entities.Reconciliation, error) {
+	reconciliation, err := s.memberReconciliation(walletID, actorID, reconciliationID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.findTransactions(reconciliation.ClearedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reconciliation.Complete(transactions); err != nil {
+		appErr := reconciliationError(err)
+		if errors.Is(err, entities.ErrReconciliationUnbalanced) {
+			if difference, diffErr := reconciliation.Difference(); diffErr == nil {
+				appErr.AddContext("difference", difference.Decimal()).
+					AddContext("currency", reconciliation.Currency())
+			}
+		}
+		return nil, appErr
+	}
+
+	if err := s.reconciliationRepo.CompleteReconciliation(reconciliation, transactions); err != nil {
+		s.logger.Error(err, "Failed to complete reconciliation", map[string]interface{}{
+			"reconciliation_id": reconciliationID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return reconciliation, nil
+}
+
+func (s *reconciliationService) CancelReconciliation(walletID, actorID, reconciliationID string) error {
+	reconciliation, err := s.memberReconciliation(walletID, actorID, reconciliationID)
+	if err != nil {
+		return err
+	}
+
+	if reconciliation.Status != entities.ReconciliationStatusOpen {
+		return reconciliationError(entities.ErrReconciliationCompleted)
+	}
+
+	if err := s.reconciliationRepo.DeleteReconciliation(reconciliation.ID); err != nil {
+		s.logger.Error(err, "Failed to delete reconciliation", map[string]interface{}{
+			"reconciliation_id": reconciliationID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// memberReconciliation returns a session of the wallet, which actorID must
+// be a member of.
+func (s *reconciliationService) memberReconciliation(walletID, actorID, reconciliationID string) (*entities.Reconciliation, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	reconciliation, err := s.reconciliationRepo.FindReconciliationByID(reconciliationID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find reconciliation", map[string]interface{}{
+			"reconciliation_id": reconciliationID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if reconciliation == nil || reconciliation.WalletID != walletID {
+		return nil, ErrReconciliationNotFound
+	}
+
+	return reconciliation, nil
+}
+
+// findTransactions loads the transactions, all of which must exist, in the
+// order of ids.
+func (s *reconciliationService) findTransactions(ids []string) ([]*entities.Transaction, error) {
+	ids = entities.UniqueIDs(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	transactions, err := s.transactionRepo.FindTransactionsByIDs(ids)
+	if err != nil {
+		s.logger.Error(err, "Failed to find transactions", map[string]interface{}{
+			"count": len(ids),
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	byID := make(map[string]*entities.Transaction, len(transactions))
+	for _, transaction := range transactions {
+		byID[transaction.ID] = transaction
+	}
+
+	ordered := make([]*entities.Transaction, 0, len(ids))
+	for _, id := range ids {
+		transaction := byID[id]
+		if transaction == nil {
+			return nil, apperror.New(apperror.ErrorTypeNotFound, "Transaction not found").
+				AddContext("transaction_id", id)
+		}
+		ordered = append(ordered, transaction)
+	}
+	return ordered, nil
+}
+
+// reconciliationError maps the errors of a session to the ones callers get.
+func reconciliationError(err error) *apperror.AppError {
+	switch {
+	case errors.Is(err, entities.ErrReconciliationCompleted),
+		errors.Is(err, entities.ErrReconciliationUnbalanced),
+		errors.Is(err, entities.ErrNotReconcilable),
+		errors.Is(err, entities.ErrAwaitingApproval):
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	default:
+		return apperror.Wrap(apperror.ErrorTypeInternal, err)
+	}
+}
+
+func NewReconciliationService(
+	reconciliationRepo repositories.ReconciliationRepository,
+	transactionRepo repositories.TransactionRepository,
+	walletRepo repositories.WalletRepository,
+	logger logger.Logger,
+) ReconciliationService {
+	return &reconciliationService{
+		reconciliationRepo: reconciliationRepo,
+		transactionRepo:    transactionRepo,
+		walletRepo:         walletRepo,
+		logger:             logger,
+	}
+}
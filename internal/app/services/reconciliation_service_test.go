@@ -0,0 +1,208 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/money"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationRepository) CreateReconciliation(reconciliation *entities.Reconciliation) error {
+	args := m.Called(reconciliation)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) FindReconciliationByID(id string) (*entities.Reconciliation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Reconciliation), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) FindOpenReconciliation(walletID, currency string) (*entities.Reconciliation, error) {
+	args := m.Called(walletID, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Reconciliation), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) FindReconciledBalance(walletID, currency string, date time.Time) (money.Money, error) {
+	args := m.Called(walletID, currency, date)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) SaveClearedTransactions(reconciliation *entities.Reconciliation) error {
+	args := m.Called(reconciliation)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) CompleteReconciliation(reconciliation *entities.Reconciliation, transactions []*entities.Transaction) error {
+	args := m.Called(reconciliation, transactions)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) DeleteReconciliation(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+var reconciliationStatementDate = time.Now().AddDate(0, 0, -1)
+
+// newTestReconciliation is an open session of "wallet-id" against a
+// statement of 150.00 BRL with 100.00 already reconciled.
+func newTestReconciliation(t *testing.T) *entities.Reconciliation {
+	t.Helper()
+	reconciliation, err := entities.NewReconciliation("wallet-id", "user-id", reconciliationStatementDate,
+		newMoney(t, "150.00", "BRL"), newMoney(t, "100.00", "BRL"))
+	require.NoError(t, err)
+	reconciliation.ID = "reconciliation-id"
+	return reconciliation
+}
+
+func newStatementTransaction(t *testing.T, id, amount string) *entities.Transaction {
+	t.Helper()
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeIncome, newMoney(t, amount, "BRL"),
+		reconciliationStatementDate.AddDate(0, 0, -2), "Salary", "", "user-id")
+	require.NoError(t, err)
+	transaction.ID = id
+	return transaction
+}
+
+func TestReconciliationService_StartReconciliation(t *testing.T) {
+	t.Run("opens a session from the reconciled balance", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("FindOpenReconciliation", "wallet-id", "BRL").Return(nil, nil)
+		repo.On("FindReconciledBalance", "wallet-id", "BRL", reconciliationStatementDate).Return(newMoney(t, "100.00", "BRL"), nil)
+		repo.On("CreateReconciliation", mock.AnythingOfType("*entities.Reconciliation")).Return(nil)
+
+		service := services.NewReconciliationService(repo, new(MockTransactionRepository), newWalletRepository(), mocks.NewMockLogger())
+		reconciliation, err := service.StartReconciliation("wallet-id", "user-id", reconciliationStatementDate, newMoney(t, "150.00", "BRL"))
+
+		require.NoError(t, err)
+		difference, err := reconciliation.Difference()
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), difference.MinorUnits())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("one open session per currency", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("FindOpenReconciliation", "wallet-id", "BRL").Return(newTestReconciliation(t), nil)
+
+		service := services.NewReconciliationService(repo, new(MockTransactionRepository), newWalletRepository(), mocks.NewMockLogger())
+		_, err := service.StartReconciliation("wallet-id", "user-id", reconciliationStatementDate, newMoney(t, "150.00", "BRL"))
+
+		assert.ErrorIs(t, err, services.ErrReconciliationOpen)
+		repo.AssertNotCalled(t, "CreateReconciliation", mock.Anything)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+
+		service := services.NewReconciliationService(repo, new(MockTransactionRepository), newWalletRepository(), mocks.NewMockLogger())
+		_, err := service.StartReconciliation("wallet-id", "stranger-id", reconciliationStatementDate, newMoney(t, "150.00", "BRL"))
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+	})
+}
+
+func TestReconciliationService_ClearTransactions(t *testing.T) {
+	t.Run("ticks off transactions", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("FindReconciliationByID", "reconciliation-id").Return(newTestReconciliation(t), nil)
+		repo.On("SaveClearedTransactions", mock.MatchedBy(func(reconciliation *entities.Reconciliation) bool {
+			return len(reconciliation.ClearedIDs) == 1 && reconciliation.ClearedIDs[0] == "salary-id"
+		})).Return(nil)
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"salary-id"}).
+			Return([]*entities.Transaction{newStatementTransaction(t, "salary-id", "50.00")}, nil)
+
+		service := services.NewReconciliationService(repo, transactionRepo, newWalletRepository(), mocks.NewMockLogger())
+		reconciliation, err := service.ClearTransactions("wallet-id", "user-id", "reconciliation-id", []string{"salary-id"}, nil)
+
+		require.NoError(t, err)
+		difference, err := reconciliation.Difference()
+		require.NoError(t, err)
+		assert.True(t, difference.IsZero())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("FindReconciliationByID", "reconciliation-id").Return(newTestReconciliation(t), nil)
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"missing-id"}).Return([]*entities.Transaction{}, nil)
+
+		service := services.NewReconciliationService(repo, transactionRepo, newWalletRepository(), mocks.NewMockLogger())
+		_, err := service.ClearTransactions("wallet-id", "user-id", "reconciliation-id", []string{"missing-id"}, nil)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		repo.AssertNotCalled(t, "SaveClearedTransactions", mock.Anything)
+	})
+
+	t.Run("session of another wallet", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("FindReconciliationByID", "reconciliation-id").Return(newTestReconciliation(t), nil)
+
+		service := services.NewReconciliationService(repo, new(MockTransactionRepository), newWalletRepository(), mocks.NewMockLogger())
+		_, err := service.ClearTransactions("other-wallet-id", "user-id", "reconciliation-id", []string{"salary-id"}, nil)
+
+		assert.ErrorIs(t, err, services.ErrReconciliationNotFound)
+	})
+}
+
+func TestReconciliationService_CompleteReconciliation(t *testing.T) {
+	t.Run("reconciles the cleared transactions", func(t *testing.T) {
+		reconciliation := newTestReconciliation(t)
+		salary := newStatementTransaction(t, "salary-id", "50.00")
+		require.NoError(t, reconciliation.Tick(salary))
+
+		repo := new(MockReconciliationRepository)
+		repo.On("FindReconciliationByID", "reconciliation-id").Return(reconciliation, nil)
+		repo.On("CompleteReconciliation", reconciliation, mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+			return len(transactions) == 1 && transactions[0].Status == entities.TransactionStatusReconciled
+		})).Return(nil)
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"salary-id"}).Return([]*entities.Transaction{salary}, nil)
+
+		service := services.NewReconciliationService(repo, transactionRepo, newWalletRepository(), mocks.NewMockLogger())
+		completed, err := service.CompleteReconciliation("wallet-id", "user-id", "reconciliation-id")
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.ReconciliationStatusCompleted, completed.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("difference left", func(t *testing.T) {
+		reconciliation := newTestReconciliation(t)
+		salary := newStatementTransaction(t, "salary-id", "45.00")
+		require.NoError(t, reconciliation.Tick(salary))
+
+		repo := new(MockReconciliationRepository)
+		repo.On("FindReconciliationByID", "reconciliation-id").Return(reconciliation, nil)
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"salary-id"}).Return([]*entities.Transaction{salary}, nil)
+
+		service := services.NewReconciliationService(repo, transactionRepo, newWalletRepository(), mocks.NewMockLogger())
+		_, err := service.CompleteReconciliation("wallet-id", "user-id", "reconciliation-id")
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		assert.Equal(t, "5.00", appErr.Context()["difference"])
+		repo.AssertNotCalled(t, "CompleteReconciliation", mock.Anything, mock.Anything)
+	})
+}
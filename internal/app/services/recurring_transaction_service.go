@@ -0,0 +1,394 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// RecurringTransactionInput holds the editable fields of a recurring
+// transaction.
+type RecurringTransactionInput struct {
+	Type        entities.TransactionType
+	Amount      money.Money
+	Description string
+	CategoryID  string
+	Rule        entities.RecurrenceRule
+	StartDate   time.Time
+}
+
+// OccurrenceInput changes a single occurrence. Empty fields keep the values
+// of the recurring transaction.
+type OccurrenceInput struct {
+	Skip        bool
+	Amount      money.Money
+	Description string
+	CategoryID  string
+}
+
+type RecurringTransactionService interface {
+	CreateRecurringTransaction(walletID, actorID string, input RecurringTransactionInput) (*entities.RecurringTransaction, error)
+	GetRecurringTransaction(walletID, actorID, recurringID string) (*entities.RecurringTransaction, error)
+	ListRecurringTransactions(
+		walletID, actorID string,
+		filter repositories.RecurringTransactionFilter,
+		page query.Page,
+	) ([]*entities.RecurringTransaction, *query.Cursor, error)
+	UpdateRecurringTransaction(walletID, actorID, recurringID string, input RecurringTransactionInput) (*entities.RecurringTransaction, error)
+	DeleteRecurringTransaction(walletID, actorID, recurringID string) error
+	PreviewOccurrences(walletID, actorID, recurringID string, limit int) ([]*entities.Occurrence, error)
+	OverrideOccurrence(walletID, actorID, recurringID string, date time.Time, input OccurrenceInput) (*entities.Occurrence, error)
+	RestoreOccurrence(walletID, actorID, recurringID string, date time.Time) error
+	// MaterializeDue creates the transactions of every occurrence due by now
+	// and returns how many were created.
+	MaterializeDue(now time.Time) (int, error)
+}
+
+type recurringTransactionService struct {
+	recurringRepo repositories.RecurringTransactionRepository
+	categoryRepo  repositories.CategoryRepository
+	walletRepo    repositories.WalletRepository
+	userRepo      repositories.UserRepository
+	// transactionService creates the occurrences, so they go through the
+	// same rules, limits and approval as transactions entered by hand.
+	transactionService TransactionService
+	logger             logger.Logger
+}
+
+var (
+	ErrRecurringTransactionNotFound       = apperror.New(apperror.ErrorTypeNotFound, "Recurring transaction not found")
+	ErrRecurringTransactionAuthorNotFound = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	// ErrRecurringTransactionDependent keeps dependents from scheduling
+	// money in or out; their parent sets up their allowance as a recurring
+	// income instead.
+	ErrRecurringTransactionDependent = apperror.New(apperror.ErrorTypeForbidden, "Dependents cannot manage recurring transactions")
+)
+
+func (s *recurringTransactionService) CreateRecurringTransaction(walletID, actorID string, input RecurringTransactionInput) (*entities.RecurringTransaction, error) {
+	author, err := s.userRepo.FindUserByID(actorID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": actorID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if author == nil {
+		return nil, ErrRecurringTransactionAuthorNotFound
+	}
+	if author.IsDependent() {
+		return nil, ErrRecurringTransactionDependent
+	}
+
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, author.ID); err != nil {
+		return nil, err
+	}
+
+	recurring, err := entities.NewRecurringTransaction(
+		walletID,
+		input.Type,
+		input.Amount,
+		input.Description,
+		input.CategoryID,
+		input.Rule,
+		input.StartDate,
+		author.ID,
+	)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := checkCategory(s.categoryRepo, s.logger, recurring.CreatedBy, recurring.CategoryID, recurring.Type); err != nil {
+		return nil, err
+	}
+
+	createdRecurring, err := s.recurringRepo.CreateRecurringTransaction(recurring)
+	if err != nil {
+		s.logger.Error(err, "Failed to create recurring transaction", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdRecurring, nil
+}
+
+func (s *recurringTransactionService) GetRecurringTransaction(walletID, actorID, recurringID string) (*entities.RecurringTransaction, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	recurring, err := s.recurringRepo.FindRecurringTransactionByID(recurringID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find recurring transaction", map[string]interface{}{
+			"recurring_id": recurringID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if recurring == nil || recurring.WalletID != walletID {
+		return nil, ErrRecurringTransactionNotFound
+	}
+
+	return recurring, nil
+}
+
+func (s *recurringTransactionService) ListRecurringTransactions(
+	walletID, actorID string,
+	filter repositories.RecurringTransactionFilter,
+	page query.Page,
+) ([]*entities.RecurringTransaction, *query.Cursor, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkCurrency(filter.Currency); err != nil {
+		return nil, nil, err
+	}
+	filter.Currency = strings.ToUpper(strings.TrimSpace(filter.Currency))
+	filter.CategoryIDs = entities.UniqueIDs(filter.CategoryIDs)
+
+	recurring, next, err := s.recurringRepo.FindRecurringTransactionsByWalletID(walletID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list recurring transactions", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return recurring, next, nil
+}
+
+func (s *recurringTransactionService) UpdateRecurringTransaction(walletID, actorID, recurringID string, input RecurringTransactionInput) (*entities.RecurringTransaction, error) {
+	recurring, err := s.managedRecurring(walletID, actorID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recurring.Update(input.Type, input.Amount, input.Description, input.CategoryID, input.Rule, input.StartDate)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := checkCategory(s.categoryRepo, s.logger, recurring.CreatedBy, recurring.CategoryID, recurring.Type); err != nil {
+		return nil, err
+	}
+
+	updatedRecurring, err := s.recurringRepo.UpdateRecurringTransaction(recurring)
+	if err != nil {
+		s.logger.Error(err, "Failed to update recurring transaction", map[string]interface{}{
+			"recurring_id": recurringID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return updatedRecurring, nil
+}
+
+// DeleteRecurringTransaction stops the schedule; the transactions it already
+// created are kept.
+func (s *recurringTransactionService) DeleteRecurringTransaction(walletID, actorID, recurringID string) error {
+	recurring, err := s.managedRecurring(walletID, actorID, recurringID)
+	if err != nil {
+		return err
+	}
+
+	recurring.Delete()
+
+	if err := s.recurringRepo.DeleteRecurringTransaction(recurring); err != nil {
+		s.logger.Error(err, "Failed to delete recurring transaction", map[string]interface{}{
+			"recurring_id": recurringID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// PreviewOccurrences lists the next occurrences still to be created, with
+// skipped and modified ones marked as such.
+func (s *recurringTransactionService) PreviewOccurrences(walletID, actorID, recurringID string, limit int) ([]*entities.Occurrence, error) {
+	recurring, err := s.GetRecurringTransaction(walletID, actorID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.findOverrides(recurring)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := recurring.UpcomingDates(limit)
+	occurrences := make([]*entities.Occurrence, 0, len(dates))
+	for _, date := range dates {
+		occurrences = append(occurrences, recurring.Occurrence(date, overrides[occurrenceKey(date)]))
+	}
+
+	return occurrences, nil
+}
+
+func (s *recurringTransactionService) OverrideOccurrence(walletID, actorID, recurringID string, date time.Time, input OccurrenceInput) (*entities.Occurrence, error) {
+	recurring, err := s.managedRecurring(walletID, actorID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := recurring.NewOverride(date, input.Skip, input.Amount, input.Description, input.CategoryID)
+	if err != nil {
+		if errors.Is(err, entities.ErrNotAnOccurrence) || errors.Is(err, entities.ErrOccurrenceMaterialized) {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err).
+				AddContext("date", occurrenceKey(date))
+		}
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := checkCategory(s.categoryRepo, s.logger, recurring.CreatedBy, override.CategoryID, recurring.Type); err != nil {
+		return nil, err
+	}
+
+	savedOverride, err := s.recurringRepo.SaveOccurrenceOverride(override)
+	if err != nil {
+		s.logger.Error(err, "Failed to save occurrence override", map[string]interface{}{
+			"recurring_id": recurringID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return recurring.Occurrence(savedOverride.Date, savedOverride), nil
+}
+
+// RestoreOccurrence drops the override of an occurrence, so it is created
+// from the recurring transaction again.
+func (s *recurringTransactionService) RestoreOccurrence(walletID, actorID, recurringID string, date time.Time) error {
+	if _, err := s.managedRecurring(walletID, actorID, recurringID); err != nil {
+		return err
+	}
+
+	if err := s.recurringRepo.DeleteOccurrenceOverride(recurringID, date); err != nil {
+		s.logger.Error(err, "Failed to delete occurrence override", map[string]interface{}{
+			"recurring_id": recurringID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// managedRecurring returns a recurring transaction the actor may change.
+// Dependents can see the schedules of their wallets but not change them.
+func (s *recurringTransactionService) managedRecurring(walletID, actorID, recurringID string) (*entities.RecurringTransaction, error) {
+	actor, err := s.userRepo.FindUserByID(actorID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": actorID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if actor == nil {
+		return nil, ErrRecurringTransactionAuthorNotFound
+	}
+	if actor.IsDependent() {
+		return nil, ErrRecurringTransactionDependent
+	}
+
+	return s.GetRecurringTransaction(walletID, actorID, recurringID)
+}
+
+// MaterializeDue goes through every schedule with occurrences due. A schedule
+// that fails is logged and left for the next run, so one bad template does
+// not hold back the others.
+func (s *recurringTransactionService) MaterializeDue(now time.Time) (int, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	due, err := s.recurringRepo.FindDueRecurringTransactions(today)
+	if err != nil {
+		s.logger.Error(err, "Failed to find due recurring transactions", map[string]interface{}{
+			"date": today,
+		})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	created := 0
+	for _, recurring := range due {
+		count, err := s.materialize(recurring, today)
+		if err != nil {
+			s.logger.Error(err, "Failed to materialize recurring transaction", map[string]interface{}{
+				"recurring_id": recurring.ID,
+			})
+			continue
+		}
+		created += count
+	}
+
+	return created, nil
+}
+
+func (s *recurringTransactionService) materialize(recurring *entities.RecurringTransaction, today time.Time) (int, error) {
+	overrides, err := s.findOverrides(recurring)
+	if err != nil {
+		return 0, err
+	}
+
+	transactions := make([]*entities.Transaction, 0)
+	for _, date := range recurring.DueDates(today) {
+		occurrence := recurring.Occurrence(date, overrides[occurrenceKey(date)])
+		if occurrence.Skipped {
+			continue
+		}
+
+		transaction, err := recurring.Materialize(occurrence)
+		if err != nil {
+			return 0, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	recurring.MarkMaterialized(today)
+
+	return s.transactionService.MaterializeOccurrences(recurring, transactions)
+}
+
+// findOverrides indexes the overrides of recurring by occurrenceKey.
+func (s *recurringTransactionService) findOverrides(recurring *entities.RecurringTransaction) (map[string]*entities.OccurrenceOverride, error) {
+	overrides, err := s.recurringRepo.FindOccurrenceOverrides(recurring.ID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find occurrence overrides", map[string]interface{}{
+			"recurring_id": recurring.ID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	byDate := make(map[string]*entities.OccurrenceOverride, len(overrides))
+	for _, override := range overrides {
+		byDate[occurrenceKey(override.Date)] = override
+	}
+	return byDate, nil
+}
+
+func occurrenceKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+func NewRecurringTransactionService(
+	recurringRepo repositories.RecurringTransactionRepository,
+	categoryRepo repositories.CategoryRepository,
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+	transactionService TransactionService,
+	logger logger.Logger,
+) RecurringTransactionService {
+	return &recurringTransactionService{
+		recurringRepo:      recurringRepo,
+		categoryRepo:       categoryRepo,
+		walletRepo:         walletRepo,
+		userRepo:           userRepo,
+		transactionService: transactionService,
+		logger:             logger,
+	}
+}
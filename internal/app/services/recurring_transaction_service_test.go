@@ -0,0 +1,500 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRecurringTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecurringTransactionRepository) CreateRecurringTransaction(recurring *entities.RecurringTransaction) (*entities.RecurringTransaction, error) {
+	args := m.Called(recurring)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RecurringTransaction), args.Error(1)
+}
+
+func (m *MockRecurringTransactionRepository) FindRecurringTransactionByID(id string) (*entities.RecurringTransaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RecurringTransaction), args.Error(1)
+}
+
+func (m *MockRecurringTransactionRepository) FindRecurringTransactionsByWalletID(
+	walletID string,
+	filter repositories.RecurringTransactionFilter,
+	page query.Page,
+) ([]*entities.RecurringTransaction, *query.Cursor, error) {
+	args := m.Called(walletID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.RecurringTransaction), next, args.Error(2)
+}
+
+func (m *MockRecurringTransactionRepository) FindDueRecurringTransactions(today time.Time) ([]*entities.RecurringTransaction, error) {
+	args := m.Called(today)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.RecurringTransaction), args.Error(1)
+}
+
+func (m *MockRecurringTransactionRepository) UpdateRecurringTransaction(recurring *entities.RecurringTransaction) (*entities.RecurringTransaction, error) {
+	args := m.Called(recurring)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RecurringTransaction), args.Error(1)
+}
+
+func (m *MockRecurringTransactionRepository) DeleteRecurringTransaction(recurring *entities.RecurringTransaction) error {
+	args := m.Called(recurring)
+	return args.Error(0)
+}
+
+func (m *MockRecurringTransactionRepository) FindOccurrenceOverrides(recurringID string) ([]*entities.OccurrenceOverride, error) {
+	args := m.Called(recurringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.OccurrenceOverride), args.Error(1)
+}
+
+func (m *MockRecurringTransactionRepository) SaveOccurrenceOverride(override *entities.OccurrenceOverride) (*entities.OccurrenceOverride, error) {
+	args := m.Called(override)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.OccurrenceOverride), args.Error(1)
+}
+
+func (m *MockRecurringTransactionRepository) DeleteOccurrenceOverride(recurringID string, date time.Time) error {
+	args := m.Called(recurringID, date)
+	return args.Error(0)
+}
+
+func (m *MockRecurringTransactionRepository) MaterializeOccurrences(
+	recurring *entities.RecurringTransaction,
+	transactions []*entities.Transaction,
+	approvals []*entities.Approval,
+) ([]*entities.Transaction, error) {
+	args := m.Called(recurring, transactions, approvals)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Transaction), args.Error(1)
+}
+
+// newOccurrenceService returns the transaction service that creates the
+// occurrences of the schedules in repo, with no rules or limits.
+func newOccurrenceService(repo *MockRecurringTransactionRepository) services.TransactionService {
+	return services.NewTransactionService(new(MockTransactionRepository), newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), repo, newRateService(), mocks.NewMockLogger())
+}
+
+func newTestRecurringTransaction(t *testing.T, id, rule string) *entities.RecurringTransaction {
+	t.Helper()
+	parsed, err := entities.ParseRecurrenceRule(rule)
+	require.NoError(t, err)
+
+	recurring, err := entities.NewRecurringTransaction(
+		"wallet-id",
+		entities.TransactionTypeExpense,
+		newMoney(t, "1500.00", "USD"),
+		"Rent",
+		"",
+		parsed,
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		"user-id",
+	)
+	require.NoError(t, err)
+	recurring.ID = id
+	return recurring
+}
+
+func TestRecurringTransactionService_CreateRecurringTransaction(t *testing.T) {
+	rule, err := entities.ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=5")
+	require.NoError(t, err)
+	input := services.RecurringTransactionInput{
+		Type:      entities.TransactionTypeExpense,
+		Amount:    newMoney(t, "1500.00", "USD"),
+		Rule:      rule,
+		StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		input     services.RecurringTransactionInput
+		mockSetup func(*MockRecurringTransactionRepository, *MockUserRepository, *mocks.MockLogger)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:  "success",
+			input: input,
+			mockSetup: func(rr *MockRecurringTransactionRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+				rr.On("CreateRecurringTransaction", mock.MatchedBy(func(recurring *entities.RecurringTransaction) bool {
+					return recurring.WalletID == "wallet-id" &&
+						recurring.NextOccurrence.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC))
+				})).Return(newTestRecurringTransaction(t, "recurring-id", "FREQ=MONTHLY;BYMONTHDAY=5"), nil)
+			},
+		},
+		{
+			name:  "unknown author",
+			input: input,
+			mockSetup: func(rr *MockRecurringTransactionRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(nil, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:  "dependent",
+			input: input,
+			mockSetup: func(rr *MockRecurringTransactionRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").
+					Return(&entities.User{ID: "user-id", Role: entities.RoleDependent, ParentID: "parent-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:  "invalid rule",
+			input: services.RecurringTransactionInput{Type: input.Type, Amount: input.Amount, StartDate: input.StartDate},
+			mockSetup: func(rr *MockRecurringTransactionRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:  "repository error",
+			input: input,
+			mockSetup: func(rr *MockRecurringTransactionRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+				rr.On("CreateRecurringTransaction", mock.Anything).Return(nil, errors.New("database error"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurringRepo := new(MockRecurringTransactionRepository)
+			userRepo := new(MockUserRepository)
+			logger := mocks.NewMockLogger()
+			tt.mockSetup(recurringRepo, userRepo, logger)
+
+			service := services.NewRecurringTransactionService(recurringRepo, new(MockCategoryRepository), newWalletRepository(), userRepo, newOccurrenceService(recurringRepo), logger)
+			recurring, err := service.CreateRecurringTransaction("wallet-id", "user-id", tt.input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, recurring)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "recurring-id", recurring.ID)
+			}
+			recurringRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecurringTransactionService_GetRecurringTransaction_OtherWallet(t *testing.T) {
+	repo := new(MockRecurringTransactionRepository)
+	repo.On("FindRecurringTransactionByID", "recurring-id").
+		Return(newTestRecurringTransaction(t, "recurring-id", "FREQ=MONTHLY"), nil)
+
+	service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), new(MockUserRepository), newOccurrenceService(repo), mocks.NewMockLogger())
+	recurring, err := service.GetRecurringTransaction("other-wallet-id", "user-id", "recurring-id")
+
+	assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	assert.Nil(t, recurring)
+}
+
+func TestRecurringTransactionService_ListRecurringTransactions(t *testing.T) {
+	page, err := repositories.RecurringTransactionSorts.NewPage(repositories.DefaultRecurringTransactionSort, nil, 1)
+	require.NoError(t, err)
+
+	t.Run("lists a page of the wallet schedules", func(t *testing.T) {
+		next := page.Next("2026-11-05", "2026-10-19T12:00:00Z", "recurring-id")
+		repo := new(MockRecurringTransactionRepository)
+		repo.On("FindRecurringTransactionsByWalletID", "wallet-id", repositories.RecurringTransactionFilter{
+			Types:       []entities.TransactionType{entities.TransactionTypeIncome},
+			CategoryIDs: []string{"salary-id"},
+			Currency:    "USD",
+		}, page).Return([]*entities.RecurringTransaction{newTestRecurringTransaction(t, "recurring-id", "FREQ=MONTHLY;BYMONTHDAY=5")}, next, nil)
+
+		service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), new(MockUserRepository), newOccurrenceService(repo), mocks.NewMockLogger())
+		recurring, cursor, err := service.ListRecurringTransactions("wallet-id", "user-id", repositories.RecurringTransactionFilter{
+			Types:       []entities.TransactionType{entities.TransactionTypeIncome},
+			CategoryIDs: []string{"salary-id", "salary-id"},
+			Currency:    "usd",
+		}, page)
+
+		require.NoError(t, err)
+		assert.Len(t, recurring, 1)
+		assert.Equal(t, next, cursor)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown currency", func(t *testing.T) {
+		repo := new(MockRecurringTransactionRepository)
+
+		service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), new(MockUserRepository), newOccurrenceService(repo), mocks.NewMockLogger())
+		_, _, err := service.ListRecurringTransactions("wallet-id", "user-id", repositories.RecurringTransactionFilter{Currency: "XYZ"}, page)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		repo.AssertNotCalled(t, "FindRecurringTransactionsByWalletID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		repo := new(MockRecurringTransactionRepository)
+
+		service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), new(MockUserRepository), newOccurrenceService(repo), mocks.NewMockLogger())
+		recurring, _, err := service.ListRecurringTransactions("wallet-id", "stranger-id", repositories.RecurringTransactionFilter{}, page)
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+		assert.Nil(t, recurring)
+		repo.AssertNotCalled(t, "FindRecurringTransactionsByWalletID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRecurringTransactionService_PreviewOccurrences(t *testing.T) {
+	recurring := newTestRecurringTransaction(t, "recurring-id", "FREQ=MONTHLY;BYMONTHDAY=5")
+	amount := newMoney(t, "1600.00", "USD")
+
+	repo := new(MockRecurringTransactionRepository)
+	repo.On("FindRecurringTransactionByID", "recurring-id").Return(recurring, nil)
+	repo.On("FindOccurrenceOverrides", "recurring-id").Return([]*entities.OccurrenceOverride{
+		{RecurringID: "recurring-id", Date: time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC), Skip: true},
+		{RecurringID: "recurring-id", Date: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), Amount: amount},
+	}, nil)
+
+	service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), new(MockUserRepository), newOccurrenceService(repo), mocks.NewMockLogger())
+	occurrences, err := service.PreviewOccurrences("wallet-id", "user-id", "recurring-id", 3)
+
+	require.NoError(t, err)
+	require.Len(t, occurrences, 3)
+	assert.False(t, occurrences[0].Skipped)
+	assert.False(t, occurrences[0].Modified)
+	assert.True(t, occurrences[1].Skipped)
+	assert.True(t, occurrences[2].Modified)
+	assert.Equal(t, int64(160000), occurrences[2].Amount.MinorUnits())
+}
+
+func TestRecurringTransactionService_OverrideOccurrence(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      time.Time
+		mockSetup func(*MockRecurringTransactionRepository, *mocks.MockLogger)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name: "skip",
+			date: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC),
+			mockSetup: func(rr *MockRecurringTransactionRepository, l *mocks.MockLogger) {
+				rr.On("SaveOccurrenceOverride", mock.MatchedBy(func(override *entities.OccurrenceOverride) bool {
+					return override.Skip && override.RecurringID == "recurring-id"
+				})).Return(&entities.OccurrenceOverride{
+					RecurringID: "recurring-id",
+					Date:        time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC),
+					Skip:        true,
+				}, nil)
+			},
+		},
+		{
+			name:      "not an occurrence",
+			date:      time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+			mockSetup: func(rr *MockRecurringTransactionRepository, l *mocks.MockLogger) {},
+			wantErr:   true,
+			errType:   apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:      "already created",
+			date:      time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			mockSetup: func(rr *MockRecurringTransactionRepository, l *mocks.MockLogger) {},
+			wantErr:   true,
+			errType:   apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name: "repository error",
+			date: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC),
+			mockSetup: func(rr *MockRecurringTransactionRepository, l *mocks.MockLogger) {
+				rr.On("SaveOccurrenceOverride", mock.Anything).Return(nil, errors.New("database error"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurring := newTestRecurringTransaction(t, "recurring-id", "FREQ=MONTHLY;BYMONTHDAY=5")
+			recurring.MarkMaterialized(time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC))
+
+			repo := new(MockRecurringTransactionRepository)
+			logger := mocks.NewMockLogger()
+			repo.On("FindRecurringTransactionByID", "recurring-id").Return(recurring, nil)
+			tt.mockSetup(repo, logger)
+
+			service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), newUserRepository(), newOccurrenceService(repo), logger)
+			occurrence, err := service.OverrideOccurrence("wallet-id", "user-id", "recurring-id", tt.date, services.OccurrenceInput{Skip: true})
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, occurrence)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, occurrence.Skipped)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecurringTransactionService_Dependent(t *testing.T) {
+	repo := new(MockRecurringTransactionRepository)
+	input := services.RecurringTransactionInput{Type: entities.TransactionTypeIncome, Amount: newMoney(t, "50.00", "USD")}
+	date := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepositoryFor("teen-id"), newUserRepository(), newOccurrenceService(repo), mocks.NewMockLogger())
+
+	_, err := service.UpdateRecurringTransaction("wallet-id", "teen-id", "recurring-id", input)
+	assert.ErrorIs(t, err, services.ErrRecurringTransactionDependent)
+
+	err = service.DeleteRecurringTransaction("wallet-id", "teen-id", "recurring-id")
+	assert.ErrorIs(t, err, services.ErrRecurringTransactionDependent)
+
+	_, err = service.OverrideOccurrence("wallet-id", "teen-id", "recurring-id", date, services.OccurrenceInput{Skip: true})
+	assert.ErrorIs(t, err, services.ErrRecurringTransactionDependent)
+
+	err = service.RestoreOccurrence("wallet-id", "teen-id", "recurring-id", date)
+	assert.ErrorIs(t, err, services.ErrRecurringTransactionDependent)
+
+	repo.AssertNotCalled(t, "FindRecurringTransactionByID", mock.Anything)
+}
+
+func TestRecurringTransactionService_MaterializeDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	rent := newTestRecurringTransaction(t, "rent-id", "FREQ=MONTHLY;BYMONTHDAY=5")
+	broken := newTestRecurringTransaction(t, "broken-id", "FREQ=MONTHLY;BYMONTHDAY=1")
+
+	repo := new(MockRecurringTransactionRepository)
+	logger := mocks.NewMockLogger()
+	repo.On("FindDueRecurringTransactions", today).Return([]*entities.RecurringTransaction{broken, rent}, nil)
+	repo.On("FindOccurrenceOverrides", "broken-id").Return(nil, errors.New("database error"))
+	repo.On("FindOccurrenceOverrides", "rent-id").Return([]*entities.OccurrenceOverride{
+		{RecurringID: "rent-id", Date: time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC), Skip: true},
+	}, nil)
+	repo.On("MaterializeOccurrences", rent, mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+		return len(transactions) == 2 &&
+			transactions[0].OccurrenceDate.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) &&
+			transactions[1].OccurrenceDate.Equal(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)) &&
+			transactions[1].RecurringID == "rent-id"
+	}), []*entities.Approval{}).Return([]*entities.Transaction{newTestTransaction(t), newTestTransaction(t)}, nil)
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), new(MockUserRepository), newOccurrenceService(repo), logger)
+	created, err := service.MaterializeDue(now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, created)
+	assert.Equal(t, today, rent.MaterializedThrough)
+	assert.Equal(t, time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC), rent.NextOccurrence)
+	repo.AssertExpectations(t)
+}
+
+func TestRecurringTransactionService_MaterializeDue_RepositoryError(t *testing.T) {
+	repo := new(MockRecurringTransactionRepository)
+	logger := mocks.NewMockLogger()
+	repo.On("FindDueRecurringTransactions", mock.Anything).Return(nil, errors.New("database error"))
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	service := services.NewRecurringTransactionService(repo, new(MockCategoryRepository), newWalletRepository(), new(MockUserRepository), newOccurrenceService(repo), logger)
+	created, err := service.MaterializeDue(time.Now())
+
+	assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	assert.Zero(t, created)
+}
+
+func TestTransactionService_MaterializeOccurrences(t *testing.T) {
+	recurring := newTestRecurringTransaction(t, "rent-id", "FREQ=MONTHLY;BYMONTHDAY=5")
+	newOccurrences := func(t *testing.T) []*entities.Transaction {
+		occurrence := recurring.Occurrence(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), nil)
+		transaction, err := recurring.Materialize(occurrence)
+		require.NoError(t, err)
+		return []*entities.Transaction{transaction}
+	}
+	newService := func(repo *MockRecurringTransactionRepository, ruleRepo *MockRuleRepository, limitRepo *MockSpendingLimitRepository) services.TransactionService {
+		return services.NewTransactionService(new(MockTransactionRepository), newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), ruleRepo, newUserRepository(), new(MockApprovalRepository), limitRepo, new(MockNotificationRepository), newActivityRepository(), repo, newRateService(), mocks.NewMockLogger())
+	}
+
+	t.Run("applies the rules of the author", func(t *testing.T) {
+		rule, err := entities.NewRule("user-id", "Housing", 1,
+			entities.RuleConditions{DescriptionPattern: "rent"},
+			entities.RuleActions{CategoryID: "housing-id", CategoryType: entities.TransactionTypeExpense},
+		)
+		require.NoError(t, err)
+		ruleRepo := new(MockRuleRepository)
+		ruleRepo.On("FindRulesByUserID", "user-id").Return([]*entities.Rule{rule}, nil)
+		occurrences := newOccurrences(t)
+		repo := new(MockRecurringTransactionRepository)
+		repo.On("MaterializeOccurrences", recurring, mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+			return transactions[0].CategoryID == "housing-id" && !transactions[0].AwaitingApproval
+		}), []*entities.Approval{}).Return(occurrences, nil)
+
+		created, err := newService(repo, ruleRepo, newLimitRepository()).MaterializeOccurrences(recurring, occurrences)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("holds an occurrence over a limit for review", func(t *testing.T) {
+		limit, err := entities.NewSpendingLimit("wallet-id", "user-id", "", entities.LimitPeriodTransaction, newMoney(t, "1000.00", "USD"), "owner-id")
+		require.NoError(t, err)
+		limitRepo := new(MockSpendingLimitRepository)
+		limitRepo.On("FindMemberSpendingLimits", "wallet-id", "user-id").Return([]*entities.SpendingLimit{limit}, nil)
+		occurrences := newOccurrences(t)
+		repo := new(MockRecurringTransactionRepository)
+		repo.On("MaterializeOccurrences", recurring, mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+			return transactions[0].AwaitingApproval
+		}), mock.MatchedBy(func(approvals []*entities.Approval) bool {
+			return len(approvals) == 1 && approvals[0].TransactionID == occurrences[0].ID
+		})).Return(occurrences, nil)
+
+		created, err := newService(repo, newRuleRepository(), limitRepo).MaterializeOccurrences(recurring, occurrences)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+		repo.AssertExpectations(t)
+	})
+}
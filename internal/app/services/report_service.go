@@ -0,0 +1,164 @@
+package services
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ReportService interface {
+	// CategoryReport adds up the wallet income and expenses dated within
+	// [from, to] by category. Split transactions count under the category
+	// of each line. When currency is set, each category is added up across
+	// currencies, converted at the rates of the last day of the period.
+	// Without a currency, the base currency of the actor is used when they
+	// have one.
+	CategoryReport(walletID, actorID string, from, to time.Time, currency string) ([]*entities.CategoryTotal, error)
+	// HouseholdCategoryReport is CategoryReport across every wallet of the
+	// household, which actorID must be a member of.
+	HouseholdCategoryReport(householdID, actorID string, from, to time.Time, currency string) ([]*entities.CategoryTotal, error)
+}
+
+type reportService struct {
+	reportRepo    repositories.ReportRepository
+	walletRepo    repositories.WalletRepository
+	householdRepo repositories.HouseholdRepository
+	userRepo      repositories.UserRepository
+	rateService   ExchangeRateService
+	logger        logger.Logger
+}
+
+func (s *reportService) CategoryReport(walletID, actorID string, from, to time.Time, currency string) ([]*entities.CategoryTotal, error) {
+	if err := checkReportPeriod(from, to, currency); err != nil {
+		return nil, err
+	}
+
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	currency, err := displayCurrency(s.userRepo, s.logger, actorID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.reportRepo.FindCategoryTotals(walletID, from, to)
+	if err != nil {
+		s.logger.Error(err, "Failed to find category totals", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if currency == "" {
+		return totals, nil
+	}
+
+	return s.convertTotals(totals, currency, to)
+}
+
+func (s *reportService) HouseholdCategoryReport(householdID, actorID string, from, to time.Time, currency string) ([]*entities.CategoryTotal, error) {
+	if err := checkReportPeriod(from, to, currency); err != nil {
+		return nil, err
+	}
+
+	household, err := s.householdRepo.FindHouseholdByID(householdID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find household", map[string]interface{}{
+			"household_id": householdID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if household == nil || household.Member(actorID) == nil {
+		return nil, ErrHouseholdNotFound
+	}
+
+	currency, err = displayCurrency(s.userRepo, s.logger, actorID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.reportRepo.FindHouseholdCategoryTotals(householdID, from, to)
+	if err != nil {
+		s.logger.Error(err, "Failed to find household category totals", map[string]interface{}{
+			"household_id": householdID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if currency == "" {
+		return totals, nil
+	}
+
+	return s.convertTotals(totals, currency, to)
+}
+
+func checkReportPeriod(from, to time.Time, currency string) error {
+	if to.Before(from) {
+		return apperror.New(apperror.ErrorTypeValidation, "Report end date must not be before its start date").
+			AddContext("field", "to")
+	}
+
+	return checkCurrency(currency)
+}
+
+// convertTotals merges the totals of a category in different currencies
+// into one in currencyCode, keeping the order the categories came in.
+func (s *reportService) convertTotals(totals []*entities.CategoryTotal, currencyCode string, date time.Time) ([]*entities.CategoryTotal, error) {
+	type categoryKey struct {
+		categoryID      string
+		transactionType entities.TransactionType
+	}
+
+	keys := make([]categoryKey, 0, len(totals))
+	amounts := make(map[categoryKey][]money.Money)
+	lineCounts := make(map[categoryKey]int)
+	for _, total := range totals {
+		key := categoryKey{total.CategoryID, total.Type}
+		if _, ok := amounts[key]; !ok {
+			keys = append(keys, key)
+		}
+		amounts[key] = append(amounts[key], total.Total)
+		lineCounts[key] += total.LineCount
+	}
+
+	converted := make([]*entities.CategoryTotal, 0, len(keys))
+	for _, key := range keys {
+		total, conversions, err := s.rateService.ConvertTotal(amounts[key], currencyCode, date)
+		if err != nil {
+			return nil, err
+		}
+
+		converted = append(converted, &entities.CategoryTotal{
+			CategoryID:  key.categoryID,
+			Type:        key.transactionType,
+			Total:       total,
+			LineCount:   lineCounts[key],
+			Conversions: conversions,
+		})
+	}
+
+	return converted, nil
+}
+
+func NewReportService(
+	reportRepo repositories.ReportRepository,
+	walletRepo repositories.WalletRepository,
+	householdRepo repositories.HouseholdRepository,
+	userRepo repositories.UserRepository,
+	rateService ExchangeRateService,
+	logger logger.Logger,
+) ReportService {
+	return &reportService{
+		reportRepo:    reportRepo,
+		walletRepo:    walletRepo,
+		householdRepo: householdRepo,
+		userRepo:      userRepo,
+		rateService:   rateService,
+		logger:        logger,
+	}
+}
@@ -0,0 +1,181 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) FindCategoryTotals(walletID string, from, to time.Time) ([]*entities.CategoryTotal, error) {
+	args := m.Called(walletID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.CategoryTotal), args.Error(1)
+}
+
+func (m *MockReportRepository) FindHouseholdCategoryTotals(householdID string, from, to time.Time) ([]*entities.CategoryTotal, error) {
+	args := m.Called(householdID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.CategoryTotal), args.Error(1)
+}
+
+func TestReportService_CategoryReport(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		actorID   string
+		from      time.Time
+		to        time.Time
+		currency  string
+		mockSetup func(*MockReportRepository, *mocks.MockLogger)
+		wantTotal int64
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name: "success",
+			from: from,
+			to:   to,
+			mockSetup: func(rr *MockReportRepository, l *mocks.MockLogger) {
+				rr.On("FindCategoryTotals", "wallet-id", from, to).Return([]*entities.CategoryTotal{
+					{CategoryID: "groceries-id", Type: entities.TransactionTypeExpense, Total: newMoney(t, "30.00", "BRL"), LineCount: 1},
+				}, nil)
+			},
+		},
+		{
+			name:     "converted into one currency",
+			from:     from,
+			to:       to,
+			currency: "BRL",
+			mockSetup: func(rr *MockReportRepository, l *mocks.MockLogger) {
+				rr.On("FindCategoryTotals", "wallet-id", from, to).Return([]*entities.CategoryTotal{
+					{CategoryID: "groceries-id", Type: entities.TransactionTypeExpense, Total: newMoney(t, "30.00", "BRL"), LineCount: 1},
+					{CategoryID: "groceries-id", Type: entities.TransactionTypeExpense, Total: newMoney(t, "10.00", "USD"), LineCount: 2},
+				}, nil)
+			},
+			wantTotal: 8000,
+		},
+		{
+			name:      "unsupported currency",
+			from:      from,
+			to:        to,
+			currency:  "XYZ",
+			mockSetup: func(rr *MockReportRepository, l *mocks.MockLogger) {},
+			wantErr:   true,
+			errType:   apperror.ErrorTypeValidation,
+		},
+		{
+			name:      "end before start",
+			from:      to,
+			to:        from,
+			mockSetup: func(rr *MockReportRepository, l *mocks.MockLogger) {},
+			wantErr:   true,
+			errType:   apperror.ErrorTypeValidation,
+		},
+		{
+			name:      "non-member",
+			actorID:   "stranger-id",
+			from:      from,
+			to:        to,
+			mockSetup: func(rr *MockReportRepository, l *mocks.MockLogger) {},
+			wantErr:   true,
+			errType:   apperror.ErrorTypeForbidden,
+		},
+		{
+			name: "repository error",
+			from: from,
+			to:   to,
+			mockSetup: func(rr *MockReportRepository, l *mocks.MockLogger) {
+				rr.On("FindCategoryTotals", "wallet-id", from, to).Return(nil, errors.New("database error"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportRepo := new(MockReportRepository)
+			logger := mocks.NewMockLogger()
+			tt.mockSetup(reportRepo, logger)
+
+			actorID := tt.actorID
+			if actorID == "" {
+				actorID = "user-id"
+			}
+
+			rates := newRateService(newRate(t, "USD", "BRL", "5", to))
+			service := services.NewReportService(reportRepo, newWalletRepository(), new(MockHouseholdRepository), newUserRepository(), rates, logger)
+			totals, err := service.CategoryReport("wallet-id", actorID, tt.from, tt.to, tt.currency)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, totals)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, totals, 1)
+			}
+			if tt.wantTotal != 0 {
+				assert.Equal(t, tt.wantTotal, totals[0].Total.MinorUnits())
+				assert.Equal(t, 3, totals[0].LineCount)
+				assert.Len(t, totals[0].Conversions, 2)
+			}
+			reportRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReportService_HouseholdCategoryReport(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("adds up the household wallets", func(t *testing.T) {
+		reportRepo := new(MockReportRepository)
+		reportRepo.On("FindHouseholdCategoryTotals", "household-id", from, to).Return([]*entities.CategoryTotal{
+			{CategoryID: "groceries-id", Type: entities.TransactionTypeExpense, Total: newMoney(t, "30.00", "BRL"), LineCount: 1},
+			{CategoryID: "groceries-id", Type: entities.TransactionTypeExpense, Total: newMoney(t, "10.00", "USD"), LineCount: 2},
+		}, nil)
+		householdRepo := new(MockHouseholdRepository)
+		householdRepo.On("FindHouseholdByID", "household-id").Return(newTestHousehold(), nil)
+
+		rates := newRateService(newRate(t, "USD", "BRL", "5", to))
+		service := services.NewReportService(reportRepo, newWalletRepository(), householdRepo, newHouseholdUserRepository(), rates, mocks.NewMockLogger())
+		totals, err := service.HouseholdCategoryReport("household-id", "member-id", from, to, "BRL")
+
+		assert.NoError(t, err)
+		assert.Len(t, totals, 1)
+		assert.Equal(t, int64(8000), totals[0].Total.MinorUnits())
+		reportRepo.AssertExpectations(t)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		reportRepo := new(MockReportRepository)
+		householdRepo := new(MockHouseholdRepository)
+		householdRepo.On("FindHouseholdByID", "household-id").Return(newTestHousehold(), nil)
+
+		service := services.NewReportService(reportRepo, newWalletRepository(), householdRepo, newHouseholdUserRepository(), newRateService(), mocks.NewMockLogger())
+		totals, err := service.HouseholdCategoryReport("household-id", "stranger-id", from, to, "")
+
+		assert.ErrorIs(t, err, services.ErrHouseholdNotFound)
+		assert.Nil(t, totals)
+		reportRepo.AssertNotCalled(t, "FindHouseholdCategoryTotals", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
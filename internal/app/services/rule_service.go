@@ -0,0 +1,405 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// RuleInput holds the editable fields of a rule. The category type of the
+// actions is filled in from the category.
+type RuleInput struct {
+	Name       string
+	Priority   int
+	Conditions entities.RuleConditions
+	Actions    entities.RuleActions
+}
+
+// RuleHistory selects the past transactions rules run against: those the
+// user created in the wallet, dated within the optional range.
+type RuleHistory struct {
+	WalletID string
+	From     time.Time
+	To       time.Time
+}
+
+// RuleMatch is a transaction changed by rules, as it was before and after
+// they ran, with the rules that changed it in the order they ran.
+type RuleMatch struct {
+	Before *entities.Transaction
+	After  *entities.Transaction
+	Rules  []*entities.Rule
+}
+
+type RuleService interface {
+	CreateRule(userID string, input RuleInput) (*entities.Rule, error)
+	ListRules(userID string, filter repositories.RuleFilter, page query.Page) ([]*entities.Rule, *query.Cursor, error)
+	UpdateRule(userID, ruleID string, input RuleInput) (*entities.Rule, error)
+	DeleteRule(userID, ruleID string) error
+	// TestRule reports what a rule would change in the history without
+	// saving the rule or the transactions.
+	TestRule(userID string, input RuleInput, history RuleHistory) ([]*RuleMatch, error)
+	// ApplyRules runs every rule of the user against the history. Unlike
+	// on new transactions, rules replace the categories already set.
+	ApplyRules(userID string, history RuleHistory, dryRun bool) ([]*RuleMatch, error)
+}
+
+type ruleService struct {
+	ruleRepo        repositories.RuleRepository
+	transactionRepo repositories.TransactionRepository
+	walletRepo      repositories.WalletRepository
+	categoryRepo    repositories.CategoryRepository
+	tagRepo         repositories.TagRepository
+	payeeRepo       repositories.PayeeRepository
+	userRepo        repositories.UserRepository
+	logger          logger.Logger
+}
+
+var (
+	ErrRuleNotFound     = apperror.New(apperror.ErrorTypeNotFound, "Rule not found")
+	ErrRuleUserNotFound = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+)
+
+func (s *ruleService) CreateRule(userID string, input RuleInput) (*entities.Rule, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.newRule(userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	createdRule, err := s.ruleRepo.CreateRule(rule)
+	if err != nil {
+		s.logger.Error(err, "Failed to create rule", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdRule, nil
+}
+
+func (s *ruleService) ListRules(
+	userID string,
+	filter repositories.RuleFilter,
+	page query.Page,
+) ([]*entities.Rule, *query.Cursor, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, nil, err
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.CategoryIDs = entities.UniqueIDs(filter.CategoryIDs)
+	filter.PayeeIDs = entities.UniqueIDs(filter.PayeeIDs)
+
+	rules, next, err := s.ruleRepo.FindRulePageByUserID(userID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list rules", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return rules, next, nil
+}
+
+func (s *ruleService) UpdateRule(userID, ruleID string, input RuleInput) (*entities.Rule, error) {
+	rules, err := s.userRules(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := findRule(rules, ruleID)
+	if rule == nil {
+		return nil, ErrRuleNotFound
+	}
+
+	if err := rule.Update(input.Name, input.Priority, input.Conditions, input.Actions); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.checkReferences(rule); err != nil {
+		return nil, err
+	}
+
+	updatedRule, err := s.ruleRepo.UpdateRule(rule)
+	if err != nil {
+		s.logger.Error(err, "Failed to update rule", map[string]interface{}{
+			"rule_id": ruleID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return updatedRule, nil
+}
+
+func (s *ruleService) DeleteRule(userID, ruleID string) error {
+	rules, err := s.userRules(userID)
+	if err != nil {
+		return err
+	}
+
+	if findRule(rules, ruleID) == nil {
+		return ErrRuleNotFound
+	}
+
+	if err := s.ruleRepo.DeleteRule(ruleID); err != nil {
+		s.logger.Error(err, "Failed to delete rule", map[string]interface{}{
+			"rule_id": ruleID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// TestRule runs the rule as it would run on those transactions if they
+// were created now, which leaves their categories alone.
+func (s *ruleService) TestRule(userID string, input RuleInput, history RuleHistory) ([]*RuleMatch, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.newRule(userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.history(userID, history)
+	if err != nil {
+		return nil, err
+	}
+
+	return runRules([]*entities.Rule{rule}, transactions, false), nil
+}
+
+// ApplyRules saves the changed transactions together, so a failed save
+// changes none of them.
+func (s *ruleService) ApplyRules(userID string, history RuleHistory, dryRun bool) ([]*RuleMatch, error) {
+	rules, err := s.userRules(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.history(userID, history)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := runRules(rules, transactions, true)
+	if dryRun || len(matches) == 0 {
+		return matches, nil
+	}
+
+	changed := make([]*entities.Transaction, 0, len(matches))
+	for _, match := range matches {
+		changed = append(changed, match.After)
+	}
+
+	if err := s.transactionRepo.SaveTransactions(changed); err != nil {
+		s.logger.Error(err, "Failed to apply rules", map[string]interface{}{
+			"user_id":   userID,
+			"wallet_id": history.WalletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return matches, nil
+}
+
+// newRule builds a rule of the user from the input.
+func (s *ruleService) newRule(userID string, input RuleInput) (*entities.Rule, error) {
+	rule, err := entities.NewRule(userID, input.Name, input.Priority, input.Conditions, input.Actions)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.checkReferences(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// checkReferences makes sure the payee and category of the rule belong to
+// its user and that its wallet is accessible to them. Tags must be tags of
+// the user, or of the wallet the rule is limited to, so they fit every
+// transaction the rule matches.
+func (s *ruleService) checkReferences(rule *entities.Rule) error {
+	if payeeID := rule.Conditions.PayeeID; payeeID != "" {
+		payee, err := s.payeeRepo.FindPayeeByID(payeeID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find payee", map[string]interface{}{
+				"payee_id": payeeID,
+			})
+			return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if payee == nil || payee.UserID != rule.UserID {
+			return ErrPayeeNotFound
+		}
+	}
+
+	if walletID := rule.Conditions.WalletID; walletID != "" {
+		if _, err := walletMember(s.walletRepo, s.logger, walletID, rule.UserID); err != nil {
+			return err
+		}
+	}
+
+	if categoryID := rule.Actions.CategoryID; categoryID != "" {
+		category, err := s.categoryRepo.FindCategoryByID(categoryID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find category", map[string]interface{}{
+				"category_id": categoryID,
+			})
+			return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if category == nil || category.UserID != rule.UserID {
+			return ErrCategoryNotFound
+		}
+		rule.Actions.CategoryType = category.Type
+	}
+
+	if len(rule.Actions.TagIDs) == 0 {
+		return nil
+	}
+
+	tags, err := s.tagRepo.FindTagsByIDs(rule.Actions.TagIDs)
+	if err != nil {
+		s.logger.Error(err, "Failed to find tags", map[string]interface{}{
+			"rule_id": rule.ID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	found := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		switch tag.Scope {
+		case entities.TagScopeUser:
+			found[tag.ID] = tag.OwnerID == rule.UserID
+		case entities.TagScopeWallet:
+			found[tag.ID] = tag.OwnerID == rule.Conditions.WalletID
+		}
+	}
+
+	for _, tagID := range rule.Actions.TagIDs {
+		if !found[tagID] {
+			return apperror.New(apperror.ErrorTypeNotFound, "Tag not found").
+				AddContext("tag_id", tagID)
+		}
+	}
+
+	return nil
+}
+
+// history lists the transactions of the history selection.
+func (s *ruleService) history(userID string, history RuleHistory) ([]*entities.Transaction, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, history.WalletID, userID); err != nil {
+		return nil, err
+	}
+
+	filter, err := normalizeTransactionFilter(repositories.TransactionFilter{
+		From:      history.From,
+		To:        history.To,
+		MemberIDs: []string{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := walletTransactions(s.transactionRepo, s.logger, history.WalletID, filter, entities.MaxBulkTransactions)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) > entities.MaxBulkTransactions {
+		return nil, apperror.New(apperror.ErrorTypeValidation,
+			fmt.Sprintf("Rules can run against at most %d transactions at a time", entities.MaxBulkTransactions)).
+			AddContext("field", "from")
+	}
+
+	return transactions, nil
+}
+
+func (s *ruleService) checkUser(userID string) error {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return ErrRuleUserNotFound
+	}
+	return nil
+}
+
+// userRules loads every rule of an existing user, in the order they run.
+func (s *ruleService) userRules(userID string) ([]*entities.Rule, error) {
+	if err := s.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.FindRulesByUserID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list rules", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return rules, nil
+}
+
+func findRule(rules []*entities.Rule, id string) *entities.Rule {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+// runRules runs the rules on each transaction and returns those they
+// changed. Transfer legs are left alone.
+func runRules(rules []*entities.Rule, transactions []*entities.Transaction, overwrite bool) []*RuleMatch {
+	matches := make([]*RuleMatch, 0)
+	for _, transaction := range transactions {
+		// ApplyRules replaces the tag slice rather than changing it, so a
+		// shallow copy keeps the transaction as it was
+		before := *transaction
+		applied := entities.ApplyRules(rules, transaction, overwrite)
+		if len(applied) > 0 {
+			matches = append(matches, &RuleMatch{Before: &before, After: transaction, Rules: applied})
+		}
+	}
+	return matches
+}
+
+func NewRuleService(
+	ruleRepo repositories.RuleRepository,
+	transactionRepo repositories.TransactionRepository,
+	walletRepo repositories.WalletRepository,
+	categoryRepo repositories.CategoryRepository,
+	tagRepo repositories.TagRepository,
+	payeeRepo repositories.PayeeRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) RuleService {
+	return &ruleService{
+		ruleRepo:        ruleRepo,
+		transactionRepo: transactionRepo,
+		walletRepo:      walletRepo,
+		categoryRepo:    categoryRepo,
+		tagRepo:         tagRepo,
+		payeeRepo:       payeeRepo,
+		userRepo:        userRepo,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,362 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleRepository) CreateRule(rule *entities.Rule) (*entities.Rule, error) {
+	args := m.Called(rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) FindRulesByUserID(userID string) ([]*entities.Rule, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) FindRulePageByUserID(
+	userID string,
+	filter repositories.RuleFilter,
+	page query.Page,
+) ([]*entities.Rule, *query.Cursor, error) {
+	args := m.Called(userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.Rule), next, args.Error(2)
+}
+
+func (m *MockRuleRepository) UpdateRule(rule *entities.Rule) (*entities.Rule, error) {
+	args := m.Called(rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) DeleteRule(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// newRuleRepository has no rules, for tests that do not care about rules.
+func newRuleRepository() *MockRuleRepository {
+	repo := new(MockRuleRepository)
+	repo.On("FindRulesByUserID", mock.Anything).Return([]*entities.Rule{}, nil).Maybe()
+	return repo
+}
+
+// newTestRule builds a rule of "user-id" filing groceries under food-id.
+func newTestRule(t *testing.T, id string, priority int) *entities.Rule {
+	t.Helper()
+	rule, err := entities.NewRule("user-id", "Groceries", priority,
+		entities.RuleConditions{DescriptionPattern: "grocer"},
+		entities.RuleActions{CategoryID: "food-id", CategoryType: entities.TransactionTypeExpense},
+	)
+	require.NoError(t, err)
+	rule.ID = id
+	return rule
+}
+
+func newRuleService(
+	ruleRepo *MockRuleRepository,
+	transactionRepo *MockTransactionRepository,
+	categoryRepo *MockCategoryRepository,
+	tagRepo *MockTagRepository,
+) services.RuleService {
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+	walletRepo := new(MockWalletRepository)
+	walletRepo.On("FindWalletMember", "wallet-id", "user-id").
+		Return(&entities.WalletMember{WalletID: "wallet-id", UserID: "user-id", Role: entities.WalletRoleOwner}, nil).Maybe()
+	walletRepo.On("FindWalletMember", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	payeeRepo := new(MockPayeeRepository)
+	payeeRepo.On("FindPayeeByID", "payee-id").Return(&entities.Payee{ID: "payee-id", UserID: "user-id"}, nil).Maybe()
+	payeeRepo.On("FindPayeeByID", "foreign-payee-id").Return(&entities.Payee{ID: "foreign-payee-id", UserID: "other-user-id"}, nil).Maybe()
+
+	logger := mocks.NewMockLogger()
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return services.NewRuleService(ruleRepo, transactionRepo, walletRepo, categoryRepo, tagRepo, payeeRepo, userRepo, logger)
+}
+
+func TestRuleService_CreateRule(t *testing.T) {
+	food := &entities.Category{ID: "food-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+	foreign := &entities.Category{ID: "foreign-id", UserID: "other-user-id", Type: entities.TransactionTypeExpense}
+	userTag := &entities.Tag{ID: "user-tag-id", Scope: entities.TagScopeUser, OwnerID: "user-id"}
+	walletTag := &entities.Tag{ID: "wallet-tag-id", Scope: entities.TagScopeWallet, OwnerID: "wallet-id"}
+
+	tests := []struct {
+		name       string
+		conditions entities.RuleConditions
+		actions    entities.RuleActions
+		wantErr    bool
+		errType    apperror.ErrorType
+	}{
+		{
+			name:       "category and tags",
+			conditions: entities.RuleConditions{DescriptionPattern: "grocer", PayeeID: "payee-id"},
+			actions:    entities.RuleActions{CategoryID: "food-id", TagIDs: []string{"user-tag-id"}},
+		},
+		{
+			name:       "wallet tag on a rule of that wallet",
+			conditions: entities.RuleConditions{WalletID: "wallet-id"},
+			actions:    entities.RuleActions{TagIDs: []string{"wallet-tag-id"}},
+		},
+		{
+			name:       "wallet tag on a rule of any wallet",
+			conditions: entities.RuleConditions{DescriptionPattern: "grocer"},
+			actions:    entities.RuleActions{TagIDs: []string{"wallet-tag-id"}},
+			wantErr:    true,
+			errType:    apperror.ErrorTypeNotFound,
+		},
+		{
+			name:       "another user's category",
+			conditions: entities.RuleConditions{DescriptionPattern: "grocer"},
+			actions:    entities.RuleActions{CategoryID: "foreign-id"},
+			wantErr:    true,
+			errType:    apperror.ErrorTypeNotFound,
+		},
+		{
+			name:       "another user's payee",
+			conditions: entities.RuleConditions{PayeeID: "foreign-payee-id"},
+			actions:    entities.RuleActions{Description: "Market"},
+			wantErr:    true,
+			errType:    apperror.ErrorTypeNotFound,
+		},
+		{
+			name:       "inaccessible wallet",
+			conditions: entities.RuleConditions{WalletID: "other-wallet-id"},
+			actions:    entities.RuleActions{Description: "Market"},
+			wantErr:    true,
+			errType:    apperror.ErrorTypeForbidden,
+		},
+		{
+			name:       "invalid pattern",
+			conditions: entities.RuleConditions{DescriptionPattern: "grocer("},
+			actions:    entities.RuleActions{Description: "Market"},
+			wantErr:    true,
+			errType:    apperror.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleRepo := new(MockRuleRepository)
+			categoryRepo := new(MockCategoryRepository)
+			categoryRepo.On("FindCategoryByID", "food-id").Return(food, nil).Maybe()
+			categoryRepo.On("FindCategoryByID", "foreign-id").Return(foreign, nil).Maybe()
+			tagRepo := new(MockTagRepository)
+			tagRepo.On("FindTagsByIDs", mock.Anything).Return([]*entities.Tag{userTag, walletTag}, nil).Maybe()
+			if !tt.wantErr {
+				ruleRepo.On("CreateRule", mock.MatchedBy(func(rule *entities.Rule) bool {
+					return rule.UserID == "user-id" && rule.Actions.CategoryID == tt.actions.CategoryID &&
+						(rule.Actions.CategoryID == "" || rule.Actions.CategoryType == entities.TransactionTypeExpense)
+				})).Return(&entities.Rule{ID: "rule-id"}, nil)
+			}
+
+			service := newRuleService(ruleRepo, new(MockTransactionRepository), categoryRepo, tagRepo)
+			rule, err := service.CreateRule("user-id", services.RuleInput{
+				Name:       "Groceries",
+				Conditions: tt.conditions,
+				Actions:    tt.actions,
+			})
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, rule)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, rule)
+			}
+			ruleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRuleService_ListRules(t *testing.T) {
+	page, err := repositories.RuleSorts.NewPage(repositories.DefaultRuleSort, nil, 1)
+	require.NoError(t, err)
+
+	t.Run("lists a page of the user rules", func(t *testing.T) {
+		rule := newTestRule(t, "rule-id", 0)
+		next := page.Next("0", "2026-10-19T12:00:00Z", "rule-id")
+		ruleRepo := new(MockRuleRepository)
+		ruleRepo.On("FindRulePageByUserID", "user-id", repositories.RuleFilter{
+			Query:       "groc",
+			CategoryIDs: []string{"food-id"},
+			PayeeIDs:    []string{"payee-id"},
+		}, page).Return([]*entities.Rule{rule}, next, nil)
+
+		service := newRuleService(ruleRepo, new(MockTransactionRepository), new(MockCategoryRepository), new(MockTagRepository))
+		rules, cursor, err := service.ListRules("user-id", repositories.RuleFilter{
+			Query:       "groc ",
+			CategoryIDs: []string{"food-id", "food-id"},
+			PayeeIDs:    []string{"payee-id"},
+		}, page)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.Rule{rule}, rules)
+		assert.Equal(t, next, cursor)
+		ruleRepo.AssertExpectations(t)
+	})
+}
+
+func TestRuleService_UpdateRule(t *testing.T) {
+	t.Run("renames the rule", func(t *testing.T) {
+		ruleRepo := new(MockRuleRepository)
+		ruleRepo.On("FindRulesByUserID", "user-id").Return([]*entities.Rule{newTestRule(t, "rule-id", 0)}, nil)
+		ruleRepo.On("UpdateRule", mock.MatchedBy(func(rule *entities.Rule) bool {
+			return rule.ID == "rule-id" && rule.Name == "Market" && rule.Priority == 3
+		})).Return(&entities.Rule{ID: "rule-id"}, nil)
+
+		service := newRuleService(ruleRepo, new(MockTransactionRepository), new(MockCategoryRepository), new(MockTagRepository))
+		_, err := service.UpdateRule("user-id", "rule-id", services.RuleInput{
+			Name:       "Market",
+			Priority:   3,
+			Conditions: entities.RuleConditions{DescriptionPattern: "market"},
+			Actions:    entities.RuleActions{Description: "Market"},
+		})
+
+		assert.NoError(t, err)
+		ruleRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		ruleRepo := new(MockRuleRepository)
+		ruleRepo.On("FindRulesByUserID", "user-id").Return([]*entities.Rule{newTestRule(t, "rule-id", 0)}, nil)
+
+		service := newRuleService(ruleRepo, new(MockTransactionRepository), new(MockCategoryRepository), new(MockTagRepository))
+		_, err := service.UpdateRule("user-id", "missing-id", services.RuleInput{Name: "Market"})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		ruleRepo.AssertNotCalled(t, "UpdateRule", mock.Anything)
+	})
+}
+
+func TestRuleService_DeleteRule(t *testing.T) {
+	ruleRepo := new(MockRuleRepository)
+	ruleRepo.On("FindRulesByUserID", "user-id").Return([]*entities.Rule{newTestRule(t, "rule-id", 0)}, nil)
+	ruleRepo.On("DeleteRule", "rule-id").Return(nil)
+
+	service := newRuleService(ruleRepo, new(MockTransactionRepository), new(MockCategoryRepository), new(MockTagRepository))
+
+	assert.NoError(t, service.DeleteRule("user-id", "rule-id"))
+	assert.True(t, apperror.IsErrorType(service.DeleteRule("user-id", "missing-id"), apperror.ErrorTypeNotFound))
+	ruleRepo.AssertExpectations(t)
+}
+
+// newRuleHistory builds the wallet history rules run against: an
+// uncategorized and a categorized grocery purchase, and a pharmacy one.
+func newRuleHistory(t *testing.T) []*entities.Transaction {
+	uncategorized := newTestTransaction(t)
+	uncategorized.ID = "uncategorized-id"
+
+	categorized := newTestTransaction(t)
+	categorized.ID = "categorized-id"
+	categorized.CategoryID = "household-id"
+
+	pharmacy := newTestTransaction(t)
+	pharmacy.ID = "pharmacy-id"
+	pharmacy.Description = "Pharmacy"
+
+	return []*entities.Transaction{uncategorized, categorized, pharmacy}
+}
+
+// expectRuleHistory expects the history of "user-id" in wallet-id to be
+// listed.
+func expectRuleHistory(t *testing.T, transactionRepo *MockTransactionRepository) {
+	transactionRepo.On("FindTransactionsByWalletID", "wallet-id", mock.MatchedBy(func(filter repositories.TransactionFilter) bool {
+		return assert.ObjectsAreEqual([]string{"user-id"}, filter.MemberIDs)
+	}), mock.Anything).Return(newRuleHistory(t), nil, nil)
+}
+
+func TestRuleService_TestRule(t *testing.T) {
+	food := &entities.Category{ID: "food-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+
+	transactionRepo := new(MockTransactionRepository)
+	expectRuleHistory(t, transactionRepo)
+	categoryRepo := new(MockCategoryRepository)
+	categoryRepo.On("FindCategoryByID", "food-id").Return(food, nil)
+
+	service := newRuleService(new(MockRuleRepository), transactionRepo, categoryRepo, new(MockTagRepository))
+	matches, err := service.TestRule("user-id", services.RuleInput{
+		Name:       "Groceries",
+		Conditions: entities.RuleConditions{DescriptionPattern: "grocer"},
+		Actions:    entities.RuleActions{CategoryID: "food-id"},
+	}, services.RuleHistory{WalletID: "wallet-id"})
+
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "uncategorized-id", matches[0].After.ID)
+	assert.Empty(t, matches[0].Before.CategoryID)
+	assert.Equal(t, "food-id", matches[0].After.CategoryID)
+	transactionRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+}
+
+func TestRuleService_ApplyRules(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		name := "replaces categories set before"
+		if dryRun {
+			name = "dry run saves nothing"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			ruleRepo := new(MockRuleRepository)
+			ruleRepo.On("FindRulesByUserID", "user-id").Return([]*entities.Rule{newTestRule(t, "rule-id", 0)}, nil)
+			transactionRepo := new(MockTransactionRepository)
+			expectRuleHistory(t, transactionRepo)
+			if !dryRun {
+				transactionRepo.On("SaveTransactions", mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+					return len(transactions) == 2 &&
+						transactions[0].CategoryID == "food-id" && transactions[1].CategoryID == "food-id"
+				})).Return(nil)
+			}
+
+			service := newRuleService(ruleRepo, transactionRepo, new(MockCategoryRepository), new(MockTagRepository))
+			matches, err := service.ApplyRules("user-id", services.RuleHistory{WalletID: "wallet-id"}, dryRun)
+
+			require.NoError(t, err)
+			require.Len(t, matches, 2)
+			assert.Equal(t, "household-id", matches[1].Before.CategoryID)
+			assert.Equal(t, "rule-id", matches[1].Rules[0].ID)
+			transactionRepo.AssertExpectations(t)
+			if dryRun {
+				transactionRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+			}
+		})
+	}
+
+	t.Run("inaccessible wallet", func(t *testing.T) {
+		ruleRepo := new(MockRuleRepository)
+		ruleRepo.On("FindRulesByUserID", "user-id").Return([]*entities.Rule{}, nil)
+
+		service := newRuleService(ruleRepo, new(MockTransactionRepository), new(MockCategoryRepository), new(MockTagRepository))
+		_, err := service.ApplyRules("user-id", services.RuleHistory{WalletID: "other-wallet-id"}, false)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeForbidden))
+	})
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// SettlementInput holds a payment from one wallet member to another.
+type SettlementInput struct {
+	FromUserID string
+	ToUserID   string
+	Amount     money.Money
+	Date       time.Time
+	Note       string
+}
+
+type SettlementService interface {
+	// PlanSettlements suggests the fewest payments that settle every member
+	// of the wallet.
+	PlanSettlements(walletID, actorID string) ([]*entities.Debt, error)
+	RecordSettlement(walletID, actorID string, input SettlementInput) (*entities.Settlement, error)
+	GetSettlement(walletID, actorID, settlementID string) (*entities.Settlement, error)
+	ListSettlements(walletID, actorID string, filter repositories.SettlementFilter, page query.Page) ([]*entities.Settlement, *query.Cursor, error)
+	VoidSettlement(walletID, actorID, settlementID string) (*entities.Settlement, error)
+}
+
+type settlementService struct {
+	settlementRepo repositories.SettlementRepository
+	balanceRepo    repositories.BalanceRepository
+	walletRepo     repositories.WalletRepository
+	userRepo       repositories.UserRepository
+	logger         logger.Logger
+}
+
+var ErrSettlementNotFound = apperror.New(apperror.ErrorTypeNotFound, "Settlement not found")
+
+func (s *settlementService) PlanSettlements(walletID, actorID string) ([]*entities.Debt, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	balances, err := s.findBalances(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := entities.PlanSettlements(balances.Members)
+	if err != nil {
+		s.logger.Error(err, "Failed to plan settlements", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeInternal, err)
+	}
+
+	return payments, nil
+}
+
+// RecordSettlement records a payment that pays back part or all of what the
+// payer owes. It cannot be more than the payer owes or the payee is owed, so
+// a settlement never turns a debt around.
+func (s *settlementService) RecordSettlement(walletID, actorID string, input SettlementInput) (*entities.Settlement, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	settlement, err := entities.NewSettlement(
+		walletID,
+		input.FromUserID,
+		input.ToUserID,
+		input.Amount,
+		input.Date,
+		input.Note,
+		actorID,
+	)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	for _, userID := range entities.UniqueIDs([]string{settlement.FromUserID, settlement.ToUserID}) {
+		if err := s.checkUser(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	balances, err := s.findBalances(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSettle(balances.Members, settlement) {
+		return nil, apperror.New(apperror.ErrorTypeUnprocessable, "Settlement is more than the payer owes or the payee is owed").
+			AddContext("amount", settlement.Amount.String())
+	}
+
+	createdSettlement, err := s.settlementRepo.CreateSettlement(settlement)
+	if err != nil {
+		s.logger.Error(err, "Failed to create settlement", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdSettlement, nil
+}
+
+func (s *settlementService) GetSettlement(walletID, actorID, settlementID string) (*entities.Settlement, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	settlement, err := s.settlementRepo.FindSettlementByID(settlementID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find settlement", map[string]interface{}{
+			"settlement_id": settlementID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if settlement == nil || settlement.WalletID != walletID {
+		return nil, ErrSettlementNotFound
+	}
+
+	return settlement, nil
+}
+
+func (s *settlementService) ListSettlements(
+	walletID, actorID string,
+	filter repositories.SettlementFilter,
+	page query.Page,
+) ([]*entities.Settlement, *query.Cursor, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, nil, err
+	}
+
+	filter, err := normalizeSettlementFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settlements, next, err := s.settlementRepo.FindSettlementsByWalletID(walletID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list settlements", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return settlements, next, nil
+}
+
+// normalizeSettlementFilter rejects date ranges that cannot match anything
+// and unknown currencies.
+func normalizeSettlementFilter(filter repositories.SettlementFilter) (repositories.SettlementFilter, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return filter, apperror.New(apperror.ErrorTypeValidation, "Date range starts after it ends").
+			AddContext("field", "from")
+	}
+
+	if err := checkCurrency(filter.Currency); err != nil {
+		return filter, err
+	}
+
+	filter.Currency = strings.ToUpper(strings.TrimSpace(filter.Currency))
+	filter.MemberIDs = entities.UniqueIDs(filter.MemberIDs)
+	return filter, nil
+}
+
+// VoidSettlement stops a settlement recorded by mistake from counting. It
+// stays in the history with who voided it and when.
+func (s *settlementService) VoidSettlement(walletID, actorID, settlementID string) (*entities.Settlement, error) {
+	settlement, err := s.GetSettlement(walletID, actorID, settlementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settlement.IsVoided() {
+		return nil, apperror.New(apperror.ErrorTypeUnprocessable, "Settlement was already voided").
+			AddContext("settlement_id", settlementID)
+	}
+
+	if err := settlement.Void(actorID); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.settlementRepo.VoidSettlement(settlement); err != nil {
+		s.logger.Error(err, "Failed to void settlement", map[string]interface{}{
+			"settlement_id": settlementID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return settlement, nil
+}
+
+func (s *settlementService) findBalances(walletID string) (*entities.SharedBalances, error) {
+	debts, err := s.balanceRepo.FindDebts(walletID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet debts", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	balances, err := entities.NewSharedBalances(debts)
+	if err != nil {
+		s.logger.Error(err, "Failed to compute wallet balances", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeInternal, err)
+	}
+
+	return balances, nil
+}
+
+func (s *settlementService) checkUser(userID string) error {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return apperror.New(apperror.ErrorTypeNotFound, "User not found").
+			AddContext("user_id", userID)
+	}
+	return nil
+}
+
+// canSettle reports whether the payer owes, and the payee is owed, at least
+// the settlement amount.
+func canSettle(balances []*entities.MemberBalance, settlement *entities.Settlement) bool {
+	var owes, owed bool
+	for _, balance := range balances {
+		if !balance.Net.SameCurrency(settlement.Amount) {
+			continue
+		}
+		switch balance.UserID {
+		case settlement.FromUserID:
+			owes = -balance.Net.MinorUnits() >= settlement.Amount.MinorUnits()
+		case settlement.ToUserID:
+			owed = balance.Net.MinorUnits() >= settlement.Amount.MinorUnits()
+		}
+	}
+	return owes && owed
+}
+
+func NewSettlementService(
+	settlementRepo repositories.SettlementRepository,
+	balanceRepo repositories.BalanceRepository,
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) SettlementService {
+	return &settlementService{
+		settlementRepo: settlementRepo,
+		balanceRepo:    balanceRepo,
+		walletRepo:     walletRepo,
+		userRepo:       userRepo,
+		logger:         logger,
+	}
+}
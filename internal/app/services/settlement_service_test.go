@@ -0,0 +1,308 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSettlementRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettlementRepository) CreateSettlement(settlement *entities.Settlement) (*entities.Settlement, error) {
+	args := m.Called(settlement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Settlement), args.Error(1)
+}
+
+func (m *MockSettlementRepository) FindSettlementByID(id string) (*entities.Settlement, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Settlement), args.Error(1)
+}
+
+func (m *MockSettlementRepository) FindSettlementsByWalletID(
+	walletID string,
+	filter repositories.SettlementFilter,
+	page query.Page,
+) ([]*entities.Settlement, *query.Cursor, error) {
+	args := m.Called(walletID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.Settlement), next, args.Error(2)
+}
+
+func (m *MockSettlementRepository) VoidSettlement(settlement *entities.Settlement) error {
+	args := m.Called(settlement)
+	return args.Error(0)
+}
+
+func newTestSettlement(t *testing.T) *entities.Settlement {
+	t.Helper()
+	settlement, err := entities.NewSettlement("wallet-id", "bia", "ana", newMoney(t, "20.00", "BRL"),
+		time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), "", "bia")
+	require.NoError(t, err)
+	return settlement
+}
+
+func TestSettlementService_PlanSettlements(t *testing.T) {
+	balanceRepo := new(MockBalanceRepository)
+	balanceRepo.On("FindDebts", "wallet-id").Return([]*entities.Debt{
+		{DebtorID: "bia", CreditorID: "ana", Amount: newMoney(t, "30.00", "BRL")},
+		{DebtorID: "caio", CreditorID: "bia", Amount: newMoney(t, "30.00", "BRL")},
+	}, nil)
+
+	service := services.NewSettlementService(new(MockSettlementRepository), balanceRepo, newWalletRepositoryFor("ana", "bia"), new(MockUserRepository), mocks.NewMockLogger())
+	payments, err := service.PlanSettlements("wallet-id", "ana")
+
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, "caio", payments[0].DebtorID)
+	assert.Equal(t, "ana", payments[0].CreditorID)
+	assert.Equal(t, int64(3000), payments[0].Amount.MinorUnits())
+}
+
+func TestSettlementService_RecordSettlement(t *testing.T) {
+	debts := []*entities.Debt{
+		{DebtorID: "bia", CreditorID: "ana", Amount: newMoney(t, "30.00", "BRL")},
+	}
+	input := func(amount string) services.SettlementInput {
+		return services.SettlementInput{
+			FromUserID: "bia",
+			ToUserID:   "ana",
+			Amount:     newMoney(t, amount, "BRL"),
+			Date:       time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	knownUsers := func(ur *MockUserRepository) {
+		ur.On("FindUserByID", "bia").Return(&entities.User{ID: "bia"}, nil)
+		ur.On("FindUserByID", "ana").Return(&entities.User{ID: "ana"}, nil)
+	}
+
+	tests := []struct {
+		name      string
+		input     services.SettlementInput
+		mockSetup func(*MockSettlementRepository, *MockBalanceRepository, *MockUserRepository, *mocks.MockLogger)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:  "partial payment",
+			input: input("20.00"),
+			mockSetup: func(sr *MockSettlementRepository, br *MockBalanceRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				knownUsers(ur)
+				br.On("FindDebts", "wallet-id").Return(debts, nil)
+				sr.On("CreateSettlement", mock.MatchedBy(func(settlement *entities.Settlement) bool {
+					return settlement.FromUserID == "bia" && settlement.CreatedBy == "bia"
+				})).Return(newTestSettlement(t), nil)
+			},
+		},
+		{
+			name:  "more than is owed",
+			input: input("30.01"),
+			mockSetup: func(sr *MockSettlementRepository, br *MockBalanceRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				knownUsers(ur)
+				br.On("FindDebts", "wallet-id").Return(debts, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name: "payee owes instead",
+			input: services.SettlementInput{
+				FromUserID: "ana",
+				ToUserID:   "bia",
+				Amount:     newMoney(t, "10.00", "BRL"),
+				Date:       time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			},
+			mockSetup: func(sr *MockSettlementRepository, br *MockBalanceRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				knownUsers(ur)
+				br.On("FindDebts", "wallet-id").Return(debts, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:  "unknown payee",
+			input: input("20.00"),
+			mockSetup: func(sr *MockSettlementRepository, br *MockBalanceRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "bia").Return(&entities.User{ID: "bia"}, nil).Maybe()
+				ur.On("FindUserByID", "ana").Return(nil, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:  "invalid settlement",
+			input: input("0.00"),
+			mockSetup: func(sr *MockSettlementRepository, br *MockBalanceRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:  "repository error",
+			input: input("20.00"),
+			mockSetup: func(sr *MockSettlementRepository, br *MockBalanceRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				knownUsers(ur)
+				br.On("FindDebts", "wallet-id").Return(debts, nil)
+				sr.On("CreateSettlement", mock.Anything).Return(nil, errors.New("database error"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlementRepo := new(MockSettlementRepository)
+			balanceRepo := new(MockBalanceRepository)
+			userRepo := new(MockUserRepository)
+			logger := mocks.NewMockLogger()
+			tt.mockSetup(settlementRepo, balanceRepo, userRepo, logger)
+
+			service := services.NewSettlementService(settlementRepo, balanceRepo, newWalletRepositoryFor("ana", "bia"), userRepo, logger)
+			settlement, err := service.RecordSettlement("wallet-id", "bia", tt.input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, settlement)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, settlement)
+			}
+			settlementRepo.AssertExpectations(t)
+			balanceRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSettlementService_ListSettlements(t *testing.T) {
+	page, err := repositories.SettlementSorts.NewPage(repositories.DefaultSettlementSort, nil, 1)
+	require.NoError(t, err)
+
+	t.Run("lists a page of the wallet settlements", func(t *testing.T) {
+		voided := false
+		next := page.Next("2026-03-10", "2026-03-10T12:00:00Z", "settlement-id")
+		settlementRepo := new(MockSettlementRepository)
+		settlementRepo.On("FindSettlementsByWalletID", "wallet-id", repositories.SettlementFilter{
+			MemberIDs: []string{"ana", "bia"},
+			Voided:    &voided,
+			Currency:  "BRL",
+		}, page).Return([]*entities.Settlement{newTestSettlement(t)}, next, nil)
+
+		service := services.NewSettlementService(settlementRepo, new(MockBalanceRepository), newWalletRepositoryFor("ana", "bia"), new(MockUserRepository), mocks.NewMockLogger())
+		settlements, cursor, err := service.ListSettlements("wallet-id", "ana", repositories.SettlementFilter{
+			MemberIDs: []string{"bia", "ana", "bia"},
+			Voided:    &voided,
+			Currency:  "brl",
+		}, page)
+
+		require.NoError(t, err)
+		assert.Len(t, settlements, 1)
+		assert.Equal(t, next, cursor)
+		settlementRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name   string
+		filter repositories.SettlementFilter
+	}{
+		{
+			name: "date range ending before it starts",
+			filter: repositories.SettlementFilter{
+				From: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "unknown currency",
+			filter: repositories.SettlementFilter{Currency: "XYZ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlementRepo := new(MockSettlementRepository)
+
+			service := services.NewSettlementService(settlementRepo, new(MockBalanceRepository), newWalletRepositoryFor("ana", "bia"), new(MockUserRepository), mocks.NewMockLogger())
+			_, _, err := service.ListSettlements("wallet-id", "ana", tt.filter, page)
+
+			assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+			settlementRepo.AssertNotCalled(t, "FindSettlementsByWalletID", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSettlementService_VoidSettlement(t *testing.T) {
+	t.Run("keeps the voided settlement", func(t *testing.T) {
+		settlementRepo := new(MockSettlementRepository)
+		settlementRepo.On("FindSettlementByID", "settlement-id").Return(newTestSettlement(t), nil)
+		settlementRepo.On("VoidSettlement", mock.MatchedBy(func(settlement *entities.Settlement) bool {
+			return settlement.IsVoided() && settlement.VoidedBy == "ana"
+		})).Return(nil)
+
+		service := services.NewSettlementService(settlementRepo, new(MockBalanceRepository), newWalletRepositoryFor("ana", "bia"), new(MockUserRepository), mocks.NewMockLogger())
+		settlement, err := service.VoidSettlement("wallet-id", "ana", "settlement-id")
+
+		require.NoError(t, err)
+		assert.True(t, settlement.IsVoided())
+		settlementRepo.AssertExpectations(t)
+	})
+
+	t.Run("already voided", func(t *testing.T) {
+		voided := newTestSettlement(t)
+		require.NoError(t, voided.Void("bia"))
+
+		settlementRepo := new(MockSettlementRepository)
+		settlementRepo.On("FindSettlementByID", "settlement-id").Return(voided, nil)
+
+		service := services.NewSettlementService(settlementRepo, new(MockBalanceRepository), newWalletRepositoryFor("ana", "bia"), new(MockUserRepository), mocks.NewMockLogger())
+		settlement, err := service.VoidSettlement("wallet-id", "ana", "settlement-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		assert.Nil(t, settlement)
+		settlementRepo.AssertNotCalled(t, "VoidSettlement", mock.Anything)
+	})
+
+	t.Run("other wallet", func(t *testing.T) {
+		settlementRepo := new(MockSettlementRepository)
+		settlementRepo.On("FindSettlementByID", "settlement-id").Return(newTestSettlement(t), nil)
+
+		service := services.NewSettlementService(settlementRepo, new(MockBalanceRepository), newWalletRepositoryFor("ana", "bia"), new(MockUserRepository), mocks.NewMockLogger())
+		settlement, err := service.VoidSettlement("other-wallet", "ana", "settlement-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		assert.Nil(t, settlement)
+	})
+	t.Run("non-member", func(t *testing.T) {
+		settlementRepo := new(MockSettlementRepository)
+
+		service := services.NewSettlementService(settlementRepo, new(MockBalanceRepository), newWalletRepositoryFor("ana", "bia"), new(MockUserRepository), mocks.NewMockLogger())
+		settlement, err := service.VoidSettlement("wallet-id", "stranger-id", "settlement-id")
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+		assert.Nil(t, settlement)
+		settlementRepo.AssertNotCalled(t, "VoidSettlement", mock.Anything)
+	})
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// SpendingLimitInput holds the fields of a new spending limit.
+type SpendingLimitInput struct {
+	UserID string
+	// CategoryID limits only the expenses under one of the member's
+	// categories; empty limits all of them.
+	CategoryID string
+	Period     entities.LimitPeriod
+	Amount     money.Money
+}
+
+type SpendingLimitService interface {
+	// CreateSpendingLimit lets a wallet owner cap the expenses of a member.
+	// A member has one limit per period and category.
+	CreateSpendingLimit(walletID, actorID string, input SpendingLimitInput) (*entities.SpendingLimit, error)
+	// ListSpendingLimits returns every limit of the wallet to owners, and
+	// their own limits to other members.
+	ListSpendingLimits(walletID, actorID string) ([]*entities.SpendingLimit, error)
+	UpdateSpendingLimit(walletID, actorID, limitID string, amount money.Money) (*entities.SpendingLimit, error)
+	DeleteSpendingLimit(walletID, actorID, limitID string) error
+}
+
+type spendingLimitService struct {
+	limitRepo    repositories.SpendingLimitRepository
+	walletRepo   repositories.WalletRepository
+	categoryRepo repositories.CategoryRepository
+	activityRepo repositories.ActivityRepository
+	logger       logger.Logger
+}
+
+var (
+	ErrSpendingLimitNotFound  = apperror.New(apperror.ErrorTypeNotFound, "Spending limit not found")
+	ErrSpendingLimitForbidden = apperror.New(apperror.ErrorTypeForbidden, "Only wallet owners can manage spending limits")
+	ErrSpendingLimitExists    = apperror.New(apperror.ErrorTypeUnprocessable, "The member already has a limit for this period and category")
+	ErrSpendingLimitMember    = apperror.New(apperror.ErrorTypeNotFound, "Member not found")
+)
+
+func (s *spendingLimitService) CreateSpendingLimit(
+	walletID string,
+	actorID string,
+	input SpendingLimitInput,
+) (*entities.SpendingLimit, error) {
+	if err := s.checkOwner(walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.walletRepo.FindWalletMember(walletID, input.UserID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet member", map[string]interface{}{
+			"wallet_id": walletID,
+			"user_id":   input.UserID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if member == nil {
+		return nil, ErrSpendingLimitMember
+	}
+
+	limit, err := entities.NewSpendingLimit(walletID, member.UserID, input.CategoryID, input.Period, input.Amount, actorID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := checkCategory(s.categoryRepo, s.logger, member.UserID, limit.CategoryID, entities.TransactionTypeExpense); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.limitRepo.FindMemberSpendingLimits(walletID, member.UserID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list spending limits", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	for _, other := range existing {
+		if other.Period == limit.Period && other.CategoryID == limit.CategoryID {
+			return nil, ErrSpendingLimitExists
+		}
+	}
+
+	if err := s.limitRepo.CreateSpendingLimit(limit); err != nil {
+		s.logger.Error(err, "Failed to create spending limit", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	s.recordChange(actorID, nil, limit)
+	return limit, nil
+}
+
+func (s *spendingLimitService) ListSpendingLimits(walletID, actorID string) ([]*entities.SpendingLimit, error) {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits []*entities.SpendingLimit
+	if member.CanManageMembers() {
+		limits, err = s.limitRepo.FindSpendingLimitsByWalletID(walletID)
+	} else {
+		limits, err = s.limitRepo.FindMemberSpendingLimits(walletID, member.UserID)
+	}
+	if err != nil {
+		s.logger.Error(err, "Failed to list spending limits", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return limits, nil
+}
+
+func (s *spendingLimitService) UpdateSpendingLimit(
+	walletID string,
+	actorID string,
+	limitID string,
+	amount money.Money,
+) (*entities.SpendingLimit, error) {
+	limit, err := s.managedLimit(walletID, actorID, limitID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *limit
+	if err := limit.SetAmount(amount); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.limitRepo.UpdateSpendingLimit(limit); err != nil {
+		s.logger.Error(err, "Failed to update spending limit", map[string]interface{}{
+			"limit_id": limitID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	s.recordChange(actorID, &previous, limit)
+	return limit, nil
+}
+
+func (s *spendingLimitService) DeleteSpendingLimit(walletID, actorID, limitID string) error {
+	limit, err := s.managedLimit(walletID, actorID, limitID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.limitRepo.DeleteSpendingLimit(limit.ID); err != nil {
+		s.logger.Error(err, "Failed to delete spending limit", map[string]interface{}{
+			"limit_id": limitID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	s.recordChange(actorID, limit, nil)
+	return nil
+}
+
+// recordChange adds the change of a limit to the wallet feed.
+func (s *spendingLimitService) recordChange(actorID string, before, after *entities.SpendingLimit) {
+	record(s.activityRepo, s.logger, newActivity(s.logger, func() (*entities.Activity, error) {
+		return entities.NewLimitActivity(actorID, before, after)
+	}))
+}
+
+func (s *spendingLimitService) checkOwner(walletID, actorID string) error {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return err
+	}
+	if !member.CanManageMembers() {
+		return ErrSpendingLimitForbidden
+	}
+	return nil
+}
+
+// managedLimit returns a limit of the wallet, which actorID must own.
+func (s *spendingLimitService) managedLimit(walletID, actorID, limitID string) (*entities.SpendingLimit, error) {
+	if err := s.checkOwner(walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	limit, err := s.limitRepo.FindSpendingLimitByID(limitID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find spending limit", map[string]interface{}{
+			"limit_id": limitID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if limit == nil || limit.WalletID != walletID {
+		return nil, ErrSpendingLimitNotFound
+	}
+
+	return limit, nil
+}
+
+// limitUsage is how much of a limit a member has used.
+type limitUsage struct {
+	limit *entities.SpendingLimit
+	used  money.Money
+}
+
+// checkSpendingLimits refuses an expense that takes its author over one of
+// their limits in the wallet, and returns the limits it brings close to
+// being used up, so owners can be warned once the expense is saved.
+// excludeID leaves out the stored version of an expense being edited.
+func checkSpendingLimits(
+	limitRepo repositories.SpendingLimitRepository,
+	rateService ExchangeRateService,
+	log logger.Logger,
+	transaction *entities.Transaction,
+	excludeID string,
+) ([]limitUsage, error) {
+	if transaction.Type != entities.TransactionTypeExpense {
+		return nil, nil
+	}
+
+	limits, err := limitRepo.FindMemberSpendingLimits(transaction.WalletID, transaction.CreatedBy)
+	if err != nil {
+		log.Error(err, "Failed to list spending limits", map[string]interface{}{
+			"wallet_id": transaction.WalletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	nearing := make([]limitUsage, 0)
+	for _, limit := range limits {
+		covered, ok := limit.Covers(transaction)
+		if !ok {
+			continue
+		}
+
+		currency := limit.Amount.Currency().Code
+		conversion, err := rateService.Convert(covered, currency, transaction.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		used, err := money.Zero(currency)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeInternal, err)
+		}
+		if limit.Period != entities.LimitPeriodTransaction {
+			from, to := limit.Period.Bounds(transaction.Date)
+			amounts, err := limitRepo.FindSpentAmounts(repositories.SpentFilter{
+				WalletID:             limit.WalletID,
+				UserID:               limit.UserID,
+				CategoryID:           limit.CategoryID,
+				From:                 from,
+				To:                   to,
+				ExcludeTransactionID: excludeID,
+			})
+			if err != nil {
+				log.Error(err, "Failed to find spent amounts", map[string]interface{}{
+					"limit_id": limit.ID,
+				})
+				return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+			}
+
+			if used, _, err = rateService.ConvertTotal(amounts, currency, transaction.Date); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := limit.Check(used, conversion.Converted); err != nil {
+			if !errors.Is(err, entities.ErrSpendingLimitExceeded) {
+				return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+			}
+			return nil, spendingLimitError(limit, used)
+		}
+
+		if limit.Nears(used, conversion.Converted) {
+			total, err := used.Add(conversion.Converted)
+			if err != nil {
+				return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+			}
+			nearing = append(nearing, limitUsage{limit: limit, used: total})
+		}
+	}
+
+	return nearing, nil
+}
+
+// spendingLimitError tells the member which limit they hit and how much of
+// it they had used already.
+func spendingLimitError(limit *entities.SpendingLimit, used money.Money) *apperror.AppError {
+	appErr := apperror.Wrap(apperror.ErrorTypeUnprocessable, entities.ErrSpendingLimitExceeded).
+		AddContext("limit_id", limit.ID).
+		AddContext("limit", limit.Amount.Decimal()).
+		AddContext("currency", limit.Amount.Currency().Code).
+		AddContext("period", limit.Period).
+		AddContext("used", used.Decimal())
+	if limit.CategoryID != "" {
+		appErr.AddContext("category_id", limit.CategoryID)
+	}
+	return appErr
+}
+
+func NewSpendingLimitService(
+	limitRepo repositories.SpendingLimitRepository,
+	walletRepo repositories.WalletRepository,
+	categoryRepo repositories.CategoryRepository,
+	activityRepo repositories.ActivityRepository,
+	logger logger.Logger,
+) SpendingLimitService {
+	return &spendingLimitService{
+		limitRepo:    limitRepo,
+		walletRepo:   walletRepo,
+		categoryRepo: categoryRepo,
+		activityRepo: activityRepo,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,242 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/money"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSpendingLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpendingLimitRepository) CreateSpendingLimit(limit *entities.SpendingLimit) error {
+	args := m.Called(limit)
+	return args.Error(0)
+}
+
+func (m *MockSpendingLimitRepository) FindSpendingLimitByID(id string) (*entities.SpendingLimit, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SpendingLimit), args.Error(1)
+}
+
+func (m *MockSpendingLimitRepository) FindSpendingLimitsByWalletID(walletID string) ([]*entities.SpendingLimit, error) {
+	args := m.Called(walletID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.SpendingLimit), args.Error(1)
+}
+
+func (m *MockSpendingLimitRepository) FindMemberSpendingLimits(walletID, userID string) ([]*entities.SpendingLimit, error) {
+	args := m.Called(walletID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.SpendingLimit), args.Error(1)
+}
+
+func (m *MockSpendingLimitRepository) UpdateSpendingLimit(limit *entities.SpendingLimit) error {
+	args := m.Called(limit)
+	return args.Error(0)
+}
+
+func (m *MockSpendingLimitRepository) DeleteSpendingLimit(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSpendingLimitRepository) FindSpentAmounts(filter repositories.SpentFilter) ([]money.Money, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]money.Money), args.Error(1)
+}
+
+// newLimitRepository returns a spending limit repository where nobody has
+// a limit.
+func newLimitRepository() *MockSpendingLimitRepository {
+	repo := new(MockSpendingLimitRepository)
+	repo.On("FindMemberSpendingLimits", mock.Anything, mock.Anything).Return([]*entities.SpendingLimit{}, nil).Maybe()
+	return repo
+}
+
+// newTestLimit builds a limit of "member-id" in "wallet-id".
+func newTestLimit(t *testing.T, period entities.LimitPeriod, amount string) *entities.SpendingLimit {
+	t.Helper()
+	limit, err := entities.NewSpendingLimit("wallet-id", "member-id", "", period, newMoney(t, amount, "BRL"), "owner-id")
+	require.NoError(t, err)
+	limit.ID = "limit-id"
+	return limit
+}
+
+func TestSpendingLimitService_CreateSpendingLimit(t *testing.T) {
+	input := services.SpendingLimitInput{
+		UserID: "member-id",
+		Period: entities.LimitPeriodMonth,
+		Amount: newMoney(t, "500.00", "BRL"),
+	}
+
+	t.Run("owner limits a member", func(t *testing.T) {
+		repo := new(MockSpendingLimitRepository)
+		repo.On("FindMemberSpendingLimits", "wallet-id", "member-id").Return([]*entities.SpendingLimit{}, nil)
+		repo.On("CreateSpendingLimit", mock.MatchedBy(func(limit *entities.SpendingLimit) bool {
+			return limit.UserID == "member-id" && limit.Period == entities.LimitPeriodMonth && limit.CreatedBy == "owner-id"
+		})).Return(nil)
+
+		service := services.NewSpendingLimitService(repo, newTestWalletRepository(), new(MockCategoryRepository), newActivityRepository(), mocks.NewMockLogger())
+		limit, err := service.CreateSpendingLimit("wallet-id", "owner-id", input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(50000), limit.Amount.MinorUnits())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("members cannot set limits", func(t *testing.T) {
+		repo := new(MockSpendingLimitRepository)
+
+		service := services.NewSpendingLimitService(repo, newTestWalletRepository(), new(MockCategoryRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.CreateSpendingLimit("wallet-id", "member-id", input)
+
+		assert.ErrorIs(t, err, services.ErrSpendingLimitForbidden)
+		repo.AssertNotCalled(t, "CreateSpendingLimit", mock.Anything)
+	})
+
+	t.Run("limited user must be a member", func(t *testing.T) {
+		repo := new(MockSpendingLimitRepository)
+		stranger := input
+		stranger.UserID = "stranger-id"
+
+		service := services.NewSpendingLimitService(repo, newTestWalletRepository(), new(MockCategoryRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.CreateSpendingLimit("wallet-id", "owner-id", stranger)
+
+		assert.ErrorIs(t, err, services.ErrSpendingLimitMember)
+	})
+
+	t.Run("one limit per period and category", func(t *testing.T) {
+		repo := new(MockSpendingLimitRepository)
+		repo.On("FindMemberSpendingLimits", "wallet-id", "member-id").
+			Return([]*entities.SpendingLimit{newTestLimit(t, entities.LimitPeriodMonth, "300.00")}, nil)
+
+		service := services.NewSpendingLimitService(repo, newTestWalletRepository(), new(MockCategoryRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.CreateSpendingLimit("wallet-id", "owner-id", input)
+
+		assert.ErrorIs(t, err, services.ErrSpendingLimitExists)
+		repo.AssertNotCalled(t, "CreateSpendingLimit", mock.Anything)
+	})
+}
+
+func TestSpendingLimitService_ListSpendingLimits(t *testing.T) {
+	t.Run("members see their own limits", func(t *testing.T) {
+		repo := new(MockSpendingLimitRepository)
+		repo.On("FindMemberSpendingLimits", "wallet-id", "member-id").
+			Return([]*entities.SpendingLimit{newTestLimit(t, entities.LimitPeriodMonth, "300.00")}, nil)
+
+		service := services.NewSpendingLimitService(repo, newTestWalletRepository(), new(MockCategoryRepository), newActivityRepository(), mocks.NewMockLogger())
+		limits, err := service.ListSpendingLimits("wallet-id", "member-id")
+
+		require.NoError(t, err)
+		assert.Len(t, limits, 1)
+		repo.AssertNotCalled(t, "FindSpendingLimitsByWalletID", mock.Anything)
+	})
+}
+
+// newLimitedTransactionService returns a transaction service where
+// "member-id" of newTestWallet has the given limits and has already spent
+// the given amounts this month.
+func newLimitedTransactionService(
+	t *testing.T,
+	transactionRepo *MockTransactionRepository,
+	notificationRepo *MockNotificationRepository,
+	spent []money.Money,
+	limits ...*entities.SpendingLimit,
+) services.TransactionService {
+	t.Helper()
+	limitRepo := new(MockSpendingLimitRepository)
+	limitRepo.On("FindMemberSpendingLimits", "wallet-id", "member-id").Return(limits, nil)
+	limitRepo.On("FindSpentAmounts", mock.MatchedBy(func(filter repositories.SpentFilter) bool {
+		return filter.UserID == "member-id" &&
+			filter.From.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) &&
+			filter.To.Equal(time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC))
+	})).Return(spent, nil).Maybe()
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindUserByID", "member-id").Return(&entities.User{ID: "member-id", Role: entities.RoleUser}, nil)
+
+	return services.NewTransactionService(transactionRepo, newTestWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), limitRepo, notificationRepo, newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+}
+
+func TestTransactionService_SpendingLimits(t *testing.T) {
+	t.Run("per-transaction limit", func(t *testing.T) {
+		transactionRepo := new(MockTransactionRepository)
+		service := newLimitedTransactionService(t, transactionRepo, new(MockNotificationRepository), nil,
+			newTestLimit(t, entities.LimitPeriodTransaction, "40.00"))
+
+		_, err := service.CreateTransaction("wallet-id", "member-id", newTransactionInput(t, "42.90"))
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		assert.Equal(t, "40.00", appErr.Context()["limit"])
+		assert.Equal(t, entities.LimitPeriodTransaction, appErr.Context()["period"])
+		assert.Equal(t, "0.00", appErr.Context()["used"])
+		transactionRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything)
+	})
+
+	t.Run("period limit counts what was spent", func(t *testing.T) {
+		transactionRepo := new(MockTransactionRepository)
+		service := newLimitedTransactionService(t, transactionRepo, new(MockNotificationRepository),
+			[]money.Money{newMoney(t, "480.00", "BRL")}, newTestLimit(t, entities.LimitPeriodMonth, "500.00"))
+
+		_, err := service.CreateTransaction("wallet-id", "member-id", newTransactionInput(t, "42.90"))
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "480.00", appErr.Context()["used"])
+		assert.Equal(t, entities.LimitPeriodMonth, appErr.Context()["period"])
+		transactionRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything)
+	})
+
+	t.Run("owners are warned near the limit", func(t *testing.T) {
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("CreateTransaction", mock.Anything).Return(newTestTransaction(t), nil)
+		notificationRepo := new(MockNotificationRepository)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].UserID == "owner-id" &&
+				notifications[0].Type == entities.NotificationSpendingLimitNear &&
+				notifications[0].Data["used"] == "422.90"
+		})).Return(nil)
+		service := newLimitedTransactionService(t, transactionRepo, notificationRepo,
+			[]money.Money{newMoney(t, "380.00", "BRL")}, newTestLimit(t, entities.LimitPeriodMonth, "500.00"))
+
+		_, err := service.CreateTransaction("wallet-id", "member-id", newTransactionInput(t, "42.90"))
+
+		require.NoError(t, err)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("no warning below the warning share", func(t *testing.T) {
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("CreateTransaction", mock.Anything).Return(newTestTransaction(t), nil)
+		notificationRepo := new(MockNotificationRepository)
+		service := newLimitedTransactionService(t, transactionRepo, notificationRepo,
+			[]money.Money{newMoney(t, "100.00", "BRL")}, newTestLimit(t, entities.LimitPeriodMonth, "500.00"))
+
+		_, err := service.CreateTransaction("wallet-id", "member-id", newTransactionInput(t, "42.90"))
+
+		require.NoError(t, err)
+		notificationRepo.AssertNotCalled(t, "CreateNotifications", mock.Anything)
+	})
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// TagService manages the tags of one owner: a user for TagScopeUser or a
+// wallet for TagScopeWallet. User tags are managed by the user alone and
+// wallet tags by any member of the wallet.
+type TagService interface {
+	CreateTag(scope entities.TagScope, ownerID, actorID, name string) (*entities.Tag, error)
+	ListTags(scope entities.TagScope, ownerID, actorID string, filter repositories.TagFilter, page query.Page) ([]*entities.TagUsage, *query.Cursor, error)
+	RenameTag(scope entities.TagScope, ownerID, actorID, tagID, name string) (*entities.Tag, error)
+	MergeTags(scope entities.TagScope, ownerID, actorID, sourceID, targetID string) error
+	DeleteTag(scope entities.TagScope, ownerID, actorID, tagID string) error
+}
+
+type tagService struct {
+	tagRepo    repositories.TagRepository
+	walletRepo repositories.WalletRepository
+	userRepo   repositories.UserRepository
+	logger     logger.Logger
+}
+
+var (
+	ErrTagNotFound      = apperror.New(apperror.ErrorTypeNotFound, "Tag not found")
+	ErrTagOwnerNotFound = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	ErrTagForbidden     = apperror.New(apperror.ErrorTypeForbidden, "Users can only manage their own tags")
+)
+
+func (s *tagService) CreateTag(scope entities.TagScope, ownerID, actorID, name string) (*entities.Tag, error) {
+	if err := s.checkAccess(scope, ownerID, actorID); err != nil {
+		return nil, err
+	}
+
+	if scope == entities.TagScopeUser {
+		user, err := s.userRepo.FindUserByID(ownerID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find user", map[string]interface{}{
+				"user_id": ownerID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if user == nil {
+			return nil, ErrTagOwnerNotFound
+		}
+	}
+
+	tag, err := entities.NewTag(scope, ownerID, name)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.checkNameIsFree(tag); err != nil {
+		return nil, err
+	}
+
+	createdTag, err := s.tagRepo.CreateTag(tag)
+	if err != nil {
+		s.logger.Error(err, "Failed to create tag", map[string]interface{}{
+			"owner_id": ownerID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdTag, nil
+}
+
+func (s *tagService) ListTags(
+	scope entities.TagScope,
+	ownerID, actorID string,
+	filter repositories.TagFilter,
+	page query.Page,
+) ([]*entities.TagUsage, *query.Cursor, error) {
+	if err := s.checkAccess(scope, ownerID, actorID); err != nil {
+		return nil, nil, err
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	usage, next, err := s.tagRepo.FindTagUsageByOwner(scope, ownerID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list tags", map[string]interface{}{
+			"owner_id": ownerID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return usage, next, nil
+}
+
+func (s *tagService) RenameTag(scope entities.TagScope, ownerID, actorID, tagID, name string) (*entities.Tag, error) {
+	if err := s.checkAccess(scope, ownerID, actorID); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.ownedTag(scope, ownerID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tag.Rename(name); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if err := s.checkNameIsFree(tag); err != nil {
+		return nil, err
+	}
+
+	updatedTag, err := s.tagRepo.UpdateTag(tag)
+	if err != nil {
+		s.logger.Error(err, "Failed to rename tag", map[string]interface{}{
+			"tag_id": tagID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return updatedTag, nil
+}
+
+func (s *tagService) MergeTags(scope entities.TagScope, ownerID, actorID, sourceID, targetID string) error {
+	if err := s.checkAccess(scope, ownerID, actorID); err != nil {
+		return err
+	}
+
+	source, err := s.ownedTag(scope, ownerID, sourceID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.ownedTag(scope, ownerID, targetID)
+	if err != nil {
+		return err
+	}
+
+	if err := source.CanMergeInto(target); err != nil {
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+
+	if err := s.tagRepo.MergeTags(source.ID, target.ID); err != nil {
+		s.logger.Error(err, "Failed to merge tags", map[string]interface{}{
+			"source_id": sourceID,
+			"target_id": targetID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// DeleteTag removes the tag from every transaction and deletes it.
+func (s *tagService) DeleteTag(scope entities.TagScope, ownerID, actorID, tagID string) error {
+	if err := s.checkAccess(scope, ownerID, actorID); err != nil {
+		return err
+	}
+
+	if _, err := s.ownedTag(scope, ownerID, tagID); err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.DeleteTag(tagID); err != nil {
+		s.logger.Error(err, "Failed to delete tag", map[string]interface{}{
+			"tag_id": tagID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// checkAccess lets users manage their own tags and the tags of the wallets
+// they are members of.
+func (s *tagService) checkAccess(scope entities.TagScope, ownerID, actorID string) error {
+	if scope == entities.TagScopeWallet {
+		_, err := walletMember(s.walletRepo, s.logger, ownerID, actorID)
+		return err
+	}
+
+	if ownerID != actorID {
+		return ErrTagForbidden
+	}
+	return nil
+}
+
+func (s *tagService) ownedTag(scope entities.TagScope, ownerID, tagID string) (*entities.Tag, error) {
+	tag, err := s.tagRepo.FindTagByID(tagID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find tag", map[string]interface{}{
+			"tag_id": tagID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if tag == nil || tag.Scope != scope || tag.OwnerID != ownerID {
+		return nil, ErrTagNotFound
+	}
+
+	return tag, nil
+}
+
+func (s *tagService) checkNameIsFree(tag *entities.Tag) error {
+	existing, err := s.tagRepo.FindTagByName(tag.Scope, tag.OwnerID, tag.Name)
+	if err != nil {
+		s.logger.Error(err, "Failed to find tag", map[string]interface{}{
+			"owner_id": tag.OwnerID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if existing != nil && existing.ID != tag.ID {
+		return apperror.New(apperror.ErrorTypeUnprocessable, "A tag with this name already exists; merge the tags instead").
+			AddContext("tag_id", existing.ID)
+	}
+
+	return nil
+}
+
+func NewTagService(
+	tagRepo repositories.TagRepository,
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) TagService {
+	return &tagService{
+		tagRepo:    tagRepo,
+		walletRepo: walletRepo,
+		userRepo:   userRepo,
+		logger:     logger,
+	}
+}
@@ -0,0 +1,340 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) CreateTag(tag *entities.Tag) (*entities.Tag, error) {
+	args := m.Called(tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindTagByID(id string) (*entities.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindTagsByIDs(ids []string) ([]*entities.Tag, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindTagByName(scope entities.TagScope, ownerID, name string) (*entities.Tag, error) {
+	args := m.Called(scope, ownerID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindTagUsageByOwner(
+	scope entities.TagScope,
+	ownerID string,
+	filter repositories.TagFilter,
+	page query.Page,
+) ([]*entities.TagUsage, *query.Cursor, error) {
+	args := m.Called(scope, ownerID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.TagUsage), next, args.Error(2)
+}
+
+func (m *MockTagRepository) UpdateTag(tag *entities.Tag) (*entities.Tag, error) {
+	args := m.Called(tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) MergeTags(sourceID, targetID string) error {
+	args := m.Called(sourceID, targetID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) DeleteTag(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func newTestTag(id string, scope entities.TagScope, ownerID, name string) *entities.Tag {
+	return &entities.Tag{ID: id, Scope: scope, OwnerID: ownerID, Name: name}
+}
+
+func TestTagService_CreateTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		scope     entities.TagScope
+		ownerID   string
+		actorID   string
+		tagName   string
+		mockSetup func(*MockTagRepository, *MockUserRepository)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:    "user tag",
+			scope:   entities.TagScopeUser,
+			ownerID: "user-id",
+			tagName: "Vacation 2026",
+			mockSetup: func(tr *MockTagRepository, ur *MockUserRepository) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+				tr.On("FindTagByName", entities.TagScopeUser, "user-id", "vacation-2026").Return(nil, nil)
+				tr.On("CreateTag", mock.MatchedBy(func(tag *entities.Tag) bool {
+					return tag.Name == "vacation-2026"
+				})).Return(newTestTag("tag-id", entities.TagScopeUser, "user-id", "vacation-2026"), nil)
+			},
+		},
+		{
+			name:    "wallet tag",
+			scope:   entities.TagScopeWallet,
+			ownerID: "wallet-id",
+			tagName: "reimbursable",
+			mockSetup: func(tr *MockTagRepository, ur *MockUserRepository) {
+				tr.On("FindTagByName", entities.TagScopeWallet, "wallet-id", "reimbursable").Return(nil, nil)
+				tr.On("CreateTag", mock.Anything).Return(newTestTag("tag-id", entities.TagScopeWallet, "wallet-id", "reimbursable"), nil)
+			},
+		},
+		{
+			name:    "unknown user",
+			scope:   entities.TagScopeUser,
+			ownerID: "missing-id",
+			actorID: "missing-id",
+			tagName: "reimbursable",
+			mockSetup: func(tr *MockTagRepository, ur *MockUserRepository) {
+				ur.On("FindUserByID", "missing-id").Return(nil, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:    "another user's tag",
+			scope:   entities.TagScopeUser,
+			ownerID: "other-id",
+			tagName: "reimbursable",
+			mockSetup: func(tr *MockTagRepository, ur *MockUserRepository) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:    "non-member wallet tag",
+			scope:   entities.TagScopeWallet,
+			ownerID: "wallet-id",
+			actorID: "stranger-id",
+			tagName: "reimbursable",
+			mockSetup: func(tr *MockTagRepository, ur *MockUserRepository) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:    "name taken",
+			scope:   entities.TagScopeWallet,
+			ownerID: "wallet-id",
+			tagName: "Reimbursable",
+			mockSetup: func(tr *MockTagRepository, ur *MockUserRepository) {
+				tr.On("FindTagByName", entities.TagScopeWallet, "wallet-id", "reimbursable").
+					Return(newTestTag("other-id", entities.TagScopeWallet, "wallet-id", "reimbursable"), nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeUnprocessable,
+		},
+		{
+			name:    "empty name",
+			scope:   entities.TagScopeWallet,
+			ownerID: "wallet-id",
+			tagName: " - ",
+			mockSetup: func(tr *MockTagRepository, ur *MockUserRepository) {
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagRepo := new(MockTagRepository)
+			userRepo := new(MockUserRepository)
+			tt.mockSetup(tagRepo, userRepo)
+			actorID := tt.actorID
+			if actorID == "" {
+				actorID = "user-id"
+			}
+
+			service := services.NewTagService(tagRepo, newWalletRepository(), userRepo, mocks.NewMockLogger())
+			tag, err := service.CreateTag(tt.scope, tt.ownerID, actorID, tt.tagName)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, tag)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "tag-id", tag.ID)
+			}
+			tagRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagService_ListTags(t *testing.T) {
+	page, err := repositories.TagSorts.NewPage(repositories.DefaultTagSort, nil, 1)
+	require.NoError(t, err)
+
+	t.Run("lists a page of the wallet tags", func(t *testing.T) {
+		used := true
+		next := page.Next("groceries", "tag-id")
+		usage := []*entities.TagUsage{{Tag: newTestTag("tag-id", entities.TagScopeWallet, "wallet-id", "groceries"), TransactionCount: 3}}
+		repo := new(MockTagRepository)
+		repo.On("FindTagUsageByOwner", entities.TagScopeWallet, "wallet-id", repositories.TagFilter{Query: "groc", Used: &used}, page).
+			Return(usage, next, nil)
+
+		service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+		got, cursor, err := service.ListTags(entities.TagScopeWallet, "wallet-id", "user-id", repositories.TagFilter{Query: " groc ", Used: &used}, page)
+
+		assert.NoError(t, err)
+		assert.Equal(t, usage, got)
+		assert.Equal(t, next, cursor)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("another user's tags", func(t *testing.T) {
+		repo := new(MockTagRepository)
+
+		service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+		_, _, err := service.ListTags(entities.TagScopeUser, "other-id", "user-id", repositories.TagFilter{}, page)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeForbidden))
+		repo.AssertNotCalled(t, "FindTagUsageByOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTagService_RenameTag(t *testing.T) {
+	t.Run("renames own tag", func(t *testing.T) {
+		repo := new(MockTagRepository)
+		repo.On("FindTagByID", "tag-id").Return(newTestTag("tag-id", entities.TagScopeUser, "user-id", "trip"), nil)
+		repo.On("FindTagByName", entities.TagScopeUser, "user-id", "vacation").Return(nil, nil)
+		repo.On("UpdateTag", mock.MatchedBy(func(tag *entities.Tag) bool {
+			return tag.Name == "vacation"
+		})).Return(newTestTag("tag-id", entities.TagScopeUser, "user-id", "vacation"), nil)
+
+		service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+		tag, err := service.RenameTag(entities.TagScopeUser, "user-id", "user-id", "tag-id", "Vacation")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "vacation", tag.Name)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("tag of another owner", func(t *testing.T) {
+		repo := new(MockTagRepository)
+		repo.On("FindTagByID", "tag-id").Return(newTestTag("tag-id", entities.TagScopeUser, "other-id", "trip"), nil)
+
+		service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+		_, err := service.RenameTag(entities.TagScopeUser, "user-id", "user-id", "tag-id", "Vacation")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+
+	t.Run("wallet tag through user scope", func(t *testing.T) {
+		repo := new(MockTagRepository)
+		repo.On("FindTagByID", "tag-id").Return(newTestTag("tag-id", entities.TagScopeWallet, "user-id", "trip"), nil)
+
+		service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+		_, err := service.RenameTag(entities.TagScopeUser, "user-id", "user-id", "tag-id", "Vacation")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		sourceID string
+		targetID string
+		wantErr  bool
+		errType  apperror.ErrorType
+	}{
+		{name: "merge into sibling", sourceID: "trip-id", targetID: "vacation-id"},
+		{name: "merge into itself", sourceID: "trip-id", targetID: "trip-id", wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "merge into another owner's tag", sourceID: "trip-id", targetID: "foreign-id", wantErr: true, errType: apperror.ErrorTypeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTagRepository)
+			repo.On("FindTagByID", "trip-id").Return(newTestTag("trip-id", entities.TagScopeWallet, "wallet-id", "trip"), nil)
+			repo.On("FindTagByID", "vacation-id").Return(newTestTag("vacation-id", entities.TagScopeWallet, "wallet-id", "vacation"), nil)
+			repo.On("FindTagByID", "foreign-id").Return(newTestTag("foreign-id", entities.TagScopeWallet, "other-wallet-id", "vacation"), nil)
+			if !tt.wantErr {
+				repo.On("MergeTags", tt.sourceID, tt.targetID).Return(nil)
+			}
+
+			service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+			err := service.MergeTags(entities.TagScopeWallet, "wallet-id", "user-id", tt.sourceID, tt.targetID)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				repo.AssertNotCalled(t, "MergeTags", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTagService_DeleteTag(t *testing.T) {
+	t.Run("deletes own tag", func(t *testing.T) {
+		repo := new(MockTagRepository)
+		repo.On("FindTagByID", "tag-id").Return(newTestTag("tag-id", entities.TagScopeUser, "user-id", "trip"), nil)
+		repo.On("DeleteTag", "tag-id").Return(nil)
+
+		service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+		err := service.DeleteTag(entities.TagScopeUser, "user-id", "user-id", "tag-id")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockTagRepository)
+		repo.On("FindTagByID", "tag-id").Return(newTestTag("tag-id", entities.TagScopeUser, "user-id", "trip"), nil)
+		repo.On("DeleteTag", "tag-id").Return(errors.New("database error"))
+		logger := mocks.NewMockLogger()
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewTagService(repo, newWalletRepository(), new(MockUserRepository), logger)
+		err := service.DeleteTag(entities.TagScopeUser, "user-id", "user-id", "tag-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+}
@@ -0,0 +1,1506 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// TransactionInput holds the editable fields of a transaction.
+type TransactionInput struct {
+	Type        entities.TransactionType
+	Amount      money.Money
+	Date        time.Time
+	Description string
+	// Status is the status a new transaction starts in, or the next step of
+	// an existing one; empty leaves it to follow the date.
+	Status     entities.TransactionStatus
+	CategoryID string
+	// PayeeID links the transaction to one of the author's payees. When
+	// empty, the payee is found, or created, from the description.
+	PayeeID string
+	TagIDs  []string
+	Splits  []TransactionSplitInput
+	// Sharing divides the expense among wallet members; nil keeps it
+	// personal.
+	Sharing *TransactionSharingInput
+	// ConfirmReconciled confirms an edit of a reconciled transaction, which
+	// is refused otherwise.
+	ConfirmReconciled bool
+}
+
+// TransactionSharingInput records who paid an expense and how it is shared.
+type TransactionSharingInput struct {
+	PaidBy string
+	Method entities.ShareMethod
+	Shares []entities.ExpenseShare
+}
+
+// TransactionSplitInput is one split line of a transaction.
+type TransactionSplitInput struct {
+	Amount     money.Money
+	CategoryID string
+	Note       string
+}
+
+// BulkTransactionInput is a bulk operation and the transactions it runs on:
+// those in TransactionIDs or, when it is empty, those of WalletID matching
+// Filter.
+type BulkTransactionInput struct {
+	Operation      entities.BulkOperation
+	TransactionIDs []string
+	WalletID       string
+	Filter         repositories.TransactionFilter
+	// CategoryID is the new category of RECATEGORIZE; empty uncategorizes.
+	CategoryID string
+	// AddTagIDs and RemoveTagIDs are the tag changes of RETAG.
+	AddTagIDs    []string
+	RemoveTagIDs []string
+	// TargetWalletID is where MOVE moves the transactions.
+	TargetWalletID string
+	// DryRun reports what the operation would do without saving anything.
+	DryRun bool
+	// ConfirmReconciled lets RECATEGORIZE and RETAG change reconciled
+	// transactions, which they leave alone otherwise.
+	ConfirmReconciled bool
+}
+
+// BulkResult reports a bulk operation transaction by transaction.
+type BulkResult struct {
+	Operation entities.BulkOperation
+	DryRun    bool
+	Items     []BulkItemResult
+}
+
+// BulkItemResult is the outcome for one selected transaction. Err is nil
+// when the operation was applied, or would be in a dry run.
+type BulkItemResult struct {
+	TransactionID string
+	Err           error
+}
+
+// Succeeded counts the transactions the operation applied to.
+func (r *BulkResult) Succeeded() int {
+	succeeded := 0
+	for _, item := range r.Items {
+		if item.Err == nil {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+type TransactionService interface {
+	// CreateTransaction records a transaction authored by actorID. Like every
+	// wallet-scoped method, it requires actorID to be a wallet member. An
+	// expense above the wallet approval threshold is held as pending until
+	// an owner reviews it, and one over a spending limit of its author is
+	// refused.
+	CreateTransaction(walletID, actorID string, input TransactionInput) (*entities.Transaction, error)
+	GetTransaction(walletID, actorID, transactionID string) (*entities.Transaction, error)
+	// ListTransactions returns a page of the wallet transactions and the
+	// cursor of the next page, nil on the last one.
+	ListTransactions(walletID, actorID string, filter repositories.TransactionFilter, page query.Page) ([]*entities.Transaction, *query.Cursor, error)
+	// SearchTransactions searches the wallets userID can access.
+	SearchTransactions(userID string, search repositories.TransactionSearch) ([]*entities.TransactionSearchResult, error)
+	UpdateTransaction(walletID, actorID, transactionID string, input TransactionInput) (*entities.Transaction, error)
+	DeleteTransaction(walletID, actorID, transactionID string) error
+	// SetTransactionStatus moves a transaction through its lifecycle.
+	SetTransactionStatus(walletID, actorID, transactionID string, status entities.TransactionStatus) (*entities.Transaction, error)
+	// PromoteScheduled makes the scheduled transactions whose date has come
+	// by now pending and returns how many were promoted.
+	PromoteScheduled(now time.Time) (int64, error)
+	// MaterializeOccurrences creates the transactions of due occurrences of
+	// recurring the way CreateTransaction would, and saves how far its
+	// schedule got. It returns how many were created.
+	MaterializeOccurrences(recurring *entities.RecurringTransaction, transactions []*entities.Transaction) (int, error)
+	// BulkUpdateTransactions runs a bulk operation on behalf of userID.
+	BulkUpdateTransactions(userID string, input BulkTransactionInput) (*BulkResult, error)
+}
+
+type transactionService struct {
+	transactionRepo  repositories.TransactionRepository
+	walletRepo       repositories.WalletRepository
+	categoryRepo     repositories.CategoryRepository
+	tagRepo          repositories.TagRepository
+	payeeRepo        repositories.PayeeRepository
+	ruleRepo         repositories.RuleRepository
+	userRepo         repositories.UserRepository
+	approvalRepo     repositories.ApprovalRepository
+	limitRepo        repositories.SpendingLimitRepository
+	notificationRepo repositories.NotificationRepository
+	activityRepo     repositories.ActivityRepository
+	recurringRepo    repositories.RecurringTransactionRepository
+	rateService      ExchangeRateService
+	logger           logger.Logger
+}
+
+var (
+	ErrTransactionNotFound       = apperror.New(apperror.ErrorTypeNotFound, "Transaction not found")
+	ErrTransactionAuthorNotFound = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	// ErrTransactionParentNotMember is returned to dependents spending from
+	// a wallet their parent cannot see, as nobody could approve it.
+	ErrTransactionParentNotMember = apperror.New(apperror.ErrorTypeUnprocessable, "Parent must be a member of the wallet to approve expenses")
+	ErrTransactionDependentChange = apperror.New(apperror.ErrorTypeForbidden, "Dependents cannot change the type or amount of an expense")
+	// ErrTransactionDependentBulk keeps dependents to retagging in bulk;
+	// moving, deleting or recategorizing would get around the review of
+	// their expenses.
+	ErrTransactionDependentBulk = apperror.New(apperror.ErrorTypeForbidden, "Dependents can only retag transactions in bulk")
+	// ErrReconciledEditUnconfirmed is returned when a reconciled transaction
+	// would be edited without confirming it.
+	ErrReconciledEditUnconfirmed = apperror.New(apperror.ErrorTypeUnprocessable, "Transaction is reconciled; confirm the edit to change it")
+	// ErrReconciledDelete keeps reconciled transactions from dropping out of
+	// the statement they were matched against.
+	ErrReconciledDelete = apperror.New(apperror.ErrorTypeUnprocessable, "Reconciled transactions cannot be deleted; un-reconcile it first")
+)
+
+func (s *transactionService) CreateTransaction(walletID, actorID string, input TransactionInput) (*entities.Transaction, error) {
+	author, err := s.findUser(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, author.ID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := entities.NewTransaction(
+		walletID,
+		input.Type,
+		input.Amount,
+		input.Date,
+		input.Description,
+		input.CategoryID,
+		author.ID,
+	)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+	transaction.SetTags(input.TagIDs)
+
+	if input.Status != "" {
+		if err := transaction.SetInitialStatus(input.Status); err != nil {
+			return nil, statusError(transaction, err)
+		}
+	}
+
+	if err := setSplits(transaction, input.Splits); err != nil {
+		return nil, err
+	}
+
+	if err := s.setSharing(transaction, input.Sharing); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCategory(transaction); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTags(transaction); err != nil {
+		return nil, err
+	}
+
+	payee, err := s.setPayee(transaction, input.PayeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRules(transaction); err != nil {
+		return nil, err
+	}
+
+	if err := s.suggestCategory(transaction, payee); err != nil {
+		return nil, err
+	}
+
+	nearing, err := checkSpendingLimits(s.limitRepo, s.rateService, s.logger, transaction, "")
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := s.holdForApproval(transaction, author)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdTransaction *entities.Transaction
+	if approval != nil {
+		createdTransaction, err = s.approvalRepo.CreateHeldTransaction(transaction, approval)
+	} else {
+		createdTransaction, err = s.transactionRepo.CreateTransaction(transaction)
+	}
+	if err != nil {
+		s.logger.Error(err, "Failed to create transaction", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	s.warnLimitOwners(transaction.WalletID, nearing)
+	record(s.activityRepo, s.logger, newActivity(s.logger, func() (*entities.Activity, error) {
+		return entities.NewTransactionActivity(transaction.WalletID, author.ID, nil, transaction)
+	}))
+	return createdTransaction, nil
+}
+
+// MaterializeOccurrences runs each occurrence through the rules, spending
+// limits and approval of CreateTransaction. Nobody is there to be refused an
+// occurrence over a limit, and refusing it would stall the schedule, so it
+// is held for the wallet owners to review instead.
+func (s *transactionService) MaterializeOccurrences(
+	recurring *entities.RecurringTransaction,
+	transactions []*entities.Transaction,
+) (int, error) {
+	author, err := s.findUser(recurring.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	wallet, err := s.findWallet(recurring.WalletID)
+	if err != nil {
+		return 0, err
+	}
+
+	nearing := make(map[string][]limitUsage, len(transactions))
+	approvals := make([]*entities.Approval, 0)
+	for _, transaction := range transactions {
+		if err := s.applyRules(transaction); err != nil {
+			return 0, err
+		}
+
+		var approval *entities.Approval
+		usage, err := checkSpendingLimits(s.limitRepo, s.rateService, s.logger, transaction, "")
+		switch {
+		case errors.Is(err, entities.ErrSpendingLimitExceeded):
+			approval, err = s.holdOverLimit(wallet, transaction, author)
+		case err == nil && transaction.Type == entities.TransactionTypeExpense:
+			nearing[transaction.ID] = usage
+			approval, err = s.holdInWallet(wallet, transaction, author)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if approval != nil {
+			approvals = append(approvals, approval)
+		}
+	}
+
+	created, err := s.recurringRepo.MaterializeOccurrences(recurring, transactions, approvals)
+	if err != nil {
+		s.logger.Error(err, "Failed to create occurrences", map[string]interface{}{
+			"recurring_id": recurring.ID,
+		})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	activities := make([]*entities.Activity, 0, len(created))
+	for _, transaction := range created {
+		s.warnLimitOwners(transaction.WalletID, nearing[transaction.ID])
+		activities = append(activities, newActivity(s.logger, func() (*entities.Activity, error) {
+			return entities.NewTransactionActivity(transaction.WalletID, author.ID, nil, transaction)
+		}))
+	}
+	record(s.activityRepo, s.logger, activities...)
+	return len(created), nil
+}
+
+// holdOverLimit holds an occurrence over a spending limit for review, by the
+// parent of a dependent or by the wallet owners otherwise.
+func (s *transactionService) holdOverLimit(
+	wallet *entities.Wallet,
+	transaction *entities.Transaction,
+	author *entities.User,
+) (*entities.Approval, error) {
+	if author.IsDependent() {
+		return s.holdInWallet(wallet, transaction, author)
+	}
+
+	if err := transaction.HoldForApproval(); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+
+	ttl := wallet.ApprovalPolicy.TTL
+	if ttl <= 0 {
+		ttl = entities.DefaultApprovalTTL
+	}
+	approval, err := entities.NewApproval(transaction, ttl, time.Now())
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+	return approval, nil
+}
+
+func (s *transactionService) GetTransaction(walletID, actorID, transactionID string) (*entities.Transaction, error) {
+	return s.memberTransaction(walletID, actorID, transactionID)
+}
+
+// memberTransaction returns the transaction when actorID is a member of
+// walletID and the transaction belongs to it, so a transaction cannot be
+// read through another wallet's URL.
+func (s *transactionService) memberTransaction(walletID, actorID, transactionID string) (*entities.Transaction, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionRepo.FindTransactionByID(transactionID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find transaction", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if transaction == nil || transaction.WalletID != walletID {
+		return nil, ErrTransactionNotFound
+	}
+
+	return transaction, nil
+}
+
+func (s *transactionService) ListTransactions(
+	walletID string,
+	actorID string,
+	filter repositories.TransactionFilter,
+	page query.Page,
+) ([]*entities.Transaction, *query.Cursor, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, nil, err
+	}
+
+	filter, err := normalizeTransactionFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactions, next, err := s.transactionRepo.FindTransactionsByWalletID(walletID, filter, page)
+	if err != nil {
+		s.logger.Error(err, "Failed to list transactions", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return transactions, next, nil
+}
+
+func (s *transactionService) SearchTransactions(userID string, search repositories.TransactionSearch) ([]*entities.TransactionSearchResult, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return nil, ErrTransactionAuthorNotFound
+	}
+
+	query, err := entities.NormalizeSearchQuery(search.Query)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+	search.Query = query
+	search.UserID = user.ID
+
+	results, err := s.transactionRepo.SearchTransactions(search)
+	if err != nil {
+		s.logger.Error(err, "Failed to search transactions", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return results, nil
+}
+
+func (s *transactionService) UpdateTransaction(walletID, actorID, transactionID string, input TransactionInput) (*entities.Transaction, error) {
+	transaction, err := s.memberTransaction(walletID, actorID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkNotTransferLeg(transaction); err != nil {
+		return nil, err
+	}
+
+	if err := checkReconciledEdit(transaction, input.ConfirmReconciled); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDependentChange(transaction, actorID, input); err != nil {
+		return nil, err
+	}
+
+	previous := *transaction
+	if err := transaction.Update(input.Type, input.Amount, input.Date, input.Description, input.CategoryID); err != nil {
+		if errors.Is(err, entities.ErrTransactionReconciled) || errors.Is(err, entities.ErrAwaitingApproval) {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+	transaction.SetTags(input.TagIDs)
+
+	if err := setStatus(transaction, input.Status); err != nil {
+		return nil, err
+	}
+
+	if err := setSplits(transaction, input.Splits); err != nil {
+		return nil, err
+	}
+
+	if err := s.setSharing(transaction, input.Sharing); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCategory(transaction); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTags(transaction); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.setPayee(transaction, input.PayeeID); err != nil {
+		return nil, err
+	}
+
+	var nearing []limitUsage
+	if spendingChanged(&previous, transaction) {
+		if nearing, err = checkSpendingLimits(s.limitRepo, s.rateService, s.logger, transaction, transaction.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	var approval *entities.Approval
+	if approvalChanged(&previous, transaction) {
+		author, err := s.findUser(transaction.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		if approval, err = s.holdForApproval(transaction, author); err != nil {
+			return nil, err
+		}
+	}
+
+	var updatedTransaction *entities.Transaction
+	if approval != nil {
+		updatedTransaction = transaction
+		err = s.approvalRepo.HoldTransactions([]*entities.Transaction{transaction}, []*entities.Approval{approval})
+	} else {
+		updatedTransaction, err = s.transactionRepo.UpdateTransaction(transaction)
+	}
+	if err != nil {
+		s.logger.Error(err, "Failed to update transaction", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	s.warnLimitOwners(transaction.WalletID, nearing)
+	record(s.activityRepo, s.logger, newActivity(s.logger, func() (*entities.Activity, error) {
+		return entities.NewTransactionActivity(transaction.WalletID, actorID, &previous, transaction)
+	}))
+	return updatedTransaction, nil
+}
+
+func (s *transactionService) DeleteTransaction(walletID, actorID, transactionID string) error {
+	transaction, err := s.memberTransaction(walletID, actorID, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkNotTransferLeg(transaction); err != nil {
+		return err
+	}
+
+	if transaction.Status == entities.TransactionStatusReconciled {
+		return ErrReconciledDelete
+	}
+
+	transaction.Delete()
+
+	if err := s.transactionRepo.DeleteTransaction(transaction); err != nil {
+		s.logger.Error(err, "Failed to delete transaction", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	record(s.activityRepo, s.logger, newActivity(s.logger, func() (*entities.Activity, error) {
+		return entities.NewTransactionActivity(transaction.WalletID, actorID, transaction, nil)
+	}))
+	return nil
+}
+
+// SetTransactionStatus changes only the status, so it applies to transfer
+// legs too: each leg clears with its own bank.
+func (s *transactionService) SetTransactionStatus(
+	walletID string,
+	actorID string,
+	transactionID string,
+	status entities.TransactionStatus,
+) (*entities.Transaction, error) {
+	transaction, err := s.memberTransaction(walletID, actorID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *transaction
+	if err := setStatus(transaction, status); err != nil {
+		return nil, err
+	}
+
+	updatedTransaction, err := s.transactionRepo.UpdateTransaction(transaction)
+	if err != nil {
+		s.logger.Error(err, "Failed to update transaction", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	record(s.activityRepo, s.logger, newActivity(s.logger, func() (*entities.Activity, error) {
+		return entities.NewTransactionActivity(transaction.WalletID, actorID, &previous, transaction)
+	}))
+	return updatedTransaction, nil
+}
+
+func (s *transactionService) PromoteScheduled(now time.Time) (int64, error) {
+	promoted, err := s.transactionRepo.PromoteScheduledTransactions(now)
+	if err != nil {
+		s.logger.Error(err, "Failed to promote scheduled transactions", map[string]interface{}{})
+		return 0, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	return promoted, nil
+}
+
+// BulkUpdateTransactions runs the operation on the selected transactions.
+// The user must be a member of the wallet of each one, and of the target
+// wallet of a move; dependents can only retag. Transactions the operation
+// cannot apply to are reported and left alone; the others are saved
+// together, so a failed save changes none of them.
+func (s *transactionService) BulkUpdateTransactions(userID string, input BulkTransactionInput) (*BulkResult, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDependent() && input.Operation != entities.BulkRetag {
+		return nil, ErrTransactionDependentBulk
+	}
+
+	walletIDs, err := s.walletRepo.FindWalletIDsByUserID(user.ID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find accessible wallets", map[string]interface{}{
+			"user_id": user.ID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	accessible := make(map[string]bool, len(walletIDs))
+	for _, walletID := range walletIDs {
+		accessible[walletID] = true
+	}
+
+	ids, selected, err := s.selectBulkTransactions(accessible, input)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[string]*entities.Approval)
+	change, err := s.bulkChange(user.ID, accessible, input, selected, held)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkResult{Operation: input.Operation, DryRun: input.DryRun}
+	changed := make([]*entities.Transaction, 0, len(selected))
+	previous := make(map[string]entities.Transaction, len(selected))
+	for _, id := range ids {
+		transaction := selected[id]
+		if transaction != nil {
+			previous[id] = *transaction
+		}
+		err := bulkApply(accessible, transaction, change)
+		if err == nil {
+			changed = append(changed, transaction)
+		}
+		result.Items = append(result.Items, BulkItemResult{TransactionID: id, Err: err})
+	}
+
+	if input.DryRun || len(changed) == 0 {
+		return result, nil
+	}
+
+	approvals := make([]*entities.Approval, 0, len(held))
+	for _, transaction := range changed {
+		if approval := held[transaction.ID]; approval != nil {
+			approvals = append(approvals, approval)
+		}
+	}
+	if len(approvals) > 0 {
+		err = s.approvalRepo.HoldTransactions(changed, approvals)
+	} else {
+		err = s.transactionRepo.SaveTransactions(changed)
+	}
+	if err != nil {
+		s.logger.Error(err, "Failed to save bulk operation", map[string]interface{}{
+			"user_id":   user.ID,
+			"operation": input.Operation,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	record(s.activityRepo, s.logger, s.bulkActivities(user.ID, previous, changed)...)
+	return result, nil
+}
+
+// bulkActivities records each changed transaction in the feed of its wallet.
+// A moved transaction shows in the feeds of both wallets.
+func (s *transactionService) bulkActivities(
+	actorID string,
+	previous map[string]entities.Transaction,
+	changed []*entities.Transaction,
+) []*entities.Activity {
+	activities := make([]*entities.Activity, 0, len(changed))
+	for _, transaction := range changed {
+		before := previous[transaction.ID]
+		after := transaction
+		if transaction.IsDeleted {
+			after = nil
+		}
+
+		walletIDs := []string{before.WalletID}
+		if transaction.WalletID != before.WalletID {
+			walletIDs = append(walletIDs, transaction.WalletID)
+		}
+		for _, walletID := range walletIDs {
+			activities = append(activities, newActivity(s.logger, func() (*entities.Activity, error) {
+				return entities.NewTransactionActivity(walletID, actorID, &before, after)
+			}))
+		}
+	}
+	return activities
+}
+
+// selectBulkTransactions returns the IDs selected, in report order, and the
+// transactions found for them. Selecting by filter requires access to the
+// wallet, so its transactions cannot be listed from outside.
+func (s *transactionService) selectBulkTransactions(
+	accessible map[string]bool,
+	input BulkTransactionInput,
+) ([]string, map[string]*entities.Transaction, error) {
+	tooMany := apperror.New(apperror.ErrorTypeValidation,
+		fmt.Sprintf("A bulk operation can select at most %d transactions", entities.MaxBulkTransactions))
+
+	if len(input.TransactionIDs) > 0 {
+		ids := entities.UniqueIDs(input.TransactionIDs)
+		if len(ids) > entities.MaxBulkTransactions {
+			return nil, nil, tooMany.AddContext("field", "transaction_ids")
+		}
+
+		transactions, err := s.transactionRepo.FindTransactionsByIDs(ids)
+		if err != nil {
+			s.logger.Error(err, "Failed to find transactions", map[string]interface{}{
+				"count": len(ids),
+			})
+			return nil, nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+
+		selected := make(map[string]*entities.Transaction, len(transactions))
+		for _, transaction := range transactions {
+			selected[transaction.ID] = transaction
+		}
+		return ids, selected, nil
+	}
+
+	if !accessible[input.WalletID] {
+		return nil, nil, ErrWalletForbidden
+	}
+
+	filter, err := normalizeTransactionFilter(input.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactions, err := walletTransactions(s.transactionRepo, s.logger, input.WalletID, filter, entities.MaxBulkTransactions)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(transactions) > entities.MaxBulkTransactions {
+		return nil, nil, tooMany.AddContext("field", "filter")
+	}
+
+	ids := make([]string, 0, len(transactions))
+	selected := make(map[string]*entities.Transaction, len(transactions))
+	for _, transaction := range transactions {
+		ids = append(ids, transaction.ID)
+		selected[transaction.ID] = transaction
+	}
+	return ids, selected, nil
+}
+
+// walletTransactions lists the transactions of the wallet matching the
+// filter, newest first. It stops once more than limit are found, so callers
+// can tell the selection is too large.
+func walletTransactions(
+	transactionRepo repositories.TransactionRepository,
+	log logger.Logger,
+	walletID string,
+	filter repositories.TransactionFilter,
+	limit int,
+) ([]*entities.Transaction, error) {
+	listed := make([]*entities.Transaction, 0)
+	var after *query.Cursor
+	for {
+		page, err := repositories.TransactionSorts.NewPage(repositories.DefaultTransactionSort, after, query.MaxLimit)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeInternal, err)
+		}
+
+		transactions, next, err := transactionRepo.FindTransactionsByWalletID(walletID, filter, page)
+		if err != nil {
+			log.Error(err, "Failed to list transactions", map[string]interface{}{
+				"wallet_id": walletID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+
+		listed = append(listed, transactions...)
+		if len(listed) > limit || next == nil {
+			return listed, nil
+		}
+		after = next
+	}
+}
+
+// bulkChange loads what the operation needs once and returns the change to
+// make to each transaction. Categories and tags that do not exist fail the
+// whole operation; those that do not fit a transaction only fail its item.
+// A move holds the expenses the target wallet requires approval for, and
+// adds the requests to review them to held by transaction ID.
+func (s *transactionService) bulkChange(
+	userID string,
+	accessible map[string]bool,
+	input BulkTransactionInput,
+	selected map[string]*entities.Transaction,
+	held map[string]*entities.Approval,
+) (func(*entities.Transaction) error, error) {
+	switch input.Operation {
+	case entities.BulkRecategorize:
+		var category *entities.Category
+		if input.CategoryID != "" {
+			var err error
+			category, err = s.categoryRepo.FindCategoryByID(input.CategoryID)
+			if err != nil {
+				s.logger.Error(err, "Failed to find category", map[string]interface{}{
+					"category_id": input.CategoryID,
+				})
+				return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+			}
+			if category == nil || category.UserID != userID {
+				return nil, ErrCategoryNotFound
+			}
+		}
+
+		return func(transaction *entities.Transaction) error {
+			if err := checkReconciledEdit(transaction, input.ConfirmReconciled); err != nil {
+				return err
+			}
+			if category != nil {
+				if category.UserID != transaction.CreatedBy {
+					return ErrCategoryNotFound
+				}
+				if !category.Accepts(transaction.Type) {
+					return apperror.Wrap(apperror.ErrorTypeUnprocessable, entities.ErrCategoryTypeMismatch).
+						AddContext("category_type", category.Type)
+				}
+			}
+			if err := transaction.Recategorize(input.CategoryID); err != nil {
+				return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+			}
+			return nil
+		}, nil
+
+	case entities.BulkRetag:
+		tags, err := s.findTags(entities.UniqueIDs(input.AddTagIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, tagID := range entities.UniqueIDs(input.AddTagIDs) {
+			if tags[tagID] == nil {
+				return nil, apperror.New(apperror.ErrorTypeNotFound, "Tag not found").
+					AddContext("tag_id", tagID)
+			}
+		}
+
+		return func(transaction *entities.Transaction) error {
+			if err := checkReconciledEdit(transaction, input.ConfirmReconciled); err != nil {
+				return err
+			}
+			for _, tag := range tags {
+				if !tag.AppliesTo(transaction) {
+					return apperror.New(apperror.ErrorTypeNotFound, "Tag not found").
+						AddContext("tag_id", tag.ID)
+				}
+			}
+			transaction.Retag(input.AddTagIDs, input.RemoveTagIDs)
+			return nil
+		}, nil
+
+	case entities.BulkMove:
+		if !accessible[input.TargetWalletID] {
+			return nil, ErrWalletForbidden
+		}
+
+		tagIDs := make([]string, 0)
+		for _, transaction := range selected {
+			tagIDs = append(tagIDs, transaction.TagIDs...)
+		}
+		tags, err := s.findTags(entities.UniqueIDs(tagIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		target, err := s.findWallet(input.TargetWalletID)
+		if err != nil {
+			return nil, err
+		}
+
+		authors := make(map[string]*entities.User)
+		// Wallet tags stay behind: they only label transactions of their
+		// wallet.
+		return func(transaction *entities.Transaction) error {
+			if err := transaction.MoveTo(input.TargetWalletID); err != nil {
+				return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+			}
+
+			kept := make([]string, 0, len(transaction.TagIDs))
+			for _, tagID := range transaction.TagIDs {
+				if tag := tags[tagID]; tag != nil && tag.AppliesTo(transaction) {
+					kept = append(kept, tagID)
+				}
+			}
+			transaction.SetTags(kept)
+
+			if transaction.Type != entities.TransactionTypeExpense {
+				return nil
+			}
+			author := authors[transaction.CreatedBy]
+			if author == nil {
+				if author, err = s.findUser(transaction.CreatedBy); err != nil {
+					return err
+				}
+				authors[author.ID] = author
+			}
+			approval, err := s.holdInWallet(target, transaction, author)
+			if err != nil {
+				return err
+			}
+			if approval != nil {
+				held[transaction.ID] = approval
+			}
+			return nil
+		}, nil
+
+	case entities.BulkDelete:
+		return func(transaction *entities.Transaction) error {
+			if transaction.Status == entities.TransactionStatusReconciled {
+				return ErrReconciledDelete
+			}
+			transaction.Delete()
+			return nil
+		}, nil
+
+	default:
+		return nil, apperror.New(apperror.ErrorTypeValidation, "Invalid bulk operation").
+			AddContext("field", "operation")
+	}
+}
+
+// checkReconciledEdit lets a reconciled transaction be edited only when the
+// caller confirms it, so that a completed reconciliation is not undone by
+// accident.
+func checkReconciledEdit(transaction *entities.Transaction, confirmed bool) error {
+	if transaction.Status == entities.TransactionStatusReconciled && !confirmed {
+		return ErrReconciledEditUnconfirmed
+	}
+	return nil
+}
+
+// bulkApply changes one selected transaction, which must exist, be in an
+// accessible wallet and not be a transfer leg.
+func bulkApply(
+	accessible map[string]bool,
+	transaction *entities.Transaction,
+	change func(*entities.Transaction) error,
+) error {
+	if transaction == nil {
+		return ErrTransactionNotFound
+	}
+	if !accessible[transaction.WalletID] {
+		return ErrWalletForbidden
+	}
+	if err := checkNotTransferLeg(transaction); err != nil {
+		return err
+	}
+	return change(transaction)
+}
+
+// findTags loads the tags with the given IDs by ID.
+func (s *transactionService) findTags(tagIDs []string) (map[string]*entities.Tag, error) {
+	tags := make(map[string]*entities.Tag, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return tags, nil
+	}
+
+	found, err := s.tagRepo.FindTagsByIDs(tagIDs)
+	if err != nil {
+		s.logger.Error(err, "Failed to find tags", map[string]interface{}{
+			"count": len(tagIDs),
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	for _, tag := range found {
+		tags[tag.ID] = tag
+	}
+	return tags, nil
+}
+
+// normalizeTransactionFilter checks the filter and drops repeated IDs.
+func normalizeTransactionFilter(filter repositories.TransactionFilter) (repositories.TransactionFilter, error) {
+	if err := checkTransactionFilter(filter); err != nil {
+		return filter, err
+	}
+
+	filter.TagIDs = entities.UniqueIDs(filter.TagIDs)
+	filter.CategoryIDs = entities.UniqueIDs(filter.CategoryIDs)
+	filter.PayeeIDs = entities.UniqueIDs(filter.PayeeIDs)
+	filter.MemberIDs = entities.UniqueIDs(filter.MemberIDs)
+	return filter, nil
+}
+
+// checkTransactionFilter rejects ranges that cannot match anything.
+func checkTransactionFilter(filter repositories.TransactionFilter) error {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return apperror.New(apperror.ErrorTypeValidation, "Date range starts after it ends").
+			AddContext("field", "from")
+	}
+
+	if filter.MinAmount != nil && filter.MaxAmount != nil {
+		cmp, err := filter.MinAmount.Cmp(*filter.MaxAmount)
+		if err != nil {
+			return apperror.New(apperror.ErrorTypeValidation, "Amount range bounds must be in the same currency").
+				AddContext("field", "currency")
+		}
+		if cmp > 0 {
+			return apperror.New(apperror.ErrorTypeValidation, "Amount range starts above its end").
+				AddContext("field", "min_amount")
+		}
+	}
+
+	return nil
+}
+
+// checkNotTransferLeg keeps the legs of a transfer in step: they can only be
+// changed through the transfer itself.
+func checkNotTransferLeg(transaction *entities.Transaction) error {
+	if !transaction.IsTransfer() {
+		return nil
+	}
+
+	return apperror.New(apperror.ErrorTypeUnprocessable, "Transfer legs can only be changed through their transfer").
+		AddContext("transfer_id", transaction.TransferID)
+}
+
+// setStatus moves the transaction to status, if one is given.
+func setStatus(transaction *entities.Transaction, status entities.TransactionStatus) error {
+	if status == "" {
+		return nil
+	}
+
+	if err := transaction.SetStatus(status); err != nil {
+		return statusError(transaction, err)
+	}
+	return nil
+}
+
+func statusError(transaction *entities.Transaction, err error) error {
+	if errors.Is(err, entities.ErrInvalidStatusTransition) || errors.Is(err, entities.ErrAwaitingApproval) {
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err).
+			AddContext("status", transaction.Status)
+	}
+	return apperror.Wrap(apperror.ErrorTypeValidation, err)
+}
+
+// setSplits replaces the split lines of the transaction with the input ones.
+func setSplits(transaction *entities.Transaction, inputs []TransactionSplitInput) error {
+	splits := make([]entities.TransactionSplit, 0, len(inputs))
+	for i, input := range inputs {
+		split, err := entities.NewTransactionSplit(input.Amount, input.CategoryID, input.Note)
+		if err != nil {
+			return apperror.Wrap(apperror.ErrorTypeValidation, err).
+				AddContext("split", i)
+		}
+		splits = append(splits, split)
+	}
+
+	if err := transaction.SetSplits(splits); err != nil {
+		return apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+	return nil
+}
+
+// setSharing shares the transaction among the input members, who must all
+// be members of its wallet, or makes it personal when input is nil.
+func (s *transactionService) setSharing(transaction *entities.Transaction, input *TransactionSharingInput) error {
+	if input == nil {
+		transaction.ClearSharing()
+		return nil
+	}
+
+	if err := transaction.ShareExpense(input.PaidBy, input.Method, input.Shares); err != nil {
+		return apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	members := []string{transaction.Sharing.PaidBy}
+	for _, share := range transaction.Sharing.Shares {
+		members = append(members, share.UserID)
+	}
+
+	for _, userID := range entities.UniqueIDs(members) {
+		member, err := s.walletRepo.FindWalletMember(transaction.WalletID, userID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find wallet member", map[string]interface{}{
+				"wallet_id": transaction.WalletID,
+				"user_id":   userID,
+			})
+			return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if member == nil {
+			return apperror.New(apperror.ErrorTypeValidation, "Expenses can only be shared with wallet members").
+				AddContext("field", "sharing").
+				AddContext("user_id", userID)
+		}
+	}
+
+	return nil
+}
+
+// checkCategory makes sure the categories of the transaction, or of its
+// split lines, belong to the transaction author and match its type.
+func (s *transactionService) checkCategory(transaction *entities.Transaction) error {
+	for _, categoryID := range transaction.CategoryIDs() {
+		if err := checkCategory(s.categoryRepo, s.logger, transaction.CreatedBy, categoryID, transaction.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCategory makes sure an optional category belongs to userID and
+// accepts transactions of the given type.
+func checkCategory(
+	categoryRepo repositories.CategoryRepository,
+	log logger.Logger,
+	userID string,
+	categoryID string,
+	transactionType entities.TransactionType,
+) error {
+	if categoryID == "" {
+		return nil
+	}
+
+	category, err := categoryRepo.FindCategoryByID(categoryID)
+	if err != nil {
+		log.Error(err, "Failed to find category", map[string]interface{}{
+			"category_id": categoryID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if category == nil || category.UserID != userID {
+		return ErrCategoryNotFound
+	}
+
+	if !category.Accepts(transactionType) {
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, entities.ErrCategoryTypeMismatch).
+			AddContext("category_type", category.Type)
+	}
+
+	return nil
+}
+
+// checkTags makes sure every tag exists and is either a tag of the author
+// or of the transaction wallet.
+func (s *transactionService) checkTags(transaction *entities.Transaction) error {
+	if len(transaction.TagIDs) == 0 {
+		return nil
+	}
+
+	tags, err := s.tagRepo.FindTagsByIDs(transaction.TagIDs)
+	if err != nil {
+		s.logger.Error(err, "Failed to find tags", map[string]interface{}{
+			"transaction_id": transaction.ID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	found := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if tag.AppliesTo(transaction) {
+			found[tag.ID] = true
+		}
+	}
+
+	for _, tagID := range transaction.TagIDs {
+		if !found[tagID] {
+			return apperror.New(apperror.ErrorTypeNotFound, "Tag not found").
+				AddContext("tag_id", tagID)
+		}
+	}
+
+	return nil
+}
+
+// resolvePayee returns the author's payee with payeeID or, when payeeID is
+// empty, the payee the description normalizes to, creating it the first
+// time the name is seen. A description that normalizes to nothing has no
+// payee.
+func (s *transactionService) resolvePayee(transaction *entities.Transaction, payeeID string) (*entities.Payee, error) {
+	if payeeID != "" {
+		payee, err := s.payeeRepo.FindPayeeByID(payeeID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find payee", map[string]interface{}{
+				"payee_id": payeeID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if payee == nil || payee.UserID != transaction.CreatedBy {
+			return nil, ErrPayeeNotFound
+		}
+		return payee, nil
+	}
+
+	alias := entities.NormalizePayeeName(transaction.Description)
+	if alias == "" {
+		return nil, nil
+	}
+
+	payee, err := s.payeeRepo.FindPayeeByAlias(transaction.CreatedBy, alias)
+	if err != nil {
+		s.logger.Error(err, "Failed to find payee", map[string]interface{}{
+			"alias": alias,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if payee != nil {
+		return payee, nil
+	}
+
+	payee, err = entities.NewPayeeFromDescription(transaction.CreatedBy, transaction.Description)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	createdPayee, err := s.payeeRepo.CreatePayee(payee)
+	if err != nil {
+		s.logger.Error(err, "Failed to create payee", map[string]interface{}{
+			"alias": alias,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdPayee, nil
+}
+
+// setPayee resolves the payee of the transaction and links them.
+func (s *transactionService) setPayee(transaction *entities.Transaction, payeeID string) (*entities.Payee, error) {
+	payee, err := s.resolvePayee(transaction, payeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payee == nil {
+		transaction.SetPayee("")
+		return nil, nil
+	}
+
+	transaction.SetPayee(payee.ID)
+	return payee, nil
+}
+
+// applyRules runs the author's rules on a new transaction. Rules only
+// categorize transactions left uncategorized, and their category takes
+// precedence over the default category of the payee.
+func (s *transactionService) applyRules(transaction *entities.Transaction) error {
+	rules, err := s.ruleRepo.FindRulesByUserID(transaction.CreatedBy)
+	if err != nil {
+		s.logger.Error(err, "Failed to list rules", map[string]interface{}{
+			"user_id": transaction.CreatedBy,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	entities.ApplyRules(rules, transaction, false)
+	return nil
+}
+
+// suggestCategory files an uncategorized transaction under the default
+// category of its payee, when that category accepts the transaction type.
+func (s *transactionService) suggestCategory(transaction *entities.Transaction, payee *entities.Payee) error {
+	if payee == nil || payee.DefaultCategoryID == "" || len(transaction.CategoryIDs()) > 0 {
+		return nil
+	}
+
+	category, err := s.categoryRepo.FindCategoryByID(payee.DefaultCategoryID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find category", map[string]interface{}{
+			"category_id": payee.DefaultCategoryID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if category == nil || category.UserID != transaction.CreatedBy || !category.Accepts(transaction.Type) {
+		return nil
+	}
+
+	transaction.SuggestCategory(category.ID)
+	return nil
+}
+
+// checkDependentChange keeps dependents from raising an expense their
+// parent already approved: the type and amount of their expenses are fixed
+// once created.
+func (s *transactionService) checkDependentChange(transaction *entities.Transaction, actorID string, input TransactionInput) error {
+	if transaction.Type != entities.TransactionTypeExpense && input.Type != entities.TransactionTypeExpense {
+		return nil
+	}
+	if input.Type == transaction.Type && input.Amount.Equal(transaction.Amount) {
+		return nil
+	}
+
+	actor, err := s.findUser(actorID)
+	if err != nil {
+		return err
+	}
+	if actor.IsDependent() {
+		return ErrTransactionDependentChange
+	}
+	return nil
+}
+
+// holdForApproval holds an expense above the approval threshold of its
+// wallet and returns the request to review it, or nil when the expense goes
+// through. Every expense of a dependent is held for their parent instead,
+// who must be a member of the wallet to review it.
+func (s *transactionService) holdForApproval(transaction *entities.Transaction, author *entities.User) (*entities.Approval, error) {
+	if transaction.Type != entities.TransactionTypeExpense {
+		return nil, nil
+	}
+
+	wallet, err := s.findWallet(transaction.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.holdInWallet(wallet, transaction, author)
+}
+
+// holdInWallet is holdForApproval with the wallet of the expense loaded.
+// Owners are exempt from the threshold: they are the ones reviewing, and
+// cannot review their own expenses.
+func (s *transactionService) holdInWallet(
+	wallet *entities.Wallet,
+	transaction *entities.Transaction,
+	author *entities.User,
+) (*entities.Approval, error) {
+	policy := wallet.ApprovalPolicy
+	if author.IsDependent() {
+		parent, err := s.walletRepo.FindWalletMember(wallet.ID, author.ParentID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find wallet member", map[string]interface{}{
+				"wallet_id": wallet.ID,
+				"user_id":   author.ParentID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if parent == nil {
+			return nil, ErrTransactionParentNotMember
+		}
+	} else {
+		if !policy.Enabled() {
+			return nil, nil
+		}
+
+		if member := wallet.Member(author.ID); member != nil && member.CanManageMembers() {
+			return nil, nil
+		}
+
+		required, err := s.exceedsThreshold(transaction, policy.Threshold)
+		if err != nil || !required {
+			return nil, err
+		}
+	}
+
+	if err := transaction.HoldForApproval(); err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+
+	approval, err := entities.NewApproval(transaction, policy.TTL, time.Now())
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+	if author.IsDependent() {
+		if err := approval.AssignReviewer(author.ParentID); err != nil {
+			return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+		}
+	}
+	return approval, nil
+}
+
+// exceedsThreshold compares the expense with the threshold in the threshold
+// currency, at the rate of the expense date. An expense without a rate is
+// held, since it cannot be shown to be within the threshold.
+func (s *transactionService) exceedsThreshold(transaction *entities.Transaction, threshold money.Money) (bool, error) {
+	conversion, err := s.rateService.Convert(transaction.Amount, threshold.Currency().Code, transaction.Date)
+	if err != nil {
+		if apperror.IsErrorType(err, apperror.ErrorTypeNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	required, err := entities.RequiresApproval(conversion.Converted, threshold)
+	if err != nil {
+		return false, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	}
+	return required, nil
+}
+
+// approvalChanged reports whether an edit can turn an expense into one that
+// needs approval: a raised amount or an income turned into an expense. An
+// expense already held keeps its request.
+func approvalChanged(previous, transaction *entities.Transaction) bool {
+	return !previous.AwaitingApproval &&
+		(previous.Type != transaction.Type || !previous.Amount.Equal(transaction.Amount))
+}
+
+// spendingChanged reports whether an edit changes what the spending limits
+// of the author count, so that other edits are not refused once a limit
+// was lowered.
+func spendingChanged(previous, transaction *entities.Transaction) bool {
+	return previous.Type != transaction.Type ||
+		!previous.Amount.Equal(transaction.Amount) ||
+		!previous.Date.Equal(transaction.Date) ||
+		!slices.Equal(previous.CategoryIDs(), transaction.CategoryIDs())
+}
+
+// findUser returns the user, who must exist.
+func (s *transactionService) findUser(userID string) (*entities.User, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return nil, ErrTransactionAuthorNotFound
+	}
+	return user, nil
+}
+
+func (s *transactionService) findWallet(walletID string) (*entities.Wallet, error) {
+	wallet, err := s.walletRepo.FindWalletByID(walletID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if wallet == nil {
+		return nil, ErrWalletForbidden
+	}
+	return wallet, nil
+}
+
+// warnLimitOwners tells the owners of the wallet, other than the member
+// limited, that a member is close to using up a limit.
+func (s *transactionService) warnLimitOwners(walletID string, nearing []limitUsage) {
+	if len(nearing) == 0 {
+		return
+	}
+
+	wallet, err := s.walletRepo.FindWalletByID(walletID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return
+	}
+	if wallet == nil {
+		return
+	}
+
+	notifications := make([]*entities.Notification, 0)
+	for _, usage := range nearing {
+		for _, member := range wallet.Members {
+			if member.Role != entities.WalletRoleOwner || member.UserID == usage.limit.UserID {
+				continue
+			}
+
+			notification, err := entities.NewSpendingLimitNotification(member.UserID, usage.limit, usage.used.Decimal())
+			if err != nil {
+				s.logger.Error(err, "Invalid notification", map[string]interface{}{
+					"limit_id": usage.limit.ID,
+				})
+				continue
+			}
+			notifications = append(notifications, notification)
+		}
+	}
+
+	notify(s.notificationRepo, s.logger, notifications)
+}
+
+func NewTransactionService(
+	transactionRepo repositories.TransactionRepository,
+	walletRepo repositories.WalletRepository,
+	categoryRepo repositories.CategoryRepository,
+	tagRepo repositories.TagRepository,
+	payeeRepo repositories.PayeeRepository,
+	ruleRepo repositories.RuleRepository,
+	userRepo repositories.UserRepository,
+	approvalRepo repositories.ApprovalRepository,
+	limitRepo repositories.SpendingLimitRepository,
+	notificationRepo repositories.NotificationRepository,
+	activityRepo repositories.ActivityRepository,
+	recurringRepo repositories.RecurringTransactionRepository,
+	rateService ExchangeRateService,
+	logger logger.Logger,
+) TransactionService {
+	return &transactionService{
+		transactionRepo:  transactionRepo,
+		walletRepo:       walletRepo,
+		categoryRepo:     categoryRepo,
+		tagRepo:          tagRepo,
+		payeeRepo:        payeeRepo,
+		ruleRepo:         ruleRepo,
+		userRepo:         userRepo,
+		approvalRepo:     approvalRepo,
+		limitRepo:        limitRepo,
+		notificationRepo: notificationRepo,
+		activityRepo:     activityRepo,
+		recurringRepo:    recurringRepo,
+		rateService:      rateService,
+		logger:           logger,
+	}
+}
@@ -0,0 +1,1545 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) CreateTransaction(transaction *entities.Transaction) (*entities.Transaction, error) {
+	args := m.Called(transaction)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) FindTransactionByID(id string) (*entities.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) FindTransactionsByWalletID(
+	walletID string,
+	filter repositories.TransactionFilter,
+	page query.Page,
+) ([]*entities.Transaction, *query.Cursor, error) {
+	args := m.Called(walletID, filter, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	next, _ := args.Get(1).(*query.Cursor)
+	return args.Get(0).([]*entities.Transaction), next, args.Error(2)
+}
+
+func (m *MockTransactionRepository) UpdateTransaction(transaction *entities.Transaction) (*entities.Transaction, error) {
+	args := m.Called(transaction)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) DeleteTransaction(transaction *entities.Transaction) error {
+	args := m.Called(transaction)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) SearchTransactions(search repositories.TransactionSearch) ([]*entities.TransactionSearchResult, error) {
+	args := m.Called(search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.TransactionSearchResult), args.Error(1)
+}
+
+func (m *MockTransactionRepository) FindTransactionsByIDs(ids []string) ([]*entities.Transaction, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SaveTransactions(transactions []*entities.Transaction) error {
+	args := m.Called(transactions)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) PromoteScheduledTransactions(today time.Time) (int64, error) {
+	args := m.Called(today)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionRepository) PurgeDeletedTransactions(deletedBefore time.Time) (int64, error) {
+	args := m.Called(deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func newTransactionInput(t *testing.T, value string) services.TransactionInput {
+	t.Helper()
+	return services.TransactionInput{
+		Type:        entities.TransactionTypeExpense,
+		Amount:      newMoney(t, value, "BRL"),
+		Date:        time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Description: "Groceries",
+	}
+}
+
+func newTestTransaction(t *testing.T) *entities.Transaction {
+	t.Helper()
+	input := newTransactionInput(t, "42.90")
+	transaction, err := entities.NewTransaction("wallet-id", input.Type, input.Amount, input.Date, input.Description, input.CategoryID, "user-id")
+	require.NoError(t, err)
+	transaction.ID = "transaction-id"
+	return transaction
+}
+
+func TestTransactionService_CreateTransaction(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    string
+		mockSetup func(*MockTransactionRepository, *MockUserRepository)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:   "successful creation",
+			amount: "42.90",
+			mockSetup: func(tr *MockTransactionRepository, ur *MockUserRepository) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+				tr.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return tx.WalletID == "wallet-id" && tx.CreatedBy == "user-id" && tx.Amount.MinorUnits() == 4290
+				})).Return(newTestTransaction(t), nil)
+			},
+		},
+		{
+			name:   "unknown author",
+			amount: "42.90",
+			mockSetup: func(tr *MockTransactionRepository, ur *MockUserRepository) {
+				ur.On("FindUserByID", "user-id").Return(nil, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:   "invalid amount",
+			amount: "0.00",
+			mockSetup: func(tr *MockTransactionRepository, ur *MockUserRepository) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:   "repository error",
+			amount: "42.90",
+			mockSetup: func(tr *MockTransactionRepository, ur *MockUserRepository) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+				tr.On("CreateTransaction", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			userRepo := new(MockUserRepository)
+			tt.mockSetup(transactionRepo, userRepo)
+			logger := mocks.NewMockLogger()
+			logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+			service := services.NewTransactionService(transactionRepo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), logger)
+			transaction, err := service.CreateTransaction("wallet-id", "user-id", newTransactionInput(t, tt.amount))
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, transaction)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, transaction)
+			}
+
+			transactionRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_Approval(t *testing.T) {
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	threshold := newMoney(t, "100.00", "USD")
+
+	tests := []struct {
+		name     string
+		txType   entities.TransactionType
+		amount   money.Money
+		role     entities.WalletRole
+		wantHeld bool
+	}{
+		{name: "expense within the threshold goes through", txType: entities.TransactionTypeExpense, amount: newMoney(t, "42.90", "BRL")},
+		{name: "expense above the threshold is held", txType: entities.TransactionTypeExpense, amount: newMoney(t, "600.00", "BRL"), wantHeld: true},
+		{name: "expense without a rate is held", txType: entities.TransactionTypeExpense, amount: newMoney(t, "50.00", "EUR"), wantHeld: true},
+		{name: "income is never held", txType: entities.TransactionTypeIncome, amount: newMoney(t, "600.00", "BRL")},
+		{name: "owner expense above the threshold goes through", txType: entities.TransactionTypeExpense, amount: newMoney(t, "600.00", "BRL"), role: entities.WalletRoleOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := entities.WalletRoleMember
+			if tt.role != "" {
+				role = tt.role
+			}
+			member := &entities.WalletMember{UserID: "user-id", Role: role}
+			wallet := &entities.Wallet{
+				ID:             "wallet-id",
+				Members:        []*entities.WalletMember{member},
+				ApprovalPolicy: entities.ApprovalPolicy{Threshold: threshold, TTL: 48 * time.Hour},
+			}
+			walletRepo := new(MockWalletRepository)
+			walletRepo.On("FindWalletMember", "wallet-id", "user-id").Return(member, nil)
+			walletRepo.On("FindWalletByID", "wallet-id").Return(wallet, nil).Maybe()
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			transactionRepo := new(MockTransactionRepository)
+			approvalRepo := new(MockApprovalRepository)
+			if tt.wantHeld {
+				approvalRepo.On("CreateHeldTransaction",
+					mock.MatchedBy(func(tx *entities.Transaction) bool {
+						return tx.AwaitingApproval && tx.Status == entities.TransactionStatusPending
+					}),
+					mock.MatchedBy(func(approval *entities.Approval) bool {
+						return approval.WalletID == "wallet-id" && approval.RequestedBy == "user-id" &&
+							approval.Amount.Equal(tt.amount) && approval.ExpiresAt.Sub(approval.RequestedAt) == 48*time.Hour
+					}),
+				).Return(newTestTransaction(t), nil)
+			} else {
+				transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return !tx.AwaitingApproval && tx.Status == entities.TransactionStatusCleared
+				})).Return(newTestTransaction(t), nil)
+			}
+
+			input := newTransactionInput(t, "1.00")
+			input.Type = tt.txType
+			input.Amount = tt.amount
+
+			rates := newRateService(newRate(t, "BRL", "USD", "0.2", date))
+			service := services.NewTransactionService(transactionRepo, walletRepo, new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, approvalRepo, newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), rates, mocks.NewMockLogger())
+			_, err := service.CreateTransaction("wallet-id", "user-id", input)
+
+			assert.NoError(t, err)
+			transactionRepo.AssertExpectations(t)
+			approvalRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_Dependent(t *testing.T) {
+	teen := &entities.User{ID: "teen-id", Role: entities.RoleDependent, ParentID: "parent-id"}
+
+	tests := []struct {
+		name         string
+		txType       entities.TransactionType
+		parentMember bool
+		wantHeld     bool
+		wantErr      error
+	}{
+		{name: "expense is held for the parent", txType: entities.TransactionTypeExpense, parentMember: true, wantHeld: true},
+		{name: "income goes through", txType: entities.TransactionTypeIncome, parentMember: true},
+		{name: "parent outside the wallet", txType: entities.TransactionTypeExpense, wantErr: services.ErrTransactionParentNotMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet, err := entities.NewWallet("Pocket money", "parent-id")
+			require.NoError(t, err)
+			walletRepo := new(MockWalletRepository)
+			walletRepo.On("FindWalletMember", "wallet-id", "teen-id").
+				Return(&entities.WalletMember{UserID: "teen-id", Role: entities.WalletRoleMember}, nil)
+			walletRepo.On("FindWalletByID", "wallet-id").Return(wallet, nil).Maybe()
+			if tt.parentMember {
+				walletRepo.On("FindWalletMember", mock.Anything, "parent-id").
+					Return(&entities.WalletMember{UserID: "parent-id", Role: entities.WalletRoleOwner}, nil).Maybe()
+			} else {
+				walletRepo.On("FindWalletMember", mock.Anything, "parent-id").Return(nil, nil).Maybe()
+			}
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "teen-id").Return(teen, nil)
+			transactionRepo := new(MockTransactionRepository)
+			approvalRepo := new(MockApprovalRepository)
+			switch {
+			case tt.wantHeld:
+				approvalRepo.On("CreateHeldTransaction",
+					mock.MatchedBy(func(tx *entities.Transaction) bool { return tx.AwaitingApproval }),
+					mock.MatchedBy(func(approval *entities.Approval) bool {
+						return approval.RequestedBy == "teen-id" && approval.ReviewerID == "parent-id" &&
+							approval.ExpiresAt.Sub(approval.RequestedAt) == entities.DefaultApprovalTTL
+					}),
+				).Return(newTestTransaction(t), nil)
+			case tt.wantErr == nil:
+				transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return !tx.AwaitingApproval
+				})).Return(newTestTransaction(t), nil)
+			}
+
+			input := newTransactionInput(t, "12.00")
+			input.Type = tt.txType
+
+			service := services.NewTransactionService(transactionRepo, walletRepo, new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, approvalRepo, newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err = service.CreateTransaction("wallet-id", "teen-id", input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			transactionRepo.AssertExpectations(t)
+			approvalRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_Category(t *testing.T) {
+	food := &entities.Category{ID: "food-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+	salary := &entities.Category{ID: "salary-id", UserID: "user-id", Type: entities.TransactionTypeIncome}
+	foreign := &entities.Category{ID: "foreign-id", UserID: "other-user-id", Type: entities.TransactionTypeExpense}
+
+	tests := []struct {
+		name     string
+		category *entities.Category
+		wantErr  bool
+		errType  apperror.ErrorType
+	}{
+		{name: "own expense category", category: food},
+		{name: "income category on expense", category: salary, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "another user's category", category: foreign, wantErr: true, errType: apperror.ErrorTypeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			categoryRepo := new(MockCategoryRepository)
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			categoryRepo.On("FindCategoryByID", tt.category.ID).Return(tt.category, nil)
+			if !tt.wantErr {
+				transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return tx.CategoryID == tt.category.ID
+				})).Return(newTestTransaction(t), nil)
+			}
+
+			input := newTransactionInput(t, "42.90")
+			input.CategoryID = tt.category.ID
+
+			service := services.NewTransactionService(transactionRepo, newWalletRepository(), categoryRepo, new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.CreateTransaction("wallet-id", "user-id", input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			transactionRepo.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_Payee(t *testing.T) {
+	transport := &entities.Category{ID: "transport-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+	salary := &entities.Category{ID: "salary-id", UserID: "user-id", Type: entities.TransactionTypeIncome}
+	uber := &entities.Payee{ID: "uber-id", UserID: "user-id", Name: "Uber", DefaultCategoryID: "transport-id", Aliases: []string{"UBER"}}
+	employer := &entities.Payee{ID: "employer-id", UserID: "user-id", Name: "Employer", DefaultCategoryID: "salary-id", Aliases: []string{"EMPLOYER"}}
+	foreign := &entities.Payee{ID: "foreign-id", UserID: "other-user-id", Name: "Uber", Aliases: []string{"UBER"}}
+
+	tests := []struct {
+		name         string
+		description  string
+		payeeID      string
+		categoryID   string
+		mockSetup    func(*MockPayeeRepository, *MockCategoryRepository)
+		wantPayee    string
+		wantCategory string
+		wantErr      bool
+		errType      apperror.ErrorType
+	}{
+		{
+			name:        "known payee suggests its default category",
+			description: "UBER *TRIP 1234 SAO PAULO",
+			mockSetup: func(pr *MockPayeeRepository, cr *MockCategoryRepository) {
+				pr.On("FindPayeeByAlias", "user-id", "UBER").Return(uber, nil)
+				cr.On("FindCategoryByID", "transport-id").Return(transport, nil)
+			},
+			wantPayee:    "uber-id",
+			wantCategory: "transport-id",
+		},
+		{
+			name:        "chosen category wins over the default",
+			description: "UBER BV",
+			categoryID:  "food-id",
+			mockSetup: func(pr *MockPayeeRepository, cr *MockCategoryRepository) {
+				cr.On("FindCategoryByID", "food-id").
+					Return(&entities.Category{ID: "food-id", UserID: "user-id", Type: entities.TransactionTypeExpense}, nil)
+				pr.On("FindPayeeByAlias", "user-id", "UBER").Return(uber, nil)
+			},
+			wantPayee:    "uber-id",
+			wantCategory: "food-id",
+		},
+		{
+			name:        "default category of another type is not suggested",
+			description: "Employer",
+			mockSetup: func(pr *MockPayeeRepository, cr *MockCategoryRepository) {
+				pr.On("FindPayeeByAlias", "user-id", "EMPLOYER").Return(employer, nil)
+				cr.On("FindCategoryByID", "salary-id").Return(salary, nil)
+			},
+			wantPayee: "employer-id",
+		},
+		{
+			name:        "unknown payee is created",
+			description: "PAYPAL *NETFLIX 4029357733",
+			mockSetup: func(pr *MockPayeeRepository, cr *MockCategoryRepository) {
+				pr.On("FindPayeeByAlias", "user-id", "NETFLIX").Return(nil, nil)
+				pr.On("CreatePayee", mock.MatchedBy(func(payee *entities.Payee) bool {
+					return payee.UserID == "user-id" && payee.Name == "Netflix" && payee.HasAlias("NETFLIX")
+				})).Return(&entities.Payee{ID: "netflix-id", UserID: "user-id"}, nil)
+			},
+			wantPayee: "netflix-id",
+		},
+		{
+			name:        "description without a name has no payee",
+			description: "1234",
+			mockSetup:   func(pr *MockPayeeRepository, cr *MockCategoryRepository) {},
+		},
+		{
+			name:        "chosen payee",
+			description: "Ride home",
+			payeeID:     "uber-id",
+			mockSetup: func(pr *MockPayeeRepository, cr *MockCategoryRepository) {
+				pr.On("FindPayeeByID", "uber-id").Return(uber, nil)
+				cr.On("FindCategoryByID", "transport-id").Return(transport, nil)
+			},
+			wantPayee:    "uber-id",
+			wantCategory: "transport-id",
+		},
+		{
+			name:        "another user's payee",
+			description: "Ride home",
+			payeeID:     "foreign-id",
+			mockSetup: func(pr *MockPayeeRepository, cr *MockCategoryRepository) {
+				pr.On("FindPayeeByID", "foreign-id").Return(foreign, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			categoryRepo := new(MockCategoryRepository)
+			payeeRepo := new(MockPayeeRepository)
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			tt.mockSetup(payeeRepo, categoryRepo)
+			if !tt.wantErr {
+				transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return tx.PayeeID == tt.wantPayee && tx.CategoryID == tt.wantCategory
+				})).Return(newTestTransaction(t), nil)
+			}
+
+			input := newTransactionInput(t, "42.90")
+			input.Description = tt.description
+			input.PayeeID = tt.payeeID
+			input.CategoryID = tt.categoryID
+
+			service := services.NewTransactionService(transactionRepo, newWalletRepository(), categoryRepo, new(MockTagRepository), payeeRepo, newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.CreateTransaction("wallet-id", "user-id", input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			transactionRepo.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+			payeeRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_GetTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		walletID string
+		found    bool
+		wantErr  bool
+	}{
+		{name: "transaction in wallet", walletID: "wallet-id", found: true},
+		{name: "transaction in another wallet", walletID: "other-wallet-id", found: true, wantErr: true},
+		{name: "missing transaction", walletID: "wallet-id", found: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTransactionRepository)
+			if tt.found {
+				repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+			} else {
+				repo.On("FindTransactionByID", "transaction-id").Return(nil, nil)
+			}
+
+			service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			transaction, err := service.GetTransaction(tt.walletID, "user-id", "transaction-id")
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+				assert.Nil(t, transaction)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "transaction-id", transaction.ID)
+			}
+		})
+	}
+}
+
+func TestTransactionService_NonMember(t *testing.T) {
+	newService := func() (services.TransactionService, *MockTransactionRepository) {
+		repo := new(MockTransactionRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "stranger-id").Return(&entities.User{ID: "stranger-id"}, nil).Maybe()
+		return services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger()), repo
+	}
+
+	calls := map[string]func(services.TransactionService) error{
+		"create": func(s services.TransactionService) error {
+			_, err := s.CreateTransaction("wallet-id", "stranger-id", newTransactionInput(t, "42.90"))
+			return err
+		},
+		"get": func(s services.TransactionService) error {
+			_, err := s.GetTransaction("wallet-id", "stranger-id", "transaction-id")
+			return err
+		},
+		"list": func(s services.TransactionService) error {
+			_, _, err := s.ListTransactions("wallet-id", "stranger-id", repositories.TransactionFilter{}, query.Page{})
+			return err
+		},
+		"update": func(s services.TransactionService) error {
+			_, err := s.UpdateTransaction("wallet-id", "stranger-id", "transaction-id", newTransactionInput(t, "19.99"))
+			return err
+		},
+		"delete": func(s services.TransactionService) error {
+			return s.DeleteTransaction("wallet-id", "stranger-id", "transaction-id")
+		},
+		"set status": func(s services.TransactionService) error {
+			_, err := s.SetTransactionStatus("wallet-id", "stranger-id", "transaction-id", entities.TransactionStatusCleared)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			service, repo := newService()
+
+			err := call(service)
+
+			assert.ErrorIs(t, err, services.ErrWalletForbidden)
+			assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeForbidden))
+			repo.AssertNotCalled(t, "FindTransactionByID", mock.Anything)
+			repo.AssertNotCalled(t, "CreateTransaction", mock.Anything)
+		})
+	}
+}
+
+func TestTransactionService_UpdateTransaction(t *testing.T) {
+	t.Run("successful update", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+		repo.On("UpdateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+			return tx.Amount.MinorUnits() == 1999
+		})).Return(newTestTransaction(t), nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.UpdateTransaction("wallet-id", "user-id", "transaction-id", newTransactionInput(t, "19.99"))
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.UpdateTransaction("wallet-id", "user-id", "transaction-id", newTransactionInput(t, "-5.00"))
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reconciled edit needs confirmation", func(t *testing.T) {
+		transaction := newTestTransaction(t)
+		transaction.Status = entities.TransactionStatusReconciled
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(transaction, nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.UpdateTransaction("wallet-id", "user-id", "transaction-id", newTransactionInput(t, "19.99"))
+
+		assert.ErrorIs(t, err, services.ErrReconciledEditUnconfirmed)
+		repo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+	})
+
+	t.Run("confirmed reconciled edit", func(t *testing.T) {
+		transaction := newTestTransaction(t)
+		transaction.Status = entities.TransactionStatusReconciled
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(transaction, nil)
+		repo.On("UpdateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+			return tx.Description == "Weekly groceries" && tx.Status == entities.TransactionStatusReconciled
+		})).Return(transaction, nil)
+
+		input := newTransactionInput(t, "42.90")
+		input.Description = "Weekly groceries"
+		input.ConfirmReconciled = true
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.UpdateTransaction("wallet-id", "user-id", "transaction-id", input)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("dependent cannot change the amount of an expense", func(t *testing.T) {
+		transaction := newTestTransaction(t)
+		transaction.CreatedBy = "teen-id"
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(transaction, nil)
+
+		service := services.NewTransactionService(repo, newWalletRepositoryFor("teen-id"), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.UpdateTransaction("wallet-id", "teen-id", "transaction-id", newTransactionInput(t, "4290.00"))
+
+		assert.ErrorIs(t, err, services.ErrTransactionDependentChange)
+		repo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+	})
+}
+
+func TestTransactionService_UpdateTransaction_Approval(t *testing.T) {
+	threshold := newMoney(t, "100.00", "BRL")
+
+	tests := []struct {
+		name     string
+		was      entities.TransactionType
+		amount   string
+		role     entities.WalletRole
+		wantHeld bool
+	}{
+		{name: "expense raised above the threshold is held", was: entities.TransactionTypeExpense, amount: "150.00", wantHeld: true},
+		{name: "income turned into an expense is held", was: entities.TransactionTypeIncome, amount: "150.00", wantHeld: true},
+		{name: "expense within the threshold goes through", was: entities.TransactionTypeExpense, amount: "99.00"},
+		{name: "owner expense goes through", was: entities.TransactionTypeExpense, amount: "150.00", role: entities.WalletRoleOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := entities.WalletRoleMember
+			if tt.role != "" {
+				role = tt.role
+			}
+			member := &entities.WalletMember{UserID: "user-id", Role: role}
+			wallet := &entities.Wallet{
+				ID:             "wallet-id",
+				Members:        []*entities.WalletMember{member},
+				ApprovalPolicy: entities.ApprovalPolicy{Threshold: threshold, TTL: 48 * time.Hour},
+			}
+			walletRepo := new(MockWalletRepository)
+			walletRepo.On("FindWalletMember", "wallet-id", "user-id").Return(member, nil)
+			walletRepo.On("FindWalletByID", "wallet-id").Return(wallet, nil)
+
+			transaction := newTestTransaction(t)
+			if tt.was == entities.TransactionTypeIncome {
+				transaction.Type = entities.TransactionTypeIncome
+				transaction.Amount = newMoney(t, "150.00", "BRL")
+			}
+			transactionRepo := new(MockTransactionRepository)
+			transactionRepo.On("FindTransactionByID", "transaction-id").Return(transaction, nil)
+			approvalRepo := new(MockApprovalRepository)
+			if tt.wantHeld {
+				approvalRepo.On("HoldTransactions",
+					mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+						return len(transactions) == 1 && transactions[0].AwaitingApproval &&
+							transactions[0].Status == entities.TransactionStatusPending
+					}),
+					mock.MatchedBy(func(approvals []*entities.Approval) bool {
+						return len(approvals) == 1 && approvals[0].TransactionID == "transaction-id" &&
+							approvals[0].RequestedBy == "user-id"
+					}),
+				).Return(nil)
+			} else {
+				transactionRepo.On("UpdateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return !tx.AwaitingApproval
+				})).Return(transaction, nil)
+			}
+
+			service := services.NewTransactionService(transactionRepo, walletRepo, new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), approvalRepo, newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.UpdateTransaction("wallet-id", "user-id", "transaction-id", newTransactionInput(t, tt.amount))
+
+			assert.NoError(t, err)
+			transactionRepo.AssertExpectations(t)
+			approvalRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_DeleteTransaction(t *testing.T) {
+	t.Run("soft deletes transaction", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+		repo.On("DeleteTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+			return tx.IsDeleted && !tx.DeletedAt.IsZero()
+		})).Return(nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		err := service.DeleteTransaction("wallet-id", "user-id", "transaction-id")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("wrong wallet", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newTestTransaction(t), nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		err := service.DeleteTransaction("other-wallet-id", "user-id", "transaction-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+		repo.AssertNotCalled(t, "DeleteTransaction", mock.Anything)
+	})
+
+	t.Run("reconciled transaction", func(t *testing.T) {
+		transaction := newTestTransaction(t)
+		transaction.Status = entities.TransactionStatusReconciled
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(transaction, nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		err := service.DeleteTransaction("wallet-id", "user-id", "transaction-id")
+
+		assert.ErrorIs(t, err, services.ErrReconciledDelete)
+		repo.AssertNotCalled(t, "DeleteTransaction", mock.Anything)
+	})
+}
+
+func TestTransactionService_TransferLegs(t *testing.T) {
+	newLeg := func(t *testing.T) *entities.Transaction {
+		leg := newTestTransaction(t)
+		leg.TransferID = "transfer-id"
+		return leg
+	}
+
+	t.Run("update", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newLeg(t), nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.UpdateTransaction("wallet-id", "user-id", "transaction-id", newTransactionInput(t, "19.99"))
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		repo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionByID", "transaction-id").Return(newLeg(t), nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		err := service.DeleteTransaction("wallet-id", "user-id", "transaction-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+		repo.AssertNotCalled(t, "DeleteTransaction", mock.Anything)
+	})
+}
+
+func TestTransactionService_CreateTransaction_Tags(t *testing.T) {
+	userTag := &entities.Tag{ID: "user-tag-id", Scope: entities.TagScopeUser, OwnerID: "user-id"}
+	walletTag := &entities.Tag{ID: "wallet-tag-id", Scope: entities.TagScopeWallet, OwnerID: "wallet-id"}
+	foreignTag := &entities.Tag{ID: "foreign-tag-id", Scope: entities.TagScopeUser, OwnerID: "other-user-id"}
+
+	tests := []struct {
+		name    string
+		tagIDs  []string
+		found   []*entities.Tag
+		wantErr bool
+	}{
+		{name: "user and wallet tags", tagIDs: []string{"wallet-tag-id", "user-tag-id", "user-tag-id"}, found: []*entities.Tag{userTag, walletTag}},
+		{name: "another user's tag", tagIDs: []string{"foreign-tag-id"}, found: []*entities.Tag{foreignTag}, wantErr: true},
+		{name: "unknown tag", tagIDs: []string{"user-tag-id", "missing-id"}, found: []*entities.Tag{userTag}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			tagRepo := new(MockTagRepository)
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			tagRepo.On("FindTagsByIDs", mock.Anything).Return(tt.found, nil)
+			if !tt.wantErr {
+				transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return assert.ObjectsAreEqual([]string{"user-tag-id", "wallet-tag-id"}, tx.TagIDs)
+				})).Return(newTestTransaction(t), nil)
+			}
+
+			input := newTransactionInput(t, "42.90")
+			input.TagIDs = tt.tagIDs
+
+			service := services.NewTransactionService(transactionRepo, newWalletRepository(), new(MockCategoryRepository), tagRepo, newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.CreateTransaction("wallet-id", "user-id", input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+			} else {
+				assert.NoError(t, err)
+			}
+			transactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_ListTransactions(t *testing.T) {
+	page, err := repositories.TransactionSorts.NewPage(repositories.DefaultTransactionSort, nil, 2)
+	require.NoError(t, err)
+
+	t.Run("ids are deduplicated", func(t *testing.T) {
+		next := page.Next("2026-10-19", "2026-10-19T12:00:00Z", "transaction-id")
+		repo := new(MockTransactionRepository)
+		repo.On("FindTransactionsByWalletID", "wallet-id", mock.MatchedBy(func(filter repositories.TransactionFilter) bool {
+			return assert.ObjectsAreEqual([]string{"a-id", "b-id"}, filter.TagIDs) &&
+				assert.ObjectsAreEqual([]string{"food-id"}, filter.CategoryIDs) &&
+				assert.ObjectsAreEqual([]string{"ana-id", "bia-id"}, filter.MemberIDs) &&
+				filter.MatchAllTags
+		}), page).Return([]*entities.Transaction{newTestTransaction(t)}, next, nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		transactions, cursor, err := service.ListTransactions("wallet-id", "user-id", repositories.TransactionFilter{
+			TagIDs:       []string{"b-id", "a-id", "b-id"},
+			MatchAllTags: true,
+			CategoryIDs:  []string{"food-id", "food-id"},
+			MemberIDs:    []string{"bia-id", "ana-id"},
+		}, page)
+
+		assert.NoError(t, err)
+		assert.Len(t, transactions, 1)
+		assert.Equal(t, next, cursor)
+		repo.AssertExpectations(t)
+	})
+
+	brl := func(value string) *money.Money {
+		amount := newMoney(t, value, "BRL")
+		return &amount
+	}
+	usd := newMoney(t, "10.00", "USD")
+
+	tests := []struct {
+		name   string
+		filter repositories.TransactionFilter
+	}{
+		{
+			name: "date range ending before it starts",
+			filter: repositories.TransactionFilter{
+				From: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "amount range ending below its start",
+			filter: repositories.TransactionFilter{MinAmount: brl("50.00"), MaxAmount: brl("10.00")},
+		},
+		{
+			name:   "amount bounds in different currencies",
+			filter: repositories.TransactionFilter{MinAmount: brl("5.00"), MaxAmount: &usd},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTransactionRepository)
+
+			service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, _, err := service.ListTransactions("wallet-id", "user-id", tt.filter, page)
+
+			assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+			repo.AssertNotCalled(t, "FindTransactionsByWalletID", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTransactionService_SearchTransactions(t *testing.T) {
+	t.Run("searches the wallets of the user", func(t *testing.T) {
+		transactionRepo := new(MockTransactionRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+		results := []*entities.TransactionSearchResult{{Transaction: newTestTransaction(t), Rank: 0.5, Snippet: "<mark>Plumber</mark>"}}
+		transactionRepo.On("SearchTransactions", repositories.TransactionSearch{
+			Query:  "encanador março",
+			UserID: "user-id",
+			Limit:  20,
+		}).Return(results, nil)
+
+		service := services.NewTransactionService(transactionRepo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		found, err := service.SearchTransactions("user-id", repositories.TransactionSearch{Query: "  encanador março ", UserID: "other-user-id", Limit: 20})
+
+		assert.NoError(t, err)
+		assert.Equal(t, results, found)
+		transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("blank query", func(t *testing.T) {
+		transactionRepo := new(MockTransactionRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+
+		service := services.NewTransactionService(transactionRepo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.SearchTransactions("user-id", repositories.TransactionSearch{Query: "   ", Limit: 20})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		transactionRepo.AssertNotCalled(t, "SearchTransactions", mock.Anything)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "user-id").Return(nil, nil)
+
+		service := services.NewTransactionService(new(MockTransactionRepository), newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		_, err := service.SearchTransactions("user-id", repositories.TransactionSearch{Query: "plumber", Limit: 20})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+}
+
+func TestTransactionService_CreateTransaction_Splits(t *testing.T) {
+	groceries := &entities.Category{ID: "groceries-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+	pharmacy := &entities.Category{ID: "pharmacy-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+	salary := &entities.Category{ID: "salary-id", UserID: "user-id", Type: entities.TransactionTypeIncome}
+
+	tests := []struct {
+		name       string
+		splits     func(t *testing.T) []services.TransactionSplitInput
+		categoryID string
+		categories []*entities.Category
+		wantErr    bool
+		errType    apperror.ErrorType
+	}{
+		{
+			name: "lines add up to the total",
+			splits: func(t *testing.T) []services.TransactionSplitInput {
+				return []services.TransactionSplitInput{
+					{Amount: newMoney(t, "30.00", "BRL"), CategoryID: "groceries-id"},
+					{Amount: newMoney(t, "12.90", "BRL"), CategoryID: "pharmacy-id", Note: "Vitamins"},
+				}
+			},
+			categories: []*entities.Category{groceries, pharmacy},
+		},
+		{
+			name: "lines off by a cent",
+			splits: func(t *testing.T) []services.TransactionSplitInput {
+				return []services.TransactionSplitInput{
+					{Amount: newMoney(t, "30.00", "BRL"), CategoryID: "groceries-id"},
+					{Amount: newMoney(t, "12.89", "BRL"), CategoryID: "pharmacy-id"},
+				}
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name: "line in another currency",
+			splits: func(t *testing.T) []services.TransactionSplitInput {
+				return []services.TransactionSplitInput{
+					{Amount: newMoney(t, "30.00", "BRL")},
+					{Amount: newMoney(t, "12.90", "USD")},
+				}
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name: "category on a split transaction",
+			splits: func(t *testing.T) []services.TransactionSplitInput {
+				return []services.TransactionSplitInput{
+					{Amount: newMoney(t, "30.00", "BRL")},
+					{Amount: newMoney(t, "12.90", "BRL")},
+				}
+			},
+			categoryID: "groceries-id",
+			wantErr:    true,
+			errType:    apperror.ErrorTypeValidation,
+		},
+		{
+			name: "line with an income category",
+			splits: func(t *testing.T) []services.TransactionSplitInput {
+				return []services.TransactionSplitInput{
+					{Amount: newMoney(t, "30.00", "BRL"), CategoryID: "groceries-id"},
+					{Amount: newMoney(t, "12.90", "BRL"), CategoryID: "salary-id"},
+				}
+			},
+			categories: []*entities.Category{groceries, salary},
+			wantErr:    true,
+			errType:    apperror.ErrorTypeUnprocessable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			categoryRepo := new(MockCategoryRepository)
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			for _, category := range tt.categories {
+				categoryRepo.On("FindCategoryByID", category.ID).Return(category, nil)
+			}
+			if !tt.wantErr {
+				transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return tx.IsSplit() && len(tx.Splits) == 2 && tx.Splits[1].Note == "Vitamins"
+				})).Return(newTestTransaction(t), nil)
+			}
+
+			input := newTransactionInput(t, "42.90")
+			input.CategoryID = tt.categoryID
+			input.Splits = tt.splits(t)
+
+			service := services.NewTransactionService(transactionRepo, newWalletRepository(), categoryRepo, new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.CreateTransaction("wallet-id", "user-id", input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			transactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_Sharing(t *testing.T) {
+	tests := []struct {
+		name    string
+		sharing *services.TransactionSharingInput
+		wantErr bool
+		errType apperror.ErrorType
+	}{
+		{
+			name: "shared equally",
+			sharing: &services.TransactionSharingInput{
+				PaidBy: "user-id",
+				Method: entities.ShareEqually,
+				Shares: []entities.ExpenseShare{{UserID: "user-id"}, {UserID: "roommate-id"}},
+			},
+		},
+		{
+			name: "shared with a non-member",
+			sharing: &services.TransactionSharingInput{
+				PaidBy: "user-id",
+				Method: entities.ShareEqually,
+				Shares: []entities.ExpenseShare{{UserID: "user-id"}, {UserID: "missing-id"}},
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name: "paid by a non-member",
+			sharing: &services.TransactionSharingInput{
+				PaidBy: "missing-id",
+				Method: entities.ShareEqually,
+				Shares: []entities.ExpenseShare{{UserID: "user-id"}, {UserID: "roommate-id"}},
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name: "percentages short of 100",
+			sharing: &services.TransactionSharingInput{
+				PaidBy: "user-id",
+				Method: entities.ShareByPercentage,
+				Shares: []entities.ExpenseShare{{UserID: "user-id", Weight: 5000}, {UserID: "roommate-id", Weight: 4000}},
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			if !tt.wantErr {
+				transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return tx.IsShared() && tx.Sharing.Shares[0].Amount.MinorUnits() == 2145
+				})).Return(newTestTransaction(t), nil)
+			}
+
+			input := newTransactionInput(t, "42.90")
+			input.Sharing = tt.sharing
+
+			service := services.NewTransactionService(transactionRepo, newWalletRepositoryFor("user-id", "roommate-id"), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), newUserRepository(), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.CreateTransaction("wallet-id", "user-id", input)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			transactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_Rules(t *testing.T) {
+	household := &entities.Category{ID: "household-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+	uber := &entities.Payee{ID: "uber-id", UserID: "user-id", Name: "Uber", DefaultCategoryID: "transport-id", Aliases: []string{"UBER"}}
+	rule, err := entities.NewRule("user-id", "Rides", 0,
+		entities.RuleConditions{PayeeID: "uber-id"},
+		entities.RuleActions{
+			CategoryID: "food-id", CategoryType: entities.TransactionTypeExpense,
+			TagIDs: []string{"trip-id"}, Description: "Uber Eats",
+		},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		categoryID   string
+		wantCategory string
+	}{
+		{name: "rule category wins over the payee default", wantCategory: "food-id"},
+		{name: "chosen category wins over the rule", categoryID: "household-id", wantCategory: "household-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			categoryRepo := new(MockCategoryRepository)
+			categoryRepo.On("FindCategoryByID", "household-id").Return(household, nil).Maybe()
+			payeeRepo := new(MockPayeeRepository)
+			payeeRepo.On("FindPayeeByAlias", "user-id", "UBER").Return(uber, nil)
+			ruleRepo := new(MockRuleRepository)
+			ruleRepo.On("FindRulesByUserID", "user-id").Return([]*entities.Rule{rule}, nil)
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			transactionRepo.On("CreateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+				return tx.CategoryID == tt.wantCategory && tx.Description == "Uber Eats" &&
+					assert.ObjectsAreEqual([]string{"trip-id"}, tx.TagIDs)
+			})).Return(newTestTransaction(t), nil)
+
+			input := newTransactionInput(t, "42.90")
+			input.Description = "UBER *EATS"
+			input.CategoryID = tt.categoryID
+
+			service := services.NewTransactionService(transactionRepo, newWalletRepository(), categoryRepo, new(MockTagRepository), payeeRepo, ruleRepo, userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.CreateTransaction("wallet-id", "user-id", input)
+
+			assert.NoError(t, err)
+			transactionRepo.AssertExpectations(t)
+			categoryRepo.AssertNotCalled(t, "FindCategoryByID", "transport-id")
+		})
+	}
+}
+
+func TestTransactionService_BulkUpdateTransactions(t *testing.T) {
+	newBulkTransaction := func(t *testing.T, id, walletID string) *entities.Transaction {
+		transaction := newTestTransaction(t)
+		transaction.ID = id
+		transaction.WalletID = walletID
+		return transaction
+	}
+	newBulkService := func(transactionRepo *MockTransactionRepository, categoryRepo *MockCategoryRepository, tagRepo *MockTagRepository) services.TransactionService {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+		walletRepo := new(MockWalletRepository)
+		walletRepo.On("FindWalletIDsByUserID", "user-id").Return([]string{"wallet-id", "savings-id"}, nil)
+		walletRepo.On("FindWalletByID", "savings-id").Return(&entities.Wallet{ID: "savings-id"}, nil).Maybe()
+		return services.NewTransactionService(transactionRepo, walletRepo, categoryRepo, tagRepo, newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+	}
+	itemErrors := func(result *services.BulkResult) map[string]apperror.ErrorType {
+		errs := make(map[string]apperror.ErrorType, len(result.Items))
+		for _, item := range result.Items {
+			var appErr *apperror.AppError
+			if errors.As(item.Err, &appErr) {
+				errs[item.TransactionID] = appErr.Type()
+			} else {
+				errs[item.TransactionID] = ""
+			}
+		}
+		return errs
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		name := "recategorizes the transactions it can"
+		if dryRun {
+			name = "dry run saves nothing"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			food := &entities.Category{ID: "food-id", UserID: "user-id", Type: entities.TransactionTypeExpense}
+			transferLeg := newBulkTransaction(t, "leg-id", "wallet-id")
+			transferLeg.TransferID = "transfer-id"
+
+			transactionRepo := new(MockTransactionRepository)
+			categoryRepo := new(MockCategoryRepository)
+			categoryRepo.On("FindCategoryByID", "food-id").Return(food, nil)
+			transactionRepo.On("FindTransactionsByIDs", []string{"foreign-id", "leg-id", "missing-id", "own-id"}).Return([]*entities.Transaction{
+				newBulkTransaction(t, "own-id", "wallet-id"),
+				newBulkTransaction(t, "foreign-id", "other-wallet-id"),
+				transferLeg,
+			}, nil)
+			if !dryRun {
+				transactionRepo.On("SaveTransactions", mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+					return len(transactions) == 1 && transactions[0].ID == "own-id" && transactions[0].CategoryID == "food-id"
+				})).Return(nil)
+			}
+
+			service := newBulkService(transactionRepo, categoryRepo, new(MockTagRepository))
+			result, err := service.BulkUpdateTransactions("user-id", services.BulkTransactionInput{
+				Operation:      entities.BulkRecategorize,
+				TransactionIDs: []string{"own-id", "missing-id", "foreign-id", "leg-id", "own-id"},
+				CategoryID:     "food-id",
+				DryRun:         dryRun,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, dryRun, result.DryRun)
+			assert.Equal(t, 1, result.Succeeded())
+			assert.Equal(t, map[string]apperror.ErrorType{
+				"own-id":     "",
+				"missing-id": apperror.ErrorTypeNotFound,
+				"foreign-id": apperror.ErrorTypeForbidden,
+				"leg-id":     apperror.ErrorTypeUnprocessable,
+			}, itemErrors(result))
+			transactionRepo.AssertExpectations(t)
+			if dryRun {
+				transactionRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+			}
+		})
+	}
+
+	t.Run("moves filtered transactions, leaving wallet tags behind", func(t *testing.T) {
+		userTag := &entities.Tag{ID: "user-tag-id", Scope: entities.TagScopeUser, OwnerID: "user-id"}
+		walletTag := &entities.Tag{ID: "wallet-tag-id", Scope: entities.TagScopeWallet, OwnerID: "wallet-id"}
+		tagged := newBulkTransaction(t, "tagged-id", "wallet-id")
+		tagged.SetTags([]string{"user-tag-id", "wallet-tag-id"})
+
+		transactionRepo := new(MockTransactionRepository)
+		tagRepo := new(MockTagRepository)
+		transactionRepo.On("FindTransactionsByWalletID", "wallet-id", mock.MatchedBy(func(filter repositories.TransactionFilter) bool {
+			return assert.ObjectsAreEqual([]string{"payee-id"}, filter.PayeeIDs)
+		}), mock.Anything).Return([]*entities.Transaction{tagged, newBulkTransaction(t, "plain-id", "wallet-id")}, nil, nil)
+		tagRepo.On("FindTagsByIDs", []string{"user-tag-id", "wallet-tag-id"}).Return([]*entities.Tag{userTag, walletTag}, nil)
+		transactionRepo.On("SaveTransactions", mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+			return len(transactions) == 2 && transactions[0].WalletID == "savings-id" &&
+				assert.ObjectsAreEqual([]string{"user-tag-id"}, transactions[0].TagIDs)
+		})).Return(nil)
+
+		service := newBulkService(transactionRepo, new(MockCategoryRepository), tagRepo)
+		result, err := service.BulkUpdateTransactions("user-id", services.BulkTransactionInput{
+			Operation:      entities.BulkMove,
+			WalletID:       "wallet-id",
+			Filter:         repositories.TransactionFilter{PayeeIDs: []string{"payee-id", "payee-id"}},
+			TargetWalletID: "savings-id",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Succeeded())
+		transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("move into a wallet with a threshold holds expenses above it", func(t *testing.T) {
+		large := newBulkTransaction(t, "large-id", "wallet-id")
+		large.Amount = newMoney(t, "500.00", "BRL")
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"large-id", "small-id"}).
+			Return([]*entities.Transaction{large, newBulkTransaction(t, "small-id", "wallet-id")}, nil)
+		approvalRepo := new(MockApprovalRepository)
+		approvalRepo.On("HoldTransactions",
+			mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+				return len(transactions) == 2 && transactions[0].AwaitingApproval && !transactions[1].AwaitingApproval
+			}),
+			mock.MatchedBy(func(approvals []*entities.Approval) bool {
+				return len(approvals) == 1 && approvals[0].TransactionID == "large-id" && approvals[0].WalletID == "savings-id"
+			}),
+		).Return(nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+		walletRepo := new(MockWalletRepository)
+		walletRepo.On("FindWalletIDsByUserID", "user-id").Return([]string{"wallet-id", "savings-id"}, nil)
+		walletRepo.On("FindWalletByID", "savings-id").Return(&entities.Wallet{
+			ID:             "savings-id",
+			ApprovalPolicy: entities.ApprovalPolicy{Threshold: newMoney(t, "100.00", "BRL"), TTL: 48 * time.Hour},
+		}, nil)
+
+		service := services.NewTransactionService(transactionRepo, walletRepo, new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, approvalRepo, newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		result, err := service.BulkUpdateTransactions("user-id", services.BulkTransactionInput{
+			Operation:      entities.BulkMove,
+			TransactionIDs: []string{"large-id", "small-id"},
+			TargetWalletID: "savings-id",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Succeeded())
+		approvalRepo.AssertExpectations(t)
+		transactionRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+	})
+
+	t.Run("save failure fails the whole operation", func(t *testing.T) {
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"own-id"}).
+			Return([]*entities.Transaction{newBulkTransaction(t, "own-id", "wallet-id")}, nil)
+		transactionRepo.On("SaveTransactions", mock.Anything).Return(errors.New("database error"))
+
+		logger := mocks.NewMockLogger()
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+		walletRepo := new(MockWalletRepository)
+		walletRepo.On("FindWalletIDsByUserID", "user-id").Return([]string{"wallet-id"}, nil)
+
+		service := services.NewTransactionService(transactionRepo, walletRepo, new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), logger)
+		_, err := service.BulkUpdateTransactions("user-id", services.BulkTransactionInput{
+			Operation:      entities.BulkDelete,
+			TransactionIDs: []string{"own-id"},
+		})
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+
+	t.Run("delete skips reconciled transactions", func(t *testing.T) {
+		reconciled := newBulkTransaction(t, "reconciled-id", "wallet-id")
+		reconciled.Status = entities.TransactionStatusReconciled
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"own-id", "reconciled-id"}).Return([]*entities.Transaction{
+			newBulkTransaction(t, "own-id", "wallet-id"),
+			reconciled,
+		}, nil)
+		transactionRepo.On("SaveTransactions", mock.MatchedBy(func(transactions []*entities.Transaction) bool {
+			return len(transactions) == 1 && transactions[0].ID == "own-id" && transactions[0].IsDeleted
+		})).Return(nil)
+
+		service := newBulkService(transactionRepo, new(MockCategoryRepository), new(MockTagRepository))
+		result, err := service.BulkUpdateTransactions("user-id", services.BulkTransactionInput{
+			Operation:      entities.BulkDelete,
+			TransactionIDs: []string{"own-id", "reconciled-id"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]apperror.ErrorType{
+			"own-id":        "",
+			"reconciled-id": apperror.ErrorTypeUnprocessable,
+		}, itemErrors(result))
+		assert.False(t, reconciled.IsDeleted)
+		transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("non-member cannot edit a wallet, even with transactions of their own in it", func(t *testing.T) {
+		planted := newBulkTransaction(t, "planted-id", "victim-wallet-id")
+		transactionRepo := new(MockTransactionRepository)
+		transactionRepo.On("FindTransactionsByIDs", []string{"planted-id", "victim-id"}).Return([]*entities.Transaction{
+			planted,
+			newBulkTransaction(t, "victim-id", "victim-wallet-id"),
+		}, nil)
+
+		service := newBulkService(transactionRepo, new(MockCategoryRepository), new(MockTagRepository))
+		result, err := service.BulkUpdateTransactions("user-id", services.BulkTransactionInput{
+			Operation:      entities.BulkDelete,
+			TransactionIDs: []string{"planted-id", "victim-id"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-id", planted.CreatedBy)
+		assert.Equal(t, 0, result.Succeeded())
+		assert.Equal(t, map[string]apperror.ErrorType{
+			"planted-id": apperror.ErrorTypeForbidden,
+			"victim-id":  apperror.ErrorTypeForbidden,
+		}, itemErrors(result))
+		transactionRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+
+		_, err = service.BulkUpdateTransactions("user-id", services.BulkTransactionInput{
+			Operation: entities.BulkDelete,
+			WalletID:  "victim-wallet-id",
+		})
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+		transactionRepo.AssertNotCalled(t, "FindTransactionsByWalletID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	tooMany := make([]string, 0, entities.MaxBulkTransactions+1)
+	for i := 0; i <= entities.MaxBulkTransactions; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("transaction-%d", i))
+	}
+
+	tests := []struct {
+		name    string
+		input   services.BulkTransactionInput
+		setup   func(*MockTransactionRepository, *MockTagRepository)
+		errType apperror.ErrorType
+	}{
+		{
+			name:    "filter over an inaccessible wallet",
+			input:   services.BulkTransactionInput{Operation: entities.BulkDelete, WalletID: "other-wallet-id"},
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:  "move to an inaccessible wallet",
+			input: services.BulkTransactionInput{Operation: entities.BulkMove, TransactionIDs: []string{"own-id"}, TargetWalletID: "other-wallet-id"},
+			setup: func(tr *MockTransactionRepository, _ *MockTagRepository) {
+				tr.On("FindTransactionsByIDs", []string{"own-id"}).Return([]*entities.Transaction{newBulkTransaction(t, "own-id", "wallet-id")}, nil)
+			},
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:  "unknown tag",
+			input: services.BulkTransactionInput{Operation: entities.BulkRetag, TransactionIDs: []string{"own-id"}, AddTagIDs: []string{"missing-id"}},
+			setup: func(tr *MockTransactionRepository, tags *MockTagRepository) {
+				tr.On("FindTransactionsByIDs", []string{"own-id"}).Return([]*entities.Transaction{newBulkTransaction(t, "own-id", "wallet-id")}, nil)
+				tags.On("FindTagsByIDs", []string{"missing-id"}).Return([]*entities.Tag{}, nil)
+			},
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:    "too many transactions",
+			input:   services.BulkTransactionInput{Operation: entities.BulkDelete, TransactionIDs: tooMany},
+			errType: apperror.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := new(MockTransactionRepository)
+			tagRepo := new(MockTagRepository)
+			if tt.setup != nil {
+				tt.setup(transactionRepo, tagRepo)
+			}
+
+			service := newBulkService(transactionRepo, new(MockCategoryRepository), tagRepo)
+			_, err := service.BulkUpdateTransactions("user-id", tt.input)
+
+			assert.True(t, apperror.IsErrorType(err, tt.errType),
+				"expected error type %s, got %v", tt.errType, err)
+			transactionRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+		})
+	}
+}
+
+func TestTransactionService_BulkUpdateTransactions_Dependent(t *testing.T) {
+	teen := &entities.User{ID: "teen-id", Role: entities.RoleDependent, ParentID: "parent-id"}
+
+	for _, operation := range []entities.BulkOperation{entities.BulkMove, entities.BulkDelete, entities.BulkRecategorize} {
+		t.Run(string(operation), func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+			userRepo.On("FindUserByID", "teen-id").Return(teen, nil)
+			transactionRepo := new(MockTransactionRepository)
+
+			service := services.NewTransactionService(transactionRepo, new(MockWalletRepository), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), userRepo, new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.BulkUpdateTransactions("teen-id", services.BulkTransactionInput{
+				Operation:      operation,
+				TransactionIDs: []string{"transaction-id"},
+				TargetWalletID: "savings-id",
+			})
+
+			assert.ErrorIs(t, err, services.ErrTransactionDependentBulk)
+			transactionRepo.AssertNotCalled(t, "FindTransactionsByIDs", mock.Anything)
+		})
+	}
+}
+
+func TestTransactionService_SetTransactionStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    entities.TransactionStatus
+		held    bool
+		status  entities.TransactionStatus
+		wantErr bool
+		errType apperror.ErrorType
+	}{
+		{name: "clears a pending transaction", from: entities.TransactionStatusPending, status: entities.TransactionStatusCleared},
+		{name: "held expense cannot clear", from: entities.TransactionStatusPending, held: true, status: entities.TransactionStatusCleared, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "reconciles a cleared transaction", from: entities.TransactionStatusCleared, status: entities.TransactionStatusReconciled},
+		{name: "un-reconciles a reconciled transaction", from: entities.TransactionStatusReconciled, status: entities.TransactionStatusCleared},
+		{name: "pending cannot skip to reconciled", from: entities.TransactionStatusPending, status: entities.TransactionStatusReconciled, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "cleared cannot go back to pending", from: entities.TransactionStatusCleared, status: entities.TransactionStatusPending, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+		{name: "cannot schedule a past transaction", from: entities.TransactionStatusPending, status: entities.TransactionStatusScheduled, wantErr: true, errType: apperror.ErrorTypeUnprocessable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := newTestTransaction(t)
+			transaction.Status = tt.from
+			transaction.AwaitingApproval = tt.held
+			repo := new(MockTransactionRepository)
+			repo.On("FindTransactionByID", "transaction-id").Return(transaction, nil)
+			if !tt.wantErr {
+				repo.On("UpdateTransaction", mock.MatchedBy(func(tx *entities.Transaction) bool {
+					return tx.Status == tt.status
+				})).Return(transaction, nil)
+			}
+
+			service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+			_, err := service.SetTransactionStatus("wallet-id", "user-id", "transaction-id", tt.status)
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_PromoteScheduled(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	t.Run("promotes due transactions", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		repo.On("PromoteScheduledTransactions", now).Return(int64(3), nil)
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), mocks.NewMockLogger())
+		promoted, err := service.PromoteScheduled(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), promoted)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockTransactionRepository)
+		logger := mocks.NewMockLogger()
+		repo.On("PromoteScheduledTransactions", now).Return(int64(0), errors.New("database error"))
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		service := services.NewTransactionService(repo, newWalletRepository(), new(MockCategoryRepository), new(MockTagRepository), newPayeeRepository(), newRuleRepository(), new(MockUserRepository), new(MockApprovalRepository), newLimitRepository(), new(MockNotificationRepository), newActivityRepository(), new(MockRecurringTransactionRepository), newRateService(), logger)
+		_, err := service.PromoteScheduled(now)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeDatabase))
+	})
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// TransferInput holds the editable fields of a transfer. ReceivedAmount is
+// only needed when the destination wallet uses another currency; it is the
+// amount that actually landed there.
+type TransferInput struct {
+	Amount         money.Money
+	ReceivedAmount money.Money
+	Date           time.Time
+	Description    string
+}
+
+type TransferService interface {
+	// CreateTransfer moves money between two wallets the actor is a member
+	// of.
+	CreateTransfer(fromWalletID, toWalletID, actorID string, input TransferInput) (*entities.Transfer, error)
+	GetTransfer(walletID, actorID, transferID string) (*entities.Transfer, error)
+	UpdateTransfer(walletID, actorID, transferID string, input TransferInput) (*entities.Transfer, error)
+	DeleteTransfer(walletID, actorID, transferID string) error
+}
+
+type transferService struct {
+	transferRepo repositories.TransferRepository
+	walletRepo   repositories.WalletRepository
+	userRepo     repositories.UserRepository
+	logger       logger.Logger
+}
+
+var (
+	ErrTransferNotFound       = apperror.New(apperror.ErrorTypeNotFound, "Transfer not found")
+	ErrTransferAuthorNotFound = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	// ErrTransferDependent keeps dependents from moving money between
+	// wallets, as transfers are not held for their parent to approve.
+	ErrTransferDependent = apperror.New(apperror.ErrorTypeForbidden, "Dependents cannot transfer between wallets")
+)
+
+func (s *transferService) CreateTransfer(fromWalletID, toWalletID, actorID string, input TransferInput) (*entities.Transfer, error) {
+	author, err := s.userRepo.FindUserByID(actorID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": actorID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if author == nil {
+		return nil, ErrTransferAuthorNotFound
+	}
+	if author.IsDependent() {
+		return nil, ErrTransferDependent
+	}
+
+	for _, walletID := range entities.UniqueIDs([]string{fromWalletID, toWalletID}) {
+		if _, err := walletMember(s.walletRepo, s.logger, walletID, author.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	transfer, err := entities.NewTransfer(
+		fromWalletID,
+		toWalletID,
+		input.Amount,
+		input.ReceivedAmount,
+		input.Date,
+		input.Description,
+		author.ID,
+	)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	createdTransfer, err := s.transferRepo.CreateTransfer(transfer)
+	if err != nil {
+		s.logger.Error(err, "Failed to create transfer", map[string]interface{}{
+			"from_wallet_id": fromWalletID,
+			"to_wallet_id":   toWalletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdTransfer, nil
+}
+
+// GetTransfer returns the transfer when walletID is either of its wallets.
+func (s *transferService) GetTransfer(walletID, actorID, transferID string) (*entities.Transfer, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.transferRepo.FindTransferByID(transferID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find transfer", map[string]interface{}{
+			"transfer_id": transferID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if transfer == nil || !transfer.Involves(walletID) {
+		return nil, ErrTransferNotFound
+	}
+
+	return transfer, nil
+}
+
+func (s *transferService) UpdateTransfer(walletID, actorID, transferID string, input TransferInput) (*entities.Transfer, error) {
+	actor, err := s.userRepo.FindUserByID(actorID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": actorID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if actor == nil {
+		return nil, ErrTransferAuthorNotFound
+	}
+	if actor.IsDependent() {
+		return nil, ErrTransferDependent
+	}
+
+	transfer, err := s.managedTransfer(walletID, actorID, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transfer.Update(input.Amount, input.ReceivedAmount, input.Date, input.Description); err != nil {
+		if errors.Is(err, entities.ErrTransactionReconciled) {
+			return nil, apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+		}
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	updatedTransfer, err := s.transferRepo.UpdateTransfer(transfer)
+	if err != nil {
+		s.logger.Error(err, "Failed to update transfer", map[string]interface{}{
+			"transfer_id": transferID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return updatedTransfer, nil
+}
+
+func (s *transferService) DeleteTransfer(walletID, actorID, transferID string) error {
+	transfer, err := s.managedTransfer(walletID, actorID, transferID)
+	if err != nil {
+		return err
+	}
+
+	transfer.Delete()
+
+	if err := s.transferRepo.DeleteTransfer(transfer); err != nil {
+		s.logger.Error(err, "Failed to delete transfer", map[string]interface{}{
+			"transfer_id": transferID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+// managedTransfer returns a transfer the actor may change. Changes apply to
+// both legs, so the actor must be a member of both wallets.
+func (s *transferService) managedTransfer(walletID, actorID, transferID string) (*entities.Transfer, error) {
+	transfer, err := s.GetTransfer(walletID, actorID, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leg := range []*entities.Transaction{transfer.Outgoing, transfer.Incoming} {
+		if leg.WalletID == walletID {
+			continue
+		}
+		if _, err := walletMember(s.walletRepo, s.logger, leg.WalletID, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	return transfer, nil
+}
+
+func NewTransferService(
+	transferRepo repositories.TransferRepository,
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+	logger logger.Logger,
+) TransferService {
+	return &transferService{
+		transferRepo: transferRepo,
+		walletRepo:   walletRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+	}
+}
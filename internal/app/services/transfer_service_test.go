@@ -0,0 +1,297 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/money"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransferRepository) CreateTransfer(transfer *entities.Transfer) (*entities.Transfer, error) {
+	args := m.Called(transfer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) FindTransferByID(id string) (*entities.Transfer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) UpdateTransfer(transfer *entities.Transfer) (*entities.Transfer, error) {
+	args := m.Called(transfer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) DeleteTransfer(transfer *entities.Transfer) error {
+	args := m.Called(transfer)
+	return args.Error(0)
+}
+
+func newTransferInput(t *testing.T, sent, received money.Money) services.TransferInput {
+	t.Helper()
+	return services.TransferInput{
+		Amount:         sent,
+		ReceivedAmount: received,
+		Date:           time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Description:    "Savings",
+	}
+}
+
+func newTestTransfer(t *testing.T) *entities.Transfer {
+	t.Helper()
+	input := newTransferInput(t, newMoney(t, "100.00", "USD"), newMoney(t, "543.21", "BRL"))
+	transfer, err := entities.NewTransfer("checking-id", "savings-id", input.Amount, input.ReceivedAmount, input.Date, input.Description, "user-id")
+	require.NoError(t, err)
+	transfer.ID = "transfer-id"
+	return transfer
+}
+
+// newCheckingOnlyWalletRepository returns a wallet repository where
+// "user-id" belongs to the checking wallet but not to the savings wallet.
+func newCheckingOnlyWalletRepository() *MockWalletRepository {
+	repo := new(MockWalletRepository)
+	repo.On("FindWalletMember", "checking-id", "user-id").
+		Return(&entities.WalletMember{UserID: "user-id", Role: entities.WalletRoleMember}, nil).Maybe()
+	repo.On("FindWalletMember", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+func TestTransferService_CreateTransfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		toWallet   string
+		walletRepo *MockWalletRepository
+		input      func(t *testing.T) services.TransferInput
+		mockSetup  func(*MockTransferRepository, *MockUserRepository, *mocks.MockLogger)
+		wantErr    bool
+		errType    apperror.ErrorType
+	}{
+		{
+			name:     "cross currency transfer",
+			toWallet: "savings-id",
+			input: func(t *testing.T) services.TransferInput {
+				return newTransferInput(t, newMoney(t, "100.00", "USD"), newMoney(t, "543.21", "BRL"))
+			},
+			mockSetup: func(tr *MockTransferRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+				tr.On("CreateTransfer", mock.MatchedBy(func(transfer *entities.Transfer) bool {
+					return transfer.IsCrossCurrency() &&
+						transfer.Outgoing.WalletID == "checking-id" &&
+						transfer.Incoming.WalletID == "savings-id" &&
+						transfer.Rate.FloatString(4) == "5.4321"
+				})).Return(newTestTransfer(t), nil)
+			},
+		},
+		{
+			name:     "unknown author",
+			toWallet: "savings-id",
+			input: func(t *testing.T) services.TransferInput {
+				return newTransferInput(t, newMoney(t, "100.00", "USD"), money.Money{})
+			},
+			mockSetup: func(tr *MockTransferRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(nil, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:     "dependent cannot transfer",
+			toWallet: "savings-id",
+			input: func(t *testing.T) services.TransferInput {
+				return newTransferInput(t, newMoney(t, "100.00", "USD"), money.Money{})
+			},
+			mockSetup: func(tr *MockTransferRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").
+					Return(&entities.User{ID: "user-id", Role: entities.RoleDependent, ParentID: "parent-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:       "not a member of the destination wallet",
+			toWallet:   "savings-id",
+			walletRepo: newCheckingOnlyWalletRepository(),
+			input: func(t *testing.T) services.TransferInput {
+				return newTransferInput(t, newMoney(t, "100.00", "USD"), money.Money{})
+			},
+			mockSetup: func(tr *MockTransferRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeForbidden,
+		},
+		{
+			name:     "same wallet",
+			toWallet: "checking-id",
+			input: func(t *testing.T) services.TransferInput {
+				return newTransferInput(t, newMoney(t, "100.00", "USD"), money.Money{})
+			},
+			mockSetup: func(tr *MockTransferRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:     "repository error",
+			toWallet: "savings-id",
+			input: func(t *testing.T) services.TransferInput {
+				return newTransferInput(t, newMoney(t, "100.00", "USD"), money.Money{})
+			},
+			mockSetup: func(tr *MockTransferRepository, ur *MockUserRepository, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+				tr.On("CreateTransfer", mock.Anything).Return(nil, errors.New("database error"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transferRepo := new(MockTransferRepository)
+			userRepo := new(MockUserRepository)
+			logger := mocks.NewMockLogger()
+			tt.mockSetup(transferRepo, userRepo, logger)
+			walletRepo := tt.walletRepo
+			if walletRepo == nil {
+				walletRepo = newWalletRepository()
+			}
+
+			service := services.NewTransferService(transferRepo, walletRepo, userRepo, logger)
+			transfer, err := service.CreateTransfer("checking-id", tt.toWallet, "user-id", tt.input(t))
+
+			if tt.wantErr {
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, transfer)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "transfer-id", transfer.ID)
+			}
+			transferRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferService_GetTransfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		walletID string
+		actorID  string
+		errType  apperror.ErrorType
+	}{
+		{name: "through source wallet", walletID: "checking-id", actorID: "user-id"},
+		{name: "through destination wallet", walletID: "savings-id", actorID: "user-id"},
+		{name: "through another wallet", walletID: "other-id", actorID: "user-id", errType: apperror.ErrorTypeNotFound},
+		{name: "non-member", walletID: "checking-id", actorID: "stranger-id", errType: apperror.ErrorTypeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTransferRepository)
+			repo.On("FindTransferByID", "transfer-id").Return(newTestTransfer(t), nil)
+
+			service := services.NewTransferService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+			transfer, err := service.GetTransfer(tt.walletID, tt.actorID, "transfer-id")
+
+			if tt.errType != "" {
+				assert.True(t, apperror.IsErrorType(err, tt.errType))
+				assert.Nil(t, transfer)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "transfer-id", transfer.ID)
+			}
+		})
+	}
+}
+
+func TestTransferService_UpdateTransfer(t *testing.T) {
+	t.Run("updates both legs", func(t *testing.T) {
+		repo := new(MockTransferRepository)
+		repo.On("FindTransferByID", "transfer-id").Return(newTestTransfer(t), nil)
+		repo.On("UpdateTransfer", mock.MatchedBy(func(transfer *entities.Transfer) bool {
+			return transfer.Outgoing.Amount.MinorUnits() == 5000 &&
+				transfer.Incoming.Amount.MinorUnits() == 27500
+		})).Return(newTestTransfer(t), nil)
+
+		service := services.NewTransferService(repo, newWalletRepository(), newUserRepository(), mocks.NewMockLogger())
+		_, err := service.UpdateTransfer("savings-id", "user-id", "transfer-id",
+			newTransferInput(t, newMoney(t, "50.00", "USD"), newMoney(t, "275.00", "BRL")))
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("changed currency", func(t *testing.T) {
+		repo := new(MockTransferRepository)
+		repo.On("FindTransferByID", "transfer-id").Return(newTestTransfer(t), nil)
+
+		service := services.NewTransferService(repo, newWalletRepository(), newUserRepository(), mocks.NewMockLogger())
+		_, err := service.UpdateTransfer("checking-id", "user-id", "transfer-id",
+			newTransferInput(t, newMoney(t, "50.00", "USD"), newMoney(t, "45.00", "EUR")))
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		repo.AssertNotCalled(t, "UpdateTransfer", mock.Anything)
+	})
+
+	t.Run("not a member of the other wallet", func(t *testing.T) {
+		repo := new(MockTransferRepository)
+		repo.On("FindTransferByID", "transfer-id").Return(newTestTransfer(t), nil)
+
+		service := services.NewTransferService(repo, newCheckingOnlyWalletRepository(), newUserRepository(), mocks.NewMockLogger())
+		_, err := service.UpdateTransfer("checking-id", "user-id", "transfer-id",
+			newTransferInput(t, newMoney(t, "50.00", "USD"), newMoney(t, "275.00", "BRL")))
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+		repo.AssertNotCalled(t, "UpdateTransfer", mock.Anything)
+	})
+
+	t.Run("dependent cannot update transfers", func(t *testing.T) {
+		repo := new(MockTransferRepository)
+
+		service := services.NewTransferService(repo, newWalletRepositoryFor("user-id", "teen-id"), newUserRepository(), mocks.NewMockLogger())
+		_, err := service.UpdateTransfer("checking-id", "teen-id", "transfer-id",
+			newTransferInput(t, newMoney(t, "50.00", "USD"), newMoney(t, "275.00", "BRL")))
+
+		assert.ErrorIs(t, err, services.ErrTransferDependent)
+		repo.AssertNotCalled(t, "UpdateTransfer", mock.Anything)
+	})
+}
+
+func TestTransferService_DeleteTransfer(t *testing.T) {
+	repo := new(MockTransferRepository)
+	repo.On("FindTransferByID", "transfer-id").Return(newTestTransfer(t), nil)
+	repo.On("DeleteTransfer", mock.MatchedBy(func(transfer *entities.Transfer) bool {
+		return transfer.IsDeleted && transfer.Outgoing.IsDeleted && transfer.Incoming.IsDeleted
+	})).Return(nil)
+
+	service := services.NewTransferService(repo, newWalletRepository(), new(MockUserRepository), mocks.NewMockLogger())
+	err := service.DeleteTransfer("checking-id", "user-id", "transfer-id")
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
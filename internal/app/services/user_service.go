@@ -10,6 +10,11 @@ import (
 
 type UserService interface {
 	CreateUser(firstName, lastName, email, password string) (*entities.User, error)
+	CreateDependent(parentID, firstName, lastName, email, password string) (*entities.User, error)
+	ListDependents(parentID string) ([]*entities.User, error)
+	// SetBaseCurrency sets the currency the balances and reports of the user
+	// are converted into by default. An empty currency clears it.
+	SetBaseCurrency(userID, currency string) (*entities.User, error)
 }
 
 type userService struct {
@@ -18,7 +23,11 @@ type userService struct {
 	logger   logger.Logger
 }
 
-var ErrUserAlreadyExists = apperror.New(apperror.ErrorTypeValidation, "Email already exists")
+var (
+	ErrUserAlreadyExists = apperror.New(apperror.ErrorTypeValidation, "Email already exists")
+	ErrParentNotFound    = apperror.New(apperror.ErrorTypeNotFound, "Parent user not found")
+	ErrUserNotFound      = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+)
 
 func (s *userService) CreateUser(firstName, lastName, email, password string) (*entities.User, error) {
 	role := entities.RoleUser
@@ -28,6 +37,80 @@ func (s *userService) CreateUser(firstName, lastName, email, password string) (*
 		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
 	}
 
+	return s.saveNewUser(user)
+}
+
+func (s *userService) CreateDependent(parentID, firstName, lastName, email, password string) (*entities.User, error) {
+	parent, err := s.userRepo.FindUserByID(parentID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find parent user", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if parent == nil {
+		return nil, ErrParentNotFound
+	}
+
+	user, err := entities.NewDependentUser(parent, firstName, lastName, email, password)
+	if err != nil {
+		s.logger.Error(err, "Invalid dependent user data", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	return s.saveNewUser(user)
+}
+
+func (s *userService) ListDependents(parentID string) ([]*entities.User, error) {
+	parent, err := s.userRepo.FindUserByID(parentID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find parent user", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if parent == nil {
+		return nil, ErrParentNotFound
+	}
+
+	dependents, err := s.userRepo.FindDependentsByParentID(parent.ID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list dependents", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return dependents, nil
+}
+
+func (s *userService) SetBaseCurrency(userID, currency string) (*entities.User, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := user.SetBaseCurrency(currency); err != nil {
+		return nil, apperror.New(apperror.ErrorTypeValidation, "Currency is not supported").
+			AddContext("field", "base_currency")
+	}
+
+	if err := s.userRepo.UpdateBaseCurrency(user); err != nil {
+		s.logger.Error(err, "Failed to update base currency", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return user, nil
+}
+
+// saveNewUser checks the email is free, hashes the password, stores the user
+// and seeds the default categories.
+func (s *userService) saveNewUser(user *entities.User) (*entities.User, error) {
+	email := user.Email
+
 	existingUser, err := s.userRepo.FindUserByEmail(email)
 	if err != nil {
 		s.logger.Error(err, "Failed to check email", nil)
@@ -41,7 +124,7 @@ func (s *userService) CreateUser(firstName, lastName, email, password string) (*
 		return nil, ErrUserAlreadyExists
 	}
 
-	hashedPassword, err := s.hashing.HashValue(password)
+	hashedPassword, err := s.hashing.HashValue(user.Password)
 	if err != nil {
 		s.logger.Error(err, "Failed to hash password", nil)
 		return nil, apperror.Wrap(apperror.ErrorTypeInternal, err)
@@ -49,7 +132,7 @@ func (s *userService) CreateUser(firstName, lastName, email, password string) (*
 
 	user.Password = hashedPassword
 
-	createdUser, err := s.userRepo.CreateUser(user)
+	createdUser, err := s.userRepo.CreateUser(user, entities.DefaultCategories(user.ID))
 	if err != nil {
 		s.logger.Error(err, "Failed to create user", nil)
 		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
@@ -58,6 +141,31 @@ func (s *userService) CreateUser(firstName, lastName, email, password string) (*
 	return createdUser, nil
 }
 
+// displayCurrency returns currency, or the base currency of userID when it
+// is empty, for the balances and reports the user asks for.
+func displayCurrency(
+	userRepo repositories.UserRepository,
+	log logger.Logger,
+	userID string,
+	currency string,
+) (string, error) {
+	if currency != "" {
+		return currency, nil
+	}
+
+	user, err := userRepo.FindUserByID(userID)
+	if err != nil {
+		log.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return "", apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return "", nil
+	}
+	return user.BaseCurrency, nil
+}
+
 func NewUserService(
 	userRepo repositories.UserRepository,
 	hashing hashing.Hashing,
@@ -16,8 +16,8 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) CreateUser(user *entities.User) (*entities.User, error) {
-	args := m.Called(user)
+func (m *MockUserRepository) CreateUser(user *entities.User, categories []*entities.Category) (*entities.User, error) {
+	args := m.Called(user, categories)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -32,6 +32,37 @@ func (m *MockUserRepository) FindUserByEmail(email string) (*entities.User, erro
 	return args.Get(0).(*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) FindUserByID(id string) (*entities.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindDependentsByParentID(parentID string) ([]*entities.User, error) {
+	args := m.Called(parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateBaseCurrency(user *entities.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+// newUserRepository serves "user-id", a regular user, and "teen-id", a
+// dependent of "parent-id".
+func newUserRepository() *MockUserRepository {
+	repo := new(MockUserRepository)
+	repo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id", Role: entities.RoleUser}, nil).Maybe()
+	repo.On("FindUserByID", "teen-id").
+		Return(&entities.User{ID: "teen-id", Role: entities.RoleDependent, ParentID: "parent-id"}, nil).Maybe()
+	return repo
+}
+
 func TestUserService_CreateUser(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -54,7 +85,7 @@ func TestUserService_CreateUser(t *testing.T) {
 
 				h.On("HashValue", "password123").Return("hashed_password", nil)
 
-				ur.On("CreateUser", mock.AnythingOfType("*entities.User")).Return(&entities.User{
+				ur.On("CreateUser", mock.AnythingOfType("*entities.User"), mock.Anything).Return(&entities.User{
 					ID:        "some-uuid",
 					FirstName: "John",
 					LastName:  "Doe",
@@ -108,7 +139,7 @@ func TestUserService_CreateUser(t *testing.T) {
 
 				h.On("HashValue", "password123").Return("hashed_password", nil)
 
-				ur.On("CreateUser", mock.AnythingOfType("*entities.User")).Return(nil, errors.New("database error"))
+				ur.On("CreateUser", mock.AnythingOfType("*entities.User"), mock.Anything).Return(nil, errors.New("database error"))
 
 				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
 			},
@@ -162,3 +193,185 @@ func TestUserService_CreateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_CreateUser_SeedsDefaultCategories(t *testing.T) {
+	hashing := mocks.NewMockHashing()
+	hashing.On("HashValue", "password123").Return("hashed_password", nil)
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindUserByEmail", "john.doe@example.com").Return(nil, nil)
+
+	var userID string
+	userRepo.On("CreateUser", mock.MatchedBy(func(u *entities.User) bool {
+		userID = u.ID
+		return true
+	}), mock.MatchedBy(func(categories []*entities.Category) bool {
+		for _, category := range categories {
+			if category.UserID != userID {
+				return false
+			}
+		}
+		return len(categories) > 0
+	})).Return(&entities.User{ID: "user-id"}, nil)
+
+	userService := services.NewUserService(userRepo, hashing, mocks.NewMockLogger())
+	_, err := userService.CreateUser("John", "Doe", "john.doe@example.com", "password123")
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+}
+
+func TestUserService_CreateDependent(t *testing.T) {
+	parent := &entities.User{ID: "parent-id", Email: "parent@example.com", Role: entities.RoleUser}
+
+	tests := []struct {
+		name      string
+		parentID  string
+		mockSetup func(*MockUserRepository, *mocks.MockHashing, *mocks.MockLogger)
+		wantErr   bool
+		errType   apperror.ErrorType
+	}{
+		{
+			name:     "successful dependent creation",
+			parentID: "parent-id",
+			mockSetup: func(ur *MockUserRepository, h *mocks.MockHashing, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "parent-id").Return(parent, nil)
+				ur.On("FindUserByEmail", "kid@example.com").Return(nil, nil)
+				h.On("HashValue", "password123").Return("hashed_password", nil)
+				ur.On("CreateUser", mock.MatchedBy(func(u *entities.User) bool {
+					return u.Role == entities.RoleDependent && u.ParentID == "parent-id" && u.Password == "hashed_password"
+				}), mock.Anything).Return(&entities.User{
+					ID:        "kid-id",
+					FirstName: "Ana",
+					LastName:  "Doe",
+					Email:     "kid@example.com",
+					Role:      entities.RoleDependent,
+					ParentID:  "parent-id",
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "parent not found",
+			parentID: "missing-id",
+			mockSetup: func(ur *MockUserRepository, h *mocks.MockHashing, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "missing-id").Return(nil, nil)
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeNotFound,
+		},
+		{
+			name:     "dependent cannot have dependents",
+			parentID: "kid-id",
+			mockSetup: func(ur *MockUserRepository, h *mocks.MockHashing, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "kid-id").Return(&entities.User{ID: "kid-id", Role: entities.RoleDependent}, nil)
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeValidation,
+		},
+		{
+			name:     "repository error on parent lookup",
+			parentID: "parent-id",
+			mockSetup: func(ur *MockUserRepository, h *mocks.MockHashing, l *mocks.MockLogger) {
+				ur.On("FindUserByID", "parent-id").Return(nil, errors.New("database error"))
+				l.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+			},
+			wantErr: true,
+			errType: apperror.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockHashing := mocks.NewMockHashing()
+			mockLogger := mocks.NewMockLogger()
+
+			tt.mockSetup(mockUserRepo, mockHashing, mockLogger)
+
+			userService := services.NewUserService(mockUserRepo, mockHashing, mockLogger)
+
+			user, err := userService.CreateDependent(tt.parentID, "Ana", "Doe", "kid@example.com", "password123")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, apperror.IsErrorType(err, tt.errType),
+					"expected error type %s, got %v", tt.errType, err)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entities.RoleDependent, user.Role)
+				assert.Equal(t, "parent-id", user.ParentID)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockHashing.AssertExpectations(t)
+			mockLogger.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_ListDependents(t *testing.T) {
+	t.Run("lists dependents of parent", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("FindUserByID", "parent-id").Return(&entities.User{ID: "parent-id"}, nil)
+		mockUserRepo.On("FindDependentsByParentID", "parent-id").Return([]*entities.User{
+			{ID: "kid-id", Role: entities.RoleDependent, ParentID: "parent-id"},
+		}, nil)
+
+		userService := services.NewUserService(mockUserRepo, mocks.NewMockHashing(), mocks.NewMockLogger())
+		dependents, err := userService.ListDependents("parent-id")
+
+		assert.NoError(t, err)
+		assert.Len(t, dependents, 1)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("FindUserByID", "missing-id").Return(nil, nil)
+
+		userService := services.NewUserService(mockUserRepo, mocks.NewMockHashing(), mocks.NewMockLogger())
+		_, err := userService.ListDependents("missing-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+}
+
+func TestUserService_SetBaseCurrency(t *testing.T) {
+	t.Run("sets the base currency", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		repo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id", Role: entities.RoleUser}, nil)
+		repo.On("UpdateBaseCurrency", mock.MatchedBy(func(user *entities.User) bool {
+			return user.BaseCurrency == "EUR"
+		})).Return(nil)
+
+		service := services.NewUserService(repo, mocks.NewMockHashing(), mocks.NewMockLogger())
+		user, err := service.SetBaseCurrency("user-id", "eur")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", user.BaseCurrency)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an unknown currency", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		repo.On("FindUserByID", "user-id").Return(&entities.User{ID: "user-id", Role: entities.RoleUser}, nil)
+
+		service := services.NewUserService(repo, mocks.NewMockHashing(), mocks.NewMockLogger())
+		_, err := service.SetBaseCurrency("user-id", "XYZ")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+		repo.AssertNotCalled(t, "UpdateBaseCurrency", mock.Anything)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		repo.On("FindUserByID", "missing-id").Return(nil, nil)
+
+		service := services.NewUserService(repo, mocks.NewMockHashing(), mocks.NewMockLogger())
+		_, err := service.SetBaseCurrency("missing-id", "EUR")
+
+		assert.True(t, errors.Is(err, services.ErrUserNotFound))
+	})
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type WalletService interface {
+	// CreateWallet creates a wallet owned by ownerID. With a householdID,
+	// the wallet belongs to that household, of which ownerID must be a
+	// member.
+	CreateWallet(ownerID, name, householdID string) (*entities.Wallet, error)
+	// ListWallets returns the wallets userID is a member of, directly or
+	// through a household.
+	ListWallets(userID string) ([]*entities.Wallet, error)
+	GetWallet(walletID, actorID string) (*entities.Wallet, error)
+	AddMember(walletID, actorID, email string, role entities.WalletRole) (*entities.WalletMember, error)
+	// RemoveMember lets owners remove others and any member leave on their
+	// own.
+	RemoveMember(walletID, actorID, userID string) error
+	// SetApprovalPolicy lets an owner hold new expenses above threshold for
+	// review within ttl. A threshold without a currency holds nothing.
+	SetApprovalPolicy(walletID, actorID string, threshold money.Money, ttl time.Duration) (*entities.Wallet, error)
+}
+
+type walletService struct {
+	walletRepo    repositories.WalletRepository
+	householdRepo repositories.HouseholdRepository
+	userRepo      repositories.UserRepository
+	activityRepo  repositories.ActivityRepository
+	logger        logger.Logger
+}
+
+var (
+	ErrWalletForbidden       = apperror.New(apperror.ErrorTypeForbidden, "Wallet is not accessible")
+	ErrWalletManageForbidden = apperror.New(apperror.ErrorTypeForbidden, "Only wallet owners can manage members")
+	ErrWalletUserNotFound    = apperror.New(apperror.ErrorTypeNotFound, "User not found")
+	ErrWalletPolicyForbidden = apperror.New(apperror.ErrorTypeForbidden, "Only wallet owners can change the approval policy")
+	ErrWalletDependentOwner  = apperror.New(apperror.ErrorTypeForbidden, "Dependents cannot create wallets")
+)
+
+func (s *walletService) CreateWallet(ownerID, name, householdID string) (*entities.Wallet, error) {
+	owner, err := s.findUser(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, ErrWalletUserNotFound
+	}
+	if owner.IsDependent() {
+		return nil, ErrWalletDependentOwner
+	}
+
+	wallet, err := entities.NewWallet(name, owner.ID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	if householdID != "" {
+		household, err := s.householdRepo.FindHouseholdByID(householdID)
+		if err != nil {
+			s.logger.Error(err, "Failed to find household", map[string]interface{}{
+				"household_id": householdID,
+			})
+			return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+		}
+		if household == nil || household.Member(owner.ID) == nil {
+			return nil, ErrHouseholdNotFound
+		}
+		wallet.HouseholdID = household.ID
+	}
+
+	createdWallet, err := s.walletRepo.CreateWallet(wallet)
+	if err != nil {
+		s.logger.Error(err, "Failed to create wallet", map[string]interface{}{
+			"user_id": ownerID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return createdWallet, nil
+}
+
+func (s *walletService) ListWallets(userID string) ([]*entities.Wallet, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrWalletUserNotFound
+	}
+
+	wallets, err := s.walletRepo.FindWalletsByUserID(user.ID)
+	if err != nil {
+		s.logger.Error(err, "Failed to list wallets", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return wallets, nil
+}
+
+func (s *walletService) GetWallet(walletID, actorID string) (*entities.Wallet, error) {
+	if _, err := walletMember(s.walletRepo, s.logger, walletID, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.findWallet(walletID)
+}
+
+func (s *walletService) findWallet(walletID string) (*entities.Wallet, error) {
+	wallet, err := s.walletRepo.FindWalletByID(walletID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find wallet", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if wallet == nil {
+		return nil, ErrWalletForbidden
+	}
+
+	return wallet, nil
+}
+
+func (s *walletService) AddMember(walletID, actorID, email string, role entities.WalletRole) (*entities.WalletMember, error) {
+	if !role.Valid() {
+		return nil, apperror.New(apperror.ErrorTypeValidation, "Role must be OWNER or MEMBER").
+			AddContext("field", "role")
+	}
+
+	wallet, err := s.managedWallet(walletID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindUserByEmail(email)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", nil)
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if user == nil {
+		return nil, ErrWalletUserNotFound
+	}
+	if user.IsDependent() && role != entities.WalletRoleMember {
+		return nil, s.domainError(entities.ErrDependentRole)
+	}
+
+	member, err := wallet.AddMember(user.ID, role)
+	if err != nil {
+		return nil, s.domainError(err)
+	}
+
+	if err := s.walletRepo.AddMember(member); err != nil {
+		s.logger.Error(err, "Failed to add wallet member", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	record(s.activityRepo, s.logger, newActivity(s.logger, func() (*entities.Activity, error) {
+		return entities.NewActivity(walletID, actorID, entities.ActivityMemberJoined, member.UserID, map[string]entities.ActivityChange{
+			"role": {After: string(member.Role)},
+		})
+	}))
+	return member, nil
+}
+
+func (s *walletService) RemoveMember(walletID, actorID, userID string) error {
+	var (
+		wallet *entities.Wallet
+		err    error
+	)
+	if actorID == userID {
+		wallet, err = s.GetWallet(walletID, actorID)
+	} else {
+		wallet, err = s.managedWallet(walletID, actorID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.RemoveMember(userID); err != nil {
+		return s.domainError(err)
+	}
+
+	if err := s.walletRepo.RemoveMember(walletID, userID); err != nil {
+		s.logger.Error(err, "Failed to remove wallet member", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return nil
+}
+
+func (s *walletService) SetApprovalPolicy(
+	walletID string,
+	actorID string,
+	threshold money.Money,
+	ttl time.Duration,
+) (*entities.Wallet, error) {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.CanManageMembers() {
+		return nil, ErrWalletPolicyForbidden
+	}
+
+	policy, err := entities.NewApprovalPolicy(threshold, ttl)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	wallet, err := s.findWallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	wallet.SetApprovalPolicy(policy)
+
+	if err := s.walletRepo.UpdateApprovalPolicy(wallet); err != nil {
+		s.logger.Error(err, "Failed to update approval policy", map[string]interface{}{
+			"wallet_id": walletID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+
+	return wallet, nil
+}
+
+// managedWallet returns the wallet when actorID may manage its members,
+// whether they own it directly or run the household owning it.
+func (s *walletService) managedWallet(walletID, actorID string) (*entities.Wallet, error) {
+	member, err := walletMember(s.walletRepo, s.logger, walletID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.CanManageMembers() {
+		return nil, ErrWalletManageForbidden
+	}
+
+	return s.findWallet(walletID)
+}
+
+func (s *walletService) findUser(userID string) (*entities.User, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		s.logger.Error(err, "Failed to find user", map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	return user, nil
+}
+
+func (s *walletService) domainError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrNotWalletMember):
+		return apperror.Wrap(apperror.ErrorTypeNotFound, err)
+	case errors.Is(err, entities.ErrAlreadyWalletMember), errors.Is(err, entities.ErrLastWalletOwner):
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err)
+	case errors.Is(err, entities.ErrDependentRole):
+		return apperror.Wrap(apperror.ErrorTypeUnprocessable, err).
+			AddContext("field", "role")
+	default:
+		return apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+}
+
+// walletMember returns the membership of userID in the wallet. Non-members,
+// and callers of wallets that do not exist, get ErrWalletForbidden.
+func walletMember(
+	walletRepo repositories.WalletRepository,
+	log logger.Logger,
+	walletID string,
+	userID string,
+) (*entities.WalletMember, error) {
+	member, err := walletRepo.FindWalletMember(walletID, userID)
+	if err != nil {
+		log.Error(err, "Failed to find wallet member", map[string]interface{}{
+			"wallet_id": walletID,
+			"user_id":   userID,
+		})
+		return nil, apperror.Wrap(apperror.ErrorTypeDatabase, err)
+	}
+	if member == nil {
+		return nil, ErrWalletForbidden
+	}
+	return member, nil
+}
+
+func NewWalletService(
+	walletRepo repositories.WalletRepository,
+	householdRepo repositories.HouseholdRepository,
+	userRepo repositories.UserRepository,
+	activityRepo repositories.ActivityRepository,
+	logger logger.Logger,
+) WalletService {
+	return &walletService{
+		walletRepo:    walletRepo,
+		householdRepo: householdRepo,
+		userRepo:      userRepo,
+		activityRepo:  activityRepo,
+		logger:        logger,
+	}
+}
@@ -0,0 +1,332 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/money"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWalletRepository struct {
+	mock.Mock
+}
+
+func (m *MockWalletRepository) CreateWallet(wallet *entities.Wallet) (*entities.Wallet, error) {
+	args := m.Called(wallet)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Wallet), args.Error(1)
+}
+
+func (m *MockWalletRepository) FindWalletByID(id string) (*entities.Wallet, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Wallet), args.Error(1)
+}
+
+func (m *MockWalletRepository) FindWalletsByUserID(userID string) ([]*entities.Wallet, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Wallet), args.Error(1)
+}
+
+func (m *MockWalletRepository) FindWalletIDsByUserID(userID string) ([]string, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockWalletRepository) FindWalletMember(walletID, userID string) (*entities.WalletMember, error) {
+	args := m.Called(walletID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WalletMember), args.Error(1)
+}
+
+func (m *MockWalletRepository) AddMember(member *entities.WalletMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) RemoveMember(walletID, userID string) error {
+	args := m.Called(walletID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) UpdateApprovalPolicy(wallet *entities.Wallet) error {
+	args := m.Called(wallet)
+	return args.Error(0)
+}
+
+// newWalletRepository returns a wallet repository where "user-id" is a
+// member of every wallet and nobody else is.
+func newWalletRepository() *MockWalletRepository {
+	return newWalletRepositoryFor("user-id")
+}
+
+// newWalletRepositoryFor returns a wallet repository where the given users
+// are members of every wallet and nobody else is. Wallets hold no expenses
+// for approval.
+func newWalletRepositoryFor(memberIDs ...string) *MockWalletRepository {
+	repo := new(MockWalletRepository)
+	repo.On("FindWalletByID", mock.Anything).
+		Return(&entities.Wallet{ApprovalPolicy: entities.ApprovalPolicy{TTL: entities.DefaultApprovalTTL}}, nil).Maybe()
+	for _, memberID := range memberIDs {
+		repo.On("FindWalletMember", mock.Anything, memberID).
+			Return(&entities.WalletMember{UserID: memberID, Role: entities.WalletRoleMember}, nil).Maybe()
+	}
+	repo.On("FindWalletMember", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+// newTestWallet builds a wallet owned by "owner-id" with a plain member
+// "member-id".
+func newTestWallet() *entities.Wallet {
+	wallet, _ := entities.NewWallet("Joint account", "owner-id")
+	wallet.ID = "wallet-id"
+	for _, m := range wallet.Members {
+		m.WalletID = wallet.ID
+	}
+	_, _ = wallet.AddMember("member-id", entities.WalletRoleMember)
+	return wallet
+}
+
+// newTestWalletRepository serves newTestWallet and its memberships.
+func newTestWalletRepository() *MockWalletRepository {
+	wallet := newTestWallet()
+	repo := new(MockWalletRepository)
+	repo.On("FindWalletByID", "wallet-id").Return(wallet, nil).Maybe()
+	for _, member := range wallet.Members {
+		repo.On("FindWalletMember", "wallet-id", member.UserID).Return(member, nil).Maybe()
+	}
+	repo.On("FindWalletMember", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+func TestWalletService_CreateWallet(t *testing.T) {
+	t.Run("successful creation", func(t *testing.T) {
+		repo := new(MockWalletRepository)
+		repo.On("CreateWallet", mock.MatchedBy(func(wallet *entities.Wallet) bool {
+			return wallet.Name == "Joint account" && wallet.Member("owner-id").CanManageMembers()
+		})).Return(newTestWallet(), nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "owner-id").Return(&entities.User{ID: "owner-id"}, nil)
+
+		service := services.NewWalletService(repo, new(MockHouseholdRepository), userRepo, newActivityRepository(), mocks.NewMockLogger())
+		wallet, err := service.CreateWallet("owner-id", "Joint account", "")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, wallet)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("owner not found", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "ghost-id").Return(nil, nil)
+
+		service := services.NewWalletService(new(MockWalletRepository), new(MockHouseholdRepository), userRepo, newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.CreateWallet("ghost-id", "Joint account", "")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeNotFound))
+	})
+
+	t.Run("dependent cannot create wallets", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "teen-id").Return(&entities.User{ID: "teen-id", Role: entities.RoleDependent, ParentID: "owner-id"}, nil)
+
+		service := services.NewWalletService(new(MockWalletRepository), new(MockHouseholdRepository), userRepo, newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.CreateWallet("teen-id", "Pocket money", "")
+
+		assert.ErrorIs(t, err, services.ErrWalletDependentOwner)
+	})
+}
+
+func TestWalletService_GetWallet(t *testing.T) {
+	service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+
+	wallet, err := service.GetWallet("wallet-id", "member-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "wallet-id", wallet.ID)
+
+	_, err = service.GetWallet("wallet-id", "stranger-id")
+	assert.ErrorIs(t, err, services.ErrWalletForbidden)
+}
+
+func TestWalletService_AddMember(t *testing.T) {
+	t.Run("owner adds a member", func(t *testing.T) {
+		repo := newTestWalletRepository()
+		repo.On("AddMember", mock.MatchedBy(func(member *entities.WalletMember) bool {
+			return member.WalletID == "wallet-id" && member.UserID == "partner-id"
+		})).Return(nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByEmail", "partner@example.com").Return(&entities.User{ID: "partner-id"}, nil)
+
+		service := services.NewWalletService(repo, new(MockHouseholdRepository), userRepo, newActivityRepository(), mocks.NewMockLogger())
+		member, err := service.AddMember("wallet-id", "owner-id", "partner@example.com", entities.WalletRoleMember)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "partner-id", member.UserID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("member cannot add members", func(t *testing.T) {
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.AddMember("wallet-id", "member-id", "partner@example.com", entities.WalletRoleMember)
+
+		assert.ErrorIs(t, err, services.ErrWalletManageForbidden)
+	})
+
+	t.Run("non-member cannot add members", func(t *testing.T) {
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.AddMember("wallet-id", "stranger-id", "stranger@example.com", entities.WalletRoleOwner)
+
+		assert.ErrorIs(t, err, services.ErrWalletForbidden)
+	})
+
+	t.Run("dependent can only join as a member", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByEmail", "teen@example.com").Return(&entities.User{ID: "teen-id", Role: entities.RoleDependent, ParentID: "owner-id"}, nil)
+
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), userRepo, newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.AddMember("wallet-id", "owner-id", "teen@example.com", entities.WalletRoleOwner)
+
+		assert.ErrorIs(t, err, entities.ErrDependentRole)
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.AddMember("wallet-id", "owner-id", "partner@example.com", entities.WalletRole("ADMIN"))
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+	})
+}
+
+func TestWalletService_RemoveMember(t *testing.T) {
+	t.Run("member leaves", func(t *testing.T) {
+		repo := newTestWalletRepository()
+		repo.On("RemoveMember", "wallet-id", "member-id").Return(nil)
+
+		service := services.NewWalletService(repo, new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		assert.NoError(t, service.RemoveMember("wallet-id", "member-id", "member-id"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("last owner cannot leave", func(t *testing.T) {
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		err := service.RemoveMember("wallet-id", "owner-id", "owner-id")
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeUnprocessable))
+	})
+
+	t.Run("member cannot remove others", func(t *testing.T) {
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		err := service.RemoveMember("wallet-id", "member-id", "owner-id")
+
+		assert.ErrorIs(t, err, services.ErrWalletManageForbidden)
+	})
+}
+
+func TestWalletService_HouseholdWallets(t *testing.T) {
+	t.Run("household member creates a household wallet", func(t *testing.T) {
+		repo := new(MockWalletRepository)
+		repo.On("CreateWallet", mock.MatchedBy(func(wallet *entities.Wallet) bool {
+			return wallet.HouseholdID == "household-id"
+		})).Return(newTestWallet(), nil)
+		householdRepo := new(MockHouseholdRepository)
+		householdRepo.On("FindHouseholdByID", "household-id").Return(newTestHousehold(), nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "member-id").Return(&entities.User{ID: "member-id"}, nil)
+
+		service := services.NewWalletService(repo, householdRepo, userRepo, newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.CreateWallet("member-id", "Groceries", "household-id")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("outsider cannot create a household wallet", func(t *testing.T) {
+		householdRepo := new(MockHouseholdRepository)
+		householdRepo.On("FindHouseholdByID", "household-id").Return(newTestHousehold(), nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByID", "stranger-id").Return(&entities.User{ID: "stranger-id"}, nil)
+
+		service := services.NewWalletService(new(MockWalletRepository), householdRepo, userRepo, newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.CreateWallet("stranger-id", "Groceries", "household-id")
+
+		assert.ErrorIs(t, err, services.ErrHouseholdNotFound)
+	})
+
+	t.Run("household admin manages members without being listed", func(t *testing.T) {
+		repo := new(MockWalletRepository)
+		repo.On("FindWalletMember", "wallet-id", "admin-id").
+			Return(&entities.WalletMember{WalletID: "wallet-id", UserID: "admin-id", Role: entities.HouseholdRoleAdmin.WalletRole()}, nil)
+		repo.On("FindWalletByID", "wallet-id").Return(newTestWallet(), nil)
+		repo.On("AddMember", mock.Anything).Return(nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindUserByEmail", "partner@example.com").Return(&entities.User{ID: "partner-id"}, nil)
+
+		service := services.NewWalletService(repo, new(MockHouseholdRepository), userRepo, newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.AddMember("wallet-id", "admin-id", "partner@example.com", entities.WalletRoleMember)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestWalletService_SetApprovalPolicy(t *testing.T) {
+	t.Run("owner sets a threshold", func(t *testing.T) {
+		repo := newTestWalletRepository()
+		repo.On("UpdateApprovalPolicy", mock.MatchedBy(func(wallet *entities.Wallet) bool {
+			return wallet.ApprovalPolicy.Enabled() && wallet.ApprovalPolicy.TTL == 24*time.Hour
+		})).Return(nil)
+
+		service := services.NewWalletService(repo, new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		wallet, err := service.SetApprovalPolicy("wallet-id", "owner-id", newMoney(t, "200.00", "BRL"), 24*time.Hour)
+
+		assert.NoError(t, err)
+		assert.True(t, wallet.ApprovalPolicy.Threshold.Equal(newMoney(t, "200.00", "BRL")))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("owner turns approvals off", func(t *testing.T) {
+		repo := newTestWalletRepository()
+		repo.On("UpdateApprovalPolicy", mock.MatchedBy(func(wallet *entities.Wallet) bool {
+			return !wallet.ApprovalPolicy.Enabled()
+		})).Return(nil)
+
+		service := services.NewWalletService(repo, new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.SetApprovalPolicy("wallet-id", "owner-id", money.Money{}, entities.DefaultApprovalTTL)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("members cannot change the policy", func(t *testing.T) {
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.SetApprovalPolicy("wallet-id", "member-id", newMoney(t, "200.00", "BRL"), time.Hour)
+
+		assert.ErrorIs(t, err, services.ErrWalletPolicyForbidden)
+	})
+
+	t.Run("expiry must be positive", func(t *testing.T) {
+		service := services.NewWalletService(newTestWalletRepository(), new(MockHouseholdRepository), new(MockUserRepository), newActivityRepository(), mocks.NewMockLogger())
+		_, err := service.SetApprovalPolicy("wallet-id", "owner-id", newMoney(t, "200.00", "BRL"), 0)
+
+		assert.True(t, apperror.IsErrorType(err, apperror.ErrorTypeValidation))
+	})
+}
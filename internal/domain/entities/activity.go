@@ -0,0 +1,179 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ActivityType string
+
+const (
+	ActivityTransactionAdded   ActivityType = "TRANSACTION_ADDED"
+	ActivityTransactionEdited  ActivityType = "TRANSACTION_EDITED"
+	ActivityTransactionDeleted ActivityType = "TRANSACTION_DELETED"
+	ActivityMemberJoined       ActivityType = "MEMBER_JOINED"
+	// ActivityBudgetChanged records a spending limit of a member being set,
+	// changed or removed; limits are the budgets of a wallet.
+	ActivityBudgetChanged ActivityType = "BUDGET_CHANGED"
+)
+
+// ActivityChange is the value of a field before and after an event. Before
+// is nil for a field the event added, and After for one it removed.
+type ActivityChange struct {
+	Before interface{}
+	After  interface{}
+}
+
+// Activity is an event of the wallet feed: who did what to which subject,
+// such as a transaction or a member, with the fields it changed. Read
+// reports whether the member viewing the feed has seen it.
+type Activity struct {
+	ID        string
+	WalletID  string
+	ActorID   string
+	Type      ActivityType
+	SubjectID string
+	Changes   map[string]ActivityChange
+	CreatedAt time.Time
+	Read      bool
+}
+
+func NewActivity(
+	walletID string,
+	actorID string,
+	activityType ActivityType,
+	subjectID string,
+	changes map[string]ActivityChange,
+) (*Activity, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("activity wallet is required")
+	}
+
+	if actorID == "" {
+		return nil, fmt.Errorf("activity actor is required")
+	}
+
+	if changes == nil {
+		changes = map[string]ActivityChange{}
+	}
+
+	return &Activity{
+		ID:        uuid.NewString(),
+		WalletID:  walletID,
+		ActorID:   actorID,
+		Type:      activityType,
+		SubjectID: subjectID,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NewTransactionActivity records a transaction being added, when before is
+// nil, deleted, when after is nil, or edited. An edit that changed none of
+// the fields the feed shows returns nil.
+func NewTransactionActivity(walletID, actorID string, before, after *Transaction) (*Activity, error) {
+	activityType := ActivityTransactionEdited
+	subject := after
+	switch {
+	case before == nil:
+		activityType = ActivityTransactionAdded
+	case after == nil:
+		activityType = ActivityTransactionDeleted
+		subject = before
+	}
+
+	changes := diffFields(transactionFields(before), transactionFields(after))
+	if activityType == ActivityTransactionEdited && len(changes) == 0 {
+		return nil, nil
+	}
+	return NewActivity(walletID, actorID, activityType, subject.ID, changes)
+}
+
+// NewLimitActivity records a spending limit being set, when before is nil,
+// removed, when after is nil, or changed.
+func NewLimitActivity(actorID string, before, after *SpendingLimit) (*Activity, error) {
+	subject := after
+	if subject == nil {
+		subject = before
+	}
+	return NewActivity(subject.WalletID, actorID, ActivityBudgetChanged, subject.ID,
+		diffFields(limitFields(before), limitFields(after)))
+}
+
+func transactionFields(transaction *Transaction) map[string]interface{} {
+	if transaction == nil {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"type":        string(transaction.Type),
+		"amount":      transaction.Amount.String(),
+		"date":        transaction.Date.Format("2006-01-02"),
+		"description": transaction.Description,
+		"status":      string(transaction.Status),
+		"wallet_id":   transaction.WalletID,
+		"category_id": transaction.CategoryID,
+		"payee_id":    transaction.PayeeID,
+	}
+	if len(transaction.TagIDs) > 0 {
+		tags := slices.Clone(transaction.TagIDs)
+		slices.Sort(tags)
+		fields["tag_ids"] = tags
+	}
+	return fields
+}
+
+func limitFields(limit *SpendingLimit) map[string]interface{} {
+	if limit == nil {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"user_id":     limit.UserID,
+		"category_id": limit.CategoryID,
+		"period":      string(limit.Period),
+		"amount":      limit.Amount.String(),
+	}
+}
+
+// diffFields returns the fields whose value differs between before and
+// after. Empty values count as missing.
+func diffFields(before, after map[string]interface{}) map[string]ActivityChange {
+	changes := make(map[string]ActivityChange)
+	for _, fields := range []map[string]interface{}{before, after} {
+		for name := range fields {
+			previous, next := fieldValue(before[name]), fieldValue(after[name])
+			if !fieldEqual(previous, next) {
+				changes[name] = ActivityChange{Before: previous, After: next}
+			}
+		}
+	}
+	return changes
+}
+
+func fieldEqual(a, b interface{}) bool {
+	as, aList := a.([]string)
+	bs, bList := b.([]string)
+	if aList || bList {
+		return slices.Equal(as, bs)
+	}
+	return a == b
+}
+
+// fieldValue returns nil for an empty value.
+func fieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+	case []string:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransactionActivity(t *testing.T) {
+	t.Run("added transaction lists its fields", func(t *testing.T) {
+		transaction := newStatementTransaction(t, "transaction-id", entities.TransactionTypeExpense, "42.90")
+
+		activity, err := entities.NewTransactionActivity("wallet-id", "user-id", nil, transaction)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.ActivityTransactionAdded, activity.Type)
+		assert.Equal(t, "transaction-id", activity.SubjectID)
+		assert.Equal(t, entities.ActivityChange{After: "BRL 42.90"}, activity.Changes["amount"])
+		assert.NotContains(t, activity.Changes, "category_id")
+	})
+
+	t.Run("edit keeps only the changed fields", func(t *testing.T) {
+		before := newStatementTransaction(t, "transaction-id", entities.TransactionTypeExpense, "42.90")
+		after := *before
+		after.Amount = brl(t, "50.00")
+		after.SetTags([]string{"tag-id"})
+
+		activity, err := entities.NewTransactionActivity("wallet-id", "user-id", before, &after)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.ActivityTransactionEdited, activity.Type)
+		assert.Equal(t, map[string]entities.ActivityChange{
+			"amount":  {Before: "BRL 42.90", After: "BRL 50.00"},
+			"tag_ids": {After: []string{"tag-id"}},
+		}, activity.Changes)
+	})
+
+	t.Run("edit without visible changes records nothing", func(t *testing.T) {
+		before := newStatementTransaction(t, "transaction-id", entities.TransactionTypeExpense, "42.90")
+		after := *before
+
+		activity, err := entities.NewTransactionActivity("wallet-id", "user-id", before, &after)
+
+		require.NoError(t, err)
+		assert.Nil(t, activity)
+	})
+
+	t.Run("deleted transaction", func(t *testing.T) {
+		transaction := newStatementTransaction(t, "transaction-id", entities.TransactionTypeExpense, "42.90")
+
+		activity, err := entities.NewTransactionActivity("wallet-id", "user-id", transaction, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.ActivityTransactionDeleted, activity.Type)
+		assert.Equal(t, entities.ActivityChange{Before: "BRL 42.90"}, activity.Changes["amount"])
+	})
+}
+
+func TestNewLimitActivity(t *testing.T) {
+	before, err := entities.NewSpendingLimit("wallet-id", "member-id", "", entities.LimitPeriodMonth, brl(t, "500.00"), "owner-id")
+	require.NoError(t, err)
+	after := *before
+	require.NoError(t, after.SetAmount(brl(t, "300.00")))
+
+	activity, err := entities.NewLimitActivity("owner-id", before, &after)
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ActivityBudgetChanged, activity.Type)
+	assert.Equal(t, "wallet-id", activity.WalletID)
+	assert.Equal(t, map[string]entities.ActivityChange{
+		"amount": {Before: "BRL 500.00", After: "BRL 300.00"},
+	}, activity.Changes)
+}
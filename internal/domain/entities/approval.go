@@ -0,0 +1,289 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "PENDING"
+	ApprovalStatusApproved ApprovalStatus = "APPROVED"
+	ApprovalStatusRejected ApprovalStatus = "REJECTED"
+	ApprovalStatusExpired  ApprovalStatus = "EXPIRED"
+)
+
+type ApprovalEvent string
+
+const (
+	ApprovalEventApprove ApprovalEvent = "APPROVE"
+	ApprovalEventReject  ApprovalEvent = "REJECT"
+	ApprovalEventExpire  ApprovalEvent = "EXPIRE"
+)
+
+var (
+	ErrInvalidApprovalTransition = errors.New("invalid approval transition")
+	ErrApprovalExpired           = errors.New("approval request has expired")
+	ErrSelfApproval              = errors.New("requesters cannot review their own expenses")
+	ErrRejectionCommentRequired  = errors.New("a comment is required to reject an expense")
+	ErrNotReviewer               = errors.New("expense is assigned to another reviewer")
+	// ErrAwaitingApproval is returned when a held expense would be posted,
+	// moved or change amount before an owner has reviewed it.
+	ErrAwaitingApproval = errors.New("expense is awaiting approval")
+)
+
+// DefaultApprovalTTL is how long owners have to review a held expense
+// unless the wallet says otherwise.
+const DefaultApprovalTTL = 72 * time.Hour
+
+// approvalTransitions is the whole state machine: only pending requests can
+// move, and every other status is final.
+var approvalTransitions = map[ApprovalStatus]map[ApprovalEvent]ApprovalStatus{
+	ApprovalStatusPending: {
+		ApprovalEventApprove: ApprovalStatusApproved,
+		ApprovalEventReject:  ApprovalStatusRejected,
+		ApprovalEventExpire:  ApprovalStatusExpired,
+	},
+	ApprovalStatusApproved: {},
+	ApprovalStatusRejected: {},
+	ApprovalStatusExpired:  {},
+}
+
+// NextApprovalStatus returns the status reached by applying event to status.
+func NextApprovalStatus(status ApprovalStatus, event ApprovalEvent) (ApprovalStatus, error) {
+	next, ok := approvalTransitions[status][event]
+	if !ok {
+		return "", fmt.Errorf("%w: cannot %s a %s request", ErrInvalidApprovalTransition,
+			strings.ToLower(string(event)), strings.ToLower(string(status)))
+	}
+	return next, nil
+}
+
+// RequiresApproval reports whether an expense of amount must be reviewed
+// given the wallet threshold. Amounts equal to the threshold do not.
+func RequiresApproval(amount, threshold money.Money) (bool, error) {
+	abs, err := amount.Abs()
+	if err != nil {
+		return false, err
+	}
+	cmp, err := abs.Cmp(threshold)
+	if err != nil {
+		return false, err
+	}
+	return cmp > 0, nil
+}
+
+// ApprovalPolicy is the rule a wallet applies to new expenses: those above
+// Threshold are held until an owner reviews them, for at most TTL. A policy
+// without a threshold holds nothing.
+type ApprovalPolicy struct {
+	Threshold money.Money
+	TTL       time.Duration
+}
+
+func NewApprovalPolicy(threshold money.Money, ttl time.Duration) (ApprovalPolicy, error) {
+	if threshold.Currency().Code != "" && threshold.IsNegative() {
+		return ApprovalPolicy{}, fmt.Errorf("approval threshold cannot be negative")
+	}
+
+	if ttl <= 0 {
+		return ApprovalPolicy{}, fmt.Errorf("approval expiry must be positive")
+	}
+
+	return ApprovalPolicy{Threshold: threshold, TTL: ttl}, nil
+}
+
+// Enabled reports whether the policy holds any expense at all.
+func (p ApprovalPolicy) Enabled() bool {
+	return p.Threshold.Currency().Code != ""
+}
+
+// Approval is the review of a single held expense. Expenses above the
+// approval threshold can be reviewed by any wallet owner; those of a
+// dependent are assigned to their parent as ReviewerID.
+type Approval struct {
+	ID            string
+	WalletID      string
+	TransactionID string
+	RequestedBy   string
+	ReviewerID    string
+	Amount        money.Money
+	Status        ApprovalStatus
+	ReviewedBy    string
+	Comment       string
+	RequestedAt   time.Time
+	ExpiresAt     time.Time
+	ReviewedAt    time.Time
+}
+
+// NewApproval asks for the review of an expense held with
+// HoldForApproval. The requester is the author of the expense.
+func NewApproval(transaction *Transaction, ttl time.Duration, now time.Time) (*Approval, error) {
+	if transaction == nil || transaction.ID == "" {
+		return nil, fmt.Errorf("transaction is required")
+	}
+
+	if !transaction.AwaitingApproval {
+		return nil, fmt.Errorf("transaction is not held for approval")
+	}
+
+	if transaction.CreatedBy == "" {
+		return nil, fmt.Errorf("requester is required")
+	}
+
+	if ttl <= 0 {
+		return nil, fmt.Errorf("approval expiry must be positive")
+	}
+
+	return &Approval{
+		ID:            uuid.NewString(),
+		WalletID:      transaction.WalletID,
+		TransactionID: transaction.ID,
+		RequestedBy:   transaction.CreatedBy,
+		Amount:        transaction.Amount,
+		Status:        ApprovalStatusPending,
+		RequestedAt:   now,
+		ExpiresAt:     now.Add(ttl),
+	}, nil
+}
+
+// AssignReviewer makes reviewerID the only user who can review the request.
+func (a *Approval) AssignReviewer(reviewerID string) error {
+	if reviewerID == "" {
+		return fmt.Errorf("reviewer is required")
+	}
+
+	if reviewerID == a.RequestedBy {
+		return ErrSelfApproval
+	}
+
+	a.ReviewerID = reviewerID
+	return nil
+}
+
+// CanReview reports whether member may decide on the request: the assigned
+// reviewer when there is one, any wallet owner otherwise.
+func (a *Approval) CanReview(member *WalletMember) bool {
+	if a.ReviewerID != "" {
+		return member.UserID == a.ReviewerID
+	}
+	return member.CanManageMembers()
+}
+
+func (a *Approval) IsPending() bool {
+	return a.Status == ApprovalStatusPending
+}
+
+// IsOverdue reports whether a pending request has passed its expiry time.
+func (a *Approval) IsOverdue(now time.Time) bool {
+	return a.IsPending() && !now.Before(a.ExpiresAt)
+}
+
+func (a *Approval) Approve(reviewerID, comment string, now time.Time) error {
+	return a.review(ApprovalEventApprove, reviewerID, comment, now)
+}
+
+func (a *Approval) Reject(reviewerID, comment string, now time.Time) error {
+	if strings.TrimSpace(comment) == "" {
+		return ErrRejectionCommentRequired
+	}
+	return a.review(ApprovalEventReject, reviewerID, comment, now)
+}
+
+// Expire closes an overdue pending request.
+func (a *Approval) Expire(now time.Time) error {
+	if a.IsPending() && !a.IsOverdue(now) {
+		return fmt.Errorf("%w: request is not overdue yet", ErrInvalidApprovalTransition)
+	}
+	return a.transition(ApprovalEventExpire, now)
+}
+
+// review approves or rejects the request. Reviewing an overdue request
+// expires it instead and reports ErrApprovalExpired.
+func (a *Approval) review(event ApprovalEvent, reviewerID, comment string, now time.Time) error {
+	if reviewerID == "" {
+		return fmt.Errorf("reviewer is required")
+	}
+
+	if reviewerID == a.RequestedBy {
+		return ErrSelfApproval
+	}
+
+	if a.ReviewerID != "" && reviewerID != a.ReviewerID {
+		return ErrNotReviewer
+	}
+
+	if a.IsOverdue(now) {
+		if err := a.transition(ApprovalEventExpire, now); err != nil {
+			return err
+		}
+		return ErrApprovalExpired
+	}
+
+	if err := a.transition(event, now); err != nil {
+		return err
+	}
+
+	a.ReviewedBy = reviewerID
+	a.Comment = strings.TrimSpace(comment)
+	return nil
+}
+
+func (a *Approval) transition(event ApprovalEvent, now time.Time) error {
+	next, err := NextApprovalStatus(a.Status, event)
+	if err != nil {
+		return err
+	}
+
+	a.Status = next
+	a.ReviewedAt = now
+	return nil
+}
+
+// HoldForApproval keeps a new expense out of the current balance until an
+// owner reviews it: a posted expense goes back to pending, and it cannot be
+// cleared until it is released.
+func (t *Transaction) HoldForApproval() error {
+	if t.Type != TransactionTypeExpense || t.IsTransfer() {
+		return fmt.Errorf("only expenses can be held for approval")
+	}
+
+	if t.Status.IsPosted() {
+		t.Status = TransactionStatusPending
+	}
+	t.AwaitingApproval = true
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Release ends the hold once the approval reached status. An approved
+// expense goes through and clears if it was pending; a rejected or expired
+// one is deleted.
+func (t *Transaction) Release(status ApprovalStatus) error {
+	if !t.AwaitingApproval {
+		return fmt.Errorf("transaction is not held for approval")
+	}
+
+	switch status {
+	case ApprovalStatusApproved:
+		t.AwaitingApproval = false
+		if t.Status == TransactionStatusPending {
+			return t.moveTo(TransactionStatusCleared)
+		}
+		t.UpdatedAt = time.Now()
+		return nil
+	case ApprovalStatusRejected, ApprovalStatusExpired:
+		t.AwaitingApproval = false
+		t.Delete()
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot release a %s request", ErrInvalidApprovalTransition,
+			strings.ToLower(string(status)))
+	}
+}
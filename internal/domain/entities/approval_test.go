@@ -0,0 +1,448 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var approvalNow = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// newHeldExpense builds an expense by "teen-id" held for approval.
+func newHeldExpense(t *testing.T) *entities.Transaction {
+	t.Helper()
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, brl(t, "500.00"), time.Now(), "Console", "", "teen-id")
+	require.NoError(t, err)
+	require.NoError(t, transaction.HoldForApproval())
+	return transaction
+}
+
+func newPendingApproval(t *testing.T) *entities.Approval {
+	t.Helper()
+	approval, err := entities.NewApproval(newHeldExpense(t), 48*time.Hour, approvalNow)
+	require.NoError(t, err)
+	return approval
+}
+
+func TestNextApprovalStatus(t *testing.T) {
+	statuses := []entities.ApprovalStatus{
+		entities.ApprovalStatusPending,
+		entities.ApprovalStatusApproved,
+		entities.ApprovalStatusRejected,
+		entities.ApprovalStatusExpired,
+	}
+	events := []entities.ApprovalEvent{
+		entities.ApprovalEventApprove,
+		entities.ApprovalEventReject,
+		entities.ApprovalEventExpire,
+	}
+	allowed := map[entities.ApprovalStatus]map[entities.ApprovalEvent]entities.ApprovalStatus{
+		entities.ApprovalStatusPending: {
+			entities.ApprovalEventApprove: entities.ApprovalStatusApproved,
+			entities.ApprovalEventReject:  entities.ApprovalStatusRejected,
+			entities.ApprovalEventExpire:  entities.ApprovalStatusExpired,
+		},
+	}
+
+	// Every status/event pair, including the ones that must be refused
+	for _, status := range statuses {
+		for _, event := range events {
+			t.Run(string(status)+" "+string(event), func(t *testing.T) {
+				next, err := entities.NextApprovalStatus(status, event)
+
+				want, ok := allowed[status][event]
+				if ok {
+					assert.NoError(t, err)
+					assert.Equal(t, want, next)
+				} else {
+					assert.ErrorIs(t, err, entities.ErrInvalidApprovalTransition)
+					assert.Empty(t, next)
+				}
+			})
+		}
+	}
+
+	_, err := entities.NextApprovalStatus("UNKNOWN", entities.ApprovalEventApprove)
+	assert.ErrorIs(t, err, entities.ErrInvalidApprovalTransition)
+}
+
+func TestNewApproval(t *testing.T) {
+	tests := []struct {
+		name        string
+		transaction func(*testing.T) *entities.Transaction
+		ttl         time.Duration
+		wantErr     bool
+	}{
+		{name: "valid approval", transaction: newHeldExpense, ttl: time.Hour},
+		{name: "missing transaction", transaction: func(*testing.T) *entities.Transaction { return nil }, ttl: time.Hour, wantErr: true},
+		{
+			name: "expense not held",
+			transaction: func(t *testing.T) *entities.Transaction {
+				transaction := newHeldExpense(t)
+				transaction.AwaitingApproval = false
+				return transaction
+			},
+			ttl:     time.Hour,
+			wantErr: true,
+		},
+		{name: "zero expiry", transaction: newHeldExpense, ttl: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := tt.transaction(t)
+			approval, err := entities.NewApproval(transaction, tt.ttl, approvalNow)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, approval)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, approval.ID)
+			assert.Equal(t, "wallet-id", approval.WalletID)
+			assert.Equal(t, transaction.ID, approval.TransactionID)
+			assert.Equal(t, "teen-id", approval.RequestedBy)
+			assert.Equal(t, transaction.Amount, approval.Amount)
+			assert.Equal(t, entities.ApprovalStatusPending, approval.Status)
+			assert.Equal(t, approvalNow.Add(tt.ttl), approval.ExpiresAt)
+			assert.True(t, approval.ReviewedAt.IsZero())
+		})
+	}
+}
+
+func TestApproval_Approve(t *testing.T) {
+	tests := []struct {
+		name       string
+		prepare    func(*entities.Approval)
+		reviewerID string
+		at         time.Time
+		wantErr    error
+		wantStatus entities.ApprovalStatus
+	}{
+		{
+			name:       "owner approves pending request",
+			prepare:    func(a *entities.Approval) {},
+			reviewerID: "parent-id",
+			at:         approvalNow.Add(time.Hour),
+			wantStatus: entities.ApprovalStatusApproved,
+		},
+		{
+			name:       "requester cannot approve own expense",
+			prepare:    func(a *entities.Approval) {},
+			reviewerID: "teen-id",
+			at:         approvalNow.Add(time.Hour),
+			wantErr:    entities.ErrSelfApproval,
+			wantStatus: entities.ApprovalStatusPending,
+		},
+		{
+			name:       "only the assigned reviewer can approve",
+			prepare:    func(a *entities.Approval) { _ = a.AssignReviewer("parent-id") },
+			reviewerID: "owner-id",
+			at:         approvalNow.Add(time.Hour),
+			wantErr:    entities.ErrNotReviewer,
+			wantStatus: entities.ApprovalStatusPending,
+		},
+		{
+			name:       "assigned reviewer approves",
+			prepare:    func(a *entities.Approval) { _ = a.AssignReviewer("parent-id") },
+			reviewerID: "parent-id",
+			at:         approvalNow.Add(time.Hour),
+			wantStatus: entities.ApprovalStatusApproved,
+		},
+		{
+			name:       "overdue request expires instead",
+			prepare:    func(a *entities.Approval) {},
+			reviewerID: "parent-id",
+			at:         approvalNow.Add(48 * time.Hour),
+			wantErr:    entities.ErrApprovalExpired,
+			wantStatus: entities.ApprovalStatusExpired,
+		},
+		{
+			name:       "already rejected",
+			prepare:    func(a *entities.Approval) { _ = a.Reject("parent-id", "no", approvalNow) },
+			reviewerID: "other-parent-id",
+			at:         approvalNow.Add(time.Hour),
+			wantErr:    entities.ErrInvalidApprovalTransition,
+			wantStatus: entities.ApprovalStatusRejected,
+		},
+		{
+			name:       "already approved",
+			prepare:    func(a *entities.Approval) { _ = a.Approve("parent-id", "", approvalNow) },
+			reviewerID: "other-parent-id",
+			at:         approvalNow.Add(time.Hour),
+			wantErr:    entities.ErrInvalidApprovalTransition,
+			wantStatus: entities.ApprovalStatusApproved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approval := newPendingApproval(t)
+			tt.prepare(approval)
+
+			err := approval.Approve(tt.reviewerID, "ok", tt.at)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.reviewerID, approval.ReviewedBy)
+				assert.Equal(t, tt.at, approval.ReviewedAt)
+				assert.Equal(t, "ok", approval.Comment)
+			}
+			assert.Equal(t, tt.wantStatus, approval.Status)
+		})
+	}
+}
+
+func TestApproval_AssignReviewer(t *testing.T) {
+	t.Run("assigns the reviewer", func(t *testing.T) {
+		approval := newPendingApproval(t)
+
+		require.NoError(t, approval.AssignReviewer("parent-id"))
+		assert.Equal(t, "parent-id", approval.ReviewerID)
+	})
+
+	t.Run("requester cannot review own expense", func(t *testing.T) {
+		approval := newPendingApproval(t)
+
+		assert.ErrorIs(t, approval.AssignReviewer("teen-id"), entities.ErrSelfApproval)
+		assert.Empty(t, approval.ReviewerID)
+	})
+
+	t.Run("reviewer is required", func(t *testing.T) {
+		assert.Error(t, newPendingApproval(t).AssignReviewer(""))
+	})
+}
+
+func TestApproval_CanReview(t *testing.T) {
+	owner := &entities.WalletMember{UserID: "owner-id", Role: entities.WalletRoleOwner}
+	parent := &entities.WalletMember{UserID: "parent-id", Role: entities.WalletRoleMember}
+
+	t.Run("any owner reviews unassigned requests", func(t *testing.T) {
+		approval := newPendingApproval(t)
+
+		assert.True(t, approval.CanReview(owner))
+		assert.False(t, approval.CanReview(parent))
+	})
+
+	t.Run("only the assigned reviewer reviews assigned requests", func(t *testing.T) {
+		approval := newPendingApproval(t)
+		require.NoError(t, approval.AssignReviewer("parent-id"))
+
+		assert.False(t, approval.CanReview(owner))
+		assert.True(t, approval.CanReview(parent))
+	})
+}
+
+func TestApproval_Reject(t *testing.T) {
+	t.Run("rejects with comment", func(t *testing.T) {
+		approval := newPendingApproval(t)
+
+		err := approval.Reject("parent-id", "  too expensive  ", approvalNow.Add(time.Hour))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.ApprovalStatusRejected, approval.Status)
+		assert.Equal(t, "too expensive", approval.Comment)
+		assert.Equal(t, "parent-id", approval.ReviewedBy)
+	})
+
+	t.Run("comment is required", func(t *testing.T) {
+		approval := newPendingApproval(t)
+
+		err := approval.Reject("parent-id", " ", approvalNow.Add(time.Hour))
+
+		assert.ErrorIs(t, err, entities.ErrRejectionCommentRequired)
+		assert.True(t, approval.IsPending())
+	})
+
+	t.Run("reviewer is required", func(t *testing.T) {
+		approval := newPendingApproval(t)
+
+		err := approval.Reject("", "no", approvalNow.Add(time.Hour))
+
+		assert.Error(t, err)
+		assert.True(t, approval.IsPending())
+	})
+
+	t.Run("expired request cannot be rejected", func(t *testing.T) {
+		approval := newPendingApproval(t)
+		require.NoError(t, approval.Expire(approvalNow.Add(72*time.Hour)))
+
+		err := approval.Reject("parent-id", "no", approvalNow.Add(73*time.Hour))
+
+		assert.ErrorIs(t, err, entities.ErrInvalidApprovalTransition)
+		assert.Equal(t, entities.ApprovalStatusExpired, approval.Status)
+	})
+}
+
+func TestApproval_Expire(t *testing.T) {
+	t.Run("overdue request expires", func(t *testing.T) {
+		approval := newPendingApproval(t)
+		at := approvalNow.Add(48 * time.Hour)
+
+		assert.True(t, approval.IsOverdue(at))
+		assert.NoError(t, approval.Expire(at))
+		assert.Equal(t, entities.ApprovalStatusExpired, approval.Status)
+		assert.Equal(t, at, approval.ReviewedAt)
+		assert.Empty(t, approval.ReviewedBy)
+		assert.False(t, approval.IsOverdue(at))
+	})
+
+	t.Run("request within its window cannot expire", func(t *testing.T) {
+		approval := newPendingApproval(t)
+
+		err := approval.Expire(approvalNow.Add(time.Hour))
+
+		assert.ErrorIs(t, err, entities.ErrInvalidApprovalTransition)
+		assert.True(t, approval.IsPending())
+	})
+
+	t.Run("reviewed request cannot expire", func(t *testing.T) {
+		approval := newPendingApproval(t)
+		require.NoError(t, approval.Approve("parent-id", "", approvalNow))
+
+		err := approval.Expire(approvalNow.Add(72 * time.Hour))
+
+		assert.ErrorIs(t, err, entities.ErrInvalidApprovalTransition)
+		assert.Equal(t, entities.ApprovalStatusApproved, approval.Status)
+	})
+}
+
+func TestRequiresApproval(t *testing.T) {
+	threshold, _ := money.Parse("200.00", "BRL")
+
+	tests := []struct {
+		name    string
+		amount  string
+		code    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "below threshold", amount: "199.99", code: "BRL", want: false},
+		{name: "at threshold", amount: "200.00", code: "BRL", want: false},
+		{name: "above threshold", amount: "200.01", code: "BRL", want: true},
+		{name: "negative expense above threshold", amount: "-250.00", code: "BRL", want: true},
+		{name: "other currency", amount: "500.00", code: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := money.Parse(tt.amount, tt.code)
+			require.NoError(t, err)
+
+			got, err := entities.RequiresApproval(amount, threshold)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewApprovalPolicy(t *testing.T) {
+	_, err := entities.NewApprovalPolicy(brl(t, "200.00"), 0)
+	assert.Error(t, err)
+
+	negative, _ := money.Parse("-1.00", "BRL")
+	_, err = entities.NewApprovalPolicy(negative, time.Hour)
+	assert.Error(t, err)
+
+	policy, err := entities.NewApprovalPolicy(brl(t, "200.00"), time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, policy.Enabled())
+
+	disabled, err := entities.NewApprovalPolicy(money.Money{}, time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, disabled.Enabled())
+}
+
+func TestTransaction_HoldForApproval(t *testing.T) {
+	t.Run("posted expense goes back to pending", func(t *testing.T) {
+		transaction := newHeldExpense(t)
+
+		assert.True(t, transaction.AwaitingApproval)
+		assert.Equal(t, entities.TransactionStatusPending, transaction.Status)
+	})
+
+	t.Run("scheduled expense stays scheduled", func(t *testing.T) {
+		transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, brl(t, "500.00"), time.Now().AddDate(0, 0, 7), "Console", "", "teen-id")
+		require.NoError(t, err)
+
+		require.NoError(t, transaction.HoldForApproval())
+		assert.Equal(t, entities.TransactionStatusScheduled, transaction.Status)
+	})
+
+	t.Run("income cannot be held", func(t *testing.T) {
+		transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeIncome, brl(t, "500.00"), time.Now(), "Salary", "", "teen-id")
+		require.NoError(t, err)
+
+		assert.Error(t, transaction.HoldForApproval())
+		assert.False(t, transaction.AwaitingApproval)
+	})
+
+	t.Run("held expense cannot clear", func(t *testing.T) {
+		transaction := newHeldExpense(t)
+
+		assert.ErrorIs(t, transaction.SetStatus(entities.TransactionStatusCleared), entities.ErrAwaitingApproval)
+		assert.Equal(t, entities.TransactionStatusPending, transaction.Status)
+	})
+
+	t.Run("held expense keeps its amount and type", func(t *testing.T) {
+		transaction := newHeldExpense(t)
+
+		err := transaction.Update(transaction.Type, brl(t, "100.00"), transaction.Date, "Console", "")
+		assert.ErrorIs(t, err, entities.ErrAwaitingApproval)
+		err = transaction.Update(entities.TransactionTypeIncome, transaction.Amount, transaction.Date, "Console", "")
+		assert.ErrorIs(t, err, entities.ErrAwaitingApproval)
+		assert.NoError(t, transaction.Update(transaction.Type, transaction.Amount, transaction.Date, "Game console", "games-id"))
+	})
+
+	t.Run("held expense cannot move wallets", func(t *testing.T) {
+		transaction := newHeldExpense(t)
+
+		assert.ErrorIs(t, transaction.MoveTo("other-wallet-id"), entities.ErrAwaitingApproval)
+	})
+}
+
+func TestTransaction_Release(t *testing.T) {
+	t.Run("approved expense clears", func(t *testing.T) {
+		transaction := newHeldExpense(t)
+
+		require.NoError(t, transaction.Release(entities.ApprovalStatusApproved))
+		assert.False(t, transaction.AwaitingApproval)
+		assert.False(t, transaction.IsDeleted)
+		assert.Equal(t, entities.TransactionStatusCleared, transaction.Status)
+	})
+
+	for _, status := range []entities.ApprovalStatus{entities.ApprovalStatusRejected, entities.ApprovalStatusExpired} {
+		t.Run(string(status)+" expense is deleted", func(t *testing.T) {
+			transaction := newHeldExpense(t)
+
+			require.NoError(t, transaction.Release(status))
+			assert.False(t, transaction.AwaitingApproval)
+			assert.True(t, transaction.IsDeleted)
+		})
+	}
+
+	t.Run("pending request does not release", func(t *testing.T) {
+		transaction := newHeldExpense(t)
+
+		assert.ErrorIs(t, transaction.Release(entities.ApprovalStatusPending), entities.ErrInvalidApprovalTransition)
+		assert.True(t, transaction.AwaitingApproval)
+	})
+
+	t.Run("expense not held", func(t *testing.T) {
+		transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, brl(t, "10.00"), time.Now(), "Snack", "", "teen-id")
+		require.NoError(t, err)
+
+		assert.Error(t, transaction.Release(entities.ApprovalStatusApproved))
+	})
+}
@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAttachmentSize is the largest file that can be attached, in bytes.
+	MaxAttachmentSize = 10 << 20
+
+	maxAttachmentNameLength = 255
+	defaultAttachmentName   = "attachment"
+)
+
+// attachmentContentTypes are the receipt and document formats accepted, as
+// sniffed from the content rather than taken from the client.
+var attachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/gif":       true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+}
+
+// Attachment is a receipt or document kept in file storage for a
+// transaction. Checksum is the hex-encoded SHA-256 of the content.
+type Attachment struct {
+	ID            string
+	TransactionID string
+	WalletID      string
+	FileName      string
+	ContentType   string
+	Size          int64
+	Checksum      string
+	StorageKey    string
+	ThumbnailKey  string
+	UploadedBy    string
+	CreatedAt     time.Time
+}
+
+func NewAttachment(
+	walletID string,
+	transactionID string,
+	fileName string,
+	contentType string,
+	size int64,
+	checksum string,
+	uploadedBy string,
+) (*Attachment, error) {
+	if walletID == "" || transactionID == "" {
+		return nil, fmt.Errorf("wallet and transaction are required")
+	}
+
+	if !attachmentContentTypes[contentType] {
+		return nil, fmt.Errorf("files of type %s cannot be attached", contentType)
+	}
+
+	if size <= 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	if size > MaxAttachmentSize {
+		return nil, fmt.Errorf("file must be at most %d MB", MaxAttachmentSize>>20)
+	}
+
+	if len(checksum) != 64 {
+		return nil, fmt.Errorf("checksum must be a hex-encoded SHA-256")
+	}
+
+	if uploadedBy == "" {
+		return nil, fmt.Errorf("uploader is required")
+	}
+
+	id := uuid.NewString()
+	return &Attachment{
+		ID:            id,
+		TransactionID: transactionID,
+		WalletID:      walletID,
+		FileName:      cleanFileName(fileName),
+		ContentType:   contentType,
+		Size:          size,
+		Checksum:      checksum,
+		StorageKey:    "attachments/" + walletID + "/" + transactionID + "/" + id,
+		UploadedBy:    uploadedBy,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// IsImage reports whether a thumbnail can be made of the attachment.
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// ThumbnailStorageKey is where the thumbnail of an image is stored, next to
+// the file itself.
+func (a *Attachment) ThumbnailStorageKey() string {
+	return a.StorageKey + ".thumbnail.jpg"
+}
+
+// SetThumbnail records that a thumbnail was stored at ThumbnailStorageKey.
+func (a *Attachment) SetThumbnail() {
+	a.ThumbnailKey = a.ThumbnailStorageKey()
+}
+
+func (a *Attachment) HasThumbnail() bool {
+	return a.ThumbnailKey != ""
+}
+
+// StorageKeys lists every object stored for the attachment.
+func (a *Attachment) StorageKeys() []string {
+	if a.HasThumbnail() {
+		return []string{a.StorageKey, a.ThumbnailKey}
+	}
+	return []string{a.StorageKey}
+}
+
+// cleanFileName keeps the base name of what the client sent, without control
+// characters, so it is safe to show and to send back in a download.
+func cleanFileName(fileName string) string {
+	if i := strings.LastIndexAny(fileName, `/\`); i >= 0 {
+		fileName = fileName[i+1:]
+	}
+
+	fileName = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, fileName))
+
+	if len(fileName) > maxAttachmentNameLength {
+		fileName = strings.ToValidUTF8(fileName[:maxAttachmentNameLength], "")
+	}
+
+	if fileName == "" || fileName == "." || fileName == ".." {
+		return defaultAttachmentName
+	}
+	return fileName
+}
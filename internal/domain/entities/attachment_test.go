@@ -0,0 +1,82 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func TestNewAttachment(t *testing.T) {
+	t.Run("valid attachment", func(t *testing.T) {
+		attachment, err := entities.NewAttachment("wallet-id", "transaction-id", "receipt.pdf", "application/pdf", 1024, testChecksum, "user-id")
+
+		require.NoError(t, err)
+		assert.Equal(t, "receipt.pdf", attachment.FileName)
+		assert.Equal(t, "attachments/wallet-id/transaction-id/"+attachment.ID, attachment.StorageKey)
+		assert.False(t, attachment.IsImage())
+		assert.Equal(t, []string{attachment.StorageKey}, attachment.StorageKeys())
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		size        int64
+		checksum    string
+	}{
+		{name: "unsupported type", contentType: "text/html", size: 1024, checksum: testChecksum},
+		{name: "empty file", contentType: "application/pdf", size: 0, checksum: testChecksum},
+		{name: "too large", contentType: "application/pdf", size: entities.MaxAttachmentSize + 1, checksum: testChecksum},
+		{name: "invalid checksum", contentType: "application/pdf", size: 1024, checksum: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment, err := entities.NewAttachment("wallet-id", "transaction-id", "receipt.pdf", tt.contentType, tt.size, tt.checksum, "user-id")
+
+			assert.Error(t, err)
+			assert.Nil(t, attachment)
+		})
+	}
+}
+
+func TestNewAttachment_FileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		want     string
+	}{
+		{name: "unix path", fileName: "/home/ana/receipt.png", want: "receipt.png"},
+		{name: "windows path", fileName: `C:\Users\ana\receipt.png`, want: "receipt.png"},
+		{name: "control characters and quotes", fileName: "re\"ceipt\n.png", want: "receipt.png"},
+		{name: "empty", fileName: "  ", want: "attachment"},
+		{name: "dot dot", fileName: "..", want: "attachment"},
+		{name: "too long", fileName: strings.Repeat("a", 300), want: strings.Repeat("a", 255)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment, err := entities.NewAttachment("wallet-id", "transaction-id", tt.fileName, "image/png", 1024, testChecksum, "user-id")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, attachment.FileName)
+		})
+	}
+}
+
+func TestAttachment_SetThumbnail(t *testing.T) {
+	attachment, err := entities.NewAttachment("wallet-id", "transaction-id", "receipt.png", "image/png", 1024, testChecksum, "user-id")
+	require.NoError(t, err)
+
+	assert.True(t, attachment.IsImage())
+	assert.False(t, attachment.HasThumbnail())
+
+	attachment.SetThumbnail()
+
+	assert.True(t, attachment.HasThumbnail())
+	assert.Equal(t, []string{attachment.StorageKey, attachment.StorageKey + ".thumbnail.jpg"}, attachment.StorageKeys())
+}
@@ -0,0 +1,276 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// Debt is an amount DebtorID owes CreditorID.
+type Debt struct {
+	DebtorID   string
+	CreditorID string
+	Amount     money.Money
+}
+
+// MemberBalance is where a wallet member stands in one currency: positive
+// when the others owe them, negative when they owe the others.
+type MemberBalance struct {
+	UserID string
+	Net    money.Money
+}
+
+type debtPair struct {
+	first, second, currency string
+}
+
+// NetDebts cancels out debts between the same two members in the same
+// currency, leaving at most one debt per pair, ordered by currency, debtor
+// and creditor. Settled pairs are left out.
+func NetDebts(debts []*Debt) ([]*Debt, error) {
+	// Amounts are owed by first to second; negative means the other way
+	netted := make(map[debtPair]money.Money)
+	for _, debt := range debts {
+		pair := debtPair{debt.DebtorID, debt.CreditorID, debt.Amount.Currency().Code}
+		amount := debt.Amount
+		if pair.first > pair.second {
+			pair.first, pair.second = pair.second, pair.first
+			negated, err := amount.Negate()
+			if err != nil {
+				return nil, err
+			}
+			amount = negated
+		}
+
+		current, ok := netted[pair]
+		if !ok {
+			netted[pair] = amount
+			continue
+		}
+		sum, err := current.Add(amount)
+		if err != nil {
+			return nil, err
+		}
+		netted[pair] = sum
+	}
+
+	result := make([]*Debt, 0, len(netted))
+	for pair, amount := range netted {
+		switch {
+		case amount.IsPositive():
+			result = append(result, &Debt{DebtorID: pair.first, CreditorID: pair.second, Amount: amount})
+		case amount.IsNegative():
+			positive, err := amount.Negate()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, &Debt{DebtorID: pair.second, CreditorID: pair.first, Amount: positive})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Amount.Currency().Code != b.Amount.Currency().Code {
+			return a.Amount.Currency().Code < b.Amount.Currency().Code
+		}
+		if a.DebtorID != b.DebtorID {
+			return a.DebtorID < b.DebtorID
+		}
+		return a.CreditorID < b.CreditorID
+	})
+	return result, nil
+}
+
+// MemberBalances adds up debts into the net balance of every member involved,
+// per currency, ordered by currency and member. The balances of each
+// currency add up to zero.
+func MemberBalances(debts []*Debt) ([]*MemberBalance, error) {
+	type memberCurrency struct {
+		userID, currency string
+	}
+
+	nets := make(map[memberCurrency]money.Money)
+	add := func(userID string, amount money.Money) error {
+		key := memberCurrency{userID, amount.Currency().Code}
+		current, ok := nets[key]
+		if !ok {
+			nets[key] = amount
+			return nil
+		}
+		sum, err := current.Add(amount)
+		if err != nil {
+			return err
+		}
+		nets[key] = sum
+		return nil
+	}
+
+	for _, debt := range debts {
+		if err := add(debt.CreditorID, debt.Amount); err != nil {
+			return nil, err
+		}
+		owed, err := debt.Amount.Negate()
+		if err != nil {
+			return nil, err
+		}
+		if err := add(debt.DebtorID, owed); err != nil {
+			return nil, err
+		}
+	}
+
+	balances := make([]*MemberBalance, 0, len(nets))
+	for key, net := range nets {
+		balances = append(balances, &MemberBalance{UserID: key.userID, Net: net})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		a, b := balances[i], balances[j]
+		if a.Net.Currency().Code != b.Net.Currency().Code {
+			return a.Net.Currency().Code < b.Net.Currency().Code
+		}
+		return a.UserID < b.UserID
+	})
+	return balances, nil
+}
+
+// SharedBalances shows where the members of a wallet stand on shared
+// expenses: each member's net balance, and who owes whom once debts between
+// the same two members cancel out.
+type SharedBalances struct {
+	Members []*MemberBalance
+	Debts   []*Debt
+}
+
+func NewSharedBalances(debts []*Debt) (*SharedBalances, error) {
+	members, err := MemberBalances(debts)
+	if err != nil {
+		return nil, err
+	}
+
+	netted, err := NetDebts(debts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SharedBalances{Members: members, Debts: netted}, nil
+}
+
+// StatusTotal adds up the transactions of a wallet in one status and
+// currency, keeping money in and money out apart. The expenses held for
+// approval get totals of their own, with AwaitingApproval set, and are left
+// out of the others.
+type StatusTotal struct {
+	Status           TransactionStatus
+	AwaitingApproval bool
+	Inflow           money.Money
+	Outflow          money.Money
+}
+
+// WalletBalance is the balance of a wallet in one currency. Current counts
+// cleared and reconciled transactions. Available also takes out pending
+// outflows, which are already committed, but not pending inflows, which
+// cannot be spent yet. Projected counts every transaction, scheduled ones
+// included. PendingApproval adds up the expenses held for an owner's
+// review, which only Projected counts, since they may still be rejected.
+//
+// A balance converted into another currency adds up every currency the
+// wallet holds. Conversions records, for each of them, how its current
+// balance was converted; the other figures use the same rate.
+type WalletBalance struct {
+	Current         money.Money
+	Available       money.Money
+	Projected       money.Money
+	PendingApproval money.Money
+	Conversions     []*Conversion
+}
+
+// NewWalletBalances turns status totals into a balance per currency,
+// ordered by currency.
+func NewWalletBalances(totals []*StatusTotal) ([]*WalletBalance, error) {
+	balances := make(map[string]*WalletBalance)
+	for _, total := range totals {
+		code := total.Inflow.Currency().Code
+		balance, ok := balances[code]
+		if !ok {
+			zero, err := money.Zero(code)
+			if err != nil {
+				return nil, err
+			}
+			balance = &WalletBalance{Current: zero, Available: zero, Projected: zero, PendingApproval: zero}
+			balances[code] = balance
+		}
+
+		net, err := total.Inflow.Subtract(total.Outflow)
+		if err != nil {
+			return nil, err
+		}
+
+		if balance.Projected, err = balance.Projected.Add(net); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case total.AwaitingApproval:
+			if balance.PendingApproval, err = balance.PendingApproval.Add(total.Outflow); err != nil {
+				return nil, err
+			}
+		case total.Status.IsPosted():
+			if balance.Current, err = balance.Current.Add(net); err != nil {
+				return nil, err
+			}
+			if balance.Available, err = balance.Available.Add(net); err != nil {
+				return nil, err
+			}
+		case total.Status == TransactionStatusPending:
+			if balance.Available, err = balance.Available.Subtract(total.Outflow); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := make([]*WalletBalance, 0, len(balances))
+	for _, balance := range balances {
+		result = append(result, balance)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Current.Currency().Code < result[j].Current.Currency().Code
+	})
+	return result, nil
+}
+
+// BalanceSnapshot is the current balance of a wallet in one currency at the
+// end of AsOf: the net of its cleared and reconciled transactions dated up
+// to that day. A converted snapshot adds up every currency the wallet held,
+// and Conversions records how each of them was converted.
+type BalanceSnapshot struct {
+	WalletID    string
+	AsOf        time.Time
+	Balance     money.Money
+	Conversions []*Conversion
+}
+
+// StatusTotalDrift is a running total of a wallet that no longer matches the
+// one recomputed from its transactions.
+type StatusTotalDrift struct {
+	WalletID   string
+	Stored     *StatusTotal
+	Recomputed *StatusTotal
+}
+
+// SnapshotDrift is a balance snapshot that no longer matches the balance
+// recomputed from the transactions of its wallet.
+type SnapshotDrift struct {
+	Stored     *BalanceSnapshot
+	Recomputed *BalanceSnapshot
+}
+
+// BalanceDrifts is every stored balance figure found out of step with the
+// transactions it was derived from.
+type BalanceDrifts struct {
+	Totals    []*StatusTotalDrift
+	Snapshots []*SnapshotDrift
+}
+
+func (d *BalanceDrifts) Count() int {
+	return len(d.Totals) + len(d.Snapshots)
+}
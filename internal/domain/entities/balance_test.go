@@ -0,0 +1,79 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSharedBalances(t *testing.T) {
+	usd, err := money.Parse("5.00", "USD")
+	require.NoError(t, err)
+
+	debts := []*entities.Debt{
+		{DebtorID: "bia", CreditorID: "ana", Amount: brl(t, "30.00")},
+		{DebtorID: "ana", CreditorID: "bia", Amount: brl(t, "10.00")},
+		{DebtorID: "caio", CreditorID: "ana", Amount: brl(t, "30.00")},
+		{DebtorID: "caio", CreditorID: "bia", Amount: brl(t, "5.00")},
+		{DebtorID: "bia", CreditorID: "caio", Amount: brl(t, "5.00")},
+		{DebtorID: "ana", CreditorID: "caio", Amount: usd},
+	}
+
+	balances, err := entities.NewSharedBalances(debts)
+	require.NoError(t, err)
+
+	got := make([]string, 0, len(balances.Debts))
+	for _, debt := range balances.Debts {
+		got = append(got, debt.DebtorID+">"+debt.CreditorID+" "+debt.Amount.String())
+	}
+	assert.Equal(t, []string{"bia>ana BRL 20.00", "caio>ana BRL 30.00", "ana>caio USD 5.00"}, got)
+
+	nets := make(map[string]string)
+	for _, member := range balances.Members {
+		nets[member.Net.Currency().Code+" "+member.UserID] = member.Net.Decimal()
+	}
+	assert.Equal(t, map[string]string{
+		"BRL ana":  "50.00",
+		"BRL bia":  "-20.00",
+		"BRL caio": "-30.00",
+		"USD ana":  "-5.00",
+		"USD caio": "5.00",
+	}, nets)
+}
+
+func TestNewSharedBalances_Empty(t *testing.T) {
+	balances, err := entities.NewSharedBalances(nil)
+	require.NoError(t, err)
+	assert.Empty(t, balances.Members)
+	assert.Empty(t, balances.Debts)
+}
+
+func TestNewWalletBalances(t *testing.T) {
+	usd := func(value string) money.Money {
+		amount, err := money.Parse(value, "USD")
+		require.NoError(t, err)
+		return amount
+	}
+
+	totals := []*entities.StatusTotal{
+		{Status: entities.TransactionStatusReconciled, Inflow: brl(t, "1000.00"), Outflow: brl(t, "200.00")},
+		{Status: entities.TransactionStatusCleared, Inflow: brl(t, "50.00"), Outflow: brl(t, "150.00")},
+		{Status: entities.TransactionStatusPending, Inflow: brl(t, "30.00"), Outflow: brl(t, "80.00")},
+		{Status: entities.TransactionStatusPending, AwaitingApproval: true, Inflow: brl(t, "0.00"), Outflow: brl(t, "100.00")},
+		{Status: entities.TransactionStatusScheduled, Inflow: brl(t, "0.00"), Outflow: brl(t, "400.00")},
+		{Status: entities.TransactionStatusScheduled, Inflow: usd("20.00"), Outflow: usd("0.00")},
+	}
+
+	balances, err := entities.NewWalletBalances(totals)
+	require.NoError(t, err)
+
+	got := make([]string, 0, len(balances))
+	for _, balance := range balances {
+		got = append(got, balance.Current.String()+" / "+balance.Available.Decimal()+" / "+balance.Projected.Decimal()+
+			" / "+balance.PendingApproval.Decimal())
+	}
+	assert.Equal(t, []string{"BRL 700.00 / 620.00 / 150.00 / 100.00", "USD 0.00 / 0.00 / 20.00 / 0.00"}, got)
+}
@@ -0,0 +1,193 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultCategoryColor  = "#9E9E9E"
+	maxCategoryNameLength = 50
+	maxCategoryIconLength = 50
+)
+
+var categoryColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+var (
+	ErrInvalidCategoryParent = errors.New("invalid parent category")
+	ErrCategoryTypeMismatch  = errors.New("category type does not match")
+)
+
+// Category groups a user's transactions. Categories nest one level deep
+// (Food > Restaurants) and each one is either an income or an expense
+// category.
+type Category struct {
+	ID        string
+	UserID    string
+	ParentID  string
+	Name      string
+	Type      TransactionType
+	Icon      string
+	Color     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCategory(userID, name string, categoryType TransactionType, icon, color string) (*Category, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("category owner is required")
+	}
+
+	if _, err := NewTransactionType(string(categoryType)); err != nil {
+		return nil, err
+	}
+
+	category := &Category{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Type:      categoryType,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := category.Update(name, icon, color); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// Update replaces the name, icon and colour. An empty colour falls back to
+// DefaultCategoryColor.
+func (c *Category) Update(name, icon, color string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("category name is required")
+	}
+
+	if len(name) > maxCategoryNameLength {
+		return fmt.Errorf("category name must be at most %d characters", maxCategoryNameLength)
+	}
+
+	icon = strings.TrimSpace(icon)
+	if len(icon) > maxCategoryIconLength {
+		return fmt.Errorf("category icon must be at most %d characters", maxCategoryIconLength)
+	}
+
+	color = strings.TrimSpace(color)
+	if color == "" {
+		color = DefaultCategoryColor
+	}
+	if !categoryColorPattern.MatchString(color) {
+		return fmt.Errorf("category color must be a hex colour like #1E88E5")
+	}
+
+	c.Name = name
+	c.Icon = icon
+	c.Color = strings.ToUpper(color)
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetParent nests the category under parent, or makes it top level when
+// parent is nil. Only top-level categories of the same owner and type can
+// be parents.
+func (c *Category) SetParent(parent *Category) error {
+	if parent == nil {
+		c.ParentID = ""
+		c.UpdatedAt = time.Now()
+		return nil
+	}
+
+	switch {
+	case parent.ID == c.ID:
+		return fmt.Errorf("%w: a category cannot be its own parent", ErrInvalidCategoryParent)
+	case parent.UserID != c.UserID:
+		return fmt.Errorf("%w: parent belongs to another user", ErrInvalidCategoryParent)
+	case parent.ParentID != "":
+		return fmt.Errorf("%w: categories can only be nested one level deep", ErrInvalidCategoryParent)
+	case parent.Type != c.Type:
+		return fmt.Errorf("%w: parent is an %s category", ErrCategoryTypeMismatch, strings.ToLower(string(parent.Type)))
+	}
+
+	c.ParentID = parent.ID
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+func (c *Category) IsTopLevel() bool {
+	return c.ParentID == ""
+}
+
+// Accepts reports whether a transaction of the given type can use this
+// category.
+func (c *Category) Accepts(transactionType TransactionType) bool {
+	return c.Type == transactionType
+}
+
+// CanMergeInto checks that the category's transactions and children can be
+// moved to target before the category is removed.
+func (c *Category) CanMergeInto(target *Category) error {
+	switch {
+	case target.ID == c.ID:
+		return fmt.Errorf("%w: cannot merge a category into itself", ErrInvalidCategoryParent)
+	case target.UserID != c.UserID:
+		return fmt.Errorf("%w: target belongs to another user", ErrInvalidCategoryParent)
+	case target.ParentID == c.ID:
+		return fmt.Errorf("%w: cannot merge a category into one of its children", ErrInvalidCategoryParent)
+	case target.Type != c.Type:
+		return fmt.Errorf("%w: target is an %s category", ErrCategoryTypeMismatch, strings.ToLower(string(target.Type)))
+	}
+	return nil
+}
+
+type defaultCategory struct {
+	name     string
+	icon     string
+	color    string
+	children []string
+}
+
+var defaultCategories = map[TransactionType][]defaultCategory{
+	TransactionTypeExpense: {
+		{name: "Food", icon: "utensils", color: "#E53935", children: []string{"Groceries", "Restaurants"}},
+		{name: "Housing", icon: "home", color: "#8E24AA", children: []string{"Rent", "Utilities", "Maintenance"}},
+		{name: "Transportation", icon: "car", color: "#1E88E5", children: []string{"Fuel", "Public transport"}},
+		{name: "Health", icon: "heart", color: "#43A047", children: []string{"Pharmacy", "Doctor"}},
+		{name: "Education", icon: "book", color: "#FDD835"},
+		{name: "Entertainment", icon: "film", color: "#FB8C00"},
+		{name: "Shopping", icon: "shopping-bag", color: "#6D4C41"},
+		{name: "Other expenses", icon: "tag", color: DefaultCategoryColor},
+	},
+	TransactionTypeIncome: {
+		{name: "Salary", icon: "briefcase", color: "#2E7D32"},
+		{name: "Freelance", icon: "laptop", color: "#00897B"},
+		{name: "Investments", icon: "trending-up", color: "#3949AB"},
+		{name: "Gifts", icon: "gift", color: "#D81B60"},
+		{name: "Other income", icon: "tag", color: DefaultCategoryColor},
+	},
+}
+
+// DefaultCategories builds the starter set of categories for a new user.
+// Parents come before their children so the slice can be inserted in order.
+func DefaultCategories(userID string) []*Category {
+	var categories []*Category
+	for _, categoryType := range []TransactionType{TransactionTypeExpense, TransactionTypeIncome} {
+		for _, def := range defaultCategories[categoryType] {
+			parent, _ := NewCategory(userID, def.name, categoryType, def.icon, def.color)
+			categories = append(categories, parent)
+
+			for _, childName := range def.children {
+				child, _ := NewCategory(userID, childName, categoryType, def.icon, def.color)
+				_ = child.SetParent(parent)
+				categories = append(categories, child)
+			}
+		}
+	}
+	return categories
+}
@@ -0,0 +1,135 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCategory(t *testing.T, userID, name string, categoryType entities.TransactionType) *entities.Category {
+	t.Helper()
+	category, err := entities.NewCategory(userID, name, categoryType, "", "")
+	require.NoError(t, err)
+	return category
+}
+
+func TestNewCategory(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		categoryName string
+		categoryType entities.TransactionType
+		color        string
+		wantColor    string
+		wantErr      bool
+	}{
+		{name: "valid category", userID: "user-id", categoryName: " Food ", categoryType: entities.TransactionTypeExpense, color: "#e53935", wantColor: "#E53935"},
+		{name: "default colour", userID: "user-id", categoryName: "Food", categoryType: entities.TransactionTypeExpense, wantColor: entities.DefaultCategoryColor},
+		{name: "missing owner", userID: "", categoryName: "Food", categoryType: entities.TransactionTypeExpense, wantErr: true},
+		{name: "missing name", userID: "user-id", categoryName: "  ", categoryType: entities.TransactionTypeExpense, wantErr: true},
+		{name: "name too long", userID: "user-id", categoryName: strings.Repeat("a", 51), categoryType: entities.TransactionTypeExpense, wantErr: true},
+		{name: "invalid type", userID: "user-id", categoryName: "Food", categoryType: "TRANSFER", wantErr: true},
+		{name: "invalid colour", userID: "user-id", categoryName: "Food", categoryType: entities.TransactionTypeExpense, color: "red", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := entities.NewCategory(tt.userID, tt.categoryName, tt.categoryType, "utensils", tt.color)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, category)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, category.ID)
+			assert.Equal(t, "Food", category.Name)
+			assert.Equal(t, tt.wantColor, category.Color)
+			assert.True(t, category.IsTopLevel())
+		})
+	}
+}
+
+func TestCategory_SetParent(t *testing.T) {
+	food := newCategory(t, "user-id", "Food", entities.TransactionTypeExpense)
+	restaurants := newCategory(t, "user-id", "Restaurants", entities.TransactionTypeExpense)
+	require.NoError(t, restaurants.SetParent(food))
+
+	tests := []struct {
+		name    string
+		parent  *entities.Category
+		wantErr error
+	}{
+		{name: "top level parent", parent: food},
+		{name: "make top level", parent: nil},
+		{name: "own parent", parent: nil, wantErr: entities.ErrInvalidCategoryParent},
+		{name: "child as parent", parent: restaurants, wantErr: entities.ErrInvalidCategoryParent},
+		{name: "another user's parent", parent: newCategory(t, "other-id", "Food", entities.TransactionTypeExpense), wantErr: entities.ErrInvalidCategoryParent},
+		{name: "parent of another type", parent: newCategory(t, "user-id", "Salary", entities.TransactionTypeIncome), wantErr: entities.ErrCategoryTypeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category := newCategory(t, "user-id", "Delivery", entities.TransactionTypeExpense)
+			parent := tt.parent
+			if tt.name == "own parent" {
+				parent = category
+			}
+
+			err := category.SetParent(parent)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, category.IsTopLevel())
+				return
+			}
+			assert.NoError(t, err)
+			if parent == nil {
+				assert.True(t, category.IsTopLevel())
+			} else {
+				assert.Equal(t, parent.ID, category.ParentID)
+			}
+		})
+	}
+}
+
+func TestCategory_CanMergeInto(t *testing.T) {
+	food := newCategory(t, "user-id", "Food", entities.TransactionTypeExpense)
+	restaurants := newCategory(t, "user-id", "Restaurants", entities.TransactionTypeExpense)
+	require.NoError(t, restaurants.SetParent(food))
+	transport := newCategory(t, "user-id", "Transport", entities.TransactionTypeExpense)
+	salary := newCategory(t, "user-id", "Salary", entities.TransactionTypeIncome)
+	foreign := newCategory(t, "other-id", "Food", entities.TransactionTypeExpense)
+
+	assert.NoError(t, restaurants.CanMergeInto(transport))
+	assert.NoError(t, transport.CanMergeInto(restaurants))
+	assert.ErrorIs(t, food.CanMergeInto(food), entities.ErrInvalidCategoryParent)
+	assert.ErrorIs(t, food.CanMergeInto(restaurants), entities.ErrInvalidCategoryParent)
+	assert.ErrorIs(t, food.CanMergeInto(foreign), entities.ErrInvalidCategoryParent)
+	assert.ErrorIs(t, food.CanMergeInto(salary), entities.ErrCategoryTypeMismatch)
+}
+
+func TestDefaultCategories(t *testing.T) {
+	categories := entities.DefaultCategories("user-id")
+	require.NotEmpty(t, categories)
+
+	seen := make(map[string]*entities.Category)
+	types := make(map[entities.TransactionType]int)
+	for _, category := range categories {
+		assert.Equal(t, "user-id", category.UserID)
+		if !category.IsTopLevel() {
+			parent, ok := seen[category.ParentID]
+			require.True(t, ok, "parent of %s must come first", category.Name)
+			assert.Equal(t, parent.Type, category.Type)
+			assert.True(t, parent.IsTopLevel())
+		}
+		seen[category.ID] = category
+		types[category.Type]++
+	}
+
+	assert.Positive(t, types[entities.TransactionTypeIncome])
+	assert.Positive(t, types[entities.TransactionTypeExpense])
+}
@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxCommentLength = 2000
+
+// mentionPattern matches a mention of a user in a comment, written as @
+// followed by their ID so that it survives name changes; clients show the
+// name in its place.
+var mentionPattern = regexp.MustCompile(`@([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})\b`)
+
+// Comment is a message about a transaction. A reply points to the comment
+// it answers with ParentID. Deleted comments are kept, like users, so the
+// replies to them still have a thread; only their body is hidden.
+type Comment struct {
+	ID            string
+	TransactionID string
+	WalletID      string
+	ParentID      string
+	AuthorID      string
+	Body          string
+	EditedAt      time.Time
+	IsDeleted     bool
+	DeletedAt     time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// CommentEdit keeps the body a comment had before an edit made at EditedAt.
+type CommentEdit struct {
+	ID        string
+	CommentID string
+	Body      string
+	EditedAt  time.Time
+}
+
+func NewComment(walletID, transactionID, parentID, authorID, body string) (*Comment, error) {
+	if walletID == "" || transactionID == "" {
+		return nil, fmt.Errorf("wallet and transaction are required")
+	}
+
+	if authorID == "" {
+		return nil, fmt.Errorf("comment author is required")
+	}
+
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Comment{
+		ID:            uuid.NewString(),
+		TransactionID: transactionID,
+		WalletID:      walletID,
+		ParentID:      parentID,
+		AuthorID:      authorID,
+		Body:          body,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// Edit replaces the body and returns the edit holding the previous one, or
+// nil when the body did not change.
+func (c *Comment) Edit(body string) (*CommentEdit, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == c.Body {
+		return nil, nil
+	}
+
+	now := time.Now()
+	edit := &CommentEdit{
+		ID:        uuid.NewString(),
+		CommentID: c.ID,
+		Body:      c.Body,
+		EditedAt:  now,
+	}
+	c.Body = body
+	c.EditedAt = now
+	c.UpdatedAt = now
+	return edit, nil
+}
+
+// IsEdited reports whether the body was changed after the comment was
+// posted.
+func (c *Comment) IsEdited() bool {
+	return !c.EditedAt.IsZero()
+}
+
+func (c *Comment) Delete() {
+	now := time.Now()
+	c.IsDeleted = true
+	c.DeletedAt = now
+	c.UpdatedAt = now
+}
+
+// Mentions returns the IDs of the users mentioned in the body, sorted and
+// without repeats.
+func (c *Comment) Mentions() []string {
+	matches := mentionPattern.FindAllStringSubmatch(c.Body, -1)
+	ids := make([]string, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, strings.ToLower(match[1]))
+	}
+	return UniqueIDs(ids)
+}
+
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("comment body is required")
+	}
+	if len([]rune(body)) > maxCommentLength {
+		return "", fmt.Errorf("comment must be at most %d characters", maxCommentLength)
+	}
+	return body, nil
+}
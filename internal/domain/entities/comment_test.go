@@ -0,0 +1,63 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mentionedID = "0b7e3f1c-5d2a-4c8e-9f10-2a3b4c5d6e7f"
+
+func TestNewComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "trimmed body", body: "  What was this?  "},
+		{name: "empty body", body: "   ", wantErr: true},
+		{name: "too long", body: strings.Repeat("a", 2001), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment, err := entities.NewComment("wallet-id", "transaction-id", "", "user-id", tt.body)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "What was this?", comment.Body)
+			assert.False(t, comment.IsEdited())
+		})
+	}
+}
+
+func TestComment_Edit(t *testing.T) {
+	comment, err := entities.NewComment("wallet-id", "transaction-id", "", "user-id", "Pizza")
+	require.NoError(t, err)
+
+	edit, err := comment.Edit("Pizza")
+	require.NoError(t, err)
+	assert.Nil(t, edit)
+	assert.False(t, comment.IsEdited())
+
+	edit, err = comment.Edit("Pizza and drinks")
+	require.NoError(t, err)
+	assert.Equal(t, "Pizza", edit.Body)
+	assert.Equal(t, comment.ID, edit.CommentID)
+	assert.Equal(t, "Pizza and drinks", comment.Body)
+	assert.True(t, comment.IsEdited())
+}
+
+func TestComment_Mentions(t *testing.T) {
+	comment, err := entities.NewComment("wallet-id", "transaction-id", "", "user-id",
+		"@"+strings.ToUpper(mentionedID)+" was this yours? cc @"+mentionedID+", not @someone")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{mentionedID}, comment.Mentions())
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// ExchangeRate is how many units of QuoteCurrency one unit of BaseCurrency
+// buys on Date, as published by Source.
+type ExchangeRate struct {
+	ID            string
+	BaseCurrency  money.Currency
+	QuoteCurrency money.Currency
+	Rate          *big.Rat
+	Date          time.Time
+	Source        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func NewExchangeRate(
+	baseCurrency string,
+	quoteCurrency string,
+	rate *big.Rat,
+	date time.Time,
+	source string,
+) (*ExchangeRate, error) {
+	base, err := money.LookupCurrency(baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := money.LookupCurrency(quoteCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	if base.Code == quote.Code {
+		return nil, fmt.Errorf("base and quote currencies must differ")
+	}
+
+	if rate == nil || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("exchange rate must be positive")
+	}
+
+	if date.IsZero() {
+		return nil, fmt.Errorf("rate date is required")
+	}
+
+	if source == "" {
+		return nil, fmt.Errorf("rate source is required")
+	}
+
+	return &ExchangeRate{
+		ID:            uuid.NewString(),
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          new(big.Rat).Set(rate),
+		Date:          truncateToDay(date),
+		Source:        source,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}, nil
+}
+
+// Inverse returns the rate for the opposite direction (quote to base).
+func (r *ExchangeRate) Inverse() *ExchangeRate {
+	return &ExchangeRate{
+		ID:            r.ID,
+		BaseCurrency:  r.QuoteCurrency,
+		QuoteCurrency: r.BaseCurrency,
+		Rate:          new(big.Rat).Inv(r.Rate),
+		Date:          r.Date,
+		Source:        r.Source,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+// Conversion is an amount expressed in another currency together with the
+// rate that produced it, so every converted figure can be audited.
+type Conversion struct {
+	Original  money.Money
+	Converted money.Money
+	Rate      *big.Rat
+	RateDate  time.Time
+	Source    string
+}
+
+func truncateToDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
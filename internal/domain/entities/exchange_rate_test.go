@@ -0,0 +1,64 @@
+package entities_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExchangeRate(t *testing.T) {
+	date := time.Date(2026, 10, 16, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		base    string
+		quote   string
+		rate    *big.Rat
+		date    time.Time
+		source  string
+		wantErr bool
+	}{
+		{name: "valid rate", base: "EUR", quote: "BRL", rate: big.NewRat(605, 100), date: date, source: "ECB"},
+		{name: "unknown base currency", base: "XXX", quote: "BRL", rate: big.NewRat(1, 1), date: date, source: "ECB", wantErr: true},
+		{name: "unknown quote currency", base: "EUR", quote: "XXX", rate: big.NewRat(1, 1), date: date, source: "ECB", wantErr: true},
+		{name: "same currency", base: "EUR", quote: "EUR", rate: big.NewRat(1, 1), date: date, source: "ECB", wantErr: true},
+		{name: "zero rate", base: "EUR", quote: "BRL", rate: big.NewRat(0, 1), date: date, source: "ECB", wantErr: true},
+		{name: "negative rate", base: "EUR", quote: "BRL", rate: big.NewRat(-1, 1), date: date, source: "ECB", wantErr: true},
+		{name: "nil rate", base: "EUR", quote: "BRL", rate: nil, date: date, source: "ECB", wantErr: true},
+		{name: "missing date", base: "EUR", quote: "BRL", rate: big.NewRat(1, 1), source: "ECB", wantErr: true},
+		{name: "missing source", base: "EUR", quote: "BRL", rate: big.NewRat(1, 1), date: date, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := entities.NewExchangeRate(tt.base, tt.quote, tt.rate, tt.date, tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, rate)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, rate.ID)
+			assert.Equal(t, tt.base, rate.BaseCurrency.Code)
+			assert.Equal(t, tt.quote, rate.QuoteCurrency.Code)
+			assert.Equal(t, 0, rate.Rate.Cmp(tt.rate))
+			assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), rate.Date)
+		})
+	}
+}
+
+func TestExchangeRateInverse(t *testing.T) {
+	rate, err := entities.NewExchangeRate("EUR", "BRL", big.NewRat(6, 1), time.Now(), "ECB")
+	assert.NoError(t, err)
+
+	inverse := rate.Inverse()
+
+	assert.Equal(t, "BRL", inverse.BaseCurrency.Code)
+	assert.Equal(t, "EUR", inverse.QuoteCurrency.Code)
+	assert.Equal(t, 0, inverse.Rate.Cmp(big.NewRat(1, 6)))
+	assert.Equal(t, 0, rate.Rate.Cmp(big.NewRat(6, 1)), "original rate must not change")
+}
@@ -0,0 +1,213 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// fullPercentage is 100% in the hundredths of a percent percentage shares
+// are weighted in.
+const fullPercentage = 10000
+
+const maxShareParticipants = 50
+
+var ErrShareSumMismatch = errors.New("shares must add up to the expense")
+
+type ShareMethod string
+
+const (
+	ShareEqually      ShareMethod = "EQUAL"
+	ShareByPercentage ShareMethod = "PERCENTAGE"
+	ShareByShares     ShareMethod = "SHARES"
+	ShareByAmount     ShareMethod = "EXACT"
+)
+
+func NewShareMethod(method string) (ShareMethod, error) {
+	formattedMethod := ShareMethod(strings.ToUpper(method))
+	switch formattedMethod {
+	case ShareEqually, ShareByPercentage, ShareByShares, ShareByAmount:
+		return formattedMethod, nil
+	default:
+		return "", fmt.Errorf("invalid share method: %s", method)
+	}
+}
+
+// ParseSharePercentage reads a percentage such as "33.34" into the
+// hundredths of a percent used as the weight of percentage shares.
+func ParseSharePercentage(value string) (int64, error) {
+	percentage, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return 0, fmt.Errorf("invalid percentage: %s", value)
+	}
+
+	weight := percentage.Mul(percentage, big.NewRat(100, 1))
+	if !weight.IsInt() || !weight.Num().IsInt64() {
+		return 0, fmt.Errorf("percentage must have at most two decimals: %s", value)
+	}
+	return weight.Num().Int64(), nil
+}
+
+// ExpenseShare is the part of a shared expense one member is responsible
+// for. Weight is the member's number of shares, or their percentage in
+// hundredths of a percent; it is unused when sharing equally or by exact
+// amounts. Amount is the member's part of the expense.
+type ExpenseShare struct {
+	UserID string
+	Weight int64
+	Amount money.Money
+}
+
+// ExpenseSharing records who paid an expense and how its cost is shared
+// among the wallet members.
+type ExpenseSharing struct {
+	PaidBy string
+	Method ShareMethod
+	Shares []ExpenseShare
+}
+
+// ShareExpense divides the transaction amount among the members of shares
+// with method; paidBy does not have to take part. Shares are kept ordered by
+// user, and minor units that do not divide evenly go one by one to the first
+// members in that order, so the same input always gives the same amounts.
+func (t *Transaction) ShareExpense(paidBy string, method ShareMethod, shares []ExpenseShare) error {
+	if t.Type != TransactionTypeExpense {
+		return fmt.Errorf("only expenses can be shared")
+	}
+
+	if paidBy == "" {
+		return fmt.Errorf("payer is required")
+	}
+
+	if _, err := NewShareMethod(string(method)); err != nil {
+		return err
+	}
+
+	if len(shares) == 0 || len(shares) > maxShareParticipants {
+		return fmt.Errorf("a shared expense must have between 1 and %d members", maxShareParticipants)
+	}
+
+	sorted := make([]ExpenseShare, len(shares))
+	copy(sorted, shares)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UserID < sorted[j].UserID })
+
+	for i, share := range sorted {
+		if share.UserID == "" {
+			return fmt.Errorf("share member is required")
+		}
+		if i > 0 && sorted[i-1].UserID == share.UserID {
+			return fmt.Errorf("member %s is listed more than once", share.UserID)
+		}
+	}
+
+	if err := allocateShares(t.Amount, method, sorted); err != nil {
+		return err
+	}
+
+	t.Sharing = &ExpenseSharing{
+		PaidBy: paidBy,
+		Method: method,
+		Shares: sorted,
+	}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// allocateShares sets the amount of every share according to method.
+func allocateShares(total money.Money, method ShareMethod, shares []ExpenseShare) error {
+	if method == ShareByAmount {
+		amounts := make([]money.Money, 0, len(shares))
+		for _, share := range shares {
+			if !share.Amount.SameCurrency(total) {
+				return fmt.Errorf("share amounts must be in %s", total.Currency().Code)
+			}
+			if share.Amount.IsNegative() {
+				return fmt.Errorf("share amounts cannot be negative")
+			}
+			amounts = append(amounts, share.Amount)
+		}
+
+		sum, err := money.Sum(total.Currency().Code, amounts...)
+		if err != nil {
+			return err
+		}
+		if !sum.Equal(total) {
+			return fmt.Errorf("%w: shares total %s, expense is %s", ErrShareSumMismatch, sum, total)
+		}
+		return nil
+	}
+
+	weights := make([]int64, 0, len(shares))
+	var weightSum int64
+	for _, share := range shares {
+		weight := share.Weight
+		switch method {
+		case ShareEqually:
+			weight = 1
+		case ShareByPercentage:
+			if weight < 0 || weight > fullPercentage {
+				return fmt.Errorf("percentages must be between 0 and 100")
+			}
+		case ShareByShares:
+			if weight < 0 || weight > 1000000 {
+				return fmt.Errorf("share counts must be between 0 and 1000000")
+			}
+		}
+		weights = append(weights, weight)
+		weightSum += weight
+	}
+
+	if method == ShareByPercentage && weightSum != fullPercentage {
+		return fmt.Errorf("%w: percentages must add up to 100", ErrShareSumMismatch)
+	}
+
+	amounts, err := total.Allocate(weights...)
+	if err != nil {
+		return err
+	}
+	for i := range shares {
+		shares[i].Amount = amounts[i]
+		if method == ShareEqually {
+			shares[i].Weight = 0
+		}
+	}
+	return nil
+}
+
+// ClearSharing makes the transaction a personal expense again.
+func (t *Transaction) ClearSharing() {
+	t.Sharing = nil
+	t.UpdatedAt = time.Now()
+}
+
+// IsShared reports whether the cost of the transaction is shared among
+// wallet members.
+func (t *Transaction) IsShared() bool {
+	return t.Sharing != nil
+}
+
+// Debts lists what each member owes the payer of a shared expense. The
+// payer's own share is not a debt.
+func (t *Transaction) Debts() []*Debt {
+	if !t.IsShared() {
+		return nil
+	}
+
+	debts := make([]*Debt, 0, len(t.Sharing.Shares))
+	for _, share := range t.Sharing.Shares {
+		if share.UserID == t.Sharing.PaidBy || share.Amount.IsZero() {
+			continue
+		}
+		debts = append(debts, &Debt{
+			DebtorID:   share.UserID,
+			CreditorID: t.Sharing.PaidBy,
+			Amount:     share.Amount,
+		})
+	}
+	return debts
+}
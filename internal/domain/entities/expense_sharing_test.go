@@ -0,0 +1,186 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSharedTestTransaction(t *testing.T, value string) *entities.Transaction {
+	t.Helper()
+	amount, err := money.Parse(value, "BRL")
+	require.NoError(t, err)
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, time.Now(), "Dinner", "", "ana")
+	require.NoError(t, err)
+	return transaction
+}
+
+func brl(t *testing.T, value string) money.Money {
+	t.Helper()
+	amount, err := money.Parse(value, "BRL")
+	require.NoError(t, err)
+	return amount
+}
+
+func TestParseSharePercentage(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "50", want: 5000},
+		{value: "33.34", want: 3334},
+		{value: "0.5", want: 50},
+		{value: "33.333", wantErr: true},
+		{value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			weight, err := entities.ParseSharePercentage(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, weight)
+		})
+	}
+}
+
+func TestTransaction_ShareExpense(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   string
+		method  entities.ShareMethod
+		shares  func(t *testing.T) []entities.ExpenseShare
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "equally with remainder to the first members by id",
+			total:  "100.00",
+			method: entities.ShareEqually,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return []entities.ExpenseShare{{UserID: "caio"}, {UserID: "ana"}, {UserID: "bia"}}
+			},
+			want: map[string]string{"ana": "33.34", "bia": "33.33", "caio": "33.33"},
+		},
+		{
+			name:   "by percentage",
+			total:  "200.00",
+			method: entities.ShareByPercentage,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return []entities.ExpenseShare{{UserID: "ana", Weight: 2500}, {UserID: "bia", Weight: 7500}}
+			},
+			want: map[string]string{"ana": "50.00", "bia": "150.00"},
+		},
+		{
+			name:   "percentages short of 100",
+			total:  "200.00",
+			method: entities.ShareByPercentage,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return []entities.ExpenseShare{{UserID: "ana", Weight: 2500}, {UserID: "bia", Weight: 7400}}
+			},
+			wantErr: true,
+		},
+		{
+			name:   "by shares",
+			total:  "10.00",
+			method: entities.ShareByShares,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return []entities.ExpenseShare{{UserID: "ana", Weight: 1}, {UserID: "bia", Weight: 2}}
+			},
+			want: map[string]string{"ana": "3.34", "bia": "6.66"},
+		},
+		{
+			name:   "by exact amounts",
+			total:  "10.00",
+			method: entities.ShareByAmount,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return []entities.ExpenseShare{{UserID: "ana", Amount: brl(t, "2.50")}, {UserID: "bia", Amount: brl(t, "7.50")}}
+			},
+			want: map[string]string{"ana": "2.50", "bia": "7.50"},
+		},
+		{
+			name:   "exact amounts off by a cent",
+			total:  "10.00",
+			method: entities.ShareByAmount,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return []entities.ExpenseShare{{UserID: "ana", Amount: brl(t, "2.50")}, {UserID: "bia", Amount: brl(t, "7.49")}}
+			},
+			wantErr: true,
+		},
+		{
+			name:   "member listed twice",
+			total:  "10.00",
+			method: entities.ShareEqually,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return []entities.ExpenseShare{{UserID: "ana"}, {UserID: "ana"}}
+			},
+			wantErr: true,
+		},
+		{
+			name:   "no members",
+			total:  "10.00",
+			method: entities.ShareEqually,
+			shares: func(t *testing.T) []entities.ExpenseShare {
+				return nil
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := newSharedTestTransaction(t, tt.total)
+
+			err := transaction.ShareExpense("ana", tt.method, tt.shares(t))
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, transaction.IsShared())
+				return
+			}
+
+			require.NoError(t, err)
+			got := make(map[string]string)
+			for i, share := range transaction.Sharing.Shares {
+				got[share.UserID] = share.Amount.Decimal()
+				if i > 0 {
+					assert.Less(t, transaction.Sharing.Shares[i-1].UserID, share.UserID)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTransaction_ShareExpense_Income(t *testing.T) {
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeIncome, brl(t, "10.00"), time.Now(), "", "", "ana")
+	require.NoError(t, err)
+
+	assert.Error(t, transaction.ShareExpense("ana", entities.ShareEqually, []entities.ExpenseShare{{UserID: "ana"}}))
+}
+
+func TestTransaction_Debts(t *testing.T) {
+	transaction := newSharedTestTransaction(t, "90.00")
+	assert.Empty(t, transaction.Debts())
+
+	require.NoError(t, transaction.ShareExpense("ana", entities.ShareEqually,
+		[]entities.ExpenseShare{{UserID: "ana"}, {UserID: "bia"}, {UserID: "caio"}}))
+
+	debts := transaction.Debts()
+	require.Len(t, debts, 2)
+	for _, debt := range debts {
+		assert.Equal(t, "ana", debt.CreditorID)
+		assert.Equal(t, "30.00", debt.Amount.Decimal())
+	}
+
+	transaction.ClearSharing()
+	assert.False(t, transaction.IsShared())
+}
@@ -0,0 +1,171 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HouseholdRole string
+
+const (
+	HouseholdRoleOwner  HouseholdRole = "OWNER"
+	HouseholdRoleAdmin  HouseholdRole = "ADMIN"
+	HouseholdRoleMember HouseholdRole = "MEMBER"
+)
+
+var (
+	ErrAlreadyHouseholdMember = errors.New("user is already a member of the household")
+	ErrNotHouseholdMember     = errors.New("user is not a member of the household")
+	ErrLastHouseholdOwner     = errors.New("a household must keep at least one owner")
+)
+
+func NewHouseholdRole(role string) (HouseholdRole, error) {
+	formattedRole := HouseholdRole(strings.ToUpper(role))
+	if !formattedRole.Valid() {
+		return "", fmt.Errorf("invalid household role: %s", role)
+	}
+	return formattedRole, nil
+}
+
+func (r HouseholdRole) Valid() bool {
+	switch r {
+	case HouseholdRoleOwner, HouseholdRoleAdmin, HouseholdRoleMember:
+		return true
+	default:
+		return false
+	}
+}
+
+// WalletRole is the access the role gives to the household wallets:
+// owners and admins manage them, members use them.
+func (r HouseholdRole) WalletRole() WalletRole {
+	if r == HouseholdRoleMember {
+		return WalletRoleMember
+	}
+	return WalletRoleOwner
+}
+
+type HouseholdMember struct {
+	HouseholdID string
+	UserID      string
+	Role        HouseholdRole
+	JoinedAt    time.Time
+}
+
+// CanManageMembers reports whether the member may invite, remove or change
+// the role of other members.
+func (m *HouseholdMember) CanManageMembers() bool {
+	return m.Role == HouseholdRoleOwner || m.Role == HouseholdRoleAdmin
+}
+
+type Household struct {
+	ID        string
+	Name      string
+	Members   []*HouseholdMember
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewHousehold(name string, ownerID string) (*Household, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("household name is required")
+	}
+
+	if ownerID == "" {
+		return nil, fmt.Errorf("household owner is required")
+	}
+
+	household := &Household{
+		ID:        uuid.NewString(),
+		Name:      name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	household.Members = []*HouseholdMember{{
+		HouseholdID: household.ID,
+		UserID:      ownerID,
+		Role:        HouseholdRoleOwner,
+		JoinedAt:    household.CreatedAt,
+	}}
+
+	return household, nil
+}
+
+// Member returns the membership of userID, or nil if they do not belong to
+// the household.
+func (h *Household) Member(userID string) *HouseholdMember {
+	for _, member := range h.Members {
+		if member.UserID == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+func (h *Household) AddMember(userID string, role HouseholdRole) (*HouseholdMember, error) {
+	if h.Member(userID) != nil {
+		return nil, ErrAlreadyHouseholdMember
+	}
+
+	member := &HouseholdMember{
+		HouseholdID: h.ID,
+		UserID:      userID,
+		Role:        role,
+		JoinedAt:    time.Now(),
+	}
+	h.Members = append(h.Members, member)
+	h.UpdatedAt = time.Now()
+
+	return member, nil
+}
+
+func (h *Household) ChangeRole(userID string, role HouseholdRole) error {
+	member := h.Member(userID)
+	if member == nil {
+		return ErrNotHouseholdMember
+	}
+
+	if member.Role == HouseholdRoleOwner && role != HouseholdRoleOwner && h.ownerCount() == 1 {
+		return ErrLastHouseholdOwner
+	}
+
+	member.Role = role
+	h.UpdatedAt = time.Now()
+	return nil
+}
+
+func (h *Household) RemoveMember(userID string) error {
+	member := h.Member(userID)
+	if member == nil {
+		return ErrNotHouseholdMember
+	}
+
+	if member.Role == HouseholdRoleOwner && h.ownerCount() == 1 {
+		return ErrLastHouseholdOwner
+	}
+
+	members := h.Members[:0]
+	for _, m := range h.Members {
+		if m.UserID != userID {
+			members = append(members, m)
+		}
+	}
+	h.Members = members
+	h.UpdatedAt = time.Now()
+	return nil
+}
+
+func (h *Household) ownerCount() int {
+	count := 0
+	for _, member := range h.Members {
+		if member.Role == HouseholdRoleOwner {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,119 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHouseholdRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		want    entities.HouseholdRole
+		wantErr bool
+	}{
+		{name: "owner", role: "OWNER", want: entities.HouseholdRoleOwner},
+		{name: "admin lower case", role: "admin", want: entities.HouseholdRoleAdmin},
+		{name: "member", role: "MEMBER", want: entities.HouseholdRoleMember},
+		{name: "invalid role", role: "GUEST", wantErr: true},
+		{name: "empty role", role: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entities.NewHouseholdRole(tt.role)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewHousehold(t *testing.T) {
+	tests := []struct {
+		name    string
+		hhName  string
+		ownerID string
+		wantErr bool
+	}{
+		{name: "valid household", hhName: "Silva family", ownerID: "owner-id"},
+		{name: "blank name", hhName: "   ", ownerID: "owner-id", wantErr: true},
+		{name: "missing owner", hhName: "Silva family", ownerID: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			household, err := entities.NewHousehold(tt.hhName, tt.ownerID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, household)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, household.ID)
+			require.Len(t, household.Members, 1)
+			assert.Equal(t, tt.ownerID, household.Members[0].UserID)
+			assert.Equal(t, household.ID, household.Members[0].HouseholdID)
+			assert.Equal(t, entities.HouseholdRoleOwner, household.Members[0].Role)
+		})
+	}
+}
+
+func TestHousehold_AddMember(t *testing.T) {
+	household, _ := entities.NewHousehold("Silva family", "owner-id")
+
+	member, err := household.AddMember("kid-id", entities.HouseholdRoleMember)
+	assert.NoError(t, err)
+	assert.Equal(t, household.ID, member.HouseholdID)
+	assert.Same(t, member, household.Member("kid-id"))
+
+	_, err = household.AddMember("kid-id", entities.HouseholdRoleAdmin)
+	assert.ErrorIs(t, err, entities.ErrAlreadyHouseholdMember)
+}
+
+func TestHousehold_ChangeRole(t *testing.T) {
+	household, _ := entities.NewHousehold("Silva family", "owner-id")
+	_, _ = household.AddMember("partner-id", entities.HouseholdRoleMember)
+
+	assert.ErrorIs(t, household.ChangeRole("owner-id", entities.HouseholdRoleAdmin), entities.ErrLastHouseholdOwner)
+	assert.ErrorIs(t, household.ChangeRole("stranger-id", entities.HouseholdRoleAdmin), entities.ErrNotHouseholdMember)
+
+	assert.NoError(t, household.ChangeRole("partner-id", entities.HouseholdRoleOwner))
+	assert.NoError(t, household.ChangeRole("owner-id", entities.HouseholdRoleAdmin))
+	assert.Equal(t, entities.HouseholdRoleAdmin, household.Member("owner-id").Role)
+	assert.True(t, household.Member("owner-id").CanManageMembers())
+}
+
+func TestHousehold_RemoveMember(t *testing.T) {
+	household, _ := entities.NewHousehold("Silva family", "owner-id")
+	_, _ = household.AddMember("kid-id", entities.HouseholdRoleMember)
+
+	assert.ErrorIs(t, household.RemoveMember("owner-id"), entities.ErrLastHouseholdOwner)
+	assert.ErrorIs(t, household.RemoveMember("stranger-id"), entities.ErrNotHouseholdMember)
+
+	assert.NoError(t, household.RemoveMember("kid-id"))
+	assert.Nil(t, household.Member("kid-id"))
+	assert.Len(t, household.Members, 1)
+}
+
+func TestHouseholdMember_CanManageMembers(t *testing.T) {
+	assert.True(t, (&entities.HouseholdMember{Role: entities.HouseholdRoleOwner}).CanManageMembers())
+	assert.True(t, (&entities.HouseholdMember{Role: entities.HouseholdRoleAdmin}).CanManageMembers())
+	assert.False(t, (&entities.HouseholdMember{Role: entities.HouseholdRoleMember}).CanManageMembers())
+}
+
+func TestHouseholdRole_WalletRole(t *testing.T) {
+	assert.True(t, entities.HouseholdRoleAdmin.Valid())
+	assert.False(t, entities.HouseholdRole("GUEST").Valid())
+
+	assert.Equal(t, entities.WalletRoleOwner, entities.HouseholdRoleOwner.WalletRole())
+	assert.Equal(t, entities.WalletRoleOwner, entities.HouseholdRoleAdmin.WalletRole())
+	assert.Equal(t, entities.WalletRoleMember, entities.HouseholdRoleMember.WalletRole())
+}
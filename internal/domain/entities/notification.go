@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	// NotificationSpendingLimitNear warns a wallet owner that a member has
+	// used most of a spending limit.
+	NotificationSpendingLimitNear NotificationType = "SPENDING_LIMIT_NEAR"
+	// NotificationMention tells a member that a comment mentions them.
+	NotificationMention NotificationType = "MENTION"
+)
+
+// Notification is an in-app message for one user. Data holds what the
+// client needs to link to its subject, such as the limit or transaction it
+// is about.
+type Notification struct {
+	ID        string
+	UserID    string
+	Type      NotificationType
+	WalletID  string
+	Message   string
+	Data      map[string]interface{}
+	ReadAt    time.Time
+	CreatedAt time.Time
+}
+
+func NewNotification(
+	userID string,
+	notificationType NotificationType,
+	walletID string,
+	message string,
+	data map[string]interface{},
+) (*Notification, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("notification recipient is required")
+	}
+
+	if message == "" {
+		return nil, fmt.Errorf("notification message is required")
+	}
+
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	return &Notification{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Type:      notificationType,
+		WalletID:  walletID,
+		Message:   message,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsRead reports whether the recipient has marked the notification read.
+func (n *Notification) IsRead() bool {
+	return !n.ReadAt.IsZero()
+}
+
+// NewSpendingLimitNotification warns ownerID that a member has used used of
+// limit.
+func NewSpendingLimitNotification(ownerID string, limit *SpendingLimit, used string) (*Notification, error) {
+	data := map[string]interface{}{
+		"limit_id": limit.ID,
+		"user_id":  limit.UserID,
+		"period":   limit.Period,
+		"limit":    limit.Amount.Decimal(),
+		"currency": limit.Amount.Currency().Code,
+		"used":     used,
+	}
+	if limit.CategoryID != "" {
+		data["category_id"] = limit.CategoryID
+	}
+
+	message := fmt.Sprintf("A member has used %s of their %s %s %s limit",
+		used, limit.Amount.Decimal(), limit.Amount.Currency().Code, limitPeriodName(limit.Period))
+	return NewNotification(ownerID, NotificationSpendingLimitNear, limit.WalletID, message, data)
+}
+
+// NewMentionNotification tells userID that comment mentions them.
+func NewMentionNotification(userID string, comment *Comment) (*Notification, error) {
+	data := map[string]interface{}{
+		"comment_id":     comment.ID,
+		"transaction_id": comment.TransactionID,
+		"author_id":      comment.AuthorID,
+	}
+	return NewNotification(userID, NotificationMention, comment.WalletID, "You were mentioned in a comment", data)
+}
+
+func limitPeriodName(period LimitPeriod) string {
+	switch period {
+	case LimitPeriodDay:
+		return "daily"
+	case LimitPeriodWeek:
+		return "weekly"
+	case LimitPeriodMonth:
+		return "monthly"
+	default:
+		return "per-transaction"
+	}
+}
@@ -0,0 +1,244 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const maxPayeeNameLength = 100
+
+var ErrPayeeOwnerMismatch = errors.New("payees belong to different users")
+
+// Payee is a merchant or person a user pays or is paid by. Aliases are the
+// normalized names that map to the payee, so "UBER *TRIP 1234 SAO PAULO" and
+// "UBER BV" both find the same payee through the alias "UBER".
+type Payee struct {
+	ID                string
+	UserID            string
+	Name              string
+	DefaultCategoryID string
+	Aliases           []string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewPayee(userID, name string) (*Payee, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("payee owner is required")
+	}
+
+	payee := &Payee{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := payee.Rename(name); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+// NewPayeeFromDescription creates the payee a transaction description
+// normalizes to, named after the normalized name.
+func NewPayeeFromDescription(userID, description string) (*Payee, error) {
+	return NewPayee(userID, PayeeDisplayName(NormalizePayeeName(description)))
+}
+
+// Rename changes the name shown for the payee. The new name also becomes an
+// alias, and the old ones are kept so past descriptions still match.
+func (p *Payee) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if len(name) > maxPayeeNameLength {
+		return fmt.Errorf("payee name must be at most %d characters", maxPayeeNameLength)
+	}
+
+	alias := NormalizePayeeName(name)
+	if alias == "" {
+		return fmt.Errorf("payee name is required")
+	}
+
+	p.Name = name
+	p.addAliases(alias)
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetDefaultCategory sets the category suggested for the payee's new
+// transactions; an empty ID clears it.
+func (p *Payee) SetDefaultCategory(categoryID string) {
+	p.DefaultCategoryID = categoryID
+	p.UpdatedAt = time.Now()
+}
+
+// CanMergeInto checks that target can take over the payee's transactions.
+func (p *Payee) CanMergeInto(target *Payee) error {
+	if target.ID == p.ID {
+		return fmt.Errorf("cannot merge a payee into itself")
+	}
+	if target.UserID != p.UserID {
+		return ErrPayeeOwnerMismatch
+	}
+	return nil
+}
+
+// Absorb takes over the aliases of source, and its default category when
+// the payee has none.
+func (p *Payee) Absorb(source *Payee) {
+	p.addAliases(source.Aliases...)
+	if p.DefaultCategoryID == "" {
+		p.DefaultCategoryID = source.DefaultCategoryID
+	}
+	p.UpdatedAt = time.Now()
+}
+
+func (p *Payee) addAliases(aliases ...string) {
+	for _, alias := range aliases {
+		if !p.HasAlias(alias) {
+			p.Aliases = append(p.Aliases, alias)
+		}
+	}
+}
+
+func (p *Payee) HasAlias(alias string) bool {
+	for _, existing := range p.Aliases {
+		if existing == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// paymentProcessors put their name before the merchant's in card
+// statements, as in "PAYPAL *NETFLIX". Anywhere else, the merchant is what
+// comes before the asterisk, as in "UBER *TRIP".
+var paymentProcessors = map[string]bool{
+	"EBN":         true,
+	"IFD":         true,
+	"MERCADOPAGO": true,
+	"MP":          true,
+	"PAG":         true,
+	"PAGSEGURO":   true,
+	"PAYPAL":      true,
+	"PG":          true,
+	"PP":          true,
+	"SQ":          true,
+	"SQU":         true,
+}
+
+// payeeSuffixes are company types and state or country codes that trail
+// merchant names without telling merchants apart.
+var payeeSuffixes = map[string]bool{
+	// Company types
+	"BV": true, "CO": true, "COM": true, "CORP": true, "EIRELI": true, "EPP": true, "GMBH": true,
+	"INC": true, "LLC": true, "LTD": true, "LTDA": true, "ME": true, "SA": true,
+	// Countries
+	"BR": true, "BRA": true, "GB": true, "IE": true, "NL": true, "PT": true, "UK": true, "US": true, "USA": true,
+	// Brazilian states
+	"AC": true, "AL": true, "AM": true, "AP": true, "BA": true, "CE": true, "DF": true, "ES": true, "GO": true,
+	"MA": true, "MG": true, "MS": true, "MT": true, "PA": true, "PB": true, "PE": true, "PI": true, "PR": true,
+	"RJ": true, "RN": true, "RO": true, "RR": true, "RS": true, "SC": true, "SE": true, "SP": true, "TO": true,
+}
+
+// payeeCities trail merchant names in card statements.
+var payeeCities = [][]string{
+	{"SAO", "PAULO"}, {"RIO", "DE", "JANEIRO"}, {"BELO", "HORIZONTE"}, {"BRASILIA"}, {"CURITIBA"},
+	{"PORTO", "ALEGRE"}, {"SALVADOR"}, {"RECIFE"}, {"FORTALEZA"}, {"CAMPINAS"}, {"GOIANIA"},
+	{"FLORIANOPOLIS"}, {"MANAUS"}, {"BELEM"}, {"OSASCO"}, {"SANTOS"}, {"NITEROI"}, {"GUARULHOS"},
+	{"LISBOA"}, {"LISBON"}, {"LONDON"}, {"DUBLIN"}, {"AMSTERDAM"}, {"NEW", "YORK"}, {"SAN", "FRANCISCO"},
+	{"SEATTLE"}, {"LOS", "ANGELES"},
+}
+
+var accentFolding = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// NormalizePayeeName reduces a transaction description or payee name to the
+// key payees are matched by. It ignores case and accents, keeps the merchant
+// out of "PROCESSOR *MERCHANT" and "MERCHANT *DETAIL", and drops store
+// numbers, trailing cities, state and country codes and company types:
+// "UBER *TRIP 1234 SAO PAULO" and "Uber BV" are both "UBER".
+func NormalizePayeeName(description string) string {
+	folded, _, err := transform.String(accentFolding, description)
+	if err != nil {
+		folded = description
+	}
+	folded = strings.ToUpper(folded)
+
+	if before, after, found := strings.Cut(folded, "*"); found {
+		processor := strings.Fields(before)
+		if len(processor) == 1 && paymentProcessors[processor[0]] {
+			folded = after
+		} else if strings.TrimSpace(before) != "" {
+			folded = before
+		}
+	}
+
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&'
+	})
+
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		// Store numbers, dates and references
+		if strings.ContainsFunc(word, unicode.IsDigit) {
+			continue
+		}
+		kept = append(kept, word)
+	}
+
+	for len(kept) > 1 {
+		if city := trailingCity(kept); city > 0 && city < len(kept) {
+			kept = kept[:len(kept)-city]
+			continue
+		}
+		if payeeSuffixes[kept[len(kept)-1]] {
+			kept = kept[:len(kept)-1]
+			continue
+		}
+		break
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// trailingCity returns how many of the last words name a city, or 0.
+func trailingCity(words []string) int {
+	for _, city := range payeeCities {
+		if len(city) > len(words) {
+			continue
+		}
+		tail := words[len(words)-len(city):]
+		matches := true
+		for i := range city {
+			if tail[i] != city[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return len(city)
+		}
+	}
+	return 0
+}
+
+// PayeeDisplayName turns a normalized name into a readable one: "UBER EATS"
+// becomes "Uber Eats".
+func PayeeDisplayName(normalized string) string {
+	words := strings.Fields(strings.ToLower(normalized))
+	for i, word := range words {
+		letters := []rune(word)
+		letters[0] = unicode.ToUpper(letters[0])
+		words[i] = string(letters)
+	}
+	return strings.Join(words, " ")
+}
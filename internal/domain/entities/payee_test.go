@@ -0,0 +1,83 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePayeeName(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{description: "UBER *TRIP 1234 SAO PAULO", want: "UBER"},
+		{description: "UBER BV", want: "UBER"},
+		{description: "uber", want: "UBER"},
+		{description: "PAYPAL *NETFLIX 4029357733", want: "NETFLIX"},
+		{description: "IFD*IFOOD CLUB", want: "IFOOD CLUB"},
+		{description: "Padaria São João Ltda - São Paulo SP", want: "PADARIA SAO JOAO"},
+		{description: "AMAZON.COM.BR", want: "AMAZON"},
+		{description: "SUPERMERCADO EXTRA 1502 RIO DE JANEIRO BR", want: "SUPERMERCADO EXTRA"},
+		{description: "M&M'S WORLD LONDON", want: "M&M S WORLD"},
+		{description: "SP", want: "SP"},
+		{description: "1234 5678", want: ""},
+		{description: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, tt.want, entities.NormalizePayeeName(tt.description))
+		})
+	}
+}
+
+func TestNewPayeeFromDescription(t *testing.T) {
+	payee, err := entities.NewPayeeFromDescription("user-id", "PAG*PADARIA SÃO JOÃO 0231")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Padaria Sao Joao", payee.Name)
+	assert.Equal(t, []string{"PADARIA SAO JOAO"}, payee.Aliases)
+
+	_, err = entities.NewPayeeFromDescription("user-id", "0231")
+	assert.Error(t, err)
+}
+
+func TestPayee_Rename(t *testing.T) {
+	payee, err := entities.NewPayee("user-id", "Uber BV")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"UBER"}, payee.Aliases)
+
+	require.NoError(t, payee.Rename("Uber Eats"))
+	assert.Equal(t, "Uber Eats", payee.Name)
+	assert.Equal(t, []string{"UBER", "UBER EATS"}, payee.Aliases)
+
+	assert.Error(t, payee.Rename("  "))
+	assert.Error(t, payee.Rename(strings.Repeat("a", 101)))
+	assert.Equal(t, "Uber Eats", payee.Name)
+}
+
+func TestPayee_Merge(t *testing.T) {
+	uber, err := entities.NewPayee("user-id", "Uber")
+	require.NoError(t, err)
+	uberTrip, err := entities.NewPayee("user-id", "Uber Trip")
+	require.NoError(t, err)
+	uberTrip.SetDefaultCategory("transport-id")
+	foreign, err := entities.NewPayee("other-user-id", "Uber")
+	require.NoError(t, err)
+
+	assert.Error(t, uber.CanMergeInto(uber))
+	assert.ErrorIs(t, uber.CanMergeInto(foreign), entities.ErrPayeeOwnerMismatch)
+	require.NoError(t, uberTrip.CanMergeInto(uber))
+
+	uber.Absorb(uberTrip)
+	assert.Equal(t, []string{"UBER", "UBER TRIP"}, uber.Aliases)
+	assert.Equal(t, "transport-id", uber.DefaultCategoryID)
+
+	uber.SetDefaultCategory("travel-id")
+	uber.Absorb(uberTrip)
+	assert.Equal(t, "travel-id", uber.DefaultCategoryID, "the target keeps its own default category")
+}
@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ReconciliationStatus string
+
+const (
+	ReconciliationStatusOpen      ReconciliationStatus = "OPEN"
+	ReconciliationStatusCompleted ReconciliationStatus = "COMPLETED"
+)
+
+var (
+	ErrReconciliationCompleted = errors.New("reconciliation is already completed")
+	// ErrReconciliationUnbalanced is returned when a reconciliation would be
+	// completed while the cleared transactions do not add up to the
+	// statement balance.
+	ErrReconciliationUnbalanced = errors.New("cleared transactions do not match the statement balance")
+	ErrNotReconcilable          = errors.New("transaction cannot be reconciled in this session")
+)
+
+// Reconciliation checks a wallet against a bank statement in one currency.
+// The user ticks off the transactions the statement shows as cleared until
+// the reconciled balance they started from plus the cleared ones matches
+// the statement balance; completing it reconciles those transactions.
+type Reconciliation struct {
+	ID               string
+	WalletID         string
+	UserID           string
+	StatementDate    time.Time
+	StatementBalance money.Money
+	// OpeningBalance is the net of the wallet's transactions reconciled
+	// before this session, dated up to the statement date.
+	OpeningBalance money.Money
+	// ClearedIDs are the transactions ticked off, and Cleared their net.
+	ClearedIDs  []string
+	Cleared     money.Money
+	Status      ReconciliationStatus
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
+func NewReconciliation(
+	walletID string,
+	userID string,
+	statementDate time.Time,
+	statementBalance money.Money,
+	openingBalance money.Money,
+) (*Reconciliation, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet is required")
+	}
+
+	if userID == "" {
+		return nil, fmt.Errorf("user is required")
+	}
+
+	if statementDate.IsZero() {
+		return nil, fmt.Errorf("statement date is required")
+	}
+
+	if isFutureDate(statementDate) {
+		return nil, fmt.Errorf("statement date cannot be in the future")
+	}
+
+	if !statementBalance.SameCurrency(openingBalance) {
+		return nil, fmt.Errorf("opening balance must be in the statement currency")
+	}
+
+	cleared, err := money.Zero(statementBalance.Currency().Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reconciliation{
+		ID:               uuid.NewString(),
+		WalletID:         walletID,
+		UserID:           userID,
+		StatementDate:    truncateToDay(statementDate),
+		StatementBalance: statementBalance,
+		OpeningBalance:   openingBalance,
+		ClearedIDs:       make([]string, 0),
+		Cleared:          cleared,
+		Status:           ReconciliationStatusOpen,
+		CreatedAt:        time.Now(),
+	}, nil
+}
+
+// Currency is the currency of the statement; only transactions in it can be
+// ticked off.
+func (r *Reconciliation) Currency() string {
+	return r.StatementBalance.Currency().Code
+}
+
+// Difference is what is left to account for: the statement balance minus
+// the opening balance and the cleared transactions. It is zero once the
+// session can be completed.
+func (r *Reconciliation) Difference() (money.Money, error) {
+	reconciled, err := r.OpeningBalance.Add(r.Cleared)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return r.StatementBalance.Subtract(reconciled)
+}
+
+// IsCleared reports whether the transaction has been ticked off.
+func (r *Reconciliation) IsCleared(transactionID string) bool {
+	for _, id := range r.ClearedIDs {
+		if id == transactionID {
+			return true
+		}
+	}
+	return false
+}
+
+// Tick marks the transaction as shown cleared on the statement. It must be
+// a pending or cleared transaction of the wallet, in the statement currency
+// and dated up to the statement date. Ticking it twice does nothing.
+func (r *Reconciliation) Tick(transaction *Transaction) error {
+	if r.Status != ReconciliationStatusOpen {
+		return ErrReconciliationCompleted
+	}
+
+	if err := r.checkReconcilable(transaction); err != nil {
+		return err
+	}
+
+	if r.IsCleared(transaction.ID) {
+		return nil
+	}
+
+	cleared, err := r.Cleared.Add(transaction.SignedAmount())
+	if err != nil {
+		return err
+	}
+
+	r.ClearedIDs = append(r.ClearedIDs, transaction.ID)
+	r.Cleared = cleared
+	return nil
+}
+
+// Untick takes the transaction off the cleared ones. Unticking one that was
+// not ticked does nothing.
+func (r *Reconciliation) Untick(transaction *Transaction) error {
+	if r.Status != ReconciliationStatusOpen {
+		return ErrReconciliationCompleted
+	}
+
+	for i, id := range r.ClearedIDs {
+		if id != transaction.ID {
+			continue
+		}
+
+		cleared, err := r.Cleared.Subtract(transaction.SignedAmount())
+		if err != nil {
+			return err
+		}
+
+		r.ClearedIDs = append(r.ClearedIDs[:i], r.ClearedIDs[i+1:]...)
+		r.Cleared = cleared
+		return nil
+	}
+	return nil
+}
+
+// Complete reconciles the ticked transactions, which must be all of them,
+// once the difference is zero. From then on they are locked against casual
+// edits, and the session cannot change.
+func (r *Reconciliation) Complete(transactions []*Transaction) error {
+	if r.Status != ReconciliationStatusOpen {
+		return ErrReconciliationCompleted
+	}
+
+	if len(transactions) != len(r.ClearedIDs) {
+		return fmt.Errorf("%w: every cleared transaction is required", ErrNotReconcilable)
+	}
+
+	cleared, err := money.Zero(r.Currency())
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		if !r.IsCleared(transaction.ID) {
+			return fmt.Errorf("%w: transaction was not ticked off", ErrNotReconcilable)
+		}
+		if err := r.checkReconcilable(transaction); err != nil {
+			return err
+		}
+		if cleared, err = cleared.Add(transaction.SignedAmount()); err != nil {
+			return err
+		}
+	}
+	r.Cleared = cleared
+
+	difference, err := r.Difference()
+	if err != nil {
+		return err
+	}
+	if !difference.IsZero() {
+		return ErrReconciliationUnbalanced
+	}
+
+	now := time.Now()
+	for _, transaction := range transactions {
+		transaction.Status = TransactionStatusReconciled
+		transaction.UpdatedAt = now
+	}
+
+	r.Status = ReconciliationStatusCompleted
+	r.CompletedAt = now
+	return nil
+}
+
+func (r *Reconciliation) checkReconcilable(transaction *Transaction) error {
+	switch {
+	case transaction.WalletID != r.WalletID:
+		return fmt.Errorf("%w: transaction is in another wallet", ErrNotReconcilable)
+	case transaction.Amount.Currency().Code != r.Currency():
+		return fmt.Errorf("%w: transaction is not in the statement currency", ErrNotReconcilable)
+	case transaction.Status != TransactionStatusPending && transaction.Status != TransactionStatusCleared:
+		return fmt.Errorf("%w: only pending and cleared transactions can be ticked off", ErrNotReconcilable)
+	case transaction.AwaitingApproval:
+		return ErrAwaitingApproval
+	case truncateToDay(transaction.Date).After(r.StatementDate):
+		return fmt.Errorf("%w: transaction is dated after the statement", ErrNotReconcilable)
+	}
+	return nil
+}
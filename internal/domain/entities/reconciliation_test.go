@@ -0,0 +1,123 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var statementDate = time.Now().AddDate(0, 0, -1)
+
+func newStatementTransaction(t *testing.T, id string, transactionType entities.TransactionType, amount string) *entities.Transaction {
+	t.Helper()
+	transaction, err := entities.NewTransaction("wallet-id", transactionType, brl(t, amount), statementDate.AddDate(0, 0, -3), "Statement line", "", "user-id")
+	require.NoError(t, err)
+	transaction.ID = id
+	return transaction
+}
+
+// newOpenReconciliation opens a session against a statement of 150.00 with
+// 100.00 already reconciled.
+func newOpenReconciliation(t *testing.T) *entities.Reconciliation {
+	t.Helper()
+	reconciliation, err := entities.NewReconciliation("wallet-id", "user-id", statementDate, brl(t, "150.00"), brl(t, "100.00"))
+	require.NoError(t, err)
+	return reconciliation
+}
+
+func TestNewReconciliation(t *testing.T) {
+	_, err := entities.NewReconciliation("wallet-id", "user-id", time.Now().AddDate(0, 0, 2), brl(t, "150.00"), brl(t, "100.00"))
+	assert.Error(t, err)
+
+	_, err = entities.NewReconciliation("wallet-id", "user-id", time.Time{}, brl(t, "150.00"), brl(t, "100.00"))
+	assert.Error(t, err)
+
+	reconciliation := newOpenReconciliation(t)
+	difference, err := reconciliation.Difference()
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), difference.MinorUnits())
+	assert.Equal(t, entities.ReconciliationStatusOpen, reconciliation.Status)
+}
+
+func TestReconciliation_TickAndUntick(t *testing.T) {
+	reconciliation := newOpenReconciliation(t)
+	income := newStatementTransaction(t, "income-id", entities.TransactionTypeIncome, "80.00")
+	expense := newStatementTransaction(t, "expense-id", entities.TransactionTypeExpense, "30.00")
+
+	require.NoError(t, reconciliation.Tick(income))
+	require.NoError(t, reconciliation.Tick(expense))
+	require.NoError(t, reconciliation.Tick(income))
+
+	assert.Equal(t, []string{"income-id", "expense-id"}, reconciliation.ClearedIDs)
+	difference, err := reconciliation.Difference()
+	require.NoError(t, err)
+	assert.True(t, difference.IsZero())
+
+	require.NoError(t, reconciliation.Untick(expense))
+	assert.Equal(t, []string{"income-id"}, reconciliation.ClearedIDs)
+	difference, err = reconciliation.Difference()
+	require.NoError(t, err)
+	assert.Equal(t, int64(-3000), difference.MinorUnits())
+}
+
+func TestReconciliation_TickRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*entities.Transaction)
+	}{
+		{"another wallet", func(transaction *entities.Transaction) { transaction.WalletID = "other-wallet-id" }},
+		{"dated after the statement", func(transaction *entities.Transaction) { transaction.Date = time.Now() }},
+		{"already reconciled", func(transaction *entities.Transaction) { transaction.Status = entities.TransactionStatusReconciled }},
+		{"awaiting approval", func(transaction *entities.Transaction) { transaction.AwaitingApproval = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciliation := newOpenReconciliation(t)
+			transaction := newStatementTransaction(t, "transaction-id", entities.TransactionTypeIncome, "50.00")
+			tt.change(transaction)
+
+			assert.Error(t, reconciliation.Tick(transaction))
+			assert.Empty(t, reconciliation.ClearedIDs)
+		})
+	}
+}
+
+func TestReconciliation_Complete(t *testing.T) {
+	t.Run("reconciles the cleared transactions", func(t *testing.T) {
+		reconciliation := newOpenReconciliation(t)
+		transaction := newStatementTransaction(t, "transaction-id", entities.TransactionTypeIncome, "50.00")
+		transaction.Status = entities.TransactionStatusPending
+		require.NoError(t, reconciliation.Tick(transaction))
+
+		require.NoError(t, reconciliation.Complete([]*entities.Transaction{transaction}))
+
+		assert.Equal(t, entities.ReconciliationStatusCompleted, reconciliation.Status)
+		assert.False(t, reconciliation.CompletedAt.IsZero())
+		assert.Equal(t, entities.TransactionStatusReconciled, transaction.Status)
+		assert.ErrorIs(t, reconciliation.Tick(transaction), entities.ErrReconciliationCompleted)
+	})
+
+	t.Run("unbalanced", func(t *testing.T) {
+		reconciliation := newOpenReconciliation(t)
+		transaction := newStatementTransaction(t, "transaction-id", entities.TransactionTypeIncome, "40.00")
+		require.NoError(t, reconciliation.Tick(transaction))
+
+		err := reconciliation.Complete([]*entities.Transaction{transaction})
+
+		assert.ErrorIs(t, err, entities.ErrReconciliationUnbalanced)
+		assert.Equal(t, entities.ReconciliationStatusOpen, reconciliation.Status)
+		assert.Equal(t, entities.TransactionStatusCleared, transaction.Status)
+	})
+
+	t.Run("missing cleared transactions", func(t *testing.T) {
+		reconciliation := newOpenReconciliation(t)
+		transaction := newStatementTransaction(t, "transaction-id", entities.TransactionTypeIncome, "50.00")
+		require.NoError(t, reconciliation.Tick(transaction))
+
+		assert.ErrorIs(t, reconciliation.Complete(nil), entities.ErrNotReconcilable)
+	})
+}
@@ -0,0 +1,322 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrencePeriods bounds how many periods a rule is walked through, so
+// a schedule that never matches cannot loop forever.
+const maxRecurrencePeriods = 100000
+
+const recurrenceUntilLayout = "20060102"
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+	RecurrenceYearly  RecurrenceFrequency = "YEARLY"
+)
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RecurrenceRule is the subset of the iCalendar RRULE (RFC 5545) needed for
+// money schedules:
+//
+//	FREQ=MONTHLY;BYMONTHDAY=5              every month on day 5
+//	FREQ=MONTHLY;BYMONTHDAY=-1             last day of the month
+//	FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1
+//	                                       last business day of the month
+//	FREQ=WEEKLY;INTERVAL=2;COUNT=10        every other week, ten times
+//	FREQ=YEARLY;UNTIL=20301231             every year until the end of 2030
+//
+// Days and weekdays without BYMONTHDAY or BYDAY follow the start date. Unlike
+// RFC 5545, a month day past the end of a short month falls on its last day
+// instead of skipping the month, so rent due on the 31st is never missed.
+// Business days do not account for holidays.
+type RecurrenceRule struct {
+	Frequency   RecurrenceFrequency
+	Interval    int
+	MonthDay    int
+	Weekdays    []time.Weekday
+	SetPosition int
+	Count       int
+	Until       time.Time
+}
+
+// ParseRecurrenceRule reads a rule such as "FREQ=MONTHLY;BYMONTHDAY=5". An
+// "RRULE:" prefix is accepted.
+func ParseRecurrenceRule(value string) (RecurrenceRule, error) {
+	rule := RecurrenceRule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	if value == "" {
+		return RecurrenceRule{}, fmt.Errorf("recurrence rule is required")
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return RecurrenceRule{}, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(val)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+		case "BYMONTHDAY":
+			rule.MonthDay, err = strconv.Atoi(val)
+		case "BYDAY":
+			rule.Weekdays, err = parseRecurrenceWeekdays(val)
+		case "BYSETPOS":
+			rule.SetPosition, err = strconv.Atoi(val)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			// Only the date of a UNTIL date-time matters for daily schedules
+			if len(val) > len(recurrenceUntilLayout) {
+				val = val[:len(recurrenceUntilLayout)]
+			}
+			rule.Until, err = time.Parse(recurrenceUntilLayout, val)
+		default:
+			return RecurrenceRule{}, fmt.Errorf("unsupported recurrence rule part %s", key)
+		}
+		if err != nil {
+			return RecurrenceRule{}, fmt.Errorf("invalid %s in recurrence rule: %s", key, val)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return RecurrenceRule{}, err
+	}
+
+	return rule, nil
+}
+
+func parseRecurrenceWeekdays(value string) ([]time.Weekday, error) {
+	seen := make(map[time.Weekday]bool)
+	weekdays := make([]time.Weekday, 0, 7)
+	for _, code := range strings.Split(value, ",") {
+		weekday, ok := recurrenceWeekdays[code]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %s", code)
+		}
+		if !seen[weekday] {
+			seen[weekday] = true
+			weekdays = append(weekdays, weekday)
+		}
+	}
+	return weekdays, nil
+}
+
+func (r RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly:
+	default:
+		return fmt.Errorf("recurrence frequency must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	}
+
+	if r.Interval < 1 || r.Interval > 1000 {
+		return fmt.Errorf("recurrence interval must be between 1 and 1000")
+	}
+
+	if r.Count < 0 {
+		return fmt.Errorf("recurrence count cannot be negative")
+	}
+
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("recurrence rule cannot have both COUNT and UNTIL")
+	}
+
+	if r.Frequency != RecurrenceMonthly && (r.MonthDay != 0 || len(r.Weekdays) > 0 || r.SetPosition != 0) {
+		return fmt.Errorf("BYMONTHDAY, BYDAY and BYSETPOS are only supported on monthly rules")
+	}
+
+	if r.MonthDay != 0 && (r.MonthDay < -1 || r.MonthDay > 31) {
+		return fmt.Errorf("BYMONTHDAY must be between 1 and 31, or -1 for the last day")
+	}
+
+	if (len(r.Weekdays) > 0) != (r.SetPosition != 0) {
+		return fmt.Errorf("BYDAY and BYSETPOS must be used together")
+	}
+
+	if r.SetPosition != 0 && (r.SetPosition < -5 || r.SetPosition > 5) {
+		return fmt.Errorf("BYSETPOS must be between -5 and 5")
+	}
+
+	if r.MonthDay != 0 && r.SetPosition != 0 {
+		return fmt.Errorf("BYMONTHDAY cannot be combined with BYDAY")
+	}
+
+	return nil
+}
+
+// String formats the rule in its canonical RRULE form.
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.MonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.MonthDay))
+	}
+	if len(r.Weekdays) > 0 {
+		codes := make([]string, 0, len(r.Weekdays))
+		for _, weekday := range r.Weekdays {
+			codes = append(codes, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+		parts = append(parts, "BYSETPOS="+strconv.Itoa(r.SetPosition))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format(recurrenceUntilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Each calls fn with every occurrence on or after start, in order, until fn
+// returns false or the rule ends.
+func (r RecurrenceRule) Each(start time.Time, fn func(date time.Time) bool) {
+	start = truncateToDay(start)
+	until := truncateToDay(r.Until)
+
+	emitted := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		date, ok := r.periodOccurrence(start, period*r.Interval)
+		if !ok || date.Before(start) {
+			continue
+		}
+		if !until.IsZero() && date.After(until) {
+			return
+		}
+		if !fn(date) {
+			return
+		}
+		emitted++
+		if r.Count > 0 && emitted >= r.Count {
+			return
+		}
+	}
+}
+
+// Between returns the occurrences from start on that fall within [from, to].
+func (r RecurrenceRule) Between(start, from, to time.Time) []time.Time {
+	from, to = truncateToDay(from), truncateToDay(to)
+
+	dates := make([]time.Time, 0)
+	r.Each(start, func(date time.Time) bool {
+		if date.After(to) {
+			return false
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return true
+	})
+	return dates
+}
+
+// Next returns up to limit occurrences from start on that fall after the
+// given day.
+func (r RecurrenceRule) Next(start, after time.Time, limit int) []time.Time {
+	after = truncateToDay(after)
+
+	dates := make([]time.Time, 0, limit)
+	if limit <= 0 {
+		return dates
+	}
+	r.Each(start, func(date time.Time) bool {
+		if date.After(after) {
+			dates = append(dates, date)
+		}
+		return len(dates) < limit
+	})
+	return dates
+}
+
+// Includes reports whether date is one of the occurrences from start on.
+func (r RecurrenceRule) Includes(start, date time.Time) bool {
+	date = truncateToDay(date)
+
+	found := false
+	r.Each(start, func(occurrence time.Time) bool {
+		found = occurrence.Equal(date)
+		return occurrence.Before(date)
+	})
+	return found
+}
+
+// periodOccurrence is the occurrence in the period offset periods after the
+// one containing start, if that period has one.
+func (r RecurrenceRule) periodOccurrence(start time.Time, offset int) (time.Time, bool) {
+	switch r.Frequency {
+	case RecurrenceDaily:
+		return start.AddDate(0, 0, offset), true
+	case RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*offset), true
+	case RecurrenceYearly:
+		return clampedDate(start.Year()+offset, start.Month(), start.Day()), true
+	}
+
+	first := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+	switch {
+	case r.SetPosition != 0:
+		return nthWeekdayOfMonth(first.Year(), first.Month(), r.Weekdays, r.SetPosition)
+	case r.MonthDay == -1:
+		return clampedDate(first.Year(), first.Month(), 31), true
+	case r.MonthDay > 0:
+		return clampedDate(first.Year(), first.Month(), r.MonthDay), true
+	default:
+		return clampedDate(first.Year(), first.Month(), start.Day()), true
+	}
+}
+
+// clampedDate is the given day of the month, or the last day of the month
+// when it is shorter.
+func clampedDate(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekdayOfMonth picks the position-th day of the month that falls on one
+// of weekdays, counting from the end when position is negative.
+func nthWeekdayOfMonth(year int, month time.Month, weekdays []time.Weekday, position int) (time.Time, bool) {
+	matches := make([]time.Time, 0, 31)
+	for day := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC); day.Month() == month; day = day.AddDate(0, 0, 1) {
+		for _, weekday := range weekdays {
+			if day.Weekday() == weekday {
+				matches = append(matches, day)
+				break
+			}
+		}
+	}
+
+	index := position - 1
+	if position < 0 {
+		index = len(matches) + position
+	}
+	if index < 0 || index >= len(matches) {
+		return time.Time{}, false
+	}
+	return matches[index], true
+}
@@ -0,0 +1,151 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "monthly on a day", value: "FREQ=MONTHLY;BYMONTHDAY=5", want: "FREQ=MONTHLY;BYMONTHDAY=5"},
+		{name: "prefix and lower case", value: " rrule:freq=weekly;interval=2 ", want: "FREQ=WEEKLY;INTERVAL=2"},
+		{name: "last business day", value: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", want: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+		{name: "until date time", value: "FREQ=YEARLY;UNTIL=20301231T235959Z", want: "FREQ=YEARLY;UNTIL=20301231"},
+		{name: "interval of one is dropped", value: "FREQ=DAILY;INTERVAL=1;COUNT=3", want: "FREQ=DAILY;COUNT=3"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "missing frequency", value: "BYMONTHDAY=5", wantErr: true},
+		{name: "unknown frequency", value: "FREQ=HOURLY", wantErr: true},
+		{name: "unsupported part", value: "FREQ=MONTHLY;BYHOUR=5", wantErr: true},
+		{name: "invalid month day", value: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "month day on weekly rule", value: "FREQ=WEEKLY;BYMONTHDAY=5", wantErr: true},
+		{name: "weekdays without position", value: "FREQ=MONTHLY;BYDAY=MO", wantErr: true},
+		{name: "unknown weekday", value: "FREQ=MONTHLY;BYDAY=XX;BYSETPOS=1", wantErr: true},
+		{name: "count and until", value: "FREQ=DAILY;COUNT=3;UNTIL=20301231", wantErr: true},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := entities.ParseRecurrenceRule(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+
+			reparsed, err := entities.ParseRecurrenceRule(rule.String())
+			require.NoError(t, err)
+			assert.Equal(t, rule, reparsed)
+		})
+	}
+}
+
+func TestRecurrenceRule_Between(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "day 31 falls on the last day of short months",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31",
+			start: day(2026, 1, 1),
+			from:  day(2026, 1, 1),
+			to:    day(2026, 4, 30),
+			want:  []time.Time{day(2026, 1, 31), day(2026, 2, 28), day(2026, 3, 31), day(2026, 4, 30)},
+		},
+		{
+			name:  "start day is kept after a short month",
+			rule:  "FREQ=MONTHLY",
+			start: day(2028, 1, 30),
+			from:  day(2028, 1, 1),
+			to:    day(2028, 3, 31),
+			want:  []time.Time{day(2028, 1, 30), day(2028, 2, 29), day(2028, 3, 30)},
+		},
+		{
+			name:  "last business day",
+			rule:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			start: day(2026, 1, 1),
+			from:  day(2026, 1, 1),
+			to:    day(2026, 6, 30),
+			want: []time.Time{
+				day(2026, 1, 30), day(2026, 2, 27), day(2026, 3, 31),
+				day(2026, 4, 30), day(2026, 5, 29), day(2026, 6, 30),
+			},
+		},
+		{
+			name:  "every two weeks",
+			rule:  "FREQ=WEEKLY;INTERVAL=2",
+			start: day(2026, 10, 2),
+			from:  day(2026, 10, 1),
+			to:    day(2026, 11, 15),
+			want:  []time.Time{day(2026, 10, 2), day(2026, 10, 16), day(2026, 10, 30), day(2026, 11, 13)},
+		},
+		{
+			name:  "count ends the schedule",
+			rule:  "FREQ=MONTHLY;COUNT=2",
+			start: day(2026, 1, 15),
+			from:  day(2026, 1, 1),
+			to:    day(2026, 12, 31),
+			want:  []time.Time{day(2026, 1, 15), day(2026, 2, 15)},
+		},
+		{
+			name:  "until ends the schedule",
+			rule:  "FREQ=YEARLY;UNTIL=20280229",
+			start: day(2024, 2, 29),
+			from:  day(2024, 1, 1),
+			to:    day(2030, 12, 31),
+			want:  []time.Time{day(2024, 2, 29), day(2025, 2, 28), day(2026, 2, 28), day(2027, 2, 28), day(2028, 2, 29)},
+		},
+		{
+			name:  "occurrences before start are skipped",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=1",
+			start: day(2026, 1, 15),
+			from:  day(2026, 1, 1),
+			to:    day(2026, 3, 1),
+			want:  []time.Time{day(2026, 2, 1), day(2026, 3, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := entities.ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, rule.Between(tt.start, tt.from, tt.to))
+		})
+	}
+}
+
+func TestRecurrenceRule_NextAndIncludes(t *testing.T) {
+	rule, err := entities.ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3")
+	require.NoError(t, err)
+	start := day(2026, 1, 1)
+
+	assert.Equal(t, []time.Time{day(2026, 2, 28), day(2026, 3, 31)}, rule.Next(start, day(2026, 1, 31), 10))
+	assert.Empty(t, rule.Next(start, day(2026, 3, 31), 10))
+	assert.Empty(t, rule.Next(start, time.Time{}, 0))
+
+	assert.True(t, rule.Includes(start, day(2026, 2, 28)))
+	assert.False(t, rule.Includes(start, day(2026, 2, 27)))
+	assert.False(t, rule.Includes(start, day(2026, 4, 30)))
+}
@@ -0,0 +1,261 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+var (
+	ErrNotAnOccurrence        = errors.New("date is not an occurrence of the schedule")
+	ErrOccurrenceMaterialized = errors.New("occurrence was already created; change its transaction instead")
+)
+
+// RecurringTransaction is a template, such as rent or a salary, that creates
+// a transaction on every occurrence of its schedule. MaterializedThrough is
+// the last day occurrences were created for, and NextOccurrence the next one
+// still to be created; it is zero once the schedule has ended.
+type RecurringTransaction struct {
+	ID                  string
+	WalletID            string
+	Type                TransactionType
+	Amount              money.Money
+	Description         string
+	CategoryID          string
+	Rule                RecurrenceRule
+	StartDate           time.Time
+	MaterializedThrough time.Time
+	NextOccurrence      time.Time
+	CreatedBy           string
+	IsDeleted           bool
+	DeletedAt           time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// OccurrenceOverride changes a single occurrence of a recurring transaction:
+// it is either skipped, or created with the given amount, description or
+// category instead of the template ones. Empty fields keep the template
+// value.
+type OccurrenceOverride struct {
+	RecurringID string
+	Date        time.Time
+	Skip        bool
+	Amount      money.Money
+	Description string
+	CategoryID  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Occurrence is a scheduled date with the values its transaction will have.
+type Occurrence struct {
+	Date        time.Time
+	Amount      money.Money
+	Description string
+	CategoryID  string
+	Skipped     bool
+	Modified    bool
+}
+
+func NewRecurringTransaction(
+	walletID string,
+	transactionType TransactionType,
+	amount money.Money,
+	description string,
+	categoryID string,
+	rule RecurrenceRule,
+	startDate time.Time,
+	createdBy string,
+) (*RecurringTransaction, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet is required")
+	}
+
+	if createdBy == "" {
+		return nil, fmt.Errorf("recurring transaction author is required")
+	}
+
+	recurring := &RecurringTransaction{
+		ID:        uuid.NewString(),
+		WalletID:  walletID,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := recurring.Update(transactionType, amount, description, categoryID, rule, startDate); err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+// Update replaces the template. Occurrences already created are kept as they
+// are; the new values apply from the next one on.
+func (r *RecurringTransaction) Update(
+	transactionType TransactionType,
+	amount money.Money,
+	description string,
+	categoryID string,
+	rule RecurrenceRule,
+	startDate time.Time,
+) error {
+	// The template must make a valid transaction on its start date
+	var prototype Transaction
+	if err := prototype.Update(transactionType, amount, startDate, description, categoryID); err != nil {
+		return err
+	}
+
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	r.Type = prototype.Type
+	r.Amount = prototype.Amount
+	r.Description = prototype.Description
+	r.CategoryID = prototype.CategoryID
+	r.Rule = rule
+	r.StartDate = prototype.Date
+	r.NextOccurrence = r.nextAfter(r.MaterializedThrough)
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// DueDates returns the occurrences up to today that were not created yet.
+func (r *RecurringTransaction) DueDates(today time.Time) []time.Time {
+	return r.Rule.Between(r.StartDate, r.MaterializedThrough.AddDate(0, 0, 1), today)
+}
+
+// UpcomingDates returns the next occurrences that were not created yet.
+func (r *RecurringTransaction) UpcomingDates(limit int) []time.Time {
+	return r.Rule.Next(r.StartDate, r.MaterializedThrough, limit)
+}
+
+// MarkMaterialized records that every occurrence up to today was created.
+func (r *RecurringTransaction) MarkMaterialized(today time.Time) {
+	today = truncateToDay(today)
+	if today.After(r.MaterializedThrough) {
+		r.MaterializedThrough = today
+	}
+	r.NextOccurrence = r.nextAfter(r.MaterializedThrough)
+	r.UpdatedAt = time.Now()
+}
+
+// nextAfter is the first occurrence after day, or zero when there is none.
+func (r *RecurringTransaction) nextAfter(day time.Time) time.Time {
+	next := r.Rule.Next(r.StartDate, day, 1)
+	if len(next) == 0 {
+		return time.Time{}
+	}
+	return next[0]
+}
+
+// HasEnded reports whether every occurrence of the schedule was created.
+func (r *RecurringTransaction) HasEnded() bool {
+	return r.NextOccurrence.IsZero()
+}
+
+// NewOverride prepares an override for the occurrence on date, which must be
+// a scheduled occurrence that was not created yet.
+func (r *RecurringTransaction) NewOverride(date time.Time, skip bool, amount money.Money, description, categoryID string) (*OccurrenceOverride, error) {
+	date = truncateToDay(date)
+
+	if !r.Rule.Includes(r.StartDate, date) {
+		return nil, ErrNotAnOccurrence
+	}
+
+	if !date.After(r.MaterializedThrough) {
+		return nil, ErrOccurrenceMaterialized
+	}
+
+	override := &OccurrenceOverride{
+		RecurringID: r.ID,
+		Date:        date,
+		Skip:        skip,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if !skip {
+		if amount.Currency().Code != "" && !amount.SameCurrency(r.Amount) {
+			return nil, fmt.Errorf("amount must be in %s", r.Amount.Currency().Code)
+		}
+		// The modified occurrence must still make a valid transaction
+		var prototype Transaction
+		occurrence := r.Occurrence(date, &OccurrenceOverride{Amount: amount, Description: description, CategoryID: categoryID})
+		if err := prototype.Update(r.Type, occurrence.Amount, date, occurrence.Description, occurrence.CategoryID); err != nil {
+			return nil, err
+		}
+		override.Amount = amount
+		override.Description = strings.TrimSpace(description)
+		override.CategoryID = categoryID
+	}
+
+	return override, nil
+}
+
+// Occurrence is the occurrence on date with override, which may be nil,
+// applied.
+func (r *RecurringTransaction) Occurrence(date time.Time, override *OccurrenceOverride) *Occurrence {
+	occurrence := &Occurrence{
+		Date:        truncateToDay(date),
+		Amount:      r.Amount,
+		Description: r.Description,
+		CategoryID:  r.CategoryID,
+	}
+
+	if override == nil {
+		return occurrence
+	}
+
+	if override.Skip {
+		occurrence.Skipped = true
+		return occurrence
+	}
+
+	if override.Amount.Currency().Code != "" {
+		occurrence.Amount = override.Amount
+		occurrence.Modified = true
+	}
+	if override.Description != "" {
+		occurrence.Description = override.Description
+		occurrence.Modified = true
+	}
+	if override.CategoryID != "" {
+		occurrence.CategoryID = override.CategoryID
+		occurrence.Modified = true
+	}
+	return occurrence
+}
+
+// Materialize builds the transaction for an occurrence that is not skipped.
+func (r *RecurringTransaction) Materialize(occurrence *Occurrence) (*Transaction, error) {
+	transaction, err := NewTransaction(
+		r.WalletID,
+		r.Type,
+		occurrence.Amount,
+		occurrence.Date,
+		occurrence.Description,
+		occurrence.CategoryID,
+		r.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.RecurringID = r.ID
+	transaction.OccurrenceDate = occurrence.Date
+	return transaction, nil
+}
+
+// Delete stops the schedule. Transactions already created are kept.
+func (r *RecurringTransaction) Delete() {
+	r.IsDeleted = true
+	r.DeletedAt = time.Now()
+	r.UpdatedAt = r.DeletedAt
+}
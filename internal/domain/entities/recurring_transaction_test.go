@@ -0,0 +1,149 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRecurringTransaction(t *testing.T, value string) *entities.RecurringTransaction {
+	t.Helper()
+	rule, err := entities.ParseRecurrenceRule(value)
+	require.NoError(t, err)
+	amount, err := money.Parse("1500.00", "USD")
+	require.NoError(t, err)
+
+	recurring, err := entities.NewRecurringTransaction(
+		"wallet-id", entities.TransactionTypeExpense, amount, " Rent ", "", rule, day(2026, 1, 1), "user-id",
+	)
+	require.NoError(t, err)
+	return recurring
+}
+
+func TestNewRecurringTransaction(t *testing.T) {
+	rule, _ := entities.ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=5")
+	amount, _ := money.Parse("1500.00", "USD")
+	zero, _ := money.Parse("0", "USD")
+
+	tests := []struct {
+		name      string
+		walletID  string
+		txType    entities.TransactionType
+		amount    money.Money
+		rule      entities.RecurrenceRule
+		createdBy string
+		wantErr   bool
+	}{
+		{name: "valid", walletID: "wallet-id", txType: entities.TransactionTypeExpense, amount: amount, rule: rule, createdBy: "user-id"},
+		{name: "missing wallet", txType: entities.TransactionTypeExpense, amount: amount, rule: rule, createdBy: "user-id", wantErr: true},
+		{name: "missing author", walletID: "wallet-id", txType: entities.TransactionTypeExpense, amount: amount, rule: rule, wantErr: true},
+		{name: "zero amount", walletID: "wallet-id", txType: entities.TransactionTypeExpense, amount: zero, rule: rule, createdBy: "user-id", wantErr: true},
+		{name: "transfer type", walletID: "wallet-id", txType: entities.TransactionTypeTransferOut, amount: amount, rule: rule, createdBy: "user-id", wantErr: true},
+		{name: "invalid rule", walletID: "wallet-id", txType: entities.TransactionTypeExpense, amount: amount, createdBy: "user-id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurring, err := entities.NewRecurringTransaction(tt.walletID, tt.txType, tt.amount, "Rent", "", tt.rule, day(2026, 1, 1), tt.createdBy)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, recurring)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, recurring.ID)
+			assert.True(t, recurring.MaterializedThrough.IsZero())
+			assert.Equal(t, day(2026, 1, 5), recurring.NextOccurrence)
+		})
+	}
+}
+
+func TestRecurringTransaction_Materialization(t *testing.T) {
+	recurring := newTestRecurringTransaction(t, "FREQ=MONTHLY;BYMONTHDAY=5;COUNT=3")
+
+	assert.Equal(t, []time.Time{day(2026, 1, 5), day(2026, 2, 5)}, recurring.DueDates(day(2026, 2, 10)))
+
+	recurring.MarkMaterialized(time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC))
+	assert.Equal(t, day(2026, 2, 10), recurring.MaterializedThrough)
+	assert.Equal(t, day(2026, 3, 5), recurring.NextOccurrence)
+	assert.Empty(t, recurring.DueDates(day(2026, 2, 10)))
+	assert.Equal(t, []time.Time{day(2026, 3, 5)}, recurring.UpcomingDates(10))
+
+	// Marking an earlier day does not move progress back
+	recurring.MarkMaterialized(day(2026, 1, 1))
+	assert.Equal(t, day(2026, 2, 10), recurring.MaterializedThrough)
+
+	recurring.MarkMaterialized(day(2026, 3, 5))
+	assert.True(t, recurring.HasEnded())
+}
+
+func TestRecurringTransaction_NewOverride(t *testing.T) {
+	usd, _ := money.Parse("1600.00", "USD")
+	brl, _ := money.Parse("1600.00", "BRL")
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		skip    bool
+		amount  money.Money
+		wantErr error
+	}{
+		{name: "skip", date: day(2026, 3, 5), skip: true},
+		{name: "modify amount", date: day(2026, 3, 5), amount: usd},
+		{name: "not an occurrence", date: day(2026, 3, 6), skip: true, wantErr: entities.ErrNotAnOccurrence},
+		{name: "already created", date: day(2026, 1, 5), skip: true, wantErr: entities.ErrOccurrenceMaterialized},
+		{name: "other currency", date: day(2026, 3, 5), amount: brl},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurring := newTestRecurringTransaction(t, "FREQ=MONTHLY;BYMONTHDAY=5")
+			recurring.MarkMaterialized(day(2026, 2, 10))
+
+			override, err := recurring.NewOverride(tt.date, tt.skip, tt.amount, "", "")
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.amount.Currency().Code == "BRL":
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, recurring.ID, override.RecurringID)
+				assert.Equal(t, tt.date, override.Date)
+			}
+		})
+	}
+}
+
+func TestRecurringTransaction_Occurrence(t *testing.T) {
+	recurring := newTestRecurringTransaction(t, "FREQ=MONTHLY;BYMONTHDAY=5")
+	amount, _ := money.Parse("1600.00", "USD")
+
+	occurrence := recurring.Occurrence(day(2026, 3, 5), nil)
+	assert.Equal(t, "Rent", occurrence.Description)
+	assert.False(t, occurrence.Modified)
+
+	override, err := recurring.NewOverride(day(2026, 3, 5), false, amount, " Rent with fee ", "")
+	require.NoError(t, err)
+	occurrence = recurring.Occurrence(day(2026, 3, 5), override)
+	assert.True(t, occurrence.Modified)
+	assert.Equal(t, int64(160000), occurrence.Amount.MinorUnits())
+	assert.Equal(t, "Rent with fee", occurrence.Description)
+
+	transaction, err := recurring.Materialize(occurrence)
+	require.NoError(t, err)
+	assert.Equal(t, recurring.ID, transaction.RecurringID)
+	assert.Equal(t, day(2026, 3, 5), transaction.OccurrenceDate)
+	assert.Equal(t, "wallet-id", transaction.WalletID)
+
+	skip, err := recurring.NewOverride(day(2026, 3, 5), true, money.Money{}, "", "")
+	require.NoError(t, err)
+	assert.True(t, recurring.Occurrence(day(2026, 3, 5), skip).Skipped)
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "github.com/stra1g/saver-api/pkg/money"
+
+// CategoryTotal is how much a wallet received or spent under one category,
+// in one currency. Split transactions count once per line, under the line
+// category; an empty CategoryID groups the uncategorised amounts.
+//
+// A total converted into another currency adds up the category in every
+// currency, and Conversions records the rate applied to each of them.
+type CategoryTotal struct {
+	CategoryID  string
+	Type        TransactionType
+	Total       money.Money
+	LineCount   int
+	Conversions []*Conversion
+}
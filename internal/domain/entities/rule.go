@@ -0,0 +1,252 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const (
+	maxRuleNameLength    = 100
+	maxRulePatternLength = 200
+)
+
+// RuleConditions select the transactions a rule applies to; every condition
+// set must match. DescriptionPattern is a regular expression matched
+// anywhere in the description, ignoring case. The amount bounds are
+// inclusive and only match transactions in their currency.
+type RuleConditions struct {
+	DescriptionPattern string
+	MinAmount          *money.Money
+	MaxAmount          *money.Money
+	PayeeID            string
+	WalletID           string
+}
+
+// RuleActions are what a rule does to the transactions it matches: file
+// them under CategoryID, add TagIDs and rename them to Description.
+// CategoryType is the type of the category, which is only set on
+// transactions of that type.
+type RuleActions struct {
+	CategoryID   string
+	CategoryType TransactionType
+	TagIDs       []string
+	Description  string
+}
+
+// Rule categorizes, tags or renames the new transactions of its user that
+// match its conditions. Rules run by ascending Priority.
+type Rule struct {
+	ID         string
+	UserID     string
+	Name       string
+	Priority   int
+	Conditions RuleConditions
+	Actions    RuleActions
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewRule(userID, name string, priority int, conditions RuleConditions, actions RuleActions) (*Rule, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("rule owner is required")
+	}
+
+	rule := &Rule{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := rule.Update(name, priority, conditions, actions); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// Update replaces the rule after validating it. A rule needs at least one
+// condition, so it cannot match every transaction, and at least one action.
+func (r *Rule) Update(name string, priority int, conditions RuleConditions, actions RuleActions) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+	if len(name) > maxRuleNameLength {
+		return fmt.Errorf("rule name must be at most %d characters", maxRuleNameLength)
+	}
+
+	if priority < 0 {
+		return fmt.Errorf("priority must not be negative")
+	}
+
+	if err := checkRuleConditions(conditions); err != nil {
+		return err
+	}
+
+	actions.Description = strings.TrimSpace(actions.Description)
+	actions.TagIDs = UniqueIDs(actions.TagIDs)
+	if actions.CategoryID == "" && len(actions.TagIDs) == 0 && actions.Description == "" {
+		return fmt.Errorf("a rule needs at least one action")
+	}
+	if len(actions.Description) > maxTransactionDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", maxTransactionDescriptionLength)
+	}
+
+	r.Name = name
+	r.Priority = priority
+	r.Conditions = conditions
+	r.Actions = actions
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+func checkRuleConditions(conditions RuleConditions) error {
+	if conditions.DescriptionPattern == "" && conditions.MinAmount == nil && conditions.MaxAmount == nil &&
+		conditions.PayeeID == "" && conditions.WalletID == "" {
+		return fmt.Errorf("a rule needs at least one condition")
+	}
+
+	if len(conditions.DescriptionPattern) > maxRulePatternLength {
+		return fmt.Errorf("description pattern must be at most %d characters", maxRulePatternLength)
+	}
+	if _, err := compileRulePattern(conditions.DescriptionPattern); err != nil {
+		return fmt.Errorf("invalid description pattern: %w", err)
+	}
+
+	if conditions.MinAmount != nil && conditions.MaxAmount != nil {
+		cmp, err := conditions.MinAmount.Cmp(*conditions.MaxAmount)
+		if err != nil {
+			return fmt.Errorf("amount bounds must be in the same currency")
+		}
+		if cmp > 0 {
+			return fmt.Errorf("minimum amount must not be above the maximum")
+		}
+	}
+
+	return nil
+}
+
+// compileRulePattern compiles a description pattern to match ignoring case.
+// Patterns use RE2 syntax, which runs in linear time whatever the input.
+func compileRulePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// Matches reports whether the transaction belongs to the rule's user and
+// meets every condition of the rule.
+func (r *Rule) Matches(transaction *Transaction) bool {
+	conditions := r.Conditions
+	switch {
+	case transaction.CreatedBy != r.UserID:
+		return false
+	case conditions.WalletID != "" && transaction.WalletID != conditions.WalletID:
+		return false
+	case conditions.PayeeID != "" && transaction.PayeeID != conditions.PayeeID:
+		return false
+	}
+
+	if conditions.MinAmount != nil {
+		cmp, err := transaction.Amount.Cmp(*conditions.MinAmount)
+		if err != nil || cmp < 0 {
+			return false
+		}
+	}
+	if conditions.MaxAmount != nil {
+		cmp, err := transaction.Amount.Cmp(*conditions.MaxAmount)
+		if err != nil || cmp > 0 {
+			return false
+		}
+	}
+
+	if conditions.DescriptionPattern != "" {
+		pattern, err := compileRulePattern(conditions.DescriptionPattern)
+		if err != nil || !pattern.MatchString(transaction.Description) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SortRules orders rules by priority, then by age, which is the order they
+// run in.
+func SortRules(rules []*Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// ApplyRules runs the rules matching the transaction in priority order and
+// returns those that changed it. Conditions are checked against the
+// transaction as it was before any rule ran. The first rule to set a
+// category, or a description, wins it; tags add up. A rule's category only
+// replaces an existing one when overwrite is set, and split transactions
+// and transfer legs keep their categories.
+func ApplyRules(rules []*Rule, transaction *Transaction, overwrite bool) []*Rule {
+	if transaction.IsTransfer() {
+		return nil
+	}
+
+	sorted := append([]*Rule{}, rules...)
+	SortRules(sorted)
+
+	matched := make([]*Rule, 0, len(sorted))
+	for _, rule := range sorted {
+		if rule.Matches(transaction) {
+			matched = append(matched, rule)
+		}
+	}
+
+	applied := make([]*Rule, 0, len(matched))
+	categorized, renamed := false, false
+	for _, rule := range matched {
+		actions := rule.Actions
+		changed := false
+
+		if !categorized && actions.CategoryID != "" && actions.CategoryType == transaction.Type {
+			categorized = true
+			if overwrite && !transaction.IsSplit() {
+				changed = transaction.CategoryID != actions.CategoryID
+				transaction.CategoryID = actions.CategoryID
+			} else {
+				changed = transaction.SuggestCategory(actions.CategoryID)
+			}
+		}
+
+		if !renamed && actions.Description != "" {
+			renamed = true
+			if transaction.Description != actions.Description {
+				transaction.Description = actions.Description
+				changed = true
+			}
+		}
+
+		if tagIDs := UniqueIDs(append(append([]string{}, transaction.TagIDs...), actions.TagIDs...)); len(tagIDs) != len(transaction.TagIDs) {
+			transaction.TagIDs = tagIDs
+			changed = true
+		}
+
+		if changed {
+			applied = append(applied, rule)
+		}
+	}
+
+	if len(applied) > 0 {
+		transaction.UpdatedAt = time.Now()
+	}
+	return applied
+}
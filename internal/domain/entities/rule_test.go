@@ -0,0 +1,159 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRule(t *testing.T, priority int, conditions entities.RuleConditions, actions entities.RuleActions) *entities.Rule {
+	t.Helper()
+	rule, err := entities.NewRule("ana", "Rule", priority, conditions, actions)
+	require.NoError(t, err)
+	return rule
+}
+
+func TestNewRule(t *testing.T) {
+	minAmount, maxAmount := brl(t, "10.00"), brl(t, "50.00")
+	usd, err := money.Parse("20.00", "USD")
+	require.NoError(t, err)
+	tag := entities.RuleActions{TagIDs: []string{"trip"}}
+
+	tests := []struct {
+		name       string
+		ruleName   string
+		priority   int
+		conditions entities.RuleConditions
+		actions    entities.RuleActions
+		wantErr    bool
+	}{
+		{
+			name:       "valid rule",
+			ruleName:   "Groceries",
+			conditions: entities.RuleConditions{DescriptionPattern: "market|grocer", MinAmount: &minAmount, MaxAmount: &maxAmount},
+			actions:    entities.RuleActions{CategoryID: "food-id", CategoryType: entities.TransactionTypeExpense, TagIDs: []string{"trip", "trip"}},
+		},
+		{name: "missing name", ruleName: " ", conditions: entities.RuleConditions{PayeeID: "payee-id"}, actions: tag, wantErr: true},
+		{name: "negative priority", ruleName: "Rule", priority: -1, conditions: entities.RuleConditions{PayeeID: "payee-id"}, actions: tag, wantErr: true},
+		{name: "no condition", ruleName: "Rule", actions: tag, wantErr: true},
+		{name: "no action", ruleName: "Rule", conditions: entities.RuleConditions{PayeeID: "payee-id"}, wantErr: true},
+		{name: "invalid pattern", ruleName: "Rule", conditions: entities.RuleConditions{DescriptionPattern: "market("}, actions: tag, wantErr: true},
+		{name: "inverted amount range", ruleName: "Rule", conditions: entities.RuleConditions{MinAmount: &maxAmount, MaxAmount: &minAmount}, actions: tag, wantErr: true},
+		{name: "mixed currencies", ruleName: "Rule", conditions: entities.RuleConditions{MinAmount: &minAmount, MaxAmount: &usd}, actions: tag, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := entities.NewRule("ana", tt.ruleName, tt.priority, tt.conditions, tt.actions)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, rule.ID)
+			assert.Equal(t, "Groceries", rule.Name)
+			assert.Equal(t, []string{"trip"}, rule.Actions.TagIDs)
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	minAmount, maxAmount := brl(t, "10.00"), brl(t, "50.00")
+	usd, err := money.Parse("10.00", "USD")
+	require.NoError(t, err)
+	tag := entities.RuleActions{TagIDs: []string{"trip"}}
+
+	transaction := newSharedTestTransaction(t, "30.00")
+	transaction.Description = "SUPERMARKET #42"
+	transaction.SetPayee("payee-id")
+
+	tests := []struct {
+		name       string
+		conditions entities.RuleConditions
+		want       bool
+	}{
+		{name: "pattern ignores case", conditions: entities.RuleConditions{DescriptionPattern: "supermarket"}, want: true},
+		{name: "pattern mismatch", conditions: entities.RuleConditions{DescriptionPattern: "^pharmacy"}},
+		{name: "within amount range", conditions: entities.RuleConditions{MinAmount: &minAmount, MaxAmount: &maxAmount}, want: true},
+		{name: "below minimum", conditions: entities.RuleConditions{MinAmount: &maxAmount}},
+		{name: "other currency", conditions: entities.RuleConditions{MinAmount: &usd}},
+		{name: "payee and wallet", conditions: entities.RuleConditions{PayeeID: "payee-id", WalletID: "wallet-id"}, want: true},
+		{name: "other payee", conditions: entities.RuleConditions{PayeeID: "other-payee-id"}},
+		{name: "other wallet", conditions: entities.RuleConditions{WalletID: "savings-id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := newRule(t, 0, tt.conditions, tag)
+			assert.Equal(t, tt.want, rule.Matches(transaction))
+		})
+	}
+
+	t.Run("other user", func(t *testing.T) {
+		rule, err := entities.NewRule("bruno", "Rule", 0, entities.RuleConditions{WalletID: "wallet-id"}, tag)
+		require.NoError(t, err)
+		assert.False(t, rule.Matches(transaction))
+	})
+}
+
+func TestApplyRules(t *testing.T) {
+	conditions := entities.RuleConditions{DescriptionPattern: "dinner"}
+	food := newRule(t, 2, conditions, entities.RuleActions{
+		CategoryID: "food-id", CategoryType: entities.TransactionTypeExpense, TagIDs: []string{"meal"}, Description: "Restaurant",
+	})
+	leisure := newRule(t, 1, conditions, entities.RuleActions{
+		CategoryID: "leisure-id", CategoryType: entities.TransactionTypeExpense, TagIDs: []string{"trip"},
+	})
+	salary := newRule(t, 0, conditions, entities.RuleActions{
+		CategoryID: "salary-id", CategoryType: entities.TransactionTypeIncome,
+	})
+	unmatched := newRule(t, 0, entities.RuleConditions{DescriptionPattern: "taxi"}, entities.RuleActions{Description: "Taxi"})
+	rules := []*entities.Rule{food, leisure, salary, unmatched}
+
+	t.Run("runs rules in priority order", func(t *testing.T) {
+		transaction := newSharedTestTransaction(t, "30.00")
+
+		applied := entities.ApplyRules(rules, transaction, false)
+
+		assert.Equal(t, []*entities.Rule{leisure, food}, applied)
+		assert.Equal(t, "leisure-id", transaction.CategoryID)
+		assert.Equal(t, "Restaurant", transaction.Description)
+		assert.Equal(t, []string{"meal", "trip"}, transaction.TagIDs)
+	})
+
+	t.Run("keeps the category unless overwriting", func(t *testing.T) {
+		transaction := newSharedTestTransaction(t, "30.00")
+		transaction.CategoryID = "pharmacy-id"
+
+		entities.ApplyRules([]*entities.Rule{leisure}, transaction, false)
+		assert.Equal(t, "pharmacy-id", transaction.CategoryID)
+
+		entities.ApplyRules([]*entities.Rule{leisure}, transaction, true)
+		assert.Equal(t, "leisure-id", transaction.CategoryID)
+	})
+
+	t.Run("keeps split categories", func(t *testing.T) {
+		transaction := newSharedTestTransaction(t, "30.00")
+		require.NoError(t, transaction.SetSplits([]entities.TransactionSplit{
+			newSplit(t, "20.00", "BRL", "food-id"),
+			newSplit(t, "10.00", "BRL", "pharmacy-id"),
+		}))
+
+		applied := entities.ApplyRules([]*entities.Rule{leisure}, transaction, true)
+
+		assert.Equal(t, []*entities.Rule{leisure}, applied)
+		assert.Empty(t, transaction.CategoryID)
+		assert.Equal(t, []string{"trip"}, transaction.TagIDs)
+	})
+
+	t.Run("reports no change", func(t *testing.T) {
+		transaction := newSharedTestTransaction(t, "30.00")
+		entities.ApplyRules(rules, transaction, false)
+
+		assert.Empty(t, entities.ApplyRules(rules, transaction, false))
+	})
+}
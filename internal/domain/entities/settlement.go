@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const maxSettlementNoteLength = 255
+
+// Settlement is a payment between two wallet members that pays back what one
+// owes the other on shared expenses. Settlements are never changed or
+// removed, so the history stays auditable: a settlement recorded by mistake
+// is voided, which keeps it in the history but stops it from counting.
+type Settlement struct {
+	ID         string
+	WalletID   string
+	FromUserID string
+	ToUserID   string
+	Amount     money.Money
+	Date       time.Time
+	Note       string
+	CreatedBy  string
+	VoidedBy   string
+	VoidedAt   time.Time
+	CreatedAt  time.Time
+}
+
+func NewSettlement(
+	walletID string,
+	fromUserID string,
+	toUserID string,
+	amount money.Money,
+	date time.Time,
+	note string,
+	createdBy string,
+) (*Settlement, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet is required")
+	}
+
+	if fromUserID == "" || toUserID == "" {
+		return nil, fmt.Errorf("payer and payee are required")
+	}
+
+	if fromUserID == toUserID {
+		return nil, fmt.Errorf("payer and payee must be different members")
+	}
+
+	if amount.Currency().Code == "" {
+		return nil, fmt.Errorf("currency is required")
+	}
+
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("amount must be greater than zero")
+	}
+
+	if date.IsZero() {
+		return nil, fmt.Errorf("date is required")
+	}
+
+	note = strings.TrimSpace(note)
+	if len(note) > maxSettlementNoteLength {
+		return nil, fmt.Errorf("note must be at most %d characters", maxSettlementNoteLength)
+	}
+
+	if createdBy == "" {
+		return nil, fmt.Errorf("settlement author is required")
+	}
+
+	return &Settlement{
+		ID:         uuid.NewString(),
+		WalletID:   walletID,
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		Amount:     amount,
+		Date:       truncateToDay(date),
+		Note:       note,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// IsVoided reports whether the settlement was voided and no longer counts.
+func (s *Settlement) IsVoided() bool {
+	return !s.VoidedAt.IsZero()
+}
+
+// Void stops the settlement from counting towards balances.
+func (s *Settlement) Void(voidedBy string) error {
+	if s.IsVoided() {
+		return fmt.Errorf("settlement was already voided")
+	}
+
+	if voidedBy == "" {
+		return fmt.Errorf("user voiding the settlement is required")
+	}
+
+	s.VoidedBy = voidedBy
+	s.VoidedAt = time.Now()
+	return nil
+}
+
+// Debt is the effect of the settlement on balances: paying someone back
+// counts as them owing the payer the same amount, which cancels out.
+func (s *Settlement) Debt() *Debt {
+	return &Debt{
+		DebtorID:   s.ToUserID,
+		CreditorID: s.FromUserID,
+		Amount:     s.Amount,
+	}
+}
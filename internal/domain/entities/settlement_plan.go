@@ -0,0 +1,173 @@
+package entities
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// maxExactSettlementMembers bounds how many members with a balance in one
+// currency the planner searches the fewest payments for. Larger groups are
+// settled greedily, which takes at most one payment less than there are
+// members.
+const maxExactSettlementMembers = 16
+
+type plannedBalance struct {
+	userID string
+	amount int64
+}
+
+// PlanSettlements returns the fewest payments that bring every balance back
+// to zero. Members are grouped into as many groups whose balances cancel out
+// as possible, since a group of n members can always be settled with n-1
+// payments and never with fewer. The plan only depends on the balances, not
+// on their order.
+func PlanSettlements(balances []*MemberBalance) ([]*Debt, error) {
+	byCurrency := make(map[string][]plannedBalance)
+	for _, balance := range balances {
+		if balance.Net.IsZero() {
+			continue
+		}
+		code := balance.Net.Currency().Code
+		byCurrency[code] = append(byCurrency[code], plannedBalance{balance.UserID, balance.Net.MinorUnits()})
+	}
+
+	currencies := make([]string, 0, len(byCurrency))
+	for code := range byCurrency {
+		currencies = append(currencies, code)
+	}
+	sort.Strings(currencies)
+
+	payments := make([]*Debt, 0)
+	for _, code := range currencies {
+		members := byCurrency[code]
+		sort.Slice(members, func(i, j int) bool { return members[i].userID < members[j].userID })
+
+		var total int64
+		for _, member := range members {
+			total += member.amount
+		}
+		if total != 0 {
+			return nil, fmt.Errorf("%s balances do not add up to zero", code)
+		}
+
+		groups := [][]plannedBalance{members}
+		if len(members) <= maxExactSettlementMembers {
+			groups = zeroSumGroups(members)
+		}
+
+		for _, group := range groups {
+			planned, err := settleGroup(group, code)
+			if err != nil {
+				return nil, err
+			}
+			payments = append(payments, planned...)
+		}
+	}
+
+	return payments, nil
+}
+
+// zeroSumGroups splits members into as many groups whose balances add up to
+// zero as possible. best[mask] is the most such groups the members in mask
+// can be split into, found by taking members out one at a time; a zero-sum
+// mask closes a group.
+func zeroSumGroups(members []plannedBalance) [][]plannedBalance {
+	size := 1 << len(members)
+	sums := make([]int64, size)
+	best := make([]int, size)
+	for mask := 1; mask < size; mask++ {
+		lowest := bits.TrailingZeros(uint(mask))
+		sums[mask] = sums[mask&(mask-1)] + members[lowest].amount
+
+		for i := range members {
+			if mask&(1<<i) != 0 && best[mask^(1<<i)] > best[mask] {
+				best[mask] = best[mask^(1<<i)]
+			}
+		}
+		if sums[mask] == 0 {
+			best[mask]++
+		}
+	}
+
+	// Walk back from everyone, cutting a group off at every zero-sum mask
+	groups := make([][]plannedBalance, 0, best[size-1])
+	mask, groupMask := size-1, size-1
+	for mask != 0 {
+		closes := 0
+		if sums[mask] == 0 {
+			closes = 1
+		}
+		for i := range members {
+			next := mask ^ (1 << i)
+			if mask&(1<<i) != 0 && best[next]+closes == best[mask] {
+				mask = next
+				break
+			}
+		}
+		if sums[mask] == 0 {
+			groups = append(groups, membersIn(members, groupMask^mask))
+			groupMask = mask
+		}
+	}
+	return groups
+}
+
+func membersIn(members []plannedBalance, mask int) []plannedBalance {
+	group := make([]plannedBalance, 0, bits.OnesCount(uint(mask)))
+	for i, member := range members {
+		if mask&(1<<i) != 0 {
+			group = append(group, member)
+		}
+	}
+	return group
+}
+
+// settleGroup pays the largest debt to the largest credit until the group
+// balances, which takes at most one payment less than the group has members.
+func settleGroup(group []plannedBalance, currencyCode string) ([]*Debt, error) {
+	var debtors, creditors []plannedBalance
+	for _, member := range group {
+		if member.amount < 0 {
+			debtors = append(debtors, plannedBalance{member.userID, -member.amount})
+		} else {
+			creditors = append(creditors, member)
+		}
+	}
+	byAmount := func(members []plannedBalance) {
+		sort.Slice(members, func(i, j int) bool {
+			if members[i].amount != members[j].amount {
+				return members[i].amount > members[j].amount
+			}
+			return members[i].userID < members[j].userID
+		})
+	}
+	byAmount(debtors)
+	byAmount(creditors)
+
+	payments := make([]*Debt, 0, len(group))
+	for d, c := 0, 0; d < len(debtors) && c < len(creditors); {
+		paid := debtors[d].amount
+		if creditors[c].amount < paid {
+			paid = creditors[c].amount
+		}
+
+		amount, err := money.New(paid, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, &Debt{DebtorID: debtors[d].userID, CreditorID: creditors[c].userID, Amount: amount})
+
+		debtors[d].amount -= paid
+		creditors[c].amount -= paid
+		if debtors[d].amount == 0 {
+			d++
+		}
+		if creditors[c].amount == 0 {
+			c++
+		}
+	}
+	return payments, nil
+}
@@ -0,0 +1,182 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSettlement(t *testing.T) {
+	t.Run("valid settlement", func(t *testing.T) {
+		settlement, err := entities.NewSettlement("wallet-id", "bia", "ana", brl(t, "20.00"), day(2026, 3, 10), " pix ", "bia")
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, settlement.ID)
+		assert.Equal(t, "pix", settlement.Note)
+		assert.False(t, settlement.IsVoided())
+	})
+
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		amount string
+	}{
+		{name: "missing payee", from: "bia", to: "", amount: "20.00"},
+		{name: "paying yourself", from: "bia", to: "bia", amount: "20.00"},
+		{name: "zero amount", from: "bia", to: "ana", amount: "0.00"},
+		{name: "negative amount", from: "bia", to: "ana", amount: "-5.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlement, err := entities.NewSettlement("wallet-id", tt.from, tt.to, brl(t, tt.amount), day(2026, 3, 10), "", "bia")
+
+			assert.Error(t, err)
+			assert.Nil(t, settlement)
+		})
+	}
+}
+
+func TestSettlement_Void(t *testing.T) {
+	settlement, err := entities.NewSettlement("wallet-id", "bia", "ana", brl(t, "20.00"), day(2026, 3, 10), "", "bia")
+	require.NoError(t, err)
+
+	require.NoError(t, settlement.Void("ana"))
+	assert.True(t, settlement.IsVoided())
+	assert.Equal(t, "ana", settlement.VoidedBy)
+
+	assert.Error(t, settlement.Void("ana"))
+}
+
+func TestSettlement_Debt(t *testing.T) {
+	settlement, err := entities.NewSettlement("wallet-id", "bia", "ana", brl(t, "20.00"), day(2026, 3, 10), "", "bia")
+	require.NoError(t, err)
+
+	debts := []*entities.Debt{
+		{DebtorID: "bia", CreditorID: "ana", Amount: brl(t, "20.00")},
+		settlement.Debt(),
+	}
+	netted, err := entities.NetDebts(debts)
+
+	require.NoError(t, err)
+	assert.Empty(t, netted)
+}
+
+func TestPlanSettlements(t *testing.T) {
+	balance := func(userID, net string) *entities.MemberBalance {
+		return &entities.MemberBalance{UserID: userID, Net: brl(t, net)}
+	}
+
+	t.Run("settles every member", func(t *testing.T) {
+		payments, err := entities.PlanSettlements([]*entities.MemberBalance{
+			balance("ana", "30.00"),
+			balance("bia", "-10.00"),
+			balance("caio", "-20.00"),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, payments, 2)
+		assert.Equal(t, "caio", payments[0].DebtorID)
+		assert.Equal(t, "ana", payments[0].CreditorID)
+		assert.Equal(t, int64(2000), payments[0].Amount.MinorUnits())
+		assert.Equal(t, "bia", payments[1].DebtorID)
+		assert.Equal(t, int64(1000), payments[1].Amount.MinorUnits())
+	})
+
+	t.Run("fewer payments than paying the largest debts first", func(t *testing.T) {
+		// Paying the largest debts first takes four payments; ana and dani
+		// cancel out, so three are enough
+		balances := []*entities.MemberBalance{
+			balance("ana", "3.00"),
+			balance("bia", "4.00"),
+			balance("caio", "-1.00"),
+			balance("dani", "-3.00"),
+			balance("edu", "-3.00"),
+		}
+
+		payments, err := entities.PlanSettlements(balances)
+
+		require.NoError(t, err)
+		assert.Len(t, payments, 3)
+		assertSettles(t, balances, payments)
+	})
+
+	t.Run("pairs that cancel out pay each other", func(t *testing.T) {
+		balances := []*entities.MemberBalance{
+			balance("ana", "7.00"),
+			balance("bia", "4.00"),
+			balance("caio", "-4.00"),
+			balance("dani", "-7.00"),
+		}
+
+		payments, err := entities.PlanSettlements(balances)
+
+		require.NoError(t, err)
+		require.Len(t, payments, 2)
+		assertSettles(t, balances, payments)
+		for _, payment := range payments {
+			if payment.DebtorID == "caio" {
+				assert.Equal(t, "bia", payment.CreditorID)
+			}
+		}
+	})
+
+	t.Run("currencies are settled separately", func(t *testing.T) {
+		owed, err := money.Parse("10.00", "USD")
+		require.NoError(t, err)
+		owes, err := money.Parse("-10.00", "USD")
+		require.NoError(t, err)
+
+		payments, err := entities.PlanSettlements([]*entities.MemberBalance{
+			balance("ana", "-5.00"),
+			balance("bia", "5.00"),
+			{UserID: "ana", Net: owed},
+			{UserID: "bia", Net: owes},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, payments, 2)
+		assert.Equal(t, "BRL", payments[0].Amount.Currency().Code)
+		assert.Equal(t, "ana", payments[0].DebtorID)
+		assert.Equal(t, "USD", payments[1].Amount.Currency().Code)
+		assert.Equal(t, "bia", payments[1].DebtorID)
+	})
+
+	t.Run("settled wallet", func(t *testing.T) {
+		payments, err := entities.PlanSettlements(nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, payments)
+	})
+
+	t.Run("balances that do not add up", func(t *testing.T) {
+		payments, err := entities.PlanSettlements([]*entities.MemberBalance{
+			balance("ana", "5.00"),
+			balance("bia", "-4.00"),
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, payments)
+	})
+}
+
+// assertSettles checks that making the payments brings every balance to zero.
+func assertSettles(t *testing.T, balances []*entities.MemberBalance, payments []*entities.Debt) {
+	t.Helper()
+
+	remaining := make(map[string]int64)
+	for _, balance := range balances {
+		remaining[balance.UserID] += balance.Net.MinorUnits()
+	}
+	for _, payment := range payments {
+		remaining[payment.DebtorID] += payment.Amount.MinorUnits()
+		remaining[payment.CreditorID] -= payment.Amount.MinorUnits()
+	}
+	for userID, amount := range remaining {
+		assert.Zero(t, amount, userID)
+	}
+}
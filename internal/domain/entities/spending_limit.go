@@ -0,0 +1,184 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// LimitPeriod is what a spending limit caps: each expense on its own, or
+// the expenses of a calendar day, week or month.
+type LimitPeriod string
+
+const (
+	LimitPeriodTransaction LimitPeriod = "TRANSACTION"
+	LimitPeriodDay         LimitPeriod = "DAY"
+	LimitPeriodWeek        LimitPeriod = "WEEK"
+	LimitPeriodMonth       LimitPeriod = "MONTH"
+)
+
+// limitWarningPercent is how much of a period limit a member can use before
+// the wallet owners are warned.
+const limitWarningPercent = 80
+
+var ErrSpendingLimitExceeded = errors.New("expense exceeds the spending limit")
+
+func NewLimitPeriod(period string) (LimitPeriod, error) {
+	formattedPeriod := LimitPeriod(strings.ToUpper(period))
+	switch formattedPeriod {
+	case LimitPeriodTransaction, LimitPeriodDay, LimitPeriodWeek, LimitPeriodMonth:
+		return formattedPeriod, nil
+	default:
+		return "", fmt.Errorf("invalid limit period: %s", period)
+	}
+}
+
+// Bounds returns the first and last day of the period holding date. Weeks
+// start on Monday. A per-transaction limit has no period, so both are zero.
+func (p LimitPeriod) Bounds(date time.Time) (time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	switch p {
+	case LimitPeriodDay:
+		return day, day
+	case LimitPeriodWeek:
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 6)
+	case LimitPeriodMonth:
+		start := day.AddDate(0, 0, 1-day.Day())
+		return start, start.AddDate(0, 1, -1)
+	default:
+		return time.Time{}, time.Time{}
+	}
+}
+
+// SpendingLimit caps the expenses a member records in a wallet, either all
+// of them or only those under CategoryID.
+type SpendingLimit struct {
+	ID         string
+	WalletID   string
+	UserID     string
+	CategoryID string
+	Period     LimitPeriod
+	Amount     money.Money
+	CreatedBy  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewSpendingLimit(
+	walletID string,
+	userID string,
+	categoryID string,
+	period LimitPeriod,
+	amount money.Money,
+	createdBy string,
+) (*SpendingLimit, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet is required")
+	}
+
+	if userID == "" {
+		return nil, fmt.Errorf("member is required")
+	}
+
+	if _, err := NewLimitPeriod(string(period)); err != nil {
+		return nil, err
+	}
+
+	limit := &SpendingLimit{
+		ID:         uuid.NewString(),
+		WalletID:   walletID,
+		UserID:     userID,
+		CategoryID: categoryID,
+		Period:     period,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}
+	if err := limit.SetAmount(amount); err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+func (l *SpendingLimit) SetAmount(amount money.Money) error {
+	if amount.Currency().Code == "" {
+		return fmt.Errorf("limit amount is required")
+	}
+
+	if !amount.IsPositive() {
+		return fmt.Errorf("limit amount must be greater than zero")
+	}
+
+	l.Amount = amount
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+// Covers returns the part of the expense the limit counts: all of it, or
+// only the lines under the limit category. ok is false when the limit does
+// not apply to the expense at all.
+func (l *SpendingLimit) Covers(transaction *Transaction) (money.Money, bool) {
+	if transaction.Type != TransactionTypeExpense || transaction.WalletID != l.WalletID ||
+		transaction.CreatedBy != l.UserID {
+		return money.Money{}, false
+	}
+
+	if l.CategoryID == "" {
+		return transaction.Amount, true
+	}
+
+	if !transaction.IsSplit() {
+		return transaction.Amount, transaction.CategoryID == l.CategoryID
+	}
+
+	covered, _ := money.Zero(transaction.Amount.Currency().Code)
+	found := false
+	for _, split := range transaction.Splits {
+		if split.CategoryID != l.CategoryID {
+			continue
+		}
+		covered, _ = covered.Add(split.Amount)
+		found = true
+	}
+	return covered, found
+}
+
+// Check refuses an expense of amount when, added to what the member used in
+// the period so far, it goes over the limit. Both are in the limit currency.
+func (l *SpendingLimit) Check(used, amount money.Money) error {
+	total, err := used.Add(amount)
+	if err != nil {
+		return err
+	}
+
+	cmp, err := total.Cmp(l.Amount)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return ErrSpendingLimitExceeded
+	}
+	return nil
+}
+
+// Nears reports whether an expense of amount takes the member from under
+// the warning share of a period limit to at or over it, so owners are
+// warned once per period rather than on every expense.
+func (l *SpendingLimit) Nears(used, amount money.Money) bool {
+	if l.Period == LimitPeriodTransaction {
+		return false
+	}
+
+	total, err := used.Add(amount)
+	if err != nil {
+		return false
+	}
+
+	warning := l.Amount.MinorUnits() * limitWarningPercent / 100
+	return used.MinorUnits() < warning && total.MinorUnits() >= warning
+}
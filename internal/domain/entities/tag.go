@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const maxTagNameLength = 40
+
+type TagScope string
+
+const (
+	TagScopeUser   TagScope = "USER"
+	TagScopeWallet TagScope = "WALLET"
+)
+
+var ErrTagScopeMismatch = errors.New("tags belong to different owners")
+
+// Tag is a free-form label shared by the transactions of a user or of a
+// wallet, depending on its scope.
+type Tag struct {
+	ID        string
+	Scope     TagScope
+	OwnerID   string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TagUsage is a tag with statistics over its non-deleted transactions.
+type TagUsage struct {
+	Tag              *Tag
+	TransactionCount int
+	LastUsedAt       time.Time
+}
+
+func NewTag(scope TagScope, ownerID, name string) (*Tag, error) {
+	if scope != TagScopeUser && scope != TagScopeWallet {
+		return nil, fmt.Errorf("invalid tag scope: %s", scope)
+	}
+
+	if ownerID == "" {
+		return nil, fmt.Errorf("tag owner is required")
+	}
+
+	tag := &Tag{
+		ID:        uuid.NewString(),
+		Scope:     scope,
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := tag.Rename(name); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (t *Tag) Rename(name string) error {
+	normalized := NormalizeTagName(name)
+	if normalized == "" {
+		return fmt.Errorf("tag name is required")
+	}
+
+	if len(normalized) > maxTagNameLength {
+		return fmt.Errorf("tag name must be at most %d characters", maxTagNameLength)
+	}
+
+	t.Name = normalized
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SameOwner reports whether both tags live in the same user or wallet.
+func (t *Tag) SameOwner(other *Tag) bool {
+	return t.Scope == other.Scope && t.OwnerID == other.OwnerID
+}
+
+// CanMergeInto checks that target can take over the tag's transactions.
+func (t *Tag) CanMergeInto(target *Tag) error {
+	if target.ID == t.ID {
+		return fmt.Errorf("cannot merge a tag into itself")
+	}
+	if !t.SameOwner(target) {
+		return ErrTagScopeMismatch
+	}
+	return nil
+}
+
+// AppliesTo reports whether the tag can label the transaction: user tags
+// follow the transaction author, wallet tags the transaction wallet.
+func (t *Tag) AppliesTo(transaction *Transaction) bool {
+	switch t.Scope {
+	case TagScopeUser:
+		return t.OwnerID == transaction.CreatedBy
+	case TagScopeWallet:
+		return t.OwnerID == transaction.WalletID
+	default:
+		return false
+	}
+}
+
+// NormalizeTagName lowercases the name and joins its words with hyphens, so
+// "Vacation 2026" and "vacation-2026" are the same tag.
+func NormalizeTagName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-'
+	})
+	return strings.Join(words, "-")
+}
+
+// UniqueIDs sorts the ids and drops empty and repeated ones.
+func UniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	normalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		normalized = append(normalized, id)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
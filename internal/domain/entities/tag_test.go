@@ -0,0 +1,86 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    entities.TagScope
+		ownerID  string
+		tagName  string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "user tag", scope: entities.TagScopeUser, ownerID: "user-id", tagName: "Vacation 2026", wantName: "vacation-2026"},
+		{name: "wallet tag", scope: entities.TagScopeWallet, ownerID: "wallet-id", tagName: " Road -- Trip ", wantName: "road-trip"},
+		{name: "invalid scope", scope: "HOUSEHOLD", ownerID: "user-id", tagName: "trip", wantErr: true},
+		{name: "missing owner", scope: entities.TagScopeUser, ownerID: "", tagName: "trip", wantErr: true},
+		{name: "missing name", scope: entities.TagScopeUser, ownerID: "user-id", tagName: " - ", wantErr: true},
+		{name: "name too long", scope: entities.TagScopeUser, ownerID: "user-id", tagName: strings.Repeat("a", 41), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := entities.NewTag(tt.scope, tt.ownerID, tt.tagName)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, tag)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, tag.ID)
+			assert.Equal(t, tt.wantName, tag.Name)
+		})
+	}
+}
+
+func TestTag_CanMergeInto(t *testing.T) {
+	trip, err := entities.NewTag(entities.TagScopeUser, "user-id", "trip")
+	require.NoError(t, err)
+	vacation, err := entities.NewTag(entities.TagScopeUser, "user-id", "vacation")
+	require.NoError(t, err)
+	walletTag, err := entities.NewTag(entities.TagScopeWallet, "user-id", "vacation")
+	require.NoError(t, err)
+
+	assert.NoError(t, trip.CanMergeInto(vacation))
+	assert.Error(t, trip.CanMergeInto(trip))
+	assert.ErrorIs(t, trip.CanMergeInto(walletTag), entities.ErrTagScopeMismatch)
+}
+
+func TestTag_AppliesTo(t *testing.T) {
+	transaction := &entities.Transaction{WalletID: "wallet-id", CreatedBy: "user-id"}
+
+	tests := []struct {
+		name    string
+		scope   entities.TagScope
+		ownerID string
+		want    bool
+	}{
+		{name: "author's tag", scope: entities.TagScopeUser, ownerID: "user-id", want: true},
+		{name: "another user's tag", scope: entities.TagScopeUser, ownerID: "other-id", want: false},
+		{name: "wallet's tag", scope: entities.TagScopeWallet, ownerID: "wallet-id", want: true},
+		{name: "another wallet's tag", scope: entities.TagScopeWallet, ownerID: "other-wallet-id", want: false},
+		{name: "user id as wallet owner", scope: entities.TagScopeWallet, ownerID: "user-id", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := &entities.Tag{ID: "tag-id", Scope: tt.scope, OwnerID: tt.ownerID, Name: "trip"}
+			assert.Equal(t, tt.want, tag.AppliesTo(transaction))
+		})
+	}
+}
+
+func TestUniqueIDs(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, entities.UniqueIDs([]string{"c", "a", "", "b", "a"}))
+	assert.Empty(t, entities.UniqueIDs(nil))
+}
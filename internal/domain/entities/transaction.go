@@ -0,0 +1,213 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const maxTransactionDescriptionLength = 255
+
+type TransactionType string
+
+const (
+	TransactionTypeIncome  TransactionType = "INCOME"
+	TransactionTypeExpense TransactionType = "EXPENSE"
+
+	// Transfer legs are created through a Transfer only, so they are not
+	// accepted by NewTransactionType and never count as income or expense.
+	TransactionTypeTransferOut TransactionType = "TRANSFER_OUT"
+	TransactionTypeTransferIn  TransactionType = "TRANSFER_IN"
+)
+
+func NewTransactionType(transactionType string) (TransactionType, error) {
+	formattedType := TransactionType(strings.ToUpper(transactionType))
+	switch formattedType {
+	case TransactionTypeIncome, TransactionTypeExpense:
+		return formattedType, nil
+	default:
+		return "", fmt.Errorf("invalid transaction type: %s", transactionType)
+	}
+}
+
+// Transaction is a single income or expense in a wallet, or one leg of a
+// transfer between wallets. Amount is always positive; Type gives the
+// direction.
+type Transaction struct {
+	ID          string
+	WalletID    string
+	Type        TransactionType
+	Amount      money.Money
+	Date        time.Time
+	Description string
+	Status      TransactionStatus
+	CategoryID  string
+	PayeeID     string
+	TagIDs      []string
+	Splits      []TransactionSplit
+	Sharing     *ExpenseSharing
+	TransferID  string
+	// RecurringID and OccurrenceDate link a transaction to the occurrence of
+	// the recurring transaction that created it.
+	RecurringID    string
+	OccurrenceDate time.Time
+	// AwaitingApproval is set while the expense is held for an owner's
+	// review; see HoldForApproval.
+	AwaitingApproval bool
+	CreatedBy        string
+	IsDeleted        bool
+	DeletedAt        time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func NewTransaction(
+	walletID string,
+	transactionType TransactionType,
+	amount money.Money,
+	date time.Time,
+	description string,
+	categoryID string,
+	createdBy string,
+) (*Transaction, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet is required")
+	}
+
+	if createdBy == "" {
+		return nil, fmt.Errorf("transaction author is required")
+	}
+
+	transaction := &Transaction{
+		ID:        uuid.NewString(),
+		WalletID:  walletID,
+		CreatedBy: createdBy,
+		IsDeleted: false,
+		DeletedAt: time.Time{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := transaction.Update(transactionType, amount, date, description, categoryID); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// Update replaces the editable fields after validating them.
+func (t *Transaction) Update(
+	transactionType TransactionType,
+	amount money.Money,
+	date time.Time,
+	description string,
+	categoryID string,
+) error {
+	if _, err := NewTransactionType(string(transactionType)); err != nil {
+		return err
+	}
+
+	if t.Status == TransactionStatusReconciled && transactionType != t.Type {
+		return ErrTransactionReconciled
+	}
+
+	if t.AwaitingApproval && (transactionType != t.Type || !amount.Equal(t.Amount)) {
+		return ErrAwaitingApproval
+	}
+
+	if err := t.setDetails(amount, date, description); err != nil {
+		return err
+	}
+
+	t.Type = transactionType
+	t.CategoryID = categoryID
+	return nil
+}
+
+// setDetails validates and sets the fields shared by every kind of
+// transaction, including transfer legs.
+func (t *Transaction) setDetails(amount money.Money, date time.Time, description string) error {
+	if amount.Currency().Code == "" {
+		return fmt.Errorf("currency is required")
+	}
+
+	if !amount.IsPositive() {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+
+	if date.IsZero() {
+		return fmt.Errorf("date is required")
+	}
+
+	description = strings.TrimSpace(description)
+	if len(description) > maxTransactionDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", maxTransactionDescriptionLength)
+	}
+
+	if t.Status == TransactionStatusReconciled &&
+		(!amount.Equal(t.Amount) || !truncateToDay(date).Equal(t.Date)) {
+		return ErrTransactionReconciled
+	}
+
+	status, err := t.statusOn(date)
+	if err != nil {
+		return err
+	}
+
+	t.Amount = amount
+	t.Status = status
+	t.Date = truncateToDay(date)
+	t.Description = description
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SignedAmount is the effect of the transaction on the wallet balance:
+// positive for income and incoming transfers, negative for expenses and
+// outgoing transfers.
+func (t *Transaction) SignedAmount() money.Money {
+	if t.Type == TransactionTypeExpense || t.Type == TransactionTypeTransferOut {
+		negated, _ := t.Amount.Negate()
+		return negated
+	}
+	return t.Amount
+}
+
+// IsTransfer reports whether the transaction is a leg of a transfer, which
+// moves money between wallets and is neither income nor expense.
+func (t *Transaction) IsTransfer() bool {
+	return t.TransferID != ""
+}
+
+// SetTags replaces the transaction tags, ignoring repeated ids.
+func (t *Transaction) SetTags(tagIDs []string) {
+	t.TagIDs = UniqueIDs(tagIDs)
+	t.UpdatedAt = time.Now()
+}
+
+// SetPayee links the transaction to a payee; an empty ID unlinks it.
+func (t *Transaction) SetPayee(payeeID string) {
+	t.PayeeID = payeeID
+	t.UpdatedAt = time.Now()
+}
+
+// SuggestCategory files the transaction under categoryID unless it already
+// has a category, on itself or on its split lines. It reports whether the
+// category was applied.
+func (t *Transaction) SuggestCategory(categoryID string) bool {
+	if categoryID == "" || t.CategoryID != "" || t.IsSplit() || t.IsTransfer() {
+		return false
+	}
+
+	t.CategoryID = categoryID
+	return true
+}
+
+func (t *Transaction) Delete() {
+	t.IsDeleted = true
+	t.DeletedAt = time.Now()
+	t.UpdatedAt = t.DeletedAt
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxBulkTransactions caps how many transactions a bulk operation selects.
+const MaxBulkTransactions = 500
+
+// BulkOperation is a change applied to many transactions at once.
+type BulkOperation string
+
+const (
+	BulkRecategorize BulkOperation = "RECATEGORIZE"
+	BulkRetag        BulkOperation = "RETAG"
+	BulkMove         BulkOperation = "MOVE"
+	BulkDelete       BulkOperation = "DELETE"
+)
+
+func NewBulkOperation(operation string) (BulkOperation, error) {
+	switch BulkOperation(operation) {
+	case BulkRecategorize, BulkRetag, BulkMove, BulkDelete:
+		return BulkOperation(operation), nil
+	default:
+		return "", fmt.Errorf("invalid bulk operation: %s", operation)
+	}
+}
+
+// Recategorize files the transaction under categoryID, or leaves it
+// uncategorized when categoryID is empty.
+func (t *Transaction) Recategorize(categoryID string) error {
+	if t.IsSplit() {
+		return ErrSplitCategory
+	}
+
+	t.CategoryID = categoryID
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Retag adds and removes tags, keeping the others. A tag in both lists is
+// removed.
+func (t *Transaction) Retag(addTagIDs, removeTagIDs []string) {
+	removed := make(map[string]bool, len(removeTagIDs))
+	for _, tagID := range removeTagIDs {
+		removed[tagID] = true
+	}
+
+	tagIDs := make([]string, 0, len(t.TagIDs)+len(addTagIDs))
+	for _, tagID := range append(append([]string{}, t.TagIDs...), addTagIDs...) {
+		if !removed[tagID] {
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+	t.SetTags(tagIDs)
+}
+
+// MoveTo moves the transaction to another wallet.
+func (t *Transaction) MoveTo(walletID string) error {
+	if walletID == "" {
+		return fmt.Errorf("wallet is required")
+	}
+	if walletID == t.WalletID {
+		return fmt.Errorf("transaction is already in the wallet")
+	}
+	if t.Status == TransactionStatusReconciled {
+		return ErrTransactionReconciled
+	}
+	if t.AwaitingApproval {
+		return ErrAwaitingApproval
+	}
+
+	t.WalletID = walletID
+	t.UpdatedAt = time.Now()
+	return nil
+}
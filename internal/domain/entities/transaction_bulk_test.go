@@ -0,0 +1,55 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBulkOperation(t *testing.T) {
+	for _, input := range []string{"RECATEGORIZE", "RETAG", "MOVE", "DELETE"} {
+		operation, err := entities.NewBulkOperation(input)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BulkOperation(input), operation)
+	}
+
+	_, err := entities.NewBulkOperation("ARCHIVE")
+	assert.Error(t, err)
+}
+
+func TestTransaction_Recategorize(t *testing.T) {
+	transaction := newSharedTestTransaction(t, "30.00")
+
+	require.NoError(t, transaction.Recategorize("food-id"))
+	assert.Equal(t, "food-id", transaction.CategoryID)
+
+	require.NoError(t, transaction.Recategorize(""))
+	assert.Empty(t, transaction.CategoryID)
+
+	require.NoError(t, transaction.SetSplits([]entities.TransactionSplit{
+		newSplit(t, "20.00", "BRL", "food-id"),
+		newSplit(t, "10.00", "BRL", "pharmacy-id"),
+	}))
+	assert.ErrorIs(t, transaction.Recategorize("food-id"), entities.ErrSplitCategory)
+}
+
+func TestTransaction_Retag(t *testing.T) {
+	transaction := newSharedTestTransaction(t, "30.00")
+	transaction.SetTags([]string{"trip", "work"})
+
+	transaction.Retag([]string{"family", "trip", "receipt"}, []string{"work", "receipt"})
+
+	assert.Equal(t, []string{"family", "trip"}, transaction.TagIDs)
+}
+
+func TestTransaction_MoveTo(t *testing.T) {
+	transaction := newSharedTestTransaction(t, "30.00")
+
+	assert.Error(t, transaction.MoveTo(""))
+	assert.Error(t, transaction.MoveTo("wallet-id"))
+
+	require.NoError(t, transaction.MoveTo("savings-id"))
+	assert.Equal(t, "savings-id", transaction.WalletID)
+}
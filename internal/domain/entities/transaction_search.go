@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxSearchQueryLength = 200
+
+// Search snippets mark the words that matched between these markers.
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightStop  = "</mark>"
+)
+
+// TransactionSearchResult is a transaction found by a full-text search.
+// Snippet is the matching text with the matched words marked, and Rank
+// orders results from most to least relevant.
+type TransactionSearchResult struct {
+	Transaction *Transaction
+	Rank        float64
+	Snippet     string
+}
+
+// NormalizeSearchQuery trims a search query and checks it can be run.
+func NormalizeSearchQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", fmt.Errorf("search query is required")
+	}
+	if len(query) > maxSearchQueryLength {
+		return "", fmt.Errorf("search query must be at most %d characters", maxSearchQueryLength)
+	}
+	return query, nil
+}
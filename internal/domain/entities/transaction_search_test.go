@@ -0,0 +1,21 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSearchQuery(t *testing.T) {
+	query, err := entities.NormalizeSearchQuery("  plumber march ")
+	assert.NoError(t, err)
+	assert.Equal(t, "plumber march", query)
+
+	_, err = entities.NormalizeSearchQuery(" \t ")
+	assert.Error(t, err)
+
+	_, err = entities.NormalizeSearchQuery(strings.Repeat("a", 201))
+	assert.Error(t, err)
+}
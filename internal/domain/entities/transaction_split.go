@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const (
+	minTransactionSplits          = 2
+	maxTransactionSplits          = 50
+	maxTransactionSplitNoteLength = 255
+)
+
+var (
+	ErrSplitSumMismatch = errors.New("split lines must add up to the transaction amount")
+	ErrSplitCategory    = errors.New("a split transaction takes its categories from its lines")
+)
+
+// TransactionSplit is one line of a split transaction, such as the pharmacy
+// part of a supermarket receipt. Reports count split lines instead of their
+// transaction, so each line carries its own category.
+type TransactionSplit struct {
+	ID         string
+	CategoryID string
+	Amount     money.Money
+	Note       string
+}
+
+func NewTransactionSplit(amount money.Money, categoryID, note string) (TransactionSplit, error) {
+	if amount.Currency().Code == "" {
+		return TransactionSplit{}, fmt.Errorf("split amount is required")
+	}
+
+	if !amount.IsPositive() {
+		return TransactionSplit{}, fmt.Errorf("split amount must be greater than zero")
+	}
+
+	note = strings.TrimSpace(note)
+	if len(note) > maxTransactionSplitNoteLength {
+		return TransactionSplit{}, fmt.Errorf("split note must be at most %d characters", maxTransactionSplitNoteLength)
+	}
+
+	return TransactionSplit{
+		ID:         uuid.NewString(),
+		CategoryID: categoryID,
+		Amount:     amount,
+		Note:       note,
+	}, nil
+}
+
+// SetSplits replaces the split lines of the transaction. The lines must be
+// in the transaction currency and add up exactly to its amount; no lines
+// makes it a plain transaction again. A split transaction is categorised by
+// its lines, so it cannot have a category of its own.
+func (t *Transaction) SetSplits(splits []TransactionSplit) error {
+	if len(splits) == 0 {
+		t.Splits = nil
+		t.UpdatedAt = time.Now()
+		return nil
+	}
+
+	if t.IsTransfer() {
+		return fmt.Errorf("transfers cannot be split")
+	}
+
+	if len(splits) < minTransactionSplits || len(splits) > maxTransactionSplits {
+		return fmt.Errorf("a split transaction must have between %d and %d lines", minTransactionSplits, maxTransactionSplits)
+	}
+
+	if t.CategoryID != "" {
+		return ErrSplitCategory
+	}
+
+	amounts := make([]money.Money, 0, len(splits))
+	for _, split := range splits {
+		if !split.Amount.SameCurrency(t.Amount) {
+			return fmt.Errorf("split amounts must be in %s", t.Amount.Currency().Code)
+		}
+		amounts = append(amounts, split.Amount)
+	}
+
+	total, err := money.Sum(t.Amount.Currency().Code, amounts...)
+	if err != nil {
+		return err
+	}
+	if !total.Equal(t.Amount) {
+		return fmt.Errorf("%w: lines total %s, transaction is %s", ErrSplitSumMismatch, total, t.Amount)
+	}
+
+	t.Splits = splits
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsSplit reports whether the transaction is divided into split lines.
+func (t *Transaction) IsSplit() bool {
+	return len(t.Splits) > 0
+}
+
+// CategoryIDs lists the categories the transaction is counted under: those
+// of its split lines, or its own category.
+func (t *Transaction) CategoryIDs() []string {
+	if !t.IsSplit() {
+		return UniqueIDs([]string{t.CategoryID})
+	}
+
+	ids := make([]string, 0, len(t.Splits))
+	for _, split := range t.Splits {
+		ids = append(ids, split.CategoryID)
+	}
+	return UniqueIDs(ids)
+}
@@ -0,0 +1,141 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSplit(t *testing.T, value, currency, categoryID string) entities.TransactionSplit {
+	t.Helper()
+	amount, err := money.Parse(value, currency)
+	require.NoError(t, err)
+	split, err := entities.NewTransactionSplit(amount, categoryID, "")
+	require.NoError(t, err)
+	return split
+}
+
+func TestNewTransactionSplit(t *testing.T) {
+	amount, _ := money.Parse("10.00", "BRL")
+	zero, _ := money.Parse("0", "BRL")
+
+	split, err := entities.NewTransactionSplit(amount, "category-id", "  Vitamins ")
+	require.NoError(t, err)
+	assert.NotEmpty(t, split.ID)
+	assert.Equal(t, "Vitamins", split.Note)
+
+	_, err = entities.NewTransactionSplit(zero, "", "")
+	assert.Error(t, err)
+
+	_, err = entities.NewTransactionSplit(money.Money{}, "", "")
+	assert.Error(t, err)
+}
+
+func TestTransaction_SetSplits(t *testing.T) {
+	tests := []struct {
+		name       string
+		categoryID string
+		splits     func(t *testing.T) []entities.TransactionSplit
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{
+			name: "lines add up to the total",
+			splits: func(t *testing.T) []entities.TransactionSplit {
+				return []entities.TransactionSplit{
+					newSplit(t, "60.00", "BRL", "groceries-id"),
+					newSplit(t, "25.50", "BRL", "household-id"),
+					newSplit(t, "14.50", "BRL", "pharmacy-id"),
+				}
+			},
+		},
+		{
+			name: "no lines",
+			splits: func(t *testing.T) []entities.TransactionSplit {
+				return nil
+			},
+		},
+		{
+			name: "lines short of the total",
+			splits: func(t *testing.T) []entities.TransactionSplit {
+				return []entities.TransactionSplit{
+					newSplit(t, "60.00", "BRL", ""),
+					newSplit(t, "39.99", "BRL", ""),
+				}
+			},
+			wantErr: entities.ErrSplitSumMismatch,
+		},
+		{
+			name: "single line",
+			splits: func(t *testing.T) []entities.TransactionSplit {
+				return []entities.TransactionSplit{newSplit(t, "100.00", "BRL", "")}
+			},
+			wantAnyErr: true,
+		},
+		{
+			name: "line in another currency",
+			splits: func(t *testing.T) []entities.TransactionSplit {
+				return []entities.TransactionSplit{
+					newSplit(t, "60.00", "BRL", ""),
+					newSplit(t, "40.00", "USD", ""),
+				}
+			},
+			wantAnyErr: true,
+		},
+		{
+			name:       "transaction with its own category",
+			categoryID: "groceries-id",
+			splits: func(t *testing.T) []entities.TransactionSplit {
+				return []entities.TransactionSplit{
+					newSplit(t, "60.00", "BRL", ""),
+					newSplit(t, "40.00", "BRL", ""),
+				}
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, _ := money.Parse("100.00", "BRL")
+			transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, time.Now(), "Supermarket", tt.categoryID, "user-id")
+			require.NoError(t, err)
+
+			splits := tt.splits(t)
+			err = transaction.SetSplits(splits)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, transaction.IsSplit())
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+				assert.False(t, transaction.IsSplit())
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, len(splits) > 0, transaction.IsSplit())
+			}
+		})
+	}
+}
+
+func TestTransaction_CategoryIDs(t *testing.T) {
+	amount, _ := money.Parse("100.00", "BRL")
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, time.Now(), "", "groceries-id", "user-id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"groceries-id"}, transaction.CategoryIDs())
+
+	transaction.CategoryID = ""
+	assert.Empty(t, transaction.CategoryIDs())
+
+	require.NoError(t, transaction.SetSplits([]entities.TransactionSplit{
+		newSplit(t, "50.00", "BRL", "pharmacy-id"),
+		newSplit(t, "30.00", "BRL", "groceries-id"),
+		newSplit(t, "20.00", "BRL", "pharmacy-id"),
+	}))
+	assert.Equal(t, []string{"groceries-id", "pharmacy-id"}, transaction.CategoryIDs())
+}
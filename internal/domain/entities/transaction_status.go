@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TransactionStatus is where a transaction is in its lifecycle: scheduled
+// for a future date, pending with the bank, cleared, and finally reconciled
+// against a statement.
+type TransactionStatus string
+
+const (
+	TransactionStatusScheduled  TransactionStatus = "SCHEDULED"
+	TransactionStatusPending    TransactionStatus = "PENDING"
+	TransactionStatusCleared    TransactionStatus = "CLEARED"
+	TransactionStatusReconciled TransactionStatus = "RECONCILED"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	// ErrTransactionReconciled is returned when a change would alter a
+	// reconciled transaction's effect on the balance.
+	ErrTransactionReconciled = errors.New("reconciled transactions cannot change amount, date or wallet; un-reconcile it first")
+)
+
+// nextStatus is the status each one moves on to. A reconciled transaction
+// can only go back to cleared, which un-reconciles it.
+var nextStatus = map[TransactionStatus]TransactionStatus{
+	TransactionStatusScheduled:  TransactionStatusPending,
+	TransactionStatusPending:    TransactionStatusCleared,
+	TransactionStatusCleared:    TransactionStatusReconciled,
+	TransactionStatusReconciled: TransactionStatusCleared,
+}
+
+func NewTransactionStatus(status string) (TransactionStatus, error) {
+	switch TransactionStatus(status) {
+	case TransactionStatusScheduled, TransactionStatusPending, TransactionStatusCleared, TransactionStatusReconciled:
+		return TransactionStatus(status), nil
+	default:
+		return "", fmt.Errorf("invalid transaction status: %s", status)
+	}
+}
+
+// IsPosted reports whether the transaction has gone through and counts
+// towards the current balance.
+func (s TransactionStatus) IsPosted() bool {
+	return s == TransactionStatusCleared || s == TransactionStatusReconciled
+}
+
+// SetStatus moves the transaction one step along scheduled → pending →
+// cleared → reconciled, or un-reconciles it back to cleared. A transaction
+// is scheduled exactly while its date is in the future, so it only becomes
+// pending when its date comes.
+func (t *Transaction) SetStatus(status TransactionStatus) error {
+	if _, err := NewTransactionStatus(string(status)); err != nil {
+		return err
+	}
+
+	if status == t.Status {
+		return nil
+	}
+
+	if nextStatus[t.Status] != status {
+		return fmt.Errorf("%w: %s transactions can only move to %s",
+			ErrInvalidStatusTransition, strings.ToLower(string(t.Status)), strings.ToLower(string(nextStatus[t.Status])))
+	}
+
+	if t.AwaitingApproval && status.IsPosted() {
+		return ErrAwaitingApproval
+	}
+
+	return t.moveTo(status)
+}
+
+// SetInitialStatus sets the status a new transaction is entered with, such
+// as a card purchase that is still pending. Transactions are only reconciled
+// against a statement, never entered reconciled.
+func (t *Transaction) SetInitialStatus(status TransactionStatus) error {
+	if _, err := NewTransactionStatus(string(status)); err != nil {
+		return err
+	}
+
+	if status == TransactionStatusReconciled {
+		return fmt.Errorf("%w: transactions are reconciled against a statement, not entered reconciled", ErrInvalidStatusTransition)
+	}
+
+	if status == t.Status {
+		return nil
+	}
+
+	return t.moveTo(status)
+}
+
+func (t *Transaction) moveTo(status TransactionStatus) error {
+	if (status == TransactionStatusScheduled) != isFutureDate(t.Date) {
+		return fmt.Errorf("%w: only transactions dated in the future are scheduled", ErrInvalidStatusTransition)
+	}
+
+	t.Status = status
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// statusOn returns the status the transaction takes when dated date, so
+// that it is scheduled exactly while its date is in the future. A new
+// transaction dated today or earlier is cleared, and a scheduled one whose
+// date comes becomes pending.
+func (t *Transaction) statusOn(date time.Time) (TransactionStatus, error) {
+	future := isFutureDate(date)
+	switch {
+	case future:
+		return TransactionStatusScheduled, nil
+	case t.Status == "":
+		return TransactionStatusCleared, nil
+	case t.Status == TransactionStatusScheduled:
+		return TransactionStatusPending, nil
+	default:
+		return t.Status, nil
+	}
+}
+
+// isFutureDate reports whether date is after today.
+func isFutureDate(date time.Time) bool {
+	return truncateToDay(date).After(truncateToDay(time.Now()))
+}
@@ -0,0 +1,150 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransactionStatus(t *testing.T) {
+	for _, input := range []string{"SCHEDULED", "PENDING", "CLEARED", "RECONCILED"} {
+		status, err := entities.NewTransactionStatus(input)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TransactionStatus(input), status)
+	}
+
+	_, err := entities.NewTransactionStatus("VOID")
+	assert.Error(t, err)
+}
+
+func TestTransaction_StatusFollowsDate(t *testing.T) {
+	amount := brl(t, "10.00")
+	today := time.Now()
+	nextWeek := today.AddDate(0, 0, 7)
+
+	t.Run("new transactions", func(t *testing.T) {
+		past, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, today, "Rent", "", "user-id")
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransactionStatusCleared, past.Status)
+
+		future, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, nextWeek, "Rent", "", "user-id")
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransactionStatusScheduled, future.Status)
+	})
+
+	t.Run("scheduled transaction dated today becomes pending", func(t *testing.T) {
+		transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, nextWeek, "Rent", "", "user-id")
+		require.NoError(t, err)
+
+		require.NoError(t, transaction.Update(transaction.Type, amount, today, "Rent", ""))
+		assert.Equal(t, entities.TransactionStatusPending, transaction.Status)
+	})
+
+	t.Run("reconciled transaction cannot move to the future", func(t *testing.T) {
+		transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, today, "Rent", "", "user-id")
+		require.NoError(t, err)
+		require.NoError(t, transaction.SetStatus(entities.TransactionStatusReconciled))
+
+		assert.Error(t, transaction.Update(transaction.Type, amount, nextWeek, "Rent", ""))
+		assert.Equal(t, entities.TransactionStatusReconciled, transaction.Status)
+	})
+}
+
+func TestTransaction_SetStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    time.Time
+		from    entities.TransactionStatus
+		to      entities.TransactionStatus
+		wantErr bool
+	}{
+		{name: "pending to cleared", date: time.Now(), from: entities.TransactionStatusPending, to: entities.TransactionStatusCleared},
+		{name: "cleared to reconciled", date: time.Now(), from: entities.TransactionStatusCleared, to: entities.TransactionStatusReconciled},
+		{name: "reconciled back to cleared", date: time.Now(), from: entities.TransactionStatusReconciled, to: entities.TransactionStatusCleared},
+		{name: "cleared back to pending", date: time.Now(), from: entities.TransactionStatusCleared, to: entities.TransactionStatusPending, wantErr: true},
+		{name: "pending cannot skip to reconciled", date: time.Now(), from: entities.TransactionStatusPending, to: entities.TransactionStatusReconciled, wantErr: true},
+		{name: "reconciled cannot go back to pending", date: time.Now(), from: entities.TransactionStatusReconciled, to: entities.TransactionStatusPending, wantErr: true},
+		{name: "past transaction cannot be scheduled", date: time.Now(), from: entities.TransactionStatusPending, to: entities.TransactionStatusScheduled, wantErr: true},
+		{name: "future transaction stays scheduled", date: time.Now().AddDate(0, 0, 3), from: entities.TransactionStatusScheduled, to: entities.TransactionStatusPending, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, brl(t, "10.00"), tt.date, "Card purchase", "", "user-id")
+			require.NoError(t, err)
+			transaction.Status = tt.from
+
+			err = transaction.SetStatus(tt.to)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entities.ErrInvalidStatusTransition)
+				assert.Equal(t, tt.from, transaction.Status)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, transaction.Status)
+		})
+	}
+}
+
+func TestTransaction_SetInitialStatus(t *testing.T) {
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, brl(t, "10.00"), time.Now(), "Card purchase", "", "user-id")
+	require.NoError(t, err)
+
+	require.NoError(t, transaction.SetInitialStatus(entities.TransactionStatusPending))
+	assert.Equal(t, entities.TransactionStatusPending, transaction.Status)
+
+	assert.ErrorIs(t, transaction.SetInitialStatus(entities.TransactionStatusScheduled), entities.ErrInvalidStatusTransition)
+	assert.ErrorIs(t, transaction.SetInitialStatus(entities.TransactionStatusReconciled), entities.ErrInvalidStatusTransition)
+}
+
+func TestTransaction_ReconciledIsLocked(t *testing.T) {
+	amount := brl(t, "10.00")
+	today := time.Now()
+
+	newReconciled := func(t *testing.T) *entities.Transaction {
+		transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, today, "Rent", "", "user-id")
+		require.NoError(t, err)
+		transaction.Status = entities.TransactionStatusReconciled
+		return transaction
+	}
+
+	t.Run("description and category can change", func(t *testing.T) {
+		transaction := newReconciled(t)
+		assert.NoError(t, transaction.Update(transaction.Type, amount, today, "Rent for May", "housing-id"))
+	})
+
+	t.Run("amount cannot change", func(t *testing.T) {
+		transaction := newReconciled(t)
+		err := transaction.Update(transaction.Type, brl(t, "12.00"), today, "Rent", "")
+		assert.ErrorIs(t, err, entities.ErrTransactionReconciled)
+		assert.Equal(t, amount, transaction.Amount)
+	})
+
+	t.Run("date cannot change", func(t *testing.T) {
+		transaction := newReconciled(t)
+		err := transaction.Update(transaction.Type, amount, today.AddDate(0, 0, -1), "Rent", "")
+		assert.ErrorIs(t, err, entities.ErrTransactionReconciled)
+	})
+
+	t.Run("type cannot change", func(t *testing.T) {
+		transaction := newReconciled(t)
+		err := transaction.Update(entities.TransactionTypeIncome, amount, today, "Rent", "")
+		assert.ErrorIs(t, err, entities.ErrTransactionReconciled)
+	})
+
+	t.Run("wallet cannot change", func(t *testing.T) {
+		transaction := newReconciled(t)
+		assert.ErrorIs(t, transaction.MoveTo("other-wallet-id"), entities.ErrTransactionReconciled)
+		assert.Equal(t, "wallet-id", transaction.WalletID)
+	})
+
+	t.Run("un-reconciling unlocks it", func(t *testing.T) {
+		transaction := newReconciled(t)
+		require.NoError(t, transaction.SetStatus(entities.TransactionStatusCleared))
+		assert.NoError(t, transaction.Update(transaction.Type, brl(t, "12.00"), today, "Rent", ""))
+	})
+}
@@ -0,0 +1,146 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransactionType(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    entities.TransactionType
+		wantErr bool
+	}{
+		{input: "INCOME", want: entities.TransactionTypeIncome},
+		{input: "expense", want: entities.TransactionTypeExpense},
+		{input: "TRANSFER", wantErr: true},
+		{input: "TRANSFER_OUT", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := entities.NewTransactionType(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewTransaction(t *testing.T) {
+	date := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	amount, _ := money.Parse("42.90", "BRL")
+	zero, _ := money.Zero("BRL")
+	negative, _ := money.Parse("-1.00", "BRL")
+
+	tests := []struct {
+		name        string
+		walletID    string
+		txType      entities.TransactionType
+		amount      money.Money
+		date        time.Time
+		description string
+		createdBy   string
+		wantErr     bool
+	}{
+		{name: "valid expense", walletID: "wallet-id", txType: entities.TransactionTypeExpense, amount: amount, date: date, description: " Groceries ", createdBy: "user-id"},
+		{name: "missing wallet", walletID: "", txType: entities.TransactionTypeExpense, amount: amount, date: date, createdBy: "user-id", wantErr: true},
+		{name: "missing author", walletID: "wallet-id", txType: entities.TransactionTypeExpense, amount: amount, date: date, createdBy: "", wantErr: true},
+		{name: "invalid type", walletID: "wallet-id", txType: "TRANSFER", amount: amount, date: date, createdBy: "user-id", wantErr: true},
+		{name: "missing amount", walletID: "wallet-id", txType: entities.TransactionTypeIncome, amount: money.Money{}, date: date, createdBy: "user-id", wantErr: true},
+		{name: "zero amount", walletID: "wallet-id", txType: entities.TransactionTypeIncome, amount: zero, date: date, createdBy: "user-id", wantErr: true},
+		{name: "negative amount", walletID: "wallet-id", txType: entities.TransactionTypeIncome, amount: negative, date: date, createdBy: "user-id", wantErr: true},
+		{name: "missing date", walletID: "wallet-id", txType: entities.TransactionTypeIncome, amount: amount, createdBy: "user-id", wantErr: true},
+		{name: "description too long", walletID: "wallet-id", txType: entities.TransactionTypeIncome, amount: amount, date: date, description: strings.Repeat("a", 256), createdBy: "user-id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction, err := entities.NewTransaction(tt.walletID, tt.txType, tt.amount, tt.date, tt.description, "category-id", tt.createdBy)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, transaction)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, transaction.ID)
+			assert.Equal(t, "Groceries", transaction.Description)
+			assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), transaction.Date)
+			assert.True(t, transaction.Amount.Equal(amount))
+			assert.False(t, transaction.IsDeleted)
+		})
+	}
+}
+
+func TestTransaction_SignedAmount(t *testing.T) {
+	amount, _ := money.Parse("10.00", "USD")
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	income, err := entities.NewTransaction("wallet-id", entities.TransactionTypeIncome, amount, date, "", "", "user-id")
+	require.NoError(t, err)
+	expense, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, date, "", "", "user-id")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1000), income.SignedAmount().MinorUnits())
+	assert.Equal(t, int64(-1000), expense.SignedAmount().MinorUnits())
+}
+
+func TestTransaction_Update(t *testing.T) {
+	amount, _ := money.Parse("10.00", "USD")
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, date, "Lunch", "category-id", "user-id")
+	require.NoError(t, err)
+
+	t.Run("invalid update keeps previous values", func(t *testing.T) {
+		zero, _ := money.Zero("USD")
+		err := transaction.Update(entities.TransactionTypeExpense, zero, date, "Dinner", "category-id")
+
+		assert.Error(t, err)
+		assert.Equal(t, "Lunch", transaction.Description)
+		assert.True(t, transaction.Amount.Equal(amount))
+	})
+
+	t.Run("valid update", func(t *testing.T) {
+		newAmount, _ := money.Parse("12.50", "EUR")
+
+		err := transaction.Update(entities.TransactionTypeIncome, newAmount, date.AddDate(0, 0, 1), "Refund", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TransactionTypeIncome, transaction.Type)
+		assert.Equal(t, "EUR", transaction.Amount.Currency().Code)
+		assert.Equal(t, "Refund", transaction.Description)
+		assert.Empty(t, transaction.CategoryID)
+	})
+}
+
+func TestTransaction_SuggestCategory(t *testing.T) {
+	amount, _ := money.Parse("10.00", "USD")
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	uncategorized, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, date, "Uber", "", "user-id")
+	require.NoError(t, err)
+	assert.True(t, uncategorized.SuggestCategory("transport-id"))
+	assert.Equal(t, "transport-id", uncategorized.CategoryID)
+
+	categorized, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, date, "Uber", "food-id", "user-id")
+	require.NoError(t, err)
+	assert.False(t, categorized.SuggestCategory("transport-id"))
+	assert.Equal(t, "food-id", categorized.CategoryID)
+
+	transferLeg, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, date, "Uber", "", "user-id")
+	require.NoError(t, err)
+	transferLeg.TransferID = "transfer-id"
+	assert.False(t, transferLeg.SuggestCategory("transport-id"))
+	assert.Empty(t, transferLeg.CategoryID)
+}
@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+var ErrTransferAmountMismatch = errors.New("amounts of a transfer in a single currency must be equal")
+
+// Transfer moves money from one wallet to another as two linked legs: an
+// outgoing transaction in the source wallet and an incoming one in the
+// destination wallet. When the wallets use different currencies, Rate is the
+// effective rate between the two legs, in units of the received currency per
+// unit of the sent one.
+type Transfer struct {
+	ID        string
+	Outgoing  *Transaction
+	Incoming  *Transaction
+	Rate      *big.Rat
+	CreatedBy string
+	IsDeleted bool
+	DeletedAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTransfer creates a transfer of sent from fromWalletID that lands as
+// received in toWalletID. received may be left zero when both wallets use
+// the sent currency.
+func NewTransfer(
+	fromWalletID string,
+	toWalletID string,
+	sent money.Money,
+	received money.Money,
+	date time.Time,
+	description string,
+	createdBy string,
+) (*Transfer, error) {
+	if fromWalletID == "" || toWalletID == "" {
+		return nil, fmt.Errorf("source and destination wallets are required")
+	}
+
+	if fromWalletID == toWalletID {
+		return nil, fmt.Errorf("cannot transfer to the same wallet")
+	}
+
+	if createdBy == "" {
+		return nil, fmt.Errorf("transfer author is required")
+	}
+
+	now := time.Now()
+	transfer := &Transfer{
+		ID:        uuid.NewString(),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	transfer.Outgoing = transfer.newLeg(fromWalletID, TransactionTypeTransferOut)
+	transfer.Incoming = transfer.newLeg(toWalletID, TransactionTypeTransferIn)
+
+	if err := transfer.Update(sent, received, date, description); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (t *Transfer) newLeg(walletID string, transactionType TransactionType) *Transaction {
+	return &Transaction{
+		ID:         uuid.NewString(),
+		WalletID:   walletID,
+		Type:       transactionType,
+		TransferID: t.ID,
+		CreatedBy:  t.CreatedBy,
+		TagIDs:     []string{},
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+// Update changes both legs together. The currencies of an existing transfer
+// cannot change, as they are the currencies of its wallets.
+func (t *Transfer) Update(sent, received money.Money, date time.Time, description string) error {
+	if received.Currency().Code == "" {
+		received = sent
+	}
+
+	if err := checkLegCurrency(t.Outgoing, sent); err != nil {
+		return err
+	}
+
+	if err := checkLegCurrency(t.Incoming, received); err != nil {
+		return err
+	}
+
+	if sent.SameCurrency(received) && !sent.Equal(received) {
+		return ErrTransferAmountMismatch
+	}
+
+	if err := t.Outgoing.setDetails(sent, date, description); err != nil {
+		return err
+	}
+
+	if err := t.Incoming.setDetails(received, date, description); err != nil {
+		return err
+	}
+
+	rate, err := sent.RateTo(received)
+	if err != nil {
+		return err
+	}
+
+	t.Rate = rate
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func checkLegCurrency(leg *Transaction, amount money.Money) error {
+	current := leg.Amount.Currency().Code
+	if current != "" && current != amount.Currency().Code {
+		return fmt.Errorf("currency of the %s leg cannot change from %s", leg.Type, current)
+	}
+	return nil
+}
+
+// IsCrossCurrency reports whether the legs are in different currencies.
+func (t *Transfer) IsCrossCurrency() bool {
+	return !t.Outgoing.Amount.SameCurrency(t.Incoming.Amount)
+}
+
+// Involves reports whether walletID is the source or destination wallet.
+func (t *Transfer) Involves(walletID string) bool {
+	return t.Outgoing.WalletID == walletID || t.Incoming.WalletID == walletID
+}
+
+// Delete soft-deletes the transfer and both of its legs.
+func (t *Transfer) Delete() {
+	t.Outgoing.Delete()
+	t.Incoming.Delete()
+	t.IsDeleted = true
+	t.DeletedAt = t.Outgoing.DeletedAt
+	t.UpdatedAt = t.DeletedAt
+}
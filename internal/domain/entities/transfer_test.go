@@ -0,0 +1,112 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransfer(t *testing.T) {
+	date := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	usd, _ := money.Parse("100.00", "USD")
+	brl, _ := money.Parse("543.21", "BRL")
+	otherUSD, _ := money.Parse("99.00", "USD")
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		sent     money.Money
+		received money.Money
+		wantRate string
+		wantErr  bool
+	}{
+		{name: "same currency", from: "checking-id", to: "savings-id", sent: usd, wantRate: "1"},
+		{name: "same currency with received amount", from: "checking-id", to: "savings-id", sent: usd, received: usd, wantRate: "1"},
+		{name: "cross currency", from: "checking-id", to: "savings-id", sent: usd, received: brl, wantRate: "5.4321"},
+		{name: "same currency with different amounts", from: "checking-id", to: "savings-id", sent: usd, received: otherUSD, wantErr: true},
+		{name: "same wallet", from: "checking-id", to: "checking-id", sent: usd, wantErr: true},
+		{name: "missing destination", from: "checking-id", to: "", sent: usd, wantErr: true},
+		{name: "missing amount", from: "checking-id", to: "savings-id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer, err := entities.NewTransfer(tt.from, tt.to, tt.sent, tt.received, date, " Savings ", "user-id")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, transfer)
+				return
+			}
+
+			require.NoError(t, err)
+			wantRate, err := money.ParseRate(tt.wantRate)
+			require.NoError(t, err)
+			assert.Equal(t, 0, transfer.Rate.Cmp(wantRate), "got rate %s", transfer.Rate.RatString())
+
+			assert.Equal(t, tt.from, transfer.Outgoing.WalletID)
+			assert.Equal(t, entities.TransactionTypeTransferOut, transfer.Outgoing.Type)
+			assert.Equal(t, tt.to, transfer.Incoming.WalletID)
+			assert.Equal(t, entities.TransactionTypeTransferIn, transfer.Incoming.Type)
+			for _, leg := range []*entities.Transaction{transfer.Outgoing, transfer.Incoming} {
+				assert.Equal(t, transfer.ID, leg.TransferID)
+				assert.True(t, leg.IsTransfer())
+				assert.Equal(t, "Savings", leg.Description)
+				assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), leg.Date)
+			}
+			assert.Equal(t, -tt.sent.MinorUnits(), transfer.Outgoing.SignedAmount().MinorUnits())
+			assert.True(t, transfer.Incoming.SignedAmount().IsPositive())
+		})
+	}
+}
+
+func TestTransfer_Update(t *testing.T) {
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	usd, _ := money.Parse("100.00", "USD")
+	brl, _ := money.Parse("543.21", "BRL")
+
+	t.Run("updates both legs", func(t *testing.T) {
+		transfer, err := entities.NewTransfer("checking-id", "savings-id", usd, brl, date, "", "user-id")
+		require.NoError(t, err)
+
+		newUSD, _ := money.Parse("50.00", "USD")
+		newBRL, _ := money.Parse("275.00", "BRL")
+		require.NoError(t, transfer.Update(newUSD, newBRL, date.AddDate(0, 0, 1), "Top up"))
+
+		assert.True(t, transfer.Outgoing.Amount.Equal(newUSD))
+		assert.True(t, transfer.Incoming.Amount.Equal(newBRL))
+		assert.Equal(t, transfer.Outgoing.Date, transfer.Incoming.Date)
+		assert.Equal(t, "Top up", transfer.Incoming.Description)
+		assert.Equal(t, "5.5", transfer.Rate.FloatString(1))
+	})
+
+	t.Run("currency cannot change", func(t *testing.T) {
+		transfer, err := entities.NewTransfer("checking-id", "savings-id", usd, brl, date, "", "user-id")
+		require.NoError(t, err)
+
+		eur, _ := money.Parse("90.00", "EUR")
+		assert.Error(t, transfer.Update(usd, eur, date, ""))
+		assert.Error(t, transfer.Update(eur, brl, date, ""))
+		assert.Error(t, transfer.Update(usd, money.Money{}, date, ""))
+	})
+}
+
+func TestTransfer_Delete(t *testing.T) {
+	amount, _ := money.Parse("10.00", "USD")
+	transfer, err := entities.NewTransfer("checking-id", "savings-id", amount, money.Money{}, time.Now(), "", "user-id")
+	require.NoError(t, err)
+
+	transfer.Delete()
+
+	assert.True(t, transfer.IsDeleted)
+	assert.True(t, transfer.Outgoing.IsDeleted)
+	assert.True(t, transfer.Incoming.IsDeleted)
+	assert.True(t, transfer.Involves("checking-id"))
+	assert.True(t, transfer.Involves("savings-id"))
+	assert.False(t, transfer.Involves("other-id"))
+}
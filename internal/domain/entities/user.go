@@ -1,31 +1,39 @@
 package entities
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/stra1g/saver-api/pkg/money"
 )
 
 type Role string
 
 const (
-	RoleRoot  Role = "ROOT"
-	RoleAdmin Role = "ADMIN"
-	RoleUser  Role = "COMMON_USER"
+	RoleRoot      Role = "ROOT"
+	RoleAdmin     Role = "ADMIN"
+	RoleUser      Role = "COMMON_USER"
+	RoleDependent Role = "DEPENDENT"
 )
 
 func NewRole(role string) (Role, error) {
 	formattedRole := strings.ToUpper(role)
 	switch Role(strings.ToUpper(formattedRole)) {
-	case RoleRoot, RoleAdmin, RoleUser:
+	case RoleRoot, RoleAdmin, RoleUser, RoleDependent:
 		return Role(formattedRole), nil
 	default:
 		return "", fmt.Errorf("invalid role: %s", role)
 	}
 }
 
+// ErrDependentRole is returned when a dependent would own or run a wallet
+// or household. Their parent does that for them; dependents only join as
+// members.
+var ErrDependentRole = errors.New("dependents can only be members")
+
 type User struct {
 	ID        string
 	FirstName string
@@ -37,6 +45,10 @@ type User struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Role      Role
+	ParentID  string
+	// BaseCurrency is the currency balances and reports are converted into
+	// when no other is asked for. Empty leaves them in their own currencies.
+	BaseCurrency string
 }
 
 func NewUser(
@@ -75,3 +87,52 @@ func NewUser(
 		Role:      role,
 	}, nil
 }
+
+func NewDependentUser(
+	parent *User,
+	firstName string,
+	lastName string,
+	email string,
+	password string,
+) (*User, error) {
+	if parent == nil {
+		return nil, fmt.Errorf("parent is required")
+	}
+
+	if parent.IsDependent() {
+		return nil, fmt.Errorf("dependent users cannot have dependents")
+	}
+
+	user, err := NewUser(firstName, lastName, email, password, RoleDependent)
+	if err != nil {
+		return nil, err
+	}
+
+	user.ParentID = parent.ID
+	return user, nil
+}
+
+func (u *User) IsDependent() bool {
+	return u.Role == RoleDependent
+}
+
+// IsParentOf reports whether u manages the dependent account other.
+func (u *User) IsParentOf(other *User) bool {
+	return other != nil && other.IsDependent() && other.ParentID == u.ID
+}
+
+// SetBaseCurrency sets the currency balances and reports default to. An empty
+// code clears it.
+func (u *User) SetBaseCurrency(code string) error {
+	if code != "" {
+		currency, err := money.LookupCurrency(code)
+		if err != nil {
+			return err
+		}
+		code = currency.Code
+	}
+
+	u.BaseCurrency = code
+	u.UpdatedAt = time.Now()
+	return nil
+}
@@ -33,6 +33,12 @@ func TestNewRole(t *testing.T) {
 			want:    entities.RoleUser,
 			wantErr: false,
 		},
+		{
+			name:    "valid DEPENDENT role",
+			role:    "DEPENDENT",
+			want:    entities.RoleDependent,
+			wantErr: false,
+		},
 		{
 			name:    "invalid role",
 			role:    "INVALID_ROLE",
@@ -167,3 +173,39 @@ func TestUserIDFormat(t *testing.T) {
 	// Pattern check: 8-4-4-4-12
 	assert.Regexp(t, "^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$", user.ID)
 }
+
+func TestNewDependentUser(t *testing.T) {
+	parent, err := entities.NewUser("John", "Doe", "john.doe@example.com", "password123", entities.RoleUser)
+	assert.NoError(t, err)
+
+	dependent, err := entities.NewDependentUser(parent, "Ana", "Doe", "ana.doe@example.com", "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.RoleDependent, dependent.Role)
+	assert.Equal(t, parent.ID, dependent.ParentID)
+	assert.True(t, dependent.IsDependent())
+	assert.True(t, parent.IsParentOf(dependent))
+	assert.False(t, dependent.IsParentOf(parent))
+
+	_, err = entities.NewDependentUser(nil, "Ana", "Doe", "ana.doe@example.com", "password123")
+	assert.Error(t, err)
+
+	_, err = entities.NewDependentUser(dependent, "Bia", "Doe", "bia.doe@example.com", "password123")
+	assert.Error(t, err)
+
+	_, err = entities.NewDependentUser(parent, "", "Doe", "ana.doe@example.com", "password123")
+	assert.Error(t, err)
+}
+
+func TestUser_SetBaseCurrency(t *testing.T) {
+	user, err := entities.NewUser("John", "Doe", "john.doe@example.com", "password123", entities.RoleUser)
+	assert.NoError(t, err)
+
+	assert.NoError(t, user.SetBaseCurrency("eur"))
+	assert.Equal(t, "EUR", user.BaseCurrency)
+
+	assert.Error(t, user.SetBaseCurrency("XYZ"))
+	assert.Equal(t, "EUR", user.BaseCurrency)
+
+	assert.NoError(t, user.SetBaseCurrency(""))
+	assert.Empty(t, user.BaseCurrency)
+}
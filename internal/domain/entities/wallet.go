@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WalletRole string
+
+const (
+	WalletRoleOwner  WalletRole = "OWNER"
+	WalletRoleMember WalletRole = "MEMBER"
+)
+
+var (
+	ErrAlreadyWalletMember = errors.New("user is already a member of the wallet")
+	ErrNotWalletMember     = errors.New("user is not a member of the wallet")
+	ErrLastWalletOwner     = errors.New("a wallet must keep at least one owner")
+)
+
+func NewWalletRole(role string) (WalletRole, error) {
+	formattedRole := WalletRole(strings.ToUpper(role))
+	if !formattedRole.Valid() {
+		return "", fmt.Errorf("invalid wallet role: %s", role)
+	}
+	return formattedRole, nil
+}
+
+func (r WalletRole) Valid() bool {
+	return r == WalletRoleOwner || r == WalletRoleMember
+}
+
+// WalletMember is a user who can read and write the wallet transactions.
+type WalletMember struct {
+	WalletID string
+	UserID   string
+	Role     WalletRole
+	JoinedAt time.Time
+}
+
+// CanManageMembers reports whether the member may add or remove others.
+func (m *WalletMember) CanManageMembers() bool {
+	return m.Role == WalletRoleOwner
+}
+
+type Wallet struct {
+	ID   string
+	Name string
+	// HouseholdID is the household owning the wallet, if any. Its members
+	// can access the wallet without being listed in Members.
+	HouseholdID    string
+	Members        []*WalletMember
+	ApprovalPolicy ApprovalPolicy
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewWallet(name string, ownerID string) (*Wallet, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("wallet name is required")
+	}
+
+	if ownerID == "" {
+		return nil, fmt.Errorf("wallet owner is required")
+	}
+
+	wallet := &Wallet{
+		ID:             uuid.NewString(),
+		Name:           name,
+		ApprovalPolicy: ApprovalPolicy{TTL: DefaultApprovalTTL},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	wallet.Members = []*WalletMember{{
+		WalletID: wallet.ID,
+		UserID:   ownerID,
+		Role:     WalletRoleOwner,
+		JoinedAt: wallet.CreatedAt,
+	}}
+
+	return wallet, nil
+}
+
+// Member returns the membership of userID, or nil if they do not belong to
+// the wallet.
+func (w *Wallet) Member(userID string) *WalletMember {
+	for _, member := range w.Members {
+		if member.UserID == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+func (w *Wallet) AddMember(userID string, role WalletRole) (*WalletMember, error) {
+	if w.Member(userID) != nil {
+		return nil, ErrAlreadyWalletMember
+	}
+
+	member := &WalletMember{
+		WalletID: w.ID,
+		UserID:   userID,
+		Role:     role,
+		JoinedAt: time.Now(),
+	}
+	w.Members = append(w.Members, member)
+	w.UpdatedAt = time.Now()
+
+	return member, nil
+}
+
+func (w *Wallet) RemoveMember(userID string) error {
+	member := w.Member(userID)
+	if member == nil {
+		return ErrNotWalletMember
+	}
+
+	if member.Role == WalletRoleOwner && w.ownerCount() == 1 {
+		return ErrLastWalletOwner
+	}
+
+	members := w.Members[:0]
+	for _, m := range w.Members {
+		if m.UserID != userID {
+			members = append(members, m)
+		}
+	}
+	w.Members = members
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetApprovalPolicy replaces the rule applied to new expenses. Expenses
+// already held keep the review they were given.
+func (w *Wallet) SetApprovalPolicy(policy ApprovalPolicy) {
+	w.ApprovalPolicy = policy
+	w.UpdatedAt = time.Now()
+}
+
+func (w *Wallet) ownerCount() int {
+	count := 0
+	for _, member := range w.Members {
+		if member.Role == WalletRoleOwner {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,72 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWalletRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		want    entities.WalletRole
+		wantErr bool
+	}{
+		{name: "owner", role: "OWNER", want: entities.WalletRoleOwner},
+		{name: "member lower case", role: "member", want: entities.WalletRoleMember},
+		{name: "invalid role", role: "ADMIN", wantErr: true},
+		{name: "empty role", role: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entities.NewWalletRole(tt.role)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewWallet(t *testing.T) {
+	wallet, err := entities.NewWallet("  Joint account ", "owner-id")
+	require.NoError(t, err)
+	assert.NotEmpty(t, wallet.ID)
+	assert.Equal(t, "Joint account", wallet.Name)
+	require.Len(t, wallet.Members, 1)
+	assert.Equal(t, wallet.ID, wallet.Members[0].WalletID)
+	assert.Equal(t, entities.WalletRoleOwner, wallet.Members[0].Role)
+	assert.True(t, wallet.Members[0].CanManageMembers())
+	assert.False(t, wallet.ApprovalPolicy.Enabled())
+	assert.Equal(t, entities.DefaultApprovalTTL, wallet.ApprovalPolicy.TTL)
+
+	_, err = entities.NewWallet(" ", "owner-id")
+	assert.Error(t, err)
+
+	_, err = entities.NewWallet("Joint account", "")
+	assert.Error(t, err)
+}
+
+func TestWallet_Members(t *testing.T) {
+	wallet, _ := entities.NewWallet("Joint account", "owner-id")
+
+	member, err := wallet.AddMember("partner-id", entities.WalletRoleMember)
+	require.NoError(t, err)
+	assert.Same(t, member, wallet.Member("partner-id"))
+	assert.False(t, member.CanManageMembers())
+
+	_, err = wallet.AddMember("partner-id", entities.WalletRoleOwner)
+	assert.ErrorIs(t, err, entities.ErrAlreadyWalletMember)
+
+	assert.ErrorIs(t, wallet.RemoveMember("owner-id"), entities.ErrLastWalletOwner)
+	assert.ErrorIs(t, wallet.RemoveMember("stranger-id"), entities.ErrNotWalletMember)
+
+	assert.NoError(t, wallet.RemoveMember("partner-id"))
+	assert.Nil(t, wallet.Member("partner-id"))
+}
@@ -0,0 +1,14 @@
+package providers
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+)
+
+// ExchangeRateProvider is an external source of reference rates.
+type ExchangeRateProvider interface {
+	Name() string
+	// FetchRates returns the rates in effect on date.
+	FetchRates(date time.Time) ([]*entities.ExchangeRate, error)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// ActivityFilter narrows a wallet feed. Zero values do not filter.
+type ActivityFilter struct {
+	// Read keeps the items the viewer has read, or only unread ones when
+	// false.
+	Read *bool
+}
+
+// ActivitySorts are the fields activity feeds can be sorted on.
+var ActivitySorts = query.Sorts{
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultActivitySort lists the newest items first.
+var DefaultActivitySort = query.Sort{Field: "created_at", Direction: query.Descending}
+
+// ActivityRepository stores the wallet feeds. An item counts as read by the
+// member who did it, and by every member who marked it read.
+type ActivityRepository interface {
+	CreateActivities(activities []*entities.Activity) error
+	// FindActivitiesByWalletID returns a page of the feed of walletID as
+	// userID sees it, and the cursor of the next page, nil on the last one.
+	FindActivitiesByWalletID(walletID, userID string, filter ActivityFilter, page query.Page) ([]*entities.Activity, *query.Cursor, error)
+	CountUnreadActivities(walletID, userID string) (int, error)
+	// MarkActivitiesRead marks the items of walletID with the given IDs read
+	// by userID at readAt, or all of them when ids is empty.
+	MarkActivitiesRead(walletID, userID string, ids []string, readAt time.Time) error
+}
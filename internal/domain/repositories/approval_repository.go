@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+)
+
+type ApprovalRepository interface {
+	// CreateHeldTransaction saves an expense held for approval together with
+	// the request to review it.
+	CreateHeldTransaction(transaction *entities.Transaction, approval *entities.Approval) (*entities.Transaction, error)
+	// HoldTransactions saves edited transactions, as SaveTransactions does,
+	// together with the requests to review those the edit held for approval.
+	HoldTransactions(transactions []*entities.Transaction, approvals []*entities.Approval) error
+	FindApprovalByID(id string) (*entities.Approval, error)
+	// FindPendingApprovalsByWalletID lists the open requests of the wallet,
+	// oldest first, leaving out those whose expense was deleted.
+	FindPendingApprovalsByWalletID(walletID string) ([]*entities.Approval, error)
+	// FindOverdueApprovals lists the pending requests that expired by now.
+	FindOverdueApprovals(now time.Time) ([]*entities.Approval, error)
+	// SaveReview saves the outcome of a request together with the expense it
+	// released. It reports false, saving nothing, when the request was
+	// already closed by someone else.
+	SaveReview(approval *entities.Approval, transaction *entities.Transaction) (bool, error)
+}
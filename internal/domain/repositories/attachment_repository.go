@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+)
+
+type AttachmentRepository interface {
+	CreateAttachment(attachment *entities.Attachment) (*entities.Attachment, error)
+	FindAttachmentByID(id string) (*entities.Attachment, error)
+	FindAttachmentsByTransactionID(transactionID string) ([]*entities.Attachment, error)
+	// FindAttachmentsOfDeletedTransactions lists the attachments of
+	// transactions deleted before deletedBefore, which are due to be purged.
+	FindAttachmentsOfDeletedTransactions(deletedBefore time.Time) ([]*entities.Attachment, error)
+	DeleteAttachment(attachment *entities.Attachment) error
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+)
+
+type BalanceRepository interface {
+	// FindDebts adds up, for every pair of wallet members and currency, what
+	// one owes the other for the shared expenses they did not pay, with the
+	// settlements that were not voided counted as debts the other way.
+	// Expenses held for approval are left out.
+	FindDebts(walletID string) ([]*entities.Debt, error)
+	// FindStatusTotals returns the money in and out of the wallet by
+	// transaction status and currency, transfers included. The totals are
+	// kept up to date as transactions change rather than added up on read.
+	// Expenses held for approval come in totals of their own.
+	FindStatusTotals(walletID string) ([]*entities.StatusTotal, error)
+	// FindBalancesAsOf returns the current balance of the wallet in each
+	// currency at the end of date, starting from the latest snapshot taken
+	// on or before it.
+	FindBalancesAsOf(walletID string, date time.Time) ([]*entities.BalanceSnapshot, error)
+	// CreateSnapshots snapshots the balance of every wallet at the end of
+	// asOf, from each wallet's previous snapshot, and returns how many
+	// balances were stored. Snapshots made stale by a later change to an
+	// earlier transaction are dropped as the transaction is written.
+	CreateSnapshots(asOf time.Time) (int, error)
+	// FindDrifts recomputes the running totals and snapshots of every wallet
+	// from its transactions and returns the ones that differ.
+	FindDrifts() (*entities.BalanceDrifts, error)
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// CategoryFilter narrows a category listing. Zero values do not filter.
+type CategoryFilter struct {
+	// Query keeps categories whose name contains it, ignoring case.
+	Query string
+	Types []entities.TransactionType
+}
+
+// CategorySorts are the fields category listings can be sorted on.
+var CategorySorts = query.Sorts{
+	"type": {
+		Columns: []string{"type::text", "name", "id"},
+		Types:   []query.ColumnType{query.Text, query.Text, query.UUID},
+	},
+	"name": {
+		Columns: []string{"name", "id"},
+		Types:   []query.ColumnType{query.Text, query.UUID},
+	},
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultCategorySort groups categories by type, alphabetically.
+var DefaultCategorySort = query.Sort{Field: "type", Direction: query.Ascending}
+
+type CategoryRepository interface {
+	CreateCategory(category *entities.Category) (*entities.Category, error)
+	FindCategoryByID(id string) (*entities.Category, error)
+	FindCategoriesByUserID(userID string) ([]*entities.Category, error)
+	// FindCategoryPageByUserID returns a page of the user categories and
+	// the cursor of the next page, nil on the last one.
+	FindCategoryPageByUserID(userID string, filter CategoryFilter, page query.Page) ([]*entities.Category, *query.Cursor, error)
+	UpdateCategory(category *entities.Category) (*entities.Category, error)
+	// CountTransactionsByCategoryID counts the transactions using the
+	// category, on themselves or on one of their split lines.
+	CountTransactionsByCategoryID(categoryID string) (int, error)
+	// MergeCategories points everything using source (transactions, split
+	// lines, rules, payees, recurring templates and children) at target and
+	// deletes source, all in one database transaction.
+	MergeCategories(sourceID, targetID string) error
+	DeleteCategory(id string) error
+}
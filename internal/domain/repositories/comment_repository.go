@@ -0,0 +1,20 @@
+package repositories
+
+import "github.com/stra1g/saver-api/internal/domain/entities"
+
+type CommentRepository interface {
+	CreateComment(comment *entities.Comment) error
+	// FindCommentByID returns the comment, or nil when it does not exist or
+	// was deleted.
+	FindCommentByID(id string) (*entities.Comment, error)
+	// FindCommentsByTransactionID lists the comments of the transaction
+	// oldest first, deleted ones included so their replies keep a thread.
+	FindCommentsByTransactionID(transactionID string) ([]*entities.Comment, error)
+	// UpdateComment saves the new body of the comment along with the edit
+	// keeping its previous one.
+	UpdateComment(comment *entities.Comment, edit *entities.CommentEdit) error
+	DeleteComment(comment *entities.Comment) error
+	// FindCommentEdits lists the previous bodies of the comment, oldest
+	// first.
+	FindCommentEdits(commentID string) ([]*entities.CommentEdit, error)
+}
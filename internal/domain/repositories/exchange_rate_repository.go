@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+)
+
+type ExchangeRateRepository interface {
+	SaveRates(rates []*entities.ExchangeRate) error
+	// FindRate returns the most recent rate published on or before date, or
+	// nil when there is none.
+	FindRate(baseCurrency, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error)
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/stra1g/saver-api/internal/domain/entities"
+
+type HouseholdRepository interface {
+	CreateHousehold(household *entities.Household) (*entities.Household, error)
+	FindHouseholdByID(id string) (*entities.Household, error)
+	AddMember(member *entities.HouseholdMember) error
+	UpdateMemberRole(householdID, userID string, role entities.HouseholdRole) error
+	RemoveMember(householdID, userID string) error
+}
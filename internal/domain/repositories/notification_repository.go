@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// NotificationFilter narrows a notification listing. Zero values do not
+// filter.
+type NotificationFilter struct {
+	// Read keeps read notifications, or only unread ones when false.
+	Read *bool
+}
+
+// NotificationSorts are the fields notification listings can be sorted on.
+var NotificationSorts = query.Sorts{
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultNotificationSort lists the newest notifications first.
+var DefaultNotificationSort = query.Sort{Field: "created_at", Direction: query.Descending}
+
+type NotificationRepository interface {
+	CreateNotifications(notifications []*entities.Notification) error
+	// FindNotificationsByUserID returns a page of the notifications of
+	// userID and the cursor of the next page, nil on the last one.
+	FindNotificationsByUserID(userID string, filter NotificationFilter, page query.Page) ([]*entities.Notification, *query.Cursor, error)
+	CountUnreadNotifications(userID string) (int, error)
+	// MarkNotificationsRead marks the notifications of userID with the given
+	// IDs read at readAt, or all of them when ids is empty. Notifications
+	// already read keep their time.
+	MarkNotificationsRead(userID string, ids []string, readAt time.Time) error
+}
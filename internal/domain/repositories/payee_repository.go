@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// PayeeFilter narrows a payee listing. Zero values do not filter.
+type PayeeFilter struct {
+	// Query keeps payees whose name or one of whose aliases contains it,
+	// ignoring case.
+	Query string
+	// CategoryIDs keeps payees defaulting to any of the categories.
+	CategoryIDs []string
+}
+
+// PayeeSorts are the fields payee listings can be sorted on.
+var PayeeSorts = query.Sorts{
+	"name": {
+		Columns: []string{"name", "id"},
+		Types:   []query.ColumnType{query.Text, query.UUID},
+	},
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultPayeeSort lists payees alphabetically.
+var DefaultPayeeSort = query.Sort{Field: "name", Direction: query.Ascending}
+
+type PayeeRepository interface {
+	CreatePayee(payee *entities.Payee) (*entities.Payee, error)
+	FindPayeeByID(id string) (*entities.Payee, error)
+	FindPayeesByUserID(userID string) ([]*entities.Payee, error)
+	// FindPayeePageByUserID returns a page of the user payees and the
+	// cursor of the next page, nil on the last one.
+	FindPayeePageByUserID(userID string, filter PayeeFilter, page query.Page) ([]*entities.Payee, *query.Cursor, error)
+	// FindPayeeByAlias finds the user's payee a normalized name maps to.
+	FindPayeeByAlias(userID, alias string) (*entities.Payee, error)
+	UpdatePayee(payee *entities.Payee) (*entities.Payee, error)
+	// MergePayees moves the transactions and aliases of source to target,
+	// saves target and deletes source, all in one database transaction.
+	MergePayees(source, target *entities.Payee) error
+	DeletePayee(id string) error
+}
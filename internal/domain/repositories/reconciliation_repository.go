@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ReconciliationRepository interface {
+	CreateReconciliation(reconciliation *entities.Reconciliation) error
+	// FindReconciliationByID returns the session with the transactions
+	// ticked off in it and their net, leaving out deleted ones. The opening
+	// balance is worked out as it stands now, without the transactions of
+	// the session itself.
+	FindReconciliationByID(id string) (*entities.Reconciliation, error)
+	// FindOpenReconciliation returns the open session of the wallet in the
+	// currency, if any.
+	FindOpenReconciliation(walletID, currency string) (*entities.Reconciliation, error)
+	// FindReconciledBalance nets the reconciled transactions of the wallet
+	// in the currency dated up to date.
+	FindReconciledBalance(walletID, currency string, date time.Time) (money.Money, error)
+	// SaveClearedTransactions makes the stored ticks of the session match
+	// reconciliation.ClearedIDs.
+	SaveClearedTransactions(reconciliation *entities.Reconciliation) error
+	// CompleteReconciliation saves the completed session together with the
+	// transactions it reconciled.
+	CompleteReconciliation(reconciliation *entities.Reconciliation, transactions []*entities.Transaction) error
+	DeleteReconciliation(id string) error
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// RecurringTransactionFilter narrows a recurring transaction listing. Zero
+// values do not filter.
+type RecurringTransactionFilter struct {
+	Types []entities.TransactionType
+	// CategoryIDs keeps schedules filed under any of the categories or
+	// their children.
+	CategoryIDs []string
+	Currency    string
+}
+
+// RecurringTransactionSorts are the fields recurring transaction listings
+// can be sorted on. Finished schedules, without a next occurrence, sort
+// after every other. Amounts are grouped by currency first, as amounts in
+// different currencies do not compare.
+var RecurringTransactionSorts = query.Sorts{
+	"next_occurrence": {
+		Columns: []string{"coalesce(next_occurrence, DATE '9999-12-31')", "created_at", "id"},
+		Types:   []query.ColumnType{query.Date, query.Timestamp, query.UUID},
+	},
+	"amount": {
+		Columns: []string{"currency", "amount", "id"},
+		Types:   []query.ColumnType{query.Text, query.Integer, query.UUID},
+	},
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultRecurringTransactionSort lists the schedules due soonest first.
+var DefaultRecurringTransactionSort = query.Sort{Field: "next_occurrence", Direction: query.Ascending}
+
+type RecurringTransactionRepository interface {
+	CreateRecurringTransaction(recurring *entities.RecurringTransaction) (*entities.RecurringTransaction, error)
+	FindRecurringTransactionByID(id string) (*entities.RecurringTransaction, error)
+	// FindRecurringTransactionsByWalletID returns a page of the wallet
+	// schedules and the cursor of the next page, nil on the last one.
+	FindRecurringTransactionsByWalletID(
+		walletID string,
+		filter RecurringTransactionFilter,
+		page query.Page,
+	) ([]*entities.RecurringTransaction, *query.Cursor, error)
+	// FindDueRecurringTransactions returns the schedules with an occurrence on
+	// or before today that was not created yet.
+	FindDueRecurringTransactions(today time.Time) ([]*entities.RecurringTransaction, error)
+	UpdateRecurringTransaction(recurring *entities.RecurringTransaction) (*entities.RecurringTransaction, error)
+	DeleteRecurringTransaction(recurring *entities.RecurringTransaction) error
+	FindOccurrenceOverrides(recurringID string) ([]*entities.OccurrenceOverride, error)
+	SaveOccurrenceOverride(override *entities.OccurrenceOverride) (*entities.OccurrenceOverride, error)
+	DeleteOccurrenceOverride(recurringID string, date time.Time) error
+	// MaterializeOccurrences creates the transactions, with the approvals of
+	// those held for review, and saves the schedule progress in one database
+	// transaction. Occurrences that already have a transaction are left
+	// alone, so it is safe to run twice; it returns the transactions created.
+	MaterializeOccurrences(
+		recurring *entities.RecurringTransaction,
+		transactions []*entities.Transaction,
+		approvals []*entities.Approval,
+	) ([]*entities.Transaction, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+)
+
+type ReportRepository interface {
+	// FindCategoryTotals adds up the income and expenses of the wallet dated
+	// within [from, to] by category, counting split transactions by line.
+	// Transfers, held expenses and scheduled transactions are left out.
+	FindCategoryTotals(walletID string, from, to time.Time) ([]*entities.CategoryTotal, error)
+	// FindHouseholdCategoryTotals is FindCategoryTotals across every wallet
+	// of the household.
+	FindHouseholdCategoryTotals(householdID string, from, to time.Time) ([]*entities.CategoryTotal, error)
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// RuleFilter narrows a rule listing. Zero values do not filter.
+type RuleFilter struct {
+	// Query keeps rules whose name contains it, ignoring case.
+	Query string
+	// CategoryIDs keeps rules filing transactions under any of the
+	// categories.
+	CategoryIDs []string
+	// PayeeIDs keeps rules matching transactions of any of the payees.
+	PayeeIDs []string
+}
+
+// RuleSorts are the fields rule listings can be sorted on.
+var RuleSorts = query.Sorts{
+	"priority": {
+		Columns: []string{"priority", "created_at", "id"},
+		Types:   []query.ColumnType{query.Integer, query.Timestamp, query.UUID},
+	},
+	"name": {
+		Columns: []string{"name", "id"},
+		Types:   []query.ColumnType{query.Text, query.UUID},
+	},
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultRuleSort lists rules in the order they run.
+var DefaultRuleSort = query.Sort{Field: "priority", Direction: query.Ascending}
+
+type RuleRepository interface {
+	CreateRule(rule *entities.Rule) (*entities.Rule, error)
+	// FindRulesByUserID lists the user's rules in the order they run.
+	FindRulesByUserID(userID string) ([]*entities.Rule, error)
+	// FindRulePageByUserID returns a page of the user rules and the cursor
+	// of the next page, nil on the last one.
+	FindRulePageByUserID(userID string, filter RuleFilter, page query.Page) ([]*entities.Rule, *query.Cursor, error)
+	UpdateRule(rule *entities.Rule) (*entities.Rule, error)
+	DeleteRule(id string) error
+}
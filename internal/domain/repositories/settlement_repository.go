@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// SettlementFilter narrows a settlement listing. Zero values do not filter.
+type SettlementFilter struct {
+	// From and To bound the settlement date, both inclusive.
+	From time.Time
+	To   time.Time
+	// MemberIDs keeps settlements any of the users paid or received.
+	MemberIDs []string
+	// Voided keeps voided settlements, or only the ones still counting
+	// when false.
+	Voided   *bool
+	Currency string
+}
+
+// SettlementSorts are the fields settlement listings can be sorted on.
+// Amounts are grouped by currency first, as amounts in different
+// currencies do not compare.
+var SettlementSorts = query.Sorts{
+	"date": {
+		Columns: []string{"date", "created_at", "id"},
+		Types:   []query.ColumnType{query.Date, query.Timestamp, query.UUID},
+	},
+	"amount": {
+		Columns: []string{"currency", "amount", "id"},
+		Types:   []query.ColumnType{query.Text, query.Integer, query.UUID},
+	},
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultSettlementSort lists the newest settlements first.
+var DefaultSettlementSort = query.Sort{Field: "date", Direction: query.Descending}
+
+type SettlementRepository interface {
+	CreateSettlement(settlement *entities.Settlement) (*entities.Settlement, error)
+	FindSettlementByID(id string) (*entities.Settlement, error)
+	// FindSettlementsByWalletID returns a page of the wallet settlements,
+	// voided ones included unless filtered out, and the cursor of the next
+	// page, nil on the last one.
+	FindSettlementsByWalletID(walletID string, filter SettlementFilter, page query.Page) ([]*entities.Settlement, *query.Cursor, error)
+	VoidSettlement(settlement *entities.Settlement) error
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// SpentFilter selects the expenses a spending limit counts.
+type SpentFilter struct {
+	WalletID string
+	UserID   string
+	// CategoryID counts only the lines under the category when set.
+	CategoryID string
+	// From and To bound the expense date, both inclusive.
+	From time.Time
+	To   time.Time
+	// ExcludeTransactionID leaves out an expense being edited, which is
+	// counted with its new amount instead.
+	ExcludeTransactionID string
+}
+
+type SpendingLimitRepository interface {
+	CreateSpendingLimit(limit *entities.SpendingLimit) error
+	FindSpendingLimitByID(id string) (*entities.SpendingLimit, error)
+	// FindSpendingLimitsByWalletID lists the limits of every member of the
+	// wallet, grouped by member.
+	FindSpendingLimitsByWalletID(walletID string) ([]*entities.SpendingLimit, error)
+	// FindMemberSpendingLimits lists the limits of userID in the wallet.
+	FindMemberSpendingLimits(walletID, userID string) ([]*entities.SpendingLimit, error)
+	UpdateSpendingLimit(limit *entities.SpendingLimit) error
+	DeleteSpendingLimit(id string) error
+	// FindSpentAmounts adds up the expenses matching the filter, one total
+	// per currency. Deleted expenses are left out; held ones count.
+	FindSpentAmounts(filter SpentFilter) ([]money.Money, error)
+}
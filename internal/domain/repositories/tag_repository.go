@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// TagFilter narrows a tag listing. Zero values do not filter.
+type TagFilter struct {
+	// Query keeps tags whose name contains it, ignoring case.
+	Query string
+	// Used keeps tags with non-deleted transactions, or without any when
+	// false.
+	Used *bool
+}
+
+// TagSorts are the fields tag listings can be sorted on.
+var TagSorts = query.Sorts{
+	"name": {
+		Columns: []string{"name", "id"},
+		Types:   []query.ColumnType{query.Text, query.UUID},
+	},
+	"usage": {
+		Columns: []string{"transaction_count", "id"},
+		Types:   []query.ColumnType{query.Integer, query.UUID},
+	},
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultTagSort lists tags alphabetically.
+var DefaultTagSort = query.Sort{Field: "name", Direction: query.Ascending}
+
+type TagRepository interface {
+	CreateTag(tag *entities.Tag) (*entities.Tag, error)
+	FindTagByID(id string) (*entities.Tag, error)
+	FindTagsByIDs(ids []string) ([]*entities.Tag, error)
+	FindTagByName(scope entities.TagScope, ownerID, name string) (*entities.Tag, error)
+	// FindTagUsageByOwner returns a page of the owner tags with their usage
+	// and the cursor of the next page, nil on the last one.
+	FindTagUsageByOwner(scope entities.TagScope, ownerID string, filter TagFilter, page query.Page) ([]*entities.TagUsage, *query.Cursor, error)
+	UpdateTag(tag *entities.Tag) (*entities.Tag, error)
+	// MergeTags moves the transactions of source to target and deletes
+	// source, all in one database transaction.
+	MergeTags(sourceID, targetID string) error
+	DeleteTag(id string) error
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// TransactionFilter narrows a transaction listing. Zero values do not filter.
+type TransactionFilter struct {
+	// TagIDs keeps transactions with any of the tags, or with all of them
+	// when MatchAllTags is set.
+	TagIDs       []string
+	MatchAllTags bool
+	// From and To bound the transaction date, both inclusive.
+	From time.Time
+	To   time.Time
+	// MinAmount and MaxAmount bound the amount, both inclusive, and keep
+	// only transactions in their currency.
+	MinAmount *money.Money
+	MaxAmount *money.Money
+	// CategoryIDs keeps transactions filed under any of the categories or
+	// their children, directly or through a split line.
+	CategoryIDs []string
+	PayeeIDs    []string
+	// MemberIDs keeps transactions any of the users recorded, paid or share.
+	MemberIDs []string
+	Types     []entities.TransactionType
+}
+
+// TransactionSorts are the fields transaction listings can be sorted on.
+// Amounts are grouped by currency first, as amounts in different
+// currencies do not compare.
+var TransactionSorts = query.Sorts{
+	"date": {
+		Columns: []string{"date", "created_at", "id"},
+		Types:   []query.ColumnType{query.Date, query.Timestamp, query.UUID},
+	},
+	"amount": {
+		Columns: []string{"currency", "amount", "id"},
+		Types:   []query.ColumnType{query.Text, query.Integer, query.UUID},
+	},
+	"created_at": {
+		Columns: []string{"created_at", "id"},
+		Types:   []query.ColumnType{query.Timestamp, query.UUID},
+	},
+}
+
+// DefaultTransactionSort lists the newest transactions first.
+var DefaultTransactionSort = query.Sort{Field: "date", Direction: query.Descending}
+
+// TransactionSearch is a full-text search over the transactions of the
+// wallets UserID can access, optionally narrowed to WalletID.
+type TransactionSearch struct {
+	Query    string
+	UserID   string
+	WalletID string
+	Limit    int
+}
+
+type TransactionRepository interface {
+	CreateTransaction(transaction *entities.Transaction) (*entities.Transaction, error)
+	FindTransactionByID(id string) (*entities.Transaction, error)
+	// FindTransactionsByIDs returns the transactions found among ids, in no
+	// particular order; deleted transactions are left out.
+	FindTransactionsByIDs(ids []string) ([]*entities.Transaction, error)
+	// FindTransactionsByWalletID returns a page of the wallet transactions
+	// and the cursor of the next page, nil on the last one.
+	FindTransactionsByWalletID(walletID string, filter TransactionFilter, page query.Page) ([]*entities.Transaction, *query.Cursor, error)
+	UpdateTransaction(transaction *entities.Transaction) (*entities.Transaction, error)
+	DeleteTransaction(transaction *entities.Transaction) error
+	// SaveTransactions updates, or deletes when marked deleted, every
+	// transaction in a single database transaction.
+	SaveTransactions(transactions []*entities.Transaction) error
+	// SearchTransactions matches the query against the description, payee,
+	// tags and split notes of transactions, most relevant first. Comments
+	// are not searched since transactions do not have any yet.
+	SearchTransactions(search TransactionSearch) ([]*entities.TransactionSearchResult, error)
+	// PromoteScheduledTransactions makes the scheduled transactions dated
+	// today or earlier pending and returns how many were promoted.
+	PromoteScheduledTransactions(today time.Time) (int64, error)
+	// PurgeDeletedTransactions removes transactions deleted before
+	// deletedBefore for good and returns how many were removed. Transactions
+	// that still have attachments are kept until those are removed.
+	PurgeDeletedTransactions(deletedBefore time.Time) (int64, error)
+}
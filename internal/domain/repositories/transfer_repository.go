@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/stra1g/saver-api/internal/domain/entities"
+
+// TransferRepository stores a transfer together with its two legs; every
+// write covers both legs atomically.
+type TransferRepository interface {
+	CreateTransfer(transfer *entities.Transfer) (*entities.Transfer, error)
+	FindTransferByID(id string) (*entities.Transfer, error)
+	UpdateTransfer(transfer *entities.Transfer) (*entities.Transfer, error)
+	DeleteTransfer(transfer *entities.Transfer) error
+}
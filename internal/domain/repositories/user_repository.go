@@ -3,6 +3,11 @@ package repositories
 import "github.com/stra1g/saver-api/internal/domain/entities"
 
 type UserRepository interface {
-	CreateUser(user *entities.User) (*entities.User, error)
+	// CreateUser stores the user together with its initial categories, all or
+	// nothing.
+	CreateUser(user *entities.User, categories []*entities.Category) (*entities.User, error)
 	FindUserByEmail(email string) (*entities.User, error)
+	FindUserByID(id string) (*entities.User, error)
+	FindDependentsByParentID(parentID string) ([]*entities.User, error)
+	UpdateBaseCurrency(user *entities.User) error
 }
@@ -0,0 +1,22 @@
+package repositories
+
+import "github.com/stra1g/saver-api/internal/domain/entities"
+
+type WalletRepository interface {
+	CreateWallet(wallet *entities.Wallet) (*entities.Wallet, error)
+	FindWalletByID(id string) (*entities.Wallet, error)
+	// FindWalletsByUserID returns the wallets userID is a member of, either
+	// directly or through the household owning them.
+	FindWalletsByUserID(userID string) ([]*entities.Wallet, error)
+	// FindWalletIDsByUserID lists the IDs of the wallets FindWalletsByUserID
+	// returns.
+	FindWalletIDsByUserID(userID string) ([]string, error)
+	// FindWalletMember returns the membership of userID in the wallet, or
+	// nil when they are not a member or the wallet does not exist. Members
+	// of the owning household get the role HouseholdRole.WalletRole gives
+	// them, unless they are listed with a stronger one.
+	FindWalletMember(walletID, userID string) (*entities.WalletMember, error)
+	AddMember(member *entities.WalletMember) error
+	RemoveMember(walletID, userID string) error
+	UpdateApprovalPolicy(wallet *entities.Wallet) error
+}
@@ -20,6 +20,21 @@ type Config struct {
 		Name     string `validate:"required"`
 		SSLMode  string `validate:"required,oneof=disable require"`
 	}
+	ExchangeRates struct {
+		Provider string `validate:"omitempty,oneof=ecb stub"`
+	}
+	Storage struct {
+		Driver         string `validate:"omitempty,oneof=local s3"`
+		LocalPath      string `validate:"required_if=Driver local"`
+		PublicURL      string `validate:"required_if=Driver local"`
+		SigningKey     string
+		S3Endpoint     string `validate:"required_if=Driver s3"`
+		S3Region       string `validate:"required_if=Driver s3"`
+		S3Bucket       string `validate:"required_if=Driver s3"`
+		S3AccessKeyID  string `validate:"required_if=Driver s3"`
+		S3SecretKey    string `validate:"required_if=Driver s3"`
+		S3UsePathStyle bool
+	}
 }
 
 func NewConfig() (*Config, error) {
@@ -46,6 +61,34 @@ func NewConfig() (*Config, error) {
 			Name:     GetEnvWithDefault("DB_NAME", ""),
 			SSLMode:  GetEnvWithDefault("DB_SSLMODE", "disable"),
 		},
+		ExchangeRates: struct {
+			Provider string `validate:"omitempty,oneof=ecb stub"`
+		}{
+			Provider: GetEnvWithDefault("EXCHANGE_RATE_PROVIDER", "stub"),
+		},
+		Storage: struct {
+			Driver         string `validate:"omitempty,oneof=local s3"`
+			LocalPath      string `validate:"required_if=Driver local"`
+			PublicURL      string `validate:"required_if=Driver local"`
+			SigningKey     string
+			S3Endpoint     string `validate:"required_if=Driver s3"`
+			S3Region       string `validate:"required_if=Driver s3"`
+			S3Bucket       string `validate:"required_if=Driver s3"`
+			S3AccessKeyID  string `validate:"required_if=Driver s3"`
+			S3SecretKey    string `validate:"required_if=Driver s3"`
+			S3UsePathStyle bool
+		}{
+			Driver:         GetEnvWithDefault("STORAGE_DRIVER", "local"),
+			LocalPath:      GetEnvWithDefault("STORAGE_LOCAL_PATH", "./data/attachments"),
+			PublicURL:      GetEnvWithDefault("STORAGE_PUBLIC_URL", "http://localhost:8080/api/v1/files"),
+			SigningKey:     GetEnvWithDefault("STORAGE_SIGNING_KEY", ""),
+			S3Endpoint:     GetEnvWithDefault("S3_ENDPOINT", ""),
+			S3Region:       GetEnvWithDefault("S3_REGION", "us-east-1"),
+			S3Bucket:       GetEnvWithDefault("S3_BUCKET", ""),
+			S3AccessKeyID:  GetEnvWithDefault("S3_ACCESS_KEY_ID", ""),
+			S3SecretKey:    GetEnvWithDefault("S3_SECRET_ACCESS_KEY", ""),
+			S3UsePathStyle: GetEnvWithDefault("S3_USE_PATH_STYLE", "false") == "true",
+		},
 	}
 
 	if err := ValidateConfig(config); err != nil {
@@ -106,6 +106,7 @@ func TestNewConfig_WithDefaults(t *testing.T) {
 	assert.Equal(t, "defaultpass", cfg.Database.Password)
 	assert.Equal(t, "defaultdb", cfg.Database.Name)
 	assert.Equal(t, "disable", cfg.Database.SSLMode)
+	assert.Equal(t, "stub", cfg.ExchangeRates.Provider)
 }
 
 func TestNewConfig_ValidationError_MissingRequired(t *testing.T) {
@@ -172,6 +173,22 @@ func TestNewConfig_ValidationError_InvalidSSLMode(t *testing.T) {
 	assert.Contains(t, err.Error(), "SSLMode failed validation: oneof")
 }
 
+func TestNewConfig_ValidationError_InvalidExchangeRateProvider(t *testing.T) {
+	// Setup environment with an unknown exchange rate provider
+	cleanup := setupEnvVars(t)
+	defer cleanup()
+
+	t.Setenv("EXCHANGE_RATE_PROVIDER", "unknown")
+
+	// Act
+	cfg, err := config.NewConfig()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "Provider failed validation: oneof")
+}
+
 func TestGetDatabaseDSN(t *testing.T) {
 	// Setup test config
 	cfg := &config.Config{}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "exchange_rates_pair_date_unique";
+DROP TABLE IF EXISTS "exchange_rates" CASCADE;
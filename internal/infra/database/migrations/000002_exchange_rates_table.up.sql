@@ -0,0 +1,12 @@
+CREATE TABLE "exchange_rates" (
+  "id" uuid PRIMARY KEY,
+  "base_currency" char(3) NOT NULL,
+  "quote_currency" char(3) NOT NULL,
+  "rate" numeric(24, 10) NOT NULL CHECK ("rate" > 0),
+  "rate_date" date NOT NULL,
+  "source" varchar NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX exchange_rates_pair_date_unique ON exchange_rates (base_currency, quote_currency, rate_date);
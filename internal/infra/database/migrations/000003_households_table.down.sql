@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "household_members_user_id_idx";
+DROP TABLE IF EXISTS "household_members" CASCADE;
+DROP TABLE IF EXISTS "households" CASCADE;
+DROP TYPE IF EXISTS "household_roles" CASCADE;
@@ -0,0 +1,22 @@
+CREATE TYPE "household_roles" AS ENUM (
+  'OWNER',
+  'ADMIN',
+  'MEMBER'
+);
+
+CREATE TABLE "households" (
+  "id" uuid PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "household_members" (
+  "household_id" uuid NOT NULL REFERENCES "households" ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "role" household_roles NOT NULL,
+  "joined_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("household_id", "user_id")
+);
+
+CREATE INDEX household_members_user_id_idx ON household_members (user_id);
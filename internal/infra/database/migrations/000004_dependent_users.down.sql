@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "users_parent_id_idx";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "parent_id";
+
+-- Postgres cannot drop a value from an enum, so the type is rebuilt
+DELETE FROM "users" WHERE "role" = 'DEPENDENT';
+ALTER TYPE "user_roles" RENAME TO "user_roles_old";
+CREATE TYPE "user_roles" AS ENUM (
+  'ROOT',
+  'ADMIN',
+  'COMMON_USER'
+);
+ALTER TABLE "users" ALTER COLUMN "role" TYPE user_roles USING "role"::text::user_roles;
+DROP TYPE "user_roles_old";
@@ -0,0 +1,6 @@
+ALTER TYPE "user_roles" ADD VALUE IF NOT EXISTS 'DEPENDENT';
+
+ALTER TABLE "users" ADD COLUMN "parent_id" uuid DEFAULT null REFERENCES "users" ("id");
+
+CREATE INDEX users_parent_id_idx ON users (parent_id)
+WHERE parent_id IS NOT NULL;
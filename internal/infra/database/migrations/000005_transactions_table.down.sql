@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "transactions_wallet_id_date_idx";
+DROP TABLE IF EXISTS "transactions" CASCADE;
+DROP TYPE IF EXISTS "transaction_types" CASCADE;
@@ -0,0 +1,23 @@
+CREATE TYPE "transaction_types" AS ENUM (
+  'INCOME',
+  'EXPENSE'
+);
+
+CREATE TABLE "transactions" (
+  "id" uuid PRIMARY KEY,
+  "wallet_id" uuid NOT NULL,
+  "type" transaction_types NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "currency" char(3) NOT NULL,
+  "date" date NOT NULL,
+  "description" varchar(255) NOT NULL DEFAULT '',
+  "category" varchar NOT NULL DEFAULT '',
+  "created_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "is_deleted" boolean DEFAULT false,
+  "deleted_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX transactions_wallet_id_date_idx ON transactions (wallet_id, date DESC)
+WHERE is_deleted = false;
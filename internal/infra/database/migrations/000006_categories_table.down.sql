@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "transactions_category_id_idx";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "category_id";
+ALTER TABLE "transactions" ADD COLUMN "category" varchar NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS "categories_parent_id_idx";
+DROP INDEX IF EXISTS "categories_user_id_type_parent_id_name_unique";
+DROP TABLE IF EXISTS "categories" CASCADE;
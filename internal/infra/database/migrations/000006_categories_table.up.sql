@@ -0,0 +1,23 @@
+CREATE TABLE "categories" (
+  "id" uuid PRIMARY KEY,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "parent_id" uuid REFERENCES "categories" ("id"),
+  "name" varchar(50) NOT NULL,
+  "type" transaction_types NOT NULL,
+  "icon" varchar(50) NOT NULL DEFAULT '',
+  "color" char(7) NOT NULL DEFAULT '#9E9E9E',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX categories_user_id_type_parent_id_name_unique ON categories (
+  user_id, type, COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), lower(name)
+);
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+-- Free-text categories are replaced by references to the user's categories
+ALTER TABLE "transactions" DROP COLUMN "category";
+ALTER TABLE "transactions" ADD COLUMN "category_id" uuid REFERENCES "categories" ("id") ON DELETE SET NULL;
+
+CREATE INDEX transactions_category_id_idx ON transactions (category_id);
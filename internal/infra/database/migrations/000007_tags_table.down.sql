@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "transaction_tags_tag_id_idx";
+DROP TABLE IF EXISTS "transaction_tags" CASCADE;
+DROP INDEX IF EXISTS "tags_scope_owner_id_name_unique";
+DROP TABLE IF EXISTS "tags" CASCADE;
+DROP TYPE IF EXISTS "tag_scopes" CASCADE;
@@ -0,0 +1,24 @@
+CREATE TYPE "tag_scopes" AS ENUM (
+  'USER',
+  'WALLET'
+);
+
+-- owner_id is a user or a wallet depending on scope
+CREATE TABLE "tags" (
+  "id" uuid PRIMARY KEY,
+  "scope" tag_scopes NOT NULL,
+  "owner_id" uuid NOT NULL,
+  "name" varchar(40) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX tags_scope_owner_id_name_unique ON tags (scope, owner_id, name);
+
+CREATE TABLE "transaction_tags" (
+  "transaction_id" uuid NOT NULL REFERENCES "transactions" ("id") ON DELETE CASCADE,
+  "tag_id" uuid NOT NULL REFERENCES "tags" ("id") ON DELETE CASCADE,
+  PRIMARY KEY ("transaction_id", "tag_id")
+);
+
+CREATE INDEX transaction_tags_tag_id_idx ON transaction_tags (tag_id);
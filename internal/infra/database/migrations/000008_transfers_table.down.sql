@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS "transactions_transfer_id_idx";
+DELETE FROM "transactions" WHERE "transfer_id" IS NOT NULL;
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "transfer_id";
+DROP TABLE IF EXISTS "transfers" CASCADE;
+
+-- Enum values cannot be dropped, so the type is rebuilt without them
+ALTER TYPE "transaction_types" RENAME TO "transaction_types_old";
+CREATE TYPE "transaction_types" AS ENUM (
+  'INCOME',
+  'EXPENSE'
+);
+ALTER TABLE "transactions" ALTER COLUMN "type" TYPE transaction_types USING "type"::text::transaction_types;
+ALTER TABLE "categories" ALTER COLUMN "type" TYPE transaction_types USING "type"::text::transaction_types;
+DROP TYPE "transaction_types_old";
@@ -0,0 +1,18 @@
+ALTER TYPE "transaction_types" ADD VALUE IF NOT EXISTS 'TRANSFER_OUT';
+ALTER TYPE "transaction_types" ADD VALUE IF NOT EXISTS 'TRANSFER_IN';
+
+-- rate is units of the incoming currency per unit of the outgoing one
+CREATE TABLE "transfers" (
+  "id" uuid PRIMARY KEY,
+  "rate" numeric(24, 10) NOT NULL CHECK ("rate" > 0),
+  "created_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "is_deleted" boolean DEFAULT false,
+  "deleted_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transactions" ADD COLUMN "transfer_id" uuid REFERENCES "transfers" ("id");
+
+CREATE INDEX transactions_transfer_id_idx ON transactions (transfer_id)
+WHERE transfer_id IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "transactions_recurring_id_occurrence_date_unique";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "occurrence_date";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "recurring_id";
+DROP TABLE IF EXISTS "recurring_occurrence_overrides" CASCADE;
+DROP INDEX IF EXISTS "recurring_transactions_next_occurrence_idx";
+DROP INDEX IF EXISTS "recurring_transactions_wallet_id_idx";
+DROP TABLE IF EXISTS "recurring_transactions" CASCADE;
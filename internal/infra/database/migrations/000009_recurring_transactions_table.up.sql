@@ -0,0 +1,49 @@
+-- rule is an RRULE such as FREQ=MONTHLY;BYMONTHDAY=5. Occurrences up to
+-- materialized_through were created; next_occurrence is null once the
+-- schedule has ended.
+CREATE TABLE "recurring_transactions" (
+  "id" uuid PRIMARY KEY,
+  "wallet_id" uuid NOT NULL,
+  "type" transaction_types NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "currency" char(3) NOT NULL,
+  "description" varchar(255) NOT NULL DEFAULT '',
+  "category_id" uuid REFERENCES "categories" ("id") ON DELETE SET NULL,
+  "rule" varchar(255) NOT NULL,
+  "start_date" date NOT NULL,
+  "materialized_through" date DEFAULT null,
+  "next_occurrence" date DEFAULT null,
+  "created_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "is_deleted" boolean DEFAULT false,
+  "deleted_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX recurring_transactions_wallet_id_idx ON recurring_transactions (wallet_id)
+WHERE is_deleted = false;
+
+CREATE INDEX recurring_transactions_next_occurrence_idx ON recurring_transactions (next_occurrence)
+WHERE is_deleted = false AND next_occurrence IS NOT NULL;
+
+-- Null columns keep the template value
+CREATE TABLE "recurring_occurrence_overrides" (
+  "recurring_id" uuid NOT NULL REFERENCES "recurring_transactions" ("id") ON DELETE CASCADE,
+  "occurrence_date" date NOT NULL,
+  "skip" boolean NOT NULL DEFAULT false,
+  "amount" bigint CHECK ("amount" > 0),
+  "currency" char(3),
+  "description" varchar(255),
+  "category_id" uuid REFERENCES "categories" ("id") ON DELETE SET NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("recurring_id", "occurrence_date")
+);
+
+ALTER TABLE "transactions" ADD COLUMN "recurring_id" uuid REFERENCES "recurring_transactions" ("id");
+ALTER TABLE "transactions" ADD COLUMN "occurrence_date" date;
+
+-- Makes materializing an occurrence idempotent, even after its transaction
+-- was deleted
+CREATE UNIQUE INDEX transactions_recurring_id_occurrence_date_unique ON transactions (recurring_id, occurrence_date)
+WHERE recurring_id IS NOT NULL;
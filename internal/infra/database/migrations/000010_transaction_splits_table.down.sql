@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "transaction_splits_category_id_idx";
+DROP INDEX IF EXISTS "transaction_splits_transaction_id_position_unique";
+DROP TABLE IF EXISTS "transaction_splits" CASCADE;
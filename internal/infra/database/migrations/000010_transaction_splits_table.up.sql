@@ -0,0 +1,14 @@
+-- Lines of a split transaction; they add up to the transaction amount and
+-- are in its currency
+CREATE TABLE "transaction_splits" (
+  "id" uuid PRIMARY KEY,
+  "transaction_id" uuid NOT NULL REFERENCES "transactions" ("id") ON DELETE CASCADE,
+  "position" smallint NOT NULL,
+  "category_id" uuid REFERENCES "categories" ("id") ON DELETE SET NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "currency" char(3) NOT NULL,
+  "note" varchar(255) NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX transaction_splits_transaction_id_position_unique ON transaction_splits (transaction_id, position);
+CREATE INDEX transaction_splits_category_id_idx ON transaction_splits (category_id);
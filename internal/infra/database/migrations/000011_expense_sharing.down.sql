@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "transaction_shares_user_id_idx";
+DROP TABLE IF EXISTS "transaction_shares" CASCADE;
+DROP TABLE IF EXISTS "transaction_sharing" CASCADE;
+DROP TYPE IF EXISTS "share_methods";
@@ -0,0 +1,26 @@
+CREATE TYPE "share_methods" AS ENUM (
+  'EQUAL',
+  'PERCENTAGE',
+  'SHARES',
+  'EXACT'
+);
+
+-- Who paid a shared expense and how it is divided; one row per shared
+-- transaction
+CREATE TABLE "transaction_sharing" (
+  "transaction_id" uuid PRIMARY KEY REFERENCES "transactions" ("id") ON DELETE CASCADE,
+  "paid_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "method" share_methods NOT NULL
+);
+
+-- weight is a share count, or hundredths of a percent for PERCENTAGE
+CREATE TABLE "transaction_shares" (
+  "transaction_id" uuid NOT NULL REFERENCES "transaction_sharing" ("transaction_id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "weight" bigint NOT NULL DEFAULT 0,
+  "amount" bigint NOT NULL CHECK ("amount" >= 0),
+  "currency" char(3) NOT NULL,
+  PRIMARY KEY ("transaction_id", "user_id")
+);
+
+CREATE INDEX transaction_shares_user_id_idx ON transaction_shares (user_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "settlements_wallet_id_date_idx";
+DROP TABLE IF EXISTS "settlements" CASCADE;
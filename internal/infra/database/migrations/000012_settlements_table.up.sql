@@ -0,0 +1,19 @@
+-- Settlements are only ever voided, never updated or deleted, so the
+-- history stays auditable
+CREATE TABLE "settlements" (
+  "id" uuid PRIMARY KEY,
+  "wallet_id" uuid NOT NULL,
+  "from_user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "to_user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "currency" char(3) NOT NULL,
+  "date" date NOT NULL,
+  "note" varchar(255) NOT NULL DEFAULT '',
+  "created_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "voided_by" uuid REFERENCES "users" ("id"),
+  "voided_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  CHECK ("from_user_id" <> "to_user_id")
+);
+
+CREATE INDEX settlements_wallet_id_date_idx ON settlements (wallet_id, date DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "transactions_deleted_at_idx";
+DROP INDEX IF EXISTS "attachments_transaction_id_idx";
+DROP TABLE IF EXISTS "attachments" CASCADE;
@@ -0,0 +1,19 @@
+-- Metadata of files attached to transactions; the content itself lives in
+-- file storage under storage_key. Attachments are removed before their
+-- transaction is purged, so a purge never leaves files behind.
+CREATE TABLE "attachments" (
+  "id" uuid PRIMARY KEY,
+  "transaction_id" uuid NOT NULL REFERENCES "transactions" ("id"),
+  "wallet_id" uuid NOT NULL,
+  "file_name" varchar(255) NOT NULL,
+  "content_type" varchar(100) NOT NULL,
+  "size" bigint NOT NULL CHECK ("size" > 0),
+  "checksum" char(64) NOT NULL,
+  "storage_key" text NOT NULL UNIQUE,
+  "thumbnail_key" text DEFAULT null,
+  "uploaded_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX attachments_transaction_id_idx ON attachments (transaction_id);
+CREATE INDEX transactions_deleted_at_idx ON transactions (deleted_at) WHERE is_deleted = true;
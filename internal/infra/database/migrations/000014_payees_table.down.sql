@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "transactions_payee_id_idx";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "payee_id";
+DROP INDEX IF EXISTS "payee_aliases_payee_id_idx";
+DROP TABLE IF EXISTS "payee_aliases" CASCADE;
+DROP INDEX IF EXISTS "payees_user_id_idx";
+DROP TABLE IF EXISTS "payees" CASCADE;
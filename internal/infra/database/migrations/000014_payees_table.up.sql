@@ -0,0 +1,25 @@
+CREATE TABLE "payees" (
+  "id" uuid PRIMARY KEY,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "name" varchar(100) NOT NULL,
+  "default_category_id" uuid REFERENCES "categories" ("id") ON DELETE SET NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX payees_user_id_idx ON payees (user_id);
+
+-- Normalized names that map to a payee; each one maps to a single payee of
+-- the user
+CREATE TABLE "payee_aliases" (
+  "user_id" uuid NOT NULL,
+  "alias" varchar(100) NOT NULL,
+  "payee_id" uuid NOT NULL REFERENCES "payees" ("id") ON DELETE CASCADE,
+  PRIMARY KEY ("user_id", "alias")
+);
+
+CREATE INDEX payee_aliases_payee_id_idx ON payee_aliases (payee_id);
+
+ALTER TABLE "transactions" ADD COLUMN "payee_id" uuid REFERENCES "payees" ("id") ON DELETE SET NULL;
+
+CREATE INDEX transactions_payee_id_idx ON transactions (payee_id);
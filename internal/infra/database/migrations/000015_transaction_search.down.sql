@@ -0,0 +1,22 @@
+DROP INDEX IF EXISTS "transactions_created_by_idx";
+DROP FUNCTION IF EXISTS accessible_wallet_ids(uuid);
+
+DROP TRIGGER IF EXISTS payees_search_vector_refresh ON payees;
+DROP FUNCTION IF EXISTS payees_search_vector_refresh();
+DROP TRIGGER IF EXISTS tags_search_vector_refresh ON tags;
+DROP FUNCTION IF EXISTS tags_search_vector_refresh();
+DROP TRIGGER IF EXISTS transaction_splits_search_vector_refresh ON transaction_splits;
+DROP TRIGGER IF EXISTS transaction_tags_search_vector_refresh ON transaction_tags;
+DROP FUNCTION IF EXISTS transaction_details_search_vector_refresh();
+DROP TRIGGER IF EXISTS transactions_search_vector_refresh ON transactions;
+DROP FUNCTION IF EXISTS transactions_search_vector_refresh();
+
+DROP INDEX IF EXISTS "transactions_search_vector_idx";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "search_vector";
+
+DROP FUNCTION IF EXISTS transaction_search_vector(uuid, text, uuid);
+DROP FUNCTION IF EXISTS transaction_search_document(uuid, text, uuid);
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS saver_english;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS saver_portuguese;
+DROP EXTENSION IF EXISTS unaccent;
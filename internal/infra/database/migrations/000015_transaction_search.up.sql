@@ -0,0 +1,138 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- Portuguese and English text search that ignores accents, so "cafe" finds
+-- "Café" and "acougue" finds "Açougue"
+CREATE TEXT SEARCH CONFIGURATION saver_portuguese (COPY = portuguese);
+ALTER TEXT SEARCH CONFIGURATION saver_portuguese
+  ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+
+CREATE TEXT SEARCH CONFIGURATION saver_english (COPY = english);
+ALTER TEXT SEARCH CONFIGURATION saver_english
+  ALTER MAPPING FOR hword, hword_part, word WITH unaccent, english_stem;
+
+-- The searchable text of a transaction, split by weight: description and
+-- payee, then tag names, then split line notes
+CREATE FUNCTION transaction_search_document(p_transaction_id uuid, p_description text, p_payee_id uuid)
+RETURNS TABLE (main text, tags text, notes text)
+LANGUAGE sql STABLE AS $$
+  SELECT
+    concat_ws(' ', p_description, (SELECT name FROM payees WHERE id = p_payee_id)),
+    coalesce((
+      SELECT string_agg(t.name, ' ' ORDER BY t.name)
+      FROM transaction_tags tt
+      JOIN tags t ON t.id = tt.tag_id
+      WHERE tt.transaction_id = p_transaction_id
+    ), ''),
+    coalesce((
+      SELECT string_agg(note, ' ' ORDER BY position)
+      FROM transaction_splits
+      WHERE transaction_id = p_transaction_id
+    ), '')
+$$;
+
+CREATE FUNCTION transaction_search_vector(p_transaction_id uuid, p_description text, p_payee_id uuid)
+RETURNS tsvector
+LANGUAGE sql STABLE AS $$
+  SELECT
+    setweight(to_tsvector('saver_portuguese', main) || to_tsvector('saver_english', main), 'A') ||
+    setweight(to_tsvector('saver_portuguese', tags) || to_tsvector('saver_english', tags), 'B') ||
+    setweight(to_tsvector('saver_portuguese', notes) || to_tsvector('saver_english', notes), 'C')
+  FROM transaction_search_document(p_transaction_id, p_description, p_payee_id)
+$$;
+
+ALTER TABLE "transactions" ADD COLUMN "search_vector" tsvector NOT NULL DEFAULT '';
+
+UPDATE transactions SET search_vector = transaction_search_vector(id, description, payee_id);
+
+CREATE INDEX transactions_search_vector_idx ON transactions USING gin (search_vector);
+
+-- Keep search_vector current as the transaction and the rows it takes text
+-- from change
+CREATE FUNCTION transactions_search_vector_refresh() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  NEW.search_vector := transaction_search_vector(NEW.id, NEW.description, NEW.payee_id);
+  RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER transactions_search_vector_refresh
+BEFORE INSERT OR UPDATE OF description, payee_id ON transactions
+FOR EACH ROW EXECUTE FUNCTION transactions_search_vector_refresh();
+
+CREATE FUNCTION transaction_details_search_vector_refresh() RETURNS trigger
+LANGUAGE plpgsql AS $$
+DECLARE
+  changed_id uuid;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed_id := OLD.transaction_id;
+  ELSE
+    changed_id := NEW.transaction_id;
+  END IF;
+
+  UPDATE transactions
+  SET search_vector = transaction_search_vector(id, description, payee_id)
+  WHERE id = changed_id;
+  RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER transaction_tags_search_vector_refresh
+AFTER INSERT OR DELETE ON transaction_tags
+FOR EACH ROW EXECUTE FUNCTION transaction_details_search_vector_refresh();
+
+CREATE TRIGGER transaction_splits_search_vector_refresh
+AFTER INSERT OR UPDATE OF note OR DELETE ON transaction_splits
+FOR EACH ROW EXECUTE FUNCTION transaction_details_search_vector_refresh();
+
+CREATE FUNCTION tags_search_vector_refresh() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  UPDATE transactions
+  SET search_vector = transaction_search_vector(id, description, payee_id)
+  WHERE id IN (SELECT transaction_id FROM transaction_tags WHERE tag_id = NEW.id);
+  RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER tags_search_vector_refresh
+AFTER UPDATE OF name ON tags
+FOR EACH ROW EXECUTE FUNCTION tags_search_vector_refresh();
+
+CREATE FUNCTION payees_search_vector_refresh() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  UPDATE transactions
+  SET search_vector = transaction_search_vector(id, description, payee_id)
+  WHERE payee_id = NEW.id;
+  RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER payees_search_vector_refresh
+AFTER UPDATE OF name ON payees
+FOR EACH ROW EXECUTE FUNCTION payees_search_vector_refresh();
+
+-- The wallets a user takes part in. Wallets have no members yet, so these
+-- are the wallets where the user recorded, paid or shares a transaction, or
+-- settled up with someone
+CREATE FUNCTION accessible_wallet_ids(p_user_id uuid)
+RETURNS TABLE (wallet_id uuid)
+LANGUAGE sql STABLE AS $$
+  SELECT t.wallet_id FROM transactions t WHERE t.created_by = p_user_id
+  UNION
+  SELECT t.wallet_id
+  FROM transaction_sharing sh
+  JOIN transactions t ON t.id = sh.transaction_id
+  WHERE sh.paid_by = p_user_id
+  UNION
+  SELECT t.wallet_id
+  FROM transaction_shares s
+  JOIN transactions t ON t.id = s.transaction_id
+  WHERE s.user_id = p_user_id
+  UNION
+  SELECT s.wallet_id FROM settlements s WHERE p_user_id IN (s.from_user_id, s.to_user_id)
+$$;
+
+CREATE INDEX transactions_created_by_idx ON transactions (created_by);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "transactions_scheduled_date_idx";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "status";
+DROP TYPE IF EXISTS "transaction_statuses";
@@ -0,0 +1,15 @@
+CREATE TYPE "transaction_statuses" AS ENUM (
+  'SCHEDULED',
+  'PENDING',
+  'CLEARED',
+  'RECONCILED'
+);
+
+ALTER TABLE "transactions" ADD COLUMN "status" transaction_statuses NOT NULL DEFAULT 'CLEARED';
+
+-- Transactions already dated in the future are waiting for their date
+UPDATE "transactions" SET "status" = 'SCHEDULED' WHERE "date" > CURRENT_DATE;
+
+-- Scheduled transactions are promoted by date
+CREATE INDEX transactions_scheduled_date_idx ON transactions (date)
+WHERE status = 'SCHEDULED' AND is_deleted = false;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "rule_tags_tag_id_idx";
+DROP TABLE IF EXISTS "rule_tags" CASCADE;
+DROP INDEX IF EXISTS "rules_user_id_priority_idx";
+DROP TABLE IF EXISTS "rules" CASCADE;
@@ -0,0 +1,28 @@
+-- Auto-categorization rules. Conditions left null or empty are not checked;
+-- the amount bounds share the currency column
+CREATE TABLE "rules" (
+  "id" uuid PRIMARY KEY,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "name" varchar(100) NOT NULL,
+  "priority" integer NOT NULL DEFAULT 0 CHECK ("priority" >= 0),
+  "description_pattern" varchar(200) NOT NULL DEFAULT '',
+  "min_amount" bigint,
+  "max_amount" bigint,
+  "currency" char(3),
+  "payee_id" uuid REFERENCES "payees" ("id") ON DELETE CASCADE,
+  "wallet_id" uuid,
+  "category_id" uuid REFERENCES "categories" ("id") ON DELETE SET NULL,
+  "set_description" varchar(255) NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX rules_user_id_priority_idx ON rules (user_id, priority);
+
+CREATE TABLE "rule_tags" (
+  "rule_id" uuid NOT NULL REFERENCES "rules" ("id") ON DELETE CASCADE,
+  "tag_id" uuid NOT NULL REFERENCES "tags" ("id") ON DELETE CASCADE,
+  PRIMARY KEY ("rule_id", "tag_id")
+);
+
+CREATE INDEX rule_tags_tag_id_idx ON rule_tags (tag_id);
@@ -0,0 +1,9 @@
+ALTER TABLE "attachments" DROP CONSTRAINT IF EXISTS "attachments_wallet_id_fkey";
+ALTER TABLE "settlements" DROP CONSTRAINT IF EXISTS "settlements_wallet_id_fkey";
+ALTER TABLE "recurring_transactions" DROP CONSTRAINT IF EXISTS "recurring_transactions_wallet_id_fkey";
+ALTER TABLE "transactions" DROP CONSTRAINT IF EXISTS "transactions_wallet_id_fkey";
+
+DROP INDEX IF EXISTS "wallet_members_user_id_idx";
+DROP TABLE IF EXISTS "wallet_members" CASCADE;
+DROP TABLE IF EXISTS "wallets" CASCADE;
+DROP TYPE IF EXISTS "wallet_roles";
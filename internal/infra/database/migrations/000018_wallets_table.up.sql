@@ -0,0 +1,57 @@
+CREATE TYPE "wallet_roles" AS ENUM (
+  'OWNER',
+  'MEMBER'
+);
+
+CREATE TABLE "wallets" (
+  "id" uuid PRIMARY KEY,
+  "name" varchar(100) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- Only members can read or write the wallet transactions
+CREATE TABLE "wallet_members" (
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "role" wallet_roles NOT NULL,
+  "joined_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("wallet_id", "user_id")
+);
+
+CREATE INDEX wallet_members_user_id_idx ON wallet_members (user_id);
+
+-- Wallets so far were only IDs on the rows that used them
+INSERT INTO "wallets" ("id", "name", "created_at", "updated_at")
+SELECT wallet_id, 'Wallet', min(created_at), min(created_at)
+FROM (
+  SELECT wallet_id, created_at FROM transactions
+  UNION ALL
+  SELECT wallet_id, created_at FROM recurring_transactions
+  UNION ALL
+  SELECT wallet_id, created_at FROM settlements
+  UNION ALL
+  SELECT wallet_id, created_at FROM attachments
+) used
+GROUP BY wallet_id;
+
+-- The first user to record something in a wallet owns it; the others who
+-- recorded something there become members
+INSERT INTO "wallet_members" ("wallet_id", "user_id", "role", "joined_at")
+SELECT wallet_id, created_by,
+  CASE WHEN row_number() OVER (PARTITION BY wallet_id ORDER BY min(created_at)) = 1
+    THEN 'OWNER'::wallet_roles ELSE 'MEMBER'::wallet_roles END,
+  min(created_at)
+FROM (
+  SELECT wallet_id, created_by, created_at FROM transactions
+  UNION ALL
+  SELECT wallet_id, created_by, created_at FROM recurring_transactions
+  UNION ALL
+  SELECT wallet_id, created_by, created_at FROM settlements
+) recorded
+GROUP BY wallet_id, created_by;
+
+ALTER TABLE "transactions" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id");
+ALTER TABLE "recurring_transactions" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id");
+ALTER TABLE "settlements" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id");
+ALTER TABLE "attachments" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id");
@@ -0,0 +1,6 @@
+-- Members of the household get access to its wallets: owners and admins
+-- manage them, members use them
+ALTER TABLE "wallets" ADD COLUMN "household_id" uuid DEFAULT null REFERENCES "households" ("id") ON DELETE SET NULL;
+
+CREATE INDEX wallets_household_id_idx ON wallets (household_id)
+WHERE household_id IS NOT NULL;
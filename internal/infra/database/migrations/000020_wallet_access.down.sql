@@ -0,0 +1,17 @@
+CREATE OR REPLACE FUNCTION accessible_wallet_ids(p_user_id uuid)
+RETURNS TABLE (wallet_id uuid)
+LANGUAGE sql STABLE AS $$
+  SELECT t.wallet_id FROM transactions t WHERE t.created_by = p_user_id
+  UNION
+  SELECT t.wallet_id
+  FROM transaction_sharing sh
+  JOIN transactions t ON t.id = sh.transaction_id
+  WHERE sh.paid_by = p_user_id
+  UNION
+  SELECT t.wallet_id
+  FROM transaction_shares s
+  JOIN transactions t ON t.id = s.transaction_id
+  WHERE s.user_id = p_user_id
+  UNION
+  SELECT s.wallet_id FROM settlements s WHERE p_user_id IN (s.from_user_id, s.to_user_id)
+$$;
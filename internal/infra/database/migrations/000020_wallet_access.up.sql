@@ -0,0 +1,13 @@
+-- Access to a wallet comes from membership only, directly or through the
+-- household owning it. Recording a transaction in a wallet no longer grants
+-- access to it.
+CREATE OR REPLACE FUNCTION accessible_wallet_ids(p_user_id uuid)
+RETURNS TABLE (wallet_id uuid)
+LANGUAGE sql STABLE AS $$
+  SELECT wm.wallet_id FROM wallet_members wm WHERE wm.user_id = p_user_id
+  UNION
+  SELECT w.id
+  FROM wallets w
+  JOIN household_members hm ON hm.household_id = w.household_id
+  WHERE hm.user_id = p_user_id
+$$;
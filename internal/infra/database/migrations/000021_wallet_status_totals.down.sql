@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS transactions_wallet_status_totals_refresh ON transactions;
+DROP FUNCTION IF EXISTS wallet_status_totals_refresh();
+DROP FUNCTION IF EXISTS wallet_status_totals_apply(transactions, integer);
+
+DROP TABLE IF EXISTS "wallet_status_totals";
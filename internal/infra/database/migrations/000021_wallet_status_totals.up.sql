@@ -0,0 +1,56 @@
+-- Running totals of every wallet by status and currency, so balances are read
+-- instead of adding up every transaction of the wallet on each request
+CREATE TABLE "wallet_status_totals" (
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "status" transaction_statuses NOT NULL,
+  "currency" char(3) NOT NULL,
+  "inflow" bigint NOT NULL DEFAULT 0,
+  "outflow" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("wallet_id", "status", "currency")
+);
+
+INSERT INTO wallet_status_totals (wallet_id, status, currency, inflow, outflow)
+SELECT wallet_id, status, currency,
+  coalesce(sum(amount) FILTER (WHERE type IN ('INCOME', 'TRANSFER_IN')), 0),
+  coalesce(sum(amount) FILTER (WHERE type IN ('EXPENSE', 'TRANSFER_OUT')), 0)
+FROM transactions
+WHERE is_deleted = false
+GROUP BY wallet_id, status, currency;
+
+-- Adds a transaction to the totals of its wallet, or takes it out when sign
+-- is -1. Deleted transactions are not counted.
+CREATE FUNCTION wallet_status_totals_apply(t transactions, sign integer) RETURNS void
+LANGUAGE plpgsql AS $$
+BEGIN
+  IF t.is_deleted THEN
+    RETURN;
+  END IF;
+
+  INSERT INTO wallet_status_totals (wallet_id, status, currency, inflow, outflow)
+  VALUES (
+    t.wallet_id, t.status, t.currency,
+    CASE WHEN t.type IN ('INCOME', 'TRANSFER_IN') THEN sign * t.amount ELSE 0 END,
+    CASE WHEN t.type IN ('EXPENSE', 'TRANSFER_OUT') THEN sign * t.amount ELSE 0 END
+  )
+  ON CONFLICT (wallet_id, status, currency) DO UPDATE
+  SET inflow = wallet_status_totals.inflow + EXCLUDED.inflow,
+    outflow = wallet_status_totals.outflow + EXCLUDED.outflow;
+END
+$$;
+
+CREATE FUNCTION wallet_status_totals_refresh() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    PERFORM wallet_status_totals_apply(OLD, -1);
+  END IF;
+  IF TG_OP IN ('INSERT', 'UPDATE') THEN
+    PERFORM wallet_status_totals_apply(NEW, 1);
+  END IF;
+  RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER transactions_wallet_status_totals_refresh
+AFTER INSERT OR UPDATE OF wallet_id, status, type, amount, currency, is_deleted OR DELETE ON transactions
+FOR EACH ROW EXECUTE FUNCTION wallet_status_totals_refresh();
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "approvals_pending_expires_at_idx";
+DROP INDEX IF EXISTS "approvals_wallet_id_idx";
+DROP INDEX IF EXISTS "approvals_pending_transaction_idx";
+DROP TABLE IF EXISTS "approvals";
+DROP TYPE IF EXISTS "approval_statuses";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "awaiting_approval";
+ALTER TABLE "wallets" DROP CONSTRAINT IF EXISTS wallets_approval_threshold_check;
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "approval_ttl_hours";
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "approval_threshold_currency";
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "approval_threshold";
@@ -0,0 +1,41 @@
+-- Expenses above the threshold are held until a wallet owner reviews them;
+-- wallets without a threshold hold nothing
+ALTER TABLE "wallets" ADD COLUMN "approval_threshold" bigint;
+ALTER TABLE "wallets" ADD COLUMN "approval_threshold_currency" char(3);
+ALTER TABLE "wallets" ADD COLUMN "approval_ttl_hours" integer NOT NULL DEFAULT 72;
+ALTER TABLE "wallets" ADD CONSTRAINT wallets_approval_threshold_check
+CHECK (("approval_threshold" IS NULL) = ("approval_threshold_currency" IS NULL) AND "approval_ttl_hours" > 0);
+
+ALTER TABLE "transactions" ADD COLUMN "awaiting_approval" boolean NOT NULL DEFAULT false;
+
+CREATE TYPE "approval_statuses" AS ENUM (
+  'PENDING',
+  'APPROVED',
+  'REJECTED',
+  'EXPIRED'
+);
+
+CREATE TABLE "approvals" (
+  "id" uuid PRIMARY KEY,
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "transaction_id" uuid NOT NULL REFERENCES "transactions" ("id") ON DELETE CASCADE,
+  "requested_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "amount" bigint NOT NULL,
+  "currency" char(3) NOT NULL,
+  "status" approval_statuses NOT NULL DEFAULT 'PENDING',
+  "reviewed_by" uuid REFERENCES "users" ("id"),
+  "comment" text,
+  "requested_at" timestamp NOT NULL DEFAULT (now()),
+  "expires_at" timestamp NOT NULL,
+  "reviewed_at" timestamp
+);
+
+-- A held expense has a single open request
+CREATE UNIQUE INDEX approvals_pending_transaction_idx ON approvals (transaction_id)
+WHERE status = 'PENDING';
+
+CREATE INDEX approvals_wallet_id_idx ON approvals (wallet_id, requested_at);
+
+-- Overdue requests are expired by date
+CREATE INDEX approvals_pending_expires_at_idx ON approvals (expires_at)
+WHERE status = 'PENDING';
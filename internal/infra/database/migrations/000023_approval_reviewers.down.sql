@@ -0,0 +1 @@
+ALTER TABLE "approvals" DROP COLUMN IF EXISTS "reviewer_id";
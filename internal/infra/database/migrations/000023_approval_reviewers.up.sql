@@ -0,0 +1,3 @@
+-- Expenses of a dependent are reviewed by their parent only; requests
+-- without a reviewer can be reviewed by any wallet owner
+ALTER TABLE "approvals" ADD COLUMN "reviewer_id" uuid REFERENCES "users" ("id");
@@ -0,0 +1,3 @@
+-- Balances and reports are converted into the base currency of the caller
+-- when they do not ask for another one
+ALTER TABLE "users" ADD COLUMN "base_currency" char(3);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS transactions_wallet_balance_snapshots_invalidate ON transactions;
+DROP FUNCTION IF EXISTS wallet_balance_snapshots_invalidate();
+DROP TABLE IF EXISTS "wallet_balance_snapshots";
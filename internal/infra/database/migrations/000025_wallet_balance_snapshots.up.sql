@@ -0,0 +1,33 @@
+-- Current balance of every wallet and currency at the end of a day, so a
+-- balance as of a past date only adds up the transactions after the latest
+-- snapshot before it
+CREATE TABLE "wallet_balance_snapshots" (
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "as_of" date NOT NULL,
+  "currency" char(3) NOT NULL,
+  "balance" bigint NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("wallet_id", "as_of", "currency")
+);
+
+-- A change to a transaction dated on or before a snapshot makes the snapshot
+-- stale, so it is dropped in the same transaction and taken again by the
+-- next run
+CREATE FUNCTION wallet_balance_snapshots_invalidate() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    DELETE FROM wallet_balance_snapshots
+    WHERE wallet_id = OLD.wallet_id AND as_of >= OLD.date;
+  END IF;
+  IF TG_OP IN ('INSERT', 'UPDATE') THEN
+    DELETE FROM wallet_balance_snapshots
+    WHERE wallet_id = NEW.wallet_id AND as_of >= NEW.date;
+  END IF;
+  RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER transactions_wallet_balance_snapshots_invalidate
+AFTER INSERT OR UPDATE OF wallet_id, date, status, type, amount, currency, is_deleted OR DELETE ON transactions
+FOR EACH ROW EXECUTE FUNCTION wallet_balance_snapshots_invalidate();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "reconciliation_transactions_transaction_id_idx";
+DROP TABLE IF EXISTS "reconciliation_transactions";
+DROP INDEX IF EXISTS "reconciliations_wallet_id_idx";
+DROP INDEX IF EXISTS "reconciliations_open_wallet_currency_idx";
+DROP TABLE IF EXISTS "reconciliations";
+DROP TYPE IF EXISTS "reconciliation_statuses";
@@ -0,0 +1,33 @@
+CREATE TYPE "reconciliation_statuses" AS ENUM (
+  'OPEN',
+  'COMPLETED'
+);
+
+-- A wallet checked against a bank statement in one currency
+CREATE TABLE "reconciliations" (
+  "id" uuid PRIMARY KEY,
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "statement_date" date NOT NULL,
+  "statement_balance" bigint NOT NULL,
+  "currency" char(3) NOT NULL,
+  "status" reconciliation_statuses NOT NULL DEFAULT 'OPEN',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "completed_at" timestamp
+);
+
+-- A wallet has a single open session per currency
+CREATE UNIQUE INDEX reconciliations_open_wallet_currency_idx ON reconciliations (wallet_id, currency)
+WHERE status = 'OPEN';
+
+CREATE INDEX reconciliations_wallet_id_idx ON reconciliations (wallet_id, created_at);
+
+-- The transactions ticked off in a session; once it is completed, the
+-- transactions it reconciled
+CREATE TABLE "reconciliation_transactions" (
+  "reconciliation_id" uuid NOT NULL REFERENCES "reconciliations" ("id") ON DELETE CASCADE,
+  "transaction_id" uuid NOT NULL REFERENCES "transactions" ("id") ON DELETE CASCADE,
+  PRIMARY KEY ("reconciliation_id", "transaction_id")
+);
+
+CREATE INDEX reconciliation_transactions_transaction_id_idx ON reconciliation_transactions (transaction_id);
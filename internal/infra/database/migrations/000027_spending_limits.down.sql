@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "spending_limits_wallet_user_idx";
+DROP TABLE IF EXISTS "spending_limits";
+DROP TYPE IF EXISTS "limit_periods";
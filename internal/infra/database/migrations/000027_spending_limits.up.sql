@@ -0,0 +1,23 @@
+CREATE TYPE "limit_periods" AS ENUM (
+  'TRANSACTION',
+  'DAY',
+  'WEEK',
+  'MONTH'
+);
+
+-- Caps on the expenses a member records in a wallet, optionally only those
+-- under one category
+CREATE TABLE "spending_limits" (
+  "id" uuid PRIMARY KEY,
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "category_id" uuid REFERENCES "categories" ("id") ON DELETE CASCADE,
+  "period" limit_periods NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "currency" char(3) NOT NULL,
+  "created_by" uuid NOT NULL REFERENCES "users" ("id"),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX spending_limits_wallet_user_idx ON spending_limits (wallet_id, user_id);
@@ -0,0 +1,16 @@
+-- In-app messages, kept until their recipient is deleted
+CREATE TABLE "notifications" (
+  "id" uuid PRIMARY KEY,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "type" varchar(50) NOT NULL,
+  "wallet_id" uuid REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "message" text NOT NULL,
+  "data" jsonb NOT NULL DEFAULT '{}',
+  "read_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC);
+
+CREATE INDEX notifications_unread_idx ON notifications (user_id)
+WHERE read_at IS NULL;
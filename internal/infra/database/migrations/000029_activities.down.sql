@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "activity_reads";
+DROP INDEX IF EXISTS "activities_wallet_id_created_at_idx";
+DROP TABLE IF EXISTS "activities";
@@ -0,0 +1,21 @@
+-- The wallet activity feed; changes maps each changed field to its value
+-- before and after the event
+CREATE TABLE "activities" (
+  "id" uuid PRIMARY KEY,
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "actor_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "type" varchar(50) NOT NULL,
+  "subject_id" uuid,
+  "changes" jsonb NOT NULL DEFAULT '{}',
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX activities_wallet_id_created_at_idx ON activities (wallet_id, created_at DESC);
+
+-- Each member marks the items they have seen
+CREATE TABLE "activity_reads" (
+  "activity_id" uuid NOT NULL REFERENCES "activities" ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "read_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("activity_id", "user_id")
+);
@@ -0,0 +1,34 @@
+DROP TRIGGER IF EXISTS transaction_comments_search_vector_refresh ON transaction_comments;
+DROP FUNCTION IF EXISTS transaction_search_document(uuid, text, uuid);
+CREATE FUNCTION transaction_search_document(p_transaction_id uuid, p_description text, p_payee_id uuid)
+RETURNS TABLE (main text, tags text, notes text)
+LANGUAGE sql STABLE AS $$
+  SELECT
+    concat_ws(' ', p_description, (SELECT name FROM payees WHERE id = p_payee_id)),
+    coalesce((
+      SELECT string_agg(t.name, ' ' ORDER BY t.name)
+      FROM transaction_tags tt
+      JOIN tags t ON t.id = tt.tag_id
+      WHERE tt.transaction_id = p_transaction_id
+    ), ''),
+    coalesce((
+      SELECT string_agg(note, ' ' ORDER BY position)
+      FROM transaction_splits
+      WHERE transaction_id = p_transaction_id
+    ), '')
+$$;
+CREATE OR REPLACE FUNCTION transaction_search_vector(p_transaction_id uuid, p_description text, p_payee_id uuid)
+RETURNS tsvector
+LANGUAGE sql STABLE AS $$
+  SELECT
+    setweight(to_tsvector('saver_portuguese', main) || to_tsvector('saver_english', main), 'A') ||
+    setweight(to_tsvector('saver_portuguese', tags) || to_tsvector('saver_english', tags), 'B') ||
+    setweight(to_tsvector('saver_portuguese', notes) || to_tsvector('saver_english', notes), 'C')
+  FROM transaction_search_document(p_transaction_id, p_description, p_payee_id)
+$$;
+UPDATE transactions SET search_vector = transaction_search_vector(id, description, payee_id)
+WHERE id IN (SELECT transaction_id FROM transaction_comments);
+DROP INDEX IF EXISTS "transaction_comment_edits_comment_id_idx";
+DROP TABLE IF EXISTS "transaction_comment_edits";
+DROP INDEX IF EXISTS "transaction_comments_transaction_id_idx";
+DROP TABLE IF EXISTS "transaction_comments";
@@ -0,0 +1,69 @@
+-- Comments on transactions; a reply points to the comment it answers.
+-- Deleted comments are kept, like users, so their replies keep a thread
+CREATE TABLE "transaction_comments" (
+  "id" uuid PRIMARY KEY,
+  "transaction_id" uuid NOT NULL REFERENCES "transactions" ("id") ON DELETE CASCADE,
+  "wallet_id" uuid NOT NULL REFERENCES "wallets" ("id") ON DELETE CASCADE,
+  "parent_id" uuid REFERENCES "transaction_comments" ("id") ON DELETE CASCADE,
+  "author_id" uuid NOT NULL REFERENCES "users" ("id"),
+  "body" text NOT NULL,
+  "edited_at" timestamp DEFAULT null,
+  "is_deleted" boolean DEFAULT false,
+  "deleted_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX transaction_comments_transaction_id_idx ON transaction_comments (transaction_id, created_at);
+
+-- The body each comment had before every edit
+CREATE TABLE "transaction_comment_edits" (
+  "id" uuid PRIMARY KEY,
+  "comment_id" uuid NOT NULL REFERENCES "transaction_comments" ("id") ON DELETE CASCADE,
+  "body" text NOT NULL,
+  "edited_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX transaction_comment_edits_comment_id_idx ON transaction_comment_edits (comment_id, edited_at);
+
+-- Comments that are not deleted become part of the searchable text, with
+-- the lowest weight
+DROP FUNCTION transaction_search_document(uuid, text, uuid);
+
+CREATE FUNCTION transaction_search_document(p_transaction_id uuid, p_description text, p_payee_id uuid)
+RETURNS TABLE (main text, tags text, notes text, comments text)
+LANGUAGE sql STABLE AS $$
+  SELECT
+    concat_ws(' ', p_description, (SELECT name FROM payees WHERE id = p_payee_id)),
+    coalesce((
+      SELECT string_agg(t.name, ' ' ORDER BY t.name)
+      FROM transaction_tags tt
+      JOIN tags t ON t.id = tt.tag_id
+      WHERE tt.transaction_id = p_transaction_id
+    ), ''),
+    coalesce((
+      SELECT string_agg(note, ' ' ORDER BY position)
+      FROM transaction_splits
+      WHERE transaction_id = p_transaction_id
+    ), ''),
+    coalesce((
+      SELECT string_agg(body, ' ' ORDER BY created_at)
+      FROM transaction_comments
+      WHERE transaction_id = p_transaction_id AND is_deleted = false
+    ), '')
+$$;
+
+CREATE OR REPLACE FUNCTION transaction_search_vector(p_transaction_id uuid, p_description text, p_payee_id uuid)
+RETURNS tsvector
+LANGUAGE sql STABLE AS $$
+  SELECT
+    setweight(to_tsvector('saver_portuguese', main) || to_tsvector('saver_english', main), 'A') ||
+    setweight(to_tsvector('saver_portuguese', tags) || to_tsvector('saver_english', tags), 'B') ||
+    setweight(to_tsvector('saver_portuguese', notes) || to_tsvector('saver_english', notes), 'C') ||
+    setweight(to_tsvector('saver_portuguese', comments) || to_tsvector('saver_english', comments), 'D')
+  FROM transaction_search_document(p_transaction_id, p_description, p_payee_id)
+$$;
+
+CREATE TRIGGER transaction_comments_search_vector_refresh
+AFTER INSERT OR UPDATE OF body, is_deleted OR DELETE ON transaction_comments
+FOR EACH ROW EXECUTE FUNCTION transaction_details_search_vector_refresh();
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// activityRead tells whether the viewer, $2 of the query, has read the item
+const activityRead = `(a.actor_id = $2 OR EXISTS (
+		SELECT 1 FROM activity_reads r WHERE r.activity_id = a.id AND r.user_id = $2
+	))`
+
+const activityColumns = `a.id, a.wallet_id, a.actor_id, a.type, a.subject_id, a.changes, a.created_at, ` + activityRead
+
+// activityChange is how an entities.ActivityChange is stored in the changes
+// column.
+type activityChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type ActivityRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *ActivityRepository) CreateActivities(activities []*entities.Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, activity := range activities {
+		changes := make(map[string]activityChange, len(activity.Changes))
+		for field, change := range activity.Changes {
+			changes[field] = activityChange{Before: change.Before, After: change.After}
+		}
+
+		_, err := tx.Exec(
+			ctx,
+			`INSERT INTO activities (id, wallet_id, actor_id, type, subject_id, changes, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			activity.ID,
+			activity.WalletID,
+			activity.ActorID,
+			activity.Type,
+			nullableID(activity.SubjectID),
+			changes,
+			activity.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindActivitiesByWalletID reads one row more than the page to tell whether
+// there is a next page.
+func (r *ActivityRepository) FindActivitiesByWalletID(
+	walletID string,
+	userID string,
+	filter repositories.ActivityFilter,
+	page query.Page,
+) ([]*entities.Activity, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.ActivitySorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+activityColumns+` FROM activities a
+		WHERE a.wallet_id = $1
+		AND ($3::boolean IS NULL OR `+activityRead+` = $3::boolean)
+		AND `+sorts.After(page, 4)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $5`,
+		walletID,
+		userID,
+		filter.Read,
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	activities := make([]*entities.Activity, 0)
+	for rows.Next() {
+		activity, err := scanActivity(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		activities = append(activities, activity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(activities) > page.Limit {
+		activities = activities[:page.Limit]
+		last := activities[page.Limit-1]
+		next = page.Next(query.FormatTimestamp(last.CreatedAt), last.ID)
+	}
+
+	return activities, next, nil
+}
+
+func (r *ActivityRepository) CountUnreadActivities(walletID, userID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var count int
+	err := r.db.QueryRow(
+		ctx,
+		"SELECT count(*) FROM activities a WHERE a.wallet_id = $1 AND NOT "+activityRead,
+		walletID, userID,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *ActivityRepository) MarkActivitiesRead(walletID, userID string, ids []string, readAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO activity_reads (activity_id, user_id, read_at)
+		SELECT a.id, $2, $4 FROM activities a
+		WHERE a.wallet_id = $1 AND a.actor_id <> $2
+		AND (cardinality($3::uuid[]) = 0 OR a.id = ANY($3::uuid[]))
+		ON CONFLICT (activity_id, user_id) DO NOTHING`,
+		walletID, userID, idArray(ids), readAt,
+	)
+	return err
+}
+
+func scanActivity(row pgx.Row) (*entities.Activity, error) {
+	var (
+		activity  entities.Activity
+		subjectID *string
+		changes   map[string]activityChange
+	)
+
+	err := row.Scan(
+		&activity.ID,
+		&activity.WalletID,
+		&activity.ActorID,
+		&activity.Type,
+		&subjectID,
+		&changes,
+		&activity.CreatedAt,
+		&activity.Read,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if subjectID != nil {
+		activity.SubjectID = *subjectID
+	}
+	activity.Changes = make(map[string]entities.ActivityChange, len(changes))
+	for field, change := range changes {
+		activity.Changes[field] = entities.ActivityChange{Before: change.Before, After: change.After}
+	}
+
+	return &activity, nil
+}
+
+func NewActivityRepository(db *pgxpool.Pool) repositories.ActivityRepository {
+	return &ActivityRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,273 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const approvalColumns = `id, wallet_id, transaction_id, requested_by, reviewer_id, amount, currency, status, reviewed_by,
+	comment, requested_at, expires_at, reviewed_at`
+
+type ApprovalRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *ApprovalRepository) CreateHeldTransaction(
+	transaction *entities.Transaction,
+	approval *entities.Approval,
+) (*entities.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTransaction(ctx, tx, transaction); err != nil {
+		return nil, err
+	}
+
+	if err := insertApproval(ctx, tx, approval); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// HoldTransactions gets a longer timeout than single-row writes, as it can
+// save a whole bulk operation.
+func (r *ApprovalRepository) HoldTransactions(transactions []*entities.Transaction, approvals []*entities.Approval) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := saveTransactions(ctx, tx, transactions); err != nil {
+		return err
+	}
+
+	for _, approval := range approvals {
+		if err := insertApproval(ctx, tx, approval); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ApprovalRepository) FindApprovalByID(id string) (*entities.Approval, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	approval, err := scanApproval(r.db.QueryRow(ctx, "SELECT "+approvalColumns+" FROM approvals WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func (r *ApprovalRepository) FindPendingApprovalsByWalletID(walletID string) ([]*entities.Approval, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+approvalColumns+` FROM approvals
+		WHERE wallet_id = $1 AND status = 'PENDING'
+		AND EXISTS (SELECT 1 FROM transactions WHERE id = approvals.transaction_id AND is_deleted = false)
+		ORDER BY requested_at, id`,
+		walletID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectApprovals(rows)
+}
+
+func (r *ApprovalRepository) FindOverdueApprovals(now time.Time) ([]*entities.Approval, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+approvalColumns+" FROM approvals WHERE status = 'PENDING' AND expires_at <= $1 ORDER BY expires_at, id",
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectApprovals(rows)
+}
+
+func (r *ApprovalRepository) SaveReview(approval *entities.Approval, transaction *entities.Transaction) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	saved, err := saveReview(ctx, tx, approval, transaction)
+	if err != nil || !saved {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// saveReview closes the request within tx, unless it was closed already, and
+// then saves or deletes the expense it released, if any.
+func saveReview(ctx context.Context, tx pgx.Tx, approval *entities.Approval, transaction *entities.Transaction) (bool, error) {
+	tag, err := tx.Exec(
+		ctx,
+		`UPDATE approvals SET status = $2, reviewed_by = $3, comment = $4, reviewed_at = $5
+		WHERE id = $1 AND status = 'PENDING'`,
+		approval.ID,
+		approval.Status,
+		nullableID(approval.ReviewedBy),
+		nullableText(approval.Comment),
+		approval.ReviewedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	switch {
+	case transaction == nil:
+	case transaction.IsDeleted:
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE transactions SET is_deleted = true, deleted_at = $2, updated_at = $2, awaiting_approval = false WHERE id = $1",
+			transaction.ID, transaction.DeletedAt,
+		)
+	default:
+		err = updateTransaction(ctx, tx, transaction)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func insertApproval(ctx context.Context, tx pgx.Tx, approval *entities.Approval) error {
+	_, err := tx.Exec(
+		ctx,
+		`INSERT INTO approvals (id, wallet_id, transaction_id, requested_by, reviewer_id, amount, currency, status, requested_at,
+		expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		approval.ID,
+		approval.WalletID,
+		approval.TransactionID,
+		approval.RequestedBy,
+		nullableID(approval.ReviewerID),
+		approval.Amount,
+		approval.Amount.Currency(),
+		approval.Status,
+		approval.RequestedAt,
+		approval.ExpiresAt,
+	)
+	return err
+}
+
+func collectApprovals(rows pgx.Rows) ([]*entities.Approval, error) {
+	defer rows.Close()
+
+	approvals := make([]*entities.Approval, 0)
+	for rows.Next() {
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, approval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+func scanApproval(row pgx.Row) (*entities.Approval, error) {
+	var (
+		approval   entities.Approval
+		minorUnits int64
+		currency   string
+		reviewerID *string
+		reviewedBy *string
+		comment    *string
+		reviewedAt *time.Time
+	)
+
+	err := row.Scan(
+		&approval.ID,
+		&approval.WalletID,
+		&approval.TransactionID,
+		&approval.RequestedBy,
+		&reviewerID,
+		&minorUnits,
+		&currency,
+		&approval.Status,
+		&reviewedBy,
+		&comment,
+		&approval.RequestedAt,
+		&approval.ExpiresAt,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if approval.Amount, err = money.New(minorUnits, currency); err != nil {
+		return nil, err
+	}
+	if reviewerID != nil {
+		approval.ReviewerID = *reviewerID
+	}
+	if reviewedBy != nil {
+		approval.ReviewedBy = *reviewedBy
+	}
+	if comment != nil {
+		approval.Comment = *comment
+	}
+	if reviewedAt != nil {
+		approval.ReviewedAt = *reviewedAt
+	}
+
+	return &approval, nil
+}
+
+func NewApprovalRepository(db *pgxpool.Pool) repositories.ApprovalRepository {
+	return &ApprovalRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveReview(t *testing.T) {
+	const (
+		approvalID    = "123e4567-e89b-12d3-a456-426614174000"
+		transactionID = "223e4567-e89b-12d3-a456-426614174000"
+		reviewerID    = "323e4567-e89b-12d3-a456-426614174000"
+	)
+	reviewedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	rejected := func() (*entities.Approval, *entities.Transaction) {
+		approval := &entities.Approval{
+			ID:         approvalID,
+			Status:     entities.ApprovalStatusRejected,
+			ReviewedBy: reviewerID,
+			Comment:    "too expensive",
+			ReviewedAt: reviewedAt,
+		}
+		transaction := &entities.Transaction{ID: transactionID, IsDeleted: true, DeletedAt: reviewedAt}
+		return approval, transaction
+	}
+
+	tests := []struct {
+		name      string
+		mockDB    func(pgxmock.PgxPoolIface)
+		wantSaved bool
+	}{
+		{
+			name: "rejected expense is deleted with the review",
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("UPDATE approvals SET status").
+					WithArgs(approvalID, entities.ApprovalStatusRejected, pgxmock.AnyArg(), pgxmock.AnyArg(), reviewedAt).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mock.ExpectExec("UPDATE transactions SET is_deleted = true").
+					WithArgs(transactionID, reviewedAt).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantSaved: true,
+		},
+		{
+			name: "request closed by someone else leaves the expense alone",
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("UPDATE approvals SET status").
+					WithArgs(approvalID, entities.ApprovalStatusRejected, pgxmock.AnyArg(), pgxmock.AnyArg(), reviewedAt).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			mock.ExpectBegin()
+			tt.mockDB(mock)
+			mock.ExpectRollback()
+
+			tx, err := mock.Begin(context.Background())
+			require.NoError(t, err)
+
+			approval, transaction := rejected()
+			saved, err := saveReview(context.Background(), tx, approval, transaction)
+			require.NoError(t, tx.Rollback(context.Background()))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSaved, saved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+)
+
+const attachmentColumns = `a.id, a.transaction_id, a.wallet_id, a.file_name, a.content_type, a.size, a.checksum,
+	a.storage_key, a.thumbnail_key, a.uploaded_by, a.created_at`
+
+type AttachmentRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *AttachmentRepository) CreateAttachment(attachment *entities.Attachment) (*entities.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO attachments (id, transaction_id, wallet_id, file_name, content_type, size, checksum,
+			storage_key, thumbnail_key, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		attachment.ID,
+		attachment.TransactionID,
+		attachment.WalletID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.StorageKey,
+		nullableText(attachment.ThumbnailKey),
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) FindAttachmentByID(id string) (*entities.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(ctx, "SELECT "+attachmentColumns+" FROM attachments a WHERE a.id = $1", id)
+
+	attachment, err := scanAttachment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) FindAttachmentsByTransactionID(transactionID string) ([]*entities.Attachment, error) {
+	return r.findAttachments(
+		"SELECT "+attachmentColumns+" FROM attachments a WHERE a.transaction_id = $1 ORDER BY a.created_at, a.id",
+		transactionID,
+	)
+}
+
+func (r *AttachmentRepository) FindAttachmentsOfDeletedTransactions(deletedBefore time.Time) ([]*entities.Attachment, error) {
+	return r.findAttachments(
+		`SELECT `+attachmentColumns+` FROM attachments a
+		JOIN transactions t ON t.id = a.transaction_id
+		WHERE t.is_deleted = true AND t.deleted_at < $1
+		ORDER BY a.created_at, a.id`,
+		deletedBefore,
+	)
+}
+
+func (r *AttachmentRepository) DeleteAttachment(attachment *entities.Attachment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, "DELETE FROM attachments WHERE id = $1", attachment.ID)
+	return err
+}
+
+func (r *AttachmentRepository) findAttachments(query string, args ...interface{}) ([]*entities.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := make([]*entities.Attachment, 0)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func scanAttachment(row pgx.Row) (*entities.Attachment, error) {
+	var (
+		attachment   entities.Attachment
+		thumbnailKey *string
+	)
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.TransactionID,
+		&attachment.WalletID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.StorageKey,
+		&thumbnailKey,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if thumbnailKey != nil {
+		attachment.ThumbnailKey = *thumbnailKey
+	}
+	return &attachment, nil
+}
+
+func NewAttachmentRepository(db *pgxpool.Pool) repositories.AttachmentRepository {
+	return &AttachmentRepository{
+		db: db,
+	}
+}
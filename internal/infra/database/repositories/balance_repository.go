@@ -0,0 +1,373 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type BalanceRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *BalanceRepository) FindDebts(walletID string) ([]*entities.Debt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A settlement paid from one member to another counts as the receiver
+	// owing it back, which cancels out the debt it pays. Expenses held for
+	// approval owe nothing until they are approved
+	rows, err := r.db.Query(
+		ctx,
+		`WITH debts AS (
+			SELECT s.user_id AS debtor_id, sh.paid_by AS creditor_id, s.currency, s.amount
+			FROM transaction_shares s
+			JOIN transaction_sharing sh ON sh.transaction_id = s.transaction_id
+			JOIN transactions t ON t.id = s.transaction_id
+			WHERE t.wallet_id = $1 AND t.is_deleted = false AND t.awaiting_approval = false AND s.user_id <> sh.paid_by
+			UNION ALL
+			SELECT to_user_id, from_user_id, currency, amount
+			FROM settlements
+			WHERE wallet_id = $1 AND voided_at IS NULL
+		)
+		SELECT debtor_id, creditor_id, currency, sum(amount)::bigint
+		FROM debts
+		GROUP BY debtor_id, creditor_id, currency
+		HAVING sum(amount) > 0`,
+		walletID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	debts := make([]*entities.Debt, 0)
+	for rows.Next() {
+		var (
+			debt       entities.Debt
+			currency   string
+			minorUnits int64
+		)
+		if err := rows.Scan(&debt.DebtorID, &debt.CreditorID, &currency, &minorUnits); err != nil {
+			return nil, err
+		}
+
+		if debt.Amount, err = money.New(minorUnits, currency); err != nil {
+			return nil, err
+		}
+		debts = append(debts, &debt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return debts, nil
+}
+
+func (r *BalanceRepository) FindStatusTotals(walletID string) ([]*entities.StatusTotal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The running totals count held expenses too, so they are taken out
+	// and returned apart
+	rows, err := r.db.Query(
+		ctx,
+		`WITH held AS (
+			SELECT status, currency, sum(amount)::bigint AS outflow
+			FROM transactions
+			WHERE wallet_id = $1 AND awaiting_approval = true AND is_deleted = false
+			GROUP BY status, currency
+		), totals AS (
+			SELECT s.status, s.currency, false AS awaiting_approval, s.inflow, s.outflow - coalesce(h.outflow, 0) AS outflow
+			FROM wallet_status_totals s
+			LEFT JOIN held h ON h.status = s.status AND h.currency = s.currency
+			WHERE s.wallet_id = $1
+			UNION ALL
+			SELECT status, currency, true, 0, outflow
+			FROM held
+		)
+		SELECT status, currency, awaiting_approval, inflow, outflow
+		FROM totals
+		WHERE inflow <> 0 OR outflow <> 0
+		ORDER BY currency, status, awaiting_approval`,
+		walletID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make([]*entities.StatusTotal, 0)
+	for rows.Next() {
+		var (
+			total    entities.StatusTotal
+			currency string
+			inflow   int64
+			outflow  int64
+		)
+		if err := rows.Scan(&total.Status, &currency, &total.AwaitingApproval, &inflow, &outflow); err != nil {
+			return nil, err
+		}
+
+		if total.Inflow, err = money.New(inflow, currency); err != nil {
+			return nil, err
+		}
+		if total.Outflow, err = money.New(outflow, currency); err != nil {
+			return nil, err
+		}
+		totals = append(totals, &total)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// postedAmount is what a transaction t adds to the current balance of its
+// wallet when it is cleared or reconciled and not deleted, and zero otherwise
+const postedAmount = `CASE
+		WHEN t.is_deleted OR t.status NOT IN ('CLEARED', 'RECONCILED') THEN 0
+		WHEN t.type IN ('INCOME', 'TRANSFER_IN') THEN t.amount
+		ELSE -t.amount
+	END`
+
+func (r *BalanceRepository) FindBalancesAsOf(walletID string, date time.Time) ([]*entities.BalanceSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		`WITH snapshot AS (
+			SELECT max(as_of) AS as_of
+			FROM wallet_balance_snapshots
+			WHERE wallet_id = $1 AND as_of <= $2
+		), amounts AS (
+			SELECT s.currency, s.balance AS amount
+			FROM wallet_balance_snapshots s, snapshot
+			WHERE s.wallet_id = $1 AND s.as_of = snapshot.as_of
+			UNION ALL
+			SELECT t.currency, `+postedAmount+`
+			FROM transactions t, snapshot
+			WHERE t.wallet_id = $1 AND t.date <= $2
+				AND (snapshot.as_of IS NULL OR t.date > snapshot.as_of)
+		)
+		SELECT currency, sum(amount)::bigint
+		FROM amounts
+		GROUP BY currency
+		ORDER BY currency`,
+		walletID, date,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make([]*entities.BalanceSnapshot, 0)
+	for rows.Next() {
+		var (
+			currency   string
+			minorUnits int64
+		)
+		if err := rows.Scan(&currency, &minorUnits); err != nil {
+			return nil, err
+		}
+
+		balance, err := money.New(minorUnits, currency)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, &entities.BalanceSnapshot{WalletID: walletID, AsOf: date, Balance: balance})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+func (r *BalanceRepository) CreateSnapshots(asOf time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Each wallet carries on from its previous snapshot, so only the
+	// transactions since then are read
+	tag, err := r.db.Exec(
+		ctx,
+		`WITH previous AS (
+			SELECT wallet_id, max(as_of) AS as_of
+			FROM wallet_balance_snapshots
+			WHERE as_of < $1
+			GROUP BY wallet_id
+		), amounts AS (
+			SELECT s.wallet_id, s.currency, s.balance AS amount
+			FROM wallet_balance_snapshots s
+			JOIN previous p ON p.wallet_id = s.wallet_id AND p.as_of = s.as_of
+			UNION ALL
+			SELECT t.wallet_id, t.currency, `+postedAmount+`
+			FROM transactions t
+			LEFT JOIN previous p ON p.wallet_id = t.wallet_id
+			WHERE t.date <= $1 AND (p.as_of IS NULL OR t.date > p.as_of)
+		)
+		INSERT INTO wallet_balance_snapshots (wallet_id, as_of, currency, balance)
+		SELECT wallet_id, $1, currency, sum(amount)::bigint
+		FROM amounts
+		GROUP BY wallet_id, currency
+		ON CONFLICT (wallet_id, as_of, currency) DO UPDATE
+		SET balance = EXCLUDED.balance, created_at = now()`,
+		asOf,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *BalanceRepository) FindDrifts() (*entities.BalanceDrifts, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	totals, err := r.findTotalDrifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := r.findSnapshotDrifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.BalanceDrifts{Totals: totals, Snapshots: snapshots}, nil
+}
+
+func (r *BalanceRepository) findTotalDrifts(ctx context.Context) ([]*entities.StatusTotalDrift, error) {
+	rows, err := r.db.Query(
+		ctx,
+		`WITH recomputed AS (
+			SELECT wallet_id, status, currency,
+				coalesce(sum(amount) FILTER (WHERE type IN ('INCOME', 'TRANSFER_IN')), 0)::bigint AS inflow,
+				coalesce(sum(amount) FILTER (WHERE type IN ('EXPENSE', 'TRANSFER_OUT')), 0)::bigint AS outflow
+			FROM transactions
+			WHERE is_deleted = false
+			GROUP BY wallet_id, status, currency
+		)
+		SELECT coalesce(s.wallet_id, r.wallet_id), coalesce(s.status, r.status), coalesce(s.currency, r.currency),
+			coalesce(s.inflow, 0), coalesce(s.outflow, 0), coalesce(r.inflow, 0), coalesce(r.outflow, 0)
+		FROM wallet_status_totals s
+		FULL JOIN recomputed r
+			ON r.wallet_id = s.wallet_id AND r.status = s.status AND r.currency = s.currency
+		WHERE coalesce(s.inflow, 0) <> coalesce(r.inflow, 0)
+			OR coalesce(s.outflow, 0) <> coalesce(r.outflow, 0)
+		ORDER BY 1, 3, 2`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drifts := make([]*entities.StatusTotalDrift, 0)
+	for rows.Next() {
+		var (
+			drift                                            entities.StatusTotalDrift
+			status                                           entities.TransactionStatus
+			currency                                         string
+			storedIn, storedOut, recomputedIn, recomputedOut int64
+		)
+		if err := rows.Scan(&drift.WalletID, &status, &currency, &storedIn, &storedOut, &recomputedIn, &recomputedOut); err != nil {
+			return nil, err
+		}
+
+		if drift.Stored, err = statusTotal(status, currency, storedIn, storedOut); err != nil {
+			return nil, err
+		}
+		if drift.Recomputed, err = statusTotal(status, currency, recomputedIn, recomputedOut); err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, &drift)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+func (r *BalanceRepository) findSnapshotDrifts(ctx context.Context) ([]*entities.SnapshotDrift, error) {
+	rows, err := r.db.Query(
+		ctx,
+		`WITH snapshots AS (
+			SELECT DISTINCT wallet_id, as_of FROM wallet_balance_snapshots
+		), recomputed AS (
+			SELECT s.wallet_id, s.as_of, t.currency, sum(`+postedAmount+`)::bigint AS balance
+			FROM snapshots s
+			JOIN transactions t ON t.wallet_id = s.wallet_id AND t.date <= s.as_of
+			GROUP BY s.wallet_id, s.as_of, t.currency
+		)
+		SELECT coalesce(b.wallet_id, r.wallet_id), coalesce(b.as_of, r.as_of), coalesce(b.currency, r.currency),
+			coalesce(b.balance, 0), coalesce(r.balance, 0)
+		FROM wallet_balance_snapshots b
+		FULL JOIN recomputed r
+			ON r.wallet_id = b.wallet_id AND r.as_of = b.as_of AND r.currency = b.currency
+		WHERE coalesce(b.balance, 0) <> coalesce(r.balance, 0)
+		ORDER BY 1, 2, 3`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drifts := make([]*entities.SnapshotDrift, 0)
+	for rows.Next() {
+		var (
+			walletID           string
+			asOf               time.Time
+			currency           string
+			stored, recomputed int64
+		)
+		if err := rows.Scan(&walletID, &asOf, &currency, &stored, &recomputed); err != nil {
+			return nil, err
+		}
+
+		storedBalance, err := money.New(stored, currency)
+		if err != nil {
+			return nil, err
+		}
+		recomputedBalance, err := money.New(recomputed, currency)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, &entities.SnapshotDrift{
+			Stored:     &entities.BalanceSnapshot{WalletID: walletID, AsOf: asOf, Balance: storedBalance},
+			Recomputed: &entities.BalanceSnapshot{WalletID: walletID, AsOf: asOf, Balance: recomputedBalance},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+func statusTotal(status entities.TransactionStatus, currency string, inflow, outflow int64) (*entities.StatusTotal, error) {
+	total := entities.StatusTotal{Status: status}
+
+	var err error
+	if total.Inflow, err = money.New(inflow, currency); err != nil {
+		return nil, err
+	}
+	if total.Outflow, err = money.New(outflow, currency); err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
+
+func NewBalanceRepository(db *pgxpool.Pool) repositories.BalanceRepository {
+	return &BalanceRepository{
+		db: db,
+	}
+}
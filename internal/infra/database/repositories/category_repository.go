@@ -0,0 +1,321 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const categoryColumns = "id, user_id, parent_id, name, type, icon, color, created_at, updated_at"
+
+const insertCategoryQuery = `INSERT INTO categories (id, user_id, parent_id, name, type, icon, color, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+type CategoryRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *CategoryRepository) CreateCategory(category *entities.Category) (*entities.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, insertCategoryQuery, categoryArgs(category)...)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (r *CategoryRepository) FindCategoryByID(id string) (*entities.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(ctx, "SELECT "+categoryColumns+" FROM categories WHERE id = $1", id)
+
+	category, err := scanCategory(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepository) FindCategoriesByUserID(userID string) ([]*entities.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+categoryColumns+" FROM categories WHERE user_id = $1 ORDER BY type, name",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]*entities.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// FindCategoryPageByUserID lists a page of the user categories. One row
+// more than the page is read to tell whether there is a next page.
+func (r *CategoryRepository) FindCategoryPageByUserID(
+	userID string,
+	filter repositories.CategoryFilter,
+	page query.Page,
+) ([]*entities.Category, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.CategorySorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+categoryColumns+` FROM categories
+		WHERE user_id = $1
+		AND ($2 = '' OR strpos(lower(name), lower($2)) > 0)
+		AND (cardinality($3::text[]) = 0 OR type::text = ANY($3::text[]))
+		AND `+sorts.After(page, 4)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $5`,
+		userID,
+		filter.Query,
+		transactionTypes(filter.Types),
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]*entities.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(categories) > page.Limit {
+		categories = categories[:page.Limit]
+		next = page.Next(categorySortValues(page.Sort.Field, categories[page.Limit-1])...)
+	}
+
+	return categories, next, nil
+}
+
+// categorySortValues are the values of the columns of
+// repositories.CategorySorts[field] for category.
+func categorySortValues(field string, category *entities.Category) []string {
+	switch field {
+	case "name":
+		return []string{category.Name, category.ID}
+	case "created_at":
+		return []string{query.FormatTimestamp(category.CreatedAt), category.ID}
+	default:
+		return []string{string(category.Type), category.Name, category.ID}
+	}
+}
+
+func (r *CategoryRepository) UpdateCategory(category *entities.Category) (*entities.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE categories SET parent_id = $2, name = $3, icon = $4, color = $5, updated_at = $6 WHERE id = $1",
+		category.ID,
+		nullableID(category.ParentID),
+		category.Name,
+		category.Icon,
+		category.Color,
+		category.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (r *CategoryRepository) CountTransactionsByCategoryID(categoryID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var count int
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT count(*) FROM transactions
+		WHERE is_deleted = false AND (category_id = $1 OR EXISTS (
+			SELECT 1 FROM transaction_splits WHERE transaction_id = transactions.id AND category_id = $1
+		))`,
+		categoryID,
+	).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *CategoryRepository) MergeCategories(sourceID, targetID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := mergeCategories(ctx, tx, sourceID, targetID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// mergeCategories points every reference to source at target and deletes
+// source. Anything left pointing at source would be cleared by its ON DELETE
+// SET NULL, so every referencing column must be listed here.
+func mergeCategories(ctx context.Context, tx pgx.Tx, sourceID, targetID string) error {
+	// Deleted transactions are moved too so the history keeps its category
+	_, err := tx.Exec(
+		ctx,
+		"UPDATE transactions SET category_id = $2, updated_at = now() WHERE category_id = $1",
+		sourceID, targetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE transaction_splits SET category_id = $2 WHERE category_id = $1", sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE rules SET category_id = $2, updated_at = now() WHERE category_id = $1", sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE payees SET default_category_id = $2, updated_at = now() WHERE default_category_id = $1",
+		sourceID, targetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE recurring_transactions SET category_id = $2, updated_at = now() WHERE category_id = $1",
+		sourceID, targetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE recurring_occurrence_overrides SET category_id = $2, updated_at = now() WHERE category_id = $1",
+		sourceID, targetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE categories SET parent_id = $2, updated_at = now() WHERE parent_id = $1",
+		sourceID, targetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM categories WHERE id = $1", sourceID)
+	return err
+}
+
+func (r *CategoryRepository) DeleteCategory(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, "DELETE FROM categories WHERE id = $1", id)
+	return err
+}
+
+func categoryArgs(category *entities.Category) []interface{} {
+	return []interface{}{
+		category.ID,
+		category.UserID,
+		nullableID(category.ParentID),
+		category.Name,
+		category.Type,
+		category.Icon,
+		category.Color,
+		category.CreatedAt,
+		category.UpdatedAt,
+	}
+}
+
+func scanCategory(row pgx.Row) (*entities.Category, error) {
+	var (
+		category entities.Category
+		parentID *string
+	)
+
+	err := row.Scan(
+		&category.ID,
+		&category.UserID,
+		&parentID,
+		&category.Name,
+		&category.Type,
+		&category.Icon,
+		&category.Color,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		category.ParentID = *parentID
+	}
+
+	return &category, nil
+}
+
+func NewCategoryRepository(db *pgxpool.Pool) repositories.CategoryRepository {
+	return &CategoryRepository{
+		db: db,
+	}
+}
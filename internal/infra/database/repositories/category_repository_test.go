@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeCategories(t *testing.T) {
+	const (
+		sourceID = "123e4567-e89b-12d3-a456-426614174000"
+		targetID = "223e4567-e89b-12d3-a456-426614174000"
+	)
+
+	expectReassign := func(mock pgxmock.PgxPoolIface) {
+		mock.ExpectExec("UPDATE transactions SET category_id").
+			WithArgs(sourceID, targetID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+		mock.ExpectExec("UPDATE transaction_splits SET category_id").
+			WithArgs(sourceID, targetID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectExec("UPDATE rules SET category_id").
+			WithArgs(sourceID, targetID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	}
+
+	tests := []struct {
+		name    string
+		mockDB  func(pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "payee defaults and recurring templates follow the merged category",
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				expectReassign(mock)
+				mock.ExpectExec("UPDATE payees SET default_category_id = \\$2").
+					WithArgs(sourceID, targetID).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+				mock.ExpectExec("UPDATE recurring_transactions SET category_id").
+					WithArgs(sourceID, targetID).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mock.ExpectExec("UPDATE recurring_occurrence_overrides SET category_id").
+					WithArgs(sourceID, targetID).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mock.ExpectExec("UPDATE categories SET parent_id").
+					WithArgs(sourceID, targetID).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+				mock.ExpectExec("DELETE FROM categories").
+					WithArgs(sourceID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			},
+		},
+		{
+			name: "source is kept when payees cannot be moved",
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				expectReassign(mock)
+				mock.ExpectExec("UPDATE payees SET default_category_id = \\$2").
+					WithArgs(sourceID, targetID).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			mock.ExpectBegin()
+			tt.mockDB(mock)
+			mock.ExpectRollback()
+
+			tx, err := mock.Begin(context.Background())
+			require.NoError(t, err)
+
+			err = mergeCategories(context.Background(), tx, sourceID, targetID)
+			require.NoError(t, tx.Rollback(context.Background()))
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+)
+
+const commentColumns = `id, transaction_id, wallet_id, parent_id, author_id, body, edited_at, is_deleted, deleted_at,
+	created_at, updated_at`
+
+type CommentRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *CommentRepository) CreateComment(comment *entities.Comment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO transaction_comments (id, transaction_id, wallet_id, parent_id, author_id, body, is_deleted,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, false, $7, $8)`,
+		comment.ID,
+		comment.TransactionID,
+		comment.WalletID,
+		nullableID(comment.ParentID),
+		comment.AuthorID,
+		comment.Body,
+		comment.CreatedAt,
+		comment.UpdatedAt,
+	)
+	return err
+}
+
+func (r *CommentRepository) FindCommentByID(id string) (*entities.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(
+		ctx,
+		"SELECT "+commentColumns+" FROM transaction_comments WHERE id = $1 AND is_deleted = false",
+		id,
+	)
+
+	comment, err := scanComment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (r *CommentRepository) FindCommentsByTransactionID(transactionID string) ([]*entities.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+commentColumns+" FROM transaction_comments WHERE transaction_id = $1 ORDER BY created_at, id",
+		transactionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]*entities.Comment, 0)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *CommentRepository) UpdateComment(comment *entities.Comment, edit *entities.CommentEdit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(
+		ctx,
+		"INSERT INTO transaction_comment_edits (id, comment_id, body, edited_at) VALUES ($1, $2, $3, $4)",
+		edit.ID,
+		edit.CommentID,
+		edit.Body,
+		edit.EditedAt,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(
+		ctx,
+		"UPDATE transaction_comments SET body = $2, edited_at = $3, updated_at = $4 WHERE id = $1 AND is_deleted = false",
+		comment.ID,
+		comment.Body,
+		nullableDate(comment.EditedAt),
+		comment.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *CommentRepository) DeleteComment(comment *entities.Comment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE transaction_comments SET is_deleted = true, deleted_at = $2, updated_at = $2 WHERE id = $1",
+		comment.ID,
+		comment.DeletedAt,
+	)
+	return err
+}
+
+func (r *CommentRepository) FindCommentEdits(commentID string) ([]*entities.CommentEdit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT id, comment_id, body, edited_at FROM transaction_comment_edits
+		WHERE comment_id = $1
+		ORDER BY edited_at, id`,
+		commentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	edits := make([]*entities.CommentEdit, 0)
+	for rows.Next() {
+		var edit entities.CommentEdit
+		if err := rows.Scan(&edit.ID, &edit.CommentID, &edit.Body, &edit.EditedAt); err != nil {
+			return nil, err
+		}
+		edits = append(edits, &edit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return edits, nil
+}
+
+func scanComment(row pgx.Row) (*entities.Comment, error) {
+	var (
+		comment   entities.Comment
+		parentID  *string
+		editedAt  *time.Time
+		isDeleted *bool
+		deletedAt *time.Time
+	)
+
+	err := row.Scan(
+		&comment.ID,
+		&comment.TransactionID,
+		&comment.WalletID,
+		&parentID,
+		&comment.AuthorID,
+		&comment.Body,
+		&editedAt,
+		&isDeleted,
+		&deletedAt,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		comment.ParentID = *parentID
+	}
+	if editedAt != nil {
+		comment.EditedAt = *editedAt
+	}
+	if isDeleted != nil {
+		comment.IsDeleted = *isDeleted
+	}
+	if deletedAt != nil {
+		comment.DeletedAt = *deletedAt
+	}
+	return &comment, nil
+}
+
+func NewCommentRepository(db *pgxpool.Pool) repositories.CommentRepository {
+	return &CommentRepository{
+		db: db,
+	}
+}
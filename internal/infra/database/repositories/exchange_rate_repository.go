@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+)
+
+// rateScale matches the scale of the exchange_rates.rate numeric column.
+const rateScale = 10
+
+type ExchangeRateRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *ExchangeRateRepository) SaveRates(rates []*entities.ExchangeRate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO exchange_rates (id, base_currency, quote_currency, rate, rate_date, source)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (base_currency, quote_currency, rate_date)
+		DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source, updated_at = now()`
+
+	for _, rate := range rates {
+		_, err := tx.Exec(
+			ctx,
+			query,
+			rate.ID, rate.BaseCurrency, rate.QuoteCurrency, rate.Rate.FloatString(rateScale), rate.Date, rate.Source,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ExchangeRateRepository) FindRate(baseCurrency, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error) {
+	var (
+		rate     entities.ExchangeRate
+		rateText string
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := `SELECT id, base_currency, quote_currency, rate::text, rate_date, source, created_at, updated_at
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND rate_date <= $3
+		ORDER BY rate_date DESC
+		LIMIT 1`
+
+	err := r.db.QueryRow(ctx, query, baseCurrency, quoteCurrency, date).Scan(
+		&rate.ID,
+		&rate.BaseCurrency,
+		&rate.QuoteCurrency,
+		&rateText,
+		&rate.Date,
+		&rate.Source,
+		&rate.CreatedAt,
+		&rate.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	parsed, ok := new(big.Rat).SetString(rateText)
+	if !ok {
+		return nil, fmt.Errorf("invalid stored exchange rate %q", rateText)
+	}
+	rate.Rate = parsed
+
+	return &rate, nil
+}
+
+func NewExchangeRateRepository(db *pgxpool.Pool) repositories.ExchangeRateRepository {
+	return &ExchangeRateRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+)
+
+type HouseholdRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *HouseholdRepository) CreateHousehold(household *entities.Household) (*entities.Household, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO households (id, name, created_at, updated_at) VALUES ($1, $2, $3, $4)",
+		household.ID, household.Name, household.CreatedAt, household.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range household.Members {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO household_members (household_id, user_id, role, joined_at) VALUES ($1, $2, $3, $4)",
+			member.HouseholdID, member.UserID, member.Role, member.JoinedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+func (r *HouseholdRepository) FindHouseholdByID(id string) (*entities.Household, error) {
+	var household entities.Household
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRow(
+		ctx,
+		"SELECT id, name, created_at, updated_at FROM households WHERE id = $1",
+		id,
+	).Scan(
+		&household.ID,
+		&household.Name,
+		&household.CreatedAt,
+		&household.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT household_id, user_id, role, joined_at FROM household_members WHERE household_id = $1 ORDER BY joined_at",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member entities.HouseholdMember
+		if err := rows.Scan(&member.HouseholdID, &member.UserID, &member.Role, &member.JoinedAt); err != nil {
+			return nil, err
+		}
+		household.Members = append(household.Members, &member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &household, nil
+}
+
+func (r *HouseholdRepository) AddMember(member *entities.HouseholdMember) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"INSERT INTO household_members (household_id, user_id, role, joined_at) VALUES ($1, $2, $3, $4)",
+		member.HouseholdID, member.UserID, member.Role, member.JoinedAt,
+	)
+	return err
+}
+
+func (r *HouseholdRepository) UpdateMemberRole(householdID, userID string, role entities.HouseholdRole) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE household_members SET role = $3 WHERE household_id = $1 AND user_id = $2",
+		householdID, userID, role,
+	)
+	return err
+}
+
+func (r *HouseholdRepository) RemoveMember(householdID, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"DELETE FROM household_members WHERE household_id = $1 AND user_id = $2",
+		householdID, userID,
+	)
+	return err
+}
+
+func NewHouseholdRepository(db *pgxpool.Pool) repositories.HouseholdRepository {
+	return &HouseholdRepository{
+		db: db,
+	}
+}
@@ -10,4 +10,84 @@ var Module = fx.Provide(
 		NewUserRepository,
 		fx.As(new(repositories.UserRepository)),
 	),
+	fx.Annotate(
+		NewExchangeRateRepository,
+		fx.As(new(repositories.ExchangeRateRepository)),
+	),
+	fx.Annotate(
+		NewHouseholdRepository,
+		fx.As(new(repositories.HouseholdRepository)),
+	),
+	fx.Annotate(
+		NewTransactionRepository,
+		fx.As(new(repositories.TransactionRepository)),
+	),
+	fx.Annotate(
+		NewCategoryRepository,
+		fx.As(new(repositories.CategoryRepository)),
+	),
+	fx.Annotate(
+		NewTagRepository,
+		fx.As(new(repositories.TagRepository)),
+	),
+	fx.Annotate(
+		NewTransferRepository,
+		fx.As(new(repositories.TransferRepository)),
+	),
+	fx.Annotate(
+		NewRecurringTransactionRepository,
+		fx.As(new(repositories.RecurringTransactionRepository)),
+	),
+	fx.Annotate(
+		NewReportRepository,
+		fx.As(new(repositories.ReportRepository)),
+	),
+	fx.Annotate(
+		NewBalanceRepository,
+		fx.As(new(repositories.BalanceRepository)),
+	),
+	fx.Annotate(
+		NewSettlementRepository,
+		fx.As(new(repositories.SettlementRepository)),
+	),
+	fx.Annotate(
+		NewAttachmentRepository,
+		fx.As(new(repositories.AttachmentRepository)),
+	),
+	fx.Annotate(
+		NewPayeeRepository,
+		fx.As(new(repositories.PayeeRepository)),
+	),
+	fx.Annotate(
+		NewRuleRepository,
+		fx.As(new(repositories.RuleRepository)),
+	),
+	fx.Annotate(
+		NewWalletRepository,
+		fx.As(new(repositories.WalletRepository)),
+	),
+	fx.Annotate(
+		NewApprovalRepository,
+		fx.As(new(repositories.ApprovalRepository)),
+	),
+	fx.Annotate(
+		NewReconciliationRepository,
+		fx.As(new(repositories.ReconciliationRepository)),
+	),
+	fx.Annotate(
+		NewSpendingLimitRepository,
+		fx.As(new(repositories.SpendingLimitRepository)),
+	),
+	fx.Annotate(
+		NewNotificationRepository,
+		fx.As(new(repositories.NotificationRepository)),
+	),
+	fx.Annotate(
+		NewActivityRepository,
+		fx.As(new(repositories.ActivityRepository)),
+	),
+	fx.Annotate(
+		NewCommentRepository,
+		fx.As(new(repositories.CommentRepository)),
+	),
 )
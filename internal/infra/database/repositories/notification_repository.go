@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const notificationColumns = `id, user_id, type, wallet_id, message, data, read_at, created_at`
+
+type NotificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *NotificationRepository) CreateNotifications(notifications []*entities.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, notification := range notifications {
+		_, err := tx.Exec(
+			ctx,
+			`INSERT INTO notifications (id, user_id, type, wallet_id, message, data, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			notification.ID,
+			notification.UserID,
+			notification.Type,
+			nullableID(notification.WalletID),
+			notification.Message,
+			notification.Data,
+			notification.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindNotificationsByUserID reads one row more than the page to tell
+// whether there is a next page.
+func (r *NotificationRepository) FindNotificationsByUserID(
+	userID string,
+	filter repositories.NotificationFilter,
+	page query.Page,
+) ([]*entities.Notification, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.NotificationSorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+notificationColumns+` FROM notifications
+		WHERE user_id = $1
+		AND ($2::boolean IS NULL OR (read_at IS NOT NULL) = $2::boolean)
+		AND `+sorts.After(page, 3)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $4`,
+		userID,
+		filter.Read,
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]*entities.Notification, 0)
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(notifications) > page.Limit {
+		notifications = notifications[:page.Limit]
+		last := notifications[page.Limit-1]
+		next = page.Next(query.FormatTimestamp(last.CreatedAt), last.ID)
+	}
+
+	return notifications, next, nil
+}
+
+func (r *NotificationRepository) CountUnreadNotifications(userID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var count int
+	err := r.db.QueryRow(
+		ctx,
+		"SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL",
+		userID,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *NotificationRepository) MarkNotificationsRead(userID string, ids []string, readAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`UPDATE notifications SET read_at = $3
+		WHERE user_id = $1 AND read_at IS NULL
+		AND (cardinality($2::uuid[]) = 0 OR id = ANY($2::uuid[]))`,
+		userID, idArray(ids), readAt,
+	)
+	return err
+}
+
+func scanNotification(row pgx.Row) (*entities.Notification, error) {
+	var (
+		notification entities.Notification
+		walletID     *string
+		readAt       *time.Time
+	)
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Type,
+		&walletID,
+		&notification.Message,
+		&notification.Data,
+		&readAt,
+		&notification.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if walletID != nil {
+		notification.WalletID = *walletID
+	}
+	if readAt != nil {
+		notification.ReadAt = *readAt
+	}
+
+	return &notification, nil
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) repositories.NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
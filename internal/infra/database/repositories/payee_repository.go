@@ -0,0 +1,303 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const payeeColumns = `id, user_id, name, default_category_id, created_at, updated_at,
+	ARRAY(SELECT alias FROM payee_aliases WHERE payee_id = payees.id ORDER BY alias)`
+
+type PayeeRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *PayeeRepository) CreatePayee(payee *entities.Payee) (*entities.Payee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO payees (id, user_id, name, default_category_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		payee.ID, payee.UserID, payee.Name, nullableID(payee.DefaultCategoryID), payee.CreatedAt, payee.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertPayeeAliases(ctx, tx, payee); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return payee, nil
+}
+
+func (r *PayeeRepository) FindPayeeByID(id string) (*entities.Payee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(ctx, "SELECT "+payeeColumns+" FROM payees WHERE id = $1", id)
+	return findPayee(row)
+}
+
+func (r *PayeeRepository) FindPayeesByUserID(userID string) ([]*entities.Payee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, "SELECT "+payeeColumns+" FROM payees WHERE user_id = $1 ORDER BY name, id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payees := make([]*entities.Payee, 0)
+	for rows.Next() {
+		payee, err := scanPayee(rows)
+		if err != nil {
+			return nil, err
+		}
+		payees = append(payees, payee)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payees, nil
+}
+
+// FindPayeePageByUserID lists a page of the user payees. One row more than
+// the page is read to tell whether there is a next page.
+func (r *PayeeRepository) FindPayeePageByUserID(
+	userID string,
+	filter repositories.PayeeFilter,
+	page query.Page,
+) ([]*entities.Payee, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.PayeeSorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+payeeColumns+` FROM payees
+		WHERE user_id = $1
+		AND ($2 = '' OR strpos(lower(name), lower($2)) > 0 OR EXISTS (
+			SELECT 1 FROM payee_aliases
+			WHERE payee_id = payees.id AND strpos(lower(alias), lower($2)) > 0
+		))
+		AND (cardinality($3::uuid[]) = 0 OR default_category_id = ANY($3::uuid[]))
+		AND `+sorts.After(page, 4)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $5`,
+		userID,
+		filter.Query,
+		idArray(filter.CategoryIDs),
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	payees := make([]*entities.Payee, 0)
+	for rows.Next() {
+		payee, err := scanPayee(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		payees = append(payees, payee)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(payees) > page.Limit {
+		payees = payees[:page.Limit]
+		next = page.Next(payeeSortValues(page.Sort.Field, payees[page.Limit-1])...)
+	}
+
+	return payees, next, nil
+}
+
+// payeeSortValues are the values of the columns of
+// repositories.PayeeSorts[field] for payee.
+func payeeSortValues(field string, payee *entities.Payee) []string {
+	if field == "created_at" {
+		return []string{query.FormatTimestamp(payee.CreatedAt), payee.ID}
+	}
+	return []string{payee.Name, payee.ID}
+}
+
+func (r *PayeeRepository) FindPayeeByAlias(userID, alias string) (*entities.Payee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(
+		ctx,
+		"SELECT "+payeeColumns+` FROM payees
+		WHERE id = (SELECT payee_id FROM payee_aliases WHERE user_id = $1 AND alias = $2)`,
+		userID, alias,
+	)
+	return findPayee(row)
+}
+
+func (r *PayeeRepository) UpdatePayee(payee *entities.Payee) (*entities.Payee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := updatePayee(ctx, tx, payee); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return payee, nil
+}
+
+func (r *PayeeRepository) MergePayees(source, target *entities.Payee) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Deleted transactions are moved too so the history keeps its payee
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE transactions SET payee_id = $2, updated_at = now() WHERE payee_id = $1",
+		source.ID, target.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE rules SET payee_id = $2, updated_at = now() WHERE payee_id = $1",
+		source.ID, target.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The aliases of source go with it, then come back on target
+	if _, err := tx.Exec(ctx, "DELETE FROM payees WHERE id = $1", source.ID); err != nil {
+		return err
+	}
+
+	if err := updatePayee(ctx, tx, target); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PayeeRepository) DeletePayee(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, "DELETE FROM payees WHERE id = $1", id)
+	return err
+}
+
+// updatePayee saves the editable fields and replaces the aliases within tx.
+func updatePayee(ctx context.Context, tx pgx.Tx, payee *entities.Payee) error {
+	_, err := tx.Exec(
+		ctx,
+		"UPDATE payees SET name = $2, default_category_id = $3, updated_at = $4 WHERE id = $1",
+		payee.ID, payee.Name, nullableID(payee.DefaultCategoryID), payee.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM payee_aliases WHERE payee_id = $1", payee.ID); err != nil {
+		return err
+	}
+
+	return insertPayeeAliases(ctx, tx, payee)
+}
+
+func insertPayeeAliases(ctx context.Context, tx pgx.Tx, payee *entities.Payee) error {
+	if len(payee.Aliases) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		`INSERT INTO payee_aliases (user_id, alias, payee_id)
+		SELECT $1, alias, $3 FROM unnest($2::text[]) AS alias`,
+		payee.UserID, payee.Aliases, payee.ID,
+	)
+	return err
+}
+
+func findPayee(row pgx.Row) (*entities.Payee, error) {
+	payee, err := scanPayee(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return payee, nil
+}
+
+func scanPayee(row pgx.Row) (*entities.Payee, error) {
+	var (
+		payee             entities.Payee
+		defaultCategoryID *string
+	)
+
+	err := row.Scan(
+		&payee.ID,
+		&payee.UserID,
+		&payee.Name,
+		&defaultCategoryID,
+		&payee.CreatedAt,
+		&payee.UpdatedAt,
+		&payee.Aliases,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaultCategoryID != nil {
+		payee.DefaultCategoryID = *defaultCategoryID
+	}
+	return &payee, nil
+}
+
+func NewPayeeRepository(db *pgxpool.Pool) repositories.PayeeRepository {
+	return &PayeeRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,249 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// signedAmount is what a transaction t adds to the balance of its wallet
+const signedAmount = `CASE WHEN t.type IN ('INCOME', 'TRANSFER_IN') THEN t.amount ELSE -t.amount END`
+
+// reconciliationColumns select a session r with its opening balance and the
+// net of the transactions ticked off in it
+const reconciliationColumns = `r.id, r.wallet_id, r.user_id, r.statement_date, r.statement_balance, r.currency, r.status,
+	r.created_at, r.completed_at,
+	(SELECT coalesce(sum(` + signedAmount + `), 0)::bigint
+		FROM transactions t
+		WHERE t.wallet_id = r.wallet_id AND t.currency = r.currency AND t.status = 'RECONCILED'
+			AND t.is_deleted = false AND t.date <= r.statement_date
+			AND NOT EXISTS (
+				SELECT 1 FROM reconciliation_transactions rt
+				WHERE rt.reconciliation_id = r.id AND rt.transaction_id = t.id
+			)),
+	(SELECT coalesce(sum(` + signedAmount + `), 0)::bigint
+		FROM reconciliation_transactions rt
+		JOIN transactions t ON t.id = rt.transaction_id
+		WHERE rt.reconciliation_id = r.id AND t.is_deleted = false),
+	ARRAY(SELECT rt.transaction_id::text
+		FROM reconciliation_transactions rt
+		JOIN transactions t ON t.id = rt.transaction_id
+		WHERE rt.reconciliation_id = r.id AND t.is_deleted = false
+		ORDER BY t.date, t.id)`
+
+type ReconciliationRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *ReconciliationRepository) CreateReconciliation(reconciliation *entities.Reconciliation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO reconciliations (id, wallet_id, user_id, statement_date, statement_balance, currency, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		reconciliation.ID,
+		reconciliation.WalletID,
+		reconciliation.UserID,
+		reconciliation.StatementDate,
+		reconciliation.StatementBalance,
+		reconciliation.StatementBalance.Currency(),
+		reconciliation.Status,
+		reconciliation.CreatedAt,
+	)
+	return err
+}
+
+func (r *ReconciliationRepository) FindReconciliationByID(id string) (*entities.Reconciliation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return findReconciliation(r.db.QueryRow(
+		ctx,
+		"SELECT "+reconciliationColumns+" FROM reconciliations r WHERE r.id = $1",
+		id,
+	))
+}
+
+func (r *ReconciliationRepository) FindOpenReconciliation(walletID, currency string) (*entities.Reconciliation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return findReconciliation(r.db.QueryRow(
+		ctx,
+		"SELECT "+reconciliationColumns+" FROM reconciliations r WHERE r.wallet_id = $1 AND r.currency = $2 AND r.status = 'OPEN'",
+		walletID, currency,
+	))
+}
+
+func (r *ReconciliationRepository) FindReconciledBalance(walletID, currency string, date time.Time) (money.Money, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var minorUnits int64
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT coalesce(sum(`+signedAmount+`), 0)::bigint
+		FROM transactions t
+		WHERE t.wallet_id = $1 AND t.currency = $2 AND t.status = 'RECONCILED'
+			AND t.is_deleted = false AND t.date <= $3`,
+		walletID, currency, date,
+	).Scan(&minorUnits)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return money.New(minorUnits, currency)
+}
+
+func (r *ReconciliationRepository) SaveClearedTransactions(reconciliation *entities.Reconciliation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceReconciliationTransactions(ctx, tx, reconciliation); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ReconciliationRepository) CompleteReconciliation(
+	reconciliation *entities.Reconciliation,
+	transactions []*entities.Transaction,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
+		ctx,
+		"UPDATE reconciliations SET status = $2, completed_at = $3 WHERE id = $1 AND status = 'OPEN'",
+		reconciliation.ID, reconciliation.Status, reconciliation.CompletedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("reconciliation %s is no longer open", reconciliation.ID)
+	}
+
+	if err := replaceReconciliationTransactions(ctx, tx, reconciliation); err != nil {
+		return err
+	}
+
+	// A transaction changed since it was read fails the whole session
+	for _, transaction := range transactions {
+		tag, err := tx.Exec(
+			ctx,
+			`UPDATE transactions SET status = $2, updated_at = $3
+			WHERE id = $1 AND is_deleted = false AND status IN ('PENDING', 'CLEARED')`,
+			transaction.ID, transaction.Status, transaction.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("transaction %s changed during reconciliation", transaction.ID)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ReconciliationRepository) DeleteReconciliation(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, "DELETE FROM reconciliations WHERE id = $1 AND status = 'OPEN'", id)
+	return err
+}
+
+// replaceReconciliationTransactions makes the stored ticks of the session
+// match reconciliation.ClearedIDs.
+func replaceReconciliationTransactions(ctx context.Context, tx pgx.Tx, reconciliation *entities.Reconciliation) error {
+	_, err := tx.Exec(ctx, "DELETE FROM reconciliation_transactions WHERE reconciliation_id = $1", reconciliation.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO reconciliation_transactions (reconciliation_id, transaction_id) SELECT $1, unnest($2::uuid[])",
+		reconciliation.ID, idArray(reconciliation.ClearedIDs),
+	)
+	return err
+}
+
+// findReconciliation reads a row selected with reconciliationColumns, or nil
+// when there is none.
+func findReconciliation(row pgx.Row) (*entities.Reconciliation, error) {
+	var (
+		reconciliation entities.Reconciliation
+		balance        int64
+		currency       string
+		completedAt    *time.Time
+		opening        int64
+		cleared        int64
+	)
+
+	err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.WalletID,
+		&reconciliation.UserID,
+		&reconciliation.StatementDate,
+		&balance,
+		&currency,
+		&reconciliation.Status,
+		&reconciliation.CreatedAt,
+		&completedAt,
+		&opening,
+		&cleared,
+		&reconciliation.ClearedIDs,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if reconciliation.StatementBalance, err = money.New(balance, currency); err != nil {
+		return nil, err
+	}
+	if reconciliation.OpeningBalance, err = money.New(opening, currency); err != nil {
+		return nil, err
+	}
+	if reconciliation.Cleared, err = money.New(cleared, currency); err != nil {
+		return nil, err
+	}
+	if completedAt != nil {
+		reconciliation.CompletedAt = *completedAt
+	}
+
+	return &reconciliation, nil
+}
+
+func NewReconciliationRepository(db *pgxpool.Pool) repositories.ReconciliationRepository {
+	return &ReconciliationRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,473 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const recurringTransactionColumns = `id, wallet_id, type, amount, currency, description, category_id, rule, start_date,
+	materialized_through, next_occurrence, created_by, created_at, updated_at`
+
+const occurrenceOverrideColumns = "recurring_id, occurrence_date, skip, amount, currency, description, category_id, created_at, updated_at"
+
+type RecurringTransactionRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *RecurringTransactionRepository) CreateRecurringTransaction(recurring *entities.RecurringTransaction) (*entities.RecurringTransaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO recurring_transactions (id, wallet_id, type, amount, currency, description, category_id, rule, start_date,
+			materialized_through, next_occurrence, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		recurring.ID,
+		recurring.WalletID,
+		recurring.Type,
+		recurring.Amount,
+		recurring.Amount.Currency(),
+		recurring.Description,
+		nullableID(recurring.CategoryID),
+		recurring.Rule.String(),
+		recurring.StartDate,
+		nullableDate(recurring.MaterializedThrough),
+		nullableDate(recurring.NextOccurrence),
+		recurring.CreatedBy,
+		recurring.CreatedAt,
+		recurring.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+func (r *RecurringTransactionRepository) FindRecurringTransactionByID(id string) (*entities.RecurringTransaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(
+		ctx,
+		"SELECT "+recurringTransactionColumns+" FROM recurring_transactions WHERE id = $1 AND is_deleted = false",
+		id,
+	)
+
+	recurring, err := scanRecurringTransaction(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+// FindRecurringTransactionsByWalletID lists a page of the wallet schedules.
+// One row more than the page is read to tell whether there is a next page.
+func (r *RecurringTransactionRepository) FindRecurringTransactionsByWalletID(
+	walletID string,
+	filter repositories.RecurringTransactionFilter,
+	page query.Page,
+) ([]*entities.RecurringTransaction, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.RecurringTransactionSorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+recurringTransactionColumns+` FROM recurring_transactions
+		WHERE wallet_id = $1 AND is_deleted = false
+		AND (cardinality($2::text[]) = 0 OR type::text = ANY($2::text[]))
+		AND (cardinality($3::uuid[]) = 0 OR category_id IN (
+			SELECT id FROM categories WHERE id = ANY($3::uuid[]) OR parent_id = ANY($3::uuid[])
+		))
+		AND ($4::text IS NULL OR currency = $4::text)
+		AND `+sorts.After(page, 5)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $6`,
+		walletID,
+		transactionTypes(filter.Types),
+		idArray(filter.CategoryIDs),
+		nullableText(filter.Currency),
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recurring, err := collectRecurringTransactions(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(recurring) > page.Limit {
+		recurring = recurring[:page.Limit]
+		next = page.Next(recurringSortValues(page.Sort.Field, recurring[page.Limit-1])...)
+	}
+
+	return recurring, next, nil
+}
+
+// noNextOccurrence is the date finished schedules sort on, as written in
+// repositories.RecurringTransactionSorts.
+const noNextOccurrence = "9999-12-31"
+
+// recurringSortValues are the values of the columns of
+// repositories.RecurringTransactionSorts[field] for recurring.
+func recurringSortValues(field string, recurring *entities.RecurringTransaction) []string {
+	switch field {
+	case "amount":
+		return []string{
+			recurring.Amount.Currency().Code,
+			query.FormatInteger(recurring.Amount.MinorUnits()),
+			recurring.ID,
+		}
+	case "created_at":
+		return []string{query.FormatTimestamp(recurring.CreatedAt), recurring.ID}
+	default:
+		nextOccurrence := noNextOccurrence
+		if !recurring.NextOccurrence.IsZero() {
+			nextOccurrence = query.FormatDate(recurring.NextOccurrence)
+		}
+		return []string{nextOccurrence, query.FormatTimestamp(recurring.CreatedAt), recurring.ID}
+	}
+}
+
+func (r *RecurringTransactionRepository) FindDueRecurringTransactions(today time.Time) ([]*entities.RecurringTransaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+recurringTransactionColumns+` FROM recurring_transactions
+		WHERE is_deleted = false AND next_occurrence <= $1
+		ORDER BY next_occurrence`,
+		today,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectRecurringTransactions(rows)
+}
+
+func (r *RecurringTransactionRepository) UpdateRecurringTransaction(recurring *entities.RecurringTransaction) (*entities.RecurringTransaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`UPDATE recurring_transactions
+		SET type = $2, amount = $3, currency = $4, description = $5, category_id = $6, rule = $7, start_date = $8,
+			next_occurrence = $9, updated_at = $10
+		WHERE id = $1 AND is_deleted = false`,
+		recurring.ID,
+		recurring.Type,
+		recurring.Amount,
+		recurring.Amount.Currency(),
+		recurring.Description,
+		nullableID(recurring.CategoryID),
+		recurring.Rule.String(),
+		recurring.StartDate,
+		nullableDate(recurring.NextOccurrence),
+		recurring.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+func (r *RecurringTransactionRepository) DeleteRecurringTransaction(recurring *entities.RecurringTransaction) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE recurring_transactions SET is_deleted = true, deleted_at = $2, updated_at = $2 WHERE id = $1",
+		recurring.ID, recurring.DeletedAt,
+	)
+	return err
+}
+
+func (r *RecurringTransactionRepository) FindOccurrenceOverrides(recurringID string) ([]*entities.OccurrenceOverride, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+occurrenceOverrideColumns+" FROM recurring_occurrence_overrides WHERE recurring_id = $1 ORDER BY occurrence_date",
+		recurringID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := make([]*entities.OccurrenceOverride, 0)
+	for rows.Next() {
+		override, err := scanOccurrenceOverride(rows)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+func (r *RecurringTransactionRepository) SaveOccurrenceOverride(override *entities.OccurrenceOverride) (*entities.OccurrenceOverride, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var (
+		amount   *int64
+		currency *string
+	)
+	if override.Amount.Currency().Code != "" {
+		minorUnits := override.Amount.MinorUnits()
+		code := override.Amount.Currency().Code
+		amount, currency = &minorUnits, &code
+	}
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO recurring_occurrence_overrides (`+occurrenceOverrideColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (recurring_id, occurrence_date) DO UPDATE
+		SET skip = EXCLUDED.skip, amount = EXCLUDED.amount, currency = EXCLUDED.currency,
+			description = EXCLUDED.description, category_id = EXCLUDED.category_id, updated_at = EXCLUDED.updated_at`,
+		override.RecurringID,
+		override.Date,
+		override.Skip,
+		amount,
+		currency,
+		nullableText(override.Description),
+		nullableID(override.CategoryID),
+		override.CreatedAt,
+		override.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return override, nil
+}
+
+func (r *RecurringTransactionRepository) DeleteOccurrenceOverride(recurringID string, date time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"DELETE FROM recurring_occurrence_overrides WHERE recurring_id = $1 AND occurrence_date = $2",
+		recurringID, date,
+	)
+	return err
+}
+
+func (r *RecurringTransactionRepository) MaterializeOccurrences(
+	recurring *entities.RecurringTransaction,
+	transactions []*entities.Transaction,
+	approvals []*entities.Approval,
+) ([]*entities.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	held := make(map[string]*entities.Approval, len(approvals))
+	for _, approval := range approvals {
+		held[approval.TransactionID] = approval
+	}
+
+	created := make([]*entities.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		tag, err := tx.Exec(
+			ctx,
+			insertTransactionQuery+` ON CONFLICT (recurring_id, occurrence_date) WHERE recurring_id IS NOT NULL DO NOTHING`,
+			transactionArgs(transaction)...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if tag.RowsAffected() == 0 {
+			continue
+		}
+
+		if err := replaceTransactionDetails(ctx, tx, transaction); err != nil {
+			return nil, err
+		}
+		if approval := held[transaction.ID]; approval != nil {
+			if err := insertApproval(ctx, tx, approval); err != nil {
+				return nil, err
+			}
+		}
+		created = append(created, transaction)
+	}
+
+	// Another run may have gone further already
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE recurring_transactions
+		SET materialized_through = $2, next_occurrence = $3, updated_at = $4
+		WHERE id = $1 AND (materialized_through IS NULL OR materialized_through < $2)`,
+		recurring.ID,
+		nullableDate(recurring.MaterializedThrough),
+		nullableDate(recurring.NextOccurrence),
+		recurring.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func collectRecurringTransactions(rows pgx.Rows) ([]*entities.RecurringTransaction, error) {
+	defer rows.Close()
+
+	recurring := make([]*entities.RecurringTransaction, 0)
+	for rows.Next() {
+		item, err := scanRecurringTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		recurring = append(recurring, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+// scanRecurringTransaction reads a row selected with
+// recurringTransactionColumns.
+func scanRecurringTransaction(row pgx.Row) (*entities.RecurringTransaction, error) {
+	var (
+		recurring           entities.RecurringTransaction
+		minorUnits          int64
+		currency            string
+		categoryID          *string
+		rule                string
+		materializedThrough *time.Time
+		nextOccurrence      *time.Time
+	)
+
+	err := row.Scan(
+		&recurring.ID,
+		&recurring.WalletID,
+		&recurring.Type,
+		&minorUnits,
+		&currency,
+		&recurring.Description,
+		&categoryID,
+		&rule,
+		&recurring.StartDate,
+		&materializedThrough,
+		&nextOccurrence,
+		&recurring.CreatedBy,
+		&recurring.CreatedAt,
+		&recurring.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := money.New(minorUnits, currency)
+	if err != nil {
+		return nil, err
+	}
+	recurring.Amount = amount
+
+	if recurring.Rule, err = entities.ParseRecurrenceRule(rule); err != nil {
+		return nil, err
+	}
+	if categoryID != nil {
+		recurring.CategoryID = *categoryID
+	}
+	if materializedThrough != nil {
+		recurring.MaterializedThrough = *materializedThrough
+	}
+	if nextOccurrence != nil {
+		recurring.NextOccurrence = *nextOccurrence
+	}
+
+	return &recurring, nil
+}
+
+func scanOccurrenceOverride(row pgx.Row) (*entities.OccurrenceOverride, error) {
+	var (
+		override    entities.OccurrenceOverride
+		minorUnits  *int64
+		currency    *string
+		description *string
+		categoryID  *string
+	)
+
+	err := row.Scan(
+		&override.RecurringID,
+		&override.Date,
+		&override.Skip,
+		&minorUnits,
+		&currency,
+		&description,
+		&categoryID,
+		&override.CreatedAt,
+		&override.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if minorUnits != nil && currency != nil {
+		if override.Amount, err = money.New(*minorUnits, *currency); err != nil {
+			return nil, err
+		}
+	}
+	if description != nil {
+		override.Description = *description
+	}
+	if categoryID != nil {
+		override.CategoryID = *categoryID
+	}
+
+	return &override, nil
+}
+
+// nullableText maps an empty optional text to NULL.
+func nullableText(text string) *string {
+	if text == "" {
+		return nil
+	}
+	return &text
+}
+
+func NewRecurringTransactionRepository(db *pgxpool.Pool) repositories.RecurringTransactionRepository {
+	return &RecurringTransactionRepository{
+		db: db,
+	}
+}
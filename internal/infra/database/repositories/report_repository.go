@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ReportRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *ReportRepository) FindCategoryTotals(walletID string, from, to time.Time) ([]*entities.CategoryTotal, error) {
+	return r.findCategoryTotals("t.wallet_id = $1", walletID, from, to)
+}
+
+func (r *ReportRepository) FindHouseholdCategoryTotals(householdID string, from, to time.Time) ([]*entities.CategoryTotal, error) {
+	return r.findCategoryTotals("t.wallet_id IN (SELECT id FROM wallets WHERE household_id = $1)", householdID, from, to)
+}
+
+// findCategoryTotals adds up the lines of the transactions matching
+// walletFilter, which takes the scope ID as $1.
+func (r *ReportRepository) findCategoryTotals(walletFilter, scopeID string, from, to time.Time) ([]*entities.CategoryTotal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A split transaction is replaced by its lines; any other transaction is
+	// a single line of its own. Held and scheduled transactions have not
+	// happened yet, so they are left out
+	rows, err := r.db.Query(
+		ctx,
+		`WITH lines AS (
+			SELECT t.type, t.currency,
+				CASE WHEN s.id IS NULL THEN t.category_id ELSE s.category_id END AS category_id,
+				CASE WHEN s.id IS NULL THEN t.amount ELSE s.amount END AS amount
+			FROM transactions t
+			LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+			WHERE `+walletFilter+` AND t.is_deleted = false
+			AND t.type IN ('INCOME', 'EXPENSE')
+			AND t.awaiting_approval = false AND t.status <> 'SCHEDULED'
+			AND t.date BETWEEN $2 AND $3
+		)
+		SELECT category_id, type, currency, sum(amount)::bigint AS total, count(*)
+		FROM lines
+		GROUP BY category_id, type, currency
+		ORDER BY type, currency, total DESC`,
+		scopeID, from, to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make([]*entities.CategoryTotal, 0)
+	for rows.Next() {
+		var (
+			total      entities.CategoryTotal
+			categoryID *string
+			minorUnits int64
+			currency   string
+		)
+		if err := rows.Scan(&categoryID, &total.Type, &currency, &minorUnits, &total.LineCount); err != nil {
+			return nil, err
+		}
+
+		if total.Total, err = money.New(minorUnits, currency); err != nil {
+			return nil, err
+		}
+		if categoryID != nil {
+			total.CategoryID = *categoryID
+		}
+		totals = append(totals, &total)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func NewReportRepository(db *pgxpool.Pool) repositories.ReportRepository {
+	return &ReportRepository{
+		db: db,
+	}
+}
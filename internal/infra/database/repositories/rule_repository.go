@@ -0,0 +1,324 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const ruleColumns = `id, user_id, name, priority, description_pattern, min_amount, max_amount, currency,
+	payee_id, wallet_id, category_id, (SELECT type FROM categories WHERE id = rules.category_id),
+	set_description, created_at, updated_at,
+	ARRAY(SELECT tag_id::text FROM rule_tags WHERE rule_id = rules.id ORDER BY tag_id)`
+
+type RuleRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *RuleRepository) CreateRule(rule *entities.Rule) (*entities.Rule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	minAmount, maxAmount, currency := ruleAmounts(rule.Conditions)
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO rules (id, user_id, name, priority, description_pattern, min_amount, max_amount, currency,
+			payee_id, wallet_id, category_id, set_description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		rule.ID,
+		rule.UserID,
+		rule.Name,
+		rule.Priority,
+		rule.Conditions.DescriptionPattern,
+		minAmount,
+		maxAmount,
+		currency,
+		nullableID(rule.Conditions.PayeeID),
+		nullableID(rule.Conditions.WalletID),
+		nullableID(rule.Actions.CategoryID),
+		rule.Actions.Description,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertRuleTags(ctx, tx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *RuleRepository) FindRulesByUserID(userID string) ([]*entities.Rule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+ruleColumns+" FROM rules WHERE user_id = $1 ORDER BY priority, created_at, id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]*entities.Rule, 0)
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// FindRulePageByUserID lists a page of the user rules. One row more than
+// the page is read to tell whether there is a next page.
+func (r *RuleRepository) FindRulePageByUserID(
+	userID string,
+	filter repositories.RuleFilter,
+	page query.Page,
+) ([]*entities.Rule, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.RuleSorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+ruleColumns+` FROM rules
+		WHERE user_id = $1
+		AND ($2 = '' OR strpos(lower(name), lower($2)) > 0)
+		AND (cardinality($3::uuid[]) = 0 OR category_id = ANY($3::uuid[]))
+		AND (cardinality($4::uuid[]) = 0 OR payee_id = ANY($4::uuid[]))
+		AND `+sorts.After(page, 5)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $6`,
+		userID,
+		filter.Query,
+		idArray(filter.CategoryIDs),
+		idArray(filter.PayeeIDs),
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]*entities.Rule, 0)
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(rules) > page.Limit {
+		rules = rules[:page.Limit]
+		next = page.Next(ruleSortValues(page.Sort.Field, rules[page.Limit-1])...)
+	}
+
+	return rules, next, nil
+}
+
+// ruleSortValues are the values of the columns of
+// repositories.RuleSorts[field] for rule.
+func ruleSortValues(field string, rule *entities.Rule) []string {
+	switch field {
+	case "name":
+		return []string{rule.Name, rule.ID}
+	case "created_at":
+		return []string{query.FormatTimestamp(rule.CreatedAt), rule.ID}
+	default:
+		return []string{query.FormatInteger(int64(rule.Priority)), query.FormatTimestamp(rule.CreatedAt), rule.ID}
+	}
+}
+
+func (r *RuleRepository) UpdateRule(rule *entities.Rule) (*entities.Rule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	minAmount, maxAmount, currency := ruleAmounts(rule.Conditions)
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE rules SET name = $2, priority = $3, description_pattern = $4, min_amount = $5, max_amount = $6,
+			currency = $7, payee_id = $8, wallet_id = $9, category_id = $10, set_description = $11, updated_at = $12
+		WHERE id = $1`,
+		rule.ID,
+		rule.Name,
+		rule.Priority,
+		rule.Conditions.DescriptionPattern,
+		minAmount,
+		maxAmount,
+		currency,
+		nullableID(rule.Conditions.PayeeID),
+		nullableID(rule.Conditions.WalletID),
+		nullableID(rule.Actions.CategoryID),
+		rule.Actions.Description,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM rule_tags WHERE rule_id = $1", rule.ID); err != nil {
+		return nil, err
+	}
+
+	if err := insertRuleTags(ctx, tx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *RuleRepository) DeleteRule(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, "DELETE FROM rules WHERE id = $1", id)
+	return err
+}
+
+func insertRuleTags(ctx context.Context, tx pgx.Tx, rule *entities.Rule) error {
+	if len(rule.Actions.TagIDs) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		`INSERT INTO rule_tags (rule_id, tag_id)
+		SELECT $1, tag_id FROM unnest($2::uuid[]) AS tag_id`,
+		rule.ID, rule.Actions.TagIDs,
+	)
+	return err
+}
+
+// ruleAmounts maps the amount bounds to their columns; both bounds share the
+// currency column.
+func ruleAmounts(conditions entities.RuleConditions) (minAmount, maxAmount *int64, currency *string) {
+	for _, bound := range []struct {
+		amount *money.Money
+		column **int64
+	}{
+		{conditions.MinAmount, &minAmount},
+		{conditions.MaxAmount, &maxAmount},
+	} {
+		if bound.amount == nil {
+			continue
+		}
+		minorUnits := bound.amount.MinorUnits()
+		code := bound.amount.Currency().Code
+		*bound.column, currency = &minorUnits, &code
+	}
+	return minAmount, maxAmount, currency
+}
+
+func scanRule(row pgx.Row) (*entities.Rule, error) {
+	var (
+		rule         entities.Rule
+		minAmount    *int64
+		maxAmount    *int64
+		currency     *string
+		payeeID      *string
+		walletID     *string
+		categoryID   *string
+		categoryType *string
+	)
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Priority,
+		&rule.Conditions.DescriptionPattern,
+		&minAmount,
+		&maxAmount,
+		&currency,
+		&payeeID,
+		&walletID,
+		&categoryID,
+		&categoryType,
+		&rule.Actions.Description,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+		&rule.Actions.TagIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency != nil {
+		for _, bound := range []struct {
+			minorUnits *int64
+			amount     **money.Money
+		}{
+			{minAmount, &rule.Conditions.MinAmount},
+			{maxAmount, &rule.Conditions.MaxAmount},
+		} {
+			if bound.minorUnits == nil {
+				continue
+			}
+			amount, err := money.New(*bound.minorUnits, *currency)
+			if err != nil {
+				return nil, err
+			}
+			*bound.amount = &amount
+		}
+	}
+	if payeeID != nil {
+		rule.Conditions.PayeeID = *payeeID
+	}
+	if walletID != nil {
+		rule.Conditions.WalletID = *walletID
+	}
+	if categoryID != nil && categoryType != nil {
+		rule.Actions.CategoryID = *categoryID
+		rule.Actions.CategoryType = entities.TransactionType(*categoryType)
+	}
+
+	return &rule, nil
+}
+
+func NewRuleRepository(db *pgxpool.Pool) repositories.RuleRepository {
+	return &RuleRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const settlementColumns = `id, wallet_id, from_user_id, to_user_id, amount, currency, date, note, created_by,
+	voided_by, voided_at, created_at`
+
+type SettlementRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *SettlementRepository) CreateSettlement(settlement *entities.Settlement) (*entities.Settlement, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO settlements (id, wallet_id, from_user_id, to_user_id, amount, currency, date, note, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		settlement.ID,
+		settlement.WalletID,
+		settlement.FromUserID,
+		settlement.ToUserID,
+		settlement.Amount,
+		settlement.Amount.Currency(),
+		settlement.Date,
+		settlement.Note,
+		settlement.CreatedBy,
+		settlement.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+func (r *SettlementRepository) FindSettlementByID(id string) (*entities.Settlement, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(ctx, "SELECT "+settlementColumns+" FROM settlements WHERE id = $1", id)
+
+	settlement, err := scanSettlement(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return settlement, nil
+}
+
+// FindSettlementsByWalletID lists a page of the wallet settlements. One row
+// more than the page is read to tell whether there is a next page.
+func (r *SettlementRepository) FindSettlementsByWalletID(
+	walletID string,
+	filter repositories.SettlementFilter,
+	page query.Page,
+) ([]*entities.Settlement, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.SettlementSorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+settlementColumns+` FROM settlements
+		WHERE wallet_id = $1
+		AND ($2::date IS NULL OR date >= $2::date)
+		AND ($3::date IS NULL OR date <= $3::date)
+		AND (cardinality($4::uuid[]) = 0 OR from_user_id = ANY($4::uuid[]) OR to_user_id = ANY($4::uuid[]))
+		AND ($5::boolean IS NULL OR (voided_at IS NOT NULL) = $5::boolean)
+		AND ($6::text IS NULL OR currency = $6::text)
+		AND `+sorts.After(page, 7)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $8`,
+		walletID,
+		nullableDate(filter.From),
+		nullableDate(filter.To),
+		idArray(filter.MemberIDs),
+		filter.Voided,
+		nullableText(filter.Currency),
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	settlements := make([]*entities.Settlement, 0)
+	for rows.Next() {
+		settlement, err := scanSettlement(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		settlements = append(settlements, settlement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(settlements) > page.Limit {
+		settlements = settlements[:page.Limit]
+		next = page.Next(settlementSortValues(page.Sort.Field, settlements[page.Limit-1])...)
+	}
+
+	return settlements, next, nil
+}
+
+// settlementSortValues are the values of the columns of
+// repositories.SettlementSorts[field] for settlement.
+func settlementSortValues(field string, settlement *entities.Settlement) []string {
+	switch field {
+	case "amount":
+		return []string{
+			settlement.Amount.Currency().Code,
+			query.FormatInteger(settlement.Amount.MinorUnits()),
+			settlement.ID,
+		}
+	case "created_at":
+		return []string{query.FormatTimestamp(settlement.CreatedAt), settlement.ID}
+	default:
+		return []string{query.FormatDate(settlement.Date), query.FormatTimestamp(settlement.CreatedAt), settlement.ID}
+	}
+}
+
+// VoidSettlement only voids a settlement that was not voided yet, so the
+// first void is the one kept in the history.
+func (r *SettlementRepository) VoidSettlement(settlement *entities.Settlement) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE settlements SET voided_by = $2, voided_at = $3 WHERE id = $1 AND voided_at IS NULL",
+		settlement.ID, settlement.VoidedBy, settlement.VoidedAt,
+	)
+	return err
+}
+
+func scanSettlement(row pgx.Row) (*entities.Settlement, error) {
+	var (
+		settlement entities.Settlement
+		minorUnits int64
+		currency   string
+		voidedBy   *string
+		voidedAt   *time.Time
+	)
+
+	err := row.Scan(
+		&settlement.ID,
+		&settlement.WalletID,
+		&settlement.FromUserID,
+		&settlement.ToUserID,
+		&minorUnits,
+		&currency,
+		&settlement.Date,
+		&settlement.Note,
+		&settlement.CreatedBy,
+		&voidedBy,
+		&voidedAt,
+		&settlement.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if settlement.Amount, err = money.New(minorUnits, currency); err != nil {
+		return nil, err
+	}
+	if voidedBy != nil {
+		settlement.VoidedBy = *voidedBy
+	}
+	if voidedAt != nil {
+		settlement.VoidedAt = *voidedAt
+	}
+
+	return &settlement, nil
+}
+
+func NewSettlementRepository(db *pgxpool.Pool) repositories.SettlementRepository {
+	return &SettlementRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const spendingLimitColumns = `id, wallet_id, user_id, category_id, period, amount, currency, created_by, created_at, updated_at`
+
+type SpendingLimitRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *SpendingLimitRepository) CreateSpendingLimit(limit *entities.SpendingLimit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO spending_limits (id, wallet_id, user_id, category_id, period, amount, currency, created_by, created_at,
+		updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		limit.ID,
+		limit.WalletID,
+		limit.UserID,
+		nullableID(limit.CategoryID),
+		limit.Period,
+		limit.Amount,
+		limit.Amount.Currency(),
+		limit.CreatedBy,
+		limit.CreatedAt,
+		limit.UpdatedAt,
+	)
+	return err
+}
+
+func (r *SpendingLimitRepository) FindSpendingLimitByID(id string) (*entities.SpendingLimit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	limit, err := scanSpendingLimit(r.db.QueryRow(ctx, "SELECT "+spendingLimitColumns+" FROM spending_limits WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+func (r *SpendingLimitRepository) FindSpendingLimitsByWalletID(walletID string) ([]*entities.SpendingLimit, error) {
+	return r.findSpendingLimits(
+		"SELECT "+spendingLimitColumns+" FROM spending_limits WHERE wallet_id = $1 ORDER BY user_id, created_at, id",
+		walletID,
+	)
+}
+
+func (r *SpendingLimitRepository) FindMemberSpendingLimits(walletID, userID string) ([]*entities.SpendingLimit, error) {
+	return r.findSpendingLimits(
+		"SELECT "+spendingLimitColumns+" FROM spending_limits WHERE wallet_id = $1 AND user_id = $2 ORDER BY created_at, id",
+		walletID, userID,
+	)
+}
+
+func (r *SpendingLimitRepository) UpdateSpendingLimit(limit *entities.SpendingLimit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE spending_limits SET amount = $2, currency = $3, updated_at = $4 WHERE id = $1",
+		limit.ID, limit.Amount, limit.Amount.Currency(), limit.UpdatedAt,
+	)
+	return err
+}
+
+func (r *SpendingLimitRepository) DeleteSpendingLimit(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, "DELETE FROM spending_limits WHERE id = $1", id)
+	return err
+}
+
+func (r *SpendingLimitRepository) FindSpentAmounts(filter repositories.SpentFilter) ([]money.Money, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// As in reports, a split transaction counts by its lines
+	rows, err := r.db.Query(
+		ctx,
+		`WITH lines AS (
+			SELECT t.currency,
+				CASE WHEN s.id IS NULL THEN t.category_id ELSE s.category_id END AS category_id,
+				CASE WHEN s.id IS NULL THEN t.amount ELSE s.amount END AS amount
+			FROM transactions t
+			LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+			WHERE t.wallet_id = $1 AND t.created_by = $2 AND t.is_deleted = false
+			AND t.type = 'EXPENSE'
+			AND t.date BETWEEN $3 AND $4
+			AND ($5::uuid IS NULL OR t.id <> $5::uuid)
+		)
+		SELECT currency, sum(amount)::bigint
+		FROM lines
+		WHERE $6::uuid IS NULL OR category_id = $6::uuid
+		GROUP BY currency
+		ORDER BY currency`,
+		filter.WalletID,
+		filter.UserID,
+		filter.From,
+		filter.To,
+		nullableID(filter.ExcludeTransactionID),
+		nullableID(filter.CategoryID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amounts := make([]money.Money, 0)
+	for rows.Next() {
+		var (
+			currency   string
+			minorUnits int64
+		)
+		if err := rows.Scan(&currency, &minorUnits); err != nil {
+			return nil, err
+		}
+
+		amount, err := money.New(minorUnits, currency)
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, amount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return amounts, nil
+}
+
+func (r *SpendingLimitRepository) findSpendingLimits(sql string, args ...interface{}) ([]*entities.SpendingLimit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	limits := make([]*entities.SpendingLimit, 0)
+	for rows.Next() {
+		limit, err := scanSpendingLimit(rows)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, limit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+func scanSpendingLimit(row pgx.Row) (*entities.SpendingLimit, error) {
+	var (
+		limit      entities.SpendingLimit
+		categoryID *string
+		minorUnits int64
+		currency   string
+	)
+
+	err := row.Scan(
+		&limit.ID,
+		&limit.WalletID,
+		&limit.UserID,
+		&categoryID,
+		&limit.Period,
+		&minorUnits,
+		&currency,
+		&limit.CreatedBy,
+		&limit.CreatedAt,
+		&limit.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit.Amount, err = money.New(minorUnits, currency); err != nil {
+		return nil, err
+	}
+	if categoryID != nil {
+		limit.CategoryID = *categoryID
+	}
+
+	return &limit, nil
+}
+
+func NewSpendingLimitRepository(db *pgxpool.Pool) repositories.SpendingLimitRepository {
+	return &SpendingLimitRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const tagColumns = "id, scope, owner_id, name, created_at, updated_at"
+
+type TagRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *TagRepository) CreateTag(tag *entities.Tag) (*entities.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"INSERT INTO tags (id, scope, owner_id, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		tag.ID, tag.Scope, tag.OwnerID, tag.Name, tag.CreatedAt, tag.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (r *TagRepository) FindTagByID(id string) (*entities.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(ctx, "SELECT "+tagColumns+" FROM tags WHERE id = $1", id)
+	return findTag(row)
+}
+
+func (r *TagRepository) FindTagsByIDs(ids []string) ([]*entities.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, "SELECT "+tagColumns+" FROM tags WHERE id = ANY($1::uuid[])", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]*entities.Tag, 0, len(ids))
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) FindTagByName(scope entities.TagScope, ownerID, name string) (*entities.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(
+		ctx,
+		"SELECT "+tagColumns+" FROM tags WHERE scope = $1 AND owner_id = $2 AND name = $3",
+		scope, ownerID, name,
+	)
+	return findTag(row)
+}
+
+// FindTagUsageByOwner lists a page of the owner tags, counting their
+// non-deleted transactions. One row more than the page is read to tell
+// whether there is a next page.
+func (r *TagRepository) FindTagUsageByOwner(
+	scope entities.TagScope,
+	ownerID string,
+	filter repositories.TagFilter,
+	page query.Page,
+) ([]*entities.TagUsage, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.TagSorts
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT id, scope, owner_id, name, created_at, updated_at, transaction_count, last_used_at
+		FROM (
+			SELECT tags.id, tags.scope, tags.owner_id, tags.name, tags.created_at, tags.updated_at,
+				count(transactions.id) AS transaction_count, max(transactions.date) AS last_used_at
+			FROM tags
+			LEFT JOIN transaction_tags ON transaction_tags.tag_id = tags.id
+			LEFT JOIN transactions ON transactions.id = transaction_tags.transaction_id AND transactions.is_deleted = false
+			WHERE tags.scope = $1 AND tags.owner_id = $2
+			GROUP BY tags.id
+		) AS tag_usage
+		WHERE ($3 = '' OR strpos(lower(name), lower($3)) > 0)
+		AND ($4::boolean IS NULL OR (transaction_count > 0) = $4::boolean)
+		AND `+sorts.After(page, 5)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $6`,
+		scope,
+		ownerID,
+		filter.Query,
+		filter.Used,
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	usage := make([]*entities.TagUsage, 0)
+	for rows.Next() {
+		var (
+			tag        entities.Tag
+			stats      entities.TagUsage
+			lastUsedAt *time.Time
+		)
+		err := rows.Scan(
+			&tag.ID,
+			&tag.Scope,
+			&tag.OwnerID,
+			&tag.Name,
+			&tag.CreatedAt,
+			&tag.UpdatedAt,
+			&stats.TransactionCount,
+			&lastUsedAt,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		stats.Tag = &tag
+		if lastUsedAt != nil {
+			stats.LastUsedAt = *lastUsedAt
+		}
+		usage = append(usage, &stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(usage) > page.Limit {
+		usage = usage[:page.Limit]
+		next = page.Next(tagSortValues(page.Sort.Field, usage[page.Limit-1])...)
+	}
+
+	return usage, next, nil
+}
+
+// tagSortValues are the values of the columns of repositories.TagSorts[field]
+// for usage.
+func tagSortValues(field string, usage *entities.TagUsage) []string {
+	switch field {
+	case "usage":
+		return []string{query.FormatInteger(int64(usage.TransactionCount)), usage.Tag.ID}
+	case "created_at":
+		return []string{query.FormatTimestamp(usage.Tag.CreatedAt), usage.Tag.ID}
+	default:
+		return []string{usage.Tag.Name, usage.Tag.ID}
+	}
+}
+
+func (r *TagRepository) UpdateTag(tag *entities.Tag) (*entities.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE tags SET name = $2, updated_at = $3 WHERE id = $1",
+		tag.ID, tag.Name, tag.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (r *TagRepository) MergeTags(sourceID, targetID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Transactions tagged with both keep a single link to target
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT transaction_id, $2 FROM transaction_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO rule_tags (rule_id, tag_id)
+		SELECT rule_id, $2 FROM rule_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Links to source are removed by the cascade
+	if _, err := tx.Exec(ctx, "DELETE FROM tags WHERE id = $1", sourceID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TagRepository) DeleteTag(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, "DELETE FROM tags WHERE id = $1", id)
+	return err
+}
+
+func findTag(row pgx.Row) (*entities.Tag, error) {
+	tag, err := scanTag(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+func scanTag(row pgx.Row) (*entities.Tag, error) {
+	var tag entities.Tag
+	err := row.Scan(&tag.ID, &tag.Scope, &tag.OwnerID, &tag.Name, &tag.CreatedAt, &tag.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func NewTagRepository(db *pgxpool.Pool) repositories.TagRepository {
+	return &TagRepository{
+		db: db,
+	}
+}
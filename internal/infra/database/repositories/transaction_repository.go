@@ -0,0 +1,779 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+const transactionColumns = `id, wallet_id, type, amount, currency, date, description, status, category_id, payee_id, transfer_id,
+	recurring_id, occurrence_date, awaiting_approval, created_by, created_at, updated_at,
+	ARRAY(SELECT tag_id::text FROM transaction_tags WHERE transaction_id = transactions.id ORDER BY tag_id)`
+
+const insertTransactionQuery = `INSERT INTO transactions (id, wallet_id, type, amount, currency, date, description, status, category_id,
+	payee_id, transfer_id, recurring_id, occurrence_date, awaiting_approval, created_by, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+
+const deleteTransactionQuery = "UPDATE transactions SET is_deleted = true, deleted_at = $2, updated_at = $2 WHERE id = $1"
+
+// searchHeadlineOptions shape the snippets of search results: up to two
+// short fragments around the matches.
+const searchHeadlineOptions = "StartSel=" + entities.SearchHighlightStart + ", StopSel=" + entities.SearchHighlightStop +
+	`, MaxWords=20, MinWords=5, MaxFragments=2, FragmentDelimiter=" … "`
+
+type TransactionRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *TransactionRepository) CreateTransaction(transaction *entities.Transaction) (*entities.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTransaction(ctx, tx, transaction); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (r *TransactionRepository) FindTransactionByID(id string) (*entities.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRow(
+		ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE id = $1 AND is_deleted = false",
+		id,
+	)
+
+	transaction, err := scanTransaction(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := loadTransactionDetails(ctx, r.db, []*entities.Transaction{transaction}); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+func (r *TransactionRepository) FindTransactionsByIDs(ids []string) ([]*entities.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE id = ANY($1::uuid[]) AND is_deleted = false",
+		idArray(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]*entities.Transaction, 0, len(ids))
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadTransactionDetails(ctx, r.db, transactions); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// FindTransactionsByWalletID lists a page of the wallet transactions. A
+// transaction matches the tag filter when it has at least one of the tags,
+// or all of them with MatchAllTags. One row more than the page is read to
+// tell whether there is a next page.
+func (r *TransactionRepository) FindTransactionsByWalletID(
+	walletID string,
+	filter repositories.TransactionFilter,
+	page query.Page,
+) ([]*entities.Transaction, *query.Cursor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sorts := repositories.TransactionSorts
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+transactionColumns+` FROM transactions
+		WHERE wallet_id = $1 AND is_deleted = false
+		AND (cardinality($2::uuid[]) = 0 OR (
+			SELECT count(*) FROM transaction_tags
+			WHERE transaction_id = transactions.id AND tag_id = ANY($2::uuid[])
+		) >= CASE WHEN $3 THEN cardinality($2::uuid[]) ELSE 1 END)
+		AND ($4::date IS NULL OR date >= $4::date)
+		AND ($5::date IS NULL OR date <= $5::date)
+		AND ($6::text IS NULL OR currency = $6::text)
+		AND ($7::bigint IS NULL OR amount >= $7::bigint)
+		AND ($8::bigint IS NULL OR amount <= $8::bigint)
+		AND (cardinality($9::uuid[]) = 0 OR EXISTS (
+			SELECT 1 FROM categories c
+			WHERE (c.id = ANY($9::uuid[]) OR c.parent_id = ANY($9::uuid[]))
+			AND (c.id = transactions.category_id OR EXISTS (
+				SELECT 1 FROM transaction_splits
+				WHERE transaction_id = transactions.id AND category_id = c.id
+			))
+		))
+		AND (cardinality($10::uuid[]) = 0 OR payee_id = ANY($10::uuid[]))
+		AND (cardinality($11::uuid[]) = 0 OR created_by = ANY($11::uuid[]) OR EXISTS (
+			SELECT 1 FROM transaction_sharing
+			WHERE transaction_id = transactions.id AND paid_by = ANY($11::uuid[])
+		) OR EXISTS (
+			SELECT 1 FROM transaction_shares
+			WHERE transaction_id = transactions.id AND user_id = ANY($11::uuid[])
+		))
+		AND (cardinality($12::text[]) = 0 OR type::text = ANY($12::text[]))
+		AND `+sorts.After(page, 13)+`
+		ORDER BY `+sorts.OrderBy(page)+`
+		LIMIT $14`,
+		walletID,
+		idArray(filter.TagIDs),
+		filter.MatchAllTags,
+		nullableDate(filter.From),
+		nullableDate(filter.To),
+		amountCurrency(filter.MinAmount, filter.MaxAmount),
+		amountMinorUnits(filter.MinAmount),
+		amountMinorUnits(filter.MaxAmount),
+		idArray(filter.CategoryIDs),
+		idArray(filter.PayeeIDs),
+		idArray(filter.MemberIDs),
+		transactionTypes(filter.Types),
+		page.CursorValues(),
+		page.Limit+1,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]*entities.Transaction, 0)
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *query.Cursor
+	if len(transactions) > page.Limit {
+		transactions = transactions[:page.Limit]
+		next = page.Next(transactionSortValues(page.Sort.Field, transactions[page.Limit-1])...)
+	}
+
+	if err := loadTransactionDetails(ctx, r.db, transactions); err != nil {
+		return nil, nil, err
+	}
+
+	return transactions, next, nil
+}
+
+// SearchTransactions runs the query as both Portuguese and English, ignoring
+// accents, and keeps the transactions matching either. The snippet is cut
+// from the text that matched, in the language that matched.
+func (r *TransactionRepository) SearchTransactions(search repositories.TransactionSearch) ([]*entities.TransactionSearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		`WITH query AS (
+			SELECT
+				websearch_to_tsquery('saver_portuguese', $2) AS portuguese,
+				websearch_to_tsquery('saver_english', $2) AS english
+		)
+		SELECT `+transactionColumns+`,
+			ts_rank_cd(search_vector, query.portuguese || query.english) AS search_rank,
+			CASE WHEN to_tsvector('saver_portuguese', document.text) @@ query.portuguese
+				THEN ts_headline('saver_portuguese', document.text, query.portuguese, $5)
+				ELSE ts_headline('saver_english', document.text, query.english, $5)
+			END
+		FROM transactions
+		CROSS JOIN query
+		CROSS JOIN LATERAL (
+			SELECT concat_ws(' ', main, tags, notes, comments) AS text
+			FROM transaction_search_document(transactions.id, transactions.description, transactions.payee_id)
+		) AS document
+		WHERE is_deleted = false
+		AND wallet_id IN (SELECT wallet_id FROM accessible_wallet_ids($1))
+		AND ($3::uuid IS NULL OR wallet_id = $3)
+		AND search_vector @@ (query.portuguese || query.english)
+		ORDER BY search_rank DESC, date DESC, created_at DESC
+		LIMIT $4`,
+		search.UserID,
+		search.Query,
+		nullableID(search.WalletID),
+		search.Limit,
+		searchHeadlineOptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]*entities.TransactionSearchResult, 0)
+	transactions := make([]*entities.Transaction, 0)
+	for rows.Next() {
+		var (
+			rank    float32
+			snippet string
+		)
+		transaction, err := scanTransaction(rows, &rank, &snippet)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &entities.TransactionSearchResult{
+			Transaction: transaction,
+			Rank:        float64(rank),
+			Snippet:     snippet,
+		})
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadTransactionDetails(ctx, r.db, transactions); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *TransactionRepository) UpdateTransaction(transaction *entities.Transaction) (*entities.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := updateTransaction(ctx, tx, transaction); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (r *TransactionRepository) DeleteTransaction(transaction *entities.Transaction) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(ctx, deleteTransactionQuery, transaction.ID, transaction.DeletedAt)
+	return err
+}
+
+// SaveTransactions writes a whole bulk operation, so it gets a longer
+// timeout than single-row writes.
+func (r *TransactionRepository) SaveTransactions(transactions []*entities.Transaction) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := saveTransactions(ctx, tx, transactions); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// saveTransactions updates or deletes each transaction within tx.
+func saveTransactions(ctx context.Context, tx pgx.Tx, transactions []*entities.Transaction) error {
+	for _, transaction := range transactions {
+		if transaction.IsDeleted {
+			if _, err := tx.Exec(ctx, deleteTransactionQuery, transaction.ID, transaction.DeletedAt); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := updateTransaction(ctx, tx, transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *TransactionRepository) PromoteScheduledTransactions(today time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Exec(
+		ctx,
+		`UPDATE transactions SET status = 'PENDING', updated_at = now()
+		WHERE status = 'SCHEDULED' AND date <= $1 AND is_deleted = false`,
+		today,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *TransactionRepository) PurgeDeletedTransactions(deletedBefore time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Exec(
+		ctx,
+		`DELETE FROM transactions t
+		WHERE t.is_deleted = true AND t.deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM attachments a WHERE a.transaction_id = t.id)`,
+		deletedBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// insertTransaction writes the transaction and its details within tx.
+func insertTransaction(ctx context.Context, tx pgx.Tx, transaction *entities.Transaction) error {
+	if _, err := tx.Exec(ctx, insertTransactionQuery, transactionArgs(transaction)...); err != nil {
+		return err
+	}
+
+	return replaceTransactionDetails(ctx, tx, transaction)
+}
+
+// transactionSortValues are the values of the columns of
+// repositories.TransactionSorts[field] for transaction.
+func transactionSortValues(field string, transaction *entities.Transaction) []string {
+	switch field {
+	case "amount":
+		return []string{
+			transaction.Amount.Currency().Code,
+			query.FormatInteger(transaction.Amount.MinorUnits()),
+			transaction.ID,
+		}
+	case "created_at":
+		return []string{query.FormatTimestamp(transaction.CreatedAt), transaction.ID}
+	default:
+		return []string{query.FormatDate(transaction.Date), query.FormatTimestamp(transaction.CreatedAt), transaction.ID}
+	}
+}
+
+// amountCurrency is the currency of an amount filter, nil when there is
+// none. Both bounds are in the same currency.
+func amountCurrency(amounts ...*money.Money) interface{} {
+	for _, amount := range amounts {
+		if amount != nil {
+			return amount.Currency().Code
+		}
+	}
+	return nil
+}
+
+func amountMinorUnits(amount *money.Money) interface{} {
+	if amount == nil {
+		return nil
+	}
+	return amount.MinorUnits()
+}
+
+func transactionTypes(types []entities.TransactionType) []string {
+	values := make([]string, 0, len(types))
+	for _, transactionType := range types {
+		values = append(values, string(transactionType))
+	}
+	return values
+}
+
+// transactionArgs lists the values of insertTransactionQuery.
+func transactionArgs(transaction *entities.Transaction) []interface{} {
+	return []interface{}{
+		transaction.ID,
+		transaction.WalletID,
+		transaction.Type,
+		transaction.Amount,
+		transaction.Amount.Currency(),
+		transaction.Date,
+		transaction.Description,
+		transaction.Status,
+		nullableID(transaction.CategoryID),
+		nullableID(transaction.PayeeID),
+		nullableID(transaction.TransferID),
+		nullableID(transaction.RecurringID),
+		nullableDate(transaction.OccurrenceDate),
+		transaction.AwaitingApproval,
+		transaction.CreatedBy,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	}
+}
+
+// updateTransaction saves the wallet, editable fields and details within tx.
+func updateTransaction(ctx context.Context, tx pgx.Tx, transaction *entities.Transaction) error {
+	_, err := tx.Exec(
+		ctx,
+		`UPDATE transactions
+		SET type = $2, amount = $3, currency = $4, date = $5, description = $6, category_id = $7, payee_id = $8,
+			updated_at = $9, wallet_id = $10, status = $11, awaiting_approval = $12
+		WHERE id = $1 AND is_deleted = false`,
+		transaction.ID,
+		transaction.Type,
+		transaction.Amount,
+		transaction.Amount.Currency(),
+		transaction.Date,
+		transaction.Description,
+		nullableID(transaction.CategoryID),
+		nullableID(transaction.PayeeID),
+		transaction.UpdatedAt,
+		transaction.WalletID,
+		transaction.Status,
+		transaction.AwaitingApproval,
+	)
+	if err != nil {
+		return err
+	}
+
+	return replaceTransactionDetails(ctx, tx, transaction)
+}
+
+// scanTransaction reads a row selected with transactionColumns, followed by
+// the extra columns, if any. The amount is stored in minor units next to its
+// currency code.
+func scanTransaction(row pgx.Row, extra ...interface{}) (*entities.Transaction, error) {
+	var (
+		transaction entities.Transaction
+		minorUnits  int64
+		currency    string
+		categoryID  *string
+		payeeID     *string
+		transferID  *string
+		recurringID *string
+		occurrence  *time.Time
+	)
+
+	dest := []interface{}{
+		&transaction.ID,
+		&transaction.WalletID,
+		&transaction.Type,
+		&minorUnits,
+		&currency,
+		&transaction.Date,
+		&transaction.Description,
+		&transaction.Status,
+		&categoryID,
+		&payeeID,
+		&transferID,
+		&recurringID,
+		&occurrence,
+		&transaction.AwaitingApproval,
+		&transaction.CreatedBy,
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+		&transaction.TagIDs,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := money.New(minorUnits, currency)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Amount = amount
+	if categoryID != nil {
+		transaction.CategoryID = *categoryID
+	}
+	if payeeID != nil {
+		transaction.PayeeID = *payeeID
+	}
+	if transferID != nil {
+		transaction.TransferID = *transferID
+	}
+	if recurringID != nil {
+		transaction.RecurringID = *recurringID
+	}
+	if occurrence != nil {
+		transaction.OccurrenceDate = *occurrence
+	}
+
+	return &transaction, nil
+}
+
+// replaceTransactionTags makes the stored links match transaction.TagIDs.
+func replaceTransactionTags(ctx context.Context, tx pgx.Tx, transaction *entities.Transaction) error {
+	_, err := tx.Exec(ctx, "DELETE FROM transaction_tags WHERE transaction_id = $1", transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO transaction_tags (transaction_id, tag_id) SELECT $1, unnest($2::uuid[])",
+		transaction.ID, idArray(transaction.TagIDs),
+	)
+	return err
+}
+
+// replaceTransactionDetails makes the stored tags, split lines and sharing
+// match the transaction.
+func replaceTransactionDetails(ctx context.Context, tx pgx.Tx, transaction *entities.Transaction) error {
+	if err := replaceTransactionTags(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	if err := replaceTransactionSplits(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	return replaceTransactionSharing(ctx, tx, transaction)
+}
+
+// loadTransactionDetails reads the split lines and sharing of transactions;
+// tags come with transactionColumns.
+func loadTransactionDetails(ctx context.Context, db *pgxpool.Pool, transactions []*entities.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	if err := loadTransactionSplits(ctx, db, transactions); err != nil {
+		return err
+	}
+
+	return loadTransactionSharing(ctx, db, transactions)
+}
+
+// replaceTransactionSplits makes the stored split lines match
+// transaction.Splits, keeping their order.
+func replaceTransactionSplits(ctx context.Context, tx pgx.Tx, transaction *entities.Transaction) error {
+	_, err := tx.Exec(ctx, "DELETE FROM transaction_splits WHERE transaction_id = $1", transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	for position, split := range transaction.Splits {
+		_, err := tx.Exec(
+			ctx,
+			`INSERT INTO transaction_splits (id, transaction_id, position, category_id, amount, currency, note)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			split.ID,
+			transaction.ID,
+			position,
+			nullableID(split.CategoryID),
+			split.Amount,
+			split.Amount.Currency(),
+			split.Note,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadTransactionSplits reads the split lines of transactions in one query.
+func loadTransactionSplits(ctx context.Context, db *pgxpool.Pool, transactions []*entities.Transaction) error {
+	byID, ids := indexTransactions(transactions)
+
+	rows, err := db.Query(
+		ctx,
+		`SELECT transaction_id, id, category_id, amount, currency, note FROM transaction_splits
+		WHERE transaction_id = ANY($1::uuid[])
+		ORDER BY transaction_id, position`,
+		ids,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			transactionID string
+			split         entities.TransactionSplit
+			categoryID    *string
+			minorUnits    int64
+			currency      string
+		)
+		if err := rows.Scan(&transactionID, &split.ID, &categoryID, &minorUnits, &currency, &split.Note); err != nil {
+			return err
+		}
+
+		if split.Amount, err = money.New(minorUnits, currency); err != nil {
+			return err
+		}
+		if categoryID != nil {
+			split.CategoryID = *categoryID
+		}
+
+		transaction := byID[transactionID]
+		transaction.Splits = append(transaction.Splits, split)
+	}
+
+	return rows.Err()
+}
+
+// replaceTransactionSharing makes the stored sharing match
+// transaction.Sharing.
+func replaceTransactionSharing(ctx context.Context, tx pgx.Tx, transaction *entities.Transaction) error {
+	_, err := tx.Exec(ctx, "DELETE FROM transaction_sharing WHERE transaction_id = $1", transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	if !transaction.IsShared() {
+		return nil
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO transaction_sharing (transaction_id, paid_by, method) VALUES ($1, $2, $3)",
+		transaction.ID, transaction.Sharing.PaidBy, transaction.Sharing.Method,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, share := range transaction.Sharing.Shares {
+		_, err := tx.Exec(
+			ctx,
+			`INSERT INTO transaction_shares (transaction_id, user_id, weight, amount, currency)
+			VALUES ($1, $2, $3, $4, $5)`,
+			transaction.ID,
+			share.UserID,
+			share.Weight,
+			share.Amount,
+			share.Amount.Currency(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadTransactionSharing reads the sharing of transactions in one query.
+func loadTransactionSharing(ctx context.Context, db *pgxpool.Pool, transactions []*entities.Transaction) error {
+	byID, ids := indexTransactions(transactions)
+
+	rows, err := db.Query(
+		ctx,
+		`SELECT sh.transaction_id, sh.paid_by, sh.method, s.user_id, s.weight, s.amount, s.currency
+		FROM transaction_sharing sh
+		JOIN transaction_shares s ON s.transaction_id = sh.transaction_id
+		WHERE sh.transaction_id = ANY($1::uuid[])
+		ORDER BY sh.transaction_id, s.user_id`,
+		ids,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			transactionID string
+			paidBy        string
+			method        entities.ShareMethod
+			share         entities.ExpenseShare
+			minorUnits    int64
+			currency      string
+		)
+		if err := rows.Scan(&transactionID, &paidBy, &method, &share.UserID, &share.Weight, &minorUnits, &currency); err != nil {
+			return err
+		}
+
+		if share.Amount, err = money.New(minorUnits, currency); err != nil {
+			return err
+		}
+
+		transaction := byID[transactionID]
+		if transaction.Sharing == nil {
+			transaction.Sharing = &entities.ExpenseSharing{PaidBy: paidBy, Method: method}
+		}
+		transaction.Sharing.Shares = append(transaction.Sharing.Shares, share)
+	}
+
+	return rows.Err()
+}
+
+// indexTransactions maps transactions by id and lists their ids.
+func indexTransactions(transactions []*entities.Transaction) (map[string]*entities.Transaction, []string) {
+	byID := make(map[string]*entities.Transaction, len(transactions))
+	ids := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		byID[transaction.ID] = transaction
+		ids = append(ids, transaction.ID)
+	}
+	return byID, ids
+}
+
+// idArray never returns nil so id filters are sent as an empty array, not NULL.
+func idArray(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
+
+// nullableID maps an empty optional reference to NULL.
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+// nullableDate maps an unset optional date to NULL.
+func nullableDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+func NewTransactionRepository(db *pgxpool.Pool) repositories.TransactionRepository {
+	return &TransactionRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+)
+
+type TransferRepository struct {
+	db *pgxpool.Pool
+}
+
+func (r *TransferRepository) CreateTransfer(transfer *entities.Transfer) (*entities.Transfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO transfers (id, rate, created_by, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)",
+		transfer.ID, transfer.Rate.FloatString(rateScale), transfer.CreatedBy, transfer.CreatedAt, transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leg := range []*entities.Transaction{transfer.Outgoing, transfer.Incoming} {
+		if err := insertTransaction(ctx, tx, leg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (r *TransferRepository) FindTransferByID(id string) (*entities.Transfer, error) {
+	var (
+		transfer entities.Transfer
+		rateText string
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRow(
+		ctx,
+		"SELECT id, rate::text, created_by, created_at, updated_at FROM transfers WHERE id = $1 AND is_deleted = false",
+		id,
+	).Scan(&transfer.ID, &rateText, &transfer.CreatedBy, &transfer.CreatedAt, &transfer.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rate, ok := new(big.Rat).SetString(rateText)
+	if !ok {
+		return nil, fmt.Errorf("invalid stored transfer rate %q", rateText)
+	}
+	transfer.Rate = rate
+
+	rows, err := r.db.Query(
+		ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE transfer_id = $1 AND is_deleted = false",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		leg, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		switch leg.Type {
+		case entities.TransactionTypeTransferOut:
+			transfer.Outgoing = leg
+		case entities.TransactionTypeTransferIn:
+			transfer.Incoming = leg
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if transfer.Outgoing == nil || transfer.Incoming == nil {
+		return nil, fmt.Errorf("transfer %s is missing a leg", id)
+	}
+
+	return &transfer, nil
+}
+
+func (r *TransferRepository) UpdateTransfer(transfer *entities.Transfer) (*entities.Transfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE transfers SET rate = $2, updated_at = $3 WHERE id = $1 AND is_deleted = false",
+		transfer.ID, transfer.Rate.FloatString(rateScale), transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leg := range []*entities.Transaction{transfer.Outgoing, transfer.Incoming} {
+		if err := updateTransaction(ctx, tx, leg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (r *TransferRepository) DeleteTransfer(transfer *entities.Transfer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE transfers SET is_deleted = true, deleted_at = $2, updated_at = $2 WHERE id = $1",
+		transfer.ID, transfer.DeletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE transactions SET is_deleted = true, deleted_at = $2, updated_at = $2 WHERE transfer_id = $1",
+		transfer.ID, transfer.DeletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func NewTransferRepository(db *pgxpool.Pool) repositories.TransferRepository {
+	return &TransferRepository{
+		db: db,
+	}
+}
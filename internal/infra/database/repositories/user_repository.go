@@ -15,16 +15,40 @@ type UserRepository struct {
 	db *pgxpool.Pool
 }
 
-func (r *UserRepository) CreateUser(user *entities.User) (*entities.User, error) {
-	_, err := r.db.Exec(
-		context.Background(),
-		"INSERT INTO users (id, first_name, last_name, email, password, role) VALUES ($1, $2, $3, $4, $5, $6)",
-		user.ID, user.FirstName, user.LastName, user.Email, user.Password, user.Role,
-	)
+func (r *UserRepository) CreateUser(user *entities.User, categories []*entities.Category) (*entities.User, error) {
+	var parentID *string
+	if user.ParentID != "" {
+		parentID = &user.ParentID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
 
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO users (id, first_name, last_name, email, password, role, parent_id) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		user.ID, user.FirstName, user.LastName, user.Email, user.Password, user.Role, parentID,
+	)
 	if err != nil {
 		return nil, err
 	}
+
+	// Parents come before their children in categories
+	for _, category := range categories {
+		if _, err := tx.Exec(ctx, insertCategoryQuery, categoryArgs(category)...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
@@ -53,6 +77,88 @@ func (r *UserRepository) FindUserByEmail(email string) (*entities.User, error) {
 	return &user, nil
 }
 
+func (r *UserRepository) FindUserByID(id string) (*entities.User, error) {
+	var (
+		user         entities.User
+		parentID     *string
+		baseCurrency *string
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := "SELECT id, first_name, last_name, email, role, parent_id, base_currency FROM users WHERE id = $1 AND is_deleted = false"
+
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&user.ID,
+		&user.FirstName,
+		&user.LastName,
+		&user.Email,
+		&user.Role,
+		&parentID,
+		&baseCurrency,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if parentID != nil {
+		user.ParentID = *parentID
+	}
+	if baseCurrency != nil {
+		user.BaseCurrency = *baseCurrency
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) FindDependentsByParentID(parentID string) ([]*entities.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := `SELECT id, first_name, last_name, email, role FROM users
+		WHERE parent_id = $1 AND is_deleted = false
+		ORDER BY created_at`
+
+	rows, err := r.db.Query(ctx, query, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dependents []*entities.User
+	for rows.Next() {
+		user := entities.User{ParentID: parentID}
+		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Role); err != nil {
+			return nil, err
+		}
+		dependents = append(dependents, &user)
+	}
+
+	return dependents, rows.Err()
+}
+
+func (r *UserRepository) UpdateBaseCurrency(user *entities.User) error {
+	var baseCurrency *string
+	if user.BaseCurrency != "" {
+		baseCurrency = &user.BaseCurrency
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"UPDATE users SET base_currency = $2, updated_at = $3 WHERE id = $1 AND is_deleted = false",
+		user.ID, baseCurrency, user.UpdatedAt,
+	)
+	return err
+}
+
 func NewUserRepository(db *pgxpool.Pool) repositories.UserRepository {
 	return &UserRepository{
 		db: db,
@@ -19,16 +19,37 @@ type MockUserRepositoryAdapter struct {
 	mock pgxmock.PgxPoolIface
 }
 
-func (r *MockUserRepositoryAdapter) CreateUser(user *entities.User) (*entities.User, error) {
-	_, err := r.mock.Exec(
-		context.Background(),
-		"INSERT INTO users (id, first_name, last_name, email, password, role) VALUES ($1, $2, $3, $4, $5, $6)",
-		user.ID, user.FirstName, user.LastName, user.Email, user.Password, user.Role,
-	)
+func (r *MockUserRepositoryAdapter) CreateUser(user *entities.User, categories []*entities.Category) (*entities.User, error) {
+	var parentID *string
+	if user.ParentID != "" {
+		parentID = &user.ParentID
+	}
 
+	ctx := context.Background()
+	tx, err := r.mock.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO users (id, first_name, last_name, email, password, role, parent_id) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		user.ID, user.FirstName, user.LastName, user.Email, user.Password, user.Role, parentID,
+	)
 	if err != nil {
 		return nil, err
 	}
+
+	for _, category := range categories {
+		if _, err := tx.Exec(ctx, "INSERT INTO categories (id, user_id, name) VALUES ($1, $2, $3)", category.ID, category.UserID, category.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
@@ -56,6 +77,81 @@ func (r *MockUserRepositoryAdapter) FindUserByEmail(email string) (*entities.Use
 	return &user, nil
 }
 
+func (r *MockUserRepositoryAdapter) FindUserByID(id string) (*entities.User, error) {
+	var (
+		user         entities.User
+		parentID     *string
+		baseCurrency *string
+	)
+
+	err := r.mock.QueryRow(
+		context.Background(),
+		"SELECT id, first_name, last_name, email, role, parent_id, base_currency FROM users WHERE id = $1 AND is_deleted = false",
+		id,
+	).Scan(
+		&user.ID,
+		&user.FirstName,
+		&user.LastName,
+		&user.Email,
+		&user.Role,
+		&parentID,
+		&baseCurrency,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if parentID != nil {
+		user.ParentID = *parentID
+	}
+	if baseCurrency != nil {
+		user.BaseCurrency = *baseCurrency
+	}
+
+	return &user, nil
+}
+
+func (r *MockUserRepositoryAdapter) FindDependentsByParentID(parentID string) ([]*entities.User, error) {
+	rows, err := r.mock.Query(
+		context.Background(),
+		"SELECT id, first_name, last_name, email, role FROM users WHERE parent_id = $1 AND is_deleted = false ORDER BY created_at",
+		parentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dependents []*entities.User
+	for rows.Next() {
+		user := entities.User{ParentID: parentID}
+		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Role); err != nil {
+			return nil, err
+		}
+		dependents = append(dependents, &user)
+	}
+
+	return dependents, rows.Err()
+}
+
+func (r *MockUserRepositoryAdapter) UpdateBaseCurrency(user *entities.User) error {
+	var baseCurrency *string
+	if user.BaseCurrency != "" {
+		baseCurrency = &user.BaseCurrency
+	}
+
+	_, err := r.mock.Exec(
+		context.Background(),
+		"UPDATE users SET base_currency = $2, updated_at = $3 WHERE id = $1 AND is_deleted = false",
+		user.ID, baseCurrency, user.UpdatedAt,
+	)
+	return err
+}
+
 func NewMockUserRepository(mock pgxmock.PgxPoolIface) repositories.UserRepository {
 	return &MockUserRepositoryAdapter{
 		mock: mock,
@@ -63,59 +159,71 @@ func NewMockUserRepository(mock pgxmock.PgxPoolIface) repositories.UserRepositor
 }
 
 func TestUserRepository_CreateUser(t *testing.T) {
+	newUser := func() *entities.User {
+		return &entities.User{
+			ID:        "123e4567-e89b-12d3-a456-426614174000",
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "john.doe@example.com",
+			Password:  "hashed_password",
+			Role:      entities.RoleUser,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+	}
+	categories := []*entities.Category{{
+		ID:     "323e4567-e89b-12d3-a456-426614174000",
+		UserID: "123e4567-e89b-12d3-a456-426614174000",
+		Name:   "Food",
+	}}
+	expectInsertUser := func(mock pgxmock.PgxPoolIface) *pgxmock.ExpectedExec {
+		return mock.ExpectExec("INSERT INTO users").
+			WithArgs(
+				"123e4567-e89b-12d3-a456-426614174000",
+				"John",
+				"Doe",
+				"john.doe@example.com",
+				"hashed_password",
+				entities.RoleUser,
+				(*string)(nil),
+			)
+	}
+
 	tests := []struct {
 		name    string
-		user    *entities.User
 		mockDB  func(pgxmock.PgxPoolIface)
 		wantErr bool
 	}{
 		{
 			name: "successful user creation",
-			user: &entities.User{
-				ID:        "123e4567-e89b-12d3-a456-426614174000",
-				FirstName: "John",
-				LastName:  "Doe",
-				Email:     "john.doe@example.com",
-				Password:  "hashed_password",
-				Role:      entities.RoleUser,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
 			mockDB: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectExec("INSERT INTO users").
-					WithArgs(
-						"123e4567-e89b-12d3-a456-426614174000",
-						"John",
-						"Doe",
-						"john.doe@example.com",
-						"hashed_password",
-						entities.RoleUser,
-					).
+				mock.ExpectBegin()
+				expectInsertUser(mock).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec("INSERT INTO categories").
+					WithArgs("323e4567-e89b-12d3-a456-426614174000", "123e4567-e89b-12d3-a456-426614174000", "Food").
 					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
 			},
 			wantErr: false,
 		},
 		{
 			name: "database error",
-			user: &entities.User{
-				ID:        "123e4567-e89b-12d3-a456-426614174000",
-				FirstName: "John",
-				LastName:  "Doe",
-				Email:     "john.doe@example.com",
-				Password:  "hashed_password",
-				Role:      entities.RoleUser,
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				expectInsertUser(mock).WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
 			},
+			wantErr: true,
+		},
+		{
+			name: "user is not stored when its categories fail",
 			mockDB: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectExec("INSERT INTO users").
-					WithArgs(
-						"123e4567-e89b-12d3-a456-426614174000",
-						"John",
-						"Doe",
-						"john.doe@example.com",
-						"hashed_password",
-						entities.RoleUser,
-					).
+				mock.ExpectBegin()
+				expectInsertUser(mock).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec("INSERT INTO categories").
+					WithArgs("323e4567-e89b-12d3-a456-426614174000", "123e4567-e89b-12d3-a456-426614174000", "Food").
 					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -131,14 +239,15 @@ func TestUserRepository_CreateUser(t *testing.T) {
 
 			repo := NewMockUserRepository(mock)
 
-			result, err := repo.CreateUser(tt.user)
+			user := newUser()
+			result, err := repo.CreateUser(user, categories)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.user, result)
+				assert.Equal(t, user, result)
 			}
 
 			err = mock.ExpectationsWereMet()
@@ -223,3 +332,83 @@ func TestUserRepository_FindUserByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_FindUserByID(t *testing.T) {
+	parentID := "223e4567-e89b-12d3-a456-426614174000"
+	baseCurrency := "EUR"
+
+	tests := []struct {
+		name     string
+		id       string
+		mockDB   func(pgxmock.PgxPoolIface)
+		expected *entities.User
+		wantErr  bool
+	}{
+		{
+			name: "dependent user found",
+			id:   "123e4567-e89b-12d3-a456-426614174000",
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "first_name", "last_name", "email", "role", "parent_id", "base_currency"}).
+					AddRow("123e4567-e89b-12d3-a456-426614174000", "Ana", "Doe", "ana.doe@example.com", entities.RoleDependent, &parentID, &baseCurrency)
+
+				mock.ExpectQuery("SELECT id, first_name, last_name, email, role, parent_id, base_currency FROM users WHERE id = \\$1").
+					WithArgs("123e4567-e89b-12d3-a456-426614174000").
+					WillReturnRows(rows)
+			},
+			expected: &entities.User{
+				ID:           "123e4567-e89b-12d3-a456-426614174000",
+				FirstName:    "Ana",
+				LastName:     "Doe",
+				Email:        "ana.doe@example.com",
+				Role:         entities.RoleDependent,
+				ParentID:     parentID,
+				BaseCurrency: baseCurrency,
+			},
+		},
+		{
+			name: "user not found",
+			id:   "123e4567-e89b-12d3-a456-426614174000",
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("SELECT id, first_name, last_name, email, role, parent_id, base_currency FROM users WHERE id = \\$1").
+					WithArgs("123e4567-e89b-12d3-a456-426614174000").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expected: nil,
+		},
+		{
+			name: "database error",
+			id:   "123e4567-e89b-12d3-a456-426614174000",
+			mockDB: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("SELECT id, first_name, last_name, email, role, parent_id, base_currency FROM users WHERE id = \\$1").
+					WithArgs("123e4567-e89b-12d3-a456-426614174000").
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			tt.mockDB(mock)
+
+			repo := NewMockUserRepository(mock)
+
+			result, err := repo.FindUserByID(tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			err = mock.ExpectationsWereMet()
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type WalletRepository struct {
+	db *pgxpool.Pool
+}
+
+const walletColumns = `id, name, household_id, approval_threshold, approval_threshold_currency, approval_ttl_hours,
+	created_at, updated_at`
+
+func (r *WalletRepository) CreateWallet(wallet *entities.Wallet) (*entities.Wallet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	threshold, currency, ttlHours := approvalPolicyValues(wallet.ApprovalPolicy)
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO wallets (id, name, household_id, approval_threshold, approval_threshold_currency, approval_ttl_hours,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		wallet.ID, wallet.Name, nullableID(wallet.HouseholdID), threshold, currency, ttlHours,
+		wallet.CreatedAt, wallet.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range wallet.Members {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO wallet_members (wallet_id, user_id, role, joined_at) VALUES ($1, $2, $3, $4)",
+			member.WalletID, member.UserID, member.Role, member.JoinedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func (r *WalletRepository) FindWalletByID(id string) (*entities.Wallet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	wallet, err := scanWallet(r.db.QueryRow(ctx, "SELECT "+walletColumns+" FROM wallets WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := r.loadMembers(ctx, []*entities.Wallet{wallet}); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func (r *WalletRepository) FindWalletsByUserID(userID string) ([]*entities.Wallet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT `+walletColumns+` FROM wallets
+		WHERE id IN (SELECT wallet_id FROM wallet_members WHERE user_id = $1)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = $1)
+		ORDER BY created_at, id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets []*entities.Wallet
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, wallet)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadMembers(ctx, wallets); err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
+
+func (r *WalletRepository) FindWalletIDsByUserID(userID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, "SELECT wallet_id::text FROM accessible_wallet_ids($1) ORDER BY wallet_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	walletIDs := make([]string, 0)
+	for rows.Next() {
+		var walletID string
+		if err := rows.Scan(&walletID); err != nil {
+			return nil, err
+		}
+		walletIDs = append(walletIDs, walletID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return walletIDs, nil
+}
+
+func (r *WalletRepository) FindWalletMember(walletID, userID string) (*entities.WalletMember, error) {
+	var member entities.WalletMember
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// wallet_roles sorts OWNER first, so the strongest role wins
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT wallet_id, user_id, role, joined_at FROM wallet_members
+		WHERE wallet_id = $1 AND user_id = $2
+		UNION ALL
+		SELECT w.id, hm.user_id,
+			CASE WHEN hm.role = 'MEMBER' THEN 'MEMBER'::wallet_roles ELSE 'OWNER'::wallet_roles END,
+			hm.joined_at
+		FROM wallets w
+		JOIN household_members hm ON hm.household_id = w.household_id
+		WHERE w.id = $1 AND hm.user_id = $2
+		ORDER BY role
+		LIMIT 1`,
+		walletID, userID,
+	).Scan(&member.WalletID, &member.UserID, &member.Role, &member.JoinedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *WalletRepository) AddMember(member *entities.WalletMember) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"INSERT INTO wallet_members (wallet_id, user_id, role, joined_at) VALUES ($1, $2, $3, $4)",
+		member.WalletID, member.UserID, member.Role, member.JoinedAt,
+	)
+	return err
+}
+
+func (r *WalletRepository) RemoveMember(walletID, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Exec(
+		ctx,
+		"DELETE FROM wallet_members WHERE wallet_id = $1 AND user_id = $2",
+		walletID, userID,
+	)
+	return err
+}
+
+func (r *WalletRepository) UpdateApprovalPolicy(wallet *entities.Wallet) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	threshold, currency, ttlHours := approvalPolicyValues(wallet.ApprovalPolicy)
+	_, err := r.db.Exec(
+		ctx,
+		`UPDATE wallets
+		SET approval_threshold = $2, approval_threshold_currency = $3, approval_ttl_hours = $4, updated_at = $5
+		WHERE id = $1`,
+		wallet.ID, threshold, currency, ttlHours, wallet.UpdatedAt,
+	)
+	return err
+}
+
+// loadMembers fills in the members of the wallets with a single query.
+func (r *WalletRepository) loadMembers(ctx context.Context, wallets []*entities.Wallet) error {
+	if len(wallets) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*entities.Wallet, len(wallets))
+	ids := make([]string, 0, len(wallets))
+	for _, wallet := range wallets {
+		byID[wallet.ID] = wallet
+		ids = append(ids, wallet.ID)
+	}
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT wallet_id, user_id, role, joined_at FROM wallet_members
+		WHERE wallet_id = ANY($1::uuid[])
+		ORDER BY joined_at, user_id`,
+		idArray(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member entities.WalletMember
+		if err := rows.Scan(&member.WalletID, &member.UserID, &member.Role, &member.JoinedAt); err != nil {
+			return err
+		}
+		wallet := byID[member.WalletID]
+		wallet.Members = append(wallet.Members, &member)
+	}
+	return rows.Err()
+}
+
+// approvalPolicyValues are the stored threshold, its currency and the
+// expiry in hours; a disabled policy has no threshold.
+func approvalPolicyValues(policy entities.ApprovalPolicy) (interface{}, interface{}, int) {
+	ttlHours := int(policy.TTL / time.Hour)
+	if !policy.Enabled() {
+		return nil, nil, ttlHours
+	}
+	return policy.Threshold.MinorUnits(), policy.Threshold.Currency().Code, ttlHours
+}
+
+func scanWallet(row pgx.Row) (*entities.Wallet, error) {
+	var (
+		wallet      entities.Wallet
+		householdID *string
+		threshold   *int64
+		currency    *string
+		ttlHours    int
+	)
+
+	err := row.Scan(&wallet.ID, &wallet.Name, &householdID, &threshold, &currency, &ttlHours,
+		&wallet.CreatedAt, &wallet.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if householdID != nil {
+		wallet.HouseholdID = *householdID
+	}
+
+	wallet.ApprovalPolicy.TTL = time.Duration(ttlHours) * time.Hour
+	if threshold != nil && currency != nil {
+		amount, err := money.New(*threshold, *currency)
+		if err != nil {
+			return nil, err
+		}
+		wallet.ApprovalPolicy.Threshold = amount
+	}
+	return &wallet, nil
+}
+
+func NewWalletRepository(db *pgxpool.Pool) repositories.WalletRepository {
+	return &WalletRepository{
+		db: db,
+	}
+}
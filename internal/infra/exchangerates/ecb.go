@@ -0,0 +1,136 @@
+package exchangerates
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// ECBSource identifies rates published by the European Central Bank. All of
+// them are quoted against the euro.
+const (
+	ECBSource       = "ECB"
+	ECBBaseCurrency = "EUR"
+)
+
+var ecbCSVDateLayouts = []string{"2006-01-02", "2 January 2006", "02 January 2006"}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECBXML reads the eurofxref-daily.xml and eurofxref-hist*.xml files.
+func ParseECBXML(r io.Reader) ([]*entities.ExchangeRate, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("invalid ECB XML: %w", err)
+	}
+
+	var rates []*entities.ExchangeRate
+	for _, day := range envelope.Days {
+		date, err := time.Parse("2006-01-02", day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB date %q: %w", day.Time, err)
+		}
+
+		for _, quote := range day.Rates {
+			rate, err := newECBRate(quote.Currency, quote.Rate, date)
+			if err != nil {
+				return nil, err
+			}
+			if rate != nil {
+				rates = append(rates, rate)
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+// ParseECBCSV reads the eurofxref.csv and eurofxref-hist.csv files: a header
+// row with "Date" followed by currency codes, then one row per day.
+func ParseECBCSV(r io.Reader) ([]*entities.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid ECB CSV header: %w", err)
+	}
+	if len(header) == 0 || !strings.EqualFold(strings.TrimSpace(header[0]), "Date") {
+		return nil, fmt.Errorf("invalid ECB CSV header: first column must be Date")
+	}
+
+	var rates []*entities.ExchangeRate
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB CSV row: %w", err)
+		}
+
+		date, err := parseECBCSVDate(record[0])
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 1; i < len(record) && i < len(header); i++ {
+			rate, err := newECBRate(header[i], record[i], date)
+			if err != nil {
+				return nil, err
+			}
+			if rate != nil {
+				rates = append(rates, rate)
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+func parseECBCSVDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range ecbCSVDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid ECB date %q", value)
+}
+
+// newECBRate returns nil for the blank and N/A cells the ECB uses for
+// currencies that were not quoted on a given day, and for currencies this
+// API does not know about (e.g. withdrawn ones in the historical files).
+func newECBRate(currency, value string, date time.Time) (*entities.ExchangeRate, error) {
+	currency = strings.TrimSpace(currency)
+	value = strings.TrimSpace(value)
+	if currency == "" || value == "" || strings.EqualFold(value, "N/A") {
+		return nil, nil
+	}
+
+	if _, err := money.LookupCurrency(currency); err != nil {
+		return nil, nil
+	}
+
+	rate, err := money.ParseRate(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ECB rate for %s: %w", currency, err)
+	}
+
+	return entities.NewExchangeRate(ECBBaseCurrency, currency, rate, date, ECBSource)
+}
@@ -0,0 +1,68 @@
+package exchangerates
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/providers"
+)
+
+// ECBHistoryURL covers the last 90 days of reference rates, which is enough
+// to answer "rate on date" for any recent date including weekends.
+const ECBHistoryURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+
+type ECBProvider struct {
+	client *http.Client
+	url    string
+}
+
+func (p *ECBProvider) Name() string {
+	return ECBSource
+}
+
+func (p *ECBProvider) FetchRates(date time.Time) ([]*entities.ExchangeRate, error) {
+	resp, err := p.client.Get(p.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ECB responded with status %d", resp.StatusCode)
+	}
+
+	rates, err := ParseECBXML(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ratesInEffect(rates, date), nil
+}
+
+// ratesInEffect keeps the rates of the latest publication day on or before
+// date, since the ECB does not publish on weekends and holidays.
+func ratesInEffect(rates []*entities.ExchangeRate, date time.Time) []*entities.ExchangeRate {
+	var latest time.Time
+	for _, rate := range rates {
+		if !rate.Date.After(date) && rate.Date.After(latest) {
+			latest = rate.Date
+		}
+	}
+
+	var result []*entities.ExchangeRate
+	for _, rate := range rates {
+		if rate.Date.Equal(latest) {
+			result = append(result, rate)
+		}
+	}
+	return result
+}
+
+func NewECBProvider(client *http.Client, url string) providers.ExchangeRateProvider {
+	return &ECBProvider{
+		client: client,
+		url:    url,
+	}
+}
@@ -0,0 +1,155 @@
+package exchangerates_test
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/infra/exchangerates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ecbXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2026-10-16">
+			<Cube currency="USD" rate="1.0866"/>
+			<Cube currency="BRL" rate="6.1337"/>
+		</Cube>
+		<Cube time="2026-10-15">
+			<Cube currency="USD" rate="1.0894"/>
+			<Cube currency="BRL" rate="6.1502"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func findRate(rates []*entities.ExchangeRate, quote string, date time.Time) *entities.ExchangeRate {
+	for _, rate := range rates {
+		if rate.QuoteCurrency.Code == quote && rate.Date.Equal(date) {
+			return rate
+		}
+	}
+	return nil
+}
+
+func TestParseECBXML(t *testing.T) {
+	// Act
+	rates, err := exchangerates.ParseECBXML(strings.NewReader(ecbXML))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, rates, 4)
+
+	rate := findRate(rates, "BRL", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, rate)
+	assert.Equal(t, "EUR", rate.BaseCurrency.Code)
+	assert.Equal(t, exchangerates.ECBSource, rate.Source)
+	assert.Equal(t, 0, rate.Rate.Cmp(big.NewRat(61337, 10000)))
+}
+
+func TestParseECBXML_Invalid(t *testing.T) {
+	_, err := exchangerates.ParseECBXML(strings.NewReader("not xml"))
+	assert.Error(t, err)
+
+	_, err = exchangerates.ParseECBXML(strings.NewReader(`<Envelope><Cube><Cube time="yesterday"></Cube></Cube></Envelope>`))
+	assert.Error(t, err)
+}
+
+func TestParseECBCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantCount int
+		wantDate  time.Time
+	}{
+		{
+			name:      "daily file",
+			input:     "Date, USD, JPY, BRL, \n16 October 2026, 1.0866, 162.41, 6.1337, \n",
+			wantCount: 3,
+			wantDate:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "historical file with missing quotes",
+			input:     "Date,USD,CYP,BRL,\n2026-10-16,1.0866,N/A,6.1337,\n2026-10-15,1.0894,N/A,,\n",
+			wantCount: 3,
+			wantDate:  time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := exchangerates.ParseECBCSV(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Len(t, rates, tt.wantCount)
+			assert.NotNil(t, findRate(rates, "USD", tt.wantDate))
+		})
+	}
+}
+
+func TestParseECBCSV_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty file", input: ""},
+		{name: "wrong header", input: "Day,USD\n2026-10-16,1.08\n"},
+		{name: "bad date", input: "Date,USD\nyesterday,1.08\n"},
+		{name: "bad rate", input: "Date,USD\n2026-10-16,abc\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exchangerates.ParseECBCSV(strings.NewReader(tt.input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestECBProvider_FetchRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ecbXML))
+	}))
+	defer server.Close()
+
+	provider := exchangerates.NewECBProvider(server.Client(), server.URL)
+
+	// A Saturday: the latest publication before it is Friday's
+	rates, err := provider.FetchRates(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Len(t, rates, 2)
+	for _, rate := range rates {
+		assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), rate.Date)
+	}
+}
+
+func TestECBProvider_FetchRates_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := exchangerates.NewECBProvider(server.Client(), server.URL)
+
+	_, err := provider.FetchRates(time.Now())
+	assert.Error(t, err)
+}
+
+func TestStubProvider_FetchRates(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	rates, err := exchangerates.NewStubProvider().FetchRates(date)
+
+	require.NoError(t, err)
+	assert.NotNil(t, findRate(rates, "BRL", date))
+	assert.NotNil(t, findRate(rates, "USD", date))
+}
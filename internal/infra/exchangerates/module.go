@@ -0,0 +1,21 @@
+package exchangerates
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/providers"
+	"github.com/stra1g/saver-api/internal/infra/config"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Provide(
+	NewExchangeRateProvider,
+)
+
+func NewExchangeRateProvider(config *config.Config) providers.ExchangeRateProvider {
+	if config.ExchangeRates.Provider == "ecb" {
+		return NewECBProvider(&http.Client{Timeout: 10 * time.Second}, ECBHistoryURL)
+	}
+	return NewStubProvider()
+}
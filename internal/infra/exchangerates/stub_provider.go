@@ -0,0 +1,47 @@
+package exchangerates
+
+import (
+	"time"
+
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/providers"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const StubSource = "STUB"
+
+// stubRates are fixed EUR based rates for local development and tests, so
+// the API can run without reaching the ECB.
+var stubRates = map[string]string{
+	"BRL": "6.0500",
+	"USD": "1.0800",
+	"GBP": "0.8400",
+	"JPY": "161.50",
+}
+
+type StubProvider struct{}
+
+func (p *StubProvider) Name() string {
+	return StubSource
+}
+
+func (p *StubProvider) FetchRates(date time.Time) ([]*entities.ExchangeRate, error) {
+	rates := make([]*entities.ExchangeRate, 0, len(stubRates))
+	for currency, value := range stubRates {
+		rate, err := money.ParseRate(value)
+		if err != nil {
+			return nil, err
+		}
+
+		exchangeRate, err := entities.NewExchangeRate(ECBBaseCurrency, currency, rate, date, StubSource)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, exchangeRate)
+	}
+	return rates, nil
+}
+
+func NewStubProvider() providers.ExchangeRateProvider {
+	return &StubProvider{}
+}
@@ -0,0 +1,43 @@
+package filestorage
+
+import (
+	"crypto/rand"
+	"net/http"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/infra/config"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/storage"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Provide(
+	NewStorage,
+)
+
+// NewStorage picks the storage driver from the configuration. Without a
+// signing key, local download links are signed with a random key and stop
+// working when the server restarts.
+func NewStorage(config *config.Config, log logger.Logger) (storage.Storage, error) {
+	if config.Storage.Driver == "s3" {
+		return storage.NewS3Storage(&http.Client{Timeout: 30 * time.Second}, storage.S3Config{
+			Endpoint:        config.Storage.S3Endpoint,
+			Region:          config.Storage.S3Region,
+			Bucket:          config.Storage.S3Bucket,
+			AccessKeyID:     config.Storage.S3AccessKeyID,
+			SecretAccessKey: config.Storage.S3SecretKey,
+			UsePathStyle:    config.Storage.S3UsePathStyle,
+		})
+	}
+
+	signingKey := []byte(config.Storage.SigningKey)
+	if len(signingKey) == 0 {
+		log.Info("STORAGE_SIGNING_KEY is not set, download links will not survive a restart", map[string]interface{}{})
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return storage.NewLocalStorage(config.Storage.LocalPath, config.Storage.PublicURL, signingKey)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type ActivityHandler struct {
+	activityService services.ActivityService
+	log             logger.Logger
+}
+
+// MarkActivitiesReadRequest marks the feed items in IDs read, or the whole
+// feed when it is empty.
+type MarkActivitiesReadRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// ActivityChangeResponse is a field of the subject before and after the
+// event; before is null for a field it added and after for one it removed.
+type ActivityChangeResponse struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type ActivityResponse struct {
+	ID        string                            `json:"id"`
+	WalletID  string                            `json:"wallet_id"`
+	ActorID   string                            `json:"actor_id"`
+	Type      string                            `json:"type"`
+	SubjectID string                            `json:"subject_id,omitempty"`
+	Changes   map[string]ActivityChangeResponse `json:"changes"`
+	Read      bool                              `json:"read"`
+	CreatedAt time.Time                         `json:"created_at"`
+}
+
+func mapActivityResponse(activity *entities.Activity) ActivityResponse {
+	changes := make(map[string]ActivityChangeResponse, len(activity.Changes))
+	for field, change := range activity.Changes {
+		changes[field] = ActivityChangeResponse{Before: change.Before, After: change.After}
+	}
+
+	return ActivityResponse{
+		ID:        activity.ID,
+		WalletID:  activity.WalletID,
+		ActorID:   activity.ActorID,
+		Type:      string(activity.Type),
+		SubjectID: activity.SubjectID,
+		Changes:   changes,
+		Read:      activity.Read,
+		CreatedAt: activity.CreatedAt,
+	}
+}
+
+// ListActivities lists a page of the wallet feed, newest first, optionally
+// only the items the caller has or has not read with ?read=. Items of the
+// caller themselves count as read. The X-Unread-Count header holds how many
+// are unread and X-Next-Cursor the ?cursor= of the next page.
+func (h *ActivityHandler) ListActivities() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		read, appErr := parseBoolQuery(c, "read")
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.ActivitySorts, repositories.DefaultActivitySort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		activities, next, unread, err := h.activityService.ListActivities(c.Param("id"), actorID, repositories.ActivityFilter{Read: read}, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]ActivityResponse, 0, len(activities))
+		for _, activity := range activities {
+			response = append(response, mapActivityResponse(activity))
+		}
+
+		setNextCursor(c, next)
+		c.Header(unreadCountHeader, strconv.Itoa(unread))
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *ActivityHandler) MarkActivitiesRead() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto MarkActivitiesReadRequest
+		if err := c.ShouldBindJSON(&dto); err != nil && !errors.Is(err, io.EOF) {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := h.activityService.MarkActivitiesRead(c.Param("id"), actorID, dto.IDs); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewActivityHandler(
+	activityService services.ActivityService,
+	log logger.Logger,
+) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+		log:             log,
+	}
+}
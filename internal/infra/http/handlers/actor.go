@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+)
+
+// actorHeader identifies the user making the request. It stands in for an
+// authenticated session, which the API does not have yet.
+const actorHeader = "X-User-ID"
+
+// parseActor returns the ID of the user making the request.
+func parseActor(c *gin.Context) (string, *apperror.AppError) {
+	actorID := c.GetHeader(actorHeader)
+	if actorID == "" {
+		return "", apperror.New(apperror.ErrorTypeUnauthorized, "The X-User-ID header is required")
+	}
+	return actorID, nil
+}
+
+// parseSelf returns the caller of a /users/:id route, who may only act as
+// themselves there.
+func parseSelf(c *gin.Context) (string, *apperror.AppError) {
+	actorID, appErr := parseActor(c)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	if actorID != c.Param("id") {
+		return "", apperror.New(apperror.ErrorTypeForbidden, "Users can only act on their own behalf")
+	}
+	return actorID, nil
+}
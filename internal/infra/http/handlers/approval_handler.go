@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ApprovalHandler struct {
+	approvalService services.ApprovalService
+	log             logger.Logger
+}
+
+type ReviewApprovalRequest struct {
+	Comment string `json:"comment"`
+}
+
+type ApprovalResponse struct {
+	ID            string      `json:"id"`
+	WalletID      string      `json:"wallet_id"`
+	TransactionID string      `json:"transaction_id"`
+	RequestedBy   string      `json:"requested_by"`
+	ReviewerID    string      `json:"reviewer_id,omitempty"`
+	Amount        money.Money `json:"amount"`
+	Status        string      `json:"status"`
+	ReviewedBy    string      `json:"reviewed_by,omitempty"`
+	Comment       string      `json:"comment,omitempty"`
+	RequestedAt   time.Time   `json:"requested_at"`
+	ExpiresAt     time.Time   `json:"expires_at"`
+	ReviewedAt    *time.Time  `json:"reviewed_at"`
+}
+
+func mapApprovalResponse(approval *entities.Approval) ApprovalResponse {
+	response := ApprovalResponse{
+		ID:            approval.ID,
+		WalletID:      approval.WalletID,
+		TransactionID: approval.TransactionID,
+		RequestedBy:   approval.RequestedBy,
+		ReviewerID:    approval.ReviewerID,
+		Amount:        approval.Amount,
+		Status:        string(approval.Status),
+		ReviewedBy:    approval.ReviewedBy,
+		Comment:       approval.Comment,
+		RequestedAt:   approval.RequestedAt,
+		ExpiresAt:     approval.ExpiresAt,
+	}
+	if !approval.ReviewedAt.IsZero() {
+		reviewedAt := approval.ReviewedAt
+		response.ReviewedAt = &reviewedAt
+	}
+	return response
+}
+
+// ListApprovals lists the expenses of the wallet waiting for review.
+func (h *ApprovalHandler) ListApprovals() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		approvals, err := h.approvalService.ListApprovals(c.Param("id"), actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]ApprovalResponse, 0, len(approvals))
+		for _, approval := range approvals {
+			response = append(response, mapApprovalResponse(approval))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ApproveExpense approves a held expense, which then goes through; the
+// comment is optional.
+func (h *ApprovalHandler) ApproveExpense() gin.HandlerFunc {
+	return h.review(h.approvalService.ApproveExpense)
+}
+
+// RejectExpense rejects a held expense, which is deleted; a comment is
+// required.
+func (h *ApprovalHandler) RejectExpense() gin.HandlerFunc {
+	return h.review(h.approvalService.RejectExpense)
+}
+
+func (h *ApprovalHandler) review(
+	decide func(walletID, actorID, approvalID, comment string) (*entities.Approval, error),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto ReviewApprovalRequest
+		if err := c.ShouldBindJSON(&dto); err != nil && !errors.Is(err, io.EOF) {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		approval, err := decide(c.Param("id"), actorID, c.Param("approvalId"), dto.Comment)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapApprovalResponse(approval))
+	}
+}
+
+func NewApprovalHandler(
+	approvalService services.ApprovalService,
+	log logger.Logger,
+) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+		log:             log,
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+// maxUploadRequestSize leaves room for the multipart framing and the other
+// form fields around the largest file accepted.
+const maxUploadRequestSize = entities.MaxAttachmentSize + 1<<20
+
+type AttachmentHandler struct {
+	attachmentService services.AttachmentService
+	log               logger.Logger
+}
+
+type AttachmentResponse struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	Checksum      string    `json:"checksum"`
+	HasThumbnail  bool      `json:"has_thumbnail"`
+	UploadedBy    string    `json:"uploaded_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AttachmentDownloadResponse struct {
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func mapAttachmentResponse(attachment *entities.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:            attachment.ID,
+		TransactionID: attachment.TransactionID,
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		Checksum:      attachment.Checksum,
+		HasThumbnail:  attachment.HasThumbnail(),
+		UploadedBy:    attachment.UploadedBy,
+		CreatedAt:     attachment.CreatedAt,
+	}
+}
+
+// UploadAttachment takes a multipart form with the file in "file". The caller
+// is recorded as the uploader.
+func (h *AttachmentHandler) UploadAttachment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadRequestSize)
+
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "File is required and must be at most 10 MB").
+				AddContext("field", "file"))
+			c.Abort()
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "File could not be read").
+				AddContext("field", "file"))
+			c.Abort()
+			return
+		}
+		defer file.Close()
+
+		attachment, err := h.attachmentService.UploadAttachment(c.Param("id"), actorID, c.Param("transactionId"), services.AttachmentInput{
+			FileName: fileHeader.Filename,
+			Content:  file,
+		})
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapAttachmentResponse(attachment))
+	}
+}
+
+func (h *AttachmentHandler) ListAttachments() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		attachments, err := h.attachmentService.ListAttachments(c.Param("id"), actorID, c.Param("transactionId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]AttachmentResponse, 0, len(attachments))
+		for _, attachment := range attachments {
+			response = append(response, mapAttachmentResponse(attachment))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *AttachmentHandler) GetAttachment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		attachment, err := h.attachmentService.GetAttachment(c.Param("id"), actorID, c.Param("transactionId"), c.Param("attachmentId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapAttachmentResponse(attachment))
+	}
+}
+
+// DownloadAttachment returns short-lived signed links to the file and its
+// thumbnail.
+func (h *AttachmentHandler) DownloadAttachment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		download, err := h.attachmentService.DownloadAttachment(c.Param("id"), actorID, c.Param("transactionId"), c.Param("attachmentId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, AttachmentDownloadResponse{
+			URL:          download.URL,
+			ThumbnailURL: download.ThumbnailURL,
+			ExpiresAt:    download.ExpiresAt,
+		})
+	}
+}
+
+func (h *AttachmentHandler) DeleteAttachment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.attachmentService.DeleteAttachment(c.Param("id"), actorID, c.Param("transactionId"), c.Param("attachmentId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewAttachmentHandler(
+	attachmentService services.AttachmentService,
+	log logger.Logger,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		log:               log,
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type BalanceHandler struct {
+	balanceService services.BalanceService
+	log            logger.Logger
+}
+
+type MemberBalanceResponse struct {
+	UserID string      `json:"user_id"`
+	Net    money.Money `json:"net"`
+}
+
+// WalletBalanceResponse is the wallet balance in one currency.
+type WalletBalanceResponse struct {
+	Current         money.Money          `json:"current"`
+	Available       money.Money          `json:"available"`
+	Projected       money.Money          `json:"projected"`
+	PendingApproval money.Money          `json:"pending_approval"`
+	Conversions     []ConversionResponse `json:"conversions,omitempty"`
+}
+
+// BalanceAsOfResponse is the current balance of the wallet in one currency
+// at the end of a past day.
+type BalanceAsOfResponse struct {
+	AsOf        string               `json:"as_of"`
+	Balance     money.Money          `json:"balance"`
+	Conversions []ConversionResponse `json:"conversions,omitempty"`
+}
+
+type DebtResponse struct {
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Amount money.Money `json:"amount"`
+}
+
+type BalancesResponse struct {
+	Wallet  []WalletBalanceResponse `json:"wallet"`
+	Members []MemberBalanceResponse `json:"members"`
+	Debts   []DebtResponse          `json:"debts"`
+}
+
+func mapDebtResponse(debt *entities.Debt) DebtResponse {
+	return DebtResponse{
+		From:   debt.DebtorID,
+		To:     debt.CreditorID,
+		Amount: debt.Amount,
+	}
+}
+
+func mapBalancesResponse(wallet []*entities.WalletBalance, balances *entities.SharedBalances) BalancesResponse {
+	response := BalancesResponse{
+		Wallet:  make([]WalletBalanceResponse, 0, len(wallet)),
+		Members: make([]MemberBalanceResponse, 0, len(balances.Members)),
+		Debts:   make([]DebtResponse, 0, len(balances.Debts)),
+	}
+	for _, balance := range wallet {
+		response.Wallet = append(response.Wallet, WalletBalanceResponse{
+			Current:         balance.Current,
+			Available:       balance.Available,
+			Projected:       balance.Projected,
+			PendingApproval: balance.PendingApproval,
+			Conversions:     mapConversionResponses(balance.Conversions),
+		})
+	}
+	for _, member := range balances.Members {
+		response.Members = append(response.Members, MemberBalanceResponse{
+			UserID: member.UserID,
+			Net:    member.Net,
+		})
+	}
+	for _, debt := range balances.Debts {
+		response.Debts = append(response.Debts, mapDebtResponse(debt))
+	}
+	return response
+}
+
+// GetBalances shows the wallet balance in each currency, or converted into
+// ?currency= or else the base currency of the caller, along with where its
+// members stand on shared expenses.
+func (h *BalanceHandler) GetBalances() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		wallet, err := h.balanceService.GetWalletBalances(c.Param("id"), actorID, c.Query("currency"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		balances, err := h.balanceService.GetBalances(c.Param("id"), actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapBalancesResponse(wallet, balances))
+	}
+}
+
+// GetBalancesAsOf shows the current balance of the wallet at the end of
+// ?date=, in each currency or converted like GetBalances.
+func (h *BalanceHandler) GetBalancesAsOf() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		date, appErr := parseDateQuery(c, "date")
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+		if date.IsZero() {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Date is required").
+				AddContext("field", "date"))
+			c.Abort()
+			return
+		}
+
+		balances, err := h.balanceService.GetBalancesAsOf(c.Param("id"), actorID, date, c.Query("currency"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]BalanceAsOfResponse, 0, len(balances))
+		for _, balance := range balances {
+			response = append(response, BalanceAsOfResponse{
+				AsOf:        balance.AsOf.Format(transactionDateLayout),
+				Balance:     balance.Balance,
+				Conversions: mapConversionResponses(balance.Conversions),
+			})
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func NewBalanceHandler(
+	balanceService services.BalanceService,
+	log logger.Logger,
+) *BalanceHandler {
+	return &BalanceHandler{
+		balanceService: balanceService,
+		log:            log,
+	}
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type CategoryHandler struct {
+	categoryService services.CategoryService
+	log             logger.Logger
+}
+
+type CategoryRequest struct {
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Color    string `json:"color"`
+	ParentID string `json:"parent_id"`
+}
+
+func (r *CategoryRequest) Validate() *apperror.AppError {
+	if r.Name == "" {
+		return apperror.New(apperror.ErrorTypeValidation, "Name is required").
+			AddContext("field", "name")
+	}
+
+	return nil
+}
+
+func (r *CategoryRequest) input() services.CategoryInput {
+	return services.CategoryInput{
+		Name:     r.Name,
+		Icon:     r.Icon,
+		Color:    r.Color,
+		ParentID: r.ParentID,
+	}
+}
+
+type CreateCategoryRequest struct {
+	CategoryRequest
+	Type string `json:"type"`
+}
+
+func (r *CreateCategoryRequest) Validate() *apperror.AppError {
+	if _, err := entities.NewTransactionType(r.Type); err != nil {
+		return apperror.New(apperror.ErrorTypeValidation, "Type must be INCOME or EXPENSE").
+			AddContext("field", "type")
+	}
+
+	return r.CategoryRequest.Validate()
+}
+
+type MergeCategoryRequest struct {
+	TargetID string `json:"target_id"`
+}
+
+type CategoryResponse struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parent_id,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Icon     string `json:"icon"`
+	Color    string `json:"color"`
+}
+
+func mapCategoryResponse(category *entities.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:       category.ID,
+		ParentID: category.ParentID,
+		Name:     category.Name,
+		Type:     string(category.Type),
+		Icon:     category.Icon,
+		Color:    category.Color,
+	}
+}
+
+func (h *CategoryHandler) CreateCategory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto CreateCategoryRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		categoryType, _ := entities.NewTransactionType(dto.Type)
+		category, err := h.categoryService.CreateCategory(userID, categoryType, dto.input())
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapCategoryResponse(category))
+	}
+}
+
+// ListCategories lists a page of the user categories, filtered by ?q= (part
+// of the name) and a comma-separated ?types=, and sorted by ?sort= (type,
+// name or created_at, with a leading "-" for descending). The X-Next-Cursor
+// header holds the ?cursor= of the next page.
+func (h *CategoryHandler) ListCategories() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		filter := repositories.CategoryFilter{Query: c.Query("q")}
+
+		if filter.Types, appErr = parseFilterTypes(parseListQuery(c, "types"), "types"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.CategorySorts, repositories.DefaultCategorySort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		categories, next, err := h.categoryService.ListCategories(userID, filter, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]CategoryResponse, 0, len(categories))
+		for _, category := range categories {
+			response = append(response, mapCategoryResponse(category))
+		}
+
+		setNextCursor(c, next)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *CategoryHandler) UpdateCategory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto CategoryRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		category, err := h.categoryService.UpdateCategory(userID, c.Param("categoryId"), dto.input())
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapCategoryResponse(category))
+	}
+}
+
+func (h *CategoryHandler) MergeCategory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto MergeCategoryRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if dto.TargetID == "" {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Target category is required").
+				AddContext("field", "target_id"))
+			c.Abort()
+			return
+		}
+
+		if err := h.categoryService.MergeCategories(userID, c.Param("categoryId"), dto.TargetID); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteCategory deletes an unused category. Passing ?reassign_to= moves its
+// transactions and children to that category first.
+func (h *CategoryHandler) DeleteCategory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		err := h.categoryService.DeleteCategory(userID, c.Param("categoryId"), c.Query("reassign_to"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewCategoryHandler(
+	categoryService services.CategoryService,
+	log logger.Logger,
+) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+		log:             log,
+	}
+}
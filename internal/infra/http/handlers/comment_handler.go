@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type CommentHandler struct {
+	commentService services.CommentService
+	log            logger.Logger
+}
+
+// CreateCommentRequest posts a comment, or a reply to parent_id. Members
+// are mentioned as @ followed by their user ID.
+type CreateCommentRequest struct {
+	ParentID string `json:"parent_id"`
+	Body     string `json:"body"`
+}
+
+func (r *CreateCommentRequest) Validate() *apperror.AppError {
+	if r.Body == "" {
+		return apperror.New(apperror.ErrorTypeValidation, "Body is required").
+			AddContext("field", "body")
+	}
+
+	return nil
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentResponse hides the body of a deleted comment, which is only listed
+// to keep the thread of its replies.
+type CommentResponse struct {
+	ID            string     `json:"id"`
+	TransactionID string     `json:"transaction_id"`
+	ParentID      string     `json:"parent_id,omitempty"`
+	AuthorID      string     `json:"author_id"`
+	Body          string     `json:"body,omitempty"`
+	Mentions      []string   `json:"mentions"`
+	EditedAt      *time.Time `json:"edited_at,omitempty"`
+	Deleted       bool       `json:"deleted"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type CommentEditResponse struct {
+	Body     string    `json:"body"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+func mapCommentResponse(comment *entities.Comment) CommentResponse {
+	response := CommentResponse{
+		ID:            comment.ID,
+		TransactionID: comment.TransactionID,
+		ParentID:      comment.ParentID,
+		AuthorID:      comment.AuthorID,
+		Mentions:      []string{},
+		Deleted:       comment.IsDeleted,
+		CreatedAt:     comment.CreatedAt,
+	}
+	if comment.IsDeleted {
+		return response
+	}
+
+	response.Body = comment.Body
+	response.Mentions = comment.Mentions()
+	if comment.IsEdited() {
+		response.EditedAt = &comment.EditedAt
+	}
+	return response
+}
+
+func (h *CommentHandler) CreateComment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto CreateCommentRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		comment, err := h.commentService.CreateComment(c.Param("id"), actorID, c.Param("transactionId"), services.CommentInput{
+			ParentID: dto.ParentID,
+			Body:     dto.Body,
+		})
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapCommentResponse(comment))
+	}
+}
+
+// ListComments lists the comments of the transaction oldest first; replies
+// point to their parent so clients can build the threads.
+func (h *CommentHandler) ListComments() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		comments, err := h.commentService.ListComments(c.Param("id"), actorID, c.Param("transactionId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]CommentResponse, 0, len(comments))
+		for _, comment := range comments {
+			response = append(response, mapCommentResponse(comment))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *CommentHandler) UpdateComment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto UpdateCommentRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		comment, err := h.commentService.UpdateComment(c.Param("id"), actorID, c.Param("transactionId"), c.Param("commentId"), dto.Body)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapCommentResponse(comment))
+	}
+}
+
+func (h *CommentHandler) DeleteComment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.commentService.DeleteComment(c.Param("id"), actorID, c.Param("transactionId"), c.Param("commentId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ListCommentEdits lists the previous bodies of a comment, oldest first.
+func (h *CommentHandler) ListCommentEdits() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		edits, err := h.commentService.ListCommentEdits(c.Param("id"), actorID, c.Param("transactionId"), c.Param("commentId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]CommentEditResponse, 0, len(edits))
+		for _, edit := range edits {
+			response = append(response, CommentEditResponse{Body: edit.Body, EditedAt: edit.EditedAt})
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func NewCommentHandler(
+	commentService services.CommentService,
+	log logger.Logger,
+) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		log:            log,
+	}
+}
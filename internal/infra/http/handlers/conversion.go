@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// exchangeRateDecimals matches the precision exchange rates are stored with.
+const exchangeRateDecimals = 10
+
+// ConversionResponse shows how an amount was converted into the requested
+// currency.
+type ConversionResponse struct {
+	Original  money.Money `json:"original"`
+	Converted money.Money `json:"converted"`
+	Rate      string      `json:"rate"`
+	RateDate  string      `json:"rate_date"`
+	Source    string      `json:"source,omitempty"`
+}
+
+func mapConversionResponses(conversions []*entities.Conversion) []ConversionResponse {
+	if conversions == nil {
+		return nil
+	}
+
+	response := make([]ConversionResponse, 0, len(conversions))
+	for _, conversion := range conversions {
+		response = append(response, ConversionResponse{
+			Original:  conversion.Original,
+			Converted: conversion.Converted,
+			Rate:      conversion.Rate.FloatString(exchangeRateDecimals),
+			RateDate:  conversion.RateDate.Format(transactionDateLayout),
+			Source:    conversion.Source,
+		})
+	}
+	return response
+}
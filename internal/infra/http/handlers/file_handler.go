@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/storage"
+)
+
+// FileHandler serves the signed download links of storage drivers that do
+// not serve files themselves, such as the local filesystem.
+type FileHandler struct {
+	storage storage.Storage
+	log     logger.Logger
+}
+
+var errFileNotFound = apperror.New(apperror.ErrorTypeNotFound, "File not found")
+
+func (h *FileHandler) ServeFile() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		verifier, ok := h.storage.(storage.URLVerifier)
+		if !ok {
+			c.Error(errFileNotFound)
+			c.Abort()
+			return
+		}
+
+		key := strings.TrimPrefix(c.Param("key"), "/")
+		if err := verifier.VerifySignedURL(key, c.Query("expires"), c.Query("signature")); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeForbidden, "Download link is invalid or expired"))
+			c.Abort()
+			return
+		}
+
+		file, err := h.storage.Get(key)
+		if errors.Is(err, storage.ErrNotFound) {
+			c.Error(errFileNotFound)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			h.log.Error(err, "Failed to read file", map[string]interface{}{
+				"key": key,
+			})
+			c.Error(apperror.Wrap(apperror.ErrorTypeInternal, err))
+			c.Abort()
+			return
+		}
+		defer file.Close()
+
+		content, err := storage.ReadLimited(file, maxUploadRequestSize)
+		if err != nil {
+			h.log.Error(err, "Failed to read file", map[string]interface{}{
+				"key": key,
+			})
+			c.Error(apperror.Wrap(apperror.ErrorTypeInternal, err))
+			c.Abort()
+			return
+		}
+
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Header("Cache-Control", "private, no-store")
+		c.Data(http.StatusOK, storage.DetectContentType(content), content)
+	}
+}
+
+func NewFileHandler(
+	storage storage.Storage,
+	log logger.Logger,
+) *FileHandler {
+	return &FileHandler{
+		storage: storage,
+		log:     log,
+	}
+}
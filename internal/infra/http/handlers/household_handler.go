@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type HouseholdHandler struct {
+	householdService services.HouseholdService
+	log              logger.Logger
+}
+
+type HouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *HouseholdRequest) Validate() *apperror.AppError {
+	if r.Name == "" {
+		return apperror.New(apperror.ErrorTypeValidation, "Name is required").
+			AddContext("field", "name")
+	}
+
+	return nil
+}
+
+type HouseholdRoleRequest struct {
+	Role string `json:"role"`
+}
+
+func (r *HouseholdRoleRequest) Validate() (entities.HouseholdRole, *apperror.AppError) {
+	role, err := entities.NewHouseholdRole(r.Role)
+	if err != nil {
+		return "", apperror.New(apperror.ErrorTypeValidation, "Role must be OWNER, ADMIN or MEMBER").
+			AddContext("field", "role")
+	}
+
+	return role, nil
+}
+
+type HouseholdMemberRequest struct {
+	HouseholdRoleRequest
+	Email string `json:"email"`
+}
+
+func (r *HouseholdMemberRequest) Validate() (entities.HouseholdRole, *apperror.AppError) {
+	if r.Email == "" {
+		return "", apperror.New(apperror.ErrorTypeValidation, "Email is required").
+			AddContext("field", "email")
+	}
+
+	return r.HouseholdRoleRequest.Validate()
+}
+
+type HouseholdMemberResponse struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type HouseholdResponse struct {
+	ID        string                    `json:"id"`
+	Name      string                    `json:"name"`
+	Members   []HouseholdMemberResponse `json:"members"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+func mapHouseholdMemberResponse(member *entities.HouseholdMember) HouseholdMemberResponse {
+	return HouseholdMemberResponse{
+		UserID:   member.UserID,
+		Role:     string(member.Role),
+		JoinedAt: member.JoinedAt,
+	}
+}
+
+func mapHouseholdResponse(household *entities.Household) HouseholdResponse {
+	members := make([]HouseholdMemberResponse, 0, len(household.Members))
+	for _, member := range household.Members {
+		members = append(members, mapHouseholdMemberResponse(member))
+	}
+
+	return HouseholdResponse{
+		ID:        household.ID,
+		Name:      household.Name,
+		Members:   members,
+		CreatedAt: household.CreatedAt,
+		UpdatedAt: household.UpdatedAt,
+	}
+}
+
+// CreateHousehold creates a household owned by the caller.
+func (h *HouseholdHandler) CreateHousehold() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto HouseholdRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		household, err := h.householdService.CreateHousehold(dto.Name, actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapHouseholdResponse(household))
+	}
+}
+
+func (h *HouseholdHandler) GetHousehold() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		household, err := h.householdService.GetHousehold(c.Param("id"), actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapHouseholdResponse(household))
+	}
+}
+
+func (h *HouseholdHandler) AddMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto HouseholdMemberRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		role, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		member, err := h.householdService.AddMember(c.Param("id"), actorID, dto.Email, role)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapHouseholdMemberResponse(member))
+	}
+}
+
+func (h *HouseholdHandler) ChangeMemberRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto HouseholdRoleRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		role, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.householdService.ChangeMemberRole(c.Param("id"), actorID, c.Param("userId"), role); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *HouseholdHandler) RemoveMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.householdService.RemoveMember(c.Param("id"), actorID, c.Param("userId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewHouseholdHandler(
+	householdService services.HouseholdService,
+	log logger.Logger,
+) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdService: householdService,
+		log:              log,
+	}
+}
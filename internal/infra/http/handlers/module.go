@@ -4,4 +4,24 @@ import "go.uber.org/fx"
 
 var Module = fx.Provide(
 	NewUserHandler,
+	NewTransactionHandler,
+	NewCategoryHandler,
+	NewTagHandler,
+	NewTransferHandler,
+	NewRecurringTransactionHandler,
+	NewReportHandler,
+	NewBalanceHandler,
+	NewSettlementHandler,
+	NewAttachmentHandler,
+	NewFileHandler,
+	NewPayeeHandler,
+	NewRuleHandler,
+	NewWalletHandler,
+	NewHouseholdHandler,
+	NewApprovalHandler,
+	NewReconciliationHandler,
+	NewSpendingLimitHandler,
+	NewNotificationHandler,
+	NewActivityHandler,
+	NewCommentHandler,
 )
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+// unreadCountHeader carries how many items of a list, such as the user
+// notifications or a wallet feed, are unread.
+const unreadCountHeader = "X-Unread-Count"
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+	log                 logger.Logger
+}
+
+// MarkNotificationsReadRequest marks the notifications in IDs read, or all
+// of them when it is empty.
+type MarkNotificationsReadRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type NotificationResponse struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	WalletID  string                 `json:"wallet_id,omitempty"`
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data"`
+	Read      bool                   `json:"read"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+func mapNotificationResponse(notification *entities.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        notification.ID,
+		Type:      string(notification.Type),
+		WalletID:  notification.WalletID,
+		Message:   notification.Message,
+		Data:      notification.Data,
+		Read:      notification.IsRead(),
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// ListNotifications lists a page of the user notifications, newest first,
+// optionally only the read or unread ones with ?read=. The X-Unread-Count
+// header holds how many are unread and X-Next-Cursor the ?cursor= of the
+// next page.
+func (h *NotificationHandler) ListNotifications() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		read, appErr := parseBoolQuery(c, "read")
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.NotificationSorts, repositories.DefaultNotificationSort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		notifications, next, unread, err := h.notificationService.ListNotifications(userID, repositories.NotificationFilter{Read: read}, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]NotificationResponse, 0, len(notifications))
+		for _, notification := range notifications {
+			response = append(response, mapNotificationResponse(notification))
+		}
+
+		setNextCursor(c, next)
+		c.Header(unreadCountHeader, strconv.Itoa(unread))
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *NotificationHandler) MarkNotificationsRead() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto MarkNotificationsReadRequest
+		if err := c.ShouldBindJSON(&dto); err != nil && !errors.Is(err, io.EOF) {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if appErr := checkIDs(dto.IDs, "ids"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.notificationService.MarkNotificationsRead(userID, dto.IDs); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewNotificationHandler(
+	notificationService services.NotificationService,
+	log logger.Logger,
+) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		log:                 log,
+	}
+}
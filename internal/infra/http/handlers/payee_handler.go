@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type PayeeHandler struct {
+	payeeService services.PayeeService
+	log          logger.Logger
+}
+
+type PayeeRequest struct {
+	Name              string `json:"name"`
+	DefaultCategoryID string `json:"default_category_id"`
+}
+
+func (r *PayeeRequest) Validate() *apperror.AppError {
+	if r.Name == "" {
+		return apperror.New(apperror.ErrorTypeValidation, "Name is required").
+			AddContext("field", "name")
+	}
+
+	return nil
+}
+
+func (r *PayeeRequest) input() services.PayeeInput {
+	return services.PayeeInput{
+		Name:              r.Name,
+		DefaultCategoryID: r.DefaultCategoryID,
+	}
+}
+
+type MergePayeeRequest struct {
+	TargetID string `json:"target_id"`
+}
+
+type PayeeResponse struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	DefaultCategoryID string   `json:"default_category_id,omitempty"`
+	Aliases           []string `json:"aliases"`
+}
+
+func mapPayeeResponse(payee *entities.Payee) PayeeResponse {
+	aliases := payee.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return PayeeResponse{
+		ID:                payee.ID,
+		Name:              payee.Name,
+		DefaultCategoryID: payee.DefaultCategoryID,
+		Aliases:           aliases,
+	}
+}
+
+func (h *PayeeHandler) CreatePayee() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto PayeeRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		payee, err := h.payeeService.CreatePayee(userID, dto.input())
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapPayeeResponse(payee))
+	}
+}
+
+// ListPayees lists a page of the user payees, filtered by ?q= (part of the
+// name or of an alias) and a comma-separated ?categories= of default
+// categories, and sorted by ?sort= (name or created_at, with a leading "-"
+// for descending). The X-Next-Cursor header holds the ?cursor= of the next
+// page.
+func (h *PayeeHandler) ListPayees() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		filter := repositories.PayeeFilter{Query: c.Query("q")}
+		if filter.CategoryIDs, appErr = parseIDListQuery(c, "categories"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.PayeeSorts, repositories.DefaultPayeeSort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		payees, next, err := h.payeeService.ListPayees(userID, filter, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]PayeeResponse, 0, len(payees))
+		for _, payee := range payees {
+			response = append(response, mapPayeeResponse(payee))
+		}
+
+		setNextCursor(c, next)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// SuggestPayee returns the payee matching ?description=, with the default
+// category a new transaction of that payee would get.
+func (h *PayeeHandler) SuggestPayee() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		description := c.Query("description")
+		if description == "" {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Description is required").
+				AddContext("field", "description"))
+			c.Abort()
+			return
+		}
+
+		payee, err := h.payeeService.SuggestPayee(userID, description)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapPayeeResponse(payee))
+	}
+}
+
+func (h *PayeeHandler) UpdatePayee() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto PayeeRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		payee, err := h.payeeService.UpdatePayee(userID, c.Param("payeeId"), dto.input())
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapPayeeResponse(payee))
+	}
+}
+
+func (h *PayeeHandler) MergePayee() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto MergePayeeRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if dto.TargetID == "" {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Target payee is required").
+				AddContext("field", "target_id"))
+			c.Abort()
+			return
+		}
+
+		if err := h.payeeService.MergePayees(userID, c.Param("payeeId"), dto.TargetID); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *PayeeHandler) DeletePayee() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.payeeService.DeletePayee(userID, c.Param("payeeId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewPayeeHandler(
+	payeeService services.PayeeService,
+	log logger.Logger,
+) *PayeeHandler {
+	return &PayeeHandler{
+		payeeService: payeeService,
+		log:          log,
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/query"
+)
+
+// nextCursorHeader carries the cursor of the next page of a list; it is
+// absent on the last page.
+const nextCursorHeader = "X-Next-Cursor"
+
+// parsePage reads ?sort=, ?cursor= and ?limit= for a list sorted on sorts.
+// Sorts are written "field" or "-field" for descending order.
+func parsePage(c *gin.Context, sorts query.Sorts, fallback query.Sort) (query.Page, *apperror.AppError) {
+	sort, err := sorts.ParseSort(c.Query("sort"), fallback)
+	if err != nil {
+		return query.Page{}, apperror.New(apperror.ErrorTypeValidation, "Unknown sort field").
+			AddContext("field", "sort")
+	}
+
+	var after *query.Cursor
+	if value := c.Query("cursor"); value != "" {
+		if after, err = query.DecodeCursor(value); err != nil {
+			return query.Page{}, invalidCursor()
+		}
+	}
+
+	limit, err := query.ParseLimit(c.Query("limit"))
+	if err != nil {
+		return query.Page{}, apperror.New(apperror.ErrorTypeValidation, fmt.Sprintf("Limit must be between 1 and %d", query.MaxLimit)).
+			AddContext("field", "limit")
+	}
+
+	page, err := sorts.NewPage(sort, after, limit)
+	if errors.Is(err, query.ErrInvalidCursor) {
+		return query.Page{}, invalidCursor()
+	}
+	if err != nil {
+		return query.Page{}, apperror.Wrap(apperror.ErrorTypeValidation, err)
+	}
+
+	return page, nil
+}
+
+func invalidCursor() *apperror.AppError {
+	return apperror.New(apperror.ErrorTypeValidation, "Cursor is invalid or was made for another sort").
+		AddContext("field", "cursor")
+}
+
+// setNextCursor tells the client where the next page starts.
+func setNextCursor(c *gin.Context, next *query.Cursor) {
+	if next != nil {
+		c.Header(nextCursorHeader, next.Encode())
+	}
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query parameter.
+func parseDateQuery(c *gin.Context, name string) (time.Time, *apperror.AppError) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(transactionDateLayout, value)
+	if err != nil {
+		return time.Time{}, apperror.New(apperror.ErrorTypeValidation, fmt.Sprintf("%s must be formatted as YYYY-MM-DD", capitalize(name))).
+			AddContext("field", name)
+	}
+	return parsed, nil
+}
+
+// parseBoolQuery reads an optional true or false query parameter.
+func parseBoolQuery(c *gin.Context, name string) (*bool, *apperror.AppError) {
+	var value bool
+	switch c.Query(name) {
+	case "":
+		return nil, nil
+	case "true":
+		value = true
+	case "false":
+	default:
+		return nil, apperror.New(apperror.ErrorTypeValidation, fmt.Sprintf("%s must be true or false", capitalize(name))).
+			AddContext("field", name)
+	}
+	return &value, nil
+}
+
+// parseListQuery reads a comma-separated query parameter, ignoring empty
+// items.
+func parseListQuery(c *gin.Context, name string) []string {
+	value := c.Query(name)
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseIDListQuery reads a comma-separated query parameter of IDs.
+func parseIDListQuery(c *gin.Context, name string) ([]string, *apperror.AppError) {
+	ids := parseListQuery(c, name)
+	if appErr := checkIDs(ids, name); appErr != nil {
+		return nil, appErr
+	}
+	return ids, nil
+}
+
+// checkIDs makes sure every item of a list of IDs is a UUID, which the
+// database would otherwise fail to compare them with.
+func checkIDs(ids []string, field string) *apperror.AppError {
+	for _, id := range ids {
+		if uuid.Validate(id) != nil {
+			return apperror.New(apperror.ErrorTypeValidation, fmt.Sprintf("%s must be a list of IDs", capitalize(field))).
+				AddContext("field", field).
+				AddContext("id", id)
+		}
+	}
+	return nil
+}
+
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + strings.ReplaceAll(value[1:], "_", " ")
+}
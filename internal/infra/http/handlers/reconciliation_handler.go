@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService services.ReconciliationService
+	log                   logger.Logger
+}
+
+type StartReconciliationRequest struct {
+	StatementDate    string      `json:"statement_date"`
+	StatementBalance money.Money `json:"statement_balance"`
+}
+
+func (r *StartReconciliationRequest) Validate() (time.Time, *apperror.AppError) {
+	if r.StatementDate == "" {
+		return time.Time{}, apperror.New(apperror.ErrorTypeValidation, "Statement date is required").
+			AddContext("field", "statement_date")
+	}
+
+	date, err := time.Parse(transactionDateLayout, r.StatementDate)
+	if err != nil {
+		return time.Time{}, apperror.New(apperror.ErrorTypeValidation, "Statement date must be formatted as YYYY-MM-DD").
+			AddContext("field", "statement_date")
+	}
+
+	if r.StatementBalance.Currency().Code == "" {
+		return time.Time{}, apperror.New(apperror.ErrorTypeValidation, "Statement balance is required").
+			AddContext("field", "statement_balance")
+	}
+
+	return date, nil
+}
+
+// ClearTransactionsRequest ticks off the transactions in Tick and takes
+// those in Untick off again.
+type ClearTransactionsRequest struct {
+	Tick   []string `json:"tick"`
+	Untick []string `json:"untick"`
+}
+
+type ReconciliationResponse struct {
+	ID               string      `json:"id"`
+	WalletID         string      `json:"wallet_id"`
+	UserID           string      `json:"user_id"`
+	StatementDate    string      `json:"statement_date"`
+	StatementBalance money.Money `json:"statement_balance"`
+	OpeningBalance   money.Money `json:"opening_balance"`
+	Cleared          money.Money `json:"cleared"`
+	Difference       money.Money `json:"difference"`
+	ClearedIDs       []string    `json:"cleared_transaction_ids"`
+	Status           string      `json:"status"`
+	CreatedAt        time.Time   `json:"created_at"`
+	CompletedAt      *time.Time  `json:"completed_at"`
+}
+
+func mapReconciliationResponse(reconciliation *entities.Reconciliation) (ReconciliationResponse, error) {
+	difference, err := reconciliation.Difference()
+	if err != nil {
+		return ReconciliationResponse{}, err
+	}
+
+	response := ReconciliationResponse{
+		ID:               reconciliation.ID,
+		WalletID:         reconciliation.WalletID,
+		UserID:           reconciliation.UserID,
+		StatementDate:    reconciliation.StatementDate.Format(transactionDateLayout),
+		StatementBalance: reconciliation.StatementBalance,
+		OpeningBalance:   reconciliation.OpeningBalance,
+		Cleared:          reconciliation.Cleared,
+		Difference:       difference,
+		ClearedIDs:       reconciliation.ClearedIDs,
+		Status:           string(reconciliation.Status),
+		CreatedAt:        reconciliation.CreatedAt,
+	}
+	if !reconciliation.CompletedAt.IsZero() {
+		completedAt := reconciliation.CompletedAt
+		response.CompletedAt = &completedAt
+	}
+	return response, nil
+}
+
+// respond writes the session, with what is left to account for.
+func (h *ReconciliationHandler) respond(c *gin.Context, status int, reconciliation *entities.Reconciliation) {
+	response, err := mapReconciliationResponse(reconciliation)
+	if err != nil {
+		c.Error(apperror.Wrap(apperror.ErrorTypeInternal, err))
+		c.Abort()
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// StartReconciliation opens a session against a bank statement.
+func (h *ReconciliationHandler) StartReconciliation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto StartReconciliationRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		statementDate, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		reconciliation, err := h.reconciliationService.StartReconciliation(c.Param("id"), actorID, statementDate, dto.StatementBalance)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		h.respond(c, http.StatusCreated, reconciliation)
+	}
+}
+
+func (h *ReconciliationHandler) GetReconciliation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		reconciliation, err := h.reconciliationService.GetReconciliation(c.Param("id"), actorID, c.Param("reconciliationId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		h.respond(c, http.StatusOK, reconciliation)
+	}
+}
+
+// ClearTransactions ticks transactions off, or back on, and shows the
+// difference left.
+func (h *ReconciliationHandler) ClearTransactions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto ClearTransactionsRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		reconciliation, err := h.reconciliationService.ClearTransactions(
+			c.Param("id"),
+			actorID,
+			c.Param("reconciliationId"),
+			dto.Tick,
+			dto.Untick,
+		)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		h.respond(c, http.StatusOK, reconciliation)
+	}
+}
+
+// CompleteReconciliation reconciles the ticked transactions once the
+// difference is zero.
+func (h *ReconciliationHandler) CompleteReconciliation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		reconciliation, err := h.reconciliationService.CompleteReconciliation(c.Param("id"), actorID, c.Param("reconciliationId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		h.respond(c, http.StatusOK, reconciliation)
+	}
+}
+
+func (h *ReconciliationHandler) CancelReconciliation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.reconciliationService.CancelReconciliation(c.Param("id"), actorID, c.Param("reconciliationId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewReconciliationHandler(
+	reconciliationService services.ReconciliationService,
+	log logger.Logger,
+) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+		log:                   log,
+	}
+}
@@ -0,0 +1,419 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const (
+	defaultOccurrencePreview = 10
+	maxOccurrencePreview     = 100
+)
+
+type RecurringTransactionHandler struct {
+	recurringService services.RecurringTransactionService
+	log              logger.Logger
+}
+
+type RecurringTransactionRequest struct {
+	Type        string      `json:"type"`
+	Amount      money.Money `json:"amount"`
+	Description string      `json:"description"`
+	CategoryID  string      `json:"category_id"`
+	Rule        string      `json:"rule"`
+	StartDate   string      `json:"start_date"`
+}
+
+func (r *RecurringTransactionRequest) Validate() (services.RecurringTransactionInput, *apperror.AppError) {
+	transactionType, err := entities.NewTransactionType(r.Type)
+	if err != nil {
+		return services.RecurringTransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Type must be INCOME or EXPENSE").
+			AddContext("field", "type")
+	}
+
+	if r.Amount.Currency().Code == "" {
+		return services.RecurringTransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Amount is required").
+			AddContext("field", "amount")
+	}
+
+	rule, err := entities.ParseRecurrenceRule(r.Rule)
+	if err != nil {
+		return services.RecurringTransactionInput{}, apperror.New(apperror.ErrorTypeValidation, err.Error()).
+			AddContext("field", "rule")
+	}
+
+	startDate, err := time.Parse(transactionDateLayout, r.StartDate)
+	if err != nil {
+		return services.RecurringTransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Start date must be formatted as YYYY-MM-DD").
+			AddContext("field", "start_date")
+	}
+
+	return services.RecurringTransactionInput{
+		Type:        transactionType,
+		Amount:      r.Amount,
+		Description: r.Description,
+		CategoryID:  r.CategoryID,
+		Rule:        rule,
+		StartDate:   startDate,
+	}, nil
+}
+
+type OccurrenceRequest struct {
+	Skip        bool        `json:"skip"`
+	Amount      money.Money `json:"amount"`
+	Description string      `json:"description"`
+	CategoryID  string      `json:"category_id"`
+}
+
+type RecurringTransactionResponse struct {
+	ID                  string      `json:"id"`
+	WalletID            string      `json:"wallet_id"`
+	Type                string      `json:"type"`
+	Amount              money.Money `json:"amount"`
+	Description         string      `json:"description"`
+	CategoryID          string      `json:"category_id,omitempty"`
+	Rule                string      `json:"rule"`
+	StartDate           string      `json:"start_date"`
+	MaterializedThrough *string     `json:"materialized_through"`
+	NextOccurrence      *string     `json:"next_occurrence"`
+	Ended               bool        `json:"ended"`
+	CreatedBy           string      `json:"created_by"`
+	CreatedAt           time.Time   `json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+}
+
+type OccurrenceResponse struct {
+	Date        string      `json:"date"`
+	Amount      money.Money `json:"amount"`
+	Description string      `json:"description"`
+	CategoryID  string      `json:"category_id,omitempty"`
+	Skipped     bool        `json:"skipped"`
+	Modified    bool        `json:"modified"`
+}
+
+func mapRecurringTransactionResponse(recurring *entities.RecurringTransaction) RecurringTransactionResponse {
+	return RecurringTransactionResponse{
+		ID:                  recurring.ID,
+		WalletID:            recurring.WalletID,
+		Type:                string(recurring.Type),
+		Amount:              recurring.Amount,
+		Description:         recurring.Description,
+		CategoryID:          recurring.CategoryID,
+		Rule:                recurring.Rule.String(),
+		StartDate:           recurring.StartDate.Format(transactionDateLayout),
+		MaterializedThrough: formatOptionalDate(recurring.MaterializedThrough),
+		NextOccurrence:      formatOptionalDate(recurring.NextOccurrence),
+		Ended:               recurring.HasEnded(),
+		CreatedBy:           recurring.CreatedBy,
+		CreatedAt:           recurring.CreatedAt,
+		UpdatedAt:           recurring.UpdatedAt,
+	}
+}
+
+func mapOccurrenceResponse(occurrence *entities.Occurrence) OccurrenceResponse {
+	return OccurrenceResponse{
+		Date:        occurrence.Date.Format(transactionDateLayout),
+		Amount:      occurrence.Amount,
+		Description: occurrence.Description,
+		CategoryID:  occurrence.CategoryID,
+		Skipped:     occurrence.Skipped,
+		Modified:    occurrence.Modified,
+	}
+}
+
+func formatOptionalDate(date time.Time) *string {
+	if date.IsZero() {
+		return nil
+	}
+	formatted := date.Format(transactionDateLayout)
+	return &formatted
+}
+
+func parseOccurrenceDate(c *gin.Context) (time.Time, *apperror.AppError) {
+	date, err := time.Parse(transactionDateLayout, c.Param("date"))
+	if err != nil {
+		return time.Time{}, apperror.New(apperror.ErrorTypeValidation, "Date must be formatted as YYYY-MM-DD").
+			AddContext("field", "date")
+	}
+	return date, nil
+}
+
+func (h *RecurringTransactionHandler) CreateRecurringTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto RecurringTransactionRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		recurring, err := h.recurringService.CreateRecurringTransaction(c.Param("id"), actorID, input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapRecurringTransactionResponse(recurring))
+	}
+}
+
+// ListRecurringTransactions lists a page of the wallet schedules, filtered
+// by comma-separated ?types= and ?categories= and by ?currency=, and sorted
+// by ?sort= (next_occurrence, amount or created_at, with a leading "-" for
+// descending). The X-Next-Cursor header holds the ?cursor= of the next page.
+func (h *RecurringTransactionHandler) ListRecurringTransactions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		filter := repositories.RecurringTransactionFilter{Currency: c.Query("currency")}
+		if filter.CategoryIDs, appErr = parseIDListQuery(c, "categories"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+		if filter.Types, appErr = parseFilterTypes(parseListQuery(c, "types"), "types"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.RecurringTransactionSorts, repositories.DefaultRecurringTransactionSort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		recurring, next, err := h.recurringService.ListRecurringTransactions(c.Param("id"), actorID, filter, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]RecurringTransactionResponse, 0, len(recurring))
+		for _, item := range recurring {
+			response = append(response, mapRecurringTransactionResponse(item))
+		}
+
+		setNextCursor(c, next)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *RecurringTransactionHandler) GetRecurringTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		recurring, err := h.recurringService.GetRecurringTransaction(c.Param("id"), actorID, c.Param("recurringId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapRecurringTransactionResponse(recurring))
+	}
+}
+
+func (h *RecurringTransactionHandler) UpdateRecurringTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto RecurringTransactionRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		recurring, err := h.recurringService.UpdateRecurringTransaction(c.Param("id"), actorID, c.Param("recurringId"), input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapRecurringTransactionResponse(recurring))
+	}
+}
+
+func (h *RecurringTransactionHandler) DeleteRecurringTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.recurringService.DeleteRecurringTransaction(c.Param("id"), actorID, c.Param("recurringId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// PreviewOccurrences lists the next ?count= occurrences still to be created.
+func (h *RecurringTransactionHandler) PreviewOccurrences() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		count := defaultOccurrencePreview
+		if value := c.Query("count"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 || parsed > maxOccurrencePreview {
+				c.Error(apperror.New(apperror.ErrorTypeValidation, "Count must be between 1 and 100").
+					AddContext("field", "count"))
+				c.Abort()
+				return
+			}
+			count = parsed
+		}
+
+		occurrences, err := h.recurringService.PreviewOccurrences(c.Param("id"), actorID, c.Param("recurringId"), count)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]OccurrenceResponse, 0, len(occurrences))
+		for _, occurrence := range occurrences {
+			response = append(response, mapOccurrenceResponse(occurrence))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// OverrideOccurrence skips or modifies the occurrence on :date.
+func (h *RecurringTransactionHandler) OverrideOccurrence() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		date, appErr := parseOccurrenceDate(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto OccurrenceRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		occurrence, err := h.recurringService.OverrideOccurrence(c.Param("id"), actorID, c.Param("recurringId"), date, services.OccurrenceInput{
+			Skip:        dto.Skip,
+			Amount:      dto.Amount,
+			Description: dto.Description,
+			CategoryID:  dto.CategoryID,
+		})
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapOccurrenceResponse(occurrence))
+	}
+}
+
+// RestoreOccurrence undoes a skip or modification of the occurrence on :date.
+func (h *RecurringTransactionHandler) RestoreOccurrence() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		date, appErr := parseOccurrenceDate(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.recurringService.RestoreOccurrence(c.Param("id"), actorID, c.Param("recurringId"), date); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewRecurringTransactionHandler(
+	recurringService services.RecurringTransactionService,
+	log logger.Logger,
+) *RecurringTransactionHandler {
+	return &RecurringTransactionHandler{
+		recurringService: recurringService,
+		log:              log,
+	}
+}
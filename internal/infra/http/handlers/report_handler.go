@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type ReportHandler struct {
+	reportService services.ReportService
+	log           logger.Logger
+}
+
+type CategoryTotalResponse struct {
+	CategoryID  string               `json:"category_id"`
+	Type        string               `json:"type"`
+	Total       money.Money          `json:"total"`
+	LineCount   int                  `json:"line_count"`
+	Conversions []ConversionResponse `json:"conversions,omitempty"`
+}
+
+type CategoryReportResponse struct {
+	From   string                  `json:"from"`
+	To     string                  `json:"to"`
+	Totals []CategoryTotalResponse `json:"totals"`
+}
+
+func mapCategoryTotalResponse(total *entities.CategoryTotal) CategoryTotalResponse {
+	return CategoryTotalResponse{
+		CategoryID:  total.CategoryID,
+		Type:        string(total.Type),
+		Total:       total.Total,
+		LineCount:   total.LineCount,
+		Conversions: mapConversionResponses(total.Conversions),
+	}
+}
+
+// parseReportPeriod reads ?from= and ?to=, which default to the first and
+// last day of the current month.
+func parseReportPeriod(c *gin.Context) (time.Time, time.Time, *apperror.AppError) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	parsedFrom, appErr := parseDateQuery(c, "from")
+	if appErr != nil {
+		return time.Time{}, time.Time{}, appErr
+	}
+	if !parsedFrom.IsZero() {
+		from = parsedFrom
+	}
+
+	parsedTo, appErr := parseDateQuery(c, "to")
+	if appErr != nil {
+		return time.Time{}, time.Time{}, appErr
+	}
+	if !parsedTo.IsZero() {
+		to = parsedTo
+	}
+
+	return from, to, nil
+}
+
+// CategoryReport totals the period by category, in each currency or
+// converted into ?currency=, or else into the base currency of the caller.
+func (h *ReportHandler) CategoryReport() gin.HandlerFunc {
+	return h.categoryReport(h.reportService.CategoryReport)
+}
+
+// HouseholdCategoryReport is CategoryReport across every wallet of the
+// household.
+func (h *ReportHandler) HouseholdCategoryReport() gin.HandlerFunc {
+	return h.categoryReport(h.reportService.HouseholdCategoryReport)
+}
+
+// categoryReport serves report for the wallet or household in :id.
+func (h *ReportHandler) categoryReport(
+	report func(id, actorID string, from, to time.Time, currency string) ([]*entities.CategoryTotal, error),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		from, to, appErr := parseReportPeriod(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		totals, err := report(c.Param("id"), actorID, from, to, c.Query("currency"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := CategoryReportResponse{
+			From:   from.Format(transactionDateLayout),
+			To:     to.Format(transactionDateLayout),
+			Totals: make([]CategoryTotalResponse, 0, len(totals)),
+		}
+		for _, total := range totals {
+			response.Totals = append(response.Totals, mapCategoryTotalResponse(total))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func NewReportHandler(
+	reportService services.ReportService,
+	log logger.Logger,
+) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		log:           log,
+	}
+}
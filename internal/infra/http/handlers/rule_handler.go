@@ -0,0 +1,442 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type RuleHandler struct {
+	ruleService services.RuleService
+	log         logger.Logger
+}
+
+type RuleRequest struct {
+	Name       string                `json:"name"`
+	Priority   int                   `json:"priority"`
+	Conditions RuleConditionsRequest `json:"conditions"`
+	Actions    RuleActionsRequest    `json:"actions"`
+}
+
+// RuleConditionsRequest holds the conditions of a rule; those left empty
+// are not checked.
+type RuleConditionsRequest struct {
+	DescriptionPattern string       `json:"description_pattern"`
+	MinAmount          *money.Money `json:"min_amount"`
+	MaxAmount          *money.Money `json:"max_amount"`
+	PayeeID            string       `json:"payee_id"`
+	WalletID           string       `json:"wallet_id"`
+}
+
+type RuleActionsRequest struct {
+	CategoryID  string   `json:"category_id"`
+	TagIDs      []string `json:"tag_ids"`
+	Description string   `json:"description"`
+}
+
+func (r *RuleRequest) Validate() *apperror.AppError {
+	if r.Name == "" {
+		return apperror.New(apperror.ErrorTypeValidation, "Name is required").
+			AddContext("field", "name")
+	}
+
+	if r.Priority < 0 {
+		return apperror.New(apperror.ErrorTypeValidation, "Priority must not be negative").
+			AddContext("field", "priority")
+	}
+
+	return nil
+}
+
+func (r *RuleRequest) input() services.RuleInput {
+	return services.RuleInput{
+		Name:     r.Name,
+		Priority: r.Priority,
+		Conditions: entities.RuleConditions{
+			DescriptionPattern: r.Conditions.DescriptionPattern,
+			MinAmount:          r.Conditions.MinAmount,
+			MaxAmount:          r.Conditions.MaxAmount,
+			PayeeID:            r.Conditions.PayeeID,
+			WalletID:           r.Conditions.WalletID,
+		},
+		Actions: entities.RuleActions{
+			CategoryID:  r.Actions.CategoryID,
+			TagIDs:      r.Actions.TagIDs,
+			Description: r.Actions.Description,
+		},
+	}
+}
+
+// RuleHistoryRequest selects the transactions of a wallet, optionally
+// within a date range, to run rules against.
+type RuleHistoryRequest struct {
+	WalletID string `json:"wallet_id"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+func (r *RuleHistoryRequest) Validate() (services.RuleHistory, *apperror.AppError) {
+	history := services.RuleHistory{WalletID: r.WalletID}
+	if r.WalletID == "" {
+		return history, apperror.New(apperror.ErrorTypeValidation, "Wallet is required").
+			AddContext("field", "wallet_id")
+	}
+
+	dates := []struct {
+		value string
+		field string
+		dest  *time.Time
+	}{
+		{value: r.From, field: "from", dest: &history.From},
+		{value: r.To, field: "to", dest: &history.To},
+	}
+	for _, date := range dates {
+		if date.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(transactionDateLayout, date.value)
+		if err != nil {
+			return history, apperror.New(apperror.ErrorTypeValidation, "Date must be formatted as YYYY-MM-DD").
+				AddContext("field", date.field)
+		}
+		*date.dest = parsed
+	}
+
+	return history, nil
+}
+
+type TestRuleRequest struct {
+	RuleHistoryRequest
+	Rule RuleRequest `json:"rule"`
+}
+
+type ApplyRulesRequest struct {
+	RuleHistoryRequest
+	DryRun bool `json:"dry_run"`
+}
+
+type RuleResponse struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Priority   int                    `json:"priority"`
+	Conditions RuleConditionsResponse `json:"conditions"`
+	Actions    RuleActionsResponse    `json:"actions"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+type RuleConditionsResponse struct {
+	DescriptionPattern string       `json:"description_pattern,omitempty"`
+	MinAmount          *money.Money `json:"min_amount,omitempty"`
+	MaxAmount          *money.Money `json:"max_amount,omitempty"`
+	PayeeID            string       `json:"payee_id,omitempty"`
+	WalletID           string       `json:"wallet_id,omitempty"`
+}
+
+type RuleActionsResponse struct {
+	CategoryID  string   `json:"category_id,omitempty"`
+	TagIDs      []string `json:"tag_ids"`
+	Description string   `json:"description,omitempty"`
+}
+
+func mapRuleResponse(rule *entities.Rule) RuleResponse {
+	tagIDs := rule.Actions.TagIDs
+	if tagIDs == nil {
+		tagIDs = []string{}
+	}
+
+	return RuleResponse{
+		ID:       rule.ID,
+		Name:     rule.Name,
+		Priority: rule.Priority,
+		Conditions: RuleConditionsResponse{
+			DescriptionPattern: rule.Conditions.DescriptionPattern,
+			MinAmount:          rule.Conditions.MinAmount,
+			MaxAmount:          rule.Conditions.MaxAmount,
+			PayeeID:            rule.Conditions.PayeeID,
+			WalletID:           rule.Conditions.WalletID,
+		},
+		Actions: RuleActionsResponse{
+			CategoryID:  rule.Actions.CategoryID,
+			TagIDs:      tagIDs,
+			Description: rule.Actions.Description,
+		},
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}
+}
+
+// RuleMatchResponse shows what rules changed in a transaction.
+type RuleMatchResponse struct {
+	TransactionID string                  `json:"transaction_id"`
+	RuleIDs       []string                `json:"rule_ids"`
+	Before        RuleMatchFieldsResponse `json:"before"`
+	After         RuleMatchFieldsResponse `json:"after"`
+}
+
+// RuleMatchFieldsResponse holds the transaction fields rules can change.
+type RuleMatchFieldsResponse struct {
+	Description string   `json:"description"`
+	CategoryID  string   `json:"category_id,omitempty"`
+	TagIDs      []string `json:"tag_ids"`
+}
+
+func mapRuleMatchFields(transaction *entities.Transaction) RuleMatchFieldsResponse {
+	tagIDs := transaction.TagIDs
+	if tagIDs == nil {
+		tagIDs = []string{}
+	}
+
+	return RuleMatchFieldsResponse{
+		Description: transaction.Description,
+		CategoryID:  transaction.CategoryID,
+		TagIDs:      tagIDs,
+	}
+}
+
+func mapRuleMatchesResponse(matches []*services.RuleMatch) []RuleMatchResponse {
+	response := make([]RuleMatchResponse, 0, len(matches))
+	for _, match := range matches {
+		ruleIDs := make([]string, 0, len(match.Rules))
+		for _, rule := range match.Rules {
+			ruleIDs = append(ruleIDs, rule.ID)
+		}
+
+		response = append(response, RuleMatchResponse{
+			TransactionID: match.After.ID,
+			RuleIDs:       ruleIDs,
+			Before:        mapRuleMatchFields(match.Before),
+			After:         mapRuleMatchFields(match.After),
+		})
+	}
+	return response
+}
+
+func (h *RuleHandler) CreateRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto RuleRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		rule, err := h.ruleService.CreateRule(userID, dto.input())
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapRuleResponse(rule))
+	}
+}
+
+// ListRules lists a page of the user rules, filtered by ?q= (part of the
+// name) and comma-separated ?categories= and ?payees=, and sorted by ?sort=
+// (priority, the order they run, name or created_at, with a leading "-" for
+// descending). The X-Next-Cursor header holds the ?cursor= of the next page.
+func (h *RuleHandler) ListRules() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		filter := repositories.RuleFilter{Query: c.Query("q")}
+		if filter.CategoryIDs, appErr = parseIDListQuery(c, "categories"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+		if filter.PayeeIDs, appErr = parseIDListQuery(c, "payees"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.RuleSorts, repositories.DefaultRuleSort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		rules, next, err := h.ruleService.ListRules(userID, filter, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]RuleResponse, 0, len(rules))
+		for _, rule := range rules {
+			response = append(response, mapRuleResponse(rule))
+		}
+
+		setNextCursor(c, next)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *RuleHandler) UpdateRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto RuleRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		rule, err := h.ruleService.UpdateRule(userID, c.Param("ruleId"), dto.input())
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapRuleResponse(rule))
+	}
+}
+
+func (h *RuleHandler) DeleteRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.ruleService.DeleteRule(userID, c.Param("ruleId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// TestRule reports the transactions of the history an unsaved rule would
+// change. Nothing is saved.
+func (h *RuleHandler) TestRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto TestRuleRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Rule.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		history, appErr := dto.RuleHistoryRequest.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		matches, err := h.ruleService.TestRule(userID, dto.Rule.input(), history)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapRuleMatchesResponse(matches))
+	}
+}
+
+// ApplyRules re-applies the user's rules to the history and reports the
+// transactions they changed. With dry_run nothing is saved.
+func (h *RuleHandler) ApplyRules() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto ApplyRulesRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		history, appErr := dto.RuleHistoryRequest.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		matches, err := h.ruleService.ApplyRules(userID, history, dto.DryRun)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapRuleMatchesResponse(matches))
+	}
+}
+
+func NewRuleHandler(
+	ruleService services.RuleService,
+	log logger.Logger,
+) *RuleHandler {
+	return &RuleHandler{
+		ruleService: ruleService,
+		log:         log,
+	}
+}
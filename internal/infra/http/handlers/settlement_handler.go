@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type SettlementHandler struct {
+	settlementService services.SettlementService
+	log               logger.Logger
+}
+
+type CreateSettlementRequest struct {
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Amount money.Money `json:"amount"`
+	Date   string      `json:"date"`
+	Note   string      `json:"note"`
+}
+
+func (r *CreateSettlementRequest) Validate() (services.SettlementInput, *apperror.AppError) {
+	if r.From == "" {
+		return services.SettlementInput{}, apperror.New(apperror.ErrorTypeValidation, "From is required").
+			AddContext("field", "from")
+	}
+
+	if r.To == "" {
+		return services.SettlementInput{}, apperror.New(apperror.ErrorTypeValidation, "To is required").
+			AddContext("field", "to")
+	}
+
+	if r.Amount.Currency().Code == "" {
+		return services.SettlementInput{}, apperror.New(apperror.ErrorTypeValidation, "Amount is required").
+			AddContext("field", "amount")
+	}
+
+	date, err := time.Parse(transactionDateLayout, r.Date)
+	if err != nil {
+		return services.SettlementInput{}, apperror.New(apperror.ErrorTypeValidation, "Date must be formatted as YYYY-MM-DD").
+			AddContext("field", "date")
+	}
+
+	return services.SettlementInput{
+		FromUserID: r.From,
+		ToUserID:   r.To,
+		Amount:     r.Amount,
+		Date:       date,
+		Note:       r.Note,
+	}, nil
+}
+
+type SettlementResponse struct {
+	ID        string      `json:"id"`
+	WalletID  string      `json:"wallet_id"`
+	From      string      `json:"from"`
+	To        string      `json:"to"`
+	Amount    money.Money `json:"amount"`
+	Date      string      `json:"date"`
+	Note      string      `json:"note"`
+	CreatedBy string      `json:"created_by"`
+	VoidedBy  string      `json:"voided_by,omitempty"`
+	VoidedAt  *time.Time  `json:"voided_at"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func mapSettlementResponse(settlement *entities.Settlement) SettlementResponse {
+	response := SettlementResponse{
+		ID:        settlement.ID,
+		WalletID:  settlement.WalletID,
+		From:      settlement.FromUserID,
+		To:        settlement.ToUserID,
+		Amount:    settlement.Amount,
+		Date:      settlement.Date.Format(transactionDateLayout),
+		Note:      settlement.Note,
+		CreatedBy: settlement.CreatedBy,
+		VoidedBy:  settlement.VoidedBy,
+		CreatedAt: settlement.CreatedAt,
+	}
+	if settlement.IsVoided() {
+		voidedAt := settlement.VoidedAt
+		response.VoidedAt = &voidedAt
+	}
+	return response
+}
+
+// PlanSettlements suggests the fewest payments that settle the wallet.
+func (h *SettlementHandler) PlanSettlements() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		payments, err := h.settlementService.PlanSettlements(c.Param("id"), actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]DebtResponse, 0, len(payments))
+		for _, payment := range payments {
+			response = append(response, mapDebtResponse(payment))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *SettlementHandler) RecordSettlement() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto CreateSettlementRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		settlement, err := h.settlementService.RecordSettlement(c.Param("id"), actorID, input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapSettlementResponse(settlement))
+	}
+}
+
+// parseSettlementFilter reads the filters of a settlement listing: ?from=
+// and ?to= dates, a comma-separated ?members=, ?voided=true|false and
+// ?currency=.
+func parseSettlementFilter(c *gin.Context) (repositories.SettlementFilter, *apperror.AppError) {
+	filter := repositories.SettlementFilter{Currency: c.Query("currency")}
+
+	var appErr *apperror.AppError
+	if filter.MemberIDs, appErr = parseIDListQuery(c, "members"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.From, appErr = parseDateQuery(c, "from"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.To, appErr = parseDateQuery(c, "to"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.Voided, appErr = parseBoolQuery(c, "voided"); appErr != nil {
+		return filter, appErr
+	}
+
+	return filter, nil
+}
+
+// ListSettlements lists a page of the wallet settlements, filtered by
+// parseSettlementFilter and sorted by ?sort= (date, amount or created_at,
+// with a leading "-" for descending). The X-Next-Cursor header holds the
+// ?cursor= of the next page.
+func (h *SettlementHandler) ListSettlements() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		filter, appErr := parseSettlementFilter(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.SettlementSorts, repositories.DefaultSettlementSort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		settlements, next, err := h.settlementService.ListSettlements(c.Param("id"), actorID, filter, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]SettlementResponse, 0, len(settlements))
+		for _, settlement := range settlements {
+			response = append(response, mapSettlementResponse(settlement))
+		}
+
+		setNextCursor(c, next)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *SettlementHandler) GetSettlement() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		settlement, err := h.settlementService.GetSettlement(c.Param("id"), actorID, c.Param("settlementId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapSettlementResponse(settlement))
+	}
+}
+
+func (h *SettlementHandler) VoidSettlement() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		settlement, err := h.settlementService.VoidSettlement(c.Param("id"), actorID, c.Param("settlementId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapSettlementResponse(settlement))
+	}
+}
+
+func NewSettlementHandler(
+	settlementService services.SettlementService,
+	log logger.Logger,
+) *SettlementHandler {
+	return &SettlementHandler{
+		settlementService: settlementService,
+		log:               log,
+	}
+}
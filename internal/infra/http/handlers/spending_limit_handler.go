@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type SpendingLimitHandler struct {
+	limitService services.SpendingLimitService
+	log          logger.Logger
+}
+
+// CreateSpendingLimitRequest caps the expenses of a member, either each one
+// (TRANSACTION) or their total over a DAY, WEEK or MONTH; category_id
+// narrows it to one of the member's categories.
+type CreateSpendingLimitRequest struct {
+	UserID     string      `json:"user_id"`
+	CategoryID string      `json:"category_id"`
+	Period     string      `json:"period"`
+	Amount     money.Money `json:"amount"`
+}
+
+func (r *CreateSpendingLimitRequest) Validate() (services.SpendingLimitInput, *apperror.AppError) {
+	if r.UserID == "" {
+		return services.SpendingLimitInput{}, apperror.New(apperror.ErrorTypeValidation, "User ID is required").
+			AddContext("field", "user_id")
+	}
+
+	period, err := entities.NewLimitPeriod(r.Period)
+	if err != nil {
+		return services.SpendingLimitInput{}, apperror.New(apperror.ErrorTypeValidation, "Period must be TRANSACTION, DAY, WEEK or MONTH").
+			AddContext("field", "period")
+	}
+
+	return services.SpendingLimitInput{
+		UserID:     r.UserID,
+		CategoryID: r.CategoryID,
+		Period:     period,
+		Amount:     r.Amount,
+	}, nil
+}
+
+type UpdateSpendingLimitRequest struct {
+	Amount money.Money `json:"amount"`
+}
+
+type SpendingLimitResponse struct {
+	ID         string      `json:"id"`
+	WalletID   string      `json:"wallet_id"`
+	UserID     string      `json:"user_id"`
+	CategoryID string      `json:"category_id,omitempty"`
+	Period     string      `json:"period"`
+	Amount     money.Money `json:"amount"`
+	CreatedBy  string      `json:"created_by"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+func mapSpendingLimitResponse(limit *entities.SpendingLimit) SpendingLimitResponse {
+	return SpendingLimitResponse{
+		ID:         limit.ID,
+		WalletID:   limit.WalletID,
+		UserID:     limit.UserID,
+		CategoryID: limit.CategoryID,
+		Period:     string(limit.Period),
+		Amount:     limit.Amount,
+		CreatedBy:  limit.CreatedBy,
+		CreatedAt:  limit.CreatedAt,
+		UpdatedAt:  limit.UpdatedAt,
+	}
+}
+
+func (h *SpendingLimitHandler) CreateSpendingLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto CreateSpendingLimitRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		limit, err := h.limitService.CreateSpendingLimit(c.Param("id"), actorID, input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapSpendingLimitResponse(limit))
+	}
+}
+
+// ListSpendingLimits lists every limit of the wallet to its owners, and
+// their own limits to other members.
+func (h *SpendingLimitHandler) ListSpendingLimits() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		limits, err := h.limitService.ListSpendingLimits(c.Param("id"), actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]SpendingLimitResponse, 0, len(limits))
+		for _, limit := range limits {
+			response = append(response, mapSpendingLimitResponse(limit))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// UpdateSpendingLimit changes the amount of a limit; to change its period
+// or category, delete it and create another.
+func (h *SpendingLimitHandler) UpdateSpendingLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto UpdateSpendingLimitRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		limit, err := h.limitService.UpdateSpendingLimit(c.Param("id"), actorID, c.Param("limitId"), dto.Amount)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapSpendingLimitResponse(limit))
+	}
+}
+
+func (h *SpendingLimitHandler) DeleteSpendingLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.limitService.DeleteSpendingLimit(c.Param("id"), actorID, c.Param("limitId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewSpendingLimitHandler(
+	limitService services.SpendingLimitService,
+	log logger.Logger,
+) *SpendingLimitHandler {
+	return &SpendingLimitHandler{
+		limitService: limitService,
+		log:          log,
+	}
+}
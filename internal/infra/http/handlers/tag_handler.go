@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+// TagHandler serves both user and wallet tags; the scope is fixed when the
+// route is registered and the owner is the :id path parameter.
+type TagHandler struct {
+	tagService services.TagService
+	log        logger.Logger
+}
+
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *TagRequest) Validate() *apperror.AppError {
+	if r.Name == "" {
+		return apperror.New(apperror.ErrorTypeValidation, "Name is required").
+			AddContext("field", "name")
+	}
+
+	return nil
+}
+
+type MergeTagRequest struct {
+	TargetID string `json:"target_id"`
+}
+
+type TagResponse struct {
+	ID    string `json:"id"`
+	Scope string `json:"scope"`
+	Name  string `json:"name"`
+}
+
+type TagUsageResponse struct {
+	TagResponse
+	TransactionCount int        `json:"transaction_count"`
+	LastUsedAt       *time.Time `json:"last_used_at"`
+}
+
+func mapTagResponse(tag *entities.Tag) TagResponse {
+	return TagResponse{
+		ID:    tag.ID,
+		Scope: string(tag.Scope),
+		Name:  tag.Name,
+	}
+}
+
+func mapTagUsageResponse(usage *entities.TagUsage) TagUsageResponse {
+	response := TagUsageResponse{
+		TagResponse:      mapTagResponse(usage.Tag),
+		TransactionCount: usage.TransactionCount,
+	}
+	if !usage.LastUsedAt.IsZero() {
+		lastUsedAt := usage.LastUsedAt
+		response.LastUsedAt = &lastUsedAt
+	}
+	return response
+}
+
+func (h *TagHandler) CreateTag(scope entities.TagScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto TagRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		tag, err := h.tagService.CreateTag(scope, c.Param("id"), actorID, dto.Name)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapTagResponse(tag))
+	}
+}
+
+// ListTags lists a page of the owner tags with their usage, filtered by
+// ?q= (part of the name) and ?used=true|false, and sorted by ?sort= (name,
+// usage or created_at, with a leading "-" for descending). The
+// X-Next-Cursor header holds the ?cursor= of the next page.
+func (h *TagHandler) ListTags(scope entities.TagScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		filter := repositories.TagFilter{Query: c.Query("q")}
+		if filter.Used, appErr = parseBoolQuery(c, "used"); appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.TagSorts, repositories.DefaultTagSort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		usage, next, err := h.tagService.ListTags(scope, c.Param("id"), actorID, filter, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]TagUsageResponse, 0, len(usage))
+		for _, tagUsage := range usage {
+			response = append(response, mapTagUsageResponse(tagUsage))
+		}
+
+		setNextCursor(c, next)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *TagHandler) RenameTag(scope entities.TagScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto TagRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		tag, err := h.tagService.RenameTag(scope, c.Param("id"), actorID, c.Param("tagId"), dto.Name)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapTagResponse(tag))
+	}
+}
+
+func (h *TagHandler) MergeTag(scope entities.TagScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto MergeTagRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if dto.TargetID == "" {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Target tag is required").
+				AddContext("field", "target_id"))
+			c.Abort()
+			return
+		}
+
+		if err := h.tagService.MergeTags(scope, c.Param("id"), actorID, c.Param("tagId"), dto.TargetID); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *TagHandler) DeleteTag(scope entities.TagScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.tagService.DeleteTag(scope, c.Param("id"), actorID, c.Param("tagId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewTagHandler(
+	tagService services.TagService,
+	log logger.Logger,
+) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		log:        log,
+	}
+}
@@ -0,0 +1,906 @@
+package handlers
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+const transactionDateLayout = "2006-01-02"
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type TransactionHandler struct {
+	transactionService services.TransactionService
+	log                logger.Logger
+}
+
+type TransactionRequest struct {
+	Type        string                     `json:"type"`
+	Amount      money.Money                `json:"amount"`
+	Date        string                     `json:"date"`
+	Description string                     `json:"description"`
+	Status      string                     `json:"status"`
+	CategoryID  string                     `json:"category_id"`
+	PayeeID     string                     `json:"payee_id"`
+	TagIDs      []string                   `json:"tag_ids"`
+	Splits      []TransactionSplitRequest  `json:"splits"`
+	Sharing     *TransactionSharingRequest `json:"sharing"`
+}
+
+type TransactionSplitRequest struct {
+	Amount     money.Money `json:"amount"`
+	CategoryID string      `json:"category_id"`
+	Note       string      `json:"note"`
+}
+
+type TransactionSharingRequest struct {
+	PaidBy string                `json:"paid_by"`
+	Method string                `json:"method"`
+	Shares []ExpenseShareRequest `json:"shares"`
+}
+
+// ExpenseShareRequest is a member's part of a shared expense. Percentage is
+// used with the PERCENTAGE method, Shares with SHARES and Amount with EXACT.
+type ExpenseShareRequest struct {
+	UserID     string      `json:"user_id"`
+	Percentage string      `json:"percentage"`
+	Shares     int64       `json:"shares"`
+	Amount     money.Money `json:"amount"`
+}
+
+func (r *TransactionSharingRequest) Validate() (*services.TransactionSharingInput, *apperror.AppError) {
+	if r.PaidBy == "" {
+		return nil, apperror.New(apperror.ErrorTypeValidation, "Paid by is required").
+			AddContext("field", "sharing.paid_by")
+	}
+
+	method, err := entities.NewShareMethod(r.Method)
+	if err != nil {
+		return nil, apperror.New(apperror.ErrorTypeValidation, "Method must be EQUAL, PERCENTAGE, SHARES or EXACT").
+			AddContext("field", "sharing.method")
+	}
+
+	shares := make([]entities.ExpenseShare, 0, len(r.Shares))
+	for _, share := range r.Shares {
+		expenseShare := entities.ExpenseShare{UserID: share.UserID}
+		switch method {
+		case entities.ShareByPercentage:
+			if expenseShare.Weight, err = entities.ParseSharePercentage(share.Percentage); err != nil {
+				return nil, apperror.New(apperror.ErrorTypeValidation, "Percentage must be a number with at most two decimals").
+					AddContext("field", "sharing.shares.percentage")
+			}
+		case entities.ShareByShares:
+			expenseShare.Weight = share.Shares
+		case entities.ShareByAmount:
+			if share.Amount.Currency().Code == "" {
+				return nil, apperror.New(apperror.ErrorTypeValidation, "Share amount is required").
+					AddContext("field", "sharing.shares.amount")
+			}
+			expenseShare.Amount = share.Amount
+		}
+		shares = append(shares, expenseShare)
+	}
+
+	return &services.TransactionSharingInput{
+		PaidBy: r.PaidBy,
+		Method: method,
+		Shares: shares,
+	}, nil
+}
+
+func (r *TransactionRequest) Validate() (services.TransactionInput, *apperror.AppError) {
+	transactionType, err := entities.NewTransactionType(r.Type)
+	if err != nil {
+		return services.TransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Type must be INCOME or EXPENSE").
+			AddContext("field", "type")
+	}
+
+	if r.Amount.Currency().Code == "" {
+		return services.TransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Amount is required").
+			AddContext("field", "amount")
+	}
+
+	date, err := time.Parse(transactionDateLayout, r.Date)
+	if err != nil {
+		return services.TransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Date must be formatted as YYYY-MM-DD").
+			AddContext("field", "date")
+	}
+
+	var status entities.TransactionStatus
+	if r.Status != "" {
+		if status, err = entities.NewTransactionStatus(r.Status); err != nil {
+			return services.TransactionInput{}, invalidStatus()
+		}
+	}
+
+	splits := make([]services.TransactionSplitInput, 0, len(r.Splits))
+	for _, split := range r.Splits {
+		if split.Amount.Currency().Code == "" {
+			return services.TransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Split amount is required").
+				AddContext("field", "splits")
+		}
+		splits = append(splits, services.TransactionSplitInput{
+			Amount:     split.Amount,
+			CategoryID: split.CategoryID,
+			Note:       split.Note,
+		})
+	}
+
+	var sharing *services.TransactionSharingInput
+	if r.Sharing != nil {
+		var appErr *apperror.AppError
+		if sharing, appErr = r.Sharing.Validate(); appErr != nil {
+			return services.TransactionInput{}, appErr
+		}
+	}
+
+	return services.TransactionInput{
+		Type:        transactionType,
+		Amount:      r.Amount,
+		Date:        date,
+		Description: r.Description,
+		Status:      status,
+		CategoryID:  r.CategoryID,
+		PayeeID:     r.PayeeID,
+		TagIDs:      r.TagIDs,
+		Splits:      splits,
+		Sharing:     sharing,
+	}, nil
+}
+
+type TransactionResponse struct {
+	ID               string                      `json:"id"`
+	WalletID         string                      `json:"wallet_id"`
+	Type             string                      `json:"type"`
+	Amount           money.Money                 `json:"amount"`
+	Date             string                      `json:"date"`
+	Description      string                      `json:"description"`
+	Status           string                      `json:"status"`
+	CategoryID       string                      `json:"category_id,omitempty"`
+	PayeeID          string                      `json:"payee_id,omitempty"`
+	TagIDs           []string                    `json:"tag_ids"`
+	Splits           []TransactionSplitResponse  `json:"splits"`
+	Sharing          *TransactionSharingResponse `json:"sharing"`
+	TransferID       string                      `json:"transfer_id,omitempty"`
+	RecurringID      string                      `json:"recurring_id,omitempty"`
+	AwaitingApproval bool                        `json:"awaiting_approval"`
+	CreatedBy        string                      `json:"created_by"`
+	CreatedAt        time.Time                   `json:"created_at"`
+	UpdatedAt        time.Time                   `json:"updated_at"`
+}
+
+type TransactionSplitResponse struct {
+	ID         string      `json:"id"`
+	Amount     money.Money `json:"amount"`
+	CategoryID string      `json:"category_id,omitempty"`
+	Note       string      `json:"note"`
+}
+
+type TransactionSharingResponse struct {
+	PaidBy string                 `json:"paid_by"`
+	Method string                 `json:"method"`
+	Shares []ExpenseShareResponse `json:"shares"`
+}
+
+type ExpenseShareResponse struct {
+	UserID     string      `json:"user_id"`
+	Percentage string      `json:"percentage,omitempty"`
+	Shares     int64       `json:"shares,omitempty"`
+	Amount     money.Money `json:"amount"`
+}
+
+func mapTransactionSharingResponse(sharing *entities.ExpenseSharing) *TransactionSharingResponse {
+	if sharing == nil {
+		return nil
+	}
+
+	shares := make([]ExpenseShareResponse, 0, len(sharing.Shares))
+	for _, share := range sharing.Shares {
+		response := ExpenseShareResponse{UserID: share.UserID, Amount: share.Amount}
+		switch sharing.Method {
+		case entities.ShareByPercentage:
+			response.Percentage = fmt.Sprintf("%d.%02d", share.Weight/100, share.Weight%100)
+		case entities.ShareByShares:
+			response.Shares = share.Weight
+		}
+		shares = append(shares, response)
+	}
+
+	return &TransactionSharingResponse{
+		PaidBy: sharing.PaidBy,
+		Method: string(sharing.Method),
+		Shares: shares,
+	}
+}
+
+func mapTransactionResponse(transaction *entities.Transaction) TransactionResponse {
+	tagIDs := transaction.TagIDs
+	if tagIDs == nil {
+		tagIDs = []string{}
+	}
+
+	splits := make([]TransactionSplitResponse, 0, len(transaction.Splits))
+	for _, split := range transaction.Splits {
+		splits = append(splits, TransactionSplitResponse{
+			ID:         split.ID,
+			Amount:     split.Amount,
+			CategoryID: split.CategoryID,
+			Note:       split.Note,
+		})
+	}
+
+	return TransactionResponse{
+		ID:               transaction.ID,
+		WalletID:         transaction.WalletID,
+		Type:             string(transaction.Type),
+		Amount:           transaction.Amount,
+		Date:             transaction.Date.Format(transactionDateLayout),
+		Description:      transaction.Description,
+		Status:           string(transaction.Status),
+		CategoryID:       transaction.CategoryID,
+		PayeeID:          transaction.PayeeID,
+		TagIDs:           tagIDs,
+		Splits:           splits,
+		Sharing:          mapTransactionSharingResponse(transaction.Sharing),
+		TransferID:       transaction.TransferID,
+		RecurringID:      transaction.RecurringID,
+		AwaitingApproval: transaction.AwaitingApproval,
+		CreatedBy:        transaction.CreatedBy,
+		CreatedAt:        transaction.CreatedAt,
+		UpdatedAt:        transaction.UpdatedAt,
+	}
+}
+
+func (h *TransactionHandler) CreateTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto TransactionRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		transaction, err := h.transactionService.CreateTransaction(c.Param("id"), actorID, input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapTransactionResponse(transaction))
+	}
+}
+
+// parseTransactionFilter reads the filters of a transaction listing:
+// ?tags=<id>,<id> with ?tag_match=any|all, ?from= and ?to= dates,
+// ?min_amount= and ?max_amount= in ?currency=, and comma-separated
+// ?categories=, ?payees=, ?members= and ?types=.
+func parseTransactionFilter(c *gin.Context) (repositories.TransactionFilter, *apperror.AppError) {
+	var filter repositories.TransactionFilter
+	ids := []struct {
+		name string
+		dest *[]string
+	}{
+		{name: "tags", dest: &filter.TagIDs},
+		{name: "categories", dest: &filter.CategoryIDs},
+		{name: "payees", dest: &filter.PayeeIDs},
+		{name: "members", dest: &filter.MemberIDs},
+	}
+	var appErr *apperror.AppError
+	for _, list := range ids {
+		if *list.dest, appErr = parseIDListQuery(c, list.name); appErr != nil {
+			return filter, appErr
+		}
+	}
+
+	switch c.DefaultQuery("tag_match", "any") {
+	case "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return filter, apperror.New(apperror.ErrorTypeValidation, "Tag match must be any or all").
+			AddContext("field", "tag_match")
+	}
+
+	if filter.From, appErr = parseDateQuery(c, "from"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.To, appErr = parseDateQuery(c, "to"); appErr != nil {
+		return filter, appErr
+	}
+
+	if filter.MinAmount, appErr = parseAmountQuery(c, "min_amount"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.MaxAmount, appErr = parseAmountQuery(c, "max_amount"); appErr != nil {
+		return filter, appErr
+	}
+
+	if filter.Types, appErr = parseFilterTypes(parseListQuery(c, "types"), "types"); appErr != nil {
+		return filter, appErr
+	}
+
+	return filter, nil
+}
+
+// parseFilterTypes reads the transaction types to filter on, in any case.
+func parseFilterTypes(values []string, field string) ([]entities.TransactionType, *apperror.AppError) {
+	var types []entities.TransactionType
+	for _, value := range values {
+		transactionType := entities.TransactionType(strings.ToUpper(value))
+		switch transactionType {
+		case entities.TransactionTypeIncome, entities.TransactionTypeExpense,
+			entities.TransactionTypeTransferIn, entities.TransactionTypeTransferOut:
+			types = append(types, transactionType)
+		default:
+			return nil, apperror.New(apperror.ErrorTypeValidation, "Types must be INCOME, EXPENSE, TRANSFER_IN or TRANSFER_OUT").
+				AddContext("field", field)
+		}
+	}
+	return types, nil
+}
+
+// parseAmountQuery reads an optional amount bound in ?currency=.
+func parseAmountQuery(c *gin.Context, name string) (*money.Money, *apperror.AppError) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	currency := c.Query("currency")
+	if currency == "" {
+		return nil, apperror.New(apperror.ErrorTypeValidation, "Currency is required to filter by amount").
+			AddContext("field", "currency")
+	}
+
+	amount, err := money.Parse(value, strings.ToUpper(currency))
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrorTypeValidation, err).
+			AddContext("field", name)
+	}
+	return &amount, nil
+}
+
+// ListTransactions lists a page of the wallet transactions, filtered by
+// parseTransactionFilter and sorted by ?sort= (date, amount or created_at,
+// with a leading "-" for descending). The X-Next-Cursor header holds the
+// ?cursor= of the next page.
+func (h *TransactionHandler) ListTransactions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		filter, appErr := parseTransactionFilter(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		page, appErr := parsePage(c, repositories.TransactionSorts, repositories.DefaultTransactionSort)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		transactions, next, err := h.transactionService.ListTransactions(c.Param("id"), actorID, filter, page)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]TransactionResponse, 0, len(transactions))
+		for _, transaction := range transactions {
+			response = append(response, mapTransactionResponse(transaction))
+		}
+
+		setNextCursor(c, next)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+type TransactionSearchResponse struct {
+	Transaction TransactionResponse `json:"transaction"`
+	Rank        float64             `json:"rank"`
+	Snippet     string              `json:"snippet"`
+}
+
+func mapTransactionSearchResponse(result *entities.TransactionSearchResult) TransactionSearchResponse {
+	return TransactionSearchResponse{
+		Transaction: mapTransactionResponse(result.Transaction),
+		Rank:        result.Rank,
+		Snippet:     escapeSnippet(result.Snippet),
+	}
+}
+
+// escapeSnippet makes a search snippet safe to render as HTML: the
+// transaction text is escaped and only the highlight markers are kept.
+func escapeSnippet(snippet string) string {
+	return strings.NewReplacer(
+		html.EscapeString(entities.SearchHighlightStart), entities.SearchHighlightStart,
+		html.EscapeString(entities.SearchHighlightStop), entities.SearchHighlightStop,
+	).Replace(html.EscapeString(snippet))
+}
+
+// SearchTransactions searches the transactions of every wallet the caller is
+// a member of for ?q=, optionally only in ?wallet_id=, returning up to
+// ?limit= results.
+func (h *TransactionHandler) SearchTransactions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		search := repositories.TransactionSearch{
+			Query:    c.Query("q"),
+			WalletID: c.Query("wallet_id"),
+			Limit:    defaultSearchLimit,
+		}
+
+		if search.Query == "" {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Search query is required").
+				AddContext("field", "q"))
+			c.Abort()
+			return
+		}
+
+		if search.WalletID != "" {
+			if appErr := checkIDs([]string{search.WalletID}, "wallet_id"); appErr != nil {
+				c.Error(appErr)
+				c.Abort()
+				return
+			}
+		}
+
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 || parsed > maxSearchLimit {
+				c.Error(apperror.New(apperror.ErrorTypeValidation, "Limit must be between 1 and 100").
+					AddContext("field", "limit"))
+				c.Abort()
+				return
+			}
+			search.Limit = parsed
+		}
+
+		results, err := h.transactionService.SearchTransactions(userID, search)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]TransactionSearchResponse, 0, len(results))
+		for _, result := range results {
+			response = append(response, mapTransactionSearchResponse(result))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *TransactionHandler) GetTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		transaction, err := h.transactionService.GetTransaction(c.Param("id"), actorID, c.Param("transactionId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapTransactionResponse(transaction))
+	}
+}
+
+// UpdateTransaction edits a transaction. A reconciled one is only edited
+// with ?confirm_reconciled=true.
+func (h *TransactionHandler) UpdateTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto TransactionRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		confirmed, appErr := parseBoolQuery(c, "confirm_reconciled")
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+		input.ConfirmReconciled = confirmed != nil && *confirmed
+
+		transaction, err := h.transactionService.UpdateTransaction(c.Param("id"), actorID, c.Param("transactionId"), input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapTransactionResponse(transaction))
+	}
+}
+
+type TransactionStatusRequest struct {
+	Status string `json:"status"`
+}
+
+func invalidStatus() *apperror.AppError {
+	return apperror.New(apperror.ErrorTypeValidation, "Status must be SCHEDULED, PENDING, CLEARED or RECONCILED").
+		AddContext("field", "status")
+}
+
+func (h *TransactionHandler) SetTransactionStatus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto TransactionStatusRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		status, err := entities.NewTransactionStatus(dto.Status)
+		if err != nil {
+			c.Error(invalidStatus())
+			c.Abort()
+			return
+		}
+
+		transaction, err := h.transactionService.SetTransactionStatus(c.Param("id"), actorID, c.Param("transactionId"), status)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapTransactionResponse(transaction))
+	}
+}
+
+func (h *TransactionHandler) DeleteTransaction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.transactionService.DeleteTransaction(c.Param("id"), actorID, c.Param("transactionId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// BulkTransactionRequest selects transactions by TransactionIDs or by
+// Filter and names the operation to run on them with its arguments.
+type BulkTransactionRequest struct {
+	Operation      string                        `json:"operation"`
+	TransactionIDs []string                      `json:"transaction_ids"`
+	Filter         *BulkTransactionFilterRequest `json:"filter"`
+	CategoryID     string                        `json:"category_id"`
+	AddTagIDs      []string                      `json:"add_tag_ids"`
+	RemoveTagIDs   []string                      `json:"remove_tag_ids"`
+	TargetWalletID string                        `json:"target_wallet_id"`
+	DryRun         bool                          `json:"dry_run"`
+	// ConfirmReconciled lets RECATEGORIZE and RETAG change reconciled
+	// transactions.
+	ConfirmReconciled bool `json:"confirm_reconciled"`
+}
+
+// BulkTransactionFilterRequest selects the transactions of a wallet with the
+// filters of a transaction listing.
+type BulkTransactionFilterRequest struct {
+	WalletID    string       `json:"wallet_id"`
+	TagIDs      []string     `json:"tag_ids"`
+	TagMatch    string       `json:"tag_match"`
+	From        string       `json:"from"`
+	To          string       `json:"to"`
+	MinAmount   *money.Money `json:"min_amount"`
+	MaxAmount   *money.Money `json:"max_amount"`
+	CategoryIDs []string     `json:"category_ids"`
+	PayeeIDs    []string     `json:"payee_ids"`
+	MemberIDs   []string     `json:"member_ids"`
+	Types       []string     `json:"types"`
+}
+
+func (r *BulkTransactionFilterRequest) Validate() (repositories.TransactionFilter, *apperror.AppError) {
+	filter := repositories.TransactionFilter{
+		TagIDs:      r.TagIDs,
+		MinAmount:   r.MinAmount,
+		MaxAmount:   r.MaxAmount,
+		CategoryIDs: r.CategoryIDs,
+		PayeeIDs:    r.PayeeIDs,
+		MemberIDs:   r.MemberIDs,
+	}
+
+	ids := []struct {
+		values []string
+		field  string
+	}{
+		{values: r.TagIDs, field: "filter.tag_ids"},
+		{values: r.CategoryIDs, field: "filter.category_ids"},
+		{values: r.PayeeIDs, field: "filter.payee_ids"},
+		{values: r.MemberIDs, field: "filter.member_ids"},
+	}
+	for _, list := range ids {
+		if appErr := checkIDs(list.values, list.field); appErr != nil {
+			return filter, appErr
+		}
+	}
+
+	switch r.TagMatch {
+	case "", "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return filter, apperror.New(apperror.ErrorTypeValidation, "Tag match must be any or all").
+			AddContext("field", "filter.tag_match")
+	}
+
+	dates := []struct {
+		value string
+		field string
+		dest  *time.Time
+	}{
+		{value: r.From, field: "filter.from", dest: &filter.From},
+		{value: r.To, field: "filter.to", dest: &filter.To},
+	}
+	for _, date := range dates {
+		if date.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(transactionDateLayout, date.value)
+		if err != nil {
+			return filter, apperror.New(apperror.ErrorTypeValidation, "Date must be formatted as YYYY-MM-DD").
+				AddContext("field", date.field)
+		}
+		*date.dest = parsed
+	}
+
+	var appErr *apperror.AppError
+	if filter.Types, appErr = parseFilterTypes(r.Types, "filter.types"); appErr != nil {
+		return filter, appErr
+	}
+
+	return filter, nil
+}
+
+func (r *BulkTransactionRequest) Validate() (services.BulkTransactionInput, *apperror.AppError) {
+	operation, err := entities.NewBulkOperation(r.Operation)
+	if err != nil {
+		return services.BulkTransactionInput{}, apperror.New(apperror.ErrorTypeValidation, "Operation must be RECATEGORIZE, RETAG, MOVE or DELETE").
+			AddContext("field", "operation")
+	}
+
+	input := services.BulkTransactionInput{
+		Operation:         operation,
+		TransactionIDs:    r.TransactionIDs,
+		CategoryID:        r.CategoryID,
+		AddTagIDs:         r.AddTagIDs,
+		RemoveTagIDs:      r.RemoveTagIDs,
+		TargetWalletID:    r.TargetWalletID,
+		DryRun:            r.DryRun,
+		ConfirmReconciled: r.ConfirmReconciled,
+	}
+
+	if (len(r.TransactionIDs) == 0) == (r.Filter == nil) {
+		return input, apperror.New(apperror.ErrorTypeValidation, "Select transactions either by transaction IDs or by filter").
+			AddContext("field", "transaction_ids")
+	}
+
+	ids := []struct {
+		values []string
+		field  string
+	}{
+		{values: r.TransactionIDs, field: "transaction_ids"},
+		{values: r.AddTagIDs, field: "add_tag_ids"},
+		{values: r.RemoveTagIDs, field: "remove_tag_ids"},
+	}
+	for _, list := range ids {
+		if appErr := checkIDs(list.values, list.field); appErr != nil {
+			return input, appErr
+		}
+	}
+
+	if r.Filter != nil {
+		if r.Filter.WalletID == "" {
+			return input, apperror.New(apperror.ErrorTypeValidation, "Wallet is required to select by filter").
+				AddContext("field", "filter.wallet_id")
+		}
+		input.WalletID = r.Filter.WalletID
+
+		var appErr *apperror.AppError
+		if input.Filter, appErr = r.Filter.Validate(); appErr != nil {
+			return input, appErr
+		}
+	}
+
+	switch operation {
+	case entities.BulkRetag:
+		if len(r.AddTagIDs) == 0 && len(r.RemoveTagIDs) == 0 {
+			return input, apperror.New(apperror.ErrorTypeValidation, "Tags to add or remove are required").
+				AddContext("field", "add_tag_ids")
+		}
+	case entities.BulkMove:
+		if r.TargetWalletID == "" {
+			return input, apperror.New(apperror.ErrorTypeValidation, "Target wallet is required").
+				AddContext("field", "target_wallet_id")
+		}
+	}
+
+	return input, nil
+}
+
+// Statuses of the items of a bulk operation report.
+const (
+	bulkItemOK     = "OK"
+	bulkItemFailed = "FAILED"
+)
+
+type BulkTransactionResponse struct {
+	Operation string                        `json:"operation"`
+	DryRun    bool                          `json:"dry_run"`
+	Succeeded int                           `json:"succeeded"`
+	Failed    int                           `json:"failed"`
+	Items     []BulkTransactionItemResponse `json:"items"`
+}
+
+type BulkTransactionItemResponse struct {
+	TransactionID string                        `json:"transaction_id"`
+	Status        string                        `json:"status"`
+	Error         *BulkTransactionErrorResponse `json:"error,omitempty"`
+}
+
+// BulkTransactionErrorResponse tells why an item failed, in the shape of
+// the API error responses.
+type BulkTransactionErrorResponse struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+func mapBulkTransactionResponse(result *services.BulkResult) BulkTransactionResponse {
+	items := make([]BulkTransactionItemResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		response := BulkTransactionItemResponse{TransactionID: item.TransactionID, Status: bulkItemOK}
+		if item.Err != nil {
+			response.Status = bulkItemFailed
+			response.Error = &BulkTransactionErrorResponse{
+				Code:    string(apperror.ErrorTypeInternal),
+				Message: item.Err.Error(),
+			}
+			if appErr, ok := item.Err.(*apperror.AppError); ok {
+				response.Error.Code = string(appErr.Type())
+				response.Error.Details = appErr.Context()
+			}
+		}
+		items = append(items, response)
+	}
+
+	succeeded := result.Succeeded()
+	return BulkTransactionResponse{
+		Operation: string(result.Operation),
+		DryRun:    result.DryRun,
+		Succeeded: succeeded,
+		Failed:    len(result.Items) - succeeded,
+		Items:     items,
+	}
+}
+
+// BulkUpdateTransactions runs a bulk operation for the user and reports the
+// outcome for every selected transaction. With dry_run nothing is saved.
+func (h *TransactionHandler) BulkUpdateTransactions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto BulkTransactionRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		result, err := h.transactionService.BulkUpdateTransactions(userID, input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapBulkTransactionResponse(result))
+	}
+}
+
+func NewTransactionHandler(
+	transactionService services.TransactionService,
+	log logger.Logger,
+) *TransactionHandler {
+	return &TransactionHandler{
+		transactionService: transactionService,
+		log:                log,
+	}
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+// transferRateDecimals matches the precision transfer rates are stored with.
+const transferRateDecimals = 10
+
+type TransferHandler struct {
+	transferService services.TransferService
+	log             logger.Logger
+}
+
+type TransferRequest struct {
+	Amount         money.Money `json:"amount"`
+	ReceivedAmount money.Money `json:"received_amount"`
+	Date           string      `json:"date"`
+	Description    string      `json:"description"`
+}
+
+func (r *TransferRequest) Validate() (services.TransferInput, *apperror.AppError) {
+	if r.Amount.Currency().Code == "" {
+		return services.TransferInput{}, apperror.New(apperror.ErrorTypeValidation, "Amount is required").
+			AddContext("field", "amount")
+	}
+
+	date, err := time.Parse(transactionDateLayout, r.Date)
+	if err != nil {
+		return services.TransferInput{}, apperror.New(apperror.ErrorTypeValidation, "Date must be formatted as YYYY-MM-DD").
+			AddContext("field", "date")
+	}
+
+	return services.TransferInput{
+		Amount:         r.Amount,
+		ReceivedAmount: r.ReceivedAmount,
+		Date:           date,
+		Description:    r.Description,
+	}, nil
+}
+
+type CreateTransferRequest struct {
+	TransferRequest
+	ToWalletID string `json:"to_wallet_id"`
+}
+
+func (r *CreateTransferRequest) Validate() (services.TransferInput, *apperror.AppError) {
+	if r.ToWalletID == "" {
+		return services.TransferInput{}, apperror.New(apperror.ErrorTypeValidation, "Destination wallet is required").
+			AddContext("field", "to_wallet_id")
+	}
+
+	return r.TransferRequest.Validate()
+}
+
+type TransferResponse struct {
+	ID             string              `json:"id"`
+	FromWalletID   string              `json:"from_wallet_id"`
+	ToWalletID     string              `json:"to_wallet_id"`
+	Amount         money.Money         `json:"amount"`
+	ReceivedAmount money.Money         `json:"received_amount"`
+	Rate           string              `json:"rate"`
+	Date           string              `json:"date"`
+	Description    string              `json:"description"`
+	Outgoing       TransactionResponse `json:"outgoing"`
+	Incoming       TransactionResponse `json:"incoming"`
+	CreatedBy      string              `json:"created_by"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+func mapTransferResponse(transfer *entities.Transfer) TransferResponse {
+	return TransferResponse{
+		ID:             transfer.ID,
+		FromWalletID:   transfer.Outgoing.WalletID,
+		ToWalletID:     transfer.Incoming.WalletID,
+		Amount:         transfer.Outgoing.Amount,
+		ReceivedAmount: transfer.Incoming.Amount,
+		Rate:           transfer.Rate.FloatString(transferRateDecimals),
+		Date:           transfer.Outgoing.Date.Format(transactionDateLayout),
+		Description:    transfer.Outgoing.Description,
+		Outgoing:       mapTransactionResponse(transfer.Outgoing),
+		Incoming:       mapTransactionResponse(transfer.Incoming),
+		CreatedBy:      transfer.CreatedBy,
+		CreatedAt:      transfer.CreatedAt,
+		UpdatedAt:      transfer.UpdatedAt,
+	}
+}
+
+// CreateTransfer moves money out of the :id wallet into to_wallet_id.
+func (h *TransferHandler) CreateTransfer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto CreateTransferRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		transfer, err := h.transferService.CreateTransfer(c.Param("id"), dto.ToWalletID, actorID, input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapTransferResponse(transfer))
+	}
+}
+
+func (h *TransferHandler) GetTransfer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		transfer, err := h.transferService.GetTransfer(c.Param("id"), actorID, c.Param("transferId"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapTransferResponse(transfer))
+	}
+}
+
+func (h *TransferHandler) UpdateTransfer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto TransferRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		input, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		transfer, err := h.transferService.UpdateTransfer(c.Param("id"), actorID, c.Param("transferId"), input)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapTransferResponse(transfer))
+	}
+}
+
+func (h *TransferHandler) DeleteTransfer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.transferService.DeleteTransfer(c.Param("id"), actorID, c.Param("transferId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func NewTransferHandler(
+	transferService services.TransferService,
+	log logger.Logger,
+) *TransferHandler {
+	return &TransferHandler{
+		transferService: transferService,
+		log:             log,
+	}
+}
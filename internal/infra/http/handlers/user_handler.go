@@ -54,19 +54,29 @@ func (c *CreateUserRequest) Validate() *apperror.AppError {
 	return nil
 }
 
+// SetBaseCurrencyRequest sets the currency balances and reports default to;
+// an empty currency clears it.
+type SetBaseCurrencyRequest struct {
+	BaseCurrency string `json:"base_currency"`
+}
+
 type UserResponse struct {
-	ID        string `json:"id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
+	ID           string `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Email        string `json:"email"`
+	ParentID     string `json:"parent_id,omitempty"`
+	BaseCurrency string `json:"base_currency,omitempty"`
 }
 
 func mapUserResponse(person *entities.User) UserResponse {
 	return UserResponse{
-		ID:        person.ID,
-		FirstName: person.FirstName,
-		LastName:  person.LastName,
-		Email:     person.Email,
+		ID:           person.ID,
+		FirstName:    person.FirstName,
+		LastName:     person.LastName,
+		Email:        person.Email,
+		ParentID:     person.ParentID,
+		BaseCurrency: person.BaseCurrency,
 	}
 }
 
@@ -117,6 +127,110 @@ func (uc *UserHandler) CreateUser() gin.HandlerFunc {
 	}
 }
 
+func (uc *UserHandler) CreateDependent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parentID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto CreateUserRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			appErr := apperror.New(apperror.ErrorTypeValidation, "Invalid request format")
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		user, err := uc.userService.CreateDependent(
+			parentID,
+			dto.FirstName,
+			dto.LastName,
+			dto.Email,
+			dto.Password,
+		)
+
+		if err != nil {
+			if errors.Is(err, services.ErrUserAlreadyExists) {
+				appErr := apperror.New(apperror.ErrorTypeValidation, "User with this email already exists").
+					AddContext("field", "email")
+				c.Error(appErr)
+				c.Abort()
+				return
+			}
+
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapUserResponse(user))
+	}
+}
+
+func (uc *UserHandler) ListDependents() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parentID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		dependents, err := uc.userService.ListDependents(parentID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]UserResponse, 0, len(dependents))
+		for _, dependent := range dependents {
+			response = append(response, mapUserResponse(dependent))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// SetBaseCurrency sets the currency the balances and reports of the user are
+// converted into when they do not ask for another one.
+func (uc *UserHandler) SetBaseCurrency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, appErr := parseSelf(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto SetBaseCurrencyRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			appErr := apperror.New(apperror.ErrorTypeValidation, "Invalid request format")
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		user, err := uc.userService.SetBaseCurrency(userID, dto.BaseCurrency)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapUserResponse(user))
+	}
+}
+
 func NewUserHandler(
 	userService services.UserService,
 	log logger.Logger,
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	apperror "github.com/stra1g/saver-api/pkg/error"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"github.com/stra1g/saver-api/pkg/money"
+)
+
+type WalletHandler struct {
+	walletService services.WalletService
+	log           logger.Logger
+}
+
+type WalletRequest struct {
+	Name        string `json:"name"`
+	HouseholdID string `json:"household_id"`
+}
+
+func (r *WalletRequest) Validate() *apperror.AppError {
+	if r.Name == "" {
+		return apperror.New(apperror.ErrorTypeValidation, "Name is required").
+			AddContext("field", "name")
+	}
+
+	return nil
+}
+
+type WalletMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+func (r *WalletMemberRequest) Validate() (entities.WalletRole, *apperror.AppError) {
+	if r.Email == "" {
+		return "", apperror.New(apperror.ErrorTypeValidation, "Email is required").
+			AddContext("field", "email")
+	}
+
+	role, err := entities.NewWalletRole(r.Role)
+	if err != nil {
+		return "", apperror.New(apperror.ErrorTypeValidation, "Role must be OWNER or MEMBER").
+			AddContext("field", "role")
+	}
+
+	return role, nil
+}
+
+// ApprovalPolicyRequest sets the approval threshold of a wallet; a null
+// threshold stops holding expenses.
+type ApprovalPolicyRequest struct {
+	Threshold *money.Money `json:"threshold"`
+	TTLHours  int          `json:"ttl_hours"`
+}
+
+func (r *ApprovalPolicyRequest) Validate() (money.Money, time.Duration, *apperror.AppError) {
+	if r.TTLHours <= 0 {
+		return money.Money{}, 0, apperror.New(apperror.ErrorTypeValidation, "TTL hours must be positive").
+			AddContext("field", "ttl_hours")
+	}
+
+	var threshold money.Money
+	if r.Threshold != nil {
+		threshold = *r.Threshold
+	}
+	return threshold, time.Duration(r.TTLHours) * time.Hour, nil
+}
+
+type ApprovalPolicyResponse struct {
+	Threshold *money.Money `json:"threshold"`
+	TTLHours  int          `json:"ttl_hours"`
+}
+
+type WalletMemberResponse struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type WalletResponse struct {
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	HouseholdID    string                 `json:"household_id,omitempty"`
+	Members        []WalletMemberResponse `json:"members"`
+	ApprovalPolicy ApprovalPolicyResponse `json:"approval_policy"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+func mapWalletMemberResponse(member *entities.WalletMember) WalletMemberResponse {
+	return WalletMemberResponse{
+		UserID:   member.UserID,
+		Role:     string(member.Role),
+		JoinedAt: member.JoinedAt,
+	}
+}
+
+func mapWalletResponse(wallet *entities.Wallet) WalletResponse {
+	members := make([]WalletMemberResponse, 0, len(wallet.Members))
+	for _, member := range wallet.Members {
+		members = append(members, mapWalletMemberResponse(member))
+	}
+
+	policy := ApprovalPolicyResponse{TTLHours: int(wallet.ApprovalPolicy.TTL / time.Hour)}
+	if wallet.ApprovalPolicy.Enabled() {
+		threshold := wallet.ApprovalPolicy.Threshold
+		policy.Threshold = &threshold
+	}
+
+	return WalletResponse{
+		ID:             wallet.ID,
+		Name:           wallet.Name,
+		HouseholdID:    wallet.HouseholdID,
+		Members:        members,
+		ApprovalPolicy: policy,
+		CreatedAt:      wallet.CreatedAt,
+		UpdatedAt:      wallet.UpdatedAt,
+	}
+}
+
+// CreateWallet creates a wallet owned by the caller.
+func (h *WalletHandler) CreateWallet() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto WalletRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		if err := dto.Validate(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		wallet, err := h.walletService.CreateWallet(actorID, dto.Name, dto.HouseholdID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapWalletResponse(wallet))
+	}
+}
+
+// ListWallets lists the wallets the caller is a member of.
+func (h *WalletHandler) ListWallets() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		wallets, err := h.walletService.ListWallets(actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		response := make([]WalletResponse, 0, len(wallets))
+		for _, wallet := range wallets {
+			response = append(response, mapWalletResponse(wallet))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (h *WalletHandler) GetWallet() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		wallet, err := h.walletService.GetWallet(c.Param("id"), actorID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapWalletResponse(wallet))
+	}
+}
+
+func (h *WalletHandler) AddMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto WalletMemberRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		role, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		member, err := h.walletService.AddMember(c.Param("id"), actorID, dto.Email, role)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusCreated, mapWalletMemberResponse(member))
+	}
+}
+
+func (h *WalletHandler) RemoveMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		if err := h.walletService.RemoveMember(c.Param("id"), actorID, c.Param("userId")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// SetApprovalPolicy sets which new expenses of the wallet are held for an
+// owner's review.
+func (h *WalletHandler) SetApprovalPolicy() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, appErr := parseActor(c)
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		var dto ApprovalPolicyRequest
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(apperror.New(apperror.ErrorTypeValidation, "Invalid request format"))
+			c.Abort()
+			return
+		}
+
+		threshold, ttl, appErr := dto.Validate()
+		if appErr != nil {
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+
+		wallet, err := h.walletService.SetApprovalPolicy(c.Param("id"), actorID, threshold, ttl)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.JSON(http.StatusOK, mapWalletResponse(wallet))
+	}
+}
+
+func NewWalletHandler(
+	walletService services.WalletService,
+	log logger.Logger,
+) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+		log:           log,
+	}
+}
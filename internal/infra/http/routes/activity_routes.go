@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type ActivityRoutes struct {
+	apiGroup        *gin.RouterGroup
+	activityHandler *handlers.ActivityHandler
+	logger          logger.Logger
+}
+
+func (r *ActivityRoutes) SetupRoutes() {
+	r.logger.Info("Setting up activity routes", map[string]interface{}{})
+
+	activityGroup := r.apiGroup.Group("/wallets/:id/activity")
+	{
+		activityGroup.GET("", r.activityHandler.ListActivities())
+		activityGroup.POST("/read", r.activityHandler.MarkActivitiesRead())
+	}
+}
+
+func NewActivityRoutes(
+	apiGroup *gin.RouterGroup,
+	activityHandler *handlers.ActivityHandler,
+	logger logger.Logger,
+) *ActivityRoutes {
+	return &ActivityRoutes{
+		apiGroup:        apiGroup,
+		activityHandler: activityHandler,
+		logger:          logger,
+	}
+}
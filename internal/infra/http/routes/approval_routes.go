@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type ApprovalRoutes struct {
+	apiGroup        *gin.RouterGroup
+	approvalHandler *handlers.ApprovalHandler
+	logger          logger.Logger
+}
+
+func (r *ApprovalRoutes) SetupRoutes() {
+	r.logger.Info("Setting up approval routes", map[string]interface{}{})
+
+	approvalsGroup := r.apiGroup.Group("/wallets/:id/approvals")
+	{
+		approvalsGroup.GET("", r.approvalHandler.ListApprovals())
+		approvalsGroup.POST("/:approvalId/approve", r.approvalHandler.ApproveExpense())
+		approvalsGroup.POST("/:approvalId/reject", r.approvalHandler.RejectExpense())
+	}
+}
+
+func NewApprovalRoutes(
+	apiGroup *gin.RouterGroup,
+	approvalHandler *handlers.ApprovalHandler,
+	logger logger.Logger,
+) *ApprovalRoutes {
+	return &ApprovalRoutes{
+		apiGroup:        apiGroup,
+		approvalHandler: approvalHandler,
+		logger:          logger,
+	}
+}
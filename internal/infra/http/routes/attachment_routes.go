@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type AttachmentRoutes struct {
+	apiGroup          *gin.RouterGroup
+	attachmentHandler *handlers.AttachmentHandler
+	fileHandler       *handlers.FileHandler
+	logger            logger.Logger
+}
+
+func (r *AttachmentRoutes) SetupRoutes() {
+	r.logger.Info("Setting up attachment routes", map[string]interface{}{})
+
+	attachmentGroup := r.apiGroup.Group("/wallets/:id/transactions/:transactionId/attachments")
+	{
+		attachmentGroup.POST("", r.attachmentHandler.UploadAttachment())
+		attachmentGroup.GET("", r.attachmentHandler.ListAttachments())
+		attachmentGroup.GET("/:attachmentId", r.attachmentHandler.GetAttachment())
+		attachmentGroup.GET("/:attachmentId/download", r.attachmentHandler.DownloadAttachment())
+		attachmentGroup.DELETE("/:attachmentId", r.attachmentHandler.DeleteAttachment())
+	}
+
+	r.apiGroup.GET("/files/*key", r.fileHandler.ServeFile())
+}
+
+func NewAttachmentRoutes(
+	apiGroup *gin.RouterGroup,
+	attachmentHandler *handlers.AttachmentHandler,
+	fileHandler *handlers.FileHandler,
+	logger logger.Logger,
+) *AttachmentRoutes {
+	return &AttachmentRoutes{
+		apiGroup:          apiGroup,
+		attachmentHandler: attachmentHandler,
+		fileHandler:       fileHandler,
+		logger:            logger,
+	}
+}
@@ -0,0 +1,220 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/domain/repositories"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/internal/infra/http/middlewares"
+	"github.com/stra1g/saver-api/internal/infra/http/routes"
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stra1g/saver-api/pkg/query"
+	mocks "github.com/stra1g/saver-api/pkg/testutils/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// The fake services embed the interface they stand in for, left nil, so a
+// call the test does not set up panics instead of passing unnoticed.
+
+type fakeRuleService struct {
+	services.RuleService
+	mock.Mock
+}
+
+func (s *fakeRuleService) ListRules(userID string, filter repositories.RuleFilter, page query.Page) ([]*entities.Rule, *query.Cursor, error) {
+	args := s.Called(userID)
+	return args.Get(0).([]*entities.Rule), nil, args.Error(1)
+}
+
+type fakePayeeService struct {
+	services.PayeeService
+	mock.Mock
+}
+
+func (s *fakePayeeService) ListPayees(userID string, filter repositories.PayeeFilter, page query.Page) ([]*entities.Payee, *query.Cursor, error) {
+	args := s.Called(userID)
+	return args.Get(0).([]*entities.Payee), nil, args.Error(1)
+}
+
+type fakeCategoryService struct {
+	services.CategoryService
+}
+
+type fakeNotificationService struct {
+	services.NotificationService
+}
+
+type fakeTransactionService struct {
+	services.TransactionService
+	mock.Mock
+}
+
+func (s *fakeTransactionService) GetTransaction(walletID, actorID, transactionID string) (*entities.Transaction, error) {
+	args := s.Called(walletID, actorID, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+type fakeCommentService struct {
+	services.CommentService
+	mock.Mock
+}
+
+func (s *fakeCommentService) ListComments(walletID, actorID, transactionID string) ([]*entities.Comment, error) {
+	args := s.Called(walletID, actorID, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Comment), args.Error(1)
+}
+
+// newRouter returns a router with the error middleware of the server, the
+// group to set routes up on and a logger that accepts anything.
+func newRouter() (*gin.Engine, *gin.RouterGroup, *mocks.MockLogger) {
+	gin.SetMode(gin.TestMode)
+
+	log := mocks.NewMockLogger()
+	log.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	log.On("Error", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	router := gin.New()
+	router.Use(middlewares.NewErrorHandler(log))
+	return router, router.Group("/api/v1"), log
+}
+
+// serve sends a request as actorID, or without X-User-ID when it is empty.
+func serve(router *gin.Engine, method, path, actorID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	if actorID != "" {
+		req.Header.Set("X-User-ID", actorID)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestUserRoutes_OnlyServeTheirUser(t *testing.T) {
+	router, api, log := newRouter()
+	routes.NewRuleRoutes(api, handlers.NewRuleHandler(new(fakeRuleService), log), log).SetupRoutes()
+	routes.NewPayeeRoutes(api, handlers.NewPayeeHandler(new(fakePayeeService), log), log).SetupRoutes()
+	routes.NewCategoryRoutes(api, handlers.NewCategoryHandler(new(fakeCategoryService), log), log).SetupRoutes()
+	routes.NewNotificationRoutes(api, handlers.NewNotificationHandler(new(fakeNotificationService), log), log).SetupRoutes()
+	routes.NewTransactionRoutes(api, handlers.NewTransactionHandler(new(fakeTransactionService), log), log).SetupRoutes()
+
+	endpoints := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/users/user-id/rules"},
+		{http.MethodGet, "/users/user-id/rules"},
+		{http.MethodPost, "/users/user-id/rules/test"},
+		{http.MethodPost, "/users/user-id/rules/apply"},
+		{http.MethodPut, "/users/user-id/rules/rule-id"},
+		{http.MethodDelete, "/users/user-id/rules/rule-id"},
+		{http.MethodPost, "/users/user-id/payees"},
+		{http.MethodGet, "/users/user-id/payees"},
+		{http.MethodGet, "/users/user-id/payees/suggest"},
+		{http.MethodPut, "/users/user-id/payees/payee-id"},
+		{http.MethodDelete, "/users/user-id/payees/payee-id"},
+		{http.MethodPost, "/users/user-id/payees/payee-id/merge"},
+		{http.MethodPost, "/users/user-id/categories"},
+		{http.MethodGet, "/users/user-id/categories"},
+		{http.MethodPut, "/users/user-id/categories/category-id"},
+		{http.MethodDelete, "/users/user-id/categories/category-id"},
+		{http.MethodPost, "/users/user-id/categories/category-id/merge"},
+		{http.MethodGet, "/users/user-id/notifications"},
+		{http.MethodPost, "/users/user-id/notifications/read"},
+		{http.MethodGet, "/users/user-id/transactions/search"},
+		{http.MethodPost, "/users/user-id/transactions/bulk"},
+	}
+
+	for _, endpoint := range endpoints {
+		t.Run(endpoint.method+" "+endpoint.path, func(t *testing.T) {
+			assert.Equal(t, http.StatusUnauthorized, serve(router, endpoint.method, endpoint.path, "").Code)
+			assert.Equal(t, http.StatusForbidden, serve(router, endpoint.method, endpoint.path, "other-user-id").Code)
+		})
+	}
+}
+
+func TestUserRoutes_ServeTheCaller(t *testing.T) {
+	router, api, log := newRouter()
+	rules := new(fakeRuleService)
+	rules.On("ListRules", "user-id").Return([]*entities.Rule{}, nil)
+	payees := new(fakePayeeService)
+	payees.On("ListPayees", "user-id").Return([]*entities.Payee{}, nil)
+	routes.NewRuleRoutes(api, handlers.NewRuleHandler(rules, log), log).SetupRoutes()
+	routes.NewPayeeRoutes(api, handlers.NewPayeeHandler(payees, log), log).SetupRoutes()
+
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/users/user-id/rules", "user-id").Code)
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/users/user-id/payees", "user-id").Code)
+	rules.AssertExpectations(t)
+	payees.AssertExpectations(t)
+}
+
+func TestWalletRoutes_ActAsTheCaller(t *testing.T) {
+	amount, err := money.Parse("42.90", "BRL")
+	require.NoError(t, err)
+	transaction, err := entities.NewTransaction("wallet-id", entities.TransactionTypeExpense, amount, time.Now(), "Groceries", "", "member-id")
+	require.NoError(t, err)
+
+	t.Run("member", func(t *testing.T) {
+		router, api, log := newRouter()
+		transactions := new(fakeTransactionService)
+		transactions.On("GetTransaction", "wallet-id", "member-id", "transaction-id").Return(transaction, nil)
+		routes.NewTransactionRoutes(api, handlers.NewTransactionHandler(transactions, log), log).SetupRoutes()
+
+		recorder := serve(router, http.MethodGet, "/wallets/wallet-id/transactions/transaction-id", "member-id")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		transactions.AssertExpectations(t)
+	})
+
+	t.Run("without X-User-ID", func(t *testing.T) {
+		router, api, log := newRouter()
+		transactions := new(fakeTransactionService)
+		comments := new(fakeCommentService)
+		routes.NewTransactionRoutes(api, handlers.NewTransactionHandler(transactions, log), log).SetupRoutes()
+		routes.NewCommentRoutes(api, handlers.NewCommentHandler(comments, log), log).SetupRoutes()
+
+		assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodGet, "/wallets/wallet-id/transactions/transaction-id", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodGet, "/wallets/wallet-id/transactions/transaction-id/comments", "").Code)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		router, api, log := newRouter()
+		transactions := new(fakeTransactionService)
+		transactions.On("GetTransaction", "wallet-id", "stranger-id", "transaction-id").Return(nil, services.ErrWalletForbidden)
+		comments := new(fakeCommentService)
+		comments.On("ListComments", "wallet-id", "stranger-id", "transaction-id").Return(nil, services.ErrWalletForbidden)
+		routes.NewTransactionRoutes(api, handlers.NewTransactionHandler(transactions, log), log).SetupRoutes()
+		routes.NewCommentRoutes(api, handlers.NewCommentHandler(comments, log), log).SetupRoutes()
+
+		assert.Equal(t, http.StatusForbidden, serve(router, http.MethodGet, "/wallets/wallet-id/transactions/transaction-id", "stranger-id").Code)
+		assert.Equal(t, http.StatusForbidden, serve(router, http.MethodGet, "/wallets/wallet-id/transactions/transaction-id/comments", "stranger-id").Code)
+		transactions.AssertExpectations(t)
+		comments.AssertExpectations(t)
+	})
+}
+
+func TestWalletRoutes_RejectMalformedFilterIDs(t *testing.T) {
+	router, api, log := newRouter()
+	routes.NewTransactionRoutes(api, handlers.NewTransactionHandler(new(fakeTransactionService), log), log).SetupRoutes()
+
+	recorder := serve(router, http.MethodGet, "/wallets/wallet-id/transactions?tags=groceries", "member-id")
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"field":"tags"`)
+}
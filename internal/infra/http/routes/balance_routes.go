@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type BalanceRoutes struct {
+	apiGroup       *gin.RouterGroup
+	balanceHandler *handlers.BalanceHandler
+	logger         logger.Logger
+}
+
+func (r *BalanceRoutes) SetupRoutes() {
+	r.logger.Info("Setting up balance routes", map[string]interface{}{})
+
+	r.apiGroup.GET("/wallets/:id/balances", r.balanceHandler.GetBalances())
+	r.apiGroup.GET("/wallets/:id/balances/as-of", r.balanceHandler.GetBalancesAsOf())
+}
+
+func NewBalanceRoutes(
+	apiGroup *gin.RouterGroup,
+	balanceHandler *handlers.BalanceHandler,
+	logger logger.Logger,
+) *BalanceRoutes {
+	return &BalanceRoutes{
+		apiGroup:       apiGroup,
+		balanceHandler: balanceHandler,
+		logger:         logger,
+	}
+}
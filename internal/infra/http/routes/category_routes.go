@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type CategoryRoutes struct {
+	apiGroup        *gin.RouterGroup
+	categoryHandler *handlers.CategoryHandler
+	logger          logger.Logger
+}
+
+func (r *CategoryRoutes) SetupRoutes() {
+	r.logger.Info("Setting up category routes", map[string]interface{}{})
+
+	categoriesGroup := r.apiGroup.Group("/users/:id/categories")
+	{
+		categoriesGroup.POST("", r.categoryHandler.CreateCategory())
+		categoriesGroup.GET("", r.categoryHandler.ListCategories())
+		categoriesGroup.PUT("/:categoryId", r.categoryHandler.UpdateCategory())
+		categoriesGroup.DELETE("/:categoryId", r.categoryHandler.DeleteCategory())
+		categoriesGroup.POST("/:categoryId/merge", r.categoryHandler.MergeCategory())
+	}
+}
+
+func NewCategoryRoutes(
+	apiGroup *gin.RouterGroup,
+	categoryHandler *handlers.CategoryHandler,
+	logger logger.Logger,
+) *CategoryRoutes {
+	return &CategoryRoutes{
+		apiGroup:        apiGroup,
+		categoryHandler: categoryHandler,
+		logger:          logger,
+	}
+}
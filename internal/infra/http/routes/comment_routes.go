@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type CommentRoutes struct {
+	apiGroup       *gin.RouterGroup
+	commentHandler *handlers.CommentHandler
+	logger         logger.Logger
+}
+
+func (r *CommentRoutes) SetupRoutes() {
+	r.logger.Info("Setting up comment routes", map[string]interface{}{})
+
+	commentGroup := r.apiGroup.Group("/wallets/:id/transactions/:transactionId/comments")
+	{
+		commentGroup.POST("", r.commentHandler.CreateComment())
+		commentGroup.GET("", r.commentHandler.ListComments())
+		commentGroup.PUT("/:commentId", r.commentHandler.UpdateComment())
+		commentGroup.DELETE("/:commentId", r.commentHandler.DeleteComment())
+		commentGroup.GET("/:commentId/edits", r.commentHandler.ListCommentEdits())
+	}
+}
+
+func NewCommentRoutes(
+	apiGroup *gin.RouterGroup,
+	commentHandler *handlers.CommentHandler,
+	logger logger.Logger,
+) *CommentRoutes {
+	return &CommentRoutes{
+		apiGroup:       apiGroup,
+		commentHandler: commentHandler,
+		logger:         logger,
+	}
+}
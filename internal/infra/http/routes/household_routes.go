@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type HouseholdRoutes struct {
+	apiGroup         *gin.RouterGroup
+	householdHandler *handlers.HouseholdHandler
+	logger           logger.Logger
+}
+
+func (r *HouseholdRoutes) SetupRoutes() {
+	r.logger.Info("Setting up household routes", map[string]interface{}{})
+
+	householdsGroup := r.apiGroup.Group("/households")
+	{
+		householdsGroup.POST("", r.householdHandler.CreateHousehold())
+		householdsGroup.GET("/:id", r.householdHandler.GetHousehold())
+		householdsGroup.POST("/:id/members", r.householdHandler.AddMember())
+		householdsGroup.PUT("/:id/members/:userId", r.householdHandler.ChangeMemberRole())
+		householdsGroup.DELETE("/:id/members/:userId", r.householdHandler.RemoveMember())
+	}
+}
+
+func NewHouseholdRoutes(
+	apiGroup *gin.RouterGroup,
+	householdHandler *handlers.HouseholdHandler,
+	logger logger.Logger,
+) *HouseholdRoutes {
+	return &HouseholdRoutes{
+		apiGroup:         apiGroup,
+		householdHandler: householdHandler,
+		logger:           logger,
+	}
+}
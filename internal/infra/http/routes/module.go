@@ -6,11 +6,68 @@ import (
 
 var Module = fx.Options(
 	fx.Provide(NewUserRoutes),
+	fx.Provide(NewTransactionRoutes),
+	fx.Provide(NewCategoryRoutes),
+	fx.Provide(NewTagRoutes),
+	fx.Provide(NewTransferRoutes),
+	fx.Provide(NewRecurringTransactionRoutes),
+	fx.Provide(NewReportRoutes),
+	fx.Provide(NewBalanceRoutes),
+	fx.Provide(NewSettlementRoutes),
+	fx.Provide(NewAttachmentRoutes),
+	fx.Provide(NewPayeeRoutes),
+	fx.Provide(NewRuleRoutes),
+	fx.Provide(NewWalletRoutes),
+	fx.Provide(NewHouseholdRoutes),
+	fx.Provide(NewApprovalRoutes),
+	fx.Provide(NewReconciliationRoutes),
+	fx.Provide(NewSpendingLimitRoutes),
+	fx.Provide(NewNotificationRoutes),
+	fx.Provide(NewActivityRoutes),
+	fx.Provide(NewCommentRoutes),
 	fx.Invoke(setupRoutes),
 )
 
 func setupRoutes(
 	userRoutes *UserRoutes,
+	transactionRoutes *TransactionRoutes,
+	categoryRoutes *CategoryRoutes,
+	tagRoutes *TagRoutes,
+	transferRoutes *TransferRoutes,
+	recurringTransactionRoutes *RecurringTransactionRoutes,
+	reportRoutes *ReportRoutes,
+	balanceRoutes *BalanceRoutes,
+	settlementRoutes *SettlementRoutes,
+	attachmentRoutes *AttachmentRoutes,
+	payeeRoutes *PayeeRoutes,
+	ruleRoutes *RuleRoutes,
+	walletRoutes *WalletRoutes,
+	householdRoutes *HouseholdRoutes,
+	approvalRoutes *ApprovalRoutes,
+	reconciliationRoutes *ReconciliationRoutes,
+	spendingLimitRoutes *SpendingLimitRoutes,
+	notificationRoutes *NotificationRoutes,
+	activityRoutes *ActivityRoutes,
+	commentRoutes *CommentRoutes,
 ) {
 	userRoutes.SetupRoutes()
+	transactionRoutes.SetupRoutes()
+	categoryRoutes.SetupRoutes()
+	tagRoutes.SetupRoutes()
+	transferRoutes.SetupRoutes()
+	recurringTransactionRoutes.SetupRoutes()
+	reportRoutes.SetupRoutes()
+	balanceRoutes.SetupRoutes()
+	settlementRoutes.SetupRoutes()
+	attachmentRoutes.SetupRoutes()
+	payeeRoutes.SetupRoutes()
+	ruleRoutes.SetupRoutes()
+	walletRoutes.SetupRoutes()
+	householdRoutes.SetupRoutes()
+	approvalRoutes.SetupRoutes()
+	reconciliationRoutes.SetupRoutes()
+	spendingLimitRoutes.SetupRoutes()
+	notificationRoutes.SetupRoutes()
+	activityRoutes.SetupRoutes()
+	commentRoutes.SetupRoutes()
 }
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type NotificationRoutes struct {
+	apiGroup            *gin.RouterGroup
+	notificationHandler *handlers.NotificationHandler
+	logger              logger.Logger
+}
+
+func (r *NotificationRoutes) SetupRoutes() {
+	r.logger.Info("Setting up notification routes", map[string]interface{}{})
+
+	notificationsGroup := r.apiGroup.Group("/users/:id/notifications")
+	{
+		notificationsGroup.GET("", r.notificationHandler.ListNotifications())
+		notificationsGroup.POST("/read", r.notificationHandler.MarkNotificationsRead())
+	}
+}
+
+func NewNotificationRoutes(
+	apiGroup *gin.RouterGroup,
+	notificationHandler *handlers.NotificationHandler,
+	logger logger.Logger,
+) *NotificationRoutes {
+	return &NotificationRoutes{
+		apiGroup:            apiGroup,
+		notificationHandler: notificationHandler,
+		logger:              logger,
+	}
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type PayeeRoutes struct {
+	apiGroup     *gin.RouterGroup
+	payeeHandler *handlers.PayeeHandler
+	logger       logger.Logger
+}
+
+func (r *PayeeRoutes) SetupRoutes() {
+	r.logger.Info("Setting up payee routes", map[string]interface{}{})
+
+	payeesGroup := r.apiGroup.Group("/users/:id/payees")
+	{
+		payeesGroup.POST("", r.payeeHandler.CreatePayee())
+		payeesGroup.GET("", r.payeeHandler.ListPayees())
+		payeesGroup.GET("/suggest", r.payeeHandler.SuggestPayee())
+		payeesGroup.PUT("/:payeeId", r.payeeHandler.UpdatePayee())
+		payeesGroup.DELETE("/:payeeId", r.payeeHandler.DeletePayee())
+		payeesGroup.POST("/:payeeId/merge", r.payeeHandler.MergePayee())
+	}
+}
+
+func NewPayeeRoutes(
+	apiGroup *gin.RouterGroup,
+	payeeHandler *handlers.PayeeHandler,
+	logger logger.Logger,
+) *PayeeRoutes {
+	return &PayeeRoutes{
+		apiGroup:     apiGroup,
+		payeeHandler: payeeHandler,
+		logger:       logger,
+	}
+}
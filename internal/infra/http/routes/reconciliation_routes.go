@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type ReconciliationRoutes struct {
+	apiGroup              *gin.RouterGroup
+	reconciliationHandler *handlers.ReconciliationHandler
+	logger                logger.Logger
+}
+
+func (r *ReconciliationRoutes) SetupRoutes() {
+	r.logger.Info("Setting up reconciliation routes", map[string]interface{}{})
+
+	reconciliationsGroup := r.apiGroup.Group("/wallets/:id/reconciliations")
+	{
+		reconciliationsGroup.POST("", r.reconciliationHandler.StartReconciliation())
+		reconciliationsGroup.GET("/:reconciliationId", r.reconciliationHandler.GetReconciliation())
+		reconciliationsGroup.PATCH("/:reconciliationId/transactions", r.reconciliationHandler.ClearTransactions())
+		reconciliationsGroup.POST("/:reconciliationId/complete", r.reconciliationHandler.CompleteReconciliation())
+		reconciliationsGroup.DELETE("/:reconciliationId", r.reconciliationHandler.CancelReconciliation())
+	}
+}
+
+func NewReconciliationRoutes(
+	apiGroup *gin.RouterGroup,
+	reconciliationHandler *handlers.ReconciliationHandler,
+	logger logger.Logger,
+) *ReconciliationRoutes {
+	return &ReconciliationRoutes{
+		apiGroup:              apiGroup,
+		reconciliationHandler: reconciliationHandler,
+		logger:                logger,
+	}
+}
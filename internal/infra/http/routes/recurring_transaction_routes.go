@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type RecurringTransactionRoutes struct {
+	apiGroup         *gin.RouterGroup
+	recurringHandler *handlers.RecurringTransactionHandler
+	logger           logger.Logger
+}
+
+func (r *RecurringTransactionRoutes) SetupRoutes() {
+	r.logger.Info("Setting up recurring transaction routes", map[string]interface{}{})
+
+	recurringGroup := r.apiGroup.Group("/wallets/:id/recurring-transactions")
+	{
+		recurringGroup.POST("", r.recurringHandler.CreateRecurringTransaction())
+		recurringGroup.GET("", r.recurringHandler.ListRecurringTransactions())
+		recurringGroup.GET("/:recurringId", r.recurringHandler.GetRecurringTransaction())
+		recurringGroup.PUT("/:recurringId", r.recurringHandler.UpdateRecurringTransaction())
+		recurringGroup.DELETE("/:recurringId", r.recurringHandler.DeleteRecurringTransaction())
+		recurringGroup.GET("/:recurringId/occurrences", r.recurringHandler.PreviewOccurrences())
+		recurringGroup.PUT("/:recurringId/occurrences/:date", r.recurringHandler.OverrideOccurrence())
+		recurringGroup.DELETE("/:recurringId/occurrences/:date", r.recurringHandler.RestoreOccurrence())
+	}
+}
+
+func NewRecurringTransactionRoutes(
+	apiGroup *gin.RouterGroup,
+	recurringHandler *handlers.RecurringTransactionHandler,
+	logger logger.Logger,
+) *RecurringTransactionRoutes {
+	return &RecurringTransactionRoutes{
+		apiGroup:         apiGroup,
+		recurringHandler: recurringHandler,
+		logger:           logger,
+	}
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type ReportRoutes struct {
+	apiGroup      *gin.RouterGroup
+	reportHandler *handlers.ReportHandler
+	logger        logger.Logger
+}
+
+func (r *ReportRoutes) SetupRoutes() {
+	r.logger.Info("Setting up report routes", map[string]interface{}{})
+
+	reportsGroup := r.apiGroup.Group("/wallets/:id/reports")
+	{
+		reportsGroup.GET("/categories", r.reportHandler.CategoryReport())
+	}
+
+	householdReportsGroup := r.apiGroup.Group("/households/:id/reports")
+	{
+		householdReportsGroup.GET("/categories", r.reportHandler.HouseholdCategoryReport())
+	}
+}
+
+func NewReportRoutes(
+	apiGroup *gin.RouterGroup,
+	reportHandler *handlers.ReportHandler,
+	logger logger.Logger,
+) *ReportRoutes {
+	return &ReportRoutes{
+		apiGroup:      apiGroup,
+		reportHandler: reportHandler,
+		logger:        logger,
+	}
+}
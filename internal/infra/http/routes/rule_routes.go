@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type RuleRoutes struct {
+	apiGroup    *gin.RouterGroup
+	ruleHandler *handlers.RuleHandler
+	logger      logger.Logger
+}
+
+func (r *RuleRoutes) SetupRoutes() {
+	r.logger.Info("Setting up rule routes", map[string]interface{}{})
+
+	rulesGroup := r.apiGroup.Group("/users/:id/rules")
+	{
+		rulesGroup.POST("", r.ruleHandler.CreateRule())
+		rulesGroup.GET("", r.ruleHandler.ListRules())
+		rulesGroup.POST("/test", r.ruleHandler.TestRule())
+		rulesGroup.POST("/apply", r.ruleHandler.ApplyRules())
+		rulesGroup.PUT("/:ruleId", r.ruleHandler.UpdateRule())
+		rulesGroup.DELETE("/:ruleId", r.ruleHandler.DeleteRule())
+	}
+}
+
+func NewRuleRoutes(
+	apiGroup *gin.RouterGroup,
+	ruleHandler *handlers.RuleHandler,
+	logger logger.Logger,
+) *RuleRoutes {
+	return &RuleRoutes{
+		apiGroup:    apiGroup,
+		ruleHandler: ruleHandler,
+		logger:      logger,
+	}
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type SettlementRoutes struct {
+	apiGroup          *gin.RouterGroup
+	settlementHandler *handlers.SettlementHandler
+	logger            logger.Logger
+}
+
+func (r *SettlementRoutes) SetupRoutes() {
+	r.logger.Info("Setting up settlement routes", map[string]interface{}{})
+
+	settlementGroup := r.apiGroup.Group("/wallets/:id/settlements")
+	{
+		settlementGroup.GET("/plan", r.settlementHandler.PlanSettlements())
+		settlementGroup.POST("", r.settlementHandler.RecordSettlement())
+		settlementGroup.GET("", r.settlementHandler.ListSettlements())
+		settlementGroup.GET("/:settlementId", r.settlementHandler.GetSettlement())
+		settlementGroup.POST("/:settlementId/void", r.settlementHandler.VoidSettlement())
+	}
+}
+
+func NewSettlementRoutes(
+	apiGroup *gin.RouterGroup,
+	settlementHandler *handlers.SettlementHandler,
+	logger logger.Logger,
+) *SettlementRoutes {
+	return &SettlementRoutes{
+		apiGroup:          apiGroup,
+		settlementHandler: settlementHandler,
+		logger:            logger,
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type SpendingLimitRoutes struct {
+	apiGroup     *gin.RouterGroup
+	limitHandler *handlers.SpendingLimitHandler
+	logger       logger.Logger
+}
+
+func (r *SpendingLimitRoutes) SetupRoutes() {
+	r.logger.Info("Setting up spending limit routes", map[string]interface{}{})
+
+	limitsGroup := r.apiGroup.Group("/wallets/:id/limits")
+	{
+		limitsGroup.POST("", r.limitHandler.CreateSpendingLimit())
+		limitsGroup.GET("", r.limitHandler.ListSpendingLimits())
+		limitsGroup.PUT("/:limitId", r.limitHandler.UpdateSpendingLimit())
+		limitsGroup.DELETE("/:limitId", r.limitHandler.DeleteSpendingLimit())
+	}
+}
+
+func NewSpendingLimitRoutes(
+	apiGroup *gin.RouterGroup,
+	limitHandler *handlers.SpendingLimitHandler,
+	logger logger.Logger,
+) *SpendingLimitRoutes {
+	return &SpendingLimitRoutes{
+		apiGroup:     apiGroup,
+		limitHandler: limitHandler,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/domain/entities"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type TagRoutes struct {
+	apiGroup   *gin.RouterGroup
+	tagHandler *handlers.TagHandler
+	logger     logger.Logger
+}
+
+func (r *TagRoutes) SetupRoutes() {
+	r.logger.Info("Setting up tag routes", map[string]interface{}{})
+
+	r.setupScope(r.apiGroup.Group("/users/:id/tags"), entities.TagScopeUser)
+	r.setupScope(r.apiGroup.Group("/wallets/:id/tags"), entities.TagScopeWallet)
+}
+
+func (r *TagRoutes) setupScope(tagsGroup *gin.RouterGroup, scope entities.TagScope) {
+	tagsGroup.POST("", r.tagHandler.CreateTag(scope))
+	tagsGroup.GET("", r.tagHandler.ListTags(scope))
+	tagsGroup.PUT("/:tagId", r.tagHandler.RenameTag(scope))
+	tagsGroup.DELETE("/:tagId", r.tagHandler.DeleteTag(scope))
+	tagsGroup.POST("/:tagId/merge", r.tagHandler.MergeTag(scope))
+}
+
+func NewTagRoutes(
+	apiGroup *gin.RouterGroup,
+	tagHandler *handlers.TagHandler,
+	logger logger.Logger,
+) *TagRoutes {
+	return &TagRoutes{
+		apiGroup:   apiGroup,
+		tagHandler: tagHandler,
+		logger:     logger,
+	}
+}
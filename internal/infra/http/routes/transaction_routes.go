@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type TransactionRoutes struct {
+	apiGroup           *gin.RouterGroup
+	transactionHandler *handlers.TransactionHandler
+	logger             logger.Logger
+}
+
+func (r *TransactionRoutes) SetupRoutes() {
+	r.logger.Info("Setting up transaction routes", map[string]interface{}{})
+
+	transactionsGroup := r.apiGroup.Group("/wallets/:id/transactions")
+	{
+		transactionsGroup.POST("", r.transactionHandler.CreateTransaction())
+		transactionsGroup.GET("", r.transactionHandler.ListTransactions())
+		transactionsGroup.GET("/:transactionId", r.transactionHandler.GetTransaction())
+		transactionsGroup.PUT("/:transactionId", r.transactionHandler.UpdateTransaction())
+		transactionsGroup.DELETE("/:transactionId", r.transactionHandler.DeleteTransaction())
+		transactionsGroup.PUT("/:transactionId/status", r.transactionHandler.SetTransactionStatus())
+	}
+
+	r.apiGroup.GET("/users/:id/transactions/search", r.transactionHandler.SearchTransactions())
+	r.apiGroup.POST("/users/:id/transactions/bulk", r.transactionHandler.BulkUpdateTransactions())
+}
+
+func NewTransactionRoutes(
+	apiGroup *gin.RouterGroup,
+	transactionHandler *handlers.TransactionHandler,
+	logger logger.Logger,
+) *TransactionRoutes {
+	return &TransactionRoutes{
+		apiGroup:           apiGroup,
+		transactionHandler: transactionHandler,
+		logger:             logger,
+	}
+}
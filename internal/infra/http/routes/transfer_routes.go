@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type TransferRoutes struct {
+	apiGroup        *gin.RouterGroup
+	transferHandler *handlers.TransferHandler
+	logger          logger.Logger
+}
+
+func (r *TransferRoutes) SetupRoutes() {
+	r.logger.Info("Setting up transfer routes", map[string]interface{}{})
+
+	transfersGroup := r.apiGroup.Group("/wallets/:id/transfers")
+	{
+		transfersGroup.POST("", r.transferHandler.CreateTransfer())
+		transfersGroup.GET("/:transferId", r.transferHandler.GetTransfer())
+		transfersGroup.PUT("/:transferId", r.transferHandler.UpdateTransfer())
+		transfersGroup.DELETE("/:transferId", r.transferHandler.DeleteTransfer())
+	}
+}
+
+func NewTransferRoutes(
+	apiGroup *gin.RouterGroup,
+	transferHandler *handlers.TransferHandler,
+	logger logger.Logger,
+) *TransferRoutes {
+	return &TransferRoutes{
+		apiGroup:        apiGroup,
+		transferHandler: transferHandler,
+		logger:          logger,
+	}
+}
@@ -18,6 +18,9 @@ func (r *UserRoutes) SetupRoutes() {
 	usersGroup := r.apiGroup.Group("/users")
 	{
 		usersGroup.POST("", r.userHandler.CreateUser())
+		usersGroup.POST("/:id/dependents", r.userHandler.CreateDependent())
+		usersGroup.GET("/:id/dependents", r.userHandler.ListDependents())
+		usersGroup.PUT("/:id/base-currency", r.userHandler.SetBaseCurrency())
 	}
 }
 
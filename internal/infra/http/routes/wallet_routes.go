@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stra1g/saver-api/internal/infra/http/handlers"
+	"github.com/stra1g/saver-api/pkg/logger"
+)
+
+type WalletRoutes struct {
+	apiGroup      *gin.RouterGroup
+	walletHandler *handlers.WalletHandler
+	logger        logger.Logger
+}
+
+func (r *WalletRoutes) SetupRoutes() {
+	r.logger.Info("Setting up wallet routes", map[string]interface{}{})
+
+	walletsGroup := r.apiGroup.Group("/wallets")
+	{
+		walletsGroup.POST("", r.walletHandler.CreateWallet())
+		walletsGroup.GET("", r.walletHandler.ListWallets())
+		walletsGroup.GET("/:id", r.walletHandler.GetWallet())
+		walletsGroup.POST("/:id/members", r.walletHandler.AddMember())
+		walletsGroup.DELETE("/:id/members/:userId", r.walletHandler.RemoveMember())
+		walletsGroup.PUT("/:id/approval-policy", r.walletHandler.SetApprovalPolicy())
+	}
+}
+
+func NewWalletRoutes(
+	apiGroup *gin.RouterGroup,
+	walletHandler *handlers.WalletHandler,
+	logger logger.Logger,
+) *WalletRoutes {
+	return &WalletRoutes{
+		apiGroup:      apiGroup,
+		walletHandler: walletHandler,
+		logger:        logger,
+	}
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// expireInterval is how often overdue approval requests are closed. Owners
+// cannot review an overdue request anyway, so this only bounds how long its
+// expense stays held.
+const expireInterval = 15 * time.Minute
+
+// ApprovalExpirer expires overdue approval requests and deletes their
+// expenses, once at start-up and then on every tick.
+type ApprovalExpirer struct {
+	approvalService services.ApprovalService
+	logger          logger.Logger
+	stop            chan struct{}
+	done            chan struct{}
+}
+
+func (e *ApprovalExpirer) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+
+	for {
+		e.expire()
+
+		select {
+		case <-ticker.C:
+		case <-e.stop:
+			return
+		}
+	}
+}
+
+func (e *ApprovalExpirer) expire() {
+	expired, err := e.approvalService.ExpireOverdue(time.Now())
+	if err != nil {
+		e.logger.Error(err, "Failed to expire approvals", map[string]interface{}{})
+		return
+	}
+
+	if expired > 0 {
+		e.logger.Info("Overdue approvals expired", map[string]interface{}{
+			"count": expired,
+		})
+	}
+}
+
+func NewApprovalExpirer(
+	approvalService services.ApprovalService,
+	logger logger.Logger,
+) *ApprovalExpirer {
+	return &ApprovalExpirer{
+		approvalService: approvalService,
+		logger:          logger,
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+}
+
+func RegisterApprovalExpirer(lc fx.Lifecycle, expirer *ApprovalExpirer) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go expirer.run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(expirer.stop)
+			select {
+			case <-expirer.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// snapshotInterval is how often the balances of the previous day are
+// snapshotted. Each run only reads the transactions since the previous
+// snapshot of a wallet, and retaking a day just overwrites it.
+const snapshotInterval = 6 * time.Hour
+
+// BalanceSnapshotter snapshots the balance of every wallet at the end of the
+// previous day, once at start-up and then on every tick.
+type BalanceSnapshotter struct {
+	balanceService services.BalanceService
+	logger         logger.Logger
+	stop           chan struct{}
+	done           chan struct{}
+}
+
+func (s *BalanceSnapshotter) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		s.snapshot()
+
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *BalanceSnapshotter) snapshot() {
+	asOf := time.Now().UTC().AddDate(0, 0, -1)
+	count, err := s.balanceService.SnapshotBalances(asOf)
+	if err != nil {
+		s.logger.Error(err, "Failed to snapshot balances", map[string]interface{}{})
+		return
+	}
+
+	s.logger.Info("Balances snapshotted", map[string]interface{}{
+		"as_of": asOf.Format("2006-01-02"),
+		"count": count,
+	})
+}
+
+func NewBalanceSnapshotter(
+	balanceService services.BalanceService,
+	logger logger.Logger,
+) *BalanceSnapshotter {
+	return &BalanceSnapshotter{
+		balanceService: balanceService,
+		logger:         logger,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+func RegisterBalanceSnapshotter(lc fx.Lifecycle, snapshotter *BalanceSnapshotter) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go snapshotter.run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(snapshotter.stop)
+			select {
+			case <-snapshotter.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,16 @@
+package jobs
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewRecurringMaterializer),
+	fx.Provide(NewTransactionPurger),
+	fx.Provide(NewScheduledPromoter),
+	fx.Provide(NewApprovalExpirer),
+	fx.Provide(NewBalanceSnapshotter),
+	fx.Invoke(RegisterRecurringMaterializer),
+	fx.Invoke(RegisterTransactionPurger),
+	fx.Invoke(RegisterScheduledPromoter),
+	fx.Invoke(RegisterApprovalExpirer),
+	fx.Invoke(RegisterBalanceSnapshotter),
+)
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// materializeInterval is how often due recurring transactions are created.
+// Materializing is idempotent, so running it often only costs a query.
+const materializeInterval = time.Hour
+
+// RecurringMaterializer creates the transactions of due recurring
+// transactions in the background, once at start-up and then on every tick.
+type RecurringMaterializer struct {
+	recurringService services.RecurringTransactionService
+	logger           logger.Logger
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+func (m *RecurringMaterializer) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(materializeInterval)
+	defer ticker.Stop()
+
+	for {
+		m.materialize()
+
+		select {
+		case <-ticker.C:
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *RecurringMaterializer) materialize() {
+	created, err := m.recurringService.MaterializeDue(time.Now())
+	if err != nil {
+		m.logger.Error(err, "Failed to materialize recurring transactions", map[string]interface{}{})
+		return
+	}
+
+	if created > 0 {
+		m.logger.Info("Recurring transactions materialized", map[string]interface{}{
+			"count": created,
+		})
+	}
+}
+
+func NewRecurringMaterializer(
+	recurringService services.RecurringTransactionService,
+	logger logger.Logger,
+) *RecurringMaterializer {
+	return &RecurringMaterializer{
+		recurringService: recurringService,
+		logger:           logger,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+}
+
+func RegisterRecurringMaterializer(lc fx.Lifecycle, materializer *RecurringMaterializer) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go materializer.run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(materializer.stop)
+			select {
+			case <-materializer.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
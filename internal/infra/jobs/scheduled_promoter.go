@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// promoteInterval is how often scheduled transactions are checked for their
+// date. Promoting is idempotent, so running it often only costs a query.
+const promoteInterval = time.Hour
+
+// ScheduledPromoter makes scheduled transactions pending once their date
+// comes, once at start-up and then on every tick.
+type ScheduledPromoter struct {
+	transactionService services.TransactionService
+	logger             logger.Logger
+	stop               chan struct{}
+	done               chan struct{}
+}
+
+func (p *ScheduledPromoter) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(promoteInterval)
+	defer ticker.Stop()
+
+	for {
+		p.promote()
+
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+func (p *ScheduledPromoter) promote() {
+	promoted, err := p.transactionService.PromoteScheduled(time.Now())
+	if err != nil {
+		p.logger.Error(err, "Failed to promote scheduled transactions", map[string]interface{}{})
+		return
+	}
+
+	if promoted > 0 {
+		p.logger.Info("Scheduled transactions promoted", map[string]interface{}{
+			"count": promoted,
+		})
+	}
+}
+
+func NewScheduledPromoter(
+	transactionService services.TransactionService,
+	logger logger.Logger,
+) *ScheduledPromoter {
+	return &ScheduledPromoter{
+		transactionService: transactionService,
+		logger:             logger,
+		stop:               make(chan struct{}),
+		done:               make(chan struct{}),
+	}
+}
+
+func RegisterScheduledPromoter(lc fx.Lifecycle, promoter *ScheduledPromoter) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go promoter.run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(promoter.stop)
+			select {
+			case <-promoter.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stra1g/saver-api/internal/app/services"
+	"github.com/stra1g/saver-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+const (
+	// purgeInterval is how often deleted transactions are looked for.
+	purgeInterval = 24 * time.Hour
+	// deletedRetention is how long a deleted transaction and its
+	// attachments are kept before being purged.
+	deletedRetention = 30 * 24 * time.Hour
+)
+
+// TransactionPurger removes transactions deleted long enough ago for good,
+// along with their attachments, once at start-up and then on every tick.
+type TransactionPurger struct {
+	attachmentService services.AttachmentService
+	logger            logger.Logger
+	stop              chan struct{}
+	done              chan struct{}
+}
+
+func (p *TransactionPurger) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		p.purge()
+
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+func (p *TransactionPurger) purge() {
+	purged, err := p.attachmentService.PurgeDeletedTransactions(time.Now().Add(-deletedRetention))
+	if err != nil {
+		p.logger.Error(err, "Failed to purge deleted transactions", map[string]interface{}{})
+		return
+	}
+
+	if purged > 0 {
+		p.logger.Info("Deleted transactions purged", map[string]interface{}{
+			"count": purged,
+		})
+	}
+}
+
+func NewTransactionPurger(
+	attachmentService services.AttachmentService,
+	logger logger.Logger,
+) *TransactionPurger {
+	return &TransactionPurger{
+		attachmentService: attachmentService,
+		logger:            logger,
+		stop:              make(chan struct{}),
+		done:              make(chan struct{}),
+	}
+}
+
+func RegisterTransactionPurger(lc fx.Lifecycle, purger *TransactionPurger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go purger.run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(purger.stop)
+			select {
+			case <-purger.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type jsonMoney struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as a decimal string so clients never have
+// to round-trip it through a float: {"amount":"12.34","currency":"BRL"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount, err := json.Marshal(m.Decimal())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonMoney{Amount: amount, Currency: m.currency.Code})
+}
+
+// UnmarshalJSON accepts the amount either as a string or as a JSON number.
+// Numbers are read from their literal text, never as float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw jsonMoney
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	amount := bytes.TrimSpace(raw.Amount)
+	if len(amount) == 0 {
+		return fmt.Errorf("%w: amount is required", ErrInvalidAmount)
+	}
+
+	var text string
+	if amount[0] == '"' {
+		if err := json.Unmarshal(amount, &text); err != nil {
+			return err
+		}
+	} else {
+		text = string(amount)
+	}
+
+	parsed, err := Parse(text, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as its minor units, so it can be bound directly to
+// a bigint column. The currency lives in a sibling column (see Currency.Value).
+func (m Money) Value() (driver.Value, error) {
+	return m.amount, nil
+}
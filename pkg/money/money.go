@@ -0,0 +1,350 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrOverflow         = errors.New("amount overflows int64 minor units")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrInvalidRatios    = errors.New("invalid allocation ratios")
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?\d+(\.\d+)?$`)
+
+// Money is an exact monetary amount stored as an integer number of minor
+// units (cents, centavos...) of a currency. It never goes through floats.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New creates a Money from an amount already expressed in minor units.
+func New(minorUnits int64, currencyCode string) (Money, error) {
+	c, err := LookupCurrency(currencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minorUnits, currency: c}, nil
+}
+
+// Zero returns a zero amount in the given currency.
+func Zero(currencyCode string) (Money, error) {
+	return New(0, currencyCode)
+}
+
+// Parse reads a decimal string such as "-1234.56" in the given currency.
+// It fails when the value has more decimal places than the currency allows.
+func Parse(value, currencyCode string) (Money, error) {
+	c, err := LookupCurrency(currencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r, err := parseDecimal(value)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(pow10(c.Exponent)))
+	if !r.IsInt() {
+		return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, value, c.Exponent)
+	}
+
+	return fromBigInt(r.Num(), c)
+}
+
+// ParseRounded is like Parse but rounds extra decimal places using mode.
+func ParseRounded(value, currencyCode string, mode RoundingMode) (Money, error) {
+	c, err := LookupCurrency(currencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r, err := parseDecimal(value)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(pow10(c.Exponent)))
+	return fromBigInt(roundRat(r, mode), c)
+}
+
+// ParseRate reads an exact decimal factor such as an exchange rate.
+func ParseRate(value string) (*big.Rat, error) {
+	return parseDecimal(value)
+}
+
+func parseDecimal(value string) (*big.Rat, error) {
+	value = strings.TrimSpace(value)
+	if !decimalPattern.MatchString(value) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	return r, nil
+}
+
+func fromBigInt(v *big.Int, c Currency) (Money, error) {
+	if !v.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: v.Int64(), currency: c}, nil
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+func (m Money) MinorUnits() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+func (m Money) Sign() int {
+	switch {
+	case m.amount > 0:
+		return 1
+	case m.amount < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return m.currency.Code == other.currency.Code
+}
+
+func (m Money) Equal(other Money) bool {
+	return m.SameCurrency(other) && m.amount == other.amount
+}
+
+// Cmp returns -1, 0 or +1 like big.Int.Cmp. Both amounts must share a currency.
+func (m Money) Cmp(other Money) (int, error) {
+	if !m.SameCurrency(other) {
+		return 0, m.mismatch(other)
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, m.mismatch(other)
+	}
+	if (other.amount > 0 && m.amount > math.MaxInt64-other.amount) ||
+		(other.amount < 0 && m.amount < math.MinInt64-other.amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+func (m Money) Subtract(other Money) (Money, error) {
+	negated, err := other.Negate()
+	if err != nil {
+		return Money{}, err
+	}
+	return m.Add(negated)
+}
+
+func (m Money) Negate() (Money, error) {
+	if m.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: -m.amount, currency: m.currency}, nil
+}
+
+func (m Money) Abs() (Money, error) {
+	if m.amount < 0 {
+		return m.Negate()
+	}
+	return m, nil
+}
+
+// Sum adds amounts that all share currencyCode. An empty list sums to zero.
+func Sum(currencyCode string, amounts ...Money) (Money, error) {
+	total, err := Zero(currencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+	for _, a := range amounts {
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Multiply scales the amount by an exact factor, rounding to a minor unit.
+func (m Money) Multiply(factor *big.Rat, mode RoundingMode) (Money, error) {
+	r := new(big.Rat).SetInt64(m.amount)
+	r.Mul(r, factor)
+	return fromBigInt(roundRat(r, mode), m.currency)
+}
+
+// Convert expresses the amount in another currency using rate, the number of
+// units of the target currency per unit of the source currency.
+func (m Money) Convert(currencyCode string, rate *big.Rat, mode RoundingMode) (Money, error) {
+	target, err := LookupCurrency(currencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+	if rate == nil || rate.Sign() <= 0 {
+		return Money{}, fmt.Errorf("%w: exchange rate must be positive", ErrInvalidAmount)
+	}
+
+	r := new(big.Rat).SetInt64(m.amount)
+	r.Mul(r, rate)
+	r.Mul(r, new(big.Rat).SetInt(pow10(target.Exponent)))
+	r.Quo(r, new(big.Rat).SetInt(pow10(m.currency.Exponent)))
+	return fromBigInt(roundRat(r, mode), target)
+}
+
+// RateTo is the effective rate between two amounts: the number of units of
+// other's currency paid per unit of m's currency. It is the rate for which
+// Convert turns m into other.
+func (m Money) RateTo(other Money) (*big.Rat, error) {
+	if m.Sign() <= 0 || other.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amounts must be positive", ErrInvalidAmount)
+	}
+
+	r := new(big.Rat).SetFrac(big.NewInt(other.amount), big.NewInt(m.amount))
+	r.Mul(r, new(big.Rat).SetInt(pow10(m.currency.Exponent)))
+	r.Quo(r, new(big.Rat).SetInt(pow10(other.currency.Exponent)))
+	return r, nil
+}
+
+// Allocate splits the amount proportionally to ratios without losing a
+// minor unit. Leftover units go one by one to the first non-zero ratios, so
+// the parts always add up to the original amount.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, fmt.Errorf("%w: at least one ratio is required", ErrInvalidRatios)
+	}
+
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("%w: ratios must not be negative", ErrInvalidRatios)
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("%w: ratios must not all be zero", ErrInvalidRatios)
+	}
+
+	amount := big.NewInt(m.amount)
+	parts := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(amount, big.NewInt(ratio))
+		share.Quo(share, total)
+		parts[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= parts[i].amount
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].amount += step
+		remainder -= step
+	}
+
+	return parts, nil
+}
+
+// Split divides the amount into n parts that differ by at most one minor unit.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: cannot split into %d parts", ErrInvalidRatios, n)
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// Decimal formats the amount as a plain decimal string, e.g. "-1234.56".
+func (m Money) Decimal() string {
+	return m.Format(".", "")
+}
+
+// Format renders the amount with custom separators, e.g. Format(",", ".")
+// gives "1.234,56" for Brazilian notation.
+func (m Money) Format(decimalSep, groupSep string) string {
+	digits := new(big.Int).Abs(big.NewInt(m.amount)).String()
+
+	exp := m.currency.Exponent
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-exp], digits[len(digits)-exp:]
+
+	if groupSep != "" {
+		var grouped strings.Builder
+		for i, d := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				grouped.WriteString(groupSep)
+			}
+			grouped.WriteRune(d)
+		}
+		intPart = grouped.String()
+	}
+
+	var b strings.Builder
+	if m.amount < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(intPart)
+	if exp > 0 {
+		b.WriteString(decimalSep)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.currency.Code, m.Decimal())
+}
+
+func (m Money) mismatch(other Money) error {
+	return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency.Code, other.currency.Code)
+}
@@ -0,0 +1,388 @@
+package money_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stra1g/saver-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, value, code string) money.Money {
+	t.Helper()
+	m, err := money.Parse(value, code)
+	require.NoError(t, err)
+	return m
+}
+
+func TestLookupCurrency(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		wantExponent int
+		wantErr      bool
+	}{
+		{name: "BRL has two decimals", code: "BRL", wantExponent: 2},
+		{name: "JPY has no decimals", code: "JPY", wantExponent: 0},
+		{name: "KWD has three decimals", code: "KWD", wantExponent: 3},
+		{name: "lower case code", code: "usd", wantExponent: 2},
+		{name: "unknown code", code: "XXX", wantErr: true},
+		{name: "empty code", code: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := money.LookupCurrency(tt.code)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, money.ErrUnknownCurrency)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExponent, c.Exponent)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		code      string
+		wantMinor int64
+		wantErr   bool
+	}{
+		{name: "two decimals", value: "1234.56", code: "BRL", wantMinor: 123456},
+		{name: "integer", value: "10", code: "USD", wantMinor: 1000},
+		{name: "one decimal", value: "0.5", code: "EUR", wantMinor: 50},
+		{name: "negative", value: "-7.01", code: "BRL", wantMinor: -701},
+		{name: "trailing zeros beyond exponent", value: "1.500", code: "BRL", wantMinor: 150},
+		{name: "zero exponent currency", value: "1500", code: "JPY", wantMinor: 1500},
+		{name: "three decimals", value: "1.234", code: "KWD", wantMinor: 1234},
+		{name: "too many decimals", value: "1.234", code: "BRL", wantErr: true},
+		{name: "not a number", value: "abc", code: "BRL", wantErr: true},
+		{name: "exponent notation", value: "1e3", code: "BRL", wantErr: true},
+		{name: "fraction notation", value: "1/3", code: "BRL", wantErr: true},
+		{name: "overflow", value: "92233720368547758.08", code: "BRL", wantErr: true},
+		{name: "unknown currency", value: "1", code: "ABC", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := money.Parse(tt.value, tt.code)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMinor, m.MinorUnits())
+		})
+	}
+}
+
+func TestParseRounded(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		mode      money.RoundingMode
+		wantMinor int64
+	}{
+		{name: "half even rounds tie to even", value: "0.125", mode: money.RoundHalfEven, wantMinor: 12},
+		{name: "half even rounds odd tie up", value: "0.135", mode: money.RoundHalfEven, wantMinor: 14},
+		{name: "half up rounds tie away from zero", value: "0.125", mode: money.RoundHalfUp, wantMinor: 13},
+		{name: "half up negative tie", value: "-0.125", mode: money.RoundHalfUp, wantMinor: -13},
+		{name: "half down rounds tie towards zero", value: "0.125", mode: money.RoundHalfDown, wantMinor: 12},
+		{name: "half down above tie", value: "0.1251", mode: money.RoundHalfDown, wantMinor: 13},
+		{name: "up", value: "0.121", mode: money.RoundUp, wantMinor: 13},
+		{name: "down", value: "0.129", mode: money.RoundDown, wantMinor: 12},
+		{name: "ceiling negative", value: "-0.129", mode: money.RoundCeiling, wantMinor: -12},
+		{name: "floor negative", value: "-0.121", mode: money.RoundFloor, wantMinor: -13},
+		{name: "exact value is untouched", value: "0.12", mode: money.RoundUp, wantMinor: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := money.ParseRounded(tt.value, "BRL", tt.mode)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMinor, m.MinorUnits())
+		})
+	}
+}
+
+func TestAddAndSubtract(t *testing.T) {
+	// Arrange
+	a := mustParse(t, "10.10", "BRL")
+	b := mustParse(t, "0.95", "BRL")
+
+	// Act
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	diff, err := b.Subtract(a)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "11.05", sum.Decimal())
+	assert.Equal(t, "-9.15", diff.Decimal())
+}
+
+func TestAdd_CurrencyMismatch(t *testing.T) {
+	_, err := mustParse(t, "1", "BRL").Add(mustParse(t, "1", "USD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+
+	_, err = mustParse(t, "1", "BRL").Cmp(mustParse(t, "1", "USD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+}
+
+func TestAdd_Overflow(t *testing.T) {
+	maxAmount, _ := money.New(math.MaxInt64, "BRL")
+	minAmount, _ := money.New(math.MinInt64, "BRL")
+	one, _ := money.New(1, "BRL")
+
+	_, err := maxAmount.Add(one)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = minAmount.Subtract(one)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = minAmount.Negate()
+	assert.ErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestSum(t *testing.T) {
+	total, err := money.Sum("BRL", mustParse(t, "1.10", "BRL"), mustParse(t, "2.20", "BRL"))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.30", total.Decimal())
+
+	empty, err := money.Sum("BRL")
+	assert.NoError(t, err)
+	assert.True(t, empty.IsZero())
+
+	_, err = money.Sum("BRL", mustParse(t, "1", "USD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+		ratios []int64
+		want   []int64
+	}{
+		{name: "even split", amount: "1.00", ratios: []int64{1, 1}, want: []int64{50, 50}},
+		{name: "remainder goes to first parts", amount: "1.00", ratios: []int64{1, 1, 1}, want: []int64{34, 33, 33}},
+		{name: "weighted", amount: "0.05", ratios: []int64{70, 30}, want: []int64{4, 1}},
+		{name: "negative amount", amount: "-1.00", ratios: []int64{1, 1, 1}, want: []int64{-34, -33, -33}},
+		{name: "zero ratio gets nothing", amount: "0.10", ratios: []int64{0, 1, 2}, want: []int64{0, 4, 6}},
+		{name: "remainder skips zero ratio", amount: "0.02", ratios: []int64{0, 1, 1, 1}, want: []int64{0, 1, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := mustParse(t, tt.amount, "BRL").Allocate(tt.ratios...)
+			require.NoError(t, err)
+
+			got := make([]int64, len(parts))
+			var total int64
+			for i, p := range parts {
+				got[i] = p.MinorUnits()
+				total += p.MinorUnits()
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, mustParse(t, tt.amount, "BRL").MinorUnits(), total)
+		})
+	}
+}
+
+func TestAllocate_InvalidRatios(t *testing.T) {
+	m := mustParse(t, "1", "BRL")
+
+	_, err := m.Allocate()
+	assert.ErrorIs(t, err, money.ErrInvalidRatios)
+
+	_, err = m.Allocate(0, 0)
+	assert.ErrorIs(t, err, money.ErrInvalidRatios)
+
+	_, err = m.Allocate(1, -1)
+	assert.ErrorIs(t, err, money.ErrInvalidRatios)
+
+	_, err = m.Split(0)
+	assert.ErrorIs(t, err, money.ErrInvalidRatios)
+}
+
+func TestSplit(t *testing.T) {
+	parts, err := mustParse(t, "100", "JPY").Split(3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(34), parts[0].MinorUnits())
+	assert.Equal(t, int64(33), parts[1].MinorUnits())
+	assert.Equal(t, int64(33), parts[2].MinorUnits())
+}
+
+func TestMultiply(t *testing.T) {
+	factor, err := money.ParseRate("0.15")
+	require.NoError(t, err)
+
+	got, err := mustParse(t, "10.05", "BRL").Multiply(factor, money.RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.51", got.Decimal())
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+		from   string
+		to     string
+		rate   string
+		want   string
+	}{
+		{name: "usd to brl", amount: "10.00", from: "USD", to: "BRL", rate: "5.4321", want: "54.32"},
+		{name: "eur to jpy", amount: "1.99", from: "EUR", to: "JPY", rate: "161.53", want: "321"},
+		{name: "jpy to eur", amount: "1000", from: "JPY", to: "EUR", rate: "0.0061908", want: "6.19"},
+		{name: "brl to kwd", amount: "100.00", from: "BRL", to: "KWD", rate: "0.05521", want: "5.521"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := money.ParseRate(tt.rate)
+			require.NoError(t, err)
+
+			got, err := mustParse(t, tt.amount, tt.from).Convert(tt.to, rate, money.RoundHalfEven)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, got.Currency().Code)
+			assert.Equal(t, tt.want, got.Decimal())
+		})
+	}
+}
+
+func TestConvert_InvalidRate(t *testing.T) {
+	_, err := mustParse(t, "1", "USD").Convert("BRL", big.NewRat(0, 1), money.RoundHalfEven)
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+
+	_, err = mustParse(t, "1", "USD").Convert("BRL", nil, money.RoundHalfEven)
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestRateTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		sent     money.Money
+		received money.Money
+		want     string
+	}{
+		{name: "usd to brl", sent: mustParse(t, "100.00", "USD"), received: mustParse(t, "543.21", "BRL"), want: "5.4321"},
+		{name: "eur to jpy", sent: mustParse(t, "10.00", "EUR"), received: mustParse(t, "1615", "JPY"), want: "161.5"},
+		{name: "same currency", sent: mustParse(t, "12.34", "BRL"), received: mustParse(t, "12.34", "BRL"), want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := tt.sent.RateTo(tt.received)
+			require.NoError(t, err)
+
+			want, err := money.ParseRate(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, 0, rate.Cmp(want), "got %s", rate.RatString())
+
+			converted, err := tt.sent.Convert(tt.received.Currency().Code, rate, money.RoundHalfEven)
+			require.NoError(t, err)
+			assert.True(t, converted.Equal(tt.received))
+		})
+	}
+}
+
+func TestRateTo_InvalidAmount(t *testing.T) {
+	zero, _ := money.Zero("USD")
+	_, err := zero.RateTo(mustParse(t, "1", "BRL"))
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     string
+		code       string
+		decimalSep string
+		groupSep   string
+		want       string
+	}{
+		{name: "plain", amount: "1234567.89", code: "USD", decimalSep: ".", groupSep: "", want: "1234567.89"},
+		{name: "brazilian", amount: "1234567.89", code: "BRL", decimalSep: ",", groupSep: ".", want: "1.234.567,89"},
+		{name: "small negative", amount: "-0.05", code: "BRL", decimalSep: ".", groupSep: ",", want: "-0.05"},
+		{name: "no minor units", amount: "1000", code: "JPY", decimalSep: ".", groupSep: ",", want: "1,000"},
+		{name: "three decimals", amount: "0.001", code: "KWD", decimalSep: ".", groupSep: "", want: "0.001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mustParse(t, tt.amount, tt.code)
+			assert.Equal(t, tt.want, m.Format(tt.decimalSep, tt.groupSep))
+		})
+	}
+
+	assert.Equal(t, "BRL 12.30", mustParse(t, "12.3", "BRL").String())
+}
+
+func TestJSON(t *testing.T) {
+	// Arrange
+	m := mustParse(t, "-12.30", "BRL")
+
+	// Act
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	var decoded money.Money
+	err = json.Unmarshal(data, &decoded)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"-12.30","currency":"BRL"}`, string(data))
+	assert.True(t, m.Equal(decoded))
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantMinor int64
+		wantErr   bool
+	}{
+		{name: "string amount", input: `{"amount":"19.99","currency":"USD"}`, wantMinor: 1999},
+		{name: "number amount read exactly", input: `{"amount":0.1,"currency":"USD"}`, wantMinor: 10},
+		{name: "missing amount", input: `{"currency":"USD"}`, wantErr: true},
+		{name: "missing currency", input: `{"amount":"1"}`, wantErr: true},
+		{name: "too precise", input: `{"amount":"0.001","currency":"USD"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m money.Money
+			err := json.Unmarshal([]byte(tt.input), &m)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMinor, m.MinorUnits())
+		})
+	}
+}
+
+func TestDatabaseValues(t *testing.T) {
+	m := mustParse(t, "12.34", "BRL")
+
+	amount, err := m.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1234), amount)
+
+	code, err := m.Currency().Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "BRL", code)
+
+	var scanned money.Currency
+	assert.NoError(t, scanned.Scan("EUR"))
+	assert.Equal(t, 2, scanned.Exponent)
+	assert.Error(t, scanned.Scan("???"))
+	assert.Error(t, scanned.Scan(42))
+}
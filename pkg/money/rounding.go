@@ -0,0 +1,73 @@
+package money
+
+import "math/big"
+
+// RoundingMode decides what happens to a fraction of a minor unit.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest minor unit, ties to the even one.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest minor unit, ties away from zero.
+	RoundHalfUp
+	// RoundHalfDown rounds to the nearest minor unit, ties towards zero.
+	RoundHalfDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundDown rounds towards zero (truncation).
+	RoundDown
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+)
+
+func roundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	num, den := r.Num(), r.Denom()
+	rem := new(big.Int)
+	quo, rem := new(big.Int).QuoRem(num, den, rem)
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	sign := int64(num.Sign())
+	away := func() *big.Int { return quo.Add(quo, big.NewInt(sign)) }
+
+	switch mode {
+	case RoundUp:
+		return away()
+	case RoundDown:
+		return quo
+	case RoundCeiling:
+		if sign > 0 {
+			return away()
+		}
+		return quo
+	case RoundFloor:
+		if sign < 0 {
+			return away()
+		}
+		return quo
+	}
+
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	switch twice.Cmp(den) {
+	case 1:
+		return away()
+	case -1:
+		return quo
+	}
+
+	switch mode {
+	case RoundHalfUp:
+		return away()
+	case RoundHalfDown:
+		return quo
+	default:
+		if quo.Bit(0) == 1 {
+			return away()
+		}
+		return quo
+	}
+}
@@ -0,0 +1,246 @@
+// Package query describes list queries: sorting on whitelisted keys and
+// keyset pagination with opaque cursors. The SQL it writes is made only of
+// the whitelisted column names, fixed keywords and parameter placeholders,
+// so request values always reach the database as query parameters.
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var (
+	ErrUnknownSort   = errors.New("query: unknown sort field")
+	ErrInvalidCursor = errors.New("query: invalid cursor")
+	ErrInvalidLimit  = fmt.Errorf("query: limit must be between 1 and %d", MaxLimit)
+)
+
+// ColumnType is the SQL type of a sort column. Cursor values are checked
+// against it before they are cast back in SQL.
+type ColumnType string
+
+const (
+	Text      ColumnType = "text"
+	Integer   ColumnType = "bigint"
+	Date      ColumnType = "date"
+	Timestamp ColumnType = "timestamp"
+	UUID      ColumnType = "uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+func (t ColumnType) valid(value string) bool {
+	switch t {
+	case Text:
+		return true
+	case Integer:
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	case Date:
+		_, err := time.Parse(dateLayout, value)
+		return err == nil
+	case Timestamp:
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case UUID:
+		_, err := uuid.Parse(value)
+		return err == nil
+	}
+	return false
+}
+
+// FormatDate, FormatTimestamp and FormatInteger write cursor values of
+// the Date, Timestamp and Integer column types.
+func FormatDate(value time.Time) string {
+	return value.Format(dateLayout)
+}
+
+func FormatTimestamp(value time.Time) string {
+	return value.Format(time.RFC3339Nano)
+}
+
+func FormatInteger(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+// SortKey is a field results can be sorted on. Columns are NOT NULL
+// columns that order the results and end with a unique one, so every row
+// has a position of its own; Types are their SQL types.
+type SortKey struct {
+	Columns []string
+	Types   []ColumnType
+}
+
+// Sorts whitelists the sort keys of a list by field name.
+type Sorts map[string]SortKey
+
+type Direction string
+
+const (
+	Ascending  Direction = "ASC"
+	Descending Direction = "DESC"
+)
+
+// Sort is a field and a direction, written "field" for ascending and
+// "-field" for descending.
+type Sort struct {
+	Field     string
+	Direction Direction
+}
+
+func (s Sort) String() string {
+	if s.Direction == Descending {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// ParseSort reads a sort written "field" or "-field". An empty value gives
+// fallback.
+func (s Sorts) ParseSort(value string, fallback Sort) (Sort, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	sort := Sort{Field: value, Direction: Ascending}
+	if field, found := strings.CutPrefix(value, "-"); found {
+		sort = Sort{Field: field, Direction: Descending}
+	}
+
+	if _, ok := s[sort.Field]; !ok {
+		return Sort{}, ErrUnknownSort
+	}
+	return sort, nil
+}
+
+// Cursor is the position after the last row of a page: the sort it was
+// made for and the sort key values of that row.
+type Cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// Encode makes the cursor opaque to clients.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// ParseLimit reads a page size. An empty value gives DefaultLimit.
+func ParseLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxLimit {
+		return 0, ErrInvalidLimit
+	}
+	return limit, nil
+}
+
+// Page asks for up to Limit rows in Sort order, starting after the After
+// cursor when it is set.
+type Page struct {
+	Sort  Sort
+	After *Cursor
+	Limit int
+}
+
+// NewPage checks that the sort is whitelisted and that the cursor, if any,
+// was made for the same sort and holds valid values.
+func (s Sorts) NewPage(sort Sort, after *Cursor, limit int) (Page, error) {
+	key, ok := s[sort.Field]
+	if !ok || (sort.Direction != Ascending && sort.Direction != Descending) {
+		return Page{}, ErrUnknownSort
+	}
+
+	if limit < 1 || limit > MaxLimit {
+		return Page{}, ErrInvalidLimit
+	}
+
+	if after != nil {
+		if after.Sort != sort.String() || len(after.Values) != len(key.Columns) {
+			return Page{}, ErrInvalidCursor
+		}
+		for i, value := range after.Values {
+			if !key.Types[i].valid(value) {
+				return Page{}, ErrInvalidCursor
+			}
+		}
+	}
+
+	return Page{Sort: sort, After: after, Limit: limit}, nil
+}
+
+// CursorValues are the values of the After cursor, to be passed as the
+// text[] parameter of Sorts.After. They are empty on the first page.
+func (p Page) CursorValues() []string {
+	if p.After == nil {
+		return []string{}
+	}
+	return p.After.Values
+}
+
+// Next returns the cursor after the row with the given sort key values.
+func (p Page) Next(values ...string) *Cursor {
+	return &Cursor{Sort: p.Sort.String(), Values: values}
+}
+
+// OrderBy returns the ORDER BY list of a page, as in "date DESC, id DESC".
+func (s Sorts) OrderBy(page Page) string {
+	key := s[page.Sort.Field]
+
+	columns := make([]string, 0, len(key.Columns))
+	for _, column := range key.Columns {
+		columns = append(columns, column+" "+string(page.Sort.Direction))
+	}
+	return strings.Join(columns, ", ")
+}
+
+// After returns the condition keeping the rows after the page cursor, whose
+// values are the text[] query parameter number param. The condition holds
+// for every row when the parameter is empty.
+func (s Sorts) After(page Page, param int) string {
+	key := s[page.Sort.Field]
+
+	operator := ">"
+	if page.Sort.Direction == Descending {
+		operator = "<"
+	}
+
+	values := make([]string, 0, len(key.Columns))
+	for i, columnType := range key.Types {
+		values = append(values, fmt.Sprintf("($%d::text[])[%d]::%s", param, i+1, columnType))
+	}
+
+	return fmt.Sprintf(
+		"(cardinality($%d::text[]) = 0 OR (%s) %s (%s))",
+		param, strings.Join(key.Columns, ", "), operator, strings.Join(values, ", "),
+	)
+}
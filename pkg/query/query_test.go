@@ -0,0 +1,143 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stra1g/saver-api/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSorts = query.Sorts{
+	"date": {
+		Columns: []string{"date", "created_at", "id"},
+		Types:   []query.ColumnType{query.Date, query.Timestamp, query.UUID},
+	},
+	"amount": {
+		Columns: []string{"amount", "id"},
+		Types:   []query.ColumnType{query.Integer, query.UUID},
+	},
+}
+
+var newestFirst = query.Sort{Field: "date", Direction: query.Descending}
+
+const testID = "0b5d1c7e-3f7a-4a53-9a4e-7f7f2b1f0e11"
+
+func TestSorts_ParseSort(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    query.Sort
+		wantErr bool
+	}{
+		{value: "", want: newestFirst},
+		{value: "amount", want: query.Sort{Field: "amount", Direction: query.Ascending}},
+		{value: "-amount", want: query.Sort{Field: "amount", Direction: query.Descending}},
+		{value: "description", wantErr: true},
+		{value: "amount; DROP TABLE transactions", wantErr: true},
+		{value: "--amount", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			sort, err := testSorts.ParseSort(tt.value, newestFirst)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, query.ErrUnknownSort)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, sort)
+		})
+	}
+}
+
+func TestCursor_Encode(t *testing.T) {
+	cursor := query.Cursor{Sort: "-amount", Values: []string{"4290", testID}}
+
+	decoded, err := query.DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, &cursor, decoded)
+	assert.NotContains(t, cursor.Encode(), "4290", "cursors are opaque")
+
+	_, err = query.DecodeCursor("not a cursor!")
+	assert.ErrorIs(t, err, query.ErrInvalidCursor)
+	_, err = query.DecodeCursor("bm90IGpzb24")
+	assert.ErrorIs(t, err, query.ErrInvalidCursor)
+}
+
+func TestParseLimit(t *testing.T) {
+	limit, err := query.ParseLimit("")
+	assert.NoError(t, err)
+	assert.Equal(t, query.DefaultLimit, limit)
+
+	limit, err = query.ParseLimit("10")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, limit)
+
+	for _, value := range []string{"0", "-1", "201", "ten"} {
+		_, err := query.ParseLimit(value)
+		assert.ErrorIs(t, err, query.ErrInvalidLimit, value)
+	}
+}
+
+func TestSorts_NewPage(t *testing.T) {
+	amountDesc := query.Sort{Field: "amount", Direction: query.Descending}
+
+	tests := []struct {
+		name    string
+		sort    query.Sort
+		after   *query.Cursor
+		limit   int
+		wantErr error
+	}{
+		{name: "first page", sort: amountDesc, limit: 20},
+		{name: "next page", sort: amountDesc, after: &query.Cursor{Sort: "-amount", Values: []string{"4290", testID}}, limit: 20},
+		{name: "cursor of another sort", sort: amountDesc, after: &query.Cursor{Sort: "amount", Values: []string{"4290", testID}}, limit: 20, wantErr: query.ErrInvalidCursor},
+		{name: "cursor with missing values", sort: amountDesc, after: &query.Cursor{Sort: "-amount", Values: []string{"4290"}}, limit: 20, wantErr: query.ErrInvalidCursor},
+		{name: "cursor with a bad integer", sort: amountDesc, after: &query.Cursor{Sort: "-amount", Values: []string{"1 OR 1=1", testID}}, limit: 20, wantErr: query.ErrInvalidCursor},
+		{name: "cursor with a bad id", sort: amountDesc, after: &query.Cursor{Sort: "-amount", Values: []string{"4290", "id"}}, limit: 20, wantErr: query.ErrInvalidCursor},
+		{name: "cursor with a bad date", sort: newestFirst, after: &query.Cursor{Sort: "-date", Values: []string{"19/10/2026", "2026-10-19T12:00:00Z", testID}}, limit: 20, wantErr: query.ErrInvalidCursor},
+		{name: "unknown sort", sort: query.Sort{Field: "payee", Direction: query.Ascending}, limit: 20, wantErr: query.ErrUnknownSort},
+		{name: "unknown direction", sort: query.Sort{Field: "amount", Direction: "DESC; --"}, limit: 20, wantErr: query.ErrUnknownSort},
+		{name: "limit too large", sort: amountDesc, limit: query.MaxLimit + 1, wantErr: query.ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := testSorts.NewPage(tt.sort, tt.after, tt.limit)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.limit, page.Limit)
+		})
+	}
+}
+
+func TestSorts_SQL(t *testing.T) {
+	page, err := testSorts.NewPage(newestFirst, nil, 20)
+	require.NoError(t, err)
+
+	assert.Equal(t, "date DESC, created_at DESC, id DESC", testSorts.OrderBy(page))
+	assert.Equal(t,
+		"(cardinality($7::text[]) = 0 OR (date, created_at, id) < "+
+			"(($7::text[])[1]::date, ($7::text[])[2]::timestamp, ($7::text[])[3]::uuid))",
+		testSorts.After(page, 7),
+	)
+	assert.Equal(t, []string{}, page.CursorValues())
+
+	next := page.Next("2026-10-19", "2026-10-19T12:00:00Z", testID)
+	assert.Equal(t, "-date", next.Sort)
+
+	page, err = testSorts.NewPage(query.Sort{Field: "amount", Direction: query.Ascending}, &query.Cursor{Sort: "amount", Values: []string{"4290", testID}}, 20)
+	require.NoError(t, err)
+
+	assert.Equal(t, "amount ASC, id ASC", testSorts.OrderBy(page))
+	assert.Equal(t,
+		"(cardinality($2::text[]) = 0 OR (amount, id) > (($2::text[])[1]::bigint, ($2::text[])[2]::uuid))",
+		testSorts.After(page, 2),
+	)
+	assert.Equal(t, []string{"4290", testID}, page.CursorValues())
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var ErrTooLarge = errors.New("storage: file is too large")
+
+// ReadLimited reads all of r, failing with ErrTooLarge instead of reading
+// more than limit bytes.
+func ReadLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+// DetectContentType sniffs the media type from the content itself, so it
+// does not trust the file name or what the client claims.
+func DetectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// Checksum returns the hex-encoded SHA-256 of data.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Thumbnail scales a JPEG, PNG or GIF image down to fit in a maxSize square,
+// keeping its aspect ratio, and encodes it as JPEG. Smaller images keep
+// their size.
+func Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			width, height = maxSize, max(1, height*maxSize/width)
+		} else {
+			width, height = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, scale(src, width, height), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("encoding thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scale resizes src by averaging the source pixels that fall into each
+// destination pixel, which keeps receipts legible when shrunk a lot.
+// Transparent areas are flattened onto white since JPEG has no alpha.
+func scale(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					// Premultiplied, so adding the missing alpha as white flattens it
+					white := 0xffff - uint64(ca)
+					r += uint64(cr) + white
+					g += uint64(cg) + white
+					b += uint64(cb) + white
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,87 @@
+package storage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stra1g/saver-api/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestReadLimited(t *testing.T) {
+	data, err := storage.ReadLimited(strings.NewReader("12345"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", string(data))
+
+	_, err = storage.ReadLimited(strings.NewReader("123456"), 5)
+	assert.ErrorIs(t, err, storage.ErrTooLarge)
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "pdf", data: []byte("%PDF-1.7\n..."), want: "application/pdf"},
+		{name: "png", data: newPNG(t, 2, 2), want: "image/png"},
+		{name: "jpeg", data: []byte("\xFF\xD8\xFF\xE0"), want: "image/jpeg"},
+		{name: "text without charset", data: []byte("not a receipt"), want: "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, storage.DetectContentType(tt.data))
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", storage.Checksum(nil))
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Run("scales down keeping the aspect ratio", func(t *testing.T) {
+		thumbnail, err := storage.Thumbnail(newPNG(t, 400, 100), 200)
+		require.NoError(t, err)
+
+		img, err := jpeg.Decode(bytes.NewReader(thumbnail))
+		require.NoError(t, err)
+		assert.Equal(t, 200, img.Bounds().Dx())
+		assert.Equal(t, 50, img.Bounds().Dy())
+	})
+
+	t.Run("small images keep their size", func(t *testing.T) {
+		thumbnail, err := storage.Thumbnail(newPNG(t, 30, 60), 200)
+		require.NoError(t, err)
+
+		img, err := jpeg.Decode(bytes.NewReader(thumbnail))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 30, 60), img.Bounds())
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		_, err := storage.Thumbnail([]byte("%PDF-1.7"), 200)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,93 @@
+package storage_test
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocalStorage(t *testing.T) *storage.LocalStorage {
+	t.Helper()
+	s, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:8080/api/v1/files/", []byte("secret"))
+	require.NoError(t, err)
+	return s
+}
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	s := newLocalStorage(t)
+
+	require.NoError(t, s.Put("attachments/a/receipt.pdf", strings.NewReader("%PDF-1.4"), 8, "application/pdf"))
+
+	file, err := s.Get("attachments/a/receipt.pdf")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	require.NoError(t, s.Delete("attachments/a/receipt.pdf"))
+	_, err = s.Get("attachments/a/receipt.pdf")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	assert.NoError(t, s.Delete("attachments/a/receipt.pdf"), "deleting twice")
+}
+
+func TestLocalStorage_PutSizeMismatch(t *testing.T) {
+	s := newLocalStorage(t)
+
+	err := s.Put("receipt.pdf", strings.NewReader("%PDF"), 8, "application/pdf")
+
+	assert.Error(t, err)
+	_, err = s.Get("receipt.pdf")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStorage_InvalidKeys(t *testing.T) {
+	s := newLocalStorage(t)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "a/../../outside", "a//b", `a\b`} {
+		t.Run(key, func(t *testing.T) {
+			assert.ErrorIs(t, s.Put(key, strings.NewReader("x"), 1, "text/plain"), storage.ErrInvalidKey)
+			_, err := s.Get(key)
+			assert.ErrorIs(t, err, storage.ErrInvalidKey)
+		})
+	}
+}
+
+func TestLocalStorage_SignedURL(t *testing.T) {
+	s := newLocalStorage(t)
+
+	signedURL, err := s.SignedURL("attachments/a/my receipt.pdf", time.Hour)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/files/attachments/a/my%20receipt.pdf", parsed.EscapedPath())
+
+	expires, signature := parsed.Query().Get("expires"), parsed.Query().Get("signature")
+	assert.NoError(t, s.VerifySignedURL("attachments/a/my receipt.pdf", expires, signature))
+
+	t.Run("other key", func(t *testing.T) {
+		assert.ErrorIs(t, s.VerifySignedURL("attachments/b/my receipt.pdf", expires, signature), storage.ErrInvalidSignature)
+	})
+
+	t.Run("tampered expiry", func(t *testing.T) {
+		assert.ErrorIs(t, s.VerifySignedURL("attachments/a/my receipt.pdf", expires+"0", signature), storage.ErrInvalidSignature)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expiredURL, err := s.SignedURL("attachments/a/my receipt.pdf", -time.Minute)
+		require.NoError(t, err)
+		expired, err := url.Parse(expiredURL)
+		require.NoError(t, err)
+
+		err = s.VerifySignedURL("attachments/a/my receipt.pdf", expired.Query().Get("expires"), expired.Query().Get("signature"))
+		assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,296 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3DateLayout     = "20060102T150405Z"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3MaxURLLifetime = 7 * 24 * time.Hour
+)
+
+// S3Config points an S3Storage at a bucket. Endpoint is the base URL of the
+// service, such as https://s3.us-east-1.amazonaws.com or a MinIO server.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses the bucket as endpoint/bucket instead of as
+	// bucket.endpoint, which most self-hosted services need.
+	UsePathStyle bool
+}
+
+// S3Storage keeps objects in a bucket of an S3-compatible service, signing
+// requests with AWS Signature Version 4.
+type S3Storage struct {
+	client   *http.Client
+	config   S3Config
+	endpoint *url.URL
+	now      func() time.Time
+}
+
+func (s *S3Storage) Put(key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Storage) Get(key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Storage) Delete(key string) error {
+	req, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SignedURL presigns a GET of the object. S3 caps presigned URLs at seven
+// days.
+func (s *S3Storage) SignedURL(key string, expiresIn time.Duration) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	if expiresIn <= 0 || expiresIn > s3MaxURLLifetime {
+		return "", fmt.Errorf("storage: signed URLs must expire within %s", s3MaxURLLifetime)
+	}
+
+	objectURL := s.objectURL(key)
+	now := s.now().UTC()
+	query := url.Values{
+		"X-Amz-Algorithm":     {s3Algorithm},
+		"X-Amz-Credential":    {s.config.AccessKeyID + "/" + s.scope(now)},
+		"X-Amz-Date":          {now.Format(s3DateLayout)},
+		"X-Amz-Expires":       {strconv.FormatInt(int64(expiresIn/time.Second), 10)},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+
+	signature := s.signature(http.MethodGet, objectURL.EscapedPath(), query,
+		map[string]string{"host": objectURL.Host}, s3UnsignedBody, now)
+	query.Set("X-Amz-Signature", signature)
+
+	objectURL.RawQuery = canonicalQuery(query)
+	return objectURL.String(), nil
+}
+
+func (s *S3Storage) newRequest(method, key string, body io.Reader) (*http.Request, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	return http.NewRequest(method, s.objectURL(key).String(), body)
+}
+
+// do signs and sends req, turning error responses into errors.
+func (s *S3Storage) do(req *http.Request) (*http.Response, error) {
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(s3DateLayout))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedBody)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+
+	signature := s.signature(req.Method, req.URL.EscapedPath(), req.URL.Query(), headers, s3UnsignedBody, now)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.config.AccessKeyID, s.scope(now), signedHeaders(headers), signature))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return nil, fmt.Errorf("storage: %s %s responded with status %d: %s",
+		req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(message)))
+}
+
+func (s *S3Storage) objectURL(key string) *url.URL {
+	objectURL := *s.endpoint
+	if s.config.UsePathStyle {
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + s.config.Bucket + "/" + key
+	} else {
+		objectURL.Host = s.config.Bucket + "." + objectURL.Host
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + key
+	}
+	objectURL.RawPath = awsEscape(objectURL.Path, false)
+	return &objectURL
+}
+
+func (s *S3Storage) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.config.Region + "/s3/aws4_request"
+}
+
+// signature signs a request the way Signature Version 4 describes: a
+// canonical form of the request is hashed into a string to sign, which is
+// signed with a key derived from the secret, the date and the region.
+func (s *S3Storage) signature(
+	method string,
+	escapedPath string,
+	query url.Values,
+	headers map[string]string,
+	payloadHash string,
+	now time.Time,
+) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		canonicalQuery(query),
+		canonicalHeaders.String(),
+		signedHeaders(headers),
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3DateLayout),
+		s.scope(now),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func signedHeaders(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ";")
+}
+
+// canonicalQuery sorts the parameters by name and escapes them strictly, as
+// both the signature and the presigned URL need.
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(query))
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, awsEscape(name, true)+"="+awsEscape(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// awsEscape percent-encodes everything but the unreserved characters of RFC
+// 3986, which is stricter than net/url.
+func awsEscape(value string, escapeSlash bool) string {
+	var escaped strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			escaped.WriteByte(b)
+		case b == '/' && !escapeSlash:
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func NewS3Storage(client *http.Client, config S3Config) (*S3Storage, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("storage: invalid S3 endpoint %q", config.Endpoint)
+	}
+
+	if config.Bucket == "" || config.Region == "" {
+		return nil, fmt.Errorf("storage: S3 bucket and region are required")
+	}
+
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("storage: S3 credentials are required")
+	}
+
+	return &S3Storage{
+		client:   client,
+		config:   config,
+		endpoint: endpoint,
+		now:      time.Now,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package storage_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stra1g/saver-api/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 stands in for an S3-compatible service: it keeps objects in memory
+// and rejects requests that are not signed.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access-key/") ||
+		r.Header.Get("X-Amz-Date") == "" {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newS3Storage(t *testing.T, secret string) (*storage.S3Storage, *fakeS3) {
+	t.Helper()
+	fake := &fakeS3{objects: make(map[string][]byte), types: make(map[string]string)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	s, err := storage.NewS3Storage(server.Client(), storage.S3Config{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "receipts",
+		AccessKeyID:     "access-key",
+		SecretAccessKey: secret,
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+	return s, fake
+}
+
+func TestS3Storage_PutGetDelete(t *testing.T) {
+	s, fake := newS3Storage(t, "secret")
+
+	require.NoError(t, s.Put("attachments/a/receipt.pdf", strings.NewReader("%PDF-1.4"), 8, "application/pdf"))
+	assert.Equal(t, "application/pdf", fake.types["/receipts/attachments/a/receipt.pdf"])
+
+	object, err := s.Get("attachments/a/receipt.pdf")
+	require.NoError(t, err)
+	content, err := io.ReadAll(object)
+	require.NoError(t, err)
+	require.NoError(t, object.Close())
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	require.NoError(t, s.Delete("attachments/a/receipt.pdf"))
+	_, err = s.Get("attachments/a/receipt.pdf")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestS3Storage_ErrorResponse(t *testing.T) {
+	fake := &fakeS3{objects: make(map[string][]byte), types: make(map[string]string)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	s, err := storage.NewS3Storage(server.Client(), storage.S3Config{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "receipts",
+		AccessKeyID:     "other-key",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+
+	err = s.Put("receipt.pdf", strings.NewReader("%PDF"), 4, "application/pdf")
+
+	assert.ErrorContains(t, err, "AccessDenied")
+}
+
+func TestS3Storage_SignedURLLifetime(t *testing.T) {
+	s, _ := newS3Storage(t, "secret")
+
+	_, err := s.SignedURL("receipt.pdf", 8*24*time.Hour)
+	assert.Error(t, err)
+
+	signedURL, err := s.SignedURL("receipt.pdf", time.Hour)
+	require.NoError(t, err)
+	assert.Contains(t, signedURL, "/receipts/receipt.pdf?")
+	assert.Contains(t, signedURL, "X-Amz-Expires=3600")
+}
+
+func TestNewS3Storage_InvalidConfig(t *testing.T) {
+	_, err := storage.NewS3Storage(http.DefaultClient, storage.S3Config{Endpoint: "not a url", Region: "us-east-1", Bucket: "b"})
+	assert.Error(t, err)
+
+	_, err = storage.NewS3Storage(http.DefaultClient, storage.S3Config{Endpoint: "http://localhost:9000", Region: "us-east-1", Bucket: "b"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+// Package storage keeps files such as receipts outside the database, behind
+// drivers for the local filesystem and S3-compatible object stores.
+package storage
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound         = errors.New("storage: object not found")
+	ErrInvalidKey       = errors.New("storage: invalid key")
+	ErrInvalidSignature = errors.New("storage: invalid or expired signature")
+)
+
+// Storage stores objects under slash-separated keys.
+type Storage interface {
+	Put(key string, r io.Reader, size int64, contentType string) error
+	// Get returns ErrNotFound when there is no object under key.
+	Get(key string) (io.ReadCloser, error)
+	// Delete succeeds when there is no object under key.
+	Delete(key string) error
+	// SignedURL returns a URL anyone can download the object from until it
+	// expires.
+	SignedURL(key string, expiresIn time.Duration) (string, error)
+}
+
+// URLVerifier is implemented by drivers whose signed URLs are served by the
+// API itself instead of by the storage service.
+type URLVerifier interface {
+	VerifySignedURL(key, expires, signature string) error
+}
+
+// validKey rejects keys that could escape the storage root or that object
+// stores treat differently from the filesystem.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}